@@ -18,15 +18,19 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"io"
 	"io/ioutil"
+	"strings"
 	"time"
 
 	"github.com/GoogleContainerTools/skaffold/cmd/skaffold/app/cmd/commands"
 	"github.com/GoogleContainerTools/skaffold/cmd/skaffold/app/flags"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/tag"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/config"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -36,6 +40,8 @@ import (
 var (
 	quietFlag       bool
 	buildFormatFlag = flags.NewTemplateFlag("{{json .}}", flags.BuildOutput{})
+	fileOutput      string
+	dryRunFlag      bool
 )
 
 // For testing
@@ -50,9 +56,10 @@ func NewCmdBuild(out io.Writer) *cobra.Command {
 		New(out).
 		WithDescription(cmdUse, "Builds the artifacts").
 		WithFlags(func(f *pflag.FlagSet) {
-			f.StringSliceVarP(&opts.TargetImages, "build-image", "b", nil, "Choose which artifacts to build. Artifacts with image names that contain the expression will be built only. Default is to build sources for all artifacts")
 			f.BoolVarP(&quietFlag, "quiet", "q", false, "Suppress the build output and print image built on success. See --output to format output.")
 			f.VarP(buildFormatFlag, "output", "o", "Used in conjuction with --quiet flag. "+buildFormatFlag.Usage())
+			f.BoolVar(&dryRunFlag, "dry-run", false, "Don't build images, just print the fully-qualified image references that would be built")
+			f.StringVar(&fileOutput, "file-output", "", "Filename to write the built images to, with their digests, builder, build duration and git commit, in a versioned JSON format")
 			AddFlags(f, cmdUse)
 		}).
 		NoArgs(cancelWithCtrlC(context.Background(), doBuild))
@@ -91,17 +98,95 @@ func createRunnerAndBuild(ctx context.Context, buildOut io.Writer) ([]build.Arti
 	}
 	defer runner.RPCServerShutdown()
 
-	return runner.BuildAndTest(ctx, buildOut, targetArtifacts(opts, config))
+	artifacts := targetArtifacts(opts, config)
+	if dryRunFlag {
+		tags, err := runner.ImageTags(ctx, buildOut, artifacts)
+		if err != nil {
+			return nil, errors.Wrap(err, "generating tags")
+		}
+		return dryRunArtifacts(artifacts, tags), nil
+	}
+
+	bRes, err := runner.BuildAndTest(ctx, buildOut, artifacts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := runner.WaitForImages(ctx, buildOut, bRes); err != nil {
+		return nil, err
+	}
+
+	if fileOutput != "" {
+		metadata := newBuildMetadata(runner.Builder.Labels()[constants.Labels.Builder], artifacts, bRes, build.Durations())
+		if err := writeBuildMetadata(fileOutput, metadata); err != nil {
+			return nil, errors.Wrap(err, "writing build output file")
+		}
+	}
+
+	return bRes, nil
 }
 
 func targetArtifacts(opts *config.SkaffoldOptions, cfg *latest.SkaffoldConfig) []*latest.Artifact {
 	var targetArtifacts []*latest.Artifact
 
 	for _, artifact := range cfg.Build.Artifacts {
-		if opts.IsTargetImage(artifact) {
+		if opts.IsBuildImage(artifact) {
 			targetArtifacts = append(targetArtifacts, artifact)
 		}
 	}
 
 	return targetArtifacts
 }
+
+// dryRunArtifacts lists the images that would be built, in the order of the configuration.
+func dryRunArtifacts(artifacts []*latest.Artifact, tags tag.ImageTags) []build.Artifact {
+	var builds []build.Artifact
+	for _, artifact := range artifacts {
+		builds = append(builds, build.Artifact{
+			ImageName: artifact.ImageName,
+			Tag:       tags[artifact.ImageName],
+		})
+	}
+	return builds
+}
+
+// newBuildMetadata describes the built artifacts for `--file-output`.
+func newBuildMetadata(builder string, artifacts []*latest.Artifact, builds []build.Artifact, durations map[string]time.Duration) flags.BuildMetadata {
+	workspaces := map[string]string{}
+	for _, artifact := range artifacts {
+		workspaces[artifact.ImageName] = artifact.Workspace
+	}
+
+	metadata := flags.BuildMetadata{
+		Version: flags.BuildMetadataVersion,
+		Builds:  []flags.ArtifactMetadata{},
+	}
+	for _, b := range builds {
+		var digest string
+		if parts := strings.SplitN(b.Tag, "@", 2); len(parts) == 2 {
+			digest = parts[1]
+		}
+		duration, built := durations[b.ImageName]
+
+		metadata.Builds = append(metadata.Builds, flags.ArtifactMetadata{
+			ImageName:  b.ImageName,
+			Tag:        b.Tag,
+			Digest:     digest,
+			Builder:    builder,
+			DurationMs: int64(duration / time.Millisecond),
+			Cached:     !built,
+			GitCommit:  build.GitCommit(workspaces[b.ImageName]),
+		})
+	}
+
+	return metadata
+}
+
+func writeBuildMetadata(filename string, metadata flags.BuildMetadata) error {
+	content, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filename, append(content, '\n'), 0644)
+}
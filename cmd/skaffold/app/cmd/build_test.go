@@ -23,12 +23,34 @@ import (
 	"io"
 	"io/ioutil"
 	"testing"
+	"time"
 
 	"github.com/GoogleContainerTools/skaffold/cmd/skaffold/app/flags"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/tag"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
 	"github.com/GoogleContainerTools/skaffold/testutil"
 )
 
+func TestDryRunArtifacts(t *testing.T) {
+	artifacts := []*latest.Artifact{
+		{ImageName: "gcr.io/skaffold/second"},
+		{ImageName: "gcr.io/skaffold/first"},
+	}
+	tags := tag.ImageTags{
+		"gcr.io/skaffold/first":  "gcr.io/skaffold/first:v1",
+		"gcr.io/skaffold/second": "gcr.io/skaffold/second:v1",
+	}
+
+	builds := dryRunArtifacts(artifacts, tags)
+
+	testutil.CheckDeepEqual(t, []build.Artifact{
+		{ImageName: "gcr.io/skaffold/second", Tag: "gcr.io/skaffold/second:v1"},
+		{ImageName: "gcr.io/skaffold/first", Tag: "gcr.io/skaffold/first:v1"},
+	}, builds)
+}
+
 func TestQuietFlag(t *testing.T) {
 	mockCreateRunner := func(context.Context, io.Writer) ([]build.Artifact, error) {
 		return []build.Artifact{{
@@ -126,3 +148,39 @@ func TestRunBuild(t *testing.T) {
 		})
 	}
 }
+
+func TestNewBuildMetadata(t *testing.T) {
+	defer testutil.Override(t, &util.DefaultExecCommand, testutil.
+		FakeRunOut(t, "git rev-parse HEAD", "a1b2c3\n").
+		WithRunOut("git rev-parse HEAD", "d4e5f6\n"))()
+
+	artifacts := []*latest.Artifact{
+		{ImageName: "gcr.io/project/built", Workspace: "built"},
+		{ImageName: "gcr.io/project/cached", Workspace: "cached"},
+	}
+	builds := []build.Artifact{
+		{ImageName: "gcr.io/project/built", Tag: "gcr.io/project/built:v1@sha256:abac"},
+		{ImageName: "gcr.io/project/cached", Tag: "gcr.io/project/cached:v1"},
+	}
+	durations := map[string]time.Duration{"gcr.io/project/built": 1500 * time.Millisecond}
+
+	metadata := newBuildMetadata("local", artifacts, builds, durations)
+
+	testutil.CheckDeepEqual(t, flags.BuildMetadata{
+		Version: "v1",
+		Builds: []flags.ArtifactMetadata{{
+			ImageName:  "gcr.io/project/built",
+			Tag:        "gcr.io/project/built:v1@sha256:abac",
+			Digest:     "sha256:abac",
+			Builder:    "local",
+			DurationMs: 1500,
+			GitCommit:  "a1b2c3",
+		}, {
+			ImageName: "gcr.io/project/cached",
+			Tag:       "gcr.io/project/cached:v1",
+			Builder:   "local",
+			Cached:    true,
+			GitCommit: "d4e5f6",
+		}},
+	}, metadata)
+}
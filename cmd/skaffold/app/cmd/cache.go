@@ -0,0 +1,74 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/cmd/skaffold/app/cmd/commands"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/cache"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var cacheOlderThan time.Duration
+
+// NewCmdCache describes the CLI commands to manage the artifact cache.
+func NewCmdCache(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "A set of commands for managing the artifact cache.",
+	}
+
+	cmd.AddCommand(commands.
+		New(out).
+		WithDescription("list", "List the entries of the artifact cache").
+		WithFlags(addCacheFileFlag).
+		NoArgs(doCacheList))
+	cmd.AddCommand(commands.
+		New(out).
+		WithDescription("prune", "Remove the stale entries of the artifact cache, and their images").
+		WithFlags(func(f *pflag.FlagSet) {
+			f.DurationVar(&cacheOlderThan, "older-than", 30*24*time.Hour, "Remove the entries that weren't used for longer than this duration")
+			addCacheFileFlag(f)
+		}).
+		NoArgs(cancelWithCtrlC(context.Background(), doCachePrune)))
+	cmd.AddCommand(commands.
+		New(out).
+		WithDescription("clear", "Remove all the entries of the artifact cache").
+		WithFlags(addCacheFileFlag).
+		NoArgs(doCacheClear))
+	return cmd
+}
+
+func addCacheFileFlag(f *pflag.FlagSet) {
+	f.StringVar(&opts.CacheFile, "cache-file", "", "Specify the location of the cache file (default $HOME/.skaffold/cache)")
+}
+
+func doCacheList(out io.Writer) error {
+	return cache.List(out, opts.CacheFile)
+}
+
+func doCachePrune(ctx context.Context, out io.Writer) error {
+	return cache.Prune(ctx, out, opts.CacheFile, cacheOlderThan)
+}
+
+func doCacheClear(out io.Writer) error {
+	return cache.Clear(out, opts.CacheFile)
+}
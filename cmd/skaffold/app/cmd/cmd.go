@@ -20,11 +20,16 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 
+	configutil "github.com/GoogleContainerTools/skaffold/cmd/skaffold/app/cmd/config"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/config"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/errcode"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/redact"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/trace"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/update"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/version"
 	"github.com/pkg/errors"
@@ -34,11 +39,12 @@ import (
 )
 
 var (
-	opts         = &config.SkaffoldOptions{}
-	v            string
-	forceColors  bool
-	defaultColor int
-	overwrite    bool
+	opts        = &config.SkaffoldOptions{}
+	v           string
+	forceColors bool
+	colorMode   string
+	overwrite   bool
+	redacted    []string
 )
 
 func NewSkaffoldCommand(out, err io.Writer) *cobra.Command {
@@ -53,23 +59,47 @@ func NewSkaffoldCommand(out, err io.Writer) *cobra.Command {
 	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
 		opts.Command = cmd.Use
 
+		if err := setUpColors(colorMode, forceColors); err != nil {
+			return err
+		}
+
 		if err := SetUpLogs(err, v); err != nil {
 			return err
 		}
 
-		if forceColors {
-			color.ForceColors()
+		if err := redact.AddPatterns(redacted); err != nil {
+			return err
 		}
 
 		rootCmd.SilenceUsage = true
 		logrus.Infof("Skaffold %+v", version.Get())
-		color.OverwriteDefault(color.Color(defaultColor))
+		trace.Initialize(fmt.Sprintf("skaffold %s", cmd.Use))
+
+		offline, cfgErr := configutil.GetOffline(opts.Offline)
+		if cfgErr != nil {
+			logrus.Debugf("unable to determine offline mode from global config: %s", cfgErr)
+		}
+		opts.Offline = offline
 
-		if quietFlag {
+		switch {
+		case quietFlag || opts.Quiet:
 			logrus.Debugf("Update check is disabled because of quiet mode")
-		} else {
+		case opts.Offline:
+			logrus.Debugf("Update check is disabled because of offline mode")
+		default:
+			// The config is read before the check runs in the background,
+			// since reading it isn't safe for concurrent use.
+			enabled, url, err := updateCheckSettings(projectSettings(opts.ConfigurationFile))
+			if err != nil {
+				logrus.Infof("update check failed: %s", err)
+				break
+			}
+			if !update.IsUpdateCheckEnabled(enabled) {
+				logrus.Debugf("Update check not enabled, skipping.")
+				break
+			}
 			go func() {
-				if err := updateCheck(updateMsg); err != nil {
+				if err := updateCheck(updateMsg, url); err != nil {
 					logrus.Infof("update check failed: %s", err)
 				}
 			}()
@@ -86,6 +116,11 @@ func NewSkaffoldCommand(out, err io.Writer) *cobra.Command {
 		}
 	}
 
+	// Invalid flags exit with the same code as an invalid configuration.
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return errcode.WithCode(errcode.ConfigInvalid, err)
+	})
+
 	SetUpFlags()
 	rootCmd.SetOutput(out)
 	rootCmd.AddCommand(NewCmdCompletion(out))
@@ -95,14 +130,21 @@ func NewSkaffoldCommand(out, err io.Writer) *cobra.Command {
 	rootCmd.AddCommand(NewCmdDebug(out))
 	rootCmd.AddCommand(NewCmdBuild(out))
 	rootCmd.AddCommand(NewCmdDeploy(out))
+	rootCmd.AddCommand(NewCmdRender(out))
+	rootCmd.AddCommand(NewCmdVerify(out))
 	rootCmd.AddCommand(NewCmdDelete(out))
+	rootCmd.AddCommand(NewCmdExec(out))
+	rootCmd.AddCommand(NewCmdPrune(out))
+	rootCmd.AddCommand(NewCmdCache(out))
 	rootCmd.AddCommand(NewCmdFix(out))
 	rootCmd.AddCommand(NewCmdConfig(out))
 	rootCmd.AddCommand(NewCmdInit(out))
 	rootCmd.AddCommand(NewCmdDiagnose(out))
+	rootCmd.AddCommand(NewCmdInspect(out))
 
 	rootCmd.PersistentFlags().StringVarP(&v, "verbosity", "v", constants.DefaultLogLevel.String(), "Log level (debug, info, warn, error, fatal, panic)")
-	rootCmd.PersistentFlags().IntVar(&defaultColor, "color", int(color.Default), "Specify the default output color in ANSI escape codes")
+	rootCmd.PersistentFlags().StringVar(&colorMode, "color", color.Auto, "When to use colors: auto, to use them when printing to a terminal, always or never. An ANSI color code sets the color of Skaffold's own output, in auto mode")
+	rootCmd.PersistentFlags().StringSliceVar(&redacted, "redact", nil, "Regular expressions whose matches are masked in the output, like tokens passed as build args. Secrets read with the secret template function are always masked")
 	rootCmd.PersistentFlags().BoolVar(&forceColors, "force-colors", false, "Always print color codes (hidden)")
 	rootCmd.PersistentFlags().MarkHidden("force-colors")
 
@@ -111,12 +153,8 @@ func NewSkaffoldCommand(out, err io.Writer) *cobra.Command {
 	return rootCmd
 }
 
-func updateCheck(ch chan string) error {
-	if !update.IsUpdateCheckEnabled() {
-		logrus.Debugf("Update check not enabled, skipping.")
-		return nil
-	}
-	latest, current, err := update.GetLatestAndCurrentVersion()
+func updateCheck(ch chan string, url string) error {
+	latest, current, err := update.GetLatestAndCurrentVersion(url)
 	if err != nil {
 		return errors.Wrap(err, "get latest and current Skaffold version")
 	}
@@ -156,8 +194,26 @@ func FlagToEnvVarName(f *pflag.Flag) string {
 	return fmt.Sprintf("SKAFFOLD_%s", strings.Replace(strings.ToUpper(f.Name), "-", "_", -1))
 }
 
+// setUpColors selects when colors are used. For backward compatibility,
+// a number is still accepted, as the default output color.
+func setUpColors(mode string, force bool) error {
+	if code, err := strconv.Atoi(mode); err == nil {
+		color.OverwriteDefault(color.Color(code))
+		mode = color.Auto
+	}
+	if force {
+		mode = color.Always
+	}
+
+	return color.SetMode(mode)
+}
+
 func SetUpLogs(out io.Writer, level string) error {
 	logrus.SetOutput(out)
+	logrus.SetFormatter(&logrus.TextFormatter{
+		ForceColors:   color.IsTerminal(out),
+		DisableColors: !color.IsTerminal(out),
+	})
 	lvl, err := logrus.ParseLevel(v)
 	if err != nil {
 		return errors.Wrap(err, "parsing log level")
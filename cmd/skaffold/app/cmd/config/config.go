@@ -26,8 +26,24 @@ type Config struct {
 // ContextConfig is the context-specific config information provided in
 // the global Skaffold config.
 type ContextConfig struct {
-	Kubecontext        string   `yaml:"kube-context,omitempty"`
-	DefaultRepo        string   `yaml:"default-repo,omitempty"`
-	LocalCluster       *bool    `yaml:"local-cluster,omitempty"`
-	InsecureRegistries []string `yaml:"insecure-registries,omitempty"`
+	Kubecontext          string   `yaml:"kube-context,omitempty"`
+	DefaultRepo          string   `yaml:"default-repo,omitempty"`
+	DefaultRepoStrategy  string   `yaml:"default-repo-strategy,omitempty"`
+	DefaultRepoOverrides []string `yaml:"default-repo-overrides,omitempty"`
+	LocalCluster         *bool    `yaml:"local-cluster,omitempty"`
+	InsecureRegistries   []string `yaml:"insecure-registries,omitempty"`
+	Offline              *bool    `yaml:"offline,omitempty"`
+	MinikubeDockerEnv    *bool    `yaml:"minikube-docker-env,omitempty"`
+	LocalClusterContexts []string `yaml:"local-cluster-contexts,omitempty"`
+	LocalClusterCIDRs    []string `yaml:"local-cluster-cidrs,omitempty"`
+	CacheFile            string   `yaml:"cache-file,omitempty"`
+	CacheMaxEntries      *int     `yaml:"cache-max-entries,omitempty"`
+	UpdateCheck          *bool    `yaml:"update-check,omitempty"`
+	UpdateCheckURL       string   `yaml:"update-check-url,omitempty"`
+	BuilderPlugins       []string `yaml:"builder-plugins,omitempty"`
+	EventLogs            *bool    `yaml:"event-logs,omitempty"`
+	EventLogsMaxFiles    *int     `yaml:"event-logs-max-files,omitempty"`
+	EventLogsMaxAge      string   `yaml:"event-logs-max-age,omitempty"`
+	CollectMetrics       *bool    `yaml:"collect-metrics,omitempty"`
+	MetricsExport        string   `yaml:"metrics-export,omitempty"`
 }
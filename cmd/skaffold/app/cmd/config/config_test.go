@@ -18,6 +18,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
 	"github.com/GoogleContainerTools/skaffold/testutil"
@@ -136,6 +137,72 @@ func TestSetAndUnsetConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "set global cache max entries",
+			key:    "cache-max-entries",
+			value:  "100",
+			global: true,
+			expectedSetCfg: &Config{
+				Global: &ContextConfig{
+					CacheMaxEntries: util.IntPtr(100),
+				},
+				ContextConfigs: []*ContextConfig{},
+			},
+			expectedUnsetCfg: &Config{
+				Global:         &ContextConfig{},
+				ContextConfigs: []*ContextConfig{},
+			},
+		},
+		{
+			name:         "set invalid cache max entries",
+			key:          "cache-max-entries",
+			shouldErrSet: true,
+			value:        "many",
+			expectedSetCfg: &Config{
+				ContextConfigs: []*ContextConfig{
+					{
+						Kubecontext: dummyContext,
+					},
+				},
+			},
+		},
+		{
+			name:        "disable update check",
+			key:         "update-check",
+			value:       "false",
+			kubecontext: "this_is_a_context",
+			expectedSetCfg: &Config{
+				ContextConfigs: []*ContextConfig{
+					{
+						Kubecontext: "this_is_a_context",
+						UpdateCheck: util.BoolPtr(false),
+					},
+				},
+			},
+			expectedUnsetCfg: &Config{
+				ContextConfigs: []*ContextConfig{
+					{
+						Kubecontext: "this_is_a_context",
+					},
+				},
+			},
+		},
+		{
+			name:   "set global update check url",
+			key:    "update-check-url",
+			value:  "https://mirror.example.com/skaffold/VERSION",
+			global: true,
+			expectedSetCfg: &Config{
+				Global: &ContextConfig{
+					UpdateCheckURL: "https://mirror.example.com/skaffold/VERSION",
+				},
+				ContextConfigs: []*ContextConfig{},
+			},
+			expectedUnsetCfg: &Config{
+				Global:         &ContextConfig{},
+				ContextConfigs: []*ContextConfig{},
+			},
+		},
 		{
 			name:         "set fake value",
 			key:          "not_a_real_value",
@@ -244,3 +311,148 @@ func TestSetAndUnsetConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestGetBuilderPlugins(t *testing.T) {
+	tests := []struct {
+		description string
+		cfg         *Config
+		expected    map[string]string
+		shouldErr   bool
+	}{
+		{
+			description: "no plugins",
+			cfg:         emptyConfig,
+			expected:    map[string]string{},
+		},
+		{
+			description: "context plugins take precedence",
+			cfg: &Config{
+				Global: &ContextConfig{
+					BuilderPlugins: []string{"ko=/usr/local/bin/ko-plugin", "nix=/opt/nix-plugin"},
+				},
+				ContextConfigs: []*ContextConfig{
+					{
+						Kubecontext:    "test-context",
+						BuilderPlugins: []string{"nix=/home/dev/nix-plugin"},
+					},
+				},
+			},
+			expected: map[string]string{"ko": "/usr/local/bin/ko-plugin", "nix": "/home/dev/nix-plugin"},
+		},
+		{
+			description: "invalid plugin",
+			cfg: &Config{
+				Global: &ContextConfig{BuilderPlugins: []string{"ko"}},
+			},
+			shouldErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			c, _ := yaml.Marshal(*test.cfg)
+			cfg, teardown := testutil.TempFile(t, "config", c)
+			defer teardown()
+
+			defer testutil.Override(t, &configFile, cfg)()
+			defer testutil.Override(t, &kubecontext, "test-context")()
+
+			plugins, err := GetBuilderPlugins()
+
+			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.expected, plugins)
+		})
+	}
+}
+
+func TestGetEventLogSettings(t *testing.T) {
+	tests := []struct {
+		description string
+		cfg         *Config
+		expected    EventLogSettings
+		shouldErr   bool
+	}{
+		{
+			description: "defaults",
+			cfg:         emptyConfig,
+			expected:    EventLogSettings{Enabled: true, MaxFiles: 50, MaxAge: 7 * 24 * time.Hour},
+		},
+		{
+			description: "global settings",
+			cfg: &Config{
+				Global: &ContextConfig{
+					EventLogs:         util.BoolPtr(false),
+					EventLogsMaxFiles: util.IntPtr(10),
+					EventLogsMaxAge:   "24h",
+				},
+			},
+			expected: EventLogSettings{Enabled: false, MaxFiles: 10, MaxAge: 24 * time.Hour},
+		},
+		{
+			description: "invalid max age",
+			cfg: &Config{
+				Global: &ContextConfig{EventLogsMaxAge: "a week"},
+			},
+			shouldErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			c, _ := yaml.Marshal(*test.cfg)
+			cfg, teardown := testutil.TempFile(t, "config", c)
+			defer teardown()
+
+			defer testutil.Override(t, &configFile, cfg)()
+
+			settings, err := GetEventLogSettings()
+
+			testutil.CheckError(t, test.shouldErr, err)
+			if !test.shouldErr {
+				testutil.CheckDeepEqual(t, test.expected, settings)
+			}
+		})
+	}
+}
+
+func TestGetMetricsSettings(t *testing.T) {
+	tests := []struct {
+		description     string
+		cfg             *Config
+		expectedEnabled bool
+		expectedExport  string
+	}{
+		{
+			description: "not opted in",
+			cfg:         emptyConfig,
+		},
+		{
+			description: "not opted in for a context",
+			cfg: &Config{
+				ContextConfigs: []*ContextConfig{
+					{Kubecontext: "test-context", CollectMetrics: util.BoolPtr(true)},
+				},
+			},
+		},
+		{
+			description: "opted in",
+			cfg: &Config{
+				Global: &ContextConfig{CollectMetrics: util.BoolPtr(true), MetricsExport: "https://metrics.example.com"},
+			},
+			expectedEnabled: true,
+			expectedExport:  "https://metrics.example.com",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			c, _ := yaml.Marshal(*test.cfg)
+			cfg, teardown := testutil.TempFile(t, "config", c)
+			defer teardown()
+
+			defer testutil.Override(t, &configFile, cfg)()
+
+			enabled, export, err := GetMetricsSettings()
+
+			testutil.CheckError(t, false, err)
+			testutil.CheckDeepEqual(t, test.expectedEnabled, enabled)
+			testutil.CheckDeepEqual(t, test.expectedExport, export)
+		})
+	}
+}
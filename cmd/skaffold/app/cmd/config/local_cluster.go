@@ -0,0 +1,110 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+
+	kubectx "github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes/context"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// For testing
+	currentKubeConfig = kubectx.CurrentConfig
+	lookupIP          = net.LookupIP
+)
+
+// isLocalCluster guesses if a kube context points to a local cluster, from its name,
+// or from the address of its API server, using the `local-cluster-contexts` patterns
+// and `local-cluster-cidrs` networks of the global config.
+func isLocalCluster(kubeContext string, globalCfg *ContextConfig) bool {
+	if isDefaultLocal(kubeContext) {
+		return true
+	}
+	if globalCfg == nil {
+		return false
+	}
+	if matchesContextPatterns(kubeContext, globalCfg.LocalClusterContexts) {
+		return true
+	}
+	return len(globalCfg.LocalClusterCIDRs) > 0 && apiServerInCIDRs(kubeContext, globalCfg.LocalClusterCIDRs)
+}
+
+// matchesContextPatterns matches a context name against patterns where `*` matches any sequence of characters.
+func matchesContextPatterns(kubeContext string, patterns []string) bool {
+	for _, pattern := range patterns {
+		expr := "^" + strings.Replace(regexp.QuoteMeta(pattern), `\*`, ".*", -1) + "$"
+		if regexp.MustCompile(expr).MatchString(kubeContext) {
+			return true
+		}
+	}
+	return false
+}
+
+func apiServerInCIDRs(kubeContext string, cidrs []string) bool {
+	ips, err := apiServerIPs(kubeContext)
+	if err != nil {
+		logrus.Debugf("Unable to find the API server address of %s: %s", kubeContext, err)
+		return false
+	}
+
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			logrus.Warnf("Ignoring invalid local-cluster-cidrs entry %q: %s", cidr, err)
+			continue
+		}
+		for _, ip := range ips {
+			if network.Contains(ip) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// apiServerIPs returns the addresses of the API server of a kube context, resolving its host name if needed.
+func apiServerIPs(kubeContext string) ([]net.IP, error) {
+	cfg, err := currentKubeConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	context, found := cfg.Contexts[kubeContext]
+	if !found {
+		return nil, fmt.Errorf("context %s not found in kubeconfig", kubeContext)
+	}
+	cluster, found := cfg.Clusters[context.Cluster]
+	if !found {
+		return nil, fmt.Errorf("cluster %s not found in kubeconfig", context.Cluster)
+	}
+
+	server, err := url.Parse(cluster.Server)
+	if err != nil {
+		return nil, err
+	}
+	host := server.Hostname()
+	if ip := net.ParseIP(host); ip != nil {
+		return []net.IP{ip}, nil
+	}
+	return lookupIP(host)
+}
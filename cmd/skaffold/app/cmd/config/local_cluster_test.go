@@ -0,0 +1,106 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestIsLocalCluster(t *testing.T) {
+	kubeConfig := clientcmdapi.Config{
+		Contexts: map[string]*clientcmdapi.Context{
+			"onprem-ip":   {Cluster: "ip"},
+			"onprem-host": {Cluster: "host"},
+			"cloud":       {Cluster: "cloud"},
+		},
+		Clusters: map[string]*clientcmdapi.Cluster{
+			"ip":    {Server: "https://10.1.2.3:6443"},
+			"host":  {Server: "https://k8s.dev.corp"},
+			"cloud": {Server: "https://35.1.2.3"},
+		},
+	}
+
+	tests := []struct {
+		description string
+		kubeContext string
+		globalCfg   *ContextConfig
+		expected    bool
+	}{
+		{
+			description: "default local context",
+			kubeContext: "docker-desktop",
+			expected:    true,
+		},
+		{
+			description: "no global config",
+			kubeContext: "onprem-ip",
+		},
+		{
+			description: "matching context pattern",
+			kubeContext: "dev-team/cluster-1",
+			globalCfg:   &ContextConfig{LocalClusterContexts: []string{"prod-*", "dev-*"}},
+			expected:    true,
+		},
+		{
+			description: "no matching context pattern",
+			kubeContext: "prod",
+			globalCfg:   &ContextConfig{LocalClusterContexts: []string{"prod-*"}},
+		},
+		{
+			description: "api server ip in cidr",
+			kubeContext: "onprem-ip",
+			globalCfg:   &ContextConfig{LocalClusterCIDRs: []string{"10.0.0.0/8"}},
+			expected:    true,
+		},
+		{
+			description: "api server host resolved in cidr",
+			kubeContext: "onprem-host",
+			globalCfg:   &ContextConfig{LocalClusterCIDRs: []string{"invalid", "192.168.0.0/16"}},
+			expected:    true,
+		},
+		{
+			description: "api server outside of cidr",
+			kubeContext: "cloud",
+			globalCfg:   &ContextConfig{LocalClusterCIDRs: []string{"10.0.0.0/8"}},
+		},
+		{
+			description: "unknown context",
+			kubeContext: "unknown",
+			globalCfg:   &ContextConfig{LocalClusterCIDRs: []string{"10.0.0.0/8"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			reset := testutil.Override(t, &currentKubeConfig, func() (clientcmdapi.Config, error) { return kubeConfig, nil })
+			defer reset()
+			resetLookup := testutil.Override(t, &lookupIP, func(host string) ([]net.IP, error) {
+				if host == "k8s.dev.corp" {
+					return []net.IP{net.ParseIP("192.168.1.10")}, nil
+				}
+				return nil, errors.New("no such host")
+			})
+			defer resetLookup()
+
+			testutil.CheckDeepEqual(t, test.expected, isLocalCluster(test.kubeContext, test.globalCfg))
+		})
+	}
+}
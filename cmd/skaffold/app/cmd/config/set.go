@@ -105,6 +105,15 @@ func parseAsType(value string, field reflect.Value) (reflect.Value, error) {
 			return reflect.Value{}, err
 		}
 		return reflect.ValueOf(&valBase), nil
+	case "*int":
+		if value == "" {
+			return reflect.Zero(fieldType), nil
+		}
+		valBase, err := strconv.Atoi(value)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(&valBase), nil
 	default:
 		return reflect.Value{}, fmt.Errorf("unsupported type: %s", fieldType)
 	}
@@ -17,11 +17,15 @@ limitations under the License.
 package config
 
 import (
+	"fmt"
 	"io/ioutil"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes/context"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/localcluster"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
 
 	homedir "github.com/mitchellh/go-homedir"
@@ -60,6 +64,14 @@ func resolveConfigFile() error {
 	return util.VerifyOrCreateFile(configFile)
 }
 
+// GetConfigFile returns the path to the global Skaffold config.
+func GetConfigFile() (string, error) {
+	if err := resolveConfigFile(); err != nil {
+		return "", errors.Wrap(err, "resolving config file location")
+	}
+	return configFile, nil
+}
+
 // ReadConfigForFile reads the specified file and returns the contents
 // parsed into a Config struct.
 func ReadConfigForFile(filename string) (*Config, error) {
@@ -173,26 +185,81 @@ func GetDefaultRepo(cliValue string) (string, error) {
 	return defaultRepo, nil
 }
 
+// GetDefaultRepoSubstitution returns how the default repo should be substituted
+// into image names. CLI values take precedence over the global config.
+func GetDefaultRepoSubstitution(cliRepo, cliStrategy string, cliOverrides []string) (util.DefaultRepoSubstitution, error) {
+	none := util.DefaultRepoSubstitution{}
+
+	defaultRepo, err := GetDefaultRepo(cliRepo)
+	if err != nil {
+		return none, err
+	}
+
+	cfg, err := GetConfigForKubectx()
+	if err != nil {
+		return none, errors.Wrap(err, "retrieving global config")
+	}
+	globalCfg, err := GetGlobalConfig()
+	if err != nil {
+		return none, errors.Wrap(err, "retrieving global config")
+	}
+
+	strategy := cliStrategy
+	overrides := cliOverrides
+	for _, c := range []*ContextConfig{cfg, globalCfg} {
+		if c == nil {
+			continue
+		}
+		if strategy == "" {
+			strategy = c.DefaultRepoStrategy
+		}
+		if len(overrides) == 0 {
+			overrides = c.DefaultRepoOverrides
+		}
+	}
+
+	if !util.IsValidDefaultRepoStrategy(strategy) {
+		return none, fmt.Errorf("unknown default-repo strategy %q, should be one of %s", strategy, strings.Join(util.DefaultRepoStrategies, ", "))
+	}
+
+	overridesByImage := map[string]string{}
+	for _, o := range overrides {
+		kv := strings.SplitN(o, "=", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return none, fmt.Errorf("invalid default-repo override %q, should be IMAGE=NEW_IMAGE", o)
+		}
+		overridesByImage[kv[0]] = kv[1]
+	}
+
+	return util.DefaultRepoSubstitution{
+		Repo:      defaultRepo,
+		Strategy:  strategy,
+		Overrides: overridesByImage,
+	}, nil
+}
+
+// GetLocalCluster returns whether the current kube context points to a local cluster,
+// to which images don't need to be pushed. A `local-cluster` value set for the context
+// takes precedence over the global value, which takes precedence over the guess made
+// from the context name and API server address.
 func GetLocalCluster() (bool, error) {
 	cfg, err := GetConfigForKubectx()
-	localCluster := isDefaultLocal(kubecontext)
 	if err != nil {
-		return localCluster, errors.Wrap(err, "retrieving global config")
+		return isDefaultLocal(kubecontext), errors.Wrap(err, "retrieving global config")
+	}
+	globalCfg, err := GetGlobalConfig()
+	if err != nil {
+		return isDefaultLocal(kubecontext), errors.Wrap(err, "retrieving global config")
 	}
 
+	localCluster := isLocalCluster(kubecontext, globalCfg)
 	if cfg != nil {
 		if cfg.LocalCluster != nil {
 			localCluster = *cfg.LocalCluster
 		}
-	} else {
+	} else if globalCfg != nil && globalCfg.LocalCluster != nil {
 		// if no value is set for this cluster, fall back to the global setting
-		globalCfg, err := GetGlobalConfig()
-		if err != nil {
-			return localCluster, errors.Wrap(err, "retrieving global config")
-		}
-		if globalCfg != nil && globalCfg.LocalCluster != nil {
-			localCluster = *globalCfg.LocalCluster
-		}
+		localCluster = *globalCfg.LocalCluster
 	}
 
 	return localCluster, nil
@@ -223,8 +290,232 @@ func GetInsecureRegistries() ([]string, error) {
 	return registries, nil
 }
 
+// GetOffline returns whether skaffold should avoid any network access
+// that isn't strictly required, such as update checks or remote digest lookups.
+func GetOffline(cliValue bool) (bool, error) {
+	// CLI flag takes precedence.
+	if cliValue {
+		return true, nil
+	}
+	cfg, err := GetConfigForKubectx()
+	if err != nil {
+		return false, errors.Wrap(err, "retrieving global config")
+	}
+	if cfg != nil && cfg.Offline != nil {
+		return *cfg.Offline, nil
+	}
+	// if no value is set for this cluster, fall back to the global setting
+	globalCfg, err := GetGlobalConfig()
+	if err != nil {
+		return false, errors.Wrap(err, "retrieving global config")
+	}
+	if globalCfg != nil && globalCfg.Offline != nil {
+		return *globalCfg.Offline, nil
+	}
+	return false, nil
+}
+
+// GetUpdateCheck returns whether skaffold should check for a newer version.
+// It can be disabled for a kube-context or globally.
+func GetUpdateCheck() (bool, error) {
+	cfg, err := GetConfigForKubectx()
+	if err != nil {
+		return true, errors.Wrap(err, "retrieving global config")
+	}
+	if cfg != nil && cfg.UpdateCheck != nil {
+		return *cfg.UpdateCheck, nil
+	}
+	// if no value is set for this cluster, fall back to the global setting
+	globalCfg, err := GetGlobalConfig()
+	if err != nil {
+		return true, errors.Wrap(err, "retrieving global config")
+	}
+	if globalCfg != nil && globalCfg.UpdateCheck != nil {
+		return *globalCfg.UpdateCheck, nil
+	}
+	return true, nil
+}
+
+// GetUpdateCheckURL returns the URL of the file that contains the latest version of skaffold,
+// so that a mirror can serve it. An empty URL means the default one.
+func GetUpdateCheckURL() (string, error) {
+	cfg, err := GetConfigForKubectx()
+	if err != nil {
+		return "", errors.Wrap(err, "retrieving global config")
+	}
+	if cfg != nil && cfg.UpdateCheckURL != "" {
+		return cfg.UpdateCheckURL, nil
+	}
+	// if no value is set for this cluster, fall back to the global setting
+	globalCfg, err := GetGlobalConfig()
+	if err != nil {
+		return "", errors.Wrap(err, "retrieving global config")
+	}
+	if globalCfg != nil {
+		return globalCfg.UpdateCheckURL, nil
+	}
+	return "", nil
+}
+
+// GetMinikubeDockerEnv returns whether images should be built by the docker daemon
+// of minikube, configured with `minikube docker-env`, rather than loaded into minikube
+// with `minikube image load`.
+func GetMinikubeDockerEnv() (bool, error) {
+	cfg, err := GetConfigForKubectx()
+	if err != nil {
+		return true, errors.Wrap(err, "retrieving global config")
+	}
+	if cfg != nil && cfg.MinikubeDockerEnv != nil {
+		return *cfg.MinikubeDockerEnv, nil
+	}
+	// if no value is set for this cluster, fall back to the global setting
+	globalCfg, err := GetGlobalConfig()
+	if err != nil {
+		return true, errors.Wrap(err, "retrieving global config")
+	}
+	if globalCfg != nil && globalCfg.MinikubeDockerEnv != nil {
+		return *globalCfg.MinikubeDockerEnv, nil
+	}
+	return true, nil
+}
+
+// GetCacheFile returns the path of the artifact cache file. The `--cache-file` flag
+// takes precedence over the config. An empty path means the default cache file.
+func GetCacheFile(cliValue string) (string, error) {
+	if cliValue != "" {
+		return cliValue, nil
+	}
+	cfg, err := GetConfigForKubectx()
+	if err != nil {
+		return "", errors.Wrap(err, "retrieving global config")
+	}
+	if cfg != nil && cfg.CacheFile != "" {
+		return cfg.CacheFile, nil
+	}
+	// if no value is set for this cluster, fall back to the global setting
+	globalCfg, err := GetGlobalConfig()
+	if err != nil {
+		return "", errors.Wrap(err, "retrieving global config")
+	}
+	if globalCfg != nil {
+		return globalCfg.CacheFile, nil
+	}
+	return "", nil
+}
+
+// GetCacheMaxEntries returns the maximum number of entries kept in the artifact cache file.
+// Zero means no limit.
+func GetCacheMaxEntries() (int, error) {
+	cfg, err := GetConfigForKubectx()
+	if err != nil {
+		return 0, errors.Wrap(err, "retrieving global config")
+	}
+	if cfg != nil && cfg.CacheMaxEntries != nil {
+		return *cfg.CacheMaxEntries, nil
+	}
+	// if no value is set for this cluster, fall back to the global setting
+	globalCfg, err := GetGlobalConfig()
+	if err != nil {
+		return 0, errors.Wrap(err, "retrieving global config")
+	}
+	if globalCfg != nil && globalCfg.CacheMaxEntries != nil {
+		return *globalCfg.CacheMaxEntries, nil
+	}
+	return 0, nil
+}
+
 func isDefaultLocal(kubeContext string) bool {
 	return kubeContext == constants.DefaultMinikubeContext ||
 		kubeContext == constants.DefaultDockerForDesktopContext ||
-		kubeContext == constants.DefaultDockerDesktopContext
+		kubeContext == constants.DefaultDockerDesktopContext ||
+		localcluster.IsLoadable(kubeContext)
+}
+
+// GetBuilderPlugins returns the commands of the builder plugins, by name.
+// Plugins are registered as NAME=COMMAND. Plugins registered for the current
+// kube context take precedence over the global ones.
+func GetBuilderPlugins() (map[string]string, error) {
+	cfg, err := GetConfigForKubectx()
+	if err != nil {
+		return nil, errors.Wrap(err, "retrieving global config")
+	}
+	globalCfg, err := GetGlobalConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "retrieving global config")
+	}
+
+	plugins := map[string]string{}
+	for _, c := range []*ContextConfig{globalCfg, cfg} {
+		if c == nil {
+			continue
+		}
+		for _, p := range c.BuilderPlugins {
+			kv := strings.SplitN(p, "=", 2)
+			if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+				return nil, fmt.Errorf("invalid builder plugin %q, should be NAME=COMMAND", p)
+			}
+			plugins[kv[0]] = kv[1]
+		}
+	}
+	return plugins, nil
+}
+
+// EventLogSettings control the event logs that are written for each session.
+type EventLogSettings struct {
+	Enabled  bool
+	MaxFiles int
+	MaxAge   time.Duration
+}
+
+const (
+	defaultEventLogsMaxFiles = 50
+	defaultEventLogsMaxAge   = 7 * 24 * time.Hour
+)
+
+// GetEventLogSettings returns whether event logs are written, and how many of them are kept.
+// These settings apply to the whole machine, so they're only read from the global section.
+func GetEventLogSettings() (EventLogSettings, error) {
+	settings := EventLogSettings{
+		Enabled:  true,
+		MaxFiles: defaultEventLogsMaxFiles,
+		MaxAge:   defaultEventLogsMaxAge,
+	}
+
+	cfg, err := GetGlobalConfig()
+	if err != nil {
+		return settings, errors.Wrap(err, "retrieving global config")
+	}
+	if cfg == nil {
+		return settings, nil
+	}
+
+	if cfg.EventLogs != nil {
+		settings.Enabled = *cfg.EventLogs
+	}
+	if cfg.EventLogsMaxFiles != nil {
+		settings.MaxFiles = *cfg.EventLogsMaxFiles
+	}
+	if cfg.EventLogsMaxAge != "" {
+		maxAge, err := time.ParseDuration(cfg.EventLogsMaxAge)
+		if err != nil {
+			return settings, fmt.Errorf("invalid event-logs-max-age %q, should be a duration like 72h", cfg.EventLogsMaxAge)
+		}
+		settings.MaxAge = maxAge
+	}
+
+	return settings, nil
+}
+
+// GetMetricsSettings returns whether metrics are collected, and where they are exported to.
+// Metrics are only collected if opted in, in the global section.
+func GetMetricsSettings() (bool, string, error) {
+	cfg, err := GetGlobalConfig()
+	if err != nil {
+		return false, "", errors.Wrap(err, "retrieving global config")
+	}
+	if cfg == nil || cfg.CollectMetrics == nil {
+		return false, "", nil
+	}
+
+	return *cfg.CollectMetrics, cfg.MetricsExport, nil
 }
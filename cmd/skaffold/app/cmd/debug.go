@@ -21,6 +21,7 @@ import (
 	"io"
 
 	"github.com/GoogleContainerTools/skaffold/cmd/skaffold/app/cmd/commands"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
 	debugging "github.com/GoogleContainerTools/skaffold/pkg/skaffold/debug"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/deploy"
 	"github.com/spf13/cobra"
@@ -34,6 +35,9 @@ func NewCmdDebug(out io.Writer) *cobra.Command {
 		New(out).
 		WithLongDescription(cmdUse, "Runs a pipeline file in debug mode", "Similar to `dev`, but configures the pipeline for debugging.").
 		WithFlags(func(f *pflag.FlagSet) {
+			f.StringVar(&opts.DelveImage, "delve-image", constants.DefaultDelveImage, "Image that installs the Delve debugger for Go applications")
+			f.StringSliceVar(&opts.DelveFlags, "delve-flags", nil, "Additional flags passed to Delve for Go applications, such as --check-go-version=false")
+			f.BoolVar(&opts.RelaxSecurity, "relax-security", false, "Add the SYS_PTRACE capability, disable seccomp and allow writing to the root filesystem in the debugged containers, so that native debuggers can attach")
 			AddFlags(f, cmdUse)
 		}).
 		NoArgs(cancelWithCtrlC(context.Background(), doDebug))
@@ -46,6 +50,9 @@ func doDebug(ctx context.Context, out io.Writer) error {
 		opts.TargetImages = []string{"none"}
 	}
 
+	debugging.DelveImage = opts.DelveImage
+	debugging.DelveFlags = opts.DelveFlags
+	debugging.RelaxSecurity = opts.RelaxSecurity
 	deploy.AddManifestTransform(debugging.ApplyDebuggingTransforms)
 
 	return doDev(ctx, out)
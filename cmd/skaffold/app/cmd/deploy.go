@@ -32,6 +32,7 @@ import (
 var (
 	buildOutputFile flags.BuildOutputFileFlag
 	preBuiltImages  flags.Images
+	imagesFromFile  = flags.NewImagesFromFileFlag()
 )
 
 // NewCmdDeploy describes the CLI command to deploy artifacts.
@@ -44,16 +45,21 @@ func NewCmdDeploy(out io.Writer) *cobra.Command {
 			f.VarP(&preBuiltImages, "images", "i", "A list of pre-built images to deploy")
 			f.VarP(&buildOutputFile, "build-artifacts", "a", `Filepath containing build output.
 E.g. build.out created by running skaffold build --quiet {{json .}} > build.out`)
+			f.Var(imagesFromFile, "images-from-file", `Filepath containing build output, e.g. created with skaffold build --file-output.
+Images are deployed by digest whenever the file records one`)
 			AddFlags(f, cmdUse)
 		}).
 		NoArgs(cancelWithCtrlC(context.Background(), doDeploy))
 }
 
 func doDeploy(ctx context.Context, out io.Writer) error {
+	impliedTail()
+
 	return withRunner(func(r *runner.SkaffoldRunner, _ *latest.SkaffoldConfig) error {
 		// If the BuildArtifacts contains an image in the preBuilt list,
 		// use image from BuildArtifacts instead
 		deployArtifacts := build.MergeWithPreviousBuilds(buildOutputFile.BuildArtifacts(), preBuiltImages.Artifacts())
+		deployArtifacts = build.MergeWithPreviousBuilds(imagesFromFile.BuildArtifacts(), deployArtifacts)
 
 		return r.Deploy(ctx, out, deployArtifacts)
 	})
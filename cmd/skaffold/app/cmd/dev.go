@@ -21,7 +21,12 @@ import (
 	"io"
 
 	"github.com/GoogleContainerTools/skaffold/cmd/skaffold/app/cmd/commands"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/deploy"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/remotedev"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/server"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -35,9 +40,17 @@ func NewCmdDev(out io.Writer) *cobra.Command {
 		New(out).
 		WithDescription(cmdUse, "Runs a pipeline file in development mode").
 		WithFlags(func(f *pflag.FlagSet) {
-			f.StringVar(&opts.Trigger, "trigger", "polling", "How are changes detected? (polling, manual or notify)")
+			f.StringVar(&opts.Trigger, "trigger", "polling", "How are changes detected? (polling, manual, notify, webhook or git)")
 			f.StringSliceVarP(&opts.TargetImages, "watch-image", "w", nil, "Choose which artifacts to watch. Artifacts with image names that contain the expression will be watched only. Default is to watch sources for all artifacts")
+			f.Var(&preBuiltImages, "images", "A list of pre-built images to deploy for the artifacts that are not selected with --build-image")
 			f.IntVarP(&opts.WatchPollInterval, "watch-poll-interval", "i", 1000, "Interval (in ms) between two checks for file changes")
+			f.IntVar(&opts.WebhookPort, "webhook-port", constants.DefaultWebhookPort, "With --trigger=webhook, port of the HTTP endpoint that triggers a check for changes")
+			f.StringVar(&opts.WebhookToken, "webhook-token", "", "With --trigger=webhook, token that the notifications must pass as a bearer token or a token query parameter")
+			f.StringVar(&opts.GitRef, "git-ref", "", "With --trigger=git, remote branch to pull new commits from, as <remote>/<branch>. Defaults to the upstream of the current branch")
+			f.IntVar(&opts.GitPollInterval, "git-poll-interval", 60, "With --trigger=git, interval (in seconds) between two fetches of the remote branch")
+			f.BoolVar(&opts.GitWebhook, "git-webhook", false, "With --trigger=git, also fetch the remote branch when a notification is received on --webhook-port")
+			f.BoolVar(&opts.RemoteDev, "remote-dev", false, "Develop the artifacts that have a remoteDev config in the cluster: their sources are synced into long-running pods instead of building images")
+			f.BoolVar(&opts.ClusterRegistry, "cluster-registry", false, "Push images to a registry that runs in the cluster, deployed if none is found, instead of an external registry")
 			AddFlags(f, cmdUse)
 		}).
 		NoArgs(cancelWithCtrlC(context.Background(), doDev))
@@ -45,6 +58,32 @@ func NewCmdDev(out io.Writer) *cobra.Command {
 
 func doDev(ctx context.Context, out io.Writer) error {
 	opts.EnableRPC = true
+	if opts.UI {
+		out = server.TeeLogs(out)
+	}
+	if opts.Open != "" {
+		opts.PortForward = true
+	}
+
+	if opts.RemoteDev {
+		deploy.AddManifestTransform(remotedev.ReplaceCommands)
+	}
+
+	if opts.ClusterRegistry {
+		registry, err := kubernetes.StartClusterRegistry(ctx, out, opts.Namespace)
+		if err != nil {
+			return errors.Wrap(err, "starting cluster registry")
+		}
+		defer registry.Stop()
+
+		opts.DefaultRepo = registry.Repo()
+		opts.InsecureRegistries = append(opts.InsecureRegistries, registry.Repo())
+	}
+
+	hooks := func() {}
+	defer func() {
+		hooks()
+	}()
 
 	cleanup := func() {}
 	if opts.Cleanup {
@@ -69,8 +108,14 @@ func doDev(ctx context.Context, out io.Writer) error {
 			if err != nil {
 				return errors.Wrap(err, "creating runner")
 			}
+			r.SetPreBuiltImages(preBuiltImages.Artifacts())
 
 			err = r.Dev(ctx, out, config.Build.Artifacts)
+			hooks = func() {
+				if err := r.RunCleanupHooks(context.Background(), out); err != nil {
+					logrus.Warnln("cleanup hooks:", err)
+				}
+			}
 			if r.HasDeployed() {
 				cleanup = func() {
 					if err := r.Cleanup(context.Background(), out); err != nil {
@@ -17,8 +17,12 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
+	"strings"
 
 	"github.com/GoogleContainerTools/skaffold/cmd/skaffold/app/cmd/commands"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
@@ -32,6 +36,11 @@ import (
 	yaml "gopkg.in/yaml.v2"
 )
 
+var (
+	diagnoseOutput string
+	diagnoseRBAC   bool
+)
+
 // NewCmdDiagnose describes the CLI command to diagnose skaffold.
 func NewCmdDiagnose(out io.Writer) *cobra.Command {
 	return commands.
@@ -40,16 +49,44 @@ func NewCmdDiagnose(out io.Writer) *cobra.Command {
 		WithFlags(func(f *pflag.FlagSet) {
 			f.StringVarP(&opts.ConfigurationFile, "filename", "f", "skaffold.yaml", "Filename or URL to the pipeline file")
 			f.StringSliceVarP(&opts.Profiles, "profile", "p", nil, "Activate profiles by name")
+			f.StringVar(&opts.Trigger, "trigger", "polling", "How are changes detected? (polling, manual, notify, webhook or git)")
+			f.IntVarP(&opts.WatchPollInterval, "watch-poll-interval", "i", 1000, "Interval (in ms) between two checks for file changes")
+			f.StringVarP(&diagnoseOutput, "output", "o", "", "Output format. Use json to attach the report of the environment to a bug report")
+			f.BoolVar(&diagnoseRBAC, "rbac", false, "Print the minimal Roles a service account needs to run Skaffold with this configuration")
+			f.BoolVar(&opts.Tail, "tail", false, "With --rbac, allow streaming logs")
+			f.BoolVar(&opts.PortForward, "port-forward", false, "With --rbac, allow port forwarding")
+			f.BoolVar(&opts.StatusCheck, "status-check", false, "With --rbac, allow waiting for deployed resources to stabilize (also enabled by deploy.statusCheck in the config)")
 		}).
 		NoArgs(doDiagnose)
 }
 
 func doDiagnose(out io.Writer) error {
 	return withRunner(func(r *runner.SkaffoldRunner, config *latest.SkaffoldConfig) error {
+		if diagnoseRBAC {
+			return r.WriteRBACRoles(context.Background(), out)
+		}
+
+		env := r.DiagnoseEnvironment(context.Background(), config.APIVersion)
+
+		switch diagnoseOutput {
+		case "":
+		case "json":
+			buf, err := json.MarshalIndent(env, "", "  ")
+			if err != nil {
+				return errors.Wrap(err, "marshalling environment")
+			}
+			fmt.Fprintln(out, string(buf))
+			return nil
+		default:
+			return fmt.Errorf("unsupported output format %q", diagnoseOutput)
+		}
+
 		fmt.Fprintln(out, "Skaffold version:", version.Get().GitCommit)
 		fmt.Fprintln(out, "Configuration version:", config.APIVersion)
 		fmt.Fprintln(out, "Number of artifacts:", len(config.Build.Artifacts))
 
+		printEnvironment(out, env)
+
 		if err := r.DiagnoseArtifacts(out); err != nil {
 			return errors.Wrap(err, "running diagnostic on artifacts")
 		}
@@ -64,3 +101,38 @@ func doDiagnose(out io.Writer) error {
 		return nil
 	})
 }
+
+func printEnvironment(out io.Writer, env *runner.Environment) {
+	color.Blue.Fprintln(out, "\nEnvironment")
+	fmt.Fprintf(out, " - Kubernetes context: %s (%s cluster)\n", env.KubeContext, env.Cluster)
+	fmt.Fprintln(out, " - Watcher:", env.Watcher)
+
+	fmt.Fprintln(out, " - Tools:")
+	for _, tool := range sortedKeys(env.Tools) {
+		fmt.Fprintf(out, "   %s: %s\n", tool, env.Tools[tool])
+	}
+
+	if len(env.Registries) > 0 {
+		fmt.Fprintln(out, " - Registries:")
+		for _, registry := range sortedKeys(env.Registries) {
+			fmt.Fprintf(out, "   %s: %s\n", registry, env.Registries[registry])
+		}
+	}
+
+	if len(env.Profiles) > 0 {
+		fmt.Fprintln(out, " - Active profiles:", strings.Join(env.Profiles, ", "))
+	}
+	fmt.Fprintln(out, " - Configuration files, by order of precedence after the command line flags:")
+	for _, file := range env.ConfigFiles {
+		fmt.Fprintln(out, "   "+file)
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
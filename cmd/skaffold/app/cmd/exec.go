@@ -0,0 +1,57 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"io"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdExec describes the CLI command to run a command in a deployed pod.
+func NewCmdExec(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "exec <artifact|resource> -- <command>",
+		Short: "Run a command in a deployed pod",
+		Long: `Run a command in the most recent running pod of an artifact, found by image,
+or in a resource like deployment/app.
+An artifact can be referred to by its full image name or by the last part of its image name.`,
+		Args: validateExecArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return doExec(context.Background(), out, args[0], args[1:])
+		},
+	}
+	AddFlags(cmd.Flags(), "exec")
+	return cmd
+}
+
+func validateExecArgs(cmd *cobra.Command, args []string) error {
+	if cmd.ArgsLenAtDash() != 1 || len(args) < 2 {
+		return errors.New("usage: skaffold exec <artifact|resource> -- <command>")
+	}
+	return nil
+}
+
+func doExec(ctx context.Context, out io.Writer, target string, command []string) error {
+	return withRunner(func(r *runner.SkaffoldRunner, _ *latest.SkaffoldConfig) error {
+		return r.Exec(ctx, out, target, command)
+	})
+}
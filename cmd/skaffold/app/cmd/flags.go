@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"reflect"
+	"time"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
 	"github.com/spf13/pflag"
@@ -33,6 +34,7 @@ type Flag struct {
 	DefValue      interface{}
 	FlagAddMethod string
 	DefinedOn     []string
+	NoOptDefVal   string
 }
 
 // FlagRegistry is a list of all Skaffold CLI flags.
@@ -79,13 +81,45 @@ var FlagRegistry = []Flag{
 		FlagAddMethod: "StringVar",
 		DefinedOn:     []string{"all"},
 	},
+	{
+		Name:          "default-repo-strategy",
+		Usage:         "How the default repository is combined with image names: auto, prefix, flatten or replace-registry (overrides global config)",
+		Value:         &opts.DefaultRepoStrategy,
+		DefValue:      "",
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"all"},
+	},
+	{
+		Name:          "default-repo-override",
+		Usage:         "Use the given name for an image instead of applying the default repository, e.g. IMAGE=NEW_IMAGE. Set multiple times for multiple images (overrides global config)",
+		Value:         &opts.DefaultRepoOverrides,
+		DefValue:      []string{},
+		FlagAddMethod: "StringSliceVar",
+		DefinedOn:     []string{"all"},
+	},
+	{
+		Name:          "offline",
+		Usage:         "Disable update checks and remote lookups, for use on locked-down networks (overrides global config)",
+		Value:         &opts.Offline,
+		DefValue:      false,
+		FlagAddMethod: "BoolVar",
+		DefinedOn:     []string{"all"},
+	},
 	{
 		Name:          "cache-artifacts",
 		Usage:         "Set to true to enable caching of artifacts",
 		Value:         &opts.CacheArtifacts,
 		DefValue:      false,
 		FlagAddMethod: "BoolVar",
-		DefinedOn:     []string{"dev", "build", "run", "debug"},
+		DefinedOn:     []string{"dev", "build", "run", "debug", "render"},
+	},
+	{
+		Name:          "remote-cache",
+		Usage:         "Look up images tagged with the artifacts' content hash in the registry before building them (requires --cache-artifacts)",
+		Value:         &opts.RemoteCache,
+		DefValue:      false,
+		FlagAddMethod: "BoolVar",
+		DefinedOn:     []string{"dev", "build", "run", "debug", "render"},
 	},
 	{
 		Name:          "cache-file",
@@ -93,7 +127,7 @@ var FlagRegistry = []Flag{
 		Value:         &opts.CacheFile,
 		DefValue:      "",
 		FlagAddMethod: "StringVar",
-		DefinedOn:     []string{"dev", "build", "run", "debug"},
+		DefinedOn:     []string{"dev", "build", "run", "debug", "render"},
 	},
 	{
 		Name:          "insecure-registry",
@@ -101,7 +135,7 @@ var FlagRegistry = []Flag{
 		Value:         &opts.InsecureRegistries,
 		DefValue:      []string{},
 		FlagAddMethod: "StringSliceVar",
-		DefinedOn:     []string{"dev", "build", "run", "debug"},
+		DefinedOn:     []string{"dev", "build", "run", "debug", "render"},
 	},
 	{
 		Name:          "enable-rpc",
@@ -109,7 +143,7 @@ var FlagRegistry = []Flag{
 		Value:         &opts.EnableRPC,
 		DefValue:      false,
 		FlagAddMethod: "BoolVar",
-		DefinedOn:     []string{"dev", "build", "run", "debug", "deploy"},
+		DefinedOn:     []string{"dev", "build", "run", "debug", "deploy", "verify"},
 	},
 	{
 		Name:          "rpc-port",
@@ -117,7 +151,7 @@ var FlagRegistry = []Flag{
 		Value:         &opts.RPCPort,
 		DefValue:      constants.DefaultRPCPort,
 		FlagAddMethod: "IntVar",
-		DefinedOn:     []string{"dev", "build", "run", "debug", "deploy"},
+		DefinedOn:     []string{"dev", "build", "run", "debug", "deploy", "verify"},
 	},
 	{
 		Name:          "rpc-http-port",
@@ -125,7 +159,23 @@ var FlagRegistry = []Flag{
 		Value:         &opts.RPCHTTPPort,
 		DefValue:      constants.DefaultRPCHTTPPort,
 		FlagAddMethod: "IntVar",
-		DefinedOn:     []string{"dev", "build", "run", "debug", "deploy"},
+		DefinedOn:     []string{"dev", "build", "run", "debug", "deploy", "verify"},
+	},
+	{
+		Name:          "ui",
+		Usage:         "Serve a web UI showing the state of the pipeline and the logs, and to trigger rebuilds",
+		Value:         &opts.UI,
+		DefValue:      false,
+		FlagAddMethod: "BoolVar",
+		DefinedOn:     []string{"dev", "debug"},
+	},
+	{
+		Name:          "ui-port",
+		Usage:         "tcp port to serve the web UI on",
+		Value:         &opts.UIPort,
+		DefValue:      constants.DefaultUIPort,
+		FlagAddMethod: "IntVar",
+		DefinedOn:     []string{"dev", "debug"},
 	},
 	{
 		Name:          "label",
@@ -134,7 +184,15 @@ var FlagRegistry = []Flag{
 		Value:         &opts.CustomLabels,
 		DefValue:      []string{},
 		FlagAddMethod: "StringSliceVar",
-		DefinedOn:     []string{"dev", "run", "debug", "deploy"},
+		DefinedOn:     []string{"dev", "run", "debug", "deploy", "render"},
+	},
+	{
+		Name:          "run-id",
+		Usage:         "Identifier of the session, set as the skaffold.dev/run-id label on deployed objects. Defaults to a random ID",
+		Value:         &opts.RunID,
+		DefValue:      "",
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"dev", "run", "debug", "deploy", "render", "inspect"},
 	},
 	{
 		Name:          "toot",
@@ -162,6 +220,30 @@ var FlagRegistry = []Flag{
 		FlagAddMethod: "BoolVar",
 		DefinedOn:     []string{"dev", "debug"},
 	},
+	{
+		Name:          "tail-for",
+		Usage:         "Stop streaming logs after this duration, e.g. 5m, and exit. Implies --tail. 0 means until interrupted",
+		Value:         &opts.TailFor,
+		DefValue:      time.Duration(0),
+		FlagAddMethod: "DurationVar",
+		DefinedOn:     []string{"deploy", "run"},
+	},
+	{
+		Name:          "tail-container",
+		Usage:         "Only stream the logs of containers with these names, or matching these glob patterns. Implies --tail",
+		Value:         &opts.TailContainers,
+		DefValue:      []string{},
+		FlagAddMethod: "StringSliceVar",
+		DefinedOn:     []string{"deploy", "run"},
+	},
+	{
+		Name:          "tail-fail-on-crash",
+		Usage:         "Fail as soon as a container whose logs are streamed exits with an error. Implies --tail",
+		Value:         &opts.TailFailOnCrash,
+		DefValue:      false,
+		FlagAddMethod: "BoolVar",
+		DefinedOn:     []string{"deploy", "run"},
+	},
 	// We need opts.Force and opts.ForceDev since cobra, overwrites the default value
 	// when registering the flag twice.
 	{
@@ -180,13 +262,30 @@ var FlagRegistry = []Flag{
 		FlagAddMethod: "BoolVar",
 		DefinedOn:     []string{"dev", "run", "debug"},
 	},
+	{
+		Name:          "build-image",
+		Shorthand:     "b",
+		Usage:         "Choose which artifacts to build. Artifacts with image names that contain the expression, or match it as a glob pattern, will be built only. Default is to build sources for all artifacts",
+		Value:         &opts.BuildImages,
+		DefValue:      []string{},
+		FlagAddMethod: "StringSliceVar",
+		DefinedOn:     []string{"build", "dev", "run", "debug"},
+	},
+	{
+		Name:          "keep-going",
+		Usage:         "Keep building the other artifacts when one fails to build, and print a summary of the failed builds",
+		Value:         &opts.KeepGoing,
+		DefValue:      false,
+		FlagAddMethod: "BoolVar",
+		DefinedOn:     []string{"build", "dev", "run", "debug"},
+	},
 	{
 		Name:          "skip-tests",
 		Usage:         "Whether to skip the tests after building",
 		Value:         &opts.SkipTests,
 		DefValue:      false,
 		FlagAddMethod: "BoolVar",
-		DefinedOn:     []string{"dev", "run", "debug", "build"},
+		DefinedOn:     []string{"dev", "run", "debug", "build", "render"},
 	},
 	{
 		Name:          "cleanup",
@@ -210,7 +309,31 @@ var FlagRegistry = []Flag{
 		Value:         &opts.NoPruneChildren,
 		DefValue:      false,
 		FlagAddMethod: "BoolVar",
-		DefinedOn:     []string{"dev", "run", "debug"},
+		DefinedOn:     []string{"dev", "run", "debug", "prune"},
+	},
+	{
+		Name:          "status-check",
+		Usage:         "Wait for deployed resources to stabilize (also enabled by deploy.statusCheck in the config)",
+		Value:         &opts.StatusCheck,
+		DefValue:      false,
+		FlagAddMethod: "BoolVar",
+		DefinedOn:     []string{"dev", "debug", "deploy", "run"},
+	},
+	{
+		Name:          "retain",
+		Usage:         "Kinds of resources to never delete, e.g. PersistentVolumeClaim,Namespace",
+		Value:         &opts.RetainKinds,
+		DefValue:      []string{},
+		FlagAddMethod: "StringSliceVar",
+		DefinedOn:     []string{"delete"},
+	},
+	{
+		Name:          "selector",
+		Usage:         "Also delete the resources that match this label selector, e.g. left behind by renamed manifests",
+		Value:         &opts.DeleteSelector,
+		DefValue:      "",
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"delete"},
 	},
 	{
 		Name:          "port-forward",
@@ -218,7 +341,121 @@ var FlagRegistry = []Flag{
 		Value:         &opts.PortForward,
 		DefValue:      false,
 		FlagAddMethod: "BoolVar",
-		DefinedOn:     []string{"dev", "debug"},
+		DefinedOn:     []string{"dev", "run", "debug"},
+	},
+	{
+		Name:          "open",
+		Usage:         "Open forwarded URLs in the browser, once the pods are ready, optionally at the given path. Implies --port-forward",
+		Value:         &opts.Open,
+		DefValue:      "",
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"dev", "run", "debug"},
+		NoOptDefVal:   "/",
+	},
+	{
+		Name:          "output",
+		Usage:         "Print the final status as machine-readable json, with a stable error code and a suggestion on failures",
+		Value:         &opts.Output,
+		DefValue:      "",
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"dev", "run", "debug", "deploy", "verify", "delete"},
+	},
+	{
+		Name:          "quiet",
+		Shorthand:     "q",
+		Usage:         "Only print the final summary, and the output of the steps that fail",
+		Value:         &opts.Quiet,
+		DefValue:      false,
+		FlagAddMethod: "BoolVar",
+		DefinedOn:     []string{"dev", "run", "debug", "deploy", "verify", "delete"},
+	},
+	{
+		Name:          "verbose",
+		Usage:         "Print the full output of the builders, testers and deployers. By default, it's only printed when they fail",
+		Value:         &opts.Verbose,
+		DefValue:      false,
+		FlagAddMethod: "BoolVar",
+		DefinedOn:     []string{"build", "dev", "run", "debug", "deploy", "verify", "delete"},
+	},
+	{
+		Name:          "ci-annotations",
+		Usage:         "Print annotations for the CI system's UI, on build failures, policy findings and status check failures: github, gitlab or auto to detect the CI system",
+		Value:         &opts.CIAnnotations,
+		DefValue:      "",
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"dev", "build", "run", "debug", "deploy", "render", "verify"},
+	},
+	{
+		Name:          "timeout",
+		Usage:         "Abort the command if it hasn't completed after this duration, e.g. 30m. 0 means no timeout",
+		Value:         &opts.Timeout,
+		DefValue:      time.Duration(0),
+		FlagAddMethod: "DurationVar",
+		DefinedOn:     []string{"build", "run", "deploy", "render", "verify", "delete"},
+	},
+	{
+		Name:          "build-timeout",
+		Usage:         "Abort the build of an artifact if it hasn't completed after this duration, e.g. 10m. 0 means no timeout",
+		Value:         &opts.BuildTimeout,
+		DefValue:      time.Duration(0),
+		FlagAddMethod: "DurationVar",
+		DefinedOn:     []string{"build", "dev", "run", "debug", "render"},
+	},
+	{
+		Name:          "render-timeout",
+		Usage:         "Abort rendering the manifests if it hasn't completed after this duration. 0 means no timeout",
+		Value:         &opts.RenderTimeout,
+		DefValue:      time.Duration(0),
+		FlagAddMethod: "DurationVar",
+		DefinedOn:     []string{"render"},
+	},
+	{
+		Name:          "deploy-timeout",
+		Usage:         "Abort the deployment if it hasn't completed after this duration, e.g. 5m. 0 means no timeout",
+		Value:         &opts.DeployTimeout,
+		DefValue:      time.Duration(0),
+		FlagAddMethod: "DurationVar",
+		DefinedOn:     []string{"dev", "run", "debug", "deploy"},
+	},
+	{
+		Name:          "wait-for-images",
+		Usage:         "After the build, wait for at most this duration, e.g. 2m, until the pushed images can be pulled from their registries. Availability is checked from this machine, not from the cluster. 0 means no wait",
+		Value:         &opts.WaitForImages,
+		DefValue:      time.Duration(0),
+		FlagAddMethod: "DurationVar",
+		DefinedOn:     []string{"build", "dev", "run", "debug", "deploy"},
+	},
+	{
+		Name:          "grace-period",
+		Usage:         "On interruption, time given to kubectl, helm and other child processes to exit before they are killed",
+		Value:         &opts.GracePeriod,
+		DefValue:      constants.DefaultGracePeriod,
+		FlagAddMethod: "DurationVar",
+		DefinedOn:     []string{"dev", "run", "debug", "deploy", "delete", "build", "render", "verify"},
+	},
+	{
+		Name:          "symlinks",
+		Usage:         "How symbolic links found in the dependencies of artifacts are handled: keep them without following them, follow, ignore or error",
+		Value:         &opts.Symlinks,
+		DefValue:      "keep",
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"dev", "run", "debug", "build"},
+	},
+	{
+		Name:          "resume",
+		Usage:         "Skip building the artifacts already built by the last failed run, if their sources didn't change",
+		Value:         &opts.Resume,
+		DefValue:      false,
+		FlagAddMethod: "BoolVar",
+		DefinedOn:     []string{"run"},
+	},
+	{
+		Name:          "env-file",
+		Usage:         "File of KEY=VALUE pairs made available to templates, like envTemplate tags, build args and helm values (overrides envFile in skaffold.yaml)",
+		Value:         &opts.EnvFile,
+		DefValue:      "",
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"dev", "build", "run", "debug", "deploy", "render", "delete", "verify", "exec"},
 	},
 }
 
@@ -240,6 +477,7 @@ func SetUpFlags() {
 		if fl.Shorthand != "" {
 			f.Shorthand = fl.Shorthand
 		}
+		f.NoOptDefVal = fl.NoOptDefVal
 		f.Annotations = map[string][]string{
 			"cmds": fl.DefinedOn,
 		}
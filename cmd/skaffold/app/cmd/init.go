@@ -27,6 +27,8 @@ import (
 
 var (
 	composeFile  string
+	kompose      bool
+	deployer     string
 	cliArtifacts []string
 	skipBuild    bool
 	force        bool
@@ -43,8 +45,10 @@ func NewCmdInit(out io.Writer) *cobra.Command {
 			f.BoolVar(&skipBuild, "skip-build", false, "Skip generating build artifacts in Skaffold config")
 			f.BoolVar(&force, "force", false, "Force the generation of the Skaffold config")
 			f.StringVar(&composeFile, "compose-file", "", "Initialize from a docker-compose file")
-			f.StringSliceVarP(&cliArtifacts, "artifact", "a", nil, "'='-delimited dockerfile/image pair to generate build artifact\n(example: --artifact=/web/Dockerfile.web=gcr.io/web-project/image)")
-			f.BoolVar(&analyze, "analyze", false, "Print all discoverable Dockerfiles and images in JSON format to stdout")
+			f.BoolVar(&kompose, "kompose", false, "Use kompose to translate the docker-compose file into Kubernetes manifests")
+			f.StringVar(&deployer, "deployer", initializer.KubectlDeployer, "Deployer to configure: kubectl, helm or kustomize. With helm or kustomize, a minimal chart or kustomization is generated when no Kubernetes manifests are found")
+			f.StringSliceVarP(&cliArtifacts, "artifact", "a", nil, "'='-delimited build definition/image pair to generate build artifact: a Dockerfile, a pom.xml or a build.gradle\n(example: --artifact=/web/Dockerfile.web=gcr.io/web-project/image)")
+			f.BoolVar(&analyze, "analyze", false, "Print all discoverable Dockerfiles, builders and images in JSON format to stdout")
 		}).
 		NoArgs(doInit)
 }
@@ -52,6 +56,8 @@ func NewCmdInit(out io.Writer) *cobra.Command {
 func doInit(out io.Writer) error {
 	return initializer.DoInit(out, initializer.Config{
 		ComposeFile:  composeFile,
+		Kompose:      kompose,
+		Deployer:     deployer,
 		CliArtifacts: cliArtifacts,
 		SkipBuild:    skipBuild,
 		Force:        force,
@@ -0,0 +1,228 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/GoogleContainerTools/skaffold/cmd/skaffold/app/cmd/commands"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	inspectOutput  string
+	syncFile       string
+	syncWorkingDir string
+)
+
+// NewCmdInspect describes the CLI command to inspect a Skaffold session.
+func NewCmdInspect(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inspect",
+		Short: "A set of commands for inspecting Skaffold sessions.",
+	}
+
+	cmd.AddCommand(NewCmdInspectSession(out))
+	cmd.AddCommand(NewCmdInspectSync(out))
+	cmd.AddCommand(NewCmdInspectEvents(out))
+	return cmd
+}
+
+// NewCmdInspectSession describes the CLI command to print the metadata of a session.
+func NewCmdInspectSession(out io.Writer) *cobra.Command {
+	return commands.
+		New(out).
+		WithDescription("session", "Print the run ID, user and git metadata of a session, and the labels and annotations it sets").
+		WithFlags(func(f *pflag.FlagSet) {
+			f.StringVarP(&inspectOutput, "output", "o", "", "Output format. Use json to read the session from external tooling")
+			AddFlags(f, "inspect")
+		}).
+		NoArgs(doInspectSession)
+}
+
+func doInspectSession(out io.Writer) error {
+	return withRunner(func(r *runner.SkaffoldRunner, _ *latest.SkaffoldConfig) error {
+		session := r.Session()
+
+		switch inspectOutput {
+		case "":
+		case "json":
+			buf, err := json.MarshalIndent(session, "", "  ")
+			if err != nil {
+				return errors.Wrap(err, "marshalling session")
+			}
+			fmt.Fprintln(out, string(buf))
+			return nil
+		default:
+			return fmt.Errorf("unsupported output format %q", inspectOutput)
+		}
+
+		fmt.Fprintln(out, "Run ID:", session.RunID)
+		fmt.Fprintln(out, "User:", session.User)
+		fmt.Fprintln(out, "Git commit:", session.GitCommit)
+		fmt.Fprintln(out, "Git branch:", session.GitBranch)
+
+		printMetadata(out, "Labels", session.Labels)
+		printMetadata(out, "Annotations", session.Annotations)
+		printMetadata(out, "Template variables", session.TemplateValues)
+
+		return nil
+	})
+}
+
+// NewCmdInspectSync describes the CLI command to explain how a changed file is synced.
+func NewCmdInspectSync(out io.Writer) *cobra.Command {
+	return commands.
+		New(out).
+		WithDescription("sync", "Print which artifacts and sync rules handle a changed file, and where it's copied to in the containers").
+		WithFlags(func(f *pflag.FlagSet) {
+			f.StringVar(&syncFile, "file", "", "Path of the changed file")
+			f.StringVar(&syncWorkingDir, "working-dir", "", "Working dir of the containers, to resolve relative destinations. Defaults to printing them as relative paths")
+			f.StringVarP(&inspectOutput, "output", "o", "", "Output format. Use json to read the sync rules from external tooling")
+			AddFlags(f, "inspect")
+		}).
+		NoArgs(doInspectSync)
+}
+
+func doInspectSync(out io.Writer) error {
+	if syncFile == "" {
+		return errors.New("the changed file must be given with --file")
+	}
+
+	return withRunner(func(r *runner.SkaffoldRunner, _ *latest.SkaffoldConfig) error {
+		infos, err := r.InspectSync(context.Background(), syncFile, syncWorkingDir)
+		if err != nil {
+			return err
+		}
+
+		switch inspectOutput {
+		case "":
+		case "json":
+			buf, err := json.MarshalIndent(infos, "", "  ")
+			if err != nil {
+				return errors.Wrap(err, "marshalling sync rules")
+			}
+			fmt.Fprintln(out, string(buf))
+			return nil
+		default:
+			return fmt.Errorf("unsupported output format %q", inspectOutput)
+		}
+
+		if len(infos) == 0 {
+			fmt.Fprintf(out, "%s isn't in the context of any artifact: changes are ignored\n", syncFile)
+			return nil
+		}
+
+		for _, info := range infos {
+			color.Blue.Fprintln(out, info.Image)
+
+			switch {
+			case !info.Dependency:
+				fmt.Fprintln(out, " - Not a dependency: changes are ignored")
+			case len(info.Rules) == 0:
+				fmt.Fprintln(out, " - No sync rule matches: changes trigger a rebuild")
+			default:
+				for _, rule := range info.Rules {
+					fmt.Fprintf(out, " - Synced to %s by the rule src: %q, dest: %q", rule.Destination, rule.Src, rule.Dest)
+					if rule.Strip != "" {
+						fmt.Fprintf(out, ", strip: %q", rule.Strip)
+					}
+					fmt.Fprintln(out)
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// NewCmdInspectEvents describes the CLI command to replay the events of a past session.
+func NewCmdInspectEvents(out io.Writer) *cobra.Command {
+	return commands.
+		New(out).
+		WithDescription("events <run-id>", "Print the events logged by a past run, dev or debug session").
+		WithFlags(func(f *pflag.FlagSet) {
+			f.StringVarP(&inspectOutput, "output", "o", "", "Output format. Use json to read the events from external tooling")
+		}).
+		ExactArgs(1, doInspectEvents)
+}
+
+func doInspectEvents(out io.Writer, args []string) error {
+	path, err := runner.EventLogFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	entries, err := event.ReadLogFile(path)
+	if err != nil {
+		return err
+	}
+
+	switch inspectOutput {
+	case "":
+	case "json":
+		marshaler := &jsonpb.Marshaler{}
+		for _, entry := range entries {
+			if err := marshaler.Marshal(out, entry); err != nil {
+				return errors.Wrap(err, "marshalling event")
+			}
+			fmt.Fprintln(out)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format %q", inspectOutput)
+	}
+
+	for _, entry := range entries {
+		text := entry.Entry
+		if text == "" {
+			text = entry.GetEvent().GetMetaEvent().GetEntry()
+		}
+		if text == "" {
+			continue
+		}
+
+		if timestamp, err := ptypes.Timestamp(entry.Timestamp); err == nil {
+			color.Default.Fprint(out, timestamp.Local().Format("15:04:05.000 "))
+		}
+		fmt.Fprintln(out, text)
+	}
+
+	return nil
+}
+
+func printMetadata(out io.Writer, title string, values map[string]string) {
+	if len(values) == 0 {
+		return
+	}
+
+	color.Blue.Fprintln(out, "\n"+title)
+	for _, key := range sortedKeys(values) {
+		fmt.Fprintf(out, " - %s: %s\n", key, values[key])
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"io"
+
+	"github.com/GoogleContainerTools/skaffold/cmd/skaffold/app/cmd/commands"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var pruneKeep int
+
+// NewCmdPrune describes the CLI command to remove the images built by Skaffold.
+func NewCmdPrune(out io.Writer) *cobra.Command {
+	cmdUse := "prune"
+	return commands.
+		New(out).
+		WithDescription(cmdUse, "Remove the images built by Skaffold from the local Docker daemon").
+		WithFlags(func(f *pflag.FlagSet) {
+			f.IntVar(&pruneKeep, "keep", -1, "Number of most recent images to keep for each artifact. Defaults to build.local.prune.keep, or 0")
+			AddFlags(f, cmdUse)
+		}).
+		NoArgs(cancelWithCtrlC(context.Background(), doPrune))
+}
+
+func doPrune(ctx context.Context, out io.Writer) error {
+	return withRunner(func(r *runner.SkaffoldRunner, config *latest.SkaffoldConfig) error {
+		return r.PruneImages(ctx, out, targetArtifacts(opts, config), pruneKeep)
+	})
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/GoogleContainerTools/skaffold/cmd/skaffold/app/cmd/commands"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/tag"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/deploy/kubectl"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	digestSource    string
+	renderOutput    string
+	renderOutputDir string
+	placeholderTag  string
+	renderWatch     bool
+)
+
+// NewCmdRender describes the CLI command to render Kubernetes manifests.
+func NewCmdRender(out io.Writer) *cobra.Command {
+	cmdUse := "render"
+	return commands.
+		New(out).
+		WithDescription(cmdUse, "Builds the artifacts and prints the Kubernetes manifests that would be deployed").
+		WithFlags(func(f *pflag.FlagSet) {
+			f.StringVar(&digestSource, "digest-source", runner.DigestSourceNone, "Where to resolve the digests of the images from, to reference them by digest: none, remote (registry) or local (Docker daemon)")
+			f.StringVarP(&renderOutput, "output", "o", "", "File to write the rendered manifests to. Defaults to stdout")
+			f.StringVar(&renderOutputDir, "output-dir", "", "Directory to write the rendered manifests to, one file per resource, in a sub-directory per namespace")
+			f.StringVar(&placeholderTag, "placeholder-tag", "", "In offline mode, tag to use for the images that are not given with --images or --images-from-file, instead of the tag policy")
+			f.BoolVar(&renderWatch, "watch", false, "Render the manifests again whenever the sources, the manifests or the configuration change. Requires --output or --output-dir")
+			f.StringVar(&opts.Trigger, "trigger", "polling", "With --watch, how are changes detected? (polling, manual, notify, webhook or git)")
+			f.IntVar(&opts.WatchPollInterval, "watch-poll-interval", 1000, "With --watch, interval (in ms) between two checks for file changes")
+			f.VarP(&preBuiltImages, "images", "i", "A list of pre-built images to render, instead of building the artifacts")
+			f.Var(imagesFromFile, "images-from-file", "Filepath containing build output, e.g. created with skaffold build --file-output, to render instead of building the artifacts")
+			AddFlags(f, cmdUse)
+		}).
+		NoArgs(cancelWithCtrlC(context.Background(), doRender))
+}
+
+func doRender(ctx context.Context, out io.Writer) error {
+	if renderOutput != "" && renderOutputDir != "" {
+		return errors.New("--output and --output-dir can't be used together")
+	}
+
+	rd := &renderer{out: out}
+	if !renderWatch {
+		return withRunner(func(r *runner.SkaffoldRunner, config *latest.SkaffoldConfig) error {
+			rd.reset(r, config)
+			return rd.render(ctx, targetArtifacts(opts, config))
+		})
+	}
+
+	if renderOutput == "" && renderOutputDir == "" {
+		return errors.New("--watch requires --output or --output-dir")
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+			r, config, err := newRunner(opts)
+			if err != nil {
+				return errors.Wrap(err, "creating runner")
+			}
+
+			rd.reset(r, config)
+			err = r.RenderOnChange(ctx, out, targetArtifacts(opts, config), rd.render)
+			r.RPCServerShutdown()
+			if err != nil && errors.Cause(err) != runner.ErrorConfigurationChanged {
+				return err
+			}
+		}
+	}
+}
+
+// renderer renders the manifests, building the given artifacts first.
+// It keeps track of the builds and the written files between renders.
+type renderer struct {
+	out     io.Writer
+	runner  *runner.SkaffoldRunner
+	config  *latest.SkaffoldConfig
+	builds  []build.Artifact
+	written []string
+}
+
+// reset forgets the previous builds, for a new runner.
+func (rd *renderer) reset(r *runner.SkaffoldRunner, config *latest.SkaffoldConfig) {
+	rd.runner = r
+	rd.config = config
+	rd.builds = build.MergeWithPreviousBuilds(imagesFromFile.BuildArtifacts(), preBuiltImages.Artifacts())
+}
+
+func (rd *renderer) render(ctx context.Context, artifacts []*latest.Artifact) error {
+	// Build logs would be mixed with the manifests on stdout.
+	buildOut := ioutil.Discard
+	manifestsOut := rd.out
+	var rendered bytes.Buffer
+	switch {
+	case renderOutputDir != "":
+		buildOut = rd.out
+		manifestsOut = &rendered
+
+	case renderOutput != "":
+		buildOut = rd.out
+
+		f, err := os.Create(renderOutput)
+		if err != nil {
+			return errors.Wrap(err, "creating output file")
+		}
+		defer f.Close()
+		manifestsOut = f
+	}
+
+	prebuilt := len(imagesFromFile.BuildArtifacts()) > 0 || len(preBuiltImages.Artifacts()) > 0
+	switch {
+	case opts.Offline:
+		// Nothing is built nor resolved in offline mode.
+		if digestSource != runner.DigestSourceNone {
+			return fmt.Errorf("--digest-source=%s can't be used in offline mode", digestSource)
+		}
+
+		tags := placeholderTags(artifacts, placeholderTag)
+		if tags == nil {
+			var err error
+			if tags, err = rd.runner.ImageTags(ctx, buildOut, artifacts); err != nil {
+				return errors.Wrap(err, "generating tags")
+			}
+		}
+		rd.builds = build.MergeWithPreviousBuilds(rd.builds, dryRunArtifacts(artifacts, tags))
+
+	case !prebuilt && len(artifacts) > 0:
+		builds, err := rd.runner.BuildAndTest(ctx, buildOut, artifacts)
+		if err != nil {
+			return errors.Wrap(err, "build")
+		}
+		rd.builds = build.MergeWithPreviousBuilds(builds, rd.builds)
+	}
+
+	if err := rd.runner.Render(ctx, manifestsOut, rd.builds, digestSource); err != nil {
+		return err
+	}
+
+	if renderOutputDir != "" {
+		var manifests kubectl.ManifestList
+		manifests.Append(rendered.Bytes())
+		written, err := manifests.WriteToDir(renderOutputDir)
+		if err != nil {
+			return errors.Wrap(err, "writing manifests")
+		}
+		if err := removeStaleFiles(rd.written, written); err != nil {
+			return errors.Wrap(err, "removing stale manifests")
+		}
+		rd.written = written
+	}
+
+	if renderWatch {
+		color.Default.Fprintf(rd.out, "Manifests rendered to %s\n", renderOutput+renderOutputDir)
+	}
+	return nil
+}
+
+// removeStaleFiles removes the files written by a previous render
+// that the last render didn't write, for resources that are gone.
+func removeStaleFiles(previous, current []string) error {
+	kept := map[string]bool{}
+	for _, path := range current {
+		kept[path] = true
+	}
+
+	for _, path := range previous {
+		if kept[path] {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// placeholderTags tags every artifact with the same placeholder tag.
+func placeholderTags(artifacts []*latest.Artifact, placeholder string) tag.ImageTags {
+	if placeholder == "" {
+		return nil
+	}
+
+	tags := tag.ImageTags{}
+	for _, artifact := range artifacts {
+		tags[artifact.ImageName] = artifact.ImageName + ":" + placeholder
+	}
+	return tags
+}
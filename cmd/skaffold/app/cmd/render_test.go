@@ -0,0 +1,52 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/tag"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestPlaceholderTags(t *testing.T) {
+	artifacts := []*latest.Artifact{
+		{ImageName: "gcr.io/skaffold/first"},
+		{ImageName: "localhost:5000/second"},
+	}
+
+	testutil.CheckDeepEqual(t, tag.ImageTags(nil), placeholderTags(artifacts, ""))
+	testutil.CheckDeepEqual(t, tag.ImageTags{
+		"gcr.io/skaffold/first": "gcr.io/skaffold/first:PLACEHOLDER",
+		"localhost:5000/second": "localhost:5000/second:PLACEHOLDER",
+	}, placeholderTags(artifacts, "PLACEHOLDER"))
+}
+
+func TestRemoveStaleFiles(t *testing.T) {
+	tmpDir, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+	tmpDir.Write("pod-web.yaml", "").Write("ns/service-web.yaml", "").Write("other.yaml", "")
+
+	err := removeStaleFiles(tmpDir.Paths("pod-web.yaml", "ns/service-web.yaml", "gone.yaml"), tmpDir.Paths("pod-web.yaml"))
+	testutil.CheckError(t, false, err)
+
+	files, err := tmpDir.List()
+	sort.Strings(files)
+	testutil.CheckErrorAndDeepEqual(t, false, err, tmpDir.Paths("", "ns", "other.yaml", "pod-web.yaml"), files)
+}
@@ -36,13 +36,21 @@ func NewCmdRun(out io.Writer) *cobra.Command {
 		WithDescription(cmdUse, "Runs a pipeline file").
 		WithFlags(func(f *pflag.FlagSet) {
 			f.StringVarP(&opts.CustomTag, "tag", "t", "", "The optional custom tag to use for images which overrides the current Tagger configuration")
+			f.VarP(&preBuiltImages, "images", "i", "A list of pre-built images to deploy for the artifacts that are not selected with --build-image")
 			AddFlags(f, cmdUse)
 		}).
 		NoArgs(cancelWithCtrlC(context.Background(), doRun))
 }
 
 func doRun(ctx context.Context, out io.Writer) error {
+	if opts.Open != "" {
+		opts.PortForward = true
+	}
+	impliedTail()
+
 	return withRunner(func(r *runner.SkaffoldRunner, config *latest.SkaffoldConfig) error {
+		r.SetPreBuiltImages(preBuiltImages.Artifacts())
+
 		err := r.Run(ctx, out, config.Build.Artifacts)
 		if err == nil {
 			tips.PrintForRun(out, opts)
@@ -51,3 +59,10 @@ func doRun(ctx context.Context, out io.Writer) error {
 		return err
 	})
 }
+
+// impliedTail streams the logs when a flag that configures the streaming is set.
+func impliedTail() {
+	if opts.TailFor > 0 || len(opts.TailContainers) > 0 || opts.TailFailOnCrash {
+		opts.Tail = true
+	}
+}
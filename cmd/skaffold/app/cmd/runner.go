@@ -17,11 +17,14 @@ limitations under the License.
 package cmd
 
 import (
+	"fmt"
 	"os"
 
 	configutil "github.com/GoogleContainerTools/skaffold/cmd/skaffold/app/cmd/config"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/config"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/errcode"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/defaults"
@@ -45,35 +48,41 @@ func withRunner(action func(*runner.SkaffoldRunner, *latest.SkaffoldConfig) erro
 
 // newRunner creates a SkaffoldRunner and returns the SkaffoldConfig associated with it.
 func newRunner(opts *config.SkaffoldOptions) (*runner.SkaffoldRunner, *latest.SkaffoldConfig, error) {
+	if opts.Offline && util.IsURL(opts.ConfigurationFile) {
+		return nil, nil, fmt.Errorf("unable to download %s in offline mode", opts.ConfigurationFile)
+	}
+
 	parsed, err := schema.ParseConfig(opts.ConfigurationFile, true)
 	if err != nil {
 		// If the error is NOT that the file doesn't exist, then we warn the user
 		// that maybe they are using an outdated version of Skaffold that's unable to read
 		// the configuration.
-		if !os.IsNotExist(err) {
-			warnIfUpdateIsAvailable()
+		if !os.IsNotExist(err) && !opts.Offline {
+			warnIfUpdateIsAvailable(opts.ConfigurationFile)
 		}
 
-		return nil, nil, errors.Wrap(err, "parsing skaffold config")
+		return nil, nil, errcode.WithCode(errcode.ConfigInvalid, errors.Wrap(err, "parsing skaffold config"))
 	}
 
 	config := parsed.(*latest.SkaffoldConfig)
+	build.ArtifactTimeout = opts.BuildTimeout
+	build.KeepGoing = opts.KeepGoing
 
 	if err = schema.ApplyProfiles(config, opts); err != nil {
-		return nil, nil, errors.Wrap(err, "applying profiles")
+		return nil, nil, errcode.WithCode(errcode.ConfigInvalid, errors.Wrap(err, "applying profiles"))
 	}
 
 	if err := defaults.Set(config); err != nil {
-		return nil, nil, errors.Wrap(err, "setting default values")
+		return nil, nil, errcode.WithCode(errcode.ConfigInvalid, errors.Wrap(err, "setting default values"))
 	}
 
 	if err := validation.Process(config); err != nil {
-		return nil, nil, errors.Wrap(err, "invalid skaffold config")
+		return nil, nil, errcode.WithCode(errcode.ConfigInvalid, errors.Wrap(err, "invalid skaffold config"))
 	}
 
-	defaultRepo, err := configutil.GetDefaultRepo(opts.DefaultRepo)
+	defaultRepo, err := configutil.GetDefaultRepoSubstitution(opts.DefaultRepo, opts.DefaultRepoStrategy, opts.DefaultRepoOverrides)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "getting default repo")
+		return nil, nil, errcode.WithCode(errcode.ConfigInvalid, errors.Wrap(err, "getting default repo"))
 	}
 
 	applyDefaultRepoSubstitution(config, defaultRepo)
@@ -86,22 +95,26 @@ func newRunner(opts *config.SkaffoldOptions) (*runner.SkaffoldRunner, *latest.Sk
 	return runner, config, nil
 }
 
-func warnIfUpdateIsAvailable() {
-	latest, current, versionErr := update.GetLatestAndCurrentVersion()
+func warnIfUpdateIsAvailable(filename string) {
+	enabled, url, err := updateCheckSettings(projectSettings(filename))
+	if err != nil || !update.IsUpdateCheckEnabled(enabled) {
+		return
+	}
+	latest, current, versionErr := update.GetLatestAndCurrentVersion(url)
 	if versionErr == nil && latest.GT(current) {
 		logrus.Warnf("Your Skaffold version might be too old. Download the latest version (%s) at %s\n", latest, constants.LatestDownloadURL)
 	}
 }
 
-func applyDefaultRepoSubstitution(config *latest.SkaffoldConfig, defaultRepo string) {
-	if defaultRepo == "" {
+func applyDefaultRepoSubstitution(config *latest.SkaffoldConfig, defaultRepo util.DefaultRepoSubstitution) {
+	if defaultRepo.Repo == "" && len(defaultRepo.Overrides) == 0 {
 		// noop
 		return
 	}
 	for _, artifact := range config.Build.Artifacts {
-		artifact.ImageName = util.SubstituteDefaultRepoIntoImage(defaultRepo, artifact.ImageName)
+		artifact.ImageName = defaultRepo.Substitute(artifact.ImageName)
 	}
 	for _, testCase := range config.Test {
-		testCase.ImageName = util.SubstituteDefaultRepoIntoImage(defaultRepo, testCase.ImageName)
+		testCase.ImageName = defaultRepo.Substitute(testCase.ImageName)
 	}
 }
@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/config"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/errcode"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/GoogleContainerTools/skaffold/testutil"
 	"github.com/pkg/errors"
@@ -64,6 +65,7 @@ func TestNewRunnerInvalidConfig(t *testing.T) {
 	})
 
 	testutil.CheckErrorContains(t, "parsing skaffold config", err)
+	testutil.CheckDeepEqual(t, errcode.ConfigInvalid, errcode.CodeOf(err))
 }
 
 func TestNewRunnerUnknownProfile(t *testing.T) {
@@ -76,4 +78,5 @@ func TestNewRunnerUnknownProfile(t *testing.T) {
 	})
 
 	testutil.CheckErrorContains(t, "applying profiles", err)
+	testutil.CheckDeepEqual(t, errcode.ConfigInvalid, errcode.CodeOf(err))
 }
@@ -0,0 +1,67 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"io/ioutil"
+
+	configutil "github.com/GoogleContainerTools/skaffold/cmd/skaffold/app/cmd/config"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// projectSettings reads the `settings` of a skaffold.yaml file. They're needed
+// before the config is parsed, so profiles can't change them. A file that can't be
+// read, like a remote config, has no settings.
+func projectSettings(filename string) *latest.ProjectSettings {
+	buf, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil
+	}
+
+	var cfg struct {
+		Settings *latest.ProjectSettings `yaml:"settings"`
+	}
+	if err := yaml.Unmarshal(buf, &cfg); err != nil {
+		return nil
+	}
+	return cfg.Settings
+}
+
+// updateCheckSettings returns whether to check for a newer version of Skaffold,
+// and the URL to check. The project settings take precedence over the global config.
+func updateCheckSettings(settings *latest.ProjectSettings) (bool, string, error) {
+	enabled, err := configutil.GetUpdateCheck()
+	if err != nil {
+		return false, "", errors.Wrap(err, "reading update check config")
+	}
+	url, err := configutil.GetUpdateCheckURL()
+	if err != nil {
+		return false, "", errors.Wrap(err, "reading update check config")
+	}
+
+	if settings != nil {
+		if settings.UpdateCheck != nil {
+			enabled = *settings.UpdateCheck
+		}
+		if settings.UpdateCheckURL != "" {
+			url = settings.UpdateCheckURL
+		}
+	}
+	return enabled, url, nil
+}
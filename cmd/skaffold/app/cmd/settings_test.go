@@ -0,0 +1,48 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestProjectSettings(t *testing.T) {
+	cfg, delete := writeSkaffoldConfig(t, `settings:
+  updateCheck: false
+  updateCheckURL: https://mirror.example.com/skaffold/VERSION
+`)
+	defer delete()
+
+	settings := projectSettings(cfg)
+
+	testutil.CheckDeepEqual(t, &latest.ProjectSettings{
+		UpdateCheck:    util.BoolPtr(false),
+		UpdateCheckURL: "https://mirror.example.com/skaffold/VERSION",
+	}, settings)
+}
+
+func TestProjectSettingsNotSet(t *testing.T) {
+	cfg, delete := writeSkaffoldConfig(t, "")
+	defer delete()
+
+	testutil.CheckDeepEqual(t, (*latest.ProjectSettings)(nil), projectSettings(cfg))
+	testutil.CheckDeepEqual(t, (*latest.ProjectSettings)(nil), projectSettings("missing-skaffold.yaml"))
+}
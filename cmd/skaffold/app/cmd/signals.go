@@ -22,19 +22,117 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
+
+	configutil "github.com/GoogleContainerTools/skaffold/cmd/skaffold/app/cmd/config"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/ci"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/errcode"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/metrics"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/output"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
+// For testing
+var forceExit = os.Exit
+
 func cancelWithCtrlC(ctx context.Context, action func(context.Context, io.Writer) error) func(io.Writer) error {
 	return func(out io.Writer) error {
+		if err := validateOutput(opts.Output); err != nil {
+			return err
+		}
+		if err := ci.SetFormat(opts.CIAnnotations); err != nil {
+			return err
+		}
+		util.GracePeriod = opts.GracePeriod
+
+		symlinks, err := util.ParseSymlinkPolicy(opts.Symlinks)
+		if err != nil {
+			return err
+		}
+		util.Symlinks = symlinks
+
+		level, err := outputLevel(opts.Quiet, opts.Verbose)
+		if err != nil {
+			return err
+		}
+		output.Current = level
+
+		if opts.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+			defer cancel()
+		}
+
 		ctx, cancel := context.WithCancel(ctx)
 		defer cancel()
 
-		catchCtrlC(cancel)
-		return action(ctx, out)
+		start := time.Now()
+		startMetrics()
+		stopCatching := catchCtrlC(cancel)
+		err = action(ctx, out)
+		stopCatching()
+
+		if ctx.Err() == context.DeadlineExceeded {
+			if err == nil {
+				err = ctx.Err()
+			}
+			err = errcode.WithCode(errcode.Timeout, errors.Wrapf(err, "timed out after %v", opts.Timeout))
+		}
+
+		exportMetrics(err, ctx.Err() == context.Canceled)
+
+		switch {
+		case opts.Output == "json":
+			if err := printFinalStatus(out, err, ctx.Err() == context.Canceled); err != nil {
+				return errors.Wrap(err, "printing final status")
+			}
+		case opts.Quiet:
+			printSummary(out, opts.Command, err, ctx.Err() == context.Canceled, time.Since(start))
+		}
+		return err
 	}
 }
 
-func catchCtrlC(cancel context.CancelFunc) {
+// startMetrics collects the metrics of the command, if opted in.
+func startMetrics() {
+	enabled, export, err := configutil.GetMetricsSettings()
+	if err != nil {
+		logrus.Debugf("unable to read the metrics settings from global config: %s", err)
+		return
+	}
+	if enabled {
+		metrics.Initialize(opts.Command, export)
+	}
+}
+
+func exportMetrics(err error, cancelled bool) {
+	status := exitStatus(err, cancelled)
+
+	var code errcode.Code
+	if status == statusFailed {
+		code = errcode.CodeOf(err)
+	}
+	metrics.Export(status, code)
+}
+
+func outputLevel(quiet, verbose bool) (output.Level, error) {
+	switch {
+	case quiet && verbose:
+		return output.Default, errors.New("--quiet and --verbose can't be used together")
+	case quiet:
+		return output.Quiet, nil
+	case verbose:
+		return output.Verbose, nil
+	default:
+		return output.Default, nil
+	}
+}
+
+// catchCtrlC cancels the given context on interruption. It returns a function
+// that stops catching the signals.
+func catchCtrlC(cancel context.CancelFunc) func() {
 	signals := make(chan os.Signal, 1)
 	signal.Notify(signals,
 		syscall.SIGTERM,
@@ -42,8 +140,29 @@ func catchCtrlC(cancel context.CancelFunc) {
 		syscall.SIGPIPE,
 	)
 
+	stop := make(chan struct{})
 	go func() {
-		<-signals
+		// The first signal cancels the running command, which gives the child processes
+		// the grace period to exit. The second one exits right away.
+		select {
+		case <-signals:
+		case <-stop:
+			return
+		}
+		logrus.Infoln("Shutting down, interrupt again to force")
 		cancel()
+
+		select {
+		case <-signals:
+		case <-stop:
+			return
+		}
+		logrus.Warnln("Forced shutdown")
+		forceExit(1)
 	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(stop)
+	}
 }
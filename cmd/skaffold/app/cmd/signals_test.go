@@ -30,7 +30,7 @@ func TestCatchCtrlC(t *testing.T) {
 	wg.Add(1)
 
 	ctx, cancel := context.WithCancel(context.Background())
-	catchCtrlC(cancel)
+	defer catchCtrlC(cancel)()
 
 	go func() {
 		<-ctx.Done()
@@ -41,3 +41,20 @@ func TestCatchCtrlC(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestForceExitOnSecondSignal(t *testing.T) {
+	defer func(f func(int)) { forceExit = f }(forceExit)
+	exited := make(chan int, 1)
+	forceExit = func(code int) { exited <- code }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer catchCtrlC(cancel)()
+
+	syscall.Kill(syscall.Getpid(), syscall.SIGINT)
+	<-ctx.Done()
+	syscall.Kill(syscall.Getpid(), syscall.SIGINT)
+
+	if code := <-exited; code != 1 {
+		t.Errorf("expected exit code 1, got %d", code)
+	}
+}
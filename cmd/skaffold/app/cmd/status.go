@@ -0,0 +1,84 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/errcode"
+	"github.com/pkg/errors"
+)
+
+const (
+	statusSucceeded = "succeeded"
+	statusFailed    = "failed"
+	statusCancelled = "cancelled"
+)
+
+// finalStatus is printed with `--output=json` when a command exits.
+type finalStatus struct {
+	Status     string `json:"status"`
+	Code       string `json:"code,omitempty"`
+	ExitCode   int    `json:"exitCode,omitempty"`
+	Message    string `json:"message,omitempty"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+func validateOutput(output string) error {
+	switch output {
+	case "", "json":
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format %q, only json is supported", output)
+	}
+}
+
+// printFinalStatus prints how a command exited, with the code of its error
+// and a suggestion to fix it.
+func printFinalStatus(out io.Writer, err error, cancelled bool) error {
+	status := finalStatus{Status: exitStatus(err, cancelled)}
+
+	if status.Status == statusFailed {
+		code := errcode.CodeOf(err)
+		status.Code = string(code)
+		status.ExitCode = errcode.ExitCode(code)
+		status.Message = err.Error()
+		status.Suggestion = errcode.Suggestion(code)
+	}
+
+	return json.NewEncoder(out).Encode(status)
+}
+
+// printSummary prints how a command exited, and how long it took, in quiet mode.
+func printSummary(out io.Writer, command string, err error, cancelled bool, elapsed time.Duration) {
+	fmt.Fprintf(out, "Skaffold %s %s in %v\n", command, exitStatus(err, cancelled), elapsed)
+}
+
+func exitStatus(err error, cancelled bool) string {
+	switch {
+	case cancelled && (err == nil || errors.Cause(err) == context.Canceled):
+		return statusCancelled
+	case err != nil:
+		return statusFailed
+	default:
+		return statusSucceeded
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/errcode"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/output"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	"github.com/pkg/errors"
+)
+
+func TestPrintFinalStatus(t *testing.T) {
+	var tests = []struct {
+		description string
+		err         error
+		cancelled   bool
+		expected    string
+	}{
+		{
+			description: "succeeded",
+			expected:    `{"status":"succeeded"}` + "\n",
+		},
+		{
+			description: "cancelled",
+			cancelled:   true,
+			expected:    `{"status":"cancelled"}` + "\n",
+		},
+		{
+			description: "cancelled while running",
+			err:         errors.Wrap(context.Canceled, "building"),
+			cancelled:   true,
+			expected:    `{"status":"cancelled"}` + "\n",
+		},
+		{
+			description: "failed without a code",
+			err:         errors.New("failure"),
+			expected:    `{"status":"failed","code":"UNKNOWN","exitCode":1,"message":"failure"}` + "\n",
+		},
+		{
+			description: "failed with a code",
+			err:         errors.Wrap(errcode.WithCode(errcode.SyncFailed, errors.New("failure")), "syncing"),
+			expected:    `{"status":"failed","code":"SYNC_FAILED","exitCode":10,"message":"syncing: failure","suggestion":"` + errcode.Suggestion(errcode.SyncFailed) + `"}` + "\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			var out bytes.Buffer
+
+			err := printFinalStatus(&out, test.err, test.cancelled)
+
+			testutil.CheckErrorAndDeepEqual(t, false, err, test.expected, out.String())
+		})
+	}
+}
+
+func TestPrintSummary(t *testing.T) {
+	var tests = []struct {
+		description string
+		err         error
+		cancelled   bool
+		expected    string
+	}{
+		{
+			description: "succeeded",
+			expected:    "Skaffold run succeeded in 1m30s\n",
+		},
+		{
+			description: "cancelled",
+			cancelled:   true,
+			expected:    "Skaffold run cancelled in 1m30s\n",
+		},
+		{
+			description: "failed",
+			err:         errors.New("failure"),
+			expected:    "Skaffold run failed in 1m30s\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			var out bytes.Buffer
+
+			printSummary(&out, "run", test.err, test.cancelled, 90*time.Second)
+
+			testutil.CheckDeepEqual(t, test.expected, out.String())
+		})
+	}
+}
+
+func TestOutputLevel(t *testing.T) {
+	var tests = []struct {
+		description string
+		quiet       bool
+		verbose     bool
+		expected    output.Level
+		shouldErr   bool
+	}{
+		{
+			description: "default",
+			expected:    output.Default,
+		},
+		{
+			description: "quiet",
+			quiet:       true,
+			expected:    output.Quiet,
+		},
+		{
+			description: "verbose",
+			verbose:     true,
+			expected:    output.Verbose,
+		},
+		{
+			description: "both",
+			quiet:       true,
+			verbose:     true,
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			level, err := outputLevel(test.quiet, test.verbose)
+
+			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.expected, level)
+		})
+	}
+}
+
+func TestValidateOutput(t *testing.T) {
+	testutil.CheckError(t, false, validateOutput(""))
+	testutil.CheckError(t, false, validateOutput("json"))
+	testutil.CheckError(t, true, validateOutput("yaml"))
+}
+
+func TestCancelWithTimeout(t *testing.T) {
+	defer func(timeout time.Duration) { opts.Timeout = timeout }(opts.Timeout)
+	opts.Timeout = 10 * time.Millisecond
+
+	run := cancelWithCtrlC(context.Background(), func(ctx context.Context, out io.Writer) error {
+		<-ctx.Done()
+		return nil
+	})
+	err := run(ioutil.Discard)
+
+	testutil.CheckErrorContains(t, "timed out after 10ms", err)
+	testutil.CheckDeepEqual(t, errcode.Timeout, errcode.CodeOf(err))
+}
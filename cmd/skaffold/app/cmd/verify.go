@@ -0,0 +1,52 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"io"
+
+	"github.com/GoogleContainerTools/skaffold/cmd/skaffold/app/cmd/commands"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// NewCmdVerify describes the CLI command to verify deployed artifacts.
+func NewCmdVerify(out io.Writer) *cobra.Command {
+	cmdUse := "verify"
+	return commands.
+		New(out).
+		WithDescription(cmdUse, "Runs the verification tests against deployed artifacts").
+		WithFlags(func(f *pflag.FlagSet) {
+			f.VarP(&preBuiltImages, "images", "i", "A list of pre-built images that were deployed")
+			f.VarP(&buildOutputFile, "build-artifacts", "a", `Filepath containing build output.
+E.g. build.out created by running skaffold build --quiet {{json .}} > build.out`)
+			AddFlags(f, cmdUse)
+		}).
+		NoArgs(cancelWithCtrlC(context.Background(), doVerify))
+}
+
+func doVerify(ctx context.Context, out io.Writer) error {
+	return withRunner(func(r *runner.SkaffoldRunner, _ *latest.SkaffoldConfig) error {
+		artifacts := build.MergeWithPreviousBuilds(buildOutputFile.BuildArtifacts(), preBuiltImages.Artifacts())
+
+		return r.Verify(ctx, out, artifacts)
+	})
+}
@@ -17,16 +17,37 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
 	"io"
+	"os/exec"
+	"regexp"
+	"sync"
+	"time"
 
 	"github.com/GoogleContainerTools/skaffold/cmd/skaffold/app/cmd/commands"
 	"github.com/GoogleContainerTools/skaffold/cmd/skaffold/app/flags"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/version"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
 
-var versionFlag = flags.NewTemplateFlag("{{.Version}}\n", version.Info{})
+var versionFlag = flags.NewTemplateFlag("{{.Version}}\n", flags.VersionOutput{})
+
+// toolVersionTimeout is how long a tool can take to print its version.
+const toolVersionTimeout = 5 * time.Second
+
+// toolVersionArgs make the external tools Skaffold runs print their version.
+var toolVersionArgs = map[string][]string{
+	"kubectl":   {"version", "--client"},
+	"helm":      {"version", "--client", "--short"},
+	"kustomize": {"version"},
+}
+
+var toolVersionRegex = regexp.MustCompile(`v?\d+\.\d+\.\d+[\w.+-]*`)
 
 func NewCmdVersion(out io.Writer) *cobra.Command {
 	return commands.
@@ -39,5 +60,92 @@ func NewCmdVersion(out io.Writer) *cobra.Command {
 }
 
 func doVersion(out io.Writer) error {
-	return versionFlag.Template().Execute(out, version.Get())
+	output := flags.NewVersionOutput(*version.Get(), schemaVersions(), func() map[string]string {
+		useConfiguredTools(opts.ConfigurationFile)
+		return toolVersions(context.Background())
+	})
+
+	return versionFlag.Template().Execute(out, output)
+}
+
+func schemaVersions() []string {
+	var versions []string
+	for _, v := range schema.SchemaVersions {
+		versions = append(versions, v.APIVersion)
+	}
+	return versions
+}
+
+// useConfiguredTools makes the binaries configured in the `tools` section of skaffold.yaml,
+// if any, the ones whose versions are printed.
+func useConfiguredTools(filename string) {
+	if filename == "" {
+		// `skaffold version` has no `--filename` flag.
+		filename = "skaffold.yaml"
+	}
+	if opts.Offline && util.IsURL(filename) {
+		return
+	}
+
+	parsed, err := schema.ParseConfig(filename, true)
+	if err != nil {
+		logrus.Debugf("unable to read the tools of %s: %s", filename, err)
+		return
+	}
+
+	cfg := parsed.(*latest.SkaffoldConfig).Tools
+	if cfg == nil {
+		return
+	}
+	for tool, t := range map[string]*latest.Tool{
+		"kubectl":   cfg.Kubectl,
+		"helm":      cfg.Helm,
+		"kustomize": cfg.Kustomize,
+	} {
+		if t != nil {
+			util.SetToolPath(tool, t.Path)
+		}
+	}
+}
+
+// toolVersions runs the tools in parallel, to print their versions.
+func toolVersions(ctx context.Context) map[string]string {
+	ctx, cancel := context.WithTimeout(ctx, toolVersionTimeout)
+	defer cancel()
+
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	versions := map[string]string{}
+
+	for tool, args := range toolVersionArgs {
+		wg.Add(1)
+		go func(tool string, args []string) {
+			defer wg.Done()
+
+			if v := toolVersion(ctx, util.ToolPath(tool), args); v != "" {
+				lock.Lock()
+				versions[tool] = v
+				lock.Unlock()
+			}
+		}(tool, args)
+	}
+	wg.Wait()
+
+	return versions
+}
+
+func toolVersion(ctx context.Context, path string, args []string) string {
+	cmd := exec.CommandContext(ctx, path, args...)
+	out, err := util.RunCmdOut(cmd)
+	if err != nil {
+		return ""
+	}
+
+	return parseToolVersion(string(out))
+}
+
+// parseToolVersion finds the version in the output of `kubectl version`, `helm version`
+// or `kustomize version`, whose formats vary between releases.
+func parseToolVersion(out string) string {
+	return toolVersionRegex.FindString(out)
 }
@@ -0,0 +1,90 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestSchemaVersions(t *testing.T) {
+	versions := schemaVersions()
+
+	testutil.CheckDeepEqual(t, "skaffold/v1alpha1", versions[0])
+	testutil.CheckDeepEqual(t, latest.Version, versions[len(versions)-1])
+}
+
+func TestParseToolVersion(t *testing.T) {
+	var tests = []struct {
+		description string
+		output      string
+		expected    string
+	}{
+		{
+			description: "kubectl",
+			output:      `Client Version: version.Info{Major:"1", Minor:"18", GitVersion:"v1.18.2", GitCommit:"52c56ce7a8272c798dbc29846288d7cd9fbae032", GitTreeState:"clean"}`,
+			expected:    "v1.18.2",
+		},
+		{
+			description: "recent kubectl",
+			output:      "Client Version: v1.28.3\nKustomize Version: v5.0.4-0.20230601165947-6ce0bf390ce3",
+			expected:    "v1.28.3",
+		},
+		{
+			description: "helm 3",
+			output:      "v3.2.1+gfe51cd1\n",
+			expected:    "v3.2.1+gfe51cd1",
+		},
+		{
+			description: "helm 2",
+			output:      "Client: v2.16.1+gbbdfe5e\n",
+			expected:    "v2.16.1+gbbdfe5e",
+		},
+		{
+			description: "kustomize 3",
+			output:      "{Version:kustomize/v3.5.4 GitCommit:3af514fa9f85430f0c1557c4a0291e62112ab026 BuildDate:2020-01-11T03:12:59Z GoOs:linux GoArch:amd64}",
+			expected:    "v3.5.4",
+		},
+		{
+			description: "no version",
+			output:      "command not found",
+			expected:    "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			testutil.CheckDeepEqual(t, test.expected, parseToolVersion(test.output))
+		})
+	}
+}
+
+func TestUseConfiguredTools(t *testing.T) {
+	cfg, delete := writeSkaffoldConfig(t, `tools:
+  helm:
+    path: /opt/helm-3.2/helm
+`)
+	defer delete()
+	defer util.SetToolPath("helm", "")
+
+	useConfiguredTools(cfg)
+
+	testutil.CheckDeepEqual(t, "kubectl", util.ToolPath("kubectl"))
+	testutil.CheckDeepEqual(t, "/opt/helm-3.2/helm", util.ToolPath("helm"))
+}
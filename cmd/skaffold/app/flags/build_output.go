@@ -23,6 +23,7 @@ import (
 	"os"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/docker"
 	"github.com/pkg/errors"
 )
 
@@ -30,6 +31,7 @@ import (
 type BuildOutputFileFlag struct {
 	filename    string
 	buildOutput BuildOutput
+	pinDigests  bool
 }
 
 // BuildOutput is the output of `skaffold build`.
@@ -37,6 +39,29 @@ type BuildOutput struct {
 	Builds []build.Artifact `json:"builds"`
 }
 
+// BuildMetadataVersion is the version of the format of the files written
+// by `skaffold build --file-output`.
+const BuildMetadataVersion = "v1"
+
+// BuildMetadata is written by `skaffold build --file-output`. It extends BuildOutput,
+// so that `skaffold deploy --build-artifacts` can read it.
+type BuildMetadata struct {
+	Version string             `json:"version"`
+	Builds  []ArtifactMetadata `json:"builds"`
+}
+
+// ArtifactMetadata describes a built artifact and how it was built.
+// Artifacts found in the cache have no duration.
+type ArtifactMetadata struct {
+	ImageName  string `json:"imageName"`
+	Tag        string `json:"tag"`
+	Digest     string `json:"digest,omitempty"`
+	Builder    string `json:"builder"`
+	DurationMs int64  `json:"durationMs"`
+	Cached     bool   `json:"cached"`
+	GitCommit  string `json:"gitCommit,omitempty"`
+}
+
 func (t *BuildOutputFileFlag) String() string {
 	return t.filename
 }
@@ -55,7 +80,11 @@ func (t *BuildOutputFileFlag) Set(value string) error {
 	if err != nil {
 		return err
 	}
-	buildOutput, err := ParseBuildOutput(b)
+	parse := ParseBuildOutput
+	if t.pinDigests {
+		parse = pinBuildOutputDigests
+	}
+	buildOutput, err := parse(b)
 	if err != nil {
 		return errors.Wrap(err, "setting template flag")
 	}
@@ -81,6 +110,40 @@ func NewBuildOutputFileFlag(value string) *BuildOutputFileFlag {
 	}
 }
 
+// NewImagesFromFileFlag returns a new BuildOutputFile that deploys
+// the images by digest rather than by tag.
+func NewImagesFromFileFlag() *BuildOutputFileFlag {
+	return &BuildOutputFileFlag{
+		pinDigests: true,
+	}
+}
+
+// pinBuildOutputDigests parses either a BuildOutput or a BuildMetadata
+// and replaces the tags with digests wherever a digest is known.
+func pinBuildOutputDigests(b []byte) (*BuildOutput, error) {
+	metadata := &BuildMetadata{}
+	if err := json.Unmarshal(b, metadata); err != nil {
+		return nil, err
+	}
+
+	buildOutput := &BuildOutput{}
+	for _, a := range metadata.Builds {
+		parsed, err := docker.ParseReference(a.Tag)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing tag for %s", a.ImageName)
+		}
+		if parsed.Digest == "" {
+			parsed.Digest = a.Digest
+		}
+
+		buildOutput.Builds = append(buildOutput.Builds, build.Artifact{
+			ImageName: a.ImageName,
+			Tag:       pinDigest(parsed, a.Tag),
+		})
+	}
+	return buildOutput, nil
+}
+
 // ParseBuildOutput parses BuildOutput from bytes
 func ParseBuildOutput(b []byte) (*BuildOutput, error) {
 	buildOutput := &BuildOutput{}
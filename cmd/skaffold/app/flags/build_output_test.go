@@ -94,6 +94,78 @@ func TestBuildOutputSet(t *testing.T) {
 	}
 }
 
+func TestImagesFromFileSet(t *testing.T) {
+	dir, cleanUp := testutil.NewTempDir(t)
+	defer cleanUp()
+
+	var tests = []struct {
+		description         string
+		buildOutputBytes    string
+		shouldErr           bool
+		expectedBuildOutput BuildOutput
+	}{
+		{
+			description: "build metadata",
+			buildOutputBytes: `{
+"version": "v1",
+"builds": [{
+	"imageName": "gcr.io/k8s/test1",
+	"tag": "gcr.io/k8s/test1:v1",
+	"digest": "sha256:81daf011d63b68cfa514ddab7741a1adddd59d3264118dfb0fd9266328bb8883"
+	}, {
+	"imageName": "gcr.io/k8s/test2",
+	"tag": "gcr.io/k8s/test2:v1"
+  }]
+}`,
+			expectedBuildOutput: BuildOutput{
+				Builds: []build.Artifact{{
+					ImageName: "gcr.io/k8s/test1",
+					Tag:       "gcr.io/k8s/test1@sha256:81daf011d63b68cfa514ddab7741a1adddd59d3264118dfb0fd9266328bb8883",
+				}, {
+					ImageName: "gcr.io/k8s/test2",
+					Tag:       "gcr.io/k8s/test2:v1",
+				}},
+			},
+		},
+		{
+			description: "build output",
+			buildOutputBytes: `{
+"builds": [{
+	"imageName": "gcr.io/k8s/test1",
+	"tag": "gcr.io/k8s/test1:v1@sha256:81daf011d63b68cfa514ddab7741a1adddd59d3264118dfb0fd9266328bb8883"
+  }]
+}`,
+			expectedBuildOutput: BuildOutput{
+				Builds: []build.Artifact{{
+					ImageName: "gcr.io/k8s/test1",
+					Tag:       "gcr.io/k8s/test1@sha256:81daf011d63b68cfa514ddab7741a1adddd59d3264118dfb0fd9266328bb8883",
+				}},
+			},
+		},
+		{
+			description: "invalid tag",
+			buildOutputBytes: `{
+"builds": [{
+	"imageName": "gcr.io/k8s/test1",
+	"tag": "sha256@foo"
+  }]
+}`,
+			shouldErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			dir.Write("build.json", test.buildOutputBytes)
+
+			flag := NewImagesFromFileFlag()
+			err := flag.Set(dir.Path("build.json"))
+
+			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.expectedBuildOutput, flag.buildOutput)
+		})
+	}
+}
+
 func TestBuildOutputString(t *testing.T) {
 	flag := NewBuildOutputFileFlag("test.in")
 
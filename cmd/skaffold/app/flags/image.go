@@ -83,16 +83,41 @@ func NewEmptyImages(usage string) *Images {
 	}
 }
 
+// convertImageToArtifact converts an image reference, optionally prefixed with
+// the name of the artifact it was built from: `name=gcr.io/project/image@sha256:...`.
 func convertImageToArtifact(value string) (*build.Artifact, error) {
 	if value == "" {
 		return nil, errors.New("cannot add an empty image value")
 	}
-	parsed, err := docker.ParseReference(value)
+
+	imageName := ""
+	ref := value
+	if index := strings.Index(value, "="); index != -1 {
+		imageName, ref = value[:index], value[index+1:]
+		if imageName == "" || ref == "" {
+			return nil, fmt.Errorf("invalid image %q, expected name=reference", value)
+		}
+	}
+
+	parsed, err := docker.ParseReference(ref)
 	if err != nil {
 		return nil, err
 	}
+	if imageName == "" {
+		imageName = parsed.BaseName
+	}
+
 	return &build.Artifact{
-		ImageName: parsed.BaseName,
-		Tag:       value,
+		ImageName: imageName,
+		Tag:       pinDigest(parsed, ref),
 	}, nil
 }
+
+// pinDigest drops the tag of image references that have a digest,
+// so that the exact image that was built is deployed.
+func pinDigest(parsed *docker.ImageReference, ref string) string {
+	if parsed.Digest == "" {
+		return ref
+	}
+	return parsed.BaseName + "@" + parsed.Digest
+}
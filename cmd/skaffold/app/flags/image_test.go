@@ -62,6 +62,32 @@ func TestImagesFlagSet(t *testing.T) {
 				Tag:       "gcr.io/test/test-image@sha256:81daf011d63b68cfa514ddab7741a1adddd59d3264118dfb0fd9266328bb8883",
 			},
 		},
+		{
+			description: "set with tag and digest pins the digest",
+			setValue:    "gcr.io/test/test-image:test@sha256:81daf011d63b68cfa514ddab7741a1adddd59d3264118dfb0fd9266328bb8883",
+			expectedArtifact: build.Artifact{
+				ImageName: "gcr.io/test/test-image",
+				Tag:       "gcr.io/test/test-image@sha256:81daf011d63b68cfa514ddab7741a1adddd59d3264118dfb0fd9266328bb8883",
+			},
+		},
+		{
+			description: "set with image name",
+			setValue:    "test-image=gcr.io/test/promoted@sha256:81daf011d63b68cfa514ddab7741a1adddd59d3264118dfb0fd9266328bb8883",
+			expectedArtifact: build.Artifact{
+				ImageName: "test-image",
+				Tag:       "gcr.io/test/promoted@sha256:81daf011d63b68cfa514ddab7741a1adddd59d3264118dfb0fd9266328bb8883",
+			},
+		},
+		{
+			description: "set errors with empty image name",
+			setValue:    "=gcr.io/test/test-image",
+			shouldErr:   true,
+		},
+		{
+			description: "set errors with empty reference",
+			setValue:    "test-image=",
+			shouldErr:   true,
+		},
 		{
 			description: "set with docker name",
 			setValue:    "docker-image-value",
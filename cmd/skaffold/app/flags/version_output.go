@@ -0,0 +1,68 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flags
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/version"
+)
+
+// VersionOutput is the output of `skaffold version`.
+type VersionOutput struct {
+	version.Info
+
+	// SchemaVersions are the versions of skaffold.yaml that can be read,
+	// from the oldest to the latest.
+	SchemaVersions []string
+
+	toolVersions func() map[string]string
+	toolsOnce    sync.Once
+	tools        map[string]string
+}
+
+// NewVersionOutput creates the output of `skaffold version`.
+// toolVersions is called at most once, when the versions of the tools are first accessed.
+func NewVersionOutput(info version.Info, schemaVersions []string, toolVersions func() map[string]string) *VersionOutput {
+	return &VersionOutput{
+		Info:           info,
+		SchemaVersions: schemaVersions,
+		toolVersions:   toolVersions,
+	}
+}
+
+// Tools are the versions of the external tools, like kubectl, helm or kustomize,
+// found on the PATH or configured in the `tools` section of skaffold.yaml.
+// Running the tools is slow, so they're only run when the output refers to them.
+func (o *VersionOutput) Tools() map[string]string {
+	o.toolsOnce.Do(func() {
+		if o.toolVersions != nil {
+			o.tools = o.toolVersions()
+		}
+	})
+	return o.tools
+}
+
+// MarshalJSON adds the versions of the tools to the fields of the output.
+func (o *VersionOutput) MarshalJSON() ([]byte, error) {
+	type fields VersionOutput
+	return json.Marshal(struct {
+		*fields
+		Tools map[string]string
+	}{(*fields)(o), o.Tools()})
+}
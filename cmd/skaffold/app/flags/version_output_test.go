@@ -0,0 +1,68 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flags
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/version"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestVersionOutputTools(t *testing.T) {
+	var tests = []struct {
+		description   string
+		template      string
+		expected      string
+		expectedCalls int
+	}{
+		{
+			description:   "tools are not run",
+			template:      "{{.Version}}",
+			expected:      "v1.0.0",
+			expectedCalls: 0,
+		},
+		{
+			description:   "tools",
+			template:      "{{.Tools.helm}} {{.Tools.kubectl}}",
+			expected:      "v3.2.1 v1.18.2",
+			expectedCalls: 1,
+		},
+		{
+			description:   "json",
+			template:      "{{json .}}",
+			expected:      `{"Version":"v1.0.0","ConfigVersion":"","GitVersion":"","GitCommit":"","GitTreeState":"","BuildDate":"","GoVersion":"","Compiler":"","Platform":"","SchemaVersions":["skaffold/v1beta12"],"Tools":{"helm":"v3.2.1","kubectl":"v1.18.2"}}`,
+			expectedCalls: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			calls := 0
+			output := NewVersionOutput(version.Info{Version: "v1.0.0"}, []string{"skaffold/v1beta12"}, func() map[string]string {
+				calls++
+				return map[string]string{"helm": "v3.2.1", "kubectl": "v1.18.2"}
+			})
+
+			var buf bytes.Buffer
+			err := NewTemplateFlag(test.template, VersionOutput{}).Template().Execute(&buf, output)
+
+			testutil.CheckErrorAndDeepEqual(t, false, err, test.expected, buf.String())
+			testutil.CheckDeepEqual(t, test.expectedCalls, calls)
+		})
+	}
+}
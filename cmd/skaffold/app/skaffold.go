@@ -20,9 +20,13 @@ import (
 	"io"
 
 	"github.com/GoogleContainerTools/skaffold/cmd/skaffold/app/cmd"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/redact"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/trace"
 )
 
 func Run(out, stderr io.Writer) error {
-	c := cmd.NewSkaffoldCommand(out, stderr)
+	defer trace.Shutdown()
+
+	c := cmd.NewSkaffoldCommand(redact.NewWriter(out), redact.NewWriter(stderr))
 	return c.Execute()
 }
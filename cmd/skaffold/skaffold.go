@@ -24,6 +24,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/GoogleContainerTools/skaffold/cmd/skaffold/app"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/errcode"
 )
 
 func main() {
@@ -31,6 +32,9 @@ func main() {
 		if errors.Cause(err) == context.Canceled {
 			logrus.Debugln(errors.Wrap(err, "ignore error since context is cancelled"))
 		} else {
+			// The exit code tells CI pipelines what kind of failure it is.
+			code := errcode.ExitCode(errcode.CodeOf(err))
+			logrus.StandardLogger().ExitFunc = func(int) { os.Exit(code) }
 			logrus.Fatal(err)
 		}
 	}
@@ -0,0 +1,133 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/docker"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// For testing
+	baseImagesRefreshPeriod = 30 * time.Minute
+
+	// baseImageDigests keeps the resolved digest of each base image between dev iterations.
+	baseImageDigests = &digestCache{entries: map[string]digestEntry{}}
+)
+
+// digestEntry is the digest a base image resolved to, at a given time.
+type digestEntry struct {
+	digest     string
+	resolvedAt time.Time
+}
+
+// digestCache caches the digest of base images so that floating tags
+// are only resolved once per refresh period.
+type digestCache struct {
+	sync.Mutex
+	entries map[string]digestEntry
+}
+
+func (c *digestCache) get(image string) (digestEntry, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	e, found := c.entries[image]
+	return e, found
+}
+
+func (c *digestCache) put(image, digest string) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.entries[image] = digestEntry{
+		digest:     digest,
+		resolvedAt: now(),
+	}
+}
+
+// artifactHash is the key of an artifact in the cache: the hash of its sources and,
+// for artifacts built from a Dockerfile, the digests of their base images.
+// That way, an update of a base image invalidates the cache.
+func (c *Cache) artifactHash(ctx context.Context, a *latest.Artifact) (string, error) {
+	hash, err := hashForArtifact(ctx, c.builder, a)
+	if err != nil || c.offline {
+		return hash, err
+	}
+
+	images, err := baseImages(a)
+	if err != nil {
+		return "", errors.Wrapf(err, "listing base images for %s", a.ImageName)
+	}
+
+	digests := c.resolveBaseImages(images)
+	if len(digests) == 0 {
+		return hash, nil
+	}
+	return util.SHA256(strings.NewReader(hash + "\n" + strings.Join(digests, "\n")))
+}
+
+// baseImages lists the base images of artifacts built from a Dockerfile.
+func baseImages(a *latest.Artifact) ([]string, error) {
+	switch {
+	case a.DockerArtifact != nil:
+		return docker.BaseImages(a.Workspace, a.DockerArtifact.DockerfilePath, a.DockerArtifact.BuildArgs, a.DockerArtifact.Target)
+	case a.KanikoArtifact != nil:
+		return docker.BaseImages(a.Workspace, a.KanikoArtifact.DockerfilePath, a.KanikoArtifact.BuildArgs, a.KanikoArtifact.Target)
+	default:
+		return nil, nil
+	}
+}
+
+// resolveBaseImages returns `image@digest` for each base image that's not already pinned to a digest.
+// Digests are resolved again after `baseImagesRefreshPeriod`. When an image can't be resolved,
+// its last known digest is used, if any.
+func (c *Cache) resolveBaseImages(images []string) []string {
+	var digests []string
+
+	for _, image := range images {
+		if strings.Contains(image, "@") {
+			// The digest is part of the Dockerfile, that's already hashed.
+			continue
+		}
+
+		e, found := baseImageDigests.get(image)
+		if !found || now().Sub(e.resolvedAt) >= baseImagesRefreshPeriod {
+			digest, err := remoteDigest(image, c.insecureRegistries)
+			if err != nil {
+				logrus.Debugf("Unable to resolve the digest of base image %s: %v", image, err)
+			} else {
+				baseImageDigests.put(image, digest)
+				e, found = digestEntry{digest: digest}, true
+			}
+		}
+
+		if found {
+			digests = append(digests, image+"@"+e.digest)
+		}
+	}
+
+	return digests
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestArtifactHashWithBaseImages(t *testing.T) {
+	tmpDir, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+	tmpDir.Write("Dockerfile", "FROM golang:1.12@sha256:123 as builder\nFROM alpine\nCOPY --from=builder /app /app")
+
+	clock := time.Now()
+	digest := "sha256:abc"
+	var resolved []string
+	resetNow := testutil.Override(t, &now, func() time.Time { return clock })
+	defer resetNow()
+	resetHash := testutil.Override(t, &hashForArtifact, mockHashForArtifact(map[string]string{"image": "hash"}))
+	defer resetHash()
+	resetDigests := testutil.Override(t, &baseImageDigests, &digestCache{entries: map[string]digestEntry{}})
+	defer resetDigests()
+	resetDigest := testutil.Override(t, &remoteDigest, func(image string, _ map[string]bool) (string, error) {
+		resolved = append(resolved, image)
+		if digest == "" {
+			return "", errors.New("unreachable")
+		}
+		return digest, nil
+	})
+	defer resetDigest()
+
+	artifact := &latest.Artifact{
+		ImageName: "image",
+		Workspace: tmpDir.Root(),
+		ArtifactType: latest.ArtifactType{
+			DockerArtifact: &latest.DockerArtifact{DockerfilePath: "Dockerfile"},
+		},
+	}
+	cache := &Cache{}
+
+	// Pinned images and stages are not resolved.
+	first, err := cache.artifactHash(context.Background(), artifact)
+	testutil.CheckError(t, false, err)
+	testutil.CheckDeepEqual(t, []string{"alpine"}, resolved)
+	if first == "hash" {
+		t.Errorf("expected base image digest to change the hash")
+	}
+
+	// The digest is reused during the refresh period.
+	digest = "sha256:def"
+	clock = clock.Add(time.Minute)
+	same, err := cache.artifactHash(context.Background(), artifact)
+	testutil.CheckErrorAndDeepEqual(t, false, err, first, same)
+	testutil.CheckDeepEqual(t, []string{"alpine"}, resolved)
+
+	// After the refresh period, the tag is resolved again.
+	clock = clock.Add(baseImagesRefreshPeriod)
+	updated, err := cache.artifactHash(context.Background(), artifact)
+	testutil.CheckError(t, false, err)
+	if updated == first {
+		t.Errorf("expected an update of the base image to change the hash")
+	}
+
+	// The last known digest is used when the image can't be resolved.
+	digest = ""
+	clock = clock.Add(baseImagesRefreshPeriod)
+	unreachable, err := cache.artifactHash(context.Background(), artifact)
+	testutil.CheckErrorAndDeepEqual(t, false, err, updated, unreachable)
+
+	// Offline, base images are not resolved.
+	offline, err := (&Cache{offline: true}).artifactHash(context.Background(), artifact)
+	testutil.CheckErrorAndDeepEqual(t, false, err, "hash", offline)
+	testutil.CheckDeepEqual(t, 3, len(resolved))
+}
@@ -20,11 +20,13 @@ import (
 	"context"
 	"io/ioutil"
 	"path/filepath"
+	"time"
 
 	"github.com/GoogleContainerTools/skaffold/cmd/skaffold/app/cmd/config"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/docker"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/localcluster"
 	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
 	"github.com/docker/docker/api/types"
@@ -49,15 +51,22 @@ type Cache struct {
 	isLocalBuilder     bool
 	pushImages         bool
 	localCluster       bool
+	imageLoader        localcluster.Loader
 	prune              bool
+	offline            bool
+	remoteCache        bool
+	maxEntries         int
 }
 
 var (
 	// For testing
-	localCluster    = config.GetLocalCluster
-	remoteDigest    = docker.RemoteDigest
-	newDockerClient = docker.NewAPIClient
-	noCache         = &Cache{}
+	localCluster        = config.GetLocalCluster
+	cacheFileFromConfig = config.GetCacheFile
+	cacheMaxEntries     = config.GetCacheMaxEntries
+	now                 = time.Now
+	remoteDigest        = docker.RemoteDigest
+	newDockerClient     = docker.NewAPIClient
+	noCache             = &Cache{}
 )
 
 // NewCache returns the current state of the cache
@@ -87,10 +96,20 @@ func NewCache(builder build.Builder, runCtx *runcontext.RunContext) *Cache {
 		}
 	}
 
+	maxEntries, err := cacheMaxEntries()
+	if err != nil {
+		logrus.Warnf("Unable to read the maximum number of cache entries, the cache won't be trimmed: %v", err)
+	}
+
 	lc, err := localCluster()
 	if err != nil {
 		logrus.Warn("Unable to determine if using a local cluster, cache may not work.")
 	}
+	var imageLoader localcluster.Loader
+	if runCtx.Cfg.Build.LocalBuild != nil && client != nil {
+		// Images built locally for kind, k3d, microk8s, or minikube without its docker daemon, have to be loaded into the cluster.
+		imageLoader, _ = localcluster.NewLoader(runCtx.KubeContext, docker.UsesMinikubeDaemon(client))
+	}
 	pushImages := runCtx.Cfg.Build.LocalBuild != nil && runCtx.Cfg.Build.LocalBuild.Push != nil && *runCtx.Cfg.Build.LocalBuild.Push
 	return &Cache{
 		artifactCache:      cache,
@@ -102,15 +121,25 @@ func NewCache(builder build.Builder, runCtx *runcontext.RunContext) *Cache {
 		isLocalBuilder:     runCtx.Cfg.Build.LocalBuild != nil,
 		imageList:          imageList,
 		localCluster:       lc,
+		imageLoader:        imageLoader,
 		prune:              runCtx.Opts.Prune(),
 		insecureRegistries: runCtx.InsecureRegistries,
+		offline:            runCtx.Opts.Offline,
+		remoteCache:        runCtx.Opts.RemoteCache,
+		maxEntries:         maxEntries,
 	}
 }
 
-// resolveCacheFile makes sure that either a passed in cache file or the default cache file exists
-func resolveCacheFile(cacheFile string) (string, error) {
-	if cacheFile != "" {
-		return cacheFile, util.VerifyOrCreateFile(cacheFile)
+// resolveCacheFile makes sure that either a passed in cache file, the one set in the
+// global config or the default cache file exists
+func resolveCacheFile(cliValue string) (string, error) {
+	cf, err := cacheFileFromConfig(cliValue)
+	if err != nil {
+		logrus.Warnf("Unable to read the cache file location from the global config: %v", err)
+		cf = cliValue
+	}
+	if cf != "" {
+		return cf, util.VerifyOrCreateFile(cf)
 	}
 	home, err := homedir.Dir()
 	if err != nil {
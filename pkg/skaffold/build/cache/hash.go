@@ -25,6 +25,8 @@ import (
 	"io"
 	"os"
 	"sort"
+	"sync"
+	"time"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
@@ -32,11 +34,65 @@ import (
 	"github.com/pkg/errors"
 )
 
+// Files modified more recently than this are always rehashed because
+// a change within the same mtime tick wouldn't be noticed.
+const racyModificationWindow = 2 * time.Second
+
 var (
 	// For testing
 	hashFunction = cacheHasher
+
+	// fileHashes keeps the hash of each dependency between dev iterations.
+	fileHashes = &fileHashCache{entries: map[string]fileHashEntry{}}
 )
 
+// fileHashEntry is the hash of a file, valid as long as the file's
+// mode, size and modification time don't change.
+type fileHashEntry struct {
+	mode    os.FileMode
+	size    int64
+	modTime time.Time
+	hash    string
+}
+
+// fileHashCache caches the hash of files to only rehash the ones that changed.
+type fileHashCache struct {
+	sync.Mutex
+	entries map[string]fileHashEntry
+}
+
+func (c *fileHashCache) get(p string, fi os.FileInfo) (string, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	e, found := c.entries[p]
+	if !found || e.mode != fi.Mode() || e.size != fi.Size() || !e.modTime.Equal(fi.ModTime()) {
+		return "", false
+	}
+	return e.hash, true
+}
+
+func (c *fileHashCache) put(p string, fi os.FileInfo, hash string) {
+	if time.Since(fi.ModTime()) < racyModificationWindow {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	c.entries[p] = fileHashEntry{
+		mode:    fi.Mode(),
+		size:    fi.Size(),
+		modTime: fi.ModTime(),
+		hash:    hash,
+	}
+}
+
+// HashArtifact returns a hash of the dependencies of an artifact, that changes when its sources change.
+func HashArtifact(ctx context.Context, builder build.Builder, a *latest.Artifact) (string, error) {
+	return getHashForArtifact(ctx, builder, a)
+}
+
 func getHashForArtifact(ctx context.Context, builder build.Builder, a *latest.Artifact) (string, error) {
 	deps, err := builder.DependenciesForArtifact(ctx, a)
 	if err != nil {
@@ -51,6 +107,13 @@ func getHashForArtifact(ctx context.Context, builder build.Builder, a *latest.Ar
 		}
 		hashes = append(hashes, h)
 	}
+	if a != nil && a.CacheSalt != "" {
+		salt, err := evaluateSalt(a.CacheSalt)
+		if err != nil {
+			return "", errors.Wrapf(err, "evaluating cache salt for %s", a.ImageName)
+		}
+		hashes = append(hashes, "salt:"+salt)
+	}
 	// get a key for the hashes
 	c := bytes.NewBuffer([]byte{})
 	enc := json.NewEncoder(c)
@@ -58,13 +121,40 @@ func getHashForArtifact(ctx context.Context, builder build.Builder, a *latest.Ar
 	return util.SHA256(c)
 }
 
-// cacheHasher takes hashes the contents and name of a file
+func evaluateSalt(salt string) (string, error) {
+	tmpl, err := util.ParseEnvTemplate(salt)
+	if err != nil {
+		return "", err
+	}
+	return util.ExecuteEnvTemplate(tmpl, nil)
+}
+
+// cachingDisabled returns true if the artifact opted out of the cache.
+func cachingDisabled(a *latest.Artifact) bool {
+	return a.Cache != nil && !*a.Cache
+}
+
+// cacheHasher takes hashes the contents and name of a file.
+// Files that didn't change since they were last hashed are not read again.
 func cacheHasher(p string) (string, error) {
-	h := md5.New()
 	fi, err := os.Lstat(p)
 	if err != nil {
 		return "", err
 	}
+	if hash, found := fileHashes.get(p, fi); found {
+		return hash, nil
+	}
+
+	hash, err := hashFile(p, fi)
+	if err != nil {
+		return "", err
+	}
+	fileHashes.put(p, fi, hash)
+	return hash, nil
+}
+
+func hashFile(p string, fi os.FileInfo) (string, error) {
+	h := md5.New()
 	h.Write([]byte(fi.Mode().String()))
 	h.Write([]byte(fi.Name()))
 	if fi.Mode().IsRegular() {
@@ -19,7 +19,9 @@ package cache
 import (
 	"context"
 	"io"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/tag"
@@ -78,6 +80,30 @@ func TestGetHashForArtifact(t *testing.T) {
 		})
 	}
 }
+
+func TestGetHashForArtifactWithSalt(t *testing.T) {
+	reset := testutil.Override(t, &hashFunction, mockCacheHasher)
+	defer reset()
+	unsetEnvs := testutil.SetEnvs(t, map[string]string{"BASE_DIGEST": "sha256:123"})
+	defer unsetEnvs()
+
+	builder := &mockBuilder{dependencies: []string{"a", "b"}}
+	unsalted, err := getHashForArtifact(context.Background(), builder, &latest.Artifact{ImageName: "image"})
+	testutil.CheckErrorAndDeepEqual(t, false, err, "eb394fd4559b1d9c383f4359667a508a615b82a74e1b160fce539f86ae0842e8", unsalted)
+
+	salted, err := getHashForArtifact(context.Background(), builder, &latest.Artifact{ImageName: "image", CacheSalt: "{{.BASE_DIGEST}}"})
+	testutil.CheckError(t, false, err)
+	if salted == unsalted {
+		t.Errorf("expected the salt to change the hash")
+	}
+
+	same, err := getHashForArtifact(context.Background(), builder, &latest.Artifact{ImageName: "image", CacheSalt: "sha256:123"})
+	testutil.CheckErrorAndDeepEqual(t, false, err, salted, same)
+
+	_, err = getHashForArtifact(context.Background(), builder, &latest.Artifact{ImageName: "image", CacheSalt: "{{"})
+	testutil.CheckError(t, true, err)
+}
+
 func TestCacheHasher(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -153,3 +179,48 @@ func TestCacheHasher(t *testing.T) {
 		})
 	}
 }
+
+func TestCacheHasherOnlyRehashesChangedFiles(t *testing.T) {
+	folder, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+	reset := testutil.Override(t, &fileHashes, &fileHashCache{entries: map[string]fileHashEntry{}})
+	defer reset()
+
+	past := time.Now().Add(-time.Hour)
+	write := func(contents string, modTime time.Time) {
+		folder.Write("foo", contents)
+		if err := os.Chtimes(folder.Path("foo"), modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("contents", past)
+	original, err := cacheHasher(folder.Path("foo"))
+	testutil.CheckError(t, false, err)
+
+	// Same size and modification time: the cached hash is used.
+	write("CONTENTS", past)
+	cached, err := cacheHasher(folder.Path("foo"))
+	testutil.CheckErrorAndDeepEqual(t, false, err, original, cached)
+
+	// Different modification time: the file is rehashed.
+	write("CONTENTS", past.Add(time.Minute))
+	rehashed, err := cacheHasher(folder.Path("foo"))
+	testutil.CheckError(t, false, err)
+	if rehashed == original {
+		t.Errorf("expected file to be rehashed after a change")
+	}
+}
+
+func TestCacheHasherIgnoresRecentlyModifiedFiles(t *testing.T) {
+	folder, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+	reset := testutil.Override(t, &fileHashes, &fileHashCache{entries: map[string]fileHashEntry{}})
+	defer reset()
+
+	folder.Write("foo", "contents")
+	_, err := cacheHasher(folder.Path("foo"))
+	testutil.CheckError(t, false, err)
+
+	testutil.CheckDeepEqual(t, 0, len(fileHashes.entries))
+}
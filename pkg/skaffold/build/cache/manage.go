@@ -0,0 +1,178 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/docker/docker/api/types"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// cacheEntry is an entry of the artifact cache, with its hash.
+type cacheEntry struct {
+	hash string
+	ImageDetails
+}
+
+// sortedEntries returns the entries of the cache, most recently used first.
+// Entries without a last use time, written by older versions of Skaffold, come last.
+func (c ArtifactCache) sortedEntries() []cacheEntry {
+	var entries []cacheEntry
+	for hash, details := range c {
+		entries = append(entries, cacheEntry{hash: hash, ImageDetails: details})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].LastUsed.Equal(entries[j].LastUsed) {
+			return entries[i].LastUsed.After(entries[j].LastUsed)
+		}
+		return entries[i].hash < entries[j].hash
+	})
+	return entries
+}
+
+// trim removes the least recently used entries, so that at most maxEntries are kept.
+// Zero means no limit.
+func (c ArtifactCache) trim(maxEntries int) {
+	if maxEntries <= 0 || len(c) <= maxEntries {
+		return
+	}
+
+	for _, e := range c.sortedEntries()[maxEntries:] {
+		logrus.Debugf("Removing cache entry %s for %s, the cache has more than %d entries", e.hash, e.Image, maxEntries)
+		delete(c, e.hash)
+	}
+}
+
+// List prints the entries of the artifact cache, most recently used first.
+func List(out io.Writer, cacheFile string) error {
+	cache, _, err := readCache(cacheFile)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "HASH\tIMAGE\tID\tDIGEST\tLAST USED")
+	for _, e := range cache.sortedEntries() {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", shorten(e.hash), orNone(e.Image), orNone(shorten(e.ID)), orNone(shorten(e.Digest)), lastUsed(e.LastUsed))
+	}
+	return w.Flush()
+}
+
+// Prune removes the entries of the artifact cache that weren't used for longer than the
+// given duration, along with their images from the local Docker daemon.
+// Entries whose image is gone from the local Docker daemon and that weren't pushed are removed too.
+func Prune(ctx context.Context, out io.Writer, cacheFile string, olderThan time.Duration) error {
+	cache, cf, err := readCache(cacheFile)
+	if err != nil {
+		return err
+	}
+
+	client, err := newDockerClient(false, nil)
+	if err != nil {
+		logrus.Warnf("Unable to connect to the local Docker daemon, only the unused entries will be removed: %v", err)
+	}
+	localImages := map[string]bool{}
+	if client != nil {
+		images, err := client.ImageList(ctx, types.ImageListOptions{})
+		if err != nil {
+			return errors.Wrap(err, "listing local images")
+		}
+		for _, image := range images {
+			localImages[image.ID] = true
+		}
+	}
+
+	total := len(cache)
+	for _, e := range cache.sortedEntries() {
+		switch {
+		case !e.LastUsed.IsZero() && now().Sub(e.LastUsed) > olderThan:
+			if client != nil && e.ID != "" && localImages[e.ID] {
+				if _, err := client.ImageRemove(ctx, e.ID, types.ImageRemoveOptions{PruneChildren: true}); err != nil {
+					logrus.Warnf("Unable to remove image %s of %s: %v", e.ID, e.Image, err)
+				}
+			}
+			color.Default.Fprintf(out, " - %s (%s): not used since %s\n", shorten(e.hash), orNone(e.Image), lastUsed(e.LastUsed))
+		case client != nil && e.ID != "" && e.Digest == "" && !localImages[e.ID]:
+			color.Default.Fprintf(out, " - %s (%s): image not found\n", shorten(e.hash), orNone(e.Image))
+		default:
+			continue
+		}
+		delete(cache, e.hash)
+	}
+
+	color.Default.Fprintf(out, "Removed %d of %d cache entries\n", total-len(cache), total)
+	return saveArtifactCache(cf, cache)
+}
+
+// Clear removes all the entries of the artifact cache. Images are left untouched.
+func Clear(out io.Writer, cacheFile string) error {
+	cache, cf, err := readCache(cacheFile)
+	if err != nil {
+		return err
+	}
+
+	color.Default.Fprintf(out, "Removed %d cache entries\n", len(cache))
+	return saveArtifactCache(cf, ArtifactCache{})
+}
+
+func readCache(cliValue string) (ArtifactCache, string, error) {
+	cf, err := resolveCacheFile(cliValue)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "resolving cache file")
+	}
+
+	cache, err := retrieveArtifactCache(cf)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "reading cache file %s", cf)
+	}
+	return cache, cf, nil
+}
+
+// shorten truncates hashes, ids and digests like `docker images` does.
+func shorten(s string) string {
+	if i := strings.Index(s, ":"); i != -1 {
+		s = s[i+1:]
+	}
+	if len(s) > 12 {
+		return s[:12]
+	}
+	return s
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
+
+func lastUsed(t time.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	return t.Local().Format(time.RFC3339)
+}
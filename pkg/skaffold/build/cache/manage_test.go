@@ -0,0 +1,149 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/docker"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	"github.com/docker/docker/api/types"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var (
+	fakeNow = time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	managedArtifactCache = ArtifactCache{
+		"recent": ImageDetails{ID: "sha256:recent", Image: "web", LastUsed: fakeNow.Add(-1 * time.Hour)},
+		"gone":   ImageDetails{ID: "sha256:gone", Image: "web", LastUsed: fakeNow.Add(-2 * time.Hour)},
+		"pushed": ImageDetails{ID: "sha256:pushed", Digest: "sha256:digest", Image: "app", LastUsed: fakeNow.Add(-3 * time.Hour)},
+		"old":    ImageDetails{ID: "sha256:old", Image: "app", LastUsed: fakeNow.Add(-60 * 24 * time.Hour)},
+		"legacy": ImageDetails{ID: "sha256:legacy"},
+	}
+)
+
+func TestTrim(t *testing.T) {
+	tests := []struct {
+		description string
+		maxEntries  int
+		expected    []string
+	}{
+		{
+			description: "no limit",
+			expected:    []string{"gone", "legacy", "old", "pushed", "recent"},
+		},
+		{
+			description: "keep most recently used",
+			maxEntries:  2,
+			expected:    []string{"gone", "recent"},
+		},
+		{
+			description: "under the limit",
+			maxEntries:  10,
+			expected:    []string{"gone", "legacy", "old", "pushed", "recent"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			cache := ArtifactCache{}
+			for hash, details := range managedArtifactCache {
+				cache[hash] = details
+			}
+
+			cache.trim(test.maxEntries)
+
+			testutil.CheckDeepEqual(t, test.expected, hashes(cache))
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	cacheFile, cleanup := createTempCacheFile(t, managedArtifactCache)
+	defer cleanup()
+
+	var out bytes.Buffer
+	err := List(&out, cacheFile)
+
+	testutil.CheckErrorAndDeepEqual(t, false, err, `HASH     IMAGE    ID       DIGEST   LAST USED
+recent   web      recent   <none>   `+lastUsed(fakeNow.Add(-1*time.Hour))+`
+gone     web      gone     <none>   `+lastUsed(fakeNow.Add(-2*time.Hour))+`
+pushed   app      pushed   digest   `+lastUsed(fakeNow.Add(-3*time.Hour))+`
+old      app      old      <none>   `+lastUsed(fakeNow.Add(-60*24*time.Hour))+`
+legacy   <none>   legacy   <none>   <unknown>
+`, out.String())
+}
+
+func TestPrune(t *testing.T) {
+	cacheFile, cleanup := createTempCacheFile(t, managedArtifactCache)
+	defer cleanup()
+
+	api := &testutil.FakeAPIClient{
+		ImageSummaries: []types.ImageSummary{{ID: "sha256:recent"}, {ID: "sha256:old"}, {ID: "sha256:legacy"}},
+	}
+	reset := testutil.Override(t, &newDockerClient, func(bool, map[string]bool) (docker.LocalDaemon, error) {
+		return docker.NewLocalDaemon(api, nil, false, nil), nil
+	})
+	defer reset()
+	resetNow := testutil.Override(t, &now, func() time.Time { return fakeNow })
+	defer resetNow()
+
+	var out bytes.Buffer
+	err := Prune(context.Background(), &out, cacheFile, 30*24*time.Hour)
+
+	testutil.CheckErrorAndDeepEqual(t, false, err, ` - gone (web): image not found
+ - old (app): not used since `+lastUsed(fakeNow.Add(-60*24*time.Hour))+`
+Removed 2 of 5 cache entries
+`, out.String())
+	testutil.CheckDeepEqual(t, []string{"sha256:old"}, api.Removed)
+	testutil.CheckDeepEqual(t, []string{"legacy", "pushed", "recent"}, hashes(readCacheFile(t, cacheFile)))
+}
+
+func TestClear(t *testing.T) {
+	cacheFile, cleanup := createTempCacheFile(t, managedArtifactCache)
+	defer cleanup()
+
+	var out bytes.Buffer
+	err := Clear(&out, cacheFile)
+
+	testutil.CheckErrorAndDeepEqual(t, false, err, "Removed 5 cache entries\n", out.String())
+	testutil.CheckDeepEqual(t, 0, len(readCacheFile(t, cacheFile)))
+}
+
+func readCacheFile(t *testing.T, cacheFile string) ArtifactCache {
+	contents, err := ioutil.ReadFile(cacheFile)
+	testutil.CheckError(t, false, err)
+
+	cache := ArtifactCache{}
+	err = yaml.Unmarshal(contents, &cache)
+	testutil.CheckError(t, false, err)
+	return cache
+}
+
+func hashes(cache ArtifactCache) []string {
+	var hashes []string
+	for _, e := range cache.sortedEntries() {
+		hashes = append(hashes, e.hash)
+	}
+	sort.Strings(hashes)
+	return hashes
+}
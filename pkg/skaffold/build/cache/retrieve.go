@@ -40,6 +40,12 @@ var (
 type ImageDetails struct {
 	Digest string `yaml:"digest,omitempty"`
 	ID     string `yaml:"id,omitempty"`
+
+	// Image is the name of the artifact's image, without tag.
+	Image string `yaml:"image,omitempty"`
+
+	// LastUsed is the last time the entry was built or found in the cache.
+	LastUsed time.Time `yaml:"lastUsed,omitempty"`
 }
 
 type detailsErr struct {
@@ -59,6 +65,9 @@ func (c *Cache) RetrieveCachedArtifacts(ctx context.Context, out io.Writer, arti
 	detailsErrs := make([]chan detailsErr, len(artifacts))
 
 	for i := range artifacts {
+		if cachingDisabled(artifacts[i]) {
+			continue
+		}
 		detailsErrs[i] = make(chan detailsErr, 1)
 
 		i := i
@@ -76,6 +85,12 @@ func (c *Cache) RetrieveCachedArtifacts(ctx context.Context, out io.Writer, arti
 	for i, artifact := range artifacts {
 		color.Default.Fprintf(out, " - %s: ", artifact.ImageName)
 
+		if detailsErrs[i] == nil {
+			color.Yellow.Fprintln(out, "Caching disabled. Rebuilding.")
+			needToBuild = append(needToBuild, artifact)
+			continue
+		}
+
 		select {
 		case <-ctx.Done():
 			return nil, nil, context.Canceled
@@ -90,12 +105,18 @@ func (c *Cache) RetrieveCachedArtifacts(ctx context.Context, out io.Writer, arti
 			}
 
 			color.Green.Fprint(out, "Found")
+			if details.foundRemotely {
+				color.Green.Fprint(out, " in registry")
+			}
 			if details.needsRetag {
 				color.Green.Fprint(out, ". Retagging")
 			}
 			if details.needsPush {
 				color.Green.Fprint(out, ". Pushing.")
 			}
+			if details.needsLoad {
+				color.Green.Fprint(out, ". Loading into cluster.")
+			}
 			color.Default.Fprintln(out)
 
 			if details.needsRetag {
@@ -108,6 +129,11 @@ func (c *Cache) RetrieveCachedArtifacts(ctx context.Context, out io.Writer, arti
 					return nil, nil, errors.Wrap(err, "pushing image")
 				}
 			}
+			if details.needsLoad {
+				if err := c.imageLoader.LoadImage(ctx, out, details.hashTag); err != nil {
+					return nil, nil, err
+				}
+			}
 
 			built = append(built, build.Artifact{
 				ImageName: artifact.ImageName,
@@ -124,23 +150,31 @@ type cachedArtifactDetails struct {
 	needsRebuild  bool
 	needsRetag    bool
 	needsPush     bool
+	needsLoad     bool
 	prebuiltImage string
 	hashTag       string
+	foundRemotely bool
 }
 
 func (c *Cache) retrieveCachedArtifactDetails(ctx context.Context, a *latest.Artifact) (*cachedArtifactDetails, error) {
-	hash, err := hashForArtifact(ctx, c.builder, a)
+	hash, err := c.artifactHash(ctx, a)
 	if err != nil {
 		return nil, errors.Wrapf(err, "getting hash for artifact %s", a.ImageName)
 	}
 	a.WorkspaceHash = hash
+	hashTag := HashTag(a)
 	imageDetails, cacheHit := c.artifactCache[hash]
 	if !cacheHit {
+		if c.existsInRegistry(hashTag) {
+			return &cachedArtifactDetails{
+				foundRemotely: true,
+				hashTag:       hashTag,
+			}, nil
+		}
 		return &cachedArtifactDetails{
 			needsRebuild: true,
 		}, nil
 	}
-	hashTag := HashTag(a)
 	il, err := c.imageLocation(ctx, imageDetails, hashTag)
 	if err != nil {
 		return nil, errors.Wrapf(err, "getting artifact details for %s", a.ImageName)
@@ -149,11 +183,26 @@ func (c *Cache) retrieveCachedArtifactDetails(ctx context.Context, a *latest.Art
 		needsRebuild:  needsRebuild(il, c.localCluster),
 		needsRetag:    needsRetag(il),
 		needsPush:     needsPush(il, c.localCluster, c.pushImages),
+		needsLoad:     needsLoad(c.localCluster, c.pushImages, c.imageLoader != nil),
 		prebuiltImage: il.prebuiltImage,
 		hashTag:       hashTag,
 	}, nil
 }
 
+// existsInRegistry checks if an image with the given tag was already pushed,
+// for example by another machine sharing the registry in CI.
+// The image has to be pulled to be used by a local cluster, so it's only looked up for remote clusters.
+func (c *Cache) existsInRegistry(tag string) bool {
+	if !c.remoteCache || c.offline || c.localCluster {
+		return false
+	}
+	if _, err := remoteDigest(tag, c.insecureRegistries); err != nil {
+		logrus.Debugf("Looking up %s in the registry: %v", tag, err)
+		return false
+	}
+	return true
+}
+
 // imageLocation holds information about where the image currently is
 type imageLocation struct {
 	existsRemotely bool
@@ -163,7 +212,8 @@ type imageLocation struct {
 
 func (c *Cache) imageLocation(ctx context.Context, imageDetails ImageDetails, tag string) (*imageLocation, error) {
 	// Check if tagged image exists remotely with the same digest
-	existsRemotely := imgExistsRemotely(tag, imageDetails.Digest, c.insecureRegistries)
+	// Registries can't be reached in offline mode, so treat the image as missing remotely.
+	existsRemotely := !c.offline && imgExistsRemotely(tag, imageDetails.Digest, c.insecureRegistries)
 	existsLocally := false
 	if c.client != nil {
 		// See if this image exists in the local daemon
@@ -214,6 +264,12 @@ func needsPush(d *imageLocation, localCluster, push bool) bool {
 	return !d.existsRemotely
 }
 
+func needsLoad(localCluster, push, loadable bool) bool {
+	// Images that are not pushed have to be loaded into kind, k3d, microk8s,
+	// or minikube when the builds don't run on its docker daemon.
+	return localCluster && !push && loadable
+}
+
 func needsRetag(d *imageLocation) bool {
 	// Don't need a retag if image already exists locally
 	if d.existsLocally {
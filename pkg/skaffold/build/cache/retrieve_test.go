@@ -19,6 +19,8 @@ package cache
 import (
 	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
 	"reflect"
 	"sort"
@@ -27,6 +29,7 @@ import (
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/docker"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
 	"github.com/GoogleContainerTools/skaffold/testutil"
 	"github.com/docker/docker/api/types"
 )
@@ -51,6 +54,16 @@ func (s *artifactSorter) Less(i, j int) bool {
 	return s.artifacts[i].ImageName < s.artifacts[j].ImageName
 }
 
+// fakeLoader records the images loaded into a local cluster.
+type fakeLoader struct {
+	images []string
+}
+
+func (l *fakeLoader) LoadImage(_ context.Context, _ io.Writer, image string) error {
+	l.images = append(l.images, image)
+	return nil
+}
+
 func Test_RetrieveCachedArtifacts(t *testing.T) {
 	tests := []struct {
 		name                 string
@@ -120,6 +133,27 @@ func Test_RetrieveCachedArtifacts(t *testing.T) {
 			expectedArtifacts:    []*latest.Artifact{{ImageName: "image2", WorkspaceHash: "hash2"}},
 			expectedBuildResults: []build.Artifact{{ImageName: "image1", Tag: "image1:hash"}},
 		},
+		{
+			name: "caching disabled for an artifact",
+			cache: &Cache{
+				useCache: true,
+				artifactCache: ArtifactCache{
+					"hash":  ImageDetails{Digest: "sha256@digest1"},
+					"hash2": ImageDetails{Digest: "sha256@digest2"},
+				},
+			},
+			api: testutil.FakeAPIClient{
+				TagToImageID: map[string]string{"image1:hash": "image1:tag", "image2:hash2": "image2:tag"},
+				ImageSummaries: []types.ImageSummary{
+					{RepoDigests: []string{"sha256@digest1"}, RepoTags: []string{"image1:hash"}},
+					{RepoDigests: []string{"sha256@digest2"}, RepoTags: []string{"image2:hash2"}},
+				},
+			},
+			hashes:               map[string]string{"image1": "hash", "image2": "hash2"},
+			artifacts:            []*latest.Artifact{{ImageName: "image1"}, {ImageName: "image2", Cache: util.BoolPtr(false)}},
+			expectedArtifacts:    []*latest.Artifact{{ImageName: "image2", Cache: util.BoolPtr(false)}},
+			expectedBuildResults: []build.Artifact{{ImageName: "image1", Tag: "image1:hash"}},
+		},
 	}
 
 	for _, test := range tests {
@@ -137,6 +171,27 @@ func Test_RetrieveCachedArtifacts(t *testing.T) {
 	}
 }
 
+func TestRetrieveCachedArtifactsLoadsImages(t *testing.T) {
+	reset := testutil.Override(t, &hashForArtifact, mockHashForArtifact(map[string]string{"image1": "hash"}))
+	defer reset()
+
+	loader := &fakeLoader{}
+	cache := &Cache{
+		useCache:      true,
+		localCluster:  true,
+		imageLoader:   loader,
+		artifactCache: ArtifactCache{"hash": ImageDetails{Digest: "sha256@digest"}},
+	}
+	cache.client = docker.NewLocalDaemon(&testutil.FakeAPIClient{
+		TagToImageID: map[string]string{"image1:hash": "image1:tag"},
+	}, nil, false, map[string]bool{})
+
+	_, built, err := cache.RetrieveCachedArtifacts(context.Background(), ioutil.Discard, []*latest.Artifact{{ImageName: "image1"}})
+
+	testutil.CheckErrorAndDeepEqual(t, false, err, []build.Artifact{{ImageName: "image1", Tag: "image1:hash"}}, built)
+	testutil.CheckDeepEqual(t, []string{"image1:hash"}, loader.images)
+}
+
 func TestRetrieveCachedArtifactDetails(t *testing.T) {
 	tests := []struct {
 		name                      string
@@ -166,6 +221,36 @@ func TestRetrieveCachedArtifactDetails(t *testing.T) {
 				needsRebuild: true,
 			},
 		},
+		{
+			name:     "image doesn't exist in cache but exists in registry, remote cluster",
+			artifact: &latest.Artifact{ImageName: "image"},
+			hashes:   map[string]string{"image": "hash"},
+			cache: &Cache{
+				useCache:      true,
+				remoteCache:   true,
+				artifactCache: ArtifactCache{},
+			},
+			digest: "digest",
+			expected: &cachedArtifactDetails{
+				foundRemotely: true,
+				hashTag:       "image:hash",
+			},
+		},
+		{
+			name:     "image doesn't exist in cache, registry not looked up for local cluster",
+			artifact: &latest.Artifact{ImageName: "image"},
+			hashes:   map[string]string{"image": "hash"},
+			cache: &Cache{
+				useCache:      true,
+				remoteCache:   true,
+				localCluster:  true,
+				artifactCache: ArtifactCache{},
+			},
+			digest: "digest",
+			expected: &cachedArtifactDetails{
+				needsRebuild: true,
+			},
+		},
 		{
 			name:                      "image in cache and exists remotely, remote cluster",
 			targetImageExistsRemotely: true,
@@ -260,6 +345,31 @@ func TestRetrieveCachedArtifactDetails(t *testing.T) {
 				hashTag:       "image:hash",
 			},
 		},
+		{
+			name:     "image in cache, prebuilt image exists, kind cluster",
+			artifact: &latest.Artifact{ImageName: "image"},
+			hashes:   map[string]string{"image": "hash"},
+			api:      &testutil.FakeAPIClient{},
+			cache: &Cache{
+				useCache:      true,
+				localCluster:  true,
+				imageLoader:   &fakeLoader{},
+				artifactCache: ArtifactCache{"hash": ImageDetails{Digest: digest}},
+				imageList: []types.ImageSummary{
+					{
+						RepoDigests: []string{fmt.Sprintf("image@%s", digest)},
+						RepoTags:    []string{"anotherimage:hash"},
+					},
+				},
+			},
+			digest: digest,
+			expected: &cachedArtifactDetails{
+				needsRetag:    true,
+				needsLoad:     true,
+				prebuiltImage: "anotherimage:hash",
+				hashTag:       "image:hash",
+			},
+		},
 		{
 			name:                      "push specified, local cluster, image exists remotely",
 			targetImageExistsRemotely: true,
@@ -300,6 +410,23 @@ func TestRetrieveCachedArtifactDetails(t *testing.T) {
 				hashTag: "image:hash",
 			},
 		},
+		{
+			name:                      "offline, image exists remotely but isn't looked up",
+			artifact:                  &latest.Artifact{ImageName: "image"},
+			hashes:                    map[string]string{"image": "hash"},
+			targetImageExistsRemotely: true,
+			cache: &Cache{
+				useCache:      true,
+				offline:       true,
+				artifactCache: ArtifactCache{"hash": ImageDetails{Digest: digest}},
+			},
+			digest: digest,
+			expected: &cachedArtifactDetails{
+				needsRebuild: true,
+				needsPush:    true,
+				hashTag:      "image:hash",
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -73,7 +73,10 @@ func (c *Cache) CacheArtifacts(ctx context.Context, artifacts []*latest.Artifact
 		tags[t.ImageName] = t.Tag
 	}
 	for _, a := range artifacts {
-		hash, err := hashForArtifact(ctx, c.builder, a)
+		if cachingDisabled(a) {
+			continue
+		}
+		hash, err := c.artifactHash(ctx, a)
 		if err != nil {
 			continue
 		}
@@ -96,10 +99,13 @@ func (c *Cache) CacheArtifacts(ctx context.Context, artifacts []*latest.Artifact
 			continue
 		}
 		c.artifactCache[hash] = ImageDetails{
-			Digest: digest,
-			ID:     id,
+			Digest:   digest,
+			ID:       id,
+			Image:    a.ImageName,
+			LastUsed: now(),
 		}
 	}
+	c.artifactCache.trim(c.maxEntries)
 	return c.save()
 }
 
@@ -107,21 +113,32 @@ func (c *Cache) CacheArtifacts(ctx context.Context, artifacts []*latest.Artifact
 func (c *Cache) retrieveImageDigest(ctx context.Context, img string) (string, error) {
 	if c.client == nil {
 		// Check for remote digest
-		return docker.RemoteDigest(img, c.insecureRegistries)
+		return c.lookupRemoteDigest(img)
 	}
 	repoDigest, err := c.client.RepoDigest(ctx, img)
 	if err != nil {
-		return docker.RemoteDigest(img, c.insecureRegistries)
+		return c.lookupRemoteDigest(img)
 	}
 	ref, err := name.NewDigest(repoDigest, name.WeakValidation)
 	return ref.DigestStr(), err
 }
 
+func (c *Cache) lookupRemoteDigest(img string) (string, error) {
+	if c.offline {
+		return "", fmt.Errorf("not looking up remote digest for %s in offline mode", img)
+	}
+	return docker.RemoteDigest(img, c.insecureRegistries)
+}
+
 // Save saves the artifactCache to the cacheFile
 func (c *Cache) save() error {
-	data, err := yaml.Marshal(c.artifactCache)
+	return saveArtifactCache(c.cacheFile, c.artifactCache)
+}
+
+func saveArtifactCache(cacheFile string, cache ArtifactCache) error {
+	data, err := yaml.Marshal(cache)
 	if err != nil {
 		return errors.Wrap(err, "marshalling hashes")
 	}
-	return ioutil.WriteFile(c.cacheFile, data, 0755)
+	return ioutil.WriteFile(cacheFile, data, 0755)
 }
@@ -22,6 +22,7 @@ import (
 	"io"
 	"sort"
 
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/cache"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/cluster/sources"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
@@ -47,6 +48,11 @@ func (b *Builder) runKanikoBuild(ctx context.Context, out io.Writer, artifact *l
 	}
 	defer s.Cleanup(ctx)
 
+	labels, err := build.ImageLabels(b.labels, artifact.Workspace)
+	if err != nil {
+		return "", err
+	}
+
 	kanikoArtifact := artifact.KanikoArtifact
 	// Create pod spec
 	args := []string{
@@ -63,6 +69,7 @@ func (b *Builder) runKanikoBuild(ctx context.Context, out io.Writer, artifact *l
 	args = appendBuildArgsIfExists(args, kanikoArtifact.BuildArgs)
 	args = appendTargetIfExists(args, kanikoArtifact.Target)
 	args = appendCacheIfExists(args, kanikoArtifact.Cache)
+	args = append(args, docker.GetLabelArgs(labels)...)
 
 	if artifact.WorkspaceHash != "" {
 		hashTag := cache.HashTag(artifact)
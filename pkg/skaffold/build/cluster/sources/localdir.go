@@ -21,7 +21,6 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"os/exec"
 	"path/filepath"
 
 	"github.com/pkg/errors"
@@ -111,13 +110,13 @@ func (g *LocalDir) ModifyPod(ctx context.Context, p *v1.Pod) error {
 	defer f.Close()
 
 	// Copy the context to the empty dir and extract it
-	copyAndExtract := exec.CommandContext(ctx, "kubectl", "exec", "-i", p.Name, "-c", initContainer, "-n", p.Namespace, "--", "tar", "-xzf", "-", "-C", constants.DefaultKanikoEmptyDirMountPath)
+	copyAndExtract := kubernetes.KubectlCommand(ctx, "exec", "-i", p.Name, "-c", initContainer, "-n", p.Namespace, "--", "tar", "-xzf", "-", "-C", constants.DefaultKanikoEmptyDirMountPath)
 	copyAndExtract.Stdin = f
 	if err := util.RunCmd(copyAndExtract); err != nil {
 		return errors.Wrap(err, "copying and extracting buildcontext to empty dir")
 	}
 	// Generate a file to successfully terminate the init container
-	file := exec.CommandContext(ctx, "kubectl", "exec", p.Name, "-c", initContainer, "-n", p.Namespace, "--", "touch", "/tmp/complete")
+	file := kubernetes.KubectlCommand(ctx, "exec", p.Name, "-c", initContainer, "-n", p.Namespace, "--", "touch", "/tmp/complete")
 	return util.RunCmd(file)
 }
 
@@ -35,6 +35,7 @@ type Builder struct {
 	*latest.ClusterDetails
 
 	timeout            time.Duration
+	labels             map[string]string
 	insecureRegistries map[string]bool
 }
 
@@ -48,6 +49,7 @@ func NewBuilder(runCtx *runcontext.RunContext) (*Builder, error) {
 	return &Builder{
 		ClusterDetails: runCtx.Cfg.Build.Cluster,
 		timeout:        timeout,
+		labels:         runCtx.Cfg.Build.Labels,
 	}, nil
 }
 
@@ -66,7 +68,7 @@ func (b *Builder) DependenciesForArtifact(ctx context.Context, a *latest.Artifac
 	)
 	switch {
 	case a.KanikoArtifact != nil:
-		paths, err = docker.GetDependencies(ctx, a.Workspace, a.KanikoArtifact.DockerfilePath, a.KanikoArtifact.BuildArgs, b.insecureRegistries)
+		paths, err = docker.GetDependencies(ctx, a.Workspace, a.KanikoArtifact.DockerfilePath, a.KanikoArtifact.BuildArgs, a.KanikoArtifact.Target, "", b.insecureRegistries)
 
 	default:
 		return nil, fmt.Errorf("undefined artifact type: %+v", a.ArtifactType)
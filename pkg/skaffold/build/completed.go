@@ -0,0 +1,47 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import (
+	"sort"
+	"sync"
+)
+
+var (
+	completed     = map[string]string{}
+	completedLock sync.Mutex
+)
+
+// Completed returns the last successful build of each artifact,
+// even when the builds of other artifacts failed.
+func Completed() []Artifact {
+	completedLock.Lock()
+	defer completedLock.Unlock()
+
+	var builds []Artifact
+	for imageName, tag := range completed {
+		builds = append(builds, Artifact{ImageName: imageName, Tag: tag})
+	}
+	sort.Slice(builds, func(i, j int) bool { return builds[i].ImageName < builds[j].ImageName })
+	return builds
+}
+
+func recordCompleted(imageName, tag string) {
+	completedLock.Lock()
+	completed[imageName] = tag
+	completedLock.Unlock()
+}
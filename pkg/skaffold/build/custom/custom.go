@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -27,6 +28,7 @@ import (
 	"strings"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/docker"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
 	"github.com/pkg/errors"
@@ -39,15 +41,17 @@ var (
 
 // ArtifactBuilder is a builder for custom artifacts
 type ArtifactBuilder struct {
-	pushImages    bool
-	additionalEnv []string
+	pushImages         bool
+	additionalEnv      []string
+	insecureRegistries map[string]bool
 }
 
 // NewArtifactBuilder returns a new custom artifact builder
-func NewArtifactBuilder(pushImages bool, additionalEnv []string) *ArtifactBuilder {
+func NewArtifactBuilder(pushImages bool, additionalEnv []string, insecureRegistries map[string]bool) *ArtifactBuilder {
 	return &ArtifactBuilder{
-		pushImages:    pushImages,
-		additionalEnv: additionalEnv,
+		pushImages:         pushImages,
+		additionalEnv:      additionalEnv,
+		insecureRegistries: insecureRegistries,
 	}
 }
 
@@ -58,9 +62,65 @@ func (b *ArtifactBuilder) Build(ctx context.Context, out io.Writer, a *latest.Ar
 	if err != nil {
 		return errors.Wrap(err, "retrieving cmd")
 	}
+
+	switch a.CustomArtifact.Upload {
+	case "stdin":
+		r, w := io.Pipe()
+		go func() {
+			w.CloseWithError(b.uploadContext(ctx, w, a))
+		}()
+		defer r.Close()
+		cmd.Stdin = r
+
+	case "file":
+		tarball, err := ioutil.TempFile("", "skaffold-context-*.tar.gz")
+		if err != nil {
+			return errors.Wrap(err, "creating context tarball")
+		}
+		defer os.Remove(tarball.Name())
+
+		err = b.uploadContext(ctx, tarball, a)
+		tarball.Close()
+		if err != nil {
+			return err
+		}
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", constants.BuildContextTar, tarball.Name()))
+	}
+
 	return cmd.Run()
 }
 
+// uploadContext writes the artifact's dependencies to a gzipped tarball.
+func (b *ArtifactBuilder) uploadContext(ctx context.Context, w io.Writer, a *latest.Artifact) error {
+	var deps []string
+	if a.CustomArtifact.Dependencies == nil {
+		files, err := docker.WalkWorkspace(a.Workspace, nil, []string{"."})
+		if err != nil {
+			return errors.Wrapf(err, "walking workspace %s", a.Workspace)
+		}
+		for file := range files {
+			deps = append(deps, file)
+		}
+		sort.Strings(deps)
+	} else {
+		var err error
+		if deps, err = GetDependencies(ctx, a.Workspace, a.CustomArtifact, b.insecureRegistries); err != nil {
+			return errors.Wrap(err, "listing dependencies")
+		}
+	}
+
+	for i, dep := range deps {
+		if !filepath.IsAbs(dep) {
+			deps[i] = filepath.Join(a.Workspace, dep)
+		}
+	}
+
+	if err := util.CreateTarGz(w, a.Workspace, deps); err != nil {
+		return errors.Wrap(err, "creating context tarball")
+	}
+	return nil
+}
+
 func (b *ArtifactBuilder) retrieveCmd(a *latest.Artifact, tag string) (*exec.Cmd, error) {
 	artifact := a.CustomArtifact
 	split := strings.Split(artifact.BuildCommand, " ")
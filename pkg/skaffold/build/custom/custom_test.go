@@ -17,8 +17,14 @@ limitations under the License.
 package custom
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -76,7 +82,7 @@ func TestRetrieveEnv(t *testing.T) {
 			})
 			defer reset()
 
-			artifactBuilder := NewArtifactBuilder(test.pushImages, test.additionalEnv)
+			artifactBuilder := NewArtifactBuilder(test.pushImages, test.additionalEnv, nil)
 			actual, err := artifactBuilder.retrieveEnv(&latest.Artifact{}, test.tag)
 
 			testutil.CheckErrorAndDeepEqual(t, false, err, test.expected, actual)
@@ -129,7 +135,7 @@ func TestRetrieveCmd(t *testing.T) {
 			})
 			defer reset()
 
-			builder := NewArtifactBuilder(false, nil)
+			builder := NewArtifactBuilder(false, nil, nil)
 			cmd, err := builder.retrieveCmd(test.artifact, test.tag)
 			if err != nil {
 				t.Fatalf("error retrieving command: %v", err)
@@ -150,3 +156,65 @@ func expectedCmd(buildCommand, dir string, args, env []string) *exec.Cmd {
 	cmd.Stderr = os.Stderr
 	return cmd
 }
+
+func TestUploadContext(t *testing.T) {
+	var tests = []struct {
+		description  string
+		dependencies *latest.CustomDependencies
+		expected     []string
+	}{
+		{
+			description:  "declared paths",
+			dependencies: &latest.CustomDependencies{Paths: []string{"src"}, Ignore: []string{"src/*.tmp"}},
+			expected:     []string{"src/main.go"},
+		},
+		{
+			description: "whole workspace",
+			expected:    []string{"build.sh", "src/main.go", "src/main.tmp"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			tmpDir, cleanup := testutil.NewTempDir(t)
+			defer cleanup()
+
+			tmpDir.Write("build.sh", "").
+				Write("src/main.go", "").
+				Write("src/main.tmp", "")
+
+			builder := NewArtifactBuilder(false, nil, nil)
+			var buf bytes.Buffer
+			err := builder.uploadContext(context.Background(), &buf, &latest.Artifact{
+				Workspace: tmpDir.Root(),
+				ArtifactType: latest.ArtifactType{
+					CustomArtifact: &latest.CustomArtifact{
+						Dependencies: test.dependencies,
+					},
+				},
+			})
+			testutil.CheckError(t, false, err)
+
+			testutil.CheckDeepEqual(t, test.expected, tarEntries(t, &buf))
+		})
+	}
+}
+
+func tarEntries(t *testing.T, r io.Reader) []string {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var entries []string
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return entries
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, filepath.ToSlash(header.Name))
+	}
+}
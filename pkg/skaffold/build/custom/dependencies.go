@@ -35,7 +35,7 @@ func GetDependencies(ctx context.Context, workspace string, a *latest.CustomArti
 	switch {
 	case a.Dependencies.Dockerfile != nil:
 		dockerfile := a.Dependencies.Dockerfile
-		return docker.GetDependencies(ctx, workspace, dockerfile.Path, dockerfile.BuildArgs, insecureRegistries)
+		return docker.GetDependencies(ctx, workspace, dockerfile.Path, dockerfile.BuildArgs, "", "", insecureRegistries)
 
 	case a.Dependencies.Command != "":
 		split := strings.Split(a.Dependencies.Command, " ")
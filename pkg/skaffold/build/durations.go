@@ -0,0 +1,45 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import (
+	"sync"
+	"time"
+)
+
+var (
+	durations     = map[string]time.Duration{}
+	durationsLock sync.Mutex
+)
+
+// Durations returns how long the last successful build of each artifact took.
+func Durations() map[string]time.Duration {
+	durationsLock.Lock()
+	defer durationsLock.Unlock()
+
+	copied := map[string]time.Duration{}
+	for imageName, duration := range durations {
+		copied[imageName] = duration
+	}
+	return copied
+}
+
+func recordDuration(imageName string, start time.Time) {
+	durationsLock.Lock()
+	durations[imageName] = time.Since(start)
+	durationsLock.Unlock()
+}
@@ -17,11 +17,14 @@ limitations under the License.
 package gcb
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	cstorage "cloud.google.com/go/storage"
@@ -43,11 +46,25 @@ import (
 )
 
 // Build builds a list of artifacts with Google Cloud Build.
+// When several artifacts are built, their logs are streamed as they come,
+// each line prefixed with the artifact's image name.
 func (b *Builder) Build(ctx context.Context, out io.Writer, tags tag.ImageTags, artifacts []*latest.Artifact) ([]build.Artifact, error) {
-	return build.InParallel(ctx, out, tags, artifacts, b.buildArtifactWithCloudBuild)
+	if len(artifacts) <= 1 {
+		return build.InParallel(ctx, out, tags, artifacts, func(ctx context.Context, w io.Writer, artifact *latest.Artifact, tag string) (string, error) {
+			return b.buildArtifactWithCloudBuild(ctx, w, w, artifact, tag)
+		})
+	}
+
+	var lock sync.Mutex
+	return build.InParallel(ctx, out, tags, artifacts, func(ctx context.Context, w io.Writer, artifact *latest.Artifact, tag string) (string, error) {
+		logs := newPrefixWriter(out, &lock, artifact.ImageName)
+		defer logs.Flush()
+
+		return b.buildArtifactWithCloudBuild(ctx, w, logs, artifact, tag)
+	})
 }
 
-func (b *Builder) buildArtifactWithCloudBuild(ctx context.Context, out io.Writer, artifact *latest.Artifact, tag string) (string, error) {
+func (b *Builder) buildArtifactWithCloudBuild(ctx context.Context, out, logs io.Writer, artifact *latest.Artifact, tag string) (string, error) {
 	client, err := google.DefaultClient(ctx, cloudbuild.CloudPlatformScope)
 	if err != nil {
 		return "", errors.Wrap(err, "getting google client")
@@ -85,7 +102,12 @@ func (b *Builder) buildArtifactWithCloudBuild(ctx context.Context, out io.Writer
 		return "", errors.Wrap(err, "checking bucket is in correct project")
 	}
 
-	desc, err := b.buildDescription(artifact, tag, cbBucket, buildObject)
+	logsBucket := cbBucket
+	if b.LogsBucket != "" {
+		logsBucket = strings.TrimPrefix(b.LogsBucket, "gs://")
+	}
+
+	desc, err := b.buildDescription(artifact, tag, cbBucket, buildObject, logsBucket)
 	if err != nil {
 		return "", errors.Wrap(err, "could not create build description")
 	}
@@ -100,18 +122,22 @@ func (b *Builder) buildArtifactWithCloudBuild(ctx context.Context, out io.Writer
 		return "", errors.Wrap(err, "uploading source tarball")
 	}
 
-	call := cbclient.Projects.Builds.Create(projectID, desc)
-	op, err := call.Context(ctx).Do()
+	op, err := b.createBuild(ctx, client, cbclient, projectID, desc)
 	if err != nil {
 		return "", errors.Wrap(err, "could not create build")
 	}
 
-	remoteID, err := getBuildID(op)
+	remoteID, logURL, err := getBuildID(op)
 	if err != nil {
 		return "", errors.Wrapf(err, "getting build ID from op")
 	}
 	logsObject := fmt.Sprintf("log-%s.txt", remoteID)
-	color.Default.Fprintf(out, "Logs are available at \nhttps://console.cloud.google.com/m/cloudstorage/b/%s/o/%s\n", cbBucket, logsObject)
+	streamLogs := b.logsInGCS()
+	if streamLogs {
+		color.Default.Fprintf(out, "Logs are available at \nhttps://console.cloud.google.com/m/cloudstorage/b/%s/o/%s\n", logsBucket, logsObject)
+	} else {
+		color.Default.Fprintf(out, "Logs are available at \n%s\n", logURL)
+	}
 
 	var digest string
 	offset := int64(0)
@@ -123,17 +149,19 @@ watch:
 			return "", errors.Wrap(err, "getting build status")
 		}
 
-		r, err := b.getLogs(ctx, offset, cbBucket, logsObject)
-		if err != nil {
-			return "", errors.Wrap(err, "getting logs")
-		}
-		if r != nil {
-			written, err := io.Copy(out, r)
+		if streamLogs {
+			r, err := b.getLogs(ctx, offset, logsBucket, logsObject)
 			if err != nil {
-				return "", errors.Wrap(err, "copying logs to stdout")
+				return "", errors.Wrap(err, "getting logs")
+			}
+			if r != nil {
+				written, err := io.Copy(logs, r)
+				if err != nil {
+					return "", errors.Wrap(err, "copying logs to stdout")
+				}
+				offset += written
+				r.Close()
 			}
-			offset += written
-			r.Close()
 		}
 		switch cb.Status {
 		case StatusQueued, StatusWorking, StatusUnknown:
@@ -160,18 +188,87 @@ watch:
 	return tag + "@" + digest, nil
 }
 
-func getBuildID(op *cloudbuild.Operation) (string, error) {
+// logsInGCS returns true if the build logs are written to Cloud Storage,
+// where Skaffold can stream them from.
+func (b *Builder) logsInGCS() bool {
+	switch b.Logging {
+	case "", "LOGGING_UNSPECIFIED", "LEGACY", "GCS_ONLY":
+		return true
+	default:
+		return false
+	}
+}
+
+// createBuild starts a build. The vendored client doesn't know about the `logging`
+// option, so builds that set it are created with a raw request.
+func (b *Builder) createBuild(ctx context.Context, client *http.Client, cbclient *cloudbuild.Service, projectID string, desc *cloudbuild.Build) (*cloudbuild.Operation, error) {
+	if b.Logging == "" {
+		return cbclient.Projects.Builds.Create(projectID, desc).Context(ctx).Do()
+	}
+
+	body, err := buildRequestBody(desc, b.Logging)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%sv1/projects/%s/builds", cbclient.BasePath, projectID), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", cbclient.UserAgent)
+
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := googleapi.CheckResponse(resp); err != nil {
+		return nil, err
+	}
+
+	var op cloudbuild.Operation
+	if err := json.NewDecoder(resp.Body).Decode(&op); err != nil {
+		return nil, errors.Wrap(err, "parsing operation")
+	}
+	return &op, nil
+}
+
+// buildRequestBody encodes a build and adds the `logging` option to it.
+func buildRequestBody(desc *cloudbuild.Build, logging string) ([]byte, error) {
+	encoded, err := json.Marshal(desc)
+	if err != nil {
+		return nil, err
+	}
+
+	var build map[string]interface{}
+	if err := json.Unmarshal(encoded, &build); err != nil {
+		return nil, err
+	}
+
+	options, _ := build["options"].(map[string]interface{})
+	if options == nil {
+		options = map[string]interface{}{}
+	}
+	options["logging"] = logging
+	build["options"] = options
+
+	return json.Marshal(build)
+}
+
+func getBuildID(op *cloudbuild.Operation) (string, string, error) {
 	if op.Metadata == nil {
-		return "", errors.New("missing Metadata in operation")
+		return "", "", errors.New("missing Metadata in operation")
 	}
 	var buildMeta cloudbuild.BuildOperationMetadata
 	if err := json.Unmarshal([]byte(op.Metadata), &buildMeta); err != nil {
-		return "", err
+		return "", "", err
 	}
 	if buildMeta.Build == nil {
-		return "", errors.New("missing Build in operation metadata")
+		return "", "", errors.New("missing Build in operation metadata")
 	}
-	return buildMeta.Build.Id, nil
+	return buildMeta.Build.Id, buildMeta.Build.LogUrl, nil
 }
 
 func getDigest(b *cloudbuild.Build) (string, error) {
@@ -0,0 +1,83 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcb
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	cloudbuild "google.golang.org/api/cloudbuild/v1"
+)
+
+func TestCreateBuildWithLogging(t *testing.T) {
+	var (
+		path string
+		body map[string]interface{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		content, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(content, &body)
+		w.Write([]byte(`{"name": "operations/build/project/id"}`))
+	}))
+	defer server.Close()
+
+	cbclient, err := cloudbuild.New(http.DefaultClient)
+	testutil.CheckError(t, false, err)
+	cbclient.BasePath = server.URL + "/"
+
+	builder := Builder{
+		GoogleCloudBuild: &latest.GoogleCloudBuild{Logging: "CLOUD_LOGGING_ONLY"},
+	}
+	op, err := builder.createBuild(context.Background(), http.DefaultClient, cbclient, "project", &cloudbuild.Build{
+		Images:  []string{"gcr.io/project/image"},
+		Options: &cloudbuild.BuildOptions{MachineType: "N1_HIGHCPU_8"},
+	})
+
+	testutil.CheckError(t, false, err)
+	testutil.CheckDeepEqual(t, "operations/build/project/id", op.Name)
+	testutil.CheckDeepEqual(t, "/v1/projects/project/builds", path)
+	testutil.CheckDeepEqual(t, map[string]interface{}{
+		"images": []interface{}{"gcr.io/project/image"},
+		"options": map[string]interface{}{
+			"machineType": "N1_HIGHCPU_8",
+			"logging":     "CLOUD_LOGGING_ONLY",
+		},
+	}, body)
+}
+
+func TestLogsInGCS(t *testing.T) {
+	for logging, expected := range map[string]bool{
+		"":                   true,
+		"GCS_ONLY":           true,
+		"LEGACY":             true,
+		"CLOUD_LOGGING_ONLY": false,
+		"NONE":               false,
+	} {
+		builder := Builder{
+			GoogleCloudBuild: &latest.GoogleCloudBuild{Logging: logging},
+		}
+
+		testutil.CheckDeepEqual(t, expected, builder.logsInGCS())
+	}
+}
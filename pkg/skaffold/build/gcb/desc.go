@@ -19,13 +19,14 @@ package gcb
 import (
 	"fmt"
 
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/cache"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/pkg/errors"
 	cloudbuild "google.golang.org/api/cloudbuild/v1"
 )
 
-func (b *Builder) buildDescription(artifact *latest.Artifact, tag, bucket, object string) (*cloudbuild.Build, error) {
+func (b *Builder) buildDescription(artifact *latest.Artifact, tag, bucket, object, logsBucket string) (*cloudbuild.Build, error) {
 	tags := []string{tag}
 	if artifact.WorkspaceHash != "" {
 		tags = append(tags, cache.HashTag(artifact))
@@ -36,38 +37,54 @@ func (b *Builder) buildDescription(artifact *latest.Artifact, tag, bucket, objec
 		return nil, err
 	}
 
-	return &cloudbuild.Build{
-		LogsBucket: bucket,
+	options := &cloudbuild.BuildOptions{
+		DiskSizeGb:  b.DiskSizeGb,
+		MachineType: b.MachineType,
+	}
+	if len(b.Substitutions) > 0 {
+		// Substitutions don't have to be used by every artifact's steps.
+		options.SubstitutionOption = "ALLOW_LOOSE"
+	}
+
+	desc := &cloudbuild.Build{
 		Source: &cloudbuild.Source{
 			StorageSource: &cloudbuild.StorageSource{
 				Bucket: bucket,
 				Object: object,
 			},
 		},
-		Steps:  steps,
-		Images: tags,
-		Options: &cloudbuild.BuildOptions{
-			DiskSizeGb:  b.DiskSizeGb,
-			MachineType: b.MachineType,
-		},
-		Timeout: b.Timeout,
-	}, nil
+		Steps:         steps,
+		Images:        tags,
+		Options:       options,
+		Substitutions: b.Substitutions,
+		Timeout:       b.Timeout,
+	}
+	if b.logsInGCS() {
+		desc.LogsBucket = logsBucket
+	}
+
+	return desc, nil
 }
 
 func (b *Builder) buildSteps(artifact *latest.Artifact, tags []string) ([]*cloudbuild.BuildStep, error) {
+	labels, err := build.ImageLabels(b.labels, artifact.Workspace)
+	if err != nil {
+		return nil, err
+	}
+
 	switch {
 	case artifact.DockerArtifact != nil:
-		return b.dockerBuildSteps(artifact.DockerArtifact, tags)
+		return b.dockerBuildSteps(artifact.DockerArtifact, tags, labels)
 
 	case artifact.BazelArtifact != nil:
 		return nil, errors.New("skaffold can't build a bazel artifact with Google Cloud Build")
 
 		// TODO: build multiple tagged images with jib in GCB (priyawadhwa@)
 	case artifact.JibMavenArtifact != nil:
-		return b.jibMavenBuildSteps(artifact.JibMavenArtifact, tags[0]), nil
+		return b.jibMavenBuildSteps(artifact.JibMavenArtifact, tags[0], labels), nil
 
 	case artifact.JibGradleArtifact != nil:
-		return b.jibGradleBuildSteps(artifact.JibGradleArtifact, tags[0]), nil
+		return b.jibGradleBuildSteps(artifact.JibGradleArtifact, tags[0], labels), nil
 
 	default:
 		return nil, fmt.Errorf("undefined artifact type: %+v", artifact.ArtifactType)
@@ -33,7 +33,7 @@ func TestBuildBazelDescriptionFail(t *testing.T) {
 	builder := Builder{
 		GoogleCloudBuild: &latest.GoogleCloudBuild{},
 	}
-	_, err := builder.buildDescription(artifact, "tag", "bucket", "object")
+	_, err := builder.buildDescription(artifact, "tag", "bucket", "object", "bucket")
 
 	testutil.CheckError(t, true, err)
 }
@@ -44,7 +44,54 @@ func TestBuildUnknownDescriptionFail(t *testing.T) {
 	builder := Builder{
 		GoogleCloudBuild: &latest.GoogleCloudBuild{},
 	}
-	_, err := builder.buildDescription(artifact, "tag", "bucket", "object")
+	_, err := builder.buildDescription(artifact, "tag", "bucket", "object", "bucket")
 
 	testutil.CheckError(t, true, err)
 }
+
+func TestBuildDescriptionLogsAndSubstitutions(t *testing.T) {
+	artifact := &latest.Artifact{
+		ArtifactType: latest.ArtifactType{
+			DockerArtifact: &latest.DockerArtifact{
+				DockerfilePath: "Dockerfile",
+			},
+		},
+	}
+
+	var tests = []struct {
+		description           string
+		config                latest.GoogleCloudBuild
+		expectedLogsBucket    string
+		expectedSubstitutions map[string]string
+		expectedOption        string
+	}{
+		{
+			description:        "logs in source bucket",
+			expectedLogsBucket: "logs",
+		},
+		{
+			description: "logs in cloud logging only",
+			config:      latest.GoogleCloudBuild{Logging: "CLOUD_LOGGING_ONLY"},
+		},
+		{
+			description:           "substitutions",
+			config:                latest.GoogleCloudBuild{Substitutions: map[string]string{"_VERSION": "1.2"}},
+			expectedLogsBucket:    "logs",
+			expectedSubstitutions: map[string]string{"_VERSION": "1.2"},
+			expectedOption:        "ALLOW_LOOSE",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			builder := Builder{
+				GoogleCloudBuild: &test.config,
+			}
+			desc, err := builder.buildDescription(artifact, "tag", "bucket", "object", "logs")
+
+			testutil.CheckError(t, false, err)
+			testutil.CheckDeepEqual(t, test.expectedLogsBucket, desc.LogsBucket)
+			testutil.CheckDeepEqual(t, test.expectedSubstitutions, desc.Substitutions)
+			testutil.CheckDeepEqual(t, test.expectedOption, desc.Options.SubstitutionOption)
+		})
+	}
+}
@@ -25,7 +25,7 @@ import (
 	cloudbuild "google.golang.org/api/cloudbuild/v1"
 )
 
-func (b *Builder) dockerBuildSteps(artifact *latest.DockerArtifact, tags []string) ([]*cloudbuild.BuildStep, error) {
+func (b *Builder) dockerBuildSteps(artifact *latest.DockerArtifact, tags []string, labels map[string]string) ([]*cloudbuild.BuildStep, error) {
 	var steps []*cloudbuild.BuildStep
 
 	for _, cacheFrom := range artifact.CacheFrom {
@@ -46,6 +46,7 @@ func (b *Builder) dockerBuildSteps(artifact *latest.DockerArtifact, tags []strin
 		return nil, errors.Wrap(err, "getting docker build args")
 	}
 	args = append(args, ba...)
+	args = append(args, docker.GetLabelArgs(labels)...)
 	args = append(args, ".")
 
 	return append(steps, &cloudbuild.BuildStep{
@@ -46,7 +46,7 @@ func TestDockerBuildDescription(t *testing.T) {
 			Timeout:     "10m",
 		},
 	}
-	desc, err := builder.buildDescription(artifact, "nginx", "bucket", "object")
+	desc, err := builder.buildDescription(artifact, "nginx", "bucket", "object", "bucket")
 
 	expected := cloudbuild.Build{
 		LogsBucket: "bucket",
@@ -82,7 +82,7 @@ func TestPullCacheFrom(t *testing.T) {
 			DockerImage: "docker/docker",
 		},
 	}
-	steps, err := builder.dockerBuildSteps(artifact, []string{"nginx2"})
+	steps, err := builder.dockerBuildSteps(artifact, []string{"nginx2"}, nil)
 
 	expected := []*cloudbuild.BuildStep{{
 		Name:       "docker/docker",
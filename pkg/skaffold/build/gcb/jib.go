@@ -23,16 +23,16 @@ import (
 )
 
 // TODO(dgageot): check that `package` is bound to `jib:build`
-func (b *Builder) jibMavenBuildSteps(artifact *latest.JibMavenArtifact, tag string) []*cloudbuild.BuildStep {
+func (b *Builder) jibMavenBuildSteps(artifact *latest.JibMavenArtifact, tag string, labels map[string]string) []*cloudbuild.BuildStep {
 	return []*cloudbuild.BuildStep{{
 		Name: b.MavenImage,
-		Args: jib.GenerateMavenArgs("dockerBuild", tag, artifact, b.skipTests),
+		Args: append(jib.GenerateMavenArgs("dockerBuild", tag, artifact, b.skipTests), jib.GenerateLabelArgs(labels)...),
 	}}
 }
 
-func (b *Builder) jibGradleBuildSteps(artifact *latest.JibGradleArtifact, tag string) []*cloudbuild.BuildStep {
+func (b *Builder) jibGradleBuildSteps(artifact *latest.JibGradleArtifact, tag string, labels map[string]string) []*cloudbuild.BuildStep {
 	return []*cloudbuild.BuildStep{{
 		Name: b.GradleImage,
-		Args: jib.GenerateGradleArgs("jibDockerBuild", tag, artifact, b.skipTests),
+		Args: append(jib.GenerateGradleArgs("jibDockerBuild", tag, artifact, b.skipTests), jib.GenerateLabelArgs(labels)...),
 	}}
 }
@@ -0,0 +1,76 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcb
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// prefixWriter writes each complete line of an artifact's build logs as soon as it's
+// received, prefixed with the artifact's image name. The logs of artifacts built in
+// parallel share the same output and lock, so that their lines are interleaved.
+type prefixWriter struct {
+	out    io.Writer
+	lock   *sync.Mutex
+	prefix []byte
+	buf    []byte
+}
+
+func newPrefixWriter(out io.Writer, lock *sync.Mutex, imageName string) *prefixWriter {
+	return &prefixWriter{
+		out:    out,
+		lock:   lock,
+		prefix: []byte("[" + imageName + "] "),
+	}
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+
+		if err := w.writeLine(w.buf[:i+1]); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[i+1:]
+	}
+}
+
+// Flush writes the last line of logs if it's not terminated by a newline.
+func (w *prefixWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+
+	line := append(w.buf, '\n')
+	w.buf = nil
+	return w.writeLine(line)
+}
+
+func (w *prefixWriter) writeLine(line []byte) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	_, err := w.out.Write(append(append([]byte{}, w.prefix...), line...))
+	return err
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcb
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestPrefixWriter(t *testing.T) {
+	var (
+		out  bytes.Buffer
+		lock sync.Mutex
+	)
+	first := newPrefixWriter(&out, &lock, "gcr.io/project/first")
+	second := newPrefixWriter(&out, &lock, "gcr.io/project/second")
+
+	first.Write([]byte("Step 1/2 : FROM"))
+	second.Write([]byte("Step 1/3 : FROM busybox\nStep 2/3"))
+	first.Write([]byte(" alpine\n"))
+	second.Write([]byte(" : COPY . .\n"))
+	first.Write([]byte("Step 2/2 : CMD"))
+	first.Flush()
+	second.Flush()
+
+	testutil.CheckDeepEqual(t, `[gcr.io/project/second] Step 1/3 : FROM busybox
+[gcr.io/project/first] Step 1/2 : FROM alpine
+[gcr.io/project/second] Step 2/3 : COPY . .
+[gcr.io/project/first] Step 2/2 : CMD
+`, out.String())
+}
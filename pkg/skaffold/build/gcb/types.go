@@ -63,6 +63,7 @@ const (
 type Builder struct {
 	*latest.GoogleCloudBuild
 	skipTests          bool
+	labels             map[string]string
 	insecureRegistries map[string]bool
 }
 
@@ -71,6 +72,7 @@ func NewBuilder(runCtx *runcontext.RunContext) *Builder {
 	return &Builder{
 		GoogleCloudBuild:   runCtx.Cfg.Build.GoogleCloudBuild,
 		skipTests:          runCtx.Opts.SkipTests,
+		labels:             runCtx.Cfg.Build.Labels,
 		insecureRegistries: runCtx.InsecureRegistries,
 	}
 }
@@ -87,7 +89,7 @@ func (b *Builder) DependenciesForArtifact(ctx context.Context, a *latest.Artifac
 	var paths []string
 	var err error
 	if a.DockerArtifact != nil {
-		paths, err = docker.GetDependencies(ctx, a.Workspace, a.DockerArtifact.DockerfilePath, a.DockerArtifact.BuildArgs, b.insecureRegistries)
+		paths, err = docker.GetDependencies(ctx, a.Workspace, a.DockerArtifact.DockerfilePath, a.DockerArtifact.BuildArgs, a.DockerArtifact.Target, a.DockerArtifact.DockerignorePath, b.insecureRegistries)
 		if err != nil {
 			return nil, errors.Wrapf(err, "getting dependencies for %s", a.ImageName)
 		}
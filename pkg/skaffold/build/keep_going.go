@@ -0,0 +1,76 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/pkg/errors"
+)
+
+// KeepGoing makes the builders build all the artifacts, even after some of them failed.
+var KeepGoing bool
+
+// buildSummary collects the outcome of each build when KeepGoing is set.
+type buildSummary struct {
+	built  []Artifact
+	failed map[string]error
+}
+
+func newBuildSummary() *buildSummary {
+	return &buildSummary{
+		failed: map[string]error{},
+	}
+}
+
+func (s *buildSummary) success(artifact Artifact) {
+	s.built = append(s.built, artifact)
+}
+
+func (s *buildSummary) failure(imageName string, err error) {
+	s.failed[imageName] = err
+}
+
+// result prints which artifacts were built and which failed, in the order of the configuration.
+// It returns an error listing the failed builds, if any.
+func (s *buildSummary) result(out io.Writer, artifacts []*latest.Artifact) ([]Artifact, error) {
+	if len(s.failed) == 0 {
+		return s.built, nil
+	}
+
+	color.Default.Fprintln(out, "Build summary:")
+
+	var failures []string
+	for _, artifact := range artifacts {
+		color.Default.Fprintf(out, " - %s: ", artifact.ImageName)
+
+		err, failed := s.failed[artifact.ImageName]
+		if !failed {
+			color.Green.Fprintln(out, "Built")
+			continue
+		}
+
+		color.Red.Fprintln(out, "Failed:", err)
+		failures = append(failures, errors.Wrapf(err, "building [%s]", artifact.ImageName).Error())
+	}
+
+	return nil, fmt.Errorf("%d of %d artifacts failed to build: %s", len(s.failed), len(artifacts), strings.Join(failures, "; "))
+}
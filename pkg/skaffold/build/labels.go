@@ -0,0 +1,76 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import (
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/version"
+	"github.com/pkg/errors"
+)
+
+// For testing
+var (
+	now = time.Now
+)
+
+// ImageLabels evaluates the labels, configured in `build.labels`, that are added to
+// an artifact's image. Their values are templates that can reference environment
+// variables, `GIT_COMMIT`, the commit of the artifact's workspace, `BUILD_TIME` and
+// `SKAFFOLD_VERSION`.
+func ImageLabels(labels map[string]string, workspace string) (map[string]string, error) {
+	if len(labels) == 0 {
+		return nil, nil
+	}
+
+	values := map[string]string{
+		"GIT_COMMIT":       GitCommit(workspace),
+		"BUILD_TIME":       now().UTC().Format(time.RFC3339),
+		"SKAFFOLD_VERSION": version.Get().Version,
+	}
+
+	evaluated := map[string]string{}
+	for key, value := range labels {
+		tmpl, err := util.ParseEnvTemplate(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing template for label %s", key)
+		}
+
+		evaluated[key], err = util.ExecuteEnvTemplate(tmpl, values)
+		if err != nil {
+			return nil, errors.Wrapf(err, "evaluating label %s", key)
+		}
+	}
+
+	return evaluated, nil
+}
+
+// GitCommit returns the commit checked out in a workspace, or an empty string
+// if it isn't a git repository.
+func GitCommit(workspace string) string {
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = workspace
+
+	out, err := util.RunCmdOut(cmd)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
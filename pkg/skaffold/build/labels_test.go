@@ -0,0 +1,81 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestImageLabels(t *testing.T) {
+	var tests = []struct {
+		description string
+		labels      map[string]string
+		command     *testutil.FakeCmd
+		env         []string
+		expected    map[string]string
+		shouldErr   bool
+	}{
+		{
+			description: "no labels",
+		},
+		{
+			description: "templated labels",
+			labels: map[string]string{
+				"org.opencontainers.image.revision": "{{.GIT_COMMIT}}",
+				"org.opencontainers.image.created":  "{{.BUILD_TIME}}",
+				"org.opencontainers.image.vendor":   "{{.VENDOR}}",
+				"team":                              "backend",
+			},
+			command: testutil.FakeRunOut(t, "git rev-parse HEAD", "a1b2c3\n"),
+			env:     []string{"VENDOR=acme"},
+			expected: map[string]string{
+				"org.opencontainers.image.revision": "a1b2c3",
+				"org.opencontainers.image.created":  "2019-06-01T10:00:00Z",
+				"org.opencontainers.image.vendor":   "acme",
+				"team":                              "backend",
+			},
+		},
+		{
+			description: "not a git repository",
+			labels:      map[string]string{"revision": "{{.GIT_COMMIT}}"},
+			command:     testutil.FakeRunOutErr(t, "git rev-parse HEAD", "", errors.New("not a git repository")),
+			expected:    map[string]string{"revision": ""},
+		},
+		{
+			description: "invalid template",
+			labels:      map[string]string{"revision": "{{.GIT_COMMIT"},
+			command:     testutil.FakeRunOut(t, "git rev-parse HEAD", "a1b2c3"),
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			defer testutil.Override(t, &util.DefaultExecCommand, test.command)()
+			defer testutil.Override(t, &util.OSEnviron, func() []string { return test.env })()
+			defer testutil.Override(t, &now, func() time.Time { return time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC) })()
+
+			labels, err := ImageLabels(test.labels, ".")
+
+			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.expected, labels)
+		})
+	}
+}
@@ -27,7 +27,7 @@ import (
 )
 
 func (b *Builder) buildCustom(ctx context.Context, out io.Writer, artifact *latest.Artifact, tag string) (string, error) {
-	customArtifactBuilder := custom.NewArtifactBuilder(b.pushImages, b.localDocker.ExtraEnv())
+	customArtifactBuilder := custom.NewArtifactBuilder(b.pushImages, b.localDocker.ExtraEnv(), b.insecureRegistries)
 
 	if err := customArtifactBuilder.Build(ctx, out, artifact, tag); err != nil {
 		return "", errors.Wrap(err, "building custom artifact")
@@ -18,9 +18,11 @@ package local
 
 import (
 	"context"
+	"fmt"
 	"io"
-	"os/exec"
 
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/docker"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
@@ -29,25 +31,40 @@ import (
 )
 
 func (b *Builder) buildDocker(ctx context.Context, out io.Writer, a *latest.Artifact, tag string) (string, error) {
+	if err := b.checkDaemonOS(ctx, a); err != nil {
+		return "", err
+	}
+
 	if err := b.pullCacheFromImages(ctx, out, a.ArtifactType.DockerArtifact); err != nil {
 		return "", errors.Wrap(err, "pulling cache-from images")
 	}
 
-	var (
-		imageID string
-		err     error
-	)
+	labels, err := build.ImageLabels(b.labels, a.Workspace)
+	if err != nil {
+		return "", err
+	}
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[constants.Labels.Artifact] = a.ImageName
 
+	var imageID string
 	if b.cfg.UseDockerCLI || b.cfg.UseBuildkit {
-		imageID, err = b.dockerCLIBuild(ctx, out, a.Workspace, a.ArtifactType.DockerArtifact, tag)
+		imageID, err = b.dockerCLIBuild(ctx, out, a.Workspace, a.ArtifactType.DockerArtifact, tag, labels)
 	} else {
-		imageID, err = b.localDocker.Build(ctx, out, a.Workspace, a.ArtifactType.DockerArtifact, tag)
+		imageID, err = b.localDocker.Build(ctx, out, a.Workspace, a.ArtifactType.DockerArtifact, tag, labels)
 	}
 
 	if err != nil {
 		return "", err
 	}
 
+	if b.cfg.Reproducible {
+		if imageID, err = b.normalizeImage(ctx, out, tag); err != nil {
+			return "", errors.Wrap(err, "making image reproducible")
+		}
+	}
+
 	if b.pushImages {
 		return b.localDocker.Push(ctx, out, tag)
 	}
@@ -55,7 +72,24 @@ func (b *Builder) buildDocker(ctx context.Context, out io.Writer, a *latest.Arti
 	return imageID, nil
 }
 
-func (b *Builder) dockerCLIBuild(ctx context.Context, out io.Writer, workspace string, a *latest.DockerArtifact, tag string) (string, error) {
+// checkDaemonOS makes sure that Windows images are built by a Docker daemon that runs Windows containers.
+func (b *Builder) checkDaemonOS(ctx context.Context, a *latest.Artifact) error {
+	if docker.PlatformOS(a.DockerArtifact.Platform) != "windows" {
+		return nil
+	}
+
+	v, err := b.localDocker.ServerVersion(ctx)
+	if err != nil {
+		return errors.Wrap(err, "getting docker daemon version")
+	}
+	if v.Os != "windows" {
+		return fmt.Errorf("%s is built for windows but the Docker daemon runs %s containers: set DOCKER_HOST to a Windows Docker host, or switch Docker Desktop to Windows containers", a.ImageName, v.Os)
+	}
+
+	return nil
+}
+
+func (b *Builder) dockerCLIBuild(ctx context.Context, out io.Writer, workspace string, a *latest.DockerArtifact, tag string, labels map[string]string) (string, error) {
 	dockerfilePath, err := docker.NormalizeDockerfilePath(workspace, a.DockerfilePath)
 	if err != nil {
 		return "", errors.Wrap(err, "normalizing dockerfile path")
@@ -67,25 +101,35 @@ func (b *Builder) dockerCLIBuild(ctx context.Context, out io.Writer, workspace s
 		return "", errors.Wrap(err, "getting docker build args")
 	}
 	args = append(args, ba...)
+	args = append(args, docker.GetLabelArgs(labels)...)
 
 	if b.prune {
 		args = append(args, "--force-rm")
 	}
 
-	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd := util.CommandContext(ctx, "docker", args...)
 	if b.cfg.UseBuildkit {
 		cmd.Env = append(util.OSEnviron(), "DOCKER_BUILDKIT=1")
 	}
 	cmd.Stdout = out
 	cmd.Stderr = out
 
-	if err := util.RunCmd(cmd); err != nil {
+	if err := cmd.Run(); err != nil {
 		return "", errors.Wrap(err, "running build")
 	}
 
 	return b.localDocker.ImageID(ctx, tag)
 }
 
+func (b *Builder) normalizeImage(ctx context.Context, out io.Writer, tag string) (string, error) {
+	t, err := docker.ReproducibleTime()
+	if err != nil {
+		return "", err
+	}
+
+	return b.localDocker.Normalize(ctx, out, tag, t)
+}
+
 func (b *Builder) pullCacheFromImages(ctx context.Context, out io.Writer, a *latest.DockerArtifact) error {
 	if len(a.CacheFrom) == 0 {
 		return nil
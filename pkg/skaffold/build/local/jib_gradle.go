@@ -20,6 +20,7 @@ import (
 	"context"
 	"io"
 
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/docker"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/jib"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
@@ -36,7 +37,7 @@ func (b *Builder) buildJibGradle(ctx context.Context, out io.Writer, workspace s
 }
 
 func (b *Builder) buildJibGradleToDocker(ctx context.Context, out io.Writer, workspace string, artifact *latest.JibGradleArtifact, tag string) (string, error) {
-	args := jib.GenerateGradleArgs("jibDockerBuild", tag, artifact, b.skipTests)
+	args := b.gradleArgs("jibDockerBuild", tag, artifact)
 	if err := b.runGradleCommand(ctx, out, workspace, args); err != nil {
 		return "", err
 	}
@@ -45,7 +46,7 @@ func (b *Builder) buildJibGradleToDocker(ctx context.Context, out io.Writer, wor
 }
 
 func (b *Builder) buildJibGradleToRegistry(ctx context.Context, out io.Writer, workspace string, artifact *latest.JibGradleArtifact, tag string) (string, error) {
-	args := jib.GenerateGradleArgs("jib", tag, artifact, b.skipTests)
+	args := b.gradleArgs("jib", tag, artifact)
 	if err := b.runGradleCommand(ctx, out, workspace, args); err != nil {
 		return "", err
 	}
@@ -53,7 +54,23 @@ func (b *Builder) buildJibGradleToRegistry(ctx context.Context, out io.Writer, w
 	return docker.RemoteDigest(tag, b.insecureRegistries)
 }
 
+// gradleArgs generates the arguments to Gradle. In the dev loop, a daemon can be
+// required, for projects that disable it with `org.gradle.daemon=false`.
+func (b *Builder) gradleArgs(task, tag string, artifact *latest.JibGradleArtifact) []string {
+	args := jib.GenerateGradleArgs(task, tag, artifact, b.skipTests)
+	if b.devLoop && artifact.Daemon {
+		args = append(args, "--daemon")
+	}
+	return args
+}
+
 func (b *Builder) runGradleCommand(ctx context.Context, out io.Writer, workspace string, args []string) error {
+	labels, err := build.ImageLabels(b.labels, workspace)
+	if err != nil {
+		return err
+	}
+	args = append(args, jib.GenerateLabelArgs(labels)...)
+
 	cmd := jib.GradleCommand.CreateCommand(ctx, workspace, args)
 	cmd.Env = append(util.OSEnviron(), b.localDocker.ExtraEnv()...)
 	cmd.Stdout = out
@@ -19,7 +19,9 @@ package local
 import (
 	"context"
 	"io"
+	"os/exec"
 
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/docker"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/jib"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
@@ -28,6 +30,9 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// For testing
+var lookPath = exec.LookPath
+
 func (b *Builder) buildJibMaven(ctx context.Context, out io.Writer, workspace string, artifact *latest.JibMavenArtifact, tag string) (string, error) {
 	if b.pushImages {
 		return b.buildJibMavenToRegistry(ctx, out, workspace, artifact, tag)
@@ -44,7 +49,7 @@ func (b *Builder) buildJibMavenToDocker(ctx context.Context, out io.Writer, work
 	}
 
 	args := jib.GenerateMavenArgs("dockerBuild", tag, artifact, b.skipTests)
-	if err := b.runMavenCommand(ctx, out, workspace, args); err != nil {
+	if err := b.runMavenCommand(ctx, out, workspace, artifact, args); err != nil {
 		return "", err
 	}
 
@@ -60,7 +65,7 @@ func (b *Builder) buildJibMavenToRegistry(ctx context.Context, out io.Writer, wo
 	}
 
 	args := jib.GenerateMavenArgs("build", tag, artifact, b.skipTests)
-	if err := b.runMavenCommand(ctx, out, workspace, args); err != nil {
+	if err := b.runMavenCommand(ctx, out, workspace, artifact, args); err != nil {
 		return "", err
 	}
 
@@ -93,8 +98,14 @@ func verifyJibPackageGoal(ctx context.Context, requiredGoal string, workspace st
 	return nil
 }
 
-func (b *Builder) runMavenCommand(ctx context.Context, out io.Writer, workspace string, args []string) error {
-	cmd := jib.MavenCommand.CreateCommand(ctx, workspace, args)
+func (b *Builder) runMavenCommand(ctx context.Context, out io.Writer, workspace string, artifact *latest.JibMavenArtifact, args []string) error {
+	labels, err := build.ImageLabels(b.labels, workspace)
+	if err != nil {
+		return err
+	}
+	args = append(args, jib.GenerateLabelArgs(labels)...)
+
+	cmd := b.mavenCommand(workspace, artifact).CreateCommand(ctx, workspace, args)
 	cmd.Env = append(util.OSEnviron(), b.localDocker.ExtraEnv()...)
 	cmd.Stdout = out
 	cmd.Stderr = out
@@ -106,3 +117,30 @@ func (b *Builder) runMavenCommand(ctx context.Context, out io.Writer, workspace
 
 	return nil
 }
+
+// mavenCommand selects the Maven Daemon, when it's available, to keep
+// a warm JVM between the builds of the dev loop. Projects with a Maven
+// wrapper keep using it, since it pins the version of Maven.
+func (b *Builder) mavenCommand(workspace string, artifact *latest.JibMavenArtifact) util.CommandWrapper {
+	if !b.devLoop || !artifact.Daemon {
+		return jib.MavenCommand
+	}
+	if hasMavenWrapper(workspace) {
+		logrus.Debugf("Using the Maven wrapper instead of %s", jib.MavenDaemonCommand.Executable)
+		return jib.MavenCommand
+	}
+	if _, err := lookPath(jib.MavenDaemonCommand.Executable); err != nil {
+		logrus.Debugf("%s not found, using %s", jib.MavenDaemonCommand.Executable, jib.MavenCommand.Executable)
+		return jib.MavenCommand
+	}
+	return jib.MavenDaemonCommand
+}
+
+func hasMavenWrapper(workspace string) bool {
+	for _, extension := range []string{"", ".cmd", ".bat"} {
+		if _, err := util.AbsFile(workspace, jib.MavenCommand.Wrapper+extension); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -18,6 +18,7 @@ package local
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
@@ -56,3 +57,104 @@ func TestMavenVerifyJibPackageGoal(t *testing.T) {
 		}
 	}
 }
+
+func TestMavenCommand(t *testing.T) {
+	var tests = []struct {
+		description string
+		devLoop     bool
+		daemon      bool
+		mvndFound   bool
+		wrapper     bool
+		expected    string
+	}{
+		{
+			description: "mvnd in the dev loop",
+			devLoop:     true,
+			daemon:      true,
+			mvndFound:   true,
+			expected:    "mvnd",
+		},
+		{
+			description: "mvnd not found",
+			devLoop:     true,
+			daemon:      true,
+			expected:    "mvn",
+		},
+		{
+			description: "project with a Maven wrapper",
+			devLoop:     true,
+			daemon:      true,
+			mvndFound:   true,
+			wrapper:     true,
+			expected:    "mvn",
+		},
+		{
+			description: "daemon not enabled",
+			devLoop:     true,
+			mvndFound:   true,
+			expected:    "mvn",
+		},
+		{
+			description: "not in the dev loop",
+			daemon:      true,
+			mvndFound:   true,
+			expected:    "mvn",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			reset := testutil.Override(t, &lookPath, func(file string) (string, error) {
+				if test.mvndFound {
+					return "/usr/bin/" + file, nil
+				}
+				return "", errors.New("not found")
+			})
+			defer reset()
+
+			tmpDir, cleanup := testutil.NewTempDir(t)
+			defer cleanup()
+			if test.wrapper {
+				tmpDir.Write("mvnw", "")
+			}
+
+			builder := &Builder{devLoop: test.devLoop}
+			command := builder.mavenCommand(tmpDir.Root(), &latest.JibMavenArtifact{Daemon: test.daemon})
+
+			testutil.CheckDeepEqual(t, test.expected, command.Executable)
+		})
+	}
+}
+
+func TestGradleArgs(t *testing.T) {
+	var tests = []struct {
+		description string
+		devLoop     bool
+		daemon      bool
+		expected    []string
+	}{
+		{
+			description: "daemon in the dev loop",
+			devLoop:     true,
+			daemon:      true,
+			expected:    []string{"-Djib.console=plain", ":jib", "--image=img", "--daemon"},
+		},
+		{
+			description: "daemon not enabled",
+			devLoop:     true,
+			expected:    []string{"-Djib.console=plain", ":jib", "--image=img"},
+		},
+		{
+			description: "not in the dev loop",
+			daemon:      true,
+			expected:    []string{"-Djib.console=plain", ":jib", "--image=img"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			builder := &Builder{devLoop: test.devLoop}
+			args := builder.gradleArgs("jib", "img", &latest.JibGradleArtifact{Daemon: test.daemon})
+
+			testutil.CheckDeepEqual(t, test.expected, args)
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/bazel"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/custom"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/plugin"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/tag"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/docker"
@@ -43,8 +44,21 @@ func (b *Builder) Build(ctx context.Context, out io.Writer, tags tag.ImageTags,
 	}
 	defer b.localDocker.Close()
 
+	b.lastBuilt = map[string]bool{}
+
 	// TODO(dgageot): parallel builds
-	return build.InSequence(ctx, out, tags, artifacts, b.buildArtifact)
+	built, err := build.InSequence(ctx, out, tags, artifacts, b.buildArtifact)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.prune && b.cfg.Prune != nil && b.cfg.Prune.AfterEachBuild {
+		if err := b.pruneArtifactImages(ctx, out, b.cfg.Prune.Keep, b.lastBuilt); err != nil {
+			logrus.Warnln("pruning images:", err)
+		}
+	}
+
+	return built, nil
 }
 
 func (b *Builder) buildArtifact(ctx context.Context, out io.Writer, artifact *latest.Artifact, tag string) (string, error) {
@@ -63,6 +77,7 @@ func (b *Builder) buildArtifact(ctx context.Context, out io.Writer, artifact *la
 			}
 			if imageID != "" {
 				b.builtImages = append(b.builtImages, imageID)
+				b.trackImage(artifact.ImageName, tag, imageID)
 			}
 		}
 		digest := digestOrImageID
@@ -75,11 +90,18 @@ func (b *Builder) buildArtifact(ctx context.Context, out io.Writer, artifact *la
 	// the imageID, and use that in the manifests.
 	imageID := digestOrImageID
 	b.builtImages = append(b.builtImages, imageID)
+	b.trackImage(artifact.ImageName, tag, imageID)
 	uniqueTag := artifact.ImageName + ":" + strings.TrimPrefix(imageID, "sha256:")
 	if err := b.localDocker.Tag(ctx, imageID, uniqueTag); err != nil {
 		return "", err
 	}
 
+	if b.localCluster && b.imageLoader != nil {
+		if err := b.imageLoader.LoadImage(ctx, out, uniqueTag); err != nil {
+			return "", err
+		}
+	}
+
 	return uniqueTag, nil
 }
 
@@ -99,6 +121,10 @@ func (b *Builder) runBuildForArtifact(ctx context.Context, out io.Writer, artifa
 
 	case artifact.CustomArtifact != nil:
 		return b.buildCustom(ctx, out, artifact, tag)
+
+	case artifact.PluginArtifact != nil:
+		return plugin.Build(ctx, out, artifact, tag, b.pushImages)
+
 	default:
 		return "", fmt.Errorf("undefined artifact type: %+v", artifact.ArtifactType)
 	}
@@ -112,7 +138,7 @@ func (b *Builder) DependenciesForArtifact(ctx context.Context, a *latest.Artifac
 
 	switch {
 	case a.DockerArtifact != nil:
-		paths, err = docker.GetDependencies(ctx, a.Workspace, a.DockerArtifact.DockerfilePath, a.DockerArtifact.BuildArgs, b.insecureRegistries)
+		paths, err = docker.GetDependencies(ctx, a.Workspace, a.DockerArtifact.DockerfilePath, a.DockerArtifact.BuildArgs, a.DockerArtifact.Target, a.DockerArtifact.DockerignorePath, b.insecureRegistries)
 
 	case a.BazelArtifact != nil:
 		paths, err = bazel.GetDependencies(ctx, a.Workspace, a.BazelArtifact)
@@ -126,6 +152,9 @@ func (b *Builder) DependenciesForArtifact(ctx context.Context, a *latest.Artifac
 	case a.CustomArtifact != nil:
 		paths, err = custom.GetDependencies(ctx, a.Workspace, a.CustomArtifact, b.insecureRegistries)
 
+	case a.PluginArtifact != nil:
+		paths, err = plugin.GetDependencies(ctx, a)
+
 	default:
 		return nil, fmt.Errorf("undefined artifact type: %+v", a.ArtifactType)
 	}
@@ -198,6 +198,39 @@ func TestLocalRun(t *testing.T) {
 			}},
 			expectedWarnings: []string{"Cache-From image couldn't be pulled: pull1\n"},
 		},
+		{
+			description: "windows image",
+			artifacts: []*latest.Artifact{{
+				ImageName: "gcr.io/test/image",
+				ArtifactType: latest.ArtifactType{
+					DockerArtifact: &latest.DockerArtifact{
+						Platform: "windows/amd64",
+					},
+				}},
+			},
+			tags: tag.ImageTags(map[string]string{"gcr.io/test/image": "gcr.io/test/image:tag"}),
+			api: testutil.FakeAPIClient{
+				DaemonOS: "windows",
+			},
+			expected: []build.Artifact{{
+				ImageName: "gcr.io/test/image",
+				Tag:       "gcr.io/test/image:1",
+			}},
+		},
+		{
+			description: "windows image on a linux daemon",
+			artifacts: []*latest.Artifact{{
+				ImageName: "gcr.io/test/image",
+				ArtifactType: latest.ArtifactType{
+					DockerArtifact: &latest.DockerArtifact{
+						Platform: "windows/amd64",
+					},
+				}},
+			},
+			tags:      tag.ImageTags(map[string]string{"gcr.io/test/image": "gcr.io/test/image:tag"}),
+			api:       testutil.FakeAPIClient{},
+			shouldErr: true,
+		},
 		{
 			description: "error checking cache-from image",
 			artifacts: []*latest.Artifact{{
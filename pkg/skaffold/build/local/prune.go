@@ -0,0 +1,82 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package local
+
+import (
+	"context"
+	"io"
+	"sort"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/tag"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/docker"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/pkg/errors"
+)
+
+// PruneArtifacts removes the images built by Skaffold for the given artifacts,
+// except for the `keep` most recent ones. Only the images tagged in the artifact's
+// repositories are removed.
+func (b *Builder) PruneArtifacts(ctx context.Context, out io.Writer, tags tag.ImageTags, artifacts []*latest.Artifact, keep int) error {
+	defer b.localDocker.Close()
+
+	for _, a := range artifacts {
+		b.trackRepository(a.ImageName, a.ImageName)
+		if t, present := tags[a.ImageName]; present {
+			b.trackRepository(a.ImageName, t)
+		}
+	}
+
+	return b.pruneArtifactImages(ctx, out, keep, nil)
+}
+
+// trackImage records an image built for an artifact, so that it can be pruned later.
+func (b *Builder) trackImage(imageName, tag, imageID string) {
+	b.trackRepository(imageName, imageName)
+	b.trackRepository(imageName, tag)
+	b.lastBuilt[imageID] = true
+}
+
+func (b *Builder) trackRepository(imageName, ref string) {
+	parsed, err := docker.ParseReference(ref)
+	if err != nil {
+		return
+	}
+
+	if b.repositories == nil {
+		b.repositories = map[string]map[string]bool{}
+	}
+	if b.repositories[imageName] == nil {
+		b.repositories[imageName] = map[string]bool{}
+	}
+	b.repositories[imageName][parsed.BaseName] = true
+}
+
+func (b *Builder) pruneArtifactImages(ctx context.Context, out io.Writer, keep int, protected map[string]bool) error {
+	var imageNames []string
+	for imageName := range b.repositories {
+		imageNames = append(imageNames, imageName)
+	}
+	sort.Strings(imageNames)
+
+	for _, imageName := range imageNames {
+		if err := docker.PruneArtifactImages(ctx, out, b.localDocker, imageName, b.repositories[imageName], keep, protected); err != nil {
+			return errors.Wrapf(err, "pruning images of %s", imageName)
+		}
+	}
+
+	return nil
+}
@@ -24,6 +24,7 @@ import (
 	configutil "github.com/GoogleContainerTools/skaffold/cmd/skaffold/app/cmd/config"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/docker"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/localcluster"
 	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/pkg/errors"
@@ -39,8 +40,13 @@ type Builder struct {
 	pushImages         bool
 	prune              bool
 	skipTests          bool
+	devLoop            bool
 	kubeContext        string
+	imageLoader        localcluster.Loader
+	labels             map[string]string
 	builtImages        []string
+	repositories       map[string]map[string]bool
+	lastBuilt          map[string]bool
 	insecureRegistries map[string]bool
 }
 
@@ -55,6 +61,10 @@ func NewBuilder(runCtx *runcontext.RunContext) (*Builder, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "getting localCluster")
 	}
+	if runCtx.Opts.ClusterRegistry {
+		// Images are pushed to the registry in the cluster, even if it's local.
+		localCluster = false
+	}
 
 	var pushImages bool
 	if runCtx.Cfg.Build.LocalBuild.Push == nil {
@@ -64,14 +74,22 @@ func NewBuilder(runCtx *runcontext.RunContext) (*Builder, error) {
 		pushImages = *runCtx.Cfg.Build.LocalBuild.Push
 	}
 
+	// Images built for kind, k3d, microk8s, or minikube without its docker daemon, have to be loaded into the cluster.
+	imageLoader, _ := localcluster.NewLoader(runCtx.KubeContext, docker.UsesMinikubeDaemon(localDocker))
+
 	return &Builder{
 		cfg:                runCtx.Cfg.Build.LocalBuild,
 		kubeContext:        runCtx.KubeContext,
+		imageLoader:        imageLoader,
+		labels:             runCtx.Cfg.Build.Labels,
 		localDocker:        localDocker,
 		localCluster:       localCluster,
 		pushImages:         pushImages,
 		skipTests:          runCtx.Opts.SkipTests,
+		devLoop:            runCtx.Opts.IsDevLoop(),
 		prune:              runCtx.Opts.Prune(),
+		repositories:       map[string]map[string]bool{},
+		lastBuilt:          map[string]bool{},
 		insecureRegistries: runCtx.InsecureRegistries,
 	}, nil
 }
@@ -90,7 +108,11 @@ func (b *Builder) Labels() map[string]string {
 	return labels
 }
 
-// Prune uses the docker API client to remove all images built with Skaffold
+// Prune uses the docker API client to remove all images built with Skaffold,
+// or only the older ones if `build.local.prune` is configured.
 func (b *Builder) Prune(ctx context.Context, out io.Writer) error {
+	if b.cfg.Prune != nil {
+		return b.pruneArtifactImages(ctx, out, b.cfg.Prune.Keep, nil)
+	}
 	return docker.Prune(ctx, out, b.builtImages, b.localDocker)
 }
@@ -22,11 +22,15 @@ import (
 	"fmt"
 	"io"
 	"sync"
+	"time"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/tag"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/ci"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/output"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/trace"
 	"github.com/pkg/errors"
 )
 
@@ -76,11 +80,18 @@ func InParallel(ctx context.Context, out io.Writer, tags tag.ImageTags, artifact
 func runBuild(ctx context.Context, cw io.WriteCloser, tags tag.ImageTags, artifact *latest.Artifact, results *sync.Map, build artifactBuilder) {
 	event.BuildInProgress(artifact.ImageName)
 
+	ctx, endTrace := trace.StartTrace(ctx, "build artifact", map[string]string{"image": artifact.ImageName})
+	start := time.Now()
 	finalTag, err := getBuildResult(ctx, cw, tags, artifact, build)
+	endTrace(err)
+	ci.EndSection(output.Headlines(cw), "build "+artifact.ImageName)
 	if err != nil {
 		event.BuildFailed(artifact.ImageName, err)
+		annotateBuildFailure(cw, artifact, err)
 		results.Store(artifact.ImageName, err)
 	} else {
+		recordDuration(artifact.ImageName, start)
+		recordCompleted(artifact.ImageName, finalTag)
 		event.BuildComplete(artifact.ImageName)
 		artifact := Artifact{ImageName: artifact.ImageName, Tag: finalTag}
 		results.Store(artifact.ImageName, artifact)
@@ -104,16 +115,16 @@ func setUpColorWriter(w io.WriteCloser, out io.Writer) io.WriteCloser {
 }
 
 func getBuildResult(ctx context.Context, cw io.Writer, tags tag.ImageTags, artifact *latest.Artifact, build artifactBuilder) (string, error) {
-	color.Default.Fprintf(cw, "Building [%s]...\n", artifact.ImageName)
+	ci.StartSection(output.Headlines(cw), "build "+artifact.ImageName, fmt.Sprintf("Building [%s]...", artifact.ImageName))
 	tag, present := tags[artifact.ImageName]
 	if !present {
 		return "", fmt.Errorf("unable to find tag for image %s", artifact.ImageName)
 	}
-	return build(ctx, cw, artifact, tag)
+	return buildWithTimeout(ctx, cw, artifact, tag, build)
 }
 
 func collectResults(out io.Writer, artifacts []*latest.Artifact, results *sync.Map, outputs []chan []byte) ([]Artifact, error) {
-	var built []Artifact
+	summary := newBuildSummary()
 	for i, artifact := range artifacts {
 		// Wait for build to complete.
 		printResult(out, outputs[i])
@@ -123,14 +134,18 @@ func collectResults(out io.Writer, artifacts []*latest.Artifact, results *sync.M
 		}
 		switch t := v.(type) {
 		case error:
+			if KeepGoing {
+				summary.failure(artifact.ImageName, t)
+				continue
+			}
 			return nil, errors.Wrapf(t, "building [%s]", artifact.ImageName)
 		case Artifact:
-			built = append(built, t)
+			summary.success(t)
 		default:
 			return nil, fmt.Errorf("unknown type %T for %s", t, artifact.ImageName)
 		}
 	}
-	return built, nil
+	return summary.result(out, artifacts)
 }
 
 func printResult(out io.Writer, output chan []byte) {
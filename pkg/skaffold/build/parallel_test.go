@@ -27,6 +27,7 @@ import (
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/tag"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/output"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/GoogleContainerTools/skaffold/testutil"
 )
@@ -36,6 +37,7 @@ func TestGetBuild(t *testing.T) {
 		description   string
 		buildArtifact artifactBuilder
 		tags          tag.ImageTags
+		level         output.Level
 		expectedTag   string
 		expectedOut   string
 		shouldErr     bool
@@ -50,9 +52,47 @@ func TestGetBuild(t *testing.T) {
 				"skaffold/image1": "skaffold/image1:v0.0.1",
 				"skaffold/image2": "skaffold/image2:v0.0.2",
 			},
+			level:       output.Verbose,
 			expectedTag: "skaffold/image1:v0.0.1@sha256:abac",
 			expectedOut: "Building [skaffold/image1]...\nbuild succeeds",
 		},
+		{
+			description: "builder output is held back on success",
+			buildArtifact: func(ctx context.Context, out io.Writer, artifact *latest.Artifact, tag string) (string, error) {
+				out.Write([]byte("build succeeds"))
+				return fmt.Sprintf("%s@sha256:abac", tag), nil
+			},
+			tags: tag.ImageTags{
+				"skaffold/image1": "skaffold/image1:v0.0.1",
+			},
+			expectedTag: "skaffold/image1:v0.0.1@sha256:abac",
+			expectedOut: "Building [skaffold/image1]...\n",
+		},
+		{
+			description: "builder output is printed on failure",
+			buildArtifact: func(ctx context.Context, out io.Writer, artifact *latest.Artifact, tag string) (string, error) {
+				out.Write([]byte("step 2 failed"))
+				return "", fmt.Errorf("build fails")
+			},
+			tags: tag.ImageTags{
+				"skaffold/image1": "",
+			},
+			expectedOut: "Building [skaffold/image1]...\nstep 2 failed",
+			shouldErr:   true,
+		},
+		{
+			description: "quiet",
+			buildArtifact: func(ctx context.Context, out io.Writer, artifact *latest.Artifact, tag string) (string, error) {
+				out.Write([]byte("build succeeds"))
+				return fmt.Sprintf("%s@sha256:abac", tag), nil
+			},
+			tags: tag.ImageTags{
+				"skaffold/image1": "skaffold/image1:v0.0.1",
+			},
+			level:       output.Quiet,
+			expectedTag: "skaffold/image1:v0.0.1@sha256:abac",
+			expectedOut: "",
+		},
 		{
 			description: "build fails",
 			buildArtifact: func(ctx context.Context, out io.Writer, artifact *latest.Artifact, tag string) (string, error) {
@@ -73,6 +113,8 @@ func TestGetBuild(t *testing.T) {
 	}
 	for _, test := range tests {
 		t.Run(test.description, func(t *testing.T) {
+			defer testutil.Override(t, &output.Current, test.level)()
+
 			out := new(bytes.Buffer)
 			artifact := &latest.Artifact{ImageName: "skaffold/image1"}
 			got, err := getBuildResult(context.Background(), out, test.tags, artifact, test.buildArtifact)
@@ -190,6 +232,28 @@ func TestCollectResults(t *testing.T) {
 	}
 }
 
+func TestCollectResultsKeepGoing(t *testing.T) {
+	defer func(k bool) { KeepGoing = k }(KeepGoing)
+	KeepGoing = true
+
+	artifacts := []*latest.Artifact{
+		{ImageName: "skaffold/image1"},
+		{ImageName: "skaffold/image2"},
+		{ImageName: "skaffold/image3"},
+	}
+	resultMap := new(sync.Map)
+	resultMap.Store("skaffold/image1", fmt.Errorf("missing file"))
+	resultMap.Store("skaffold/image2", Artifact{ImageName: "skaffold/image2", Tag: "skaffold/image2:v1"})
+	resultMap.Store("skaffold/image3", fmt.Errorf("unauthorized"))
+
+	out := new(bytes.Buffer)
+	got, err := collectResults(out, artifacts, resultMap, setUpChannels(len(artifacts)))
+
+	testutil.CheckErrorAndDeepEqual(t, true, err, []Artifact(nil), got)
+	testutil.CheckDeepEqual(t, "2 of 3 artifacts failed to build: building [skaffold/image1]: missing file; building [skaffold/image3]: unauthorized", err.Error())
+	testutil.CheckDeepEqual(t, "Build summary:\n - skaffold/image1: Failed: missing file\n - skaffold/image2: Built\n - skaffold/image3: Failed: unauthorized\n", out.String())
+}
+
 func TestInParallel(t *testing.T) {
 	var tests = []struct {
 		description string
@@ -221,6 +285,8 @@ And new lines
 	}
 	for _, test := range tests {
 		t.Run(test.description, func(t *testing.T) {
+			defer testutil.Override(t, &output.Current, output.Verbose)()
+
 			out := new(bytes.Buffer)
 			artifacts := []*latest.Artifact{
 				{ImageName: "skaffold/image1"},
@@ -0,0 +1,136 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	configutil "github.com/GoogleContainerTools/skaffold/cmd/skaffold/app/cmd/config"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/plugin/proto"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/pkg/errors"
+)
+
+var (
+	// For testing
+	startPlugin    = Start
+	builderPlugins = configutil.GetBuilderPlugins
+)
+
+// GetDependencies asks the plugin of an artifact for the files it's built from.
+func GetDependencies(ctx context.Context, a *latest.Artifact) ([]string, error) {
+	client, stop, err := start(a.PluginArtifact, ioutil.Discard)
+	if err != nil {
+		return nil, err
+	}
+	defer stop()
+
+	resp, err := client.Dependencies(ctx, &proto.DependenciesRequest{
+		Workspace: a.Workspace,
+		ImageName: a.ImageName,
+		Config:    a.PluginArtifact.Config,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting dependencies from plugin %s", a.PluginArtifact.Name)
+	}
+
+	return resp.Paths, nil
+}
+
+// Build asks the plugin of an artifact to build and tag its image.
+// It returns the digest of the pushed image, or the ID of the image
+// loaded into the local Docker daemon.
+func Build(ctx context.Context, out io.Writer, a *latest.Artifact, tag string, push bool) (string, error) {
+	client, stop, err := start(a.PluginArtifact, out)
+	if err != nil {
+		return "", err
+	}
+	defer stop()
+
+	resp, err := client.Build(ctx, &proto.BuildRequest{
+		Workspace: a.Workspace,
+		ImageName: a.ImageName,
+		Tag:       tag,
+		Push:      push,
+		Config:    a.PluginArtifact.Config,
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "building with plugin %s", a.PluginArtifact.Name)
+	}
+	fmt.Fprint(out, resp.Output)
+
+	if resp.Digest == "" {
+		return "", fmt.Errorf("plugin %s didn't return the digest of %s", a.PluginArtifact.Name, tag)
+	}
+	return resp.Digest, nil
+}
+
+// CheckSyncModes checks that the plugins of the artifacts support the
+// sync modes that the artifacts are configured with.
+func CheckSyncModes(ctx context.Context, artifacts []*latest.Artifact) error {
+	for _, a := range artifacts {
+		if a.PluginArtifact == nil || a.Sync == nil || len(a.Sync.Manual) == 0 {
+			continue
+		}
+
+		modes, err := syncModes(ctx, a.PluginArtifact)
+		if err != nil {
+			return err
+		}
+		if !modes[proto.SyncModesResponse_MANUAL] {
+			return fmt.Errorf("artifact %s can't be synced manually: plugin %s doesn't support it", a.ImageName, a.PluginArtifact.Name)
+		}
+	}
+	return nil
+}
+
+func syncModes(ctx context.Context, a *latest.PluginArtifact) (map[proto.SyncModesResponse_SyncMode]bool, error) {
+	client, stop, err := start(a, ioutil.Discard)
+	if err != nil {
+		return nil, err
+	}
+	defer stop()
+
+	resp, err := client.SyncModes(ctx, &proto.SyncModesRequest{Config: a.Config})
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting sync modes from plugin %s", a.Name)
+	}
+
+	modes := map[proto.SyncModesResponse_SyncMode]bool{}
+	for _, mode := range resp.Modes {
+		modes[mode] = true
+	}
+	return modes, nil
+}
+
+// start runs the plugin of an artifact, as registered in the global config.
+func start(a *latest.PluginArtifact, stderr io.Writer) (proto.BuilderClient, func(), error) {
+	plugins, err := builderPlugins()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	command, found := plugins[a.Name]
+	if !found {
+		return nil, nil, fmt.Errorf("unknown builder plugin %s, register it with `skaffold config set builder-plugins %s=COMMAND`", a.Name, a.Name)
+	}
+
+	return startPlugin(command, stderr)
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/plugin/proto"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	plugin "github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+)
+
+type fakeBuilderPlugin struct {
+	proto.UnimplementedBuilderServer
+
+	built     *proto.BuildRequest
+	syncModes []proto.SyncModesResponse_SyncMode
+}
+
+func (f *fakeBuilderPlugin) Dependencies(_ context.Context, req *proto.DependenciesRequest) (*proto.DependenciesResponse, error) {
+	return &proto.DependenciesResponse{Paths: []string{"BUILD", req.Config["main"]}}, nil
+}
+
+func (f *fakeBuilderPlugin) Build(_ context.Context, req *proto.BuildRequest) (*proto.BuildResponse, error) {
+	f.built = req
+	if req.Tag == "" {
+		return &proto.BuildResponse{}, nil
+	}
+	return &proto.BuildResponse{Digest: "sha256:abac", Output: "built " + req.Tag + "\n"}, nil
+}
+
+func (f *fakeBuilderPlugin) SyncModes(context.Context, *proto.SyncModesRequest) (*proto.SyncModesResponse, error) {
+	return &proto.SyncModesResponse{Modes: f.syncModes}, nil
+}
+
+func servePlugin(t *testing.T, impl proto.BuilderServer) func() {
+	resetPlugins := testutil.Override(t, &builderPlugins, func() (map[string]string, error) {
+		return map[string]string{"nix": "/usr/local/bin/nix-builder"}, nil
+	})
+	resetStart := testutil.Override(t, &startPlugin, func(command string, _ io.Writer) (proto.BuilderClient, func(), error) {
+		testutil.CheckDeepEqual(t, "/usr/local/bin/nix-builder", command)
+
+		conn, server := plugin.TestGRPCConn(t, func(s *grpc.Server) {
+			proto.RegisterBuilderServer(s, impl)
+		})
+		return proto.NewBuilderClient(conn), func() {
+			conn.Close()
+			server.Stop()
+		}, nil
+	})
+
+	return func() {
+		resetStart()
+		resetPlugins()
+	}
+}
+
+func pluginArtifact(name string) *latest.Artifact {
+	return &latest.Artifact{
+		ImageName: "app",
+		Workspace: "app",
+		ArtifactType: latest.ArtifactType{
+			PluginArtifact: &latest.PluginArtifact{
+				Name:   name,
+				Config: map[string]string{"main": "main.nix"},
+			},
+		},
+	}
+}
+
+func TestGetDependencies(t *testing.T) {
+	defer servePlugin(t, &fakeBuilderPlugin{})()
+
+	deps, err := GetDependencies(context.Background(), pluginArtifact("nix"))
+
+	testutil.CheckErrorAndDeepEqual(t, false, err, []string{"BUILD", "main.nix"}, deps)
+}
+
+func TestGetDependenciesUnknownPlugin(t *testing.T) {
+	defer servePlugin(t, &fakeBuilderPlugin{})()
+
+	_, err := GetDependencies(context.Background(), pluginArtifact("unknown"))
+
+	testutil.CheckError(t, true, err)
+}
+
+func TestBuild(t *testing.T) {
+	tests := []struct {
+		description string
+		tag         string
+		expected    string
+		shouldErr   bool
+	}{
+		{
+			description: "build",
+			tag:         "app:v1",
+			expected:    "sha256:abac",
+		},
+		{
+			description: "missing digest",
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			impl := &fakeBuilderPlugin{}
+			defer servePlugin(t, impl)()
+
+			var out bytes.Buffer
+			digest, err := Build(context.Background(), &out, pluginArtifact("nix"), test.tag, true)
+
+			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.expected, digest)
+			testutil.CheckDeepEqual(t, true, impl.built.Push)
+			testutil.CheckDeepEqual(t, "app", impl.built.Workspace)
+		})
+	}
+}
+
+func TestCheckSyncModes(t *testing.T) {
+	tests := []struct {
+		description string
+		sync        *latest.Sync
+		syncModes   []proto.SyncModesResponse_SyncMode
+		shouldErr   bool
+	}{
+		{
+			description: "no sync",
+		},
+		{
+			description: "manual sync supported",
+			sync:        &latest.Sync{Manual: []*latest.SyncRule{{Src: "*.html", Dest: "."}}},
+			syncModes:   []proto.SyncModesResponse_SyncMode{proto.SyncModesResponse_MANUAL},
+		},
+		{
+			description: "manual sync not supported",
+			sync:        &latest.Sync{Manual: []*latest.SyncRule{{Src: "*.html", Dest: "."}}},
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			defer servePlugin(t, &fakeBuilderPlugin{syncModes: test.syncModes})()
+
+			artifact := pluginArtifact("nix")
+			artifact.Sync = test.sync
+			err := CheckSyncModes(context.Background(), []*latest.Artifact{artifact})
+
+			testutil.CheckError(t, test.shouldErr, err)
+		})
+	}
+}
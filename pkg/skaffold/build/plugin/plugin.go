@@ -0,0 +1,94 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"io"
+	"os/exec"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/plugin/proto"
+	hclog "github.com/hashicorp/go-hclog"
+	plugin "github.com/hashicorp/go-plugin"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+// Handshake is shared by skaffold and the builder plugins. It ensures that
+// a binary is actually meant to be run as a builder plugin.
+var Handshake = plugin.HandshakeConfig{
+	ProtocolVersion:  1,
+	MagicCookieKey:   "SKAFFOLD_BUILDER_PLUGIN",
+	MagicCookieValue: "4f9b8d3e-6a57-4c1f-9e4b-2d7c1a0f5b83",
+}
+
+const builderPlugin = "builder"
+
+// Serve is called from the main of a builder plugin, to serve
+// its implementation of the Builder service.
+func Serve(impl proto.BuilderServer) {
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: Handshake,
+		Plugins: plugin.PluginSet{
+			builderPlugin: &grpcPlugin{impl: impl},
+		},
+		GRPCServer: plugin.DefaultGRPCServer,
+	})
+}
+
+// Start runs a builder plugin and connects to it.
+// The returned function stops the plugin.
+func Start(command string, stderr io.Writer) (proto.BuilderClient, func(), error) {
+	client := plugin.NewClient(&plugin.ClientConfig{
+		HandshakeConfig:  Handshake,
+		Plugins:          plugin.PluginSet{builderPlugin: &grpcPlugin{}},
+		Cmd:              exec.Command(command),
+		AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+		Logger:           hclog.NewNullLogger(),
+		Stderr:           stderr,
+	})
+
+	rpcClient, err := client.Client()
+	if err != nil {
+		client.Kill()
+		return nil, nil, errors.Wrapf(err, "starting plugin %s", command)
+	}
+
+	raw, err := rpcClient.Dispense(builderPlugin)
+	if err != nil {
+		client.Kill()
+		return nil, nil, errors.Wrapf(err, "connecting to plugin %s", command)
+	}
+
+	return raw.(proto.BuilderClient), client.Kill, nil
+}
+
+// grpcPlugin serves and consumes the Builder service over gRPC.
+type grpcPlugin struct {
+	plugin.NetRPCUnsupportedPlugin
+
+	impl proto.BuilderServer
+}
+
+func (p *grpcPlugin) GRPCServer(_ *plugin.GRPCBroker, s *grpc.Server) error {
+	proto.RegisterBuilderServer(s, p.impl)
+	return nil
+}
+
+func (p *grpcPlugin) GRPCClient(_ context.Context, _ *plugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
+	return proto.NewBuilderClient(c), nil
+}
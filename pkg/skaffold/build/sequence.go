@@ -20,20 +20,25 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/tag"
-	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/ci"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/output"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/trace"
 	"github.com/pkg/errors"
 )
 
 // InSequence builds a list of artifacts in sequence.
+// With KeepGoing, the next artifacts are still built after a failure.
 func InSequence(ctx context.Context, out io.Writer, tags tag.ImageTags, artifacts []*latest.Artifact, buildArtifact artifactBuilder) ([]Artifact, error) {
-	var builds []Artifact
+	summary := newBuildSummary()
 
 	for _, artifact := range artifacts {
-		color.Default.Fprintf(out, "Building [%s]...\n", artifact.ImageName)
+		section := "build " + artifact.ImageName
+		ci.StartSection(output.Headlines(out), section, fmt.Sprintf("Building [%s]...", artifact.ImageName))
 
 		event.BuildInProgress(artifact.ImageName)
 
@@ -42,19 +47,30 @@ func InSequence(ctx context.Context, out io.Writer, tags tag.ImageTags, artifact
 			return nil, fmt.Errorf("unable to find tag for image %s", artifact.ImageName)
 		}
 
-		finalTag, err := buildArtifact(ctx, out, artifact, tag)
+		ctx, endTrace := trace.StartTrace(ctx, "build artifact", map[string]string{"image": artifact.ImageName})
+		start := time.Now()
+		finalTag, err := buildWithTimeout(ctx, out, artifact, tag, buildArtifact)
+		endTrace(err)
+		ci.EndSection(output.Headlines(out), section)
 		if err != nil {
 			event.BuildFailed(artifact.ImageName, err)
+			annotateBuildFailure(out, artifact, err)
+			if KeepGoing {
+				summary.failure(artifact.ImageName, err)
+				continue
+			}
 			return nil, errors.Wrapf(err, "building [%s]", artifact.ImageName)
 		}
 
+		recordDuration(artifact.ImageName, start)
+		recordCompleted(artifact.ImageName, finalTag)
 		event.BuildComplete(artifact.ImageName)
 
-		builds = append(builds, Artifact{
+		summary.success(Artifact{
 			ImageName: artifact.ImageName,
 			Tag:       finalTag,
 		})
 	}
 
-	return builds, nil
+	return summary.result(out, artifacts)
 }
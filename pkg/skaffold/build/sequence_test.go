@@ -23,6 +23,7 @@ import (
 	"io"
 	"io/ioutil"
 	"testing"
+	"time"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/tag"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/config"
@@ -90,6 +91,33 @@ func TestInSequence(t *testing.T) {
 	}
 }
 
+func TestInSequenceKeepGoing(t *testing.T) {
+	defer func(k bool) { KeepGoing = k }(KeepGoing)
+	KeepGoing = true
+
+	var built []string
+	buildArtifact := func(ctx context.Context, out io.Writer, artifact *latest.Artifact, tag string) (string, error) {
+		built = append(built, artifact.ImageName)
+		if artifact.ImageName == "skaffold/image1" {
+			return "", fmt.Errorf("build fails")
+		}
+		return tag, nil
+	}
+	tags := tag.ImageTags{
+		"skaffold/image1": "skaffold/image1:v1",
+		"skaffold/image2": "skaffold/image2:v1",
+	}
+	artifacts := []*latest.Artifact{
+		{ImageName: "skaffold/image1"},
+		{ImageName: "skaffold/image2"},
+	}
+
+	got, err := InSequence(context.Background(), ioutil.Discard, tags, artifacts, buildArtifact)
+
+	testutil.CheckErrorAndDeepEqual(t, true, err, []Artifact(nil), got)
+	testutil.CheckDeepEqual(t, []string{"skaffold/image1", "skaffold/image2"}, built)
+}
+
 func TestInSequenceResultsOrder(t *testing.T) {
 	var tests = []struct {
 		description string
@@ -154,3 +182,40 @@ func initializeEvents() {
 		Opts: &config.SkaffoldOptions{},
 	})
 }
+
+func TestInSequenceTimeout(t *testing.T) {
+	defer func(timeout time.Duration) { ArtifactTimeout = timeout }(ArtifactTimeout)
+	ArtifactTimeout = 10 * time.Millisecond
+	initializeEvents()
+
+	artifacts := []*latest.Artifact{{ImageName: "skaffold/image1"}}
+	tags := tag.ImageTags{"skaffold/image1": "skaffold/image1:v0.0.1"}
+	hangs := func(ctx context.Context, out io.Writer, artifact *latest.Artifact, tag string) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	}
+
+	_, err := InSequence(context.Background(), ioutil.Discard, tags, artifacts, hangs)
+
+	testutil.CheckErrorContains(t, "building [skaffold/image1]: timed out after 10ms", err)
+}
+
+func TestInSequenceRecordsCompleted(t *testing.T) {
+	defer func() { completed = map[string]string{} }()
+	completed = map[string]string{}
+	initializeEvents()
+
+	artifacts := []*latest.Artifact{{ImageName: "skaffold/image1"}, {ImageName: "skaffold/image2"}}
+	tags := tag.ImageTags{"skaffold/image1": "skaffold/image1:v1", "skaffold/image2": "skaffold/image2:v1"}
+	failsSecond := func(ctx context.Context, out io.Writer, artifact *latest.Artifact, tag string) (string, error) {
+		if artifact.ImageName == "skaffold/image2" {
+			return "", fmt.Errorf("build fails")
+		}
+		return tag, nil
+	}
+
+	_, err := InSequence(context.Background(), ioutil.Discard, tags, artifacts, failsSecond)
+
+	testutil.CheckError(t, true, err)
+	testutil.CheckDeepEqual(t, []Artifact{{ImageName: "skaffold/image1", Tag: "skaffold/image1:v1"}}, Completed())
+}
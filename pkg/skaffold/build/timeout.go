@@ -0,0 +1,57 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/output"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/pkg/errors"
+)
+
+// ArtifactTimeout is the maximum time allowed to build a single artifact.
+// Zero means no timeout.
+var ArtifactTimeout time.Duration
+
+// buildWithTimeout builds an artifact and gives up after ArtifactTimeout.
+// Unless in verbose mode, the builder's output is only printed if the build fails.
+func buildWithTimeout(ctx context.Context, out io.Writer, artifact *latest.Artifact, tag string, buildArtifact artifactBuilder) (string, error) {
+	stepOut, finish := output.Step(out)
+
+	finalTag, err := buildArtifactWithTimeout(ctx, stepOut, artifact, tag, buildArtifact)
+	finish(err)
+
+	return finalTag, err
+}
+
+func buildArtifactWithTimeout(ctx context.Context, out io.Writer, artifact *latest.Artifact, tag string, buildArtifact artifactBuilder) (string, error) {
+	if ArtifactTimeout <= 0 {
+		return buildArtifact(ctx, out, artifact, tag)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ArtifactTimeout)
+	defer cancel()
+
+	finalTag, err := buildArtifact(ctx, out, artifact, tag)
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		return "", errors.Wrapf(err, "timed out after %v", ArtifactTimeout)
+	}
+	return finalTag, err
+}
@@ -16,6 +16,15 @@ limitations under the License.
 
 package build
 
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/ci"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+)
+
 // MergeWithPreviousBuilds merges previous or prebuilt build artifacts with
 // builds. If an artifact is already present in builds, the same artifact from
 // previous will be ignored.
@@ -36,3 +45,20 @@ func MergeWithPreviousBuilds(builds, previous []Artifact) []Artifact {
 
 	return merged
 }
+
+// annotateBuildFailure reports a build failure to the CI system, on the artifact's Dockerfile if it has one.
+func annotateBuildFailure(out io.Writer, a *latest.Artifact, err error) {
+	var file string
+	switch {
+	case a.DockerArtifact != nil:
+		file = filepath.Join(a.Workspace, a.DockerArtifact.DockerfilePath)
+	case a.KanikoArtifact != nil:
+		file = filepath.Join(a.Workspace, a.KanikoArtifact.DockerfilePath)
+	}
+
+	ci.Error(out, ci.Annotation{
+		File:    file,
+		Title:   fmt.Sprintf("Build failed: %s", a.ImageName),
+		Message: err.Error(),
+	})
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ci
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+)
+
+const (
+	// None prints no annotations.
+	None = ""
+	// GitHub prints GitHub Actions workflow commands.
+	GitHub = "github"
+	// GitLab prints GitLab CI collapsible section markers.
+	GitLab = "gitlab"
+	// Auto detects the CI system from the environment.
+	Auto = "auto"
+)
+
+var (
+	// format is the kind of annotations to print.
+	format = None
+
+	// For testing
+	now = time.Now
+)
+
+var sectionNameCharacters = regexp.MustCompile(`[^a-zA-Z0-9_.-]`)
+
+// Annotation is a problem reported to the CI system, ideally on a given file.
+type Annotation struct {
+	File    string
+	Title   string
+	Message string
+}
+
+// SetFormat sets the kind of annotations to print, `github`, `gitlab`, `auto` or none.
+func SetFormat(f string) error {
+	switch f {
+	case None, GitHub, GitLab:
+		format = f
+	case Auto:
+		format = detect()
+	default:
+		return fmt.Errorf("unsupported CI annotations %q, expected one of %s, %s or %s", f, GitHub, GitLab, Auto)
+	}
+	return nil
+}
+
+func detect() string {
+	switch {
+	case os.Getenv("GITHUB_ACTIONS") == "true":
+		return GitHub
+	case os.Getenv("GITLAB_CI") == "true":
+		return GitLab
+	default:
+		return None
+	}
+}
+
+// StartSection prints the header of a section of the output,
+// collapsible in the CI system's UI.
+func StartSection(out io.Writer, name, header string) {
+	switch format {
+	case GitHub:
+		fmt.Fprintf(out, "::group::%s\n", header)
+	case GitLab:
+		fmt.Fprintf(out, "\x1b[0Ksection_start:%d:%s[collapsed=true]\r\x1b[0K%s\n", now().Unix(), sectionName(name), header)
+	default:
+		color.Default.Fprintln(out, header)
+	}
+}
+
+// EndSection ends a section started with StartSection.
+func EndSection(out io.Writer, name string) {
+	switch format {
+	case GitHub:
+		fmt.Fprintln(out, "::endgroup::")
+	case GitLab:
+		fmt.Fprintf(out, "\x1b[0Ksection_end:%d:%s\r\x1b[0K\n", now().Unix(), sectionName(name))
+	}
+}
+
+// Error reports an error. Only GitHub shows errors on files.
+func Error(out io.Writer, a Annotation) {
+	if format == GitHub {
+		fmt.Fprintf(out, "::error %s::%s\n", properties(a), escapeData(a.Message))
+	}
+}
+
+// Warning reports a warning. Only GitHub shows warnings on files.
+func Warning(out io.Writer, a Annotation) {
+	if format == GitHub {
+		fmt.Fprintf(out, "::warning %s::%s\n", properties(a), escapeData(a.Message))
+	}
+}
+
+func properties(a Annotation) string {
+	var props []string
+	if a.File != "" {
+		props = append(props, "file="+escapeProperty(relative(a.File)))
+	}
+	if a.Title != "" {
+		props = append(props, "title="+escapeProperty(a.Title))
+	}
+	return strings.Join(props, ",")
+}
+
+// relative makes a path relative to the working directory, which is usually the root of the repository.
+func relative(path string) string {
+	if !filepath.IsAbs(path) {
+		return filepath.ToSlash(path)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(wd, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+func sectionName(name string) string {
+	return sectionNameCharacters.ReplaceAllString(strings.ToLower(name), "_")
+}
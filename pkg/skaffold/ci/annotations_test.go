@@ -0,0 +1,135 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ci
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestSetFormat(t *testing.T) {
+	var tests = []struct {
+		description string
+		format      string
+		env         map[string]string
+		expected    string
+		shouldErr   bool
+	}{
+		{
+			description: "none",
+			format:      "",
+			expected:    None,
+		},
+		{
+			description: "github",
+			format:      "github",
+			expected:    GitHub,
+		},
+		{
+			description: "detect github",
+			format:      "auto",
+			env:         map[string]string{"GITHUB_ACTIONS": "true", "GITLAB_CI": ""},
+			expected:    GitHub,
+		},
+		{
+			description: "detect gitlab",
+			format:      "auto",
+			env:         map[string]string{"GITHUB_ACTIONS": "", "GITLAB_CI": "true"},
+			expected:    GitLab,
+		},
+		{
+			description: "detect nothing",
+			format:      "auto",
+			env:         map[string]string{"GITHUB_ACTIONS": "", "GITLAB_CI": ""},
+			expected:    None,
+		},
+		{
+			description: "unsupported",
+			format:      "jenkins",
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			unsetEnvs := testutil.SetEnvs(t, test.env)
+			defer unsetEnvs()
+			reset := testutil.Override(t, &format, None)
+			defer reset()
+
+			err := SetFormat(test.format)
+
+			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.expected, format)
+		})
+	}
+}
+
+func TestAnnotations(t *testing.T) {
+	wd, err := os.Getwd()
+	testutil.CheckError(t, false, err)
+
+	annotate := func(out io.Writer) {
+		StartSection(out, "Build gcr.io/project/app", "Building [gcr.io/project/app]...")
+		EndSection(out, "Build gcr.io/project/app")
+		Error(out, Annotation{File: filepath.Join(wd, "app", "Dockerfile"), Title: "Build failed: app", Message: "step 1:\nfailed 100%"})
+		Warning(out, Annotation{Title: "Policy warning", Message: "no limits"})
+	}
+
+	var tests = []struct {
+		description string
+		format      string
+		expected    string
+	}{
+		{
+			description: "none",
+			format:      None,
+			expected:    "Building [gcr.io/project/app]...\n",
+		},
+		{
+			description: "github",
+			format:      GitHub,
+			expected: "::group::Building [gcr.io/project/app]...\n" +
+				"::endgroup::\n" +
+				"::error file=app/Dockerfile,title=Build failed%3A app::step 1:%0Afailed 100%25\n" +
+				"::warning title=Policy warning::no limits\n",
+		},
+		{
+			description: "gitlab",
+			format:      GitLab,
+			expected: "\x1b[0Ksection_start:1560000000:build_gcr.io_project_app[collapsed=true]\r\x1b[0KBuilding [gcr.io/project/app]...\n" +
+				"\x1b[0Ksection_end:1560000000:build_gcr.io_project_app\r\x1b[0K\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			reset := testutil.Override(t, &format, test.format)
+			defer reset()
+			resetNow := testutil.Override(t, &now, func() time.Time { return time.Unix(1560000000, 0) })
+			defer resetNow()
+
+			var out bytes.Buffer
+			annotate(&out)
+
+			testutil.CheckDeepEqual(t, test.expected, out.String())
+		})
+	}
+}
@@ -25,10 +25,23 @@ import (
 	"golang.org/x/crypto/ssh/terminal"
 )
 
-// IsTerminal will check if the specified output stream is a terminal. This can be changed
-// for testing to an arbitrary method.
+// IsTerminal will check if the specified output stream is a terminal that supports colors.
+// This can be changed for testing to an arbitrary method.
 var IsTerminal = isTerminal
 
+// For testing
+var getenv = os.Getenv
+
+// Modes select when colors are used.
+const (
+	// Auto uses colors when printing to a terminal that supports them.
+	Auto = "auto"
+	// Always uses colors, even when the output is redirected.
+	Always = "always"
+	// Never doesn't use colors.
+	Never = "never"
+)
+
 // Color can be used to format text using ANSI escape codes so it can be printed to
 // the terminal in color.
 type Color int
@@ -106,15 +119,43 @@ func OverwriteDefault(color Color) {
 	Default = color
 }
 
-// This implementation comes from logrus (https://github.com/sirupsen/logrus/blob/master/terminal_check_notappengine.go),
-// unfortunately logrus doesn't expose a public interface we can use to call it.
+// SetMode selects when colors are used: auto, always or never.
+func SetMode(mode string) error {
+	switch mode {
+	case Auto:
+		IsTerminal = isTerminal
+	case Always:
+		ForceColors()
+	case Never:
+		IsTerminal = func(_ io.Writer) bool {
+			return false
+		}
+	default:
+		return fmt.Errorf("unsupported color mode %q, use auto, always or never", mode)
+	}
+	return nil
+}
+
 func isTerminal(w io.Writer) bool {
 	if _, ok := w.(ColoredWriteCloser); ok {
 		return true
 	}
 
+	return supportsColors() && IsTTY(w)
+}
+
+// supportsColors follows the conventions of https://no-color.org and of dumb terminals.
+func supportsColors() bool {
+	return getenv("NO_COLOR") == "" && getenv("TERM") != "dumb"
+}
+
+// IsTTY checks if the specified output stream is a terminal, whether
+// it supports colors or not.
+// This implementation comes from logrus (https://github.com/sirupsen/logrus/blob/master/terminal_check_notappengine.go),
+// unfortunately logrus doesn't expose a public interface we can use to call it.
+func IsTTY(w io.Writer) bool {
 	switch v := w.(type) {
-	case *os.File:
+	case interface{ Fd() uintptr }:
 		return terminal.IsTerminal(int(v.Fd()))
 	default:
 		return false
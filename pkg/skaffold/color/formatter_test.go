@@ -109,3 +109,79 @@ func TestOverwriteDefault(t *testing.T) {
 	OverwriteDefault(Red)
 	testutil.CheckDeepEqual(t, Red, Default)
 }
+
+func TestSetMode(t *testing.T) {
+	var tests = []struct {
+		description string
+		mode        string
+		expected    string
+		shouldErr   bool
+	}{
+		{
+			description: "auto doesn't color redirected output",
+			mode:        Auto,
+			expected:    "It's not easy being",
+		},
+		{
+			description: "always",
+			mode:        Always,
+			expected:    "\033[32mIt's not easy being\033[0m",
+		},
+		{
+			description: "never",
+			mode:        Never,
+			expected:    "It's not easy being",
+		},
+		{
+			description: "unsupported",
+			mode:        "sometimes",
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			defer func() { IsTerminal = isTerminal }()
+
+			err := SetMode(test.mode)
+			testutil.CheckError(t, test.shouldErr, err)
+			if test.shouldErr {
+				return
+			}
+
+			var b bytes.Buffer
+			Green.Fprint(&b, "It's not easy being")
+			testutil.CheckDeepEqual(t, test.expected, b.String())
+		})
+	}
+}
+
+func TestSupportsColors(t *testing.T) {
+	var tests = []struct {
+		description string
+		env         map[string]string
+		expected    bool
+	}{
+		{
+			description: "terminal",
+			env:         map[string]string{"TERM": "xterm-256color"},
+			expected:    true,
+		},
+		{
+			description: "NO_COLOR",
+			env:         map[string]string{"TERM": "xterm-256color", "NO_COLOR": "1"},
+			expected:    false,
+		},
+		{
+			description: "dumb terminal",
+			env:         map[string]string{"TERM": "dumb"},
+			expected:    false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			defer testutil.Override(t, &getenv, func(key string) string { return test.env[key] })()
+
+			testutil.CheckDeepEqual(t, test.expected, supportsColors())
+		})
+	}
+}
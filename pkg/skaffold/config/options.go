@@ -17,7 +17,9 @@ limitations under the License.
 package config
 
 import (
+	"path"
 	"strings"
+	"time"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 )
@@ -25,32 +27,71 @@ import (
 // SkaffoldOptions are options that are set by command line arguments not included
 // in the config file itself
 type SkaffoldOptions struct {
-	ConfigurationFile  string
-	Cleanup            bool
-	Notification       bool
-	Tail               bool
-	TailDev            bool
-	PortForward        bool
-	SkipTests          bool
-	CacheArtifacts     bool
-	EnableRPC          bool
-	Force              bool
-	ForceDev           bool
-	NoPrune            bool
-	NoPruneChildren    bool
-	CustomTag          string
-	Namespace          string
-	CacheFile          string
-	Trigger            string
-	WatchPollInterval  int
-	DefaultRepo        string
-	CustomLabels       []string
-	TargetImages       []string
-	Profiles           []string
-	InsecureRegistries []string
-	Command            string
-	RPCPort            int
-	RPCHTTPPort        int
+	ConfigurationFile    string
+	Cleanup              bool
+	Notification         bool
+	Tail                 bool
+	TailDev              bool
+	TailFor              time.Duration
+	TailContainers       []string
+	TailFailOnCrash      bool
+	PortForward          bool
+	Open                 string
+	SkipTests            bool
+	CacheArtifacts       bool
+	RemoteCache          bool
+	EnableRPC            bool
+	UI                   bool
+	UIPort               int
+	Force                bool
+	ForceDev             bool
+	NoPrune              bool
+	NoPruneChildren      bool
+	Offline              bool
+	StatusCheck          bool
+	CustomTag            string
+	Namespace            string
+	CacheFile            string
+	Trigger              string
+	WatchPollInterval    int
+	WebhookPort          int
+	WebhookToken         string
+	GitRef               string
+	GitPollInterval      int
+	GitWebhook           bool
+	RemoteDev            bool
+	ClusterRegistry      bool
+	DelveImage           string
+	DelveFlags           []string
+	RelaxSecurity        bool
+	DefaultRepo          string
+	DefaultRepoStrategy  string
+	DefaultRepoOverrides []string
+	CustomLabels         []string
+	TargetImages         []string
+	BuildImages          []string
+	Profiles             []string
+	InsecureRegistries   []string
+	RetainKinds          []string
+	DeleteSelector       string
+	RunID                string
+	Command              string
+	RPCPort              int
+	RPCHTTPPort          int
+	Output               string
+	CIAnnotations        string
+	Timeout              time.Duration
+	BuildTimeout         time.Duration
+	RenderTimeout        time.Duration
+	DeployTimeout        time.Duration
+	WaitForImages        time.Duration
+	GracePeriod          time.Duration
+	Resume               bool
+	KeepGoing            bool
+	Quiet                bool
+	Verbose              bool
+	EnvFile              string
+	Symlinks             string
 }
 
 // Labels returns a map of labels to be applied to all deployed
@@ -87,17 +128,44 @@ func (opts *SkaffoldOptions) Prune() bool {
 	return !opts.NoPrune && !opts.CacheArtifacts
 }
 
+// IsDevLoop returns true for the commands that rebuild the artifacts when their sources change.
+func (opts *SkaffoldOptions) IsDevLoop() bool {
+	return opts.Command == "dev" || opts.Command == "debug"
+}
+
 func (opts *SkaffoldOptions) ForceDeploy() bool {
 	return opts.ForceDev || opts.Force
 }
 
 func (opts *SkaffoldOptions) IsTargetImage(artifact *latest.Artifact) bool {
-	if len(opts.TargetImages) == 0 {
+	return matchesImage(opts.TargetImages, artifact.ImageName)
+}
+
+// IsBuildImage returns true if the artifact is selected with --build-image.
+func (opts *SkaffoldOptions) IsBuildImage(artifact *latest.Artifact) bool {
+	return matchesImage(opts.BuildImages, artifact.ImageName)
+}
+
+// matchesImage checks if an image name contains one of the expressions.
+// Expressions with wildcards are glob patterns, matched against either
+// the full image name or its last path component.
+func matchesImage(expressions []string, imageName string) bool {
+	if len(expressions) == 0 {
 		return true
 	}
 
-	for _, targetImage := range opts.TargetImages {
-		if strings.Contains(artifact.ImageName, targetImage) {
+	for _, expression := range expressions {
+		if !strings.ContainsAny(expression, "*?[") {
+			if strings.Contains(imageName, expression) {
+				return true
+			}
+			continue
+		}
+
+		if matched, _ := path.Match(expression, imageName); matched {
+			return true
+		}
+		if matched, _ := path.Match(expression, path.Base(imageName)); matched {
 			return true
 		}
 	}
@@ -169,3 +169,61 @@ func TestIsTargetImage(t *testing.T) {
 		})
 	}
 }
+
+func TestIsBuildImage(t *testing.T) {
+	var tests = []struct {
+		description   string
+		buildImages   []string
+		expectedMatch bool
+	}{
+		{
+			description:   "match all",
+			buildImages:   nil,
+			expectedMatch: true,
+		},
+		{
+			description:   "match partial name",
+			buildImages:   []string{"front"},
+			expectedMatch: true,
+		},
+		{
+			description:   "match glob on full name",
+			buildImages:   []string{"gcr.io/*/frontend"},
+			expectedMatch: true,
+		},
+		{
+			description:   "match glob on last path component",
+			buildImages:   []string{"front*"},
+			expectedMatch: true,
+		},
+		{
+			description:   "glob must match the whole name",
+			buildImages:   []string{"*end-v?"},
+			expectedMatch: false,
+		},
+		{
+			description:   "match any",
+			buildImages:   []string{"back*", "frontend"},
+			expectedMatch: true,
+		},
+		{
+			description:   "no match",
+			buildImages:   []string{"backend"},
+			expectedMatch: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			opts := &SkaffoldOptions{
+				BuildImages: test.buildImages,
+			}
+
+			match := opts.IsBuildImage(&latest.Artifact{
+				ImageName: "gcr.io/project/frontend",
+			})
+
+			testutil.CheckDeepEqual(t, test.expectedMatch, match)
+		})
+	}
+}
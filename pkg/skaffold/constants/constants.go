@@ -19,6 +19,7 @@ package constants
 import (
 	"fmt"
 	"runtime"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
@@ -53,6 +54,10 @@ const (
 
 	DefaultBusyboxImage = "busybox"
 
+	DefaultRemoteDevDest = "/workspace"
+
+	DefaultDelveImage = "gcr.io/gcp-dev-tools/duct-tape/go"
+
 	UpdateCheckEnvironmentVariable = "SKAFFOLD_UPDATE_CHECK"
 
 	DefaultCloudBuildDockerImage = "gcr.io/cloud-builders/docker"
@@ -64,6 +69,11 @@ const (
 
 	DefaultRPCPort     = 50051
 	DefaultRPCHTTPPort = 50052
+	DefaultWebhookPort = 50053
+	DefaultUIPort      = 50054
+
+	// DefaultGracePeriod is how long child processes are given to exit, once asked to, before they are killed.
+	DefaultGracePeriod = 5 * time.Second
 )
 
 var (
@@ -75,6 +85,12 @@ var (
 
 	// BuildContext is the absolute path to a directory this artifact is meant to be built from for custom artifacts
 	BuildContext = "BUILD_CONTEXT"
+
+	// BuildContextTar is the path to a tarball of a custom artifact's dependencies, when they're uploaded to a file.
+	BuildContextTar = "BUILD_CONTEXT_TAR"
+
+	// Image is an environment variable key, whose value is the fully qualified image name passed in to a custom test command.
+	Image = "IMAGE"
 )
 
 var DefaultKubectlManifests = []string{"k8s/*.yaml"}
@@ -86,9 +102,11 @@ var Labels = struct {
 	Deployer         string
 	Builder          string
 	DockerAPIVersion string
+	Artifact         string
 }{
 	TagPolicy:        "skaffold.dev/tag-policy",
 	Deployer:         "skaffold.dev/deployer",
 	Builder:          "skaffold.dev/builder",
 	DockerAPIVersion: "skaffold.dev/docker-api-version",
+	Artifact:         "skaffold.dev/artifact",
 }
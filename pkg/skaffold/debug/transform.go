@@ -58,6 +58,9 @@ type containerTransformer interface {
 
 var containerTransforms []containerTransformer
 
+// RelaxSecurity allows native debuggers to attach to the processes of the debugged containers.
+var RelaxSecurity bool
+
 // transformManifest attempts to configure a manifest for debugging.
 // Returns true if changed, false otherwise.
 func transformManifest(obj runtime.Object, retrieveImageConfiguration configurationRetriever) bool {
@@ -133,15 +136,66 @@ func transformPodSpec(metadata *metav1.ObjectMeta, podSpec *v1.PodSpec, retrieve
 		}
 	}
 	if len(configurations) > 0 {
+		if usesDelve(configurations) {
+			installDelve(podSpec)
+		}
 		if metadata.Annotations == nil {
 			metadata.Annotations = make(map[string]string)
 		}
 		metadata.Annotations["debug.cloud.google.com/config"] = encodeConfigurations(configurations)
+		if RelaxSecurity {
+			for i := range podSpec.Containers {
+				if _, found := configurations[podSpec.Containers[i].Name]; found {
+					relaxSecurity(metadata, &podSpec.Containers[i])
+				}
+			}
+		}
 		return true
 	}
 	return false
 }
 
+// relaxSecurity adds the SYS_PTRACE capability to a container, makes its root filesystem
+// writable and disables its seccomp profile.
+func relaxSecurity(metadata *metav1.ObjectMeta, container *v1.Container) {
+	if container.SecurityContext == nil {
+		container.SecurityContext = &v1.SecurityContext{}
+	}
+	securityContext := container.SecurityContext
+
+	if securityContext.Capabilities == nil {
+		securityContext.Capabilities = &v1.Capabilities{}
+	}
+	capabilities := securityContext.Capabilities
+
+	var drop []v1.Capability
+	for _, capability := range capabilities.Drop {
+		if capability != "SYS_PTRACE" {
+			drop = append(drop, capability)
+		}
+	}
+	capabilities.Drop = drop
+	if !hasCapability(capabilities.Add, "SYS_PTRACE") {
+		capabilities.Add = append(capabilities.Add, "SYS_PTRACE")
+	}
+
+	if securityContext.ReadOnlyRootFilesystem != nil && *securityContext.ReadOnlyRootFilesystem {
+		writable := false
+		securityContext.ReadOnlyRootFilesystem = &writable
+	}
+
+	metadata.Annotations[v1.SeccompContainerAnnotationKeyPrefix+container.Name] = "unconfined"
+}
+
+func hasCapability(capabilities []v1.Capability, capability v1.Capability) bool {
+	for _, c := range capabilities {
+		if c == capability || c == "ALL" {
+			return true
+		}
+	}
+	return false
+}
+
 // allocatePort walks the podSpec's containers looking for an available port that is close to desiredPort.
 // We deal with wrapping and avoid allocating ports < 1024
 func allocatePort(podSpec *v1.PodSpec, desiredPort int32) int32 {
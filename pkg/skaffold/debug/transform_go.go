@@ -0,0 +1,138 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"strconv"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+)
+
+type dlvTransformer struct{}
+
+func init() {
+	containerTransforms = append(containerTransforms, dlvTransformer{})
+}
+
+const (
+	// delve's default port for headless mode
+	defaultDlvPort = 56268
+
+	// the delve image copies its files into this volume
+	dlvVolumeName = "debugging-support-files"
+	dlvMountPath  = "/dbg"
+	dlvPath       = dlvMountPath + "/go/bin/dlv"
+)
+
+var (
+	// DelveImage is the image of the init container that copies `dlv` into the pods.
+	DelveImage = constants.DefaultDelveImage
+
+	// DelveFlags are additional flags passed to `dlv exec`.
+	DelveFlags []string
+)
+
+func (t dlvTransformer) IsApplicable(config imageConfiguration) bool {
+	// the Go runtime is usually tuned with one of these variables
+	for _, name := range []string{"GOTRACEBACK", "GODEBUG", "GOGC", "GOMAXPROCS", "KO_DATA_PATH"} {
+		if _, found := config.env[name]; found {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply configures a container definition for Go debugging with Delve.
+// Returns a simple map describing the debug configuration details.
+func (t dlvTransformer) Apply(container *v1.Container, config imageConfiguration, portAlloc portAllocator) map[string]interface{} {
+	logrus.Infof("Configuring [%s] for Go/Delve debugging", container.Name)
+
+	port := portAlloc(defaultDlvPort)
+	switch {
+	case len(config.entrypoint) > 0:
+		container.Command = rewriteDlvCommandLine(config.entrypoint, port, DelveFlags)
+		// setting the command would otherwise drop the image's arguments
+		if len(container.Args) == 0 {
+			container.Args = config.arguments
+		}
+
+	case len(config.arguments) > 0:
+		container.Args = rewriteDlvCommandLine(config.arguments, port, DelveFlags)
+
+	default:
+		logrus.Warnf("Skipping [%s] as it does not have a command line to debug", container.Name)
+		return nil
+	}
+
+	container.Ports = append(container.Ports, v1.ContainerPort{
+		Name:          "dlv",
+		ContainerPort: port,
+	})
+	container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{
+		Name:      dlvVolumeName,
+		MountPath: dlvMountPath,
+	})
+
+	return map[string]interface{}{
+		"runtime": "go",
+		"dlv":     port,
+	}
+}
+
+// rewriteDlvCommandLine rewrites a command-line to launch the binary with `dlv exec`
+func rewriteDlvCommandLine(commandLine []string, port int32, flags []string) []string {
+	rewritten := []string{dlvPath, "exec", "--headless", "--continue", "--accept-multiclient", "--listen=:" + strconv.FormatInt(int64(port), 10), "--api-version=2"}
+	rewritten = append(rewritten, flags...)
+	rewritten = append(rewritten, commandLine[0], "--")
+	return append(rewritten, commandLine[1:]...)
+}
+
+// usesDelve returns true if one of the containers was configured for Delve.
+func usesDelve(configurations map[string]map[string]interface{}) bool {
+	for _, configuration := range configurations {
+		if configuration["runtime"] == "go" {
+			return true
+		}
+	}
+	return false
+}
+
+// installDelve adds an init container that copies `dlv` into a volume shared with the containers.
+func installDelve(podSpec *v1.PodSpec) {
+	for _, volume := range podSpec.Volumes {
+		if volume.Name == dlvVolumeName {
+			return
+		}
+	}
+
+	podSpec.Volumes = append(podSpec.Volumes, v1.Volume{
+		Name: dlvVolumeName,
+		VolumeSource: v1.VolumeSource{
+			EmptyDir: &v1.EmptyDirVolumeSource{},
+		},
+	})
+	podSpec.InitContainers = append(podSpec.InitContainers, v1.Container{
+		Name:  "install-go-support",
+		Image: DelveImage,
+		VolumeMounts: []v1.VolumeMount{{
+			Name:      dlvVolumeName,
+			MountPath: dlvMountPath,
+		}},
+	})
+}
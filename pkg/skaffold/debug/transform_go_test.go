@@ -0,0 +1,223 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestDlvTransformer_IsApplicable(t *testing.T) {
+	tests := []struct {
+		description string
+		source      imageConfiguration
+		result      bool
+	}{
+		{
+			description: "GOMAXPROCS",
+			source:      imageConfiguration{env: map[string]string{"GOMAXPROCS": "2"}},
+			result:      true,
+		},
+		{
+			description: "KO_DATA_PATH",
+			source:      imageConfiguration{env: map[string]string{"KO_DATA_PATH": "/var/run/ko"}},
+			result:      true,
+		},
+		{
+			description: "entrypoint only",
+			source:      imageConfiguration{entrypoint: []string{"/app"}},
+			result:      false,
+		},
+		{
+			description: "nothing",
+			source:      imageConfiguration{},
+			result:      false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result := dlvTransformer{}.IsApplicable(test.source)
+
+			testutil.CheckDeepEqual(t, test.result, result)
+		})
+	}
+}
+
+func TestRewriteDlvCommandLine(t *testing.T) {
+	tests := []struct {
+		description string
+		in          []string
+		flags       []string
+		result      []string
+	}{
+		{
+			description: "binary",
+			in:          []string{"/app"},
+			result:      []string{"/dbg/go/bin/dlv", "exec", "--headless", "--continue", "--accept-multiclient", "--listen=:56268", "--api-version=2", "/app", "--"},
+		},
+		{
+			description: "binary with arguments",
+			in:          []string{"/app", "--port", "8080"},
+			result:      []string{"/dbg/go/bin/dlv", "exec", "--headless", "--continue", "--accept-multiclient", "--listen=:56268", "--api-version=2", "/app", "--", "--port", "8080"},
+		},
+		{
+			description: "extra flags",
+			in:          []string{"/app"},
+			flags:       []string{"--check-go-version=false", "--only-same-user=false"},
+			result:      []string{"/dbg/go/bin/dlv", "exec", "--headless", "--continue", "--accept-multiclient", "--listen=:56268", "--api-version=2", "--check-go-version=false", "--only-same-user=false", "/app", "--"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			result := rewriteDlvCommandLine(test.in, defaultDlvPort, test.flags)
+
+			testutil.CheckDeepEqual(t, test.result, result)
+		})
+	}
+}
+
+func TestDlvTransformerApply(t *testing.T) {
+	dlv := func(args ...string) []string {
+		return append([]string{"/dbg/go/bin/dlv", "exec", "--headless", "--continue", "--accept-multiclient", "--listen=:56268", "--api-version=2"}, args...)
+	}
+	mounts := []v1.VolumeMount{{Name: "debugging-support-files", MountPath: "/dbg"}}
+	ports := []v1.ContainerPort{{Name: "dlv", ContainerPort: 56268}}
+
+	tests := []struct {
+		description   string
+		containerSpec v1.Container
+		configuration imageConfiguration
+		result        v1.Container
+	}{
+		{
+			description:   "empty",
+			containerSpec: v1.Container{},
+			configuration: imageConfiguration{},
+			result:        v1.Container{},
+		},
+		{
+			description:   "entrypoint",
+			containerSpec: v1.Container{},
+			configuration: imageConfiguration{entrypoint: []string{"/app", "--debug"}},
+			result: v1.Container{
+				Command:      dlv("/app", "--", "--debug"),
+				Ports:        ports,
+				VolumeMounts: mounts,
+			},
+		},
+		{
+			description:   "entrypoint keeps image arguments",
+			containerSpec: v1.Container{},
+			configuration: imageConfiguration{entrypoint: []string{"/app"}, arguments: []string{"serve"}},
+			result: v1.Container{
+				Command:      dlv("/app", "--"),
+				Args:         []string{"serve"},
+				Ports:        ports,
+				VolumeMounts: mounts,
+			},
+		},
+		{
+			description:   "command not entrypoint",
+			containerSpec: v1.Container{},
+			configuration: imageConfiguration{arguments: []string{"/app", "serve"}},
+			result: v1.Container{
+				Args:         dlv("/app", "--", "serve"),
+				Ports:        ports,
+				VolumeMounts: mounts,
+			},
+		},
+	}
+	var identity portAllocator = func(port int32) int32 {
+		return port
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			dlvTransformer{}.Apply(&test.containerSpec, test.configuration, identity)
+
+			testutil.CheckDeepEqual(t, test.result, test.containerSpec)
+		})
+	}
+}
+
+func TestTransformManifestGo(t *testing.T) {
+	defer func(image string, flags []string) { DelveImage, DelveFlags = image, flags }(DelveImage, DelveFlags)
+	DelveImage = "delve:1.4"
+	DelveFlags = []string{"--check-go-version=false"}
+
+	tests := []struct {
+		description string
+		in          runtime.Object
+		transformed bool
+		out         runtime.Object
+	}{
+		{
+			"Pod with Go container",
+			&v1.Pod{
+				Spec: v1.PodSpec{Containers: []v1.Container{
+					{
+						Name:    "test",
+						Command: []string{"/app"},
+					},
+				}}},
+			true,
+			&v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{"debug.cloud.google.com/config": `{"test":{"dlv":56268,"runtime":"go"}}`},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name:         "test",
+							Command:      []string{"/dbg/go/bin/dlv", "exec", "--headless", "--continue", "--accept-multiclient", "--listen=:56268", "--api-version=2", "--check-go-version=false", "/app", "--"},
+							Ports:        []v1.ContainerPort{{Name: "dlv", ContainerPort: 56268}},
+							VolumeMounts: []v1.VolumeMount{{Name: "debugging-support-files", MountPath: "/dbg"}},
+						},
+					},
+					InitContainers: []v1.Container{
+						{
+							Name:         "install-go-support",
+							Image:        "delve:1.4",
+							VolumeMounts: []v1.VolumeMount{{Name: "debugging-support-files", MountPath: "/dbg"}},
+						},
+					},
+					Volumes: []v1.Volume{
+						{
+							Name:         "debugging-support-files",
+							VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}},
+						},
+					},
+				}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			value := test.in.DeepCopyObject()
+
+			retriever := func(image string) (imageConfiguration, error) {
+				return imageConfiguration{env: map[string]string{"GOTRACEBACK": "all"}}, nil
+			}
+			result := transformManifest(value, retriever)
+			testutil.CheckDeepEqual(t, test.transformed, result)
+			testutil.CheckDeepEqual(t, test.out, value)
+		})
+	}
+}
@@ -132,3 +132,56 @@ func TestDescribe(t *testing.T) {
 		})
 	}
 }
+
+func TestRelaxSecurity(t *testing.T) {
+	readOnly, writable := true, false
+	tests := []struct {
+		description string
+		in          v1.Container
+		result      v1.SecurityContext
+	}{
+		{
+			description: "no security context",
+			in:          v1.Container{Name: "test"},
+			result: v1.SecurityContext{
+				Capabilities: &v1.Capabilities{Add: []v1.Capability{"SYS_PTRACE"}},
+			},
+		},
+		{
+			description: "restrictive security context",
+			in: v1.Container{
+				Name: "test",
+				SecurityContext: &v1.SecurityContext{
+					Capabilities:           &v1.Capabilities{Add: []v1.Capability{"NET_ADMIN"}, Drop: []v1.Capability{"SYS_PTRACE", "KILL"}},
+					ReadOnlyRootFilesystem: &readOnly,
+				},
+			},
+			result: v1.SecurityContext{
+				Capabilities:           &v1.Capabilities{Add: []v1.Capability{"NET_ADMIN", "SYS_PTRACE"}, Drop: []v1.Capability{"KILL"}},
+				ReadOnlyRootFilesystem: &writable,
+			},
+		},
+		{
+			description: "all capabilities",
+			in: v1.Container{
+				Name: "test",
+				SecurityContext: &v1.SecurityContext{
+					Capabilities: &v1.Capabilities{Add: []v1.Capability{"ALL"}},
+				},
+			},
+			result: v1.SecurityContext{
+				Capabilities: &v1.Capabilities{Add: []v1.Capability{"ALL"}},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			metadata := metav1.ObjectMeta{Annotations: map[string]string{}}
+
+			relaxSecurity(&metadata, &test.in)
+
+			testutil.CheckDeepEqual(t, test.result, *test.in.SecurityContext)
+			testutil.CheckDeepEqual(t, map[string]string{"container.seccomp.security.alpha.kubernetes.io/test": "unconfined"}, metadata.Annotations)
+		})
+	}
+}
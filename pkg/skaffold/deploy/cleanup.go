@@ -0,0 +1,98 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"context"
+	"io"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/deploy/kubectl"
+	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
+)
+
+// selectorResources are the kinds of resources, and their resource names,
+// that are deleted by label selector.
+var selectorResources = []struct {
+	kind     string
+	resource string
+}{
+	{"Deployment", "deployments"},
+	{"StatefulSet", "statefulsets"},
+	{"DaemonSet", "daemonsets"},
+	{"ReplicaSet", "replicasets"},
+	{"Job", "jobs"},
+	{"CronJob", "cronjobs"},
+	{"Pod", "pods"},
+	{"Service", "services"},
+	{"Ingress", "ingresses"},
+	{"ConfigMap", "configmaps"},
+	{"Secret", "secrets"},
+	{"ServiceAccount", "serviceaccounts"},
+	{"PersistentVolumeClaim", "persistentvolumeclaims"},
+}
+
+// cleanupOptions describe what kubectl and kustomize deployments delete.
+type cleanupOptions struct {
+	retain   map[string]bool
+	selector string
+}
+
+// newCleanupOptions merges the `deploy.cleanup` configuration with the command line flags.
+func newCleanupOptions(runCtx *runcontext.RunContext) cleanupOptions {
+	options := cleanupOptions{
+		retain:   map[string]bool{},
+		selector: runCtx.Opts.DeleteSelector,
+	}
+
+	if cfg := runCtx.Cfg.Deploy.Cleanup; cfg != nil {
+		for _, kind := range cfg.Retain {
+			options.retain[kind] = true
+		}
+		if options.selector == "" {
+			options.selector = cfg.Selector
+		}
+	}
+	for _, kind := range runCtx.Opts.RetainKinds {
+		options.retain[kind] = true
+	}
+
+	return options
+}
+
+// deleteManifests deletes the given manifests, except for the retained kinds,
+// and the resources that match the selector.
+func (c cleanupOptions) deleteManifests(ctx context.Context, out io.Writer, cli *kubectl.CLI, manifests kubectl.ManifestList) error {
+	manifests = manifests.ExcludeKinds(c.retain)
+	if len(manifests) > 0 {
+		if err := cli.Delete(ctx, out, manifests); err != nil {
+			return err
+		}
+	}
+
+	if c.selector == "" {
+		return nil
+	}
+
+	var resources []string
+	for _, r := range selectorResources {
+		if !c.retain[r.kind] {
+			resources = append(resources, r.resource)
+		}
+	}
+
+	return cli.DeleteSelected(ctx, out, resources, c.selector)
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var (
+	// For testing
+	cloudRunPollPeriod = 2 * time.Second
+
+	invalidCloudRunLabel = regexp.MustCompile(`[^a-z0-9_-]`)
+)
+
+// CloudRunDeployer deploys images as Cloud Run services, with `gcloud`.
+type CloudRunDeployer struct {
+	*latest.CloudRunDeploy
+
+	statusCheck *latest.StatusCheckConfig
+}
+
+// NewCloudRunDeployer returns a new CloudRunDeployer for a RunContext.
+func NewCloudRunDeployer(runCtx *runcontext.RunContext) *CloudRunDeployer {
+	return &CloudRunDeployer{
+		CloudRunDeploy: runCtx.Cfg.Deploy.CloudRunDeploy,
+		statusCheck:    runCtx.Cfg.Deploy.StatusCheck,
+	}
+}
+
+// Labels returns the labels specific to Cloud Run.
+func (c *CloudRunDeployer) Labels() map[string]string {
+	return map[string]string{
+		constants.Labels.Deployer: "cloudrun",
+	}
+}
+
+// Deploy runs `gcloud run deploy` for each service and waits for their new
+// revisions to serve traffic.
+func (c *CloudRunDeployer) Deploy(ctx context.Context, out io.Writer, builds []build.Artifact, labellers []Labeller) error {
+	event.DeployInProgress()
+
+	labels := cloudRunLabels(merge(labellers...))
+	for _, s := range c.Services {
+		if err := c.deployService(ctx, out, s, builds, labels); err != nil {
+			event.DeployFailed(err)
+			return errors.Wrapf(err, "deploying cloud run service %s", s.Name)
+		}
+	}
+
+	event.DeployComplete()
+	return nil
+}
+
+func (c *CloudRunDeployer) deployService(ctx context.Context, out io.Writer, s latest.CloudRunService, builds []build.Artifact, labels string) error {
+	image, err := imageForArtifact(builds, s.Image)
+	if err != nil {
+		return err
+	}
+
+	args := []string{"run", "deploy", s.Name, "--image", image}
+	if labels != "" {
+		args = append(args, "--labels", labels)
+	}
+	args = append(args, s.Flags...)
+
+	cmd := util.CommandContext(ctx, "gcloud", c.gcloudArgs(args...)...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	if err := cmd.Run(); err != nil {
+		return err
+	}
+
+	return c.waitForService(ctx, out, s.Name)
+}
+
+// waitForService waits for the latest revision of a service to be ready and serve traffic.
+// Cloud Run services follow the Knative Serving API.
+func (c *CloudRunDeployer) waitForService(ctx context.Context, out io.Writer, name string) error {
+	deadline := settingsForResource(c.statusCheck, statusCheckResource{kind: knativeServiceKind, name: name}).deadline
+	ctx, cancel := context.WithTimeout(ctx, deadline)
+	defer cancel()
+
+	for {
+		service, err := c.describeService(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		status, err := knativeServiceStatus(service)
+		if err != nil {
+			return err
+		}
+		if strings.Contains(status, "successfully rolled out") {
+			revision, _, _ := unstructured.NestedString(service.Object, "status", "latestReadyRevisionName")
+			url, _, _ := unstructured.NestedString(service.Object, "status", "url")
+			color.Default.Fprintf(out, "Service %s is serving revision %s at %s\n", name, revision, url)
+			return nil
+		}
+		logrus.Debugln(status)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("could not stabilize within %v", deadline)
+		case <-time.After(cloudRunPollPeriod):
+		}
+	}
+}
+
+func (c *CloudRunDeployer) describeService(ctx context.Context, name string) (*unstructured.Unstructured, error) {
+	cmd := util.CommandContext(ctx, "gcloud", c.gcloudArgs("run", "services", "describe", name, "--format", "json")...)
+	out, err := cmd.RunOut()
+	if err != nil {
+		return nil, errors.Wrap(err, "describing service")
+	}
+
+	var service map[string]interface{}
+	if err := json.Unmarshal(out, &service); err != nil {
+		return nil, errors.Wrap(err, "parsing service")
+	}
+	return &unstructured.Unstructured{Object: service}, nil
+}
+
+// Render is not supported: Cloud Run services are deployed from images, not manifests.
+func (c *CloudRunDeployer) Render(context.Context, io.Writer, []build.Artifact, []Labeller) error {
+	return errors.New("the cloudrun deployer doesn't render manifests")
+}
+
+// Dependencies returns nothing since services are deployed from images.
+func (c *CloudRunDeployer) Dependencies() ([]string, error) {
+	return nil, nil
+}
+
+// Cleanup deletes the services.
+func (c *CloudRunDeployer) Cleanup(ctx context.Context, out io.Writer) error {
+	for _, s := range c.Services {
+		cmd := util.CommandContext(ctx, "gcloud", c.gcloudArgs("run", "services", "delete", s.Name)...)
+		cmd.Stdout = out
+		cmd.Stderr = out
+		if err := cmd.Run(); err != nil {
+			return errors.Wrapf(err, "deleting cloud run service %s", s.Name)
+		}
+	}
+	return nil
+}
+
+// Logs streams the request logs of the services, with `gcloud beta run services logs tail`.
+func (c *CloudRunDeployer) Logs(ctx context.Context) (map[string]io.Reader, error) {
+	streams := map[string]io.Reader{}
+	for _, s := range c.Services {
+		r, w := io.Pipe()
+		cmd := util.CommandContext(ctx, "gcloud", c.gcloudArgs("beta", "run", "services", "logs", "tail", s.Name)...)
+		cmd.Stdout = w
+		cmd.Stderr = w
+		go func(name string) {
+			if err := cmd.Run(); err != nil && ctx.Err() == nil {
+				logrus.Warnf("streaming logs of cloud run service %s: %s", name, err)
+			}
+			w.Close()
+		}(s.Name)
+
+		streams[s.Name] = r
+	}
+	return streams, nil
+}
+
+// gcloudArgs adds the location of the services to a `gcloud` command.
+func (c *CloudRunDeployer) gcloudArgs(arg ...string) []string {
+	args := append(arg, "--platform", "managed", "--region", c.Region)
+	if c.ProjectID != "" {
+		args = append(args, "--project", c.ProjectID)
+	}
+	return append(args, "--quiet")
+}
+
+func imageForArtifact(builds []build.Artifact, imageName string) (string, error) {
+	for _, b := range builds {
+		if b.ImageName == imageName {
+			return b.Tag, nil
+		}
+	}
+	return "", fmt.Errorf("no image built for artifact %s", imageName)
+}
+
+// cloudRunLabels formats labels for `--labels`. Cloud Run only accepts lowercase
+// letters, digits, underscores and dashes, up to 63 characters.
+func cloudRunLabels(labels map[string]string) string {
+	var pairs []string
+	for k, v := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", cloudRunLabel(k), cloudRunLabel(v)))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func cloudRunLabel(s string) string {
+	s = invalidCloudRunLabel.ReplaceAllString(strings.ToLower(s), "-")
+	if len(s) > 63 {
+		s = s[:63]
+	}
+	return s
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+const (
+	cloudRunLocation = "--platform managed --region us-central1 --project my-project --quiet"
+
+	cloudRunPending = `{"metadata": {"name": "hello", "generation": 2}, "status": {"observedGeneration": 2,
+"latestCreatedRevisionName": "hello-00002", "latestReadyRevisionName": "hello-00001",
+"conditions": [{"type": "Ready", "status": "Unknown"}]}}`
+
+	cloudRunReady = `{"metadata": {"name": "hello", "generation": 2}, "status": {"observedGeneration": 2,
+"latestCreatedRevisionName": "hello-00002", "latestReadyRevisionName": "hello-00002", "url": "https://hello-abc-uc.a.run.app",
+"conditions": [{"type": "Ready", "status": "True"}]}}`
+
+	cloudRunFailed = `{"metadata": {"name": "hello", "generation": 2}, "status": {"observedGeneration": 2,
+"latestCreatedRevisionName": "hello-00002", "latestReadyRevisionName": "hello-00001",
+"conditions": [{"type": "Ready", "status": "False", "reason": "HealthCheckFailed"}]}}`
+)
+
+func TestCloudRunDeploy(t *testing.T) {
+	tests := []struct {
+		description string
+		builds      []build.Artifact
+		command     util.Command
+		expected    string
+		shouldErr   bool
+	}{
+		{
+			description: "deploy and wait for the new revision",
+			builds:      []build.Artifact{{ImageName: "gcr.io/my-project/hello", Tag: "gcr.io/my-project/hello:v1@sha256:abac"}},
+			command: testutil.NewFakeCmd(t).
+				WithRun("gcloud run deploy hello --image gcr.io/my-project/hello:v1@sha256:abac --labels skaffold-dev-deployer=cloudrun,skaffold-dev-run-id=abc-123 --allow-unauthenticated "+cloudRunLocation).
+				WithRunOut("gcloud run services describe hello --format json "+cloudRunLocation, cloudRunPending).
+				WithRunOut("gcloud run services describe hello --format json "+cloudRunLocation, cloudRunReady),
+			expected: "Service hello is serving revision hello-00002 at https://hello-abc-uc.a.run.app\n",
+		},
+		{
+			description: "revision fails",
+			builds:      []build.Artifact{{ImageName: "gcr.io/my-project/hello", Tag: "gcr.io/my-project/hello:v1"}},
+			command: testutil.NewFakeCmd(t).
+				WithRun("gcloud run deploy hello --image gcr.io/my-project/hello:v1 --labels skaffold-dev-deployer=cloudrun,skaffold-dev-run-id=abc-123 --allow-unauthenticated "+cloudRunLocation).
+				WithRunOut("gcloud run services describe hello --format json "+cloudRunLocation, cloudRunFailed),
+			shouldErr: true,
+		},
+		{
+			description: "gcloud fails",
+			builds:      []build.Artifact{{ImageName: "gcr.io/my-project/hello", Tag: "gcr.io/my-project/hello:v1"}},
+			command: testutil.FakeRunErr(t,
+				"gcloud run deploy hello --image gcr.io/my-project/hello:v1 --labels skaffold-dev-deployer=cloudrun,skaffold-dev-run-id=abc-123 --allow-unauthenticated "+cloudRunLocation,
+				errors.New("permission denied")),
+			shouldErr: true,
+		},
+		{
+			description: "image not built",
+			command:     testutil.NewFakeCmd(t),
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			defer testutil.Override(t, &util.DefaultExecCommand, test.command)()
+			defer testutil.Override(t, &cloudRunPollPeriod, time.Duration(0))()
+
+			runCtx := &runcontext.RunContext{
+				Cfg: &latest.Pipeline{
+					Deploy: latest.DeployConfig{
+						DeployType: latest.DeployType{
+							CloudRunDeploy: &latest.CloudRunDeploy{
+								ProjectID: "my-project",
+								Region:    "us-central1",
+								Services: []latest.CloudRunService{{
+									Name:  "hello",
+									Image: "gcr.io/my-project/hello",
+									Flags: []string{"--allow-unauthenticated"},
+								}},
+							},
+						},
+					},
+				},
+			}
+			event.InitializeState(runCtx)
+			deployer := NewCloudRunDeployer(runCtx)
+
+			var out bytes.Buffer
+			err := deployer.Deploy(context.Background(), &out, test.builds, []Labeller{deployer, testLabeller{"skaffold.dev/run-id": "ABC-123"}})
+
+			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.expected, out.String())
+		})
+	}
+}
+
+func TestCloudRunCleanup(t *testing.T) {
+	defer testutil.Override(t, &util.DefaultExecCommand, testutil.FakeRun(t, "gcloud run services delete hello --platform managed --region us-central1 --quiet"))()
+
+	deployer := &CloudRunDeployer{
+		CloudRunDeploy: &latest.CloudRunDeploy{
+			Region:   "us-central1",
+			Services: []latest.CloudRunService{{Name: "hello", Image: "hello"}},
+		},
+	}
+	err := deployer.Cleanup(context.Background(), &bytes.Buffer{})
+
+	testutil.CheckError(t, false, err)
+}
+
+func TestCloudRunLabels(t *testing.T) {
+	labels := cloudRunLabels(map[string]string{
+		"skaffold.dev/tag-policy":      "git-commit",
+		"app.kubernetes.io/managed-by": "skaffold-v1.0.0",
+	})
+
+	testutil.CheckDeepEqual(t, "app-kubernetes-io-managed-by=skaffold-v1-0-0,skaffold-dev-tag-policy=git-commit", labels)
+}
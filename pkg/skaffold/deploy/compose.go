@@ -0,0 +1,209 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/deploy/kubectl"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var invalidComposeName = regexp.MustCompile(`[^a-z0-9_-]`)
+
+// DockerComposeDeployer runs images on the local Docker daemon, with `docker compose`.
+type DockerComposeDeployer struct {
+	*latest.DockerComposeDeploy
+
+	workingDir  string
+	defaultRepo util.DefaultRepoSubstitution
+}
+
+// NewDockerComposeDeployer returns a new DockerComposeDeployer for a RunContext.
+func NewDockerComposeDeployer(runCtx *runcontext.RunContext) *DockerComposeDeployer {
+	return &DockerComposeDeployer{
+		DockerComposeDeploy: runCtx.Cfg.Deploy.DockerComposeDeploy,
+		workingDir:          runCtx.WorkingDir,
+		defaultRepo:         runCtx.DefaultRepoSubstitution(),
+	}
+}
+
+// Labels returns the labels specific to docker compose.
+func (d *DockerComposeDeployer) Labels() map[string]string {
+	return map[string]string{
+		constants.Labels.Deployer: "docker-compose",
+	}
+}
+
+// Deploy writes a compose file with the built images and runs `docker compose up`.
+func (d *DockerComposeDeployer) Deploy(ctx context.Context, out io.Writer, builds []build.Artifact, _ []Labeller) error {
+	event.DeployInProgress()
+
+	if err := d.up(ctx, out, builds); err != nil {
+		event.DeployFailed(err)
+		return errors.Wrap(err, "docker compose up")
+	}
+
+	event.DeployComplete()
+	return nil
+}
+
+func (d *DockerComposeDeployer) up(ctx context.Context, out io.Writer, builds []build.Artifact) error {
+	composeFile, err := d.composeFile(builds)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile("", "skaffold-compose-*.yaml")
+	if err != nil {
+		return errors.Wrap(err, "creating compose file")
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(composeFile)
+	tmp.Close()
+	if err != nil {
+		return errors.Wrap(err, "writing compose file")
+	}
+
+	cmd := util.CommandContext(ctx, "docker", d.composeArgs("-f", tmp.Name(), "--project-directory", d.projectDirectory(), "up", "--detach", "--no-build", "--remove-orphans")...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	return cmd.Run()
+}
+
+// Render writes the compose file with the built images.
+func (d *DockerComposeDeployer) Render(_ context.Context, out io.Writer, builds []build.Artifact, _ []Labeller) error {
+	composeFile, err := d.composeFile(builds)
+	if err != nil {
+		return err
+	}
+
+	_, err = out.Write(composeFile)
+	return err
+}
+
+// Dependencies returns the user provided compose file, if any.
+func (d *DockerComposeDeployer) Dependencies() ([]string, error) {
+	if d.ComposeFile == "" {
+		return nil, nil
+	}
+	return []string{d.ComposeFile}, nil
+}
+
+// Cleanup stops and removes the containers of the project.
+func (d *DockerComposeDeployer) Cleanup(ctx context.Context, out io.Writer) error {
+	cmd := util.CommandContext(ctx, "docker", d.composeArgs("down", "--remove-orphans")...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	if err := cmd.Run(); err != nil {
+		return errors.Wrap(err, "docker compose down")
+	}
+	return nil
+}
+
+// Logs streams the logs of the project's containers, with `docker compose logs`.
+func (d *DockerComposeDeployer) Logs(ctx context.Context) (map[string]io.Reader, error) {
+	r, w := io.Pipe()
+	cmd := util.CommandContext(ctx, "docker", d.composeArgs("logs", "--follow", "--no-color")...)
+	cmd.Stdout = w
+	cmd.Stderr = w
+	go func() {
+		if err := cmd.Run(); err != nil && ctx.Err() == nil {
+			logrus.Warnln("streaming docker compose logs:", err)
+		}
+		w.Close()
+	}()
+
+	return map[string]io.Reader{d.projectName(): r}, nil
+}
+
+// composeFile returns the user provided compose file with the built images,
+// or generates one with a service per built image.
+func (d *DockerComposeDeployer) composeFile(builds []build.Artifact) ([]byte, error) {
+	if d.ComposeFile == "" {
+		return generateComposeFile(builds)
+	}
+
+	content, err := ioutil.ReadFile(d.ComposeFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading compose file")
+	}
+
+	updated, err := (&kubectl.ManifestList{content}).ReplaceImages(builds, d.defaultRepo)
+	if err != nil {
+		return nil, err
+	}
+	if len(updated) == 0 {
+		return nil, fmt.Errorf("compose file %s is empty", d.ComposeFile)
+	}
+	return updated[0], nil
+}
+
+func generateComposeFile(builds []build.Artifact) ([]byte, error) {
+	services := map[string]interface{}{}
+	for _, b := range builds {
+		services[composeName(b.ImageName[strings.LastIndex(b.ImageName, "/")+1:])] = map[string]interface{}{
+			"image": b.Tag,
+		}
+	}
+
+	return yaml.Marshal(map[string]interface{}{
+		"services": services,
+	})
+}
+
+// composeArgs runs a `docker compose` command for the project.
+func (d *DockerComposeDeployer) composeArgs(arg ...string) []string {
+	return append([]string{"compose", "-p", d.projectName()}, arg...)
+}
+
+func (d *DockerComposeDeployer) projectName() string {
+	if d.ProjectName != "" {
+		return d.ProjectName
+	}
+	return composeName(filepath.Base(d.workingDir))
+}
+
+// projectDirectory is where relative paths of the compose file are resolved from.
+func (d *DockerComposeDeployer) projectDirectory() string {
+	if d.ComposeFile == "" {
+		return d.workingDir
+	}
+	return filepath.Dir(d.ComposeFile)
+}
+
+// composeName formats a name for compose: only lowercase letters,
+// digits, underscores and dashes are allowed.
+func composeName(s string) string {
+	return invalidComposeName.ReplaceAllString(strings.ToLower(s), "-")
+}
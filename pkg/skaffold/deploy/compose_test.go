@@ -0,0 +1,109 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestDockerComposeRender(t *testing.T) {
+	tests := []struct {
+		description string
+		composeFile string
+		builds      []build.Artifact
+		expected    string
+	}{
+		{
+			description: "generated compose file",
+			builds: []build.Artifact{
+				{ImageName: "gcr.io/project/Web.App", Tag: "gcr.io/project/Web.App:v1"},
+				{ImageName: "worker", Tag: "worker:v2"},
+			},
+			expected: `services:
+  web-app:
+    image: gcr.io/project/Web.App:v1
+  worker:
+    image: worker:v2
+`,
+		},
+		{
+			description: "user provided compose file",
+			composeFile: `services:
+  web:
+    image: web
+    ports:
+    - 8080:8080
+  db:
+    image: postgres:11
+`,
+			builds: []build.Artifact{{ImageName: "web", Tag: "web:abc"}},
+			expected: `services:
+  db:
+    image: postgres:11
+  web:
+    image: web:abc
+    ports:
+    - 8080:8080
+`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			config := &latest.DockerComposeDeploy{}
+			if test.composeFile != "" {
+				tmpDir, cleanup := testutil.NewTempDir(t)
+				defer cleanup()
+
+				tmpDir.Write("docker-compose.yaml", test.composeFile)
+				config.ComposeFile = tmpDir.Path("docker-compose.yaml")
+			}
+
+			deployer := &DockerComposeDeployer{DockerComposeDeploy: config}
+
+			var out bytes.Buffer
+			err := deployer.Render(context.Background(), &out, test.builds, nil)
+
+			testutil.CheckErrorAndDeepEqual(t, false, err, test.expected, out.String())
+		})
+	}
+}
+
+func TestDockerComposeCleanup(t *testing.T) {
+	defer testutil.Override(t, &util.DefaultExecCommand, testutil.FakeRun(t, "docker compose -p my-app down --remove-orphans"))()
+
+	deployer := &DockerComposeDeployer{
+		DockerComposeDeploy: &latest.DockerComposeDeploy{},
+		workingDir:          "/home/user/My.App",
+	}
+	err := deployer.Cleanup(context.Background(), &bytes.Buffer{})
+
+	testutil.CheckError(t, false, err)
+}
+
+func TestDockerComposeDependencies(t *testing.T) {
+	deployer := &DockerComposeDeployer{DockerComposeDeploy: &latest.DockerComposeDeploy{ComposeFile: "docker-compose.yaml"}}
+	deps, err := deployer.Dependencies()
+
+	testutil.CheckErrorAndDeepEqual(t, false, err, []string{"docker-compose.yaml"}, deps)
+}
@@ -32,6 +32,10 @@ type Deployer interface {
 	// cluster.
 	Deploy(context.Context, io.Writer, []build.Artifact, []Labeller) error
 
+	// Render writes the manifests that Deploy would apply to the cluster,
+	// without deploying them.
+	Render(context.Context, io.Writer, []build.Artifact, []Labeller) error
+
 	// Dependencies returns a list of files that the deployer depends on.
 	// In dev mode, a redeploy will be triggered
 	Dependencies() ([]string, error)
@@ -0,0 +1,232 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/deploy/kubectl"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+)
+
+const defaultDiagnosticsLogLines = 20
+
+// diagnostic is one piece of information gathered about a failed resource.
+type diagnostic struct {
+	name    string
+	content string
+}
+
+// gatherDiagnostics collects the describe output, the recent events and the logs of
+// crashing containers for the pods of a resource that failed its status check.
+func gatherDiagnostics(ctx context.Context, cli *kubectl.CLI, client kubernetes.Interface, r statusCheckResource, logLines int) []diagnostic {
+	var diags []diagnostic
+
+	if events, err := resourceEvents(client, r); err != nil {
+		logrus.Debugf("Unable to list events for %s: %s", r, err)
+	} else if events != "" {
+		diags = append(diags, diagnostic{name: fmt.Sprintf("%s/events", r), content: events})
+	}
+
+	pods, err := podsForResource(client, r)
+	if err != nil {
+		logrus.Debugf("Unable to list pods for %s: %s", r, err)
+		return diags
+	}
+
+	for _, pod := range pods {
+		describe, err := cli.RunOut(ctx, "describe", nil, "pod", pod.Name, "--namespace", pod.Namespace)
+		if err != nil {
+			logrus.Debugf("Unable to describe pod %s: %s", pod.Name, err)
+		} else {
+			diags = append(diags, diagnostic{name: fmt.Sprintf("%s/pods/%s/describe", r, pod.Name), content: string(describe)})
+		}
+
+		for _, cs := range crashingContainers(pod) {
+			args := []string{pod.Name, "--namespace", pod.Namespace, "-c", cs.Name, "--tail", strconv.Itoa(logLines)}
+			if cs.RestartCount > 0 {
+				args = append(args, "--previous")
+			}
+			logs, err := cli.RunOut(ctx, "logs", nil, args...)
+			if err != nil {
+				logrus.Debugf("Unable to get logs of container %s in pod %s: %s", cs.Name, pod.Name, err)
+				continue
+			}
+			diags = append(diags, diagnostic{name: fmt.Sprintf("%s/pods/%s/logs/%s", r, pod.Name, cs.Name), content: string(logs)})
+		}
+	}
+
+	return diags
+}
+
+// resourceEvents returns the events of a resource, sorted from oldest to newest.
+func resourceEvents(client kubernetes.Interface, r statusCheckResource) (string, error) {
+	list, err := client.CoreV1().Events(r.namespace).List(metav1.ListOptions{
+		FieldSelector: fields.Set{
+			"involvedObject.kind": r.kind,
+			"involvedObject.name": r.name,
+		}.AsSelector().String(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	events := list.Items
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].LastTimestamp.Before(&events[j].LastTimestamp)
+	})
+
+	var lines []string
+	for _, e := range events {
+		lines = append(lines, fmt.Sprintf("%s\t%s\t%s\t%s", e.LastTimestamp.Format(time.RFC3339), e.Type, e.Reason, e.Message))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func podsForResource(client kubernetes.Interface, r statusCheckResource) ([]v1.Pod, error) {
+	var selector *metav1.LabelSelector
+
+	switch r.kind {
+	case "Deployment":
+		d, err := client.AppsV1().Deployments(r.namespace).Get(r.name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		selector = d.Spec.Selector
+	case "StatefulSet":
+		s, err := client.AppsV1().StatefulSets(r.namespace).Get(r.name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		selector = s.Spec.Selector
+	case "DaemonSet":
+		d, err := client.AppsV1().DaemonSets(r.namespace).Get(r.name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		selector = d.Spec.Selector
+	case "ReplicaSet":
+		rs, err := client.AppsV1().ReplicaSets(r.namespace).Get(r.name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		selector = rs.Spec.Selector
+	case knativeServiceKind:
+		selector = &metav1.LabelSelector{MatchLabels: map[string]string{"serving.knative.dev/service": r.name}}
+	default:
+		return nil, fmt.Errorf("unsupported kind %s", r.kind)
+	}
+
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing selector")
+	}
+
+	pods, err := client.CoreV1().Pods(r.namespace).List(metav1.ListOptions{
+		LabelSelector: s.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
+
+// crashingContainers returns the containers of a pod that are restarting,
+// waiting to be restarted or that terminated with an error.
+func crashingContainers(pod v1.Pod) []v1.ContainerStatus {
+	var crashing []v1.ContainerStatus
+
+	for _, cs := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		switch {
+		case cs.RestartCount > 0:
+			crashing = append(crashing, cs)
+		case cs.State.Terminated != nil && cs.State.Terminated.ExitCode != 0:
+			crashing = append(crashing, cs)
+		}
+	}
+
+	return crashing
+}
+
+func printDiagnostics(out io.Writer, diags []diagnostic) {
+	for _, d := range diags {
+		color.Yellow.Fprintf(out, "---- %s\n", d.name)
+		fmt.Fprintln(out, strings.TrimRight(d.content, "\n"))
+	}
+}
+
+// writeDiagnosticsTarball writes the diagnostics into a gzipped tarball, one file per diagnostic.
+func writeDiagnosticsTarball(path string, diags []diagnostic) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, "creating diagnostics tarball")
+	}
+	defer f.Close()
+
+	gw := gzip.NewWriter(f)
+	defer gw.Close()
+	tw := tar.NewWriter(gw)
+	defer tw.Close()
+
+	now := time.Now()
+	for _, d := range diags {
+		hdr := &tar.Header{
+			Name:    strings.Replace(d.name, ":", "/", 1),
+			Mode:    0644,
+			Size:    int64(len(d.content)),
+			ModTime: now,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return errors.Wrapf(err, "writing header for %s", d.name)
+		}
+		if _, err := tw.Write([]byte(d.content)); err != nil {
+			return errors.Wrapf(err, "writing %s", d.name)
+		}
+	}
+
+	return nil
+}
+
+func diagnosticsLogLines(cfg *latest.StatusCheckConfig) int {
+	if cfg == nil || cfg.Diagnostics == nil || cfg.Diagnostics.LogLines <= 0 {
+		return defaultDiagnosticsLogLines
+	}
+	return cfg.Diagnostics.LogLines
+}
+
+func diagnosticsTarball(cfg *latest.StatusCheckConfig) string {
+	if cfg == nil || cfg.Diagnostics == nil {
+		return ""
+	}
+	return cfg.Diagnostics.Tarball
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/deploy/kubectl"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCrashingContainers(t *testing.T) {
+	tests := []struct {
+		description string
+		status      v1.PodStatus
+		expected    []string
+	}{
+		{
+			description: "healthy",
+			status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{{Name: "app", State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}}},
+			},
+		},
+		{
+			description: "restarting",
+			status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{{Name: "app", RestartCount: 3}},
+			},
+			expected: []string{"app"},
+		},
+		{
+			description: "init container failed",
+			status: v1.PodStatus{
+				InitContainerStatuses: []v1.ContainerStatus{{Name: "init", State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 1}}}},
+				ContainerStatuses:     []v1.ContainerStatus{{Name: "app"}},
+			},
+			expected: []string{"init"},
+		},
+		{
+			description: "completed successfully",
+			status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{{Name: "job", State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 0}}}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			var names []string
+			for _, cs := range crashingContainers(v1.Pod{Status: test.status}) {
+				names = append(names, cs.Name)
+			}
+
+			testutil.CheckDeepEqual(t, test.expected, names)
+		})
+	}
+}
+
+func TestGatherDiagnostics(t *testing.T) {
+	labels := map[string]string{"app": "web"}
+
+	client := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "test"},
+			Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: labels}},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "test", Labels: labels},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{{Name: "app", RestartCount: 2}},
+			},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "test"},
+		},
+	)
+
+	reset := testutil.Override(t, &util.DefaultExecCommand, testutil.NewFakeCmd(t).
+		WithRunOut("kubectl --context kubecontext describe pod web-1 --namespace test", "Name: web-1").
+		WithRunOut("kubectl --context kubecontext logs web-1 --namespace test -c app --tail 5 --previous", "panic: boom"))
+	defer reset()
+
+	cli := &kubectl.CLI{KubeContext: testKubeContext}
+	r := statusCheckResource{kind: "Deployment", name: "web", namespace: "test"}
+
+	diags := gatherDiagnostics(context.Background(), cli, client, r, 5)
+
+	expected := []diagnostic{
+		{name: "test:deployment/web/pods/web-1/describe", content: "Name: web-1"},
+		{name: "test:deployment/web/pods/web-1/logs/app", content: "panic: boom"},
+	}
+	testutil.CheckDeepEqual(t, expected, diags, cmp.AllowUnexported(diagnostic{}))
+}
+
+func TestWriteDiagnosticsTarball(t *testing.T) {
+	tmpDir, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+
+	path := tmpDir.Path("diagnostics.tar.gz")
+	diags := []diagnostic{
+		{name: "test:deployment/web/events", content: "events"},
+		{name: "test:deployment/web/pods/web-1/describe", content: "describe"},
+	}
+
+	err := writeDiagnosticsTarball(path, diags)
+	testutil.CheckError(t, false, err)
+
+	f, err := os.Open(path)
+	testutil.CheckError(t, false, err)
+	defer f.Close()
+	gr, err := gzip.NewReader(f)
+	testutil.CheckError(t, false, err)
+
+	files := map[string]string{}
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			break
+		}
+		content, err := ioutil.ReadAll(tr)
+		testutil.CheckError(t, false, err)
+		files[filepath.ToSlash(hdr.Name)] = string(content)
+	}
+
+	testutil.CheckDeepEqual(t, map[string]string{
+		"test/deployment/web/events":              "events",
+		"test/deployment/web/pods/web-1/describe": "describe",
+	}, files)
+}
+
+func TestDiagnosticsSettings(t *testing.T) {
+	testutil.CheckDeepEqual(t, defaultDiagnosticsLogLines, diagnosticsLogLines(nil))
+	testutil.CheckDeepEqual(t, "", diagnosticsTarball(nil))
+
+	cfg := &latest.StatusCheckConfig{
+		Diagnostics: &latest.StatusCheckDiagnostics{LogLines: 50, Tarball: "out.tar.gz"},
+	}
+	testutil.CheckDeepEqual(t, 50, diagnosticsLogLines(cfg))
+	testutil.CheckDeepEqual(t, "out.tar.gz", diagnosticsTarball(cfg))
+}
@@ -0,0 +1,281 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/api/extensions/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// Timeout of a single probe.
+	endpointRequestTimeout = 5 * time.Second
+
+	// Poll period for probing an endpoint.
+	defaultEndpointPollPeriod = time.Second
+)
+
+var (
+	// For testing
+	executeEndpointProbe = probeEndpoint
+	endpointPollPeriod   = defaultEndpointPollPeriod
+)
+
+// statusCheckEndpoint is a Service or an Ingress probed over HTTP(S).
+type statusCheckEndpoint struct {
+	statusCheckResource
+	config latest.StatusCheckEndpoint
+}
+
+// getStatusCheckEndpoints finds the namespace of each configured endpoint.
+func getStatusCheckEndpoints(client kubernetes.Interface, namespaces []string, cfg *latest.StatusCheckConfig) ([]statusCheckEndpoint, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	var endpoints []statusCheckEndpoint
+	for _, e := range cfg.Endpoints {
+		kind, err := endpointKind(e.Kind)
+		if err != nil {
+			return nil, err
+		}
+
+		namespace, err := findEndpointNamespace(client, namespaces, kind, e.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		endpoints = append(endpoints, statusCheckEndpoint{
+			statusCheckResource: statusCheckResource{kind: kind, name: e.Name, namespace: namespace},
+			config:              e,
+		})
+	}
+
+	return endpoints, nil
+}
+
+func endpointKind(kind string) (string, error) {
+	switch {
+	case strings.EqualFold(kind, "Service"):
+		return "Service", nil
+	case strings.EqualFold(kind, "Ingress"):
+		return "Ingress", nil
+	default:
+		return "", fmt.Errorf("unsupported endpoint kind %q, should be Service or Ingress", kind)
+	}
+}
+
+func findEndpointNamespace(client kubernetes.Interface, namespaces []string, kind, name string) (string, error) {
+	for _, ns := range namespaces {
+		var err error
+		switch kind {
+		case "Service":
+			_, err = client.CoreV1().Services(ns).Get(name, metav1.GetOptions{})
+		case "Ingress":
+			_, err = client.ExtensionsV1beta1().Ingresses(ns).Get(name, metav1.GetOptions{})
+		}
+		if err == nil {
+			return ns, nil
+		}
+		if !apierrors.IsNotFound(err) {
+			return "", errors.Wrapf(err, "getting %s %s", strings.ToLower(kind), name)
+		}
+	}
+
+	return "", fmt.Errorf("%s %s not found", strings.ToLower(kind), name)
+}
+
+// endpointDeadline is the time given to an endpoint to answer with the expected status.
+func endpointDeadline(cfg *latest.StatusCheckConfig, e statusCheckEndpoint) time.Duration {
+	if e.config.TimeoutSeconds > 0 {
+		return time.Duration(e.config.TimeoutSeconds) * time.Second
+	}
+	return settingsForResource(cfg, e.statusCheckResource).deadline
+}
+
+func pollEndpoint(ctx context.Context, client kubernetes.Interface, e statusCheckEndpoint, deadline time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, deadline)
+	defer cancel()
+
+	var lastErr error
+	for {
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return errors.Wrapf(lastErr, "not reachable within %v", deadline)
+			}
+			return fmt.Errorf("not reachable within %v", deadline)
+		case <-time.After(endpointPollPeriod):
+			status, err := executeEndpointProbe(ctx, client, e)
+			if err == nil && !statusMatches(e.config.Status, status) {
+				err = fmt.Errorf("unexpected status %d", status)
+			}
+			if err != nil {
+				logrus.Debugf("Probing %s: %s", e, err)
+				lastErr = err
+				continue
+			}
+
+			return nil
+		}
+	}
+}
+
+// statusMatches checks a status code against the expected one.
+// Any 2xx status is accepted if no status is expected.
+func statusMatches(expected, actual int) bool {
+	if expected == 0 {
+		return actual >= 200 && actual < 300
+	}
+	return actual == expected
+}
+
+// probeEndpoint sends an HTTP(S) request to an endpoint and returns the status code.
+func probeEndpoint(ctx context.Context, client kubernetes.Interface, e statusCheckEndpoint) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, endpointRequestTimeout)
+	defer cancel()
+
+	switch e.kind {
+	case "Service":
+		return probeService(ctx, client, e)
+	case "Ingress":
+		return probeIngress(ctx, client, e)
+	default:
+		return 0, fmt.Errorf("unsupported endpoint kind %s", e.kind)
+	}
+}
+
+// probeService reaches a service through the API server proxy, which works
+// for any service type, without exposing it outside of the cluster.
+func probeService(ctx context.Context, client kubernetes.Interface, e statusCheckEndpoint) (int, error) {
+	var port string
+	if e.config.Port > 0 {
+		port = strconv.Itoa(e.config.Port)
+	}
+
+	req, ok := client.CoreV1().Services(e.namespace).ProxyGet(e.config.Scheme, e.name, port, endpointPath(e.config), nil).(*rest.Request)
+	if !ok {
+		return 0, errors.New("unable to proxy requests to services")
+	}
+
+	var status int
+	err := req.Context(ctx).Do().StatusCode(&status).Error()
+	if status == 0 {
+		return 0, errors.Wrap(err, "proxying request")
+	}
+	return status, nil
+}
+
+// probeIngress reaches an ingress through its load balancer, using the host of its first rule.
+func probeIngress(ctx context.Context, client kubernetes.Interface, e statusCheckEndpoint) (int, error) {
+	ing, err := client.ExtensionsV1beta1().Ingresses(e.namespace).Get(e.name, metav1.GetOptions{})
+	if err != nil {
+		return 0, errors.Wrap(err, "getting ingress")
+	}
+
+	url, host, err := ingressURL(ing, e.config)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "creating request")
+	}
+	if host != "" {
+		req.Host = host
+	}
+
+	httpClient := http.DefaultClient
+	if host != "" && req.URL.Scheme == "https" {
+		// Verify the certificate against the ingress host, not the load balancer address.
+		httpClient = &http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{ServerName: host},
+			},
+		}
+	}
+
+	resp, err := httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
+
+// ingressURL returns the url of an ingress's load balancer and the host to request.
+func ingressURL(ing *v1beta1.Ingress, e latest.StatusCheckEndpoint) (string, string, error) {
+	var address string
+	for _, lb := range ing.Status.LoadBalancer.Ingress {
+		if lb.IP != "" {
+			address = lb.IP
+			break
+		}
+		if lb.Hostname != "" {
+			address = lb.Hostname
+			break
+		}
+	}
+	if address == "" {
+		return "", "", errors.New("no load balancer address yet")
+	}
+
+	scheme := e.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	if e.Port > 0 {
+		address = fmt.Sprintf("%s:%d", address, e.Port)
+	}
+
+	var host string
+	for _, rule := range ing.Spec.Rules {
+		if rule.Host != "" {
+			host = rule.Host
+			break
+		}
+	}
+
+	return fmt.Sprintf("%s://%s%s", scheme, address, endpointPath(e)), host, nil
+}
+
+func endpointPath(e latest.StatusCheckEndpoint) string {
+	if e.Path == "" {
+		return "/"
+	}
+	if !strings.HasPrefix(e.Path, "/") {
+		return "/" + e.Path
+	}
+	return e.Path
+}
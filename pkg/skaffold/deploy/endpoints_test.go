@@ -0,0 +1,188 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetStatusCheckEndpoints(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "other"}},
+		&v1beta1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "front", Namespace: "test"}},
+	)
+
+	tests := []struct {
+		description string
+		endpoints   []latest.StatusCheckEndpoint
+		expected    []statusCheckResource
+		shouldErr   bool
+	}{
+		{
+			description: "service and ingress",
+			endpoints: []latest.StatusCheckEndpoint{
+				{Kind: "service", Name: "web"},
+				{Kind: "Ingress", Name: "front"},
+			},
+			expected: []statusCheckResource{
+				{kind: "Service", name: "web", namespace: "other"},
+				{kind: "Ingress", name: "front", namespace: "test"},
+			},
+		},
+		{
+			description: "not found",
+			endpoints:   []latest.StatusCheckEndpoint{{Kind: "Service", Name: "unknown"}},
+			shouldErr:   true,
+		},
+		{
+			description: "unsupported kind",
+			endpoints:   []latest.StatusCheckEndpoint{{Kind: "Deployment", Name: "web"}},
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			endpoints, err := getStatusCheckEndpoints(client, []string{"test", "other"}, &latest.StatusCheckConfig{Endpoints: test.endpoints})
+
+			var actual []statusCheckResource
+			for _, e := range endpoints {
+				actual = append(actual, e.statusCheckResource)
+			}
+			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.expected, actual, cmp.AllowUnexported(statusCheckResource{}))
+		})
+	}
+}
+
+func TestIngressURL(t *testing.T) {
+	tests := []struct {
+		description  string
+		ingress      *v1beta1.Ingress
+		endpoint     latest.StatusCheckEndpoint
+		expectedURL  string
+		expectedHost string
+		shouldErr    bool
+	}{
+		{
+			description: "no address yet",
+			ingress:     &v1beta1.Ingress{},
+			shouldErr:   true,
+		},
+		{
+			description: "ip",
+			ingress: &v1beta1.Ingress{
+				Status: v1beta1.IngressStatus{LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{IP: "10.0.0.1"}}}},
+			},
+			expectedURL: "http://10.0.0.1/",
+		},
+		{
+			description: "hostname with rule host",
+			ingress: &v1beta1.Ingress{
+				Spec:   v1beta1.IngressSpec{Rules: []v1beta1.IngressRule{{}, {Host: "example.com"}}},
+				Status: v1beta1.IngressStatus{LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{Hostname: "lb.cloud"}}}},
+			},
+			endpoint:     latest.StatusCheckEndpoint{Scheme: "https", Port: 8443, Path: "healthz"},
+			expectedURL:  "https://lb.cloud:8443/healthz",
+			expectedHost: "example.com",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			url, host, err := ingressURL(test.ingress, test.endpoint)
+
+			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.expectedURL, url)
+			testutil.CheckDeepEqual(t, test.expectedHost, host)
+		})
+	}
+}
+
+func TestStatusMatches(t *testing.T) {
+	testutil.CheckDeepEqual(t, true, statusMatches(0, 204))
+	testutil.CheckDeepEqual(t, false, statusMatches(0, 302))
+	testutil.CheckDeepEqual(t, true, statusMatches(401, 401))
+	testutil.CheckDeepEqual(t, false, statusMatches(401, 200))
+}
+
+func TestEndpointDeadline(t *testing.T) {
+	cfg := &latest.StatusCheckConfig{DeadlineSeconds: 60}
+
+	testutil.CheckDeepEqual(t, time.Minute, endpointDeadline(cfg, statusCheckEndpoint{}))
+	testutil.CheckDeepEqual(t, 5*time.Second, endpointDeadline(cfg, statusCheckEndpoint{config: latest.StatusCheckEndpoint{TimeoutSeconds: 5}}))
+}
+
+func TestPollEndpoint(t *testing.T) {
+	tests := []struct {
+		description string
+		expected    int
+		statuses    []int
+		errs        []error
+		shouldErr   bool
+	}{
+		{
+			description: "reachable after a while",
+			statuses:    []int{0, 503, 200},
+			errs:        []error{errors.New("connection refused"), nil, nil},
+		},
+		{
+			description: "expected status",
+			expected:    401,
+			statuses:    []int{401},
+			errs:        []error{nil},
+		},
+		{
+			description: "never reachable",
+			statuses:    []int{503},
+			errs:        []error{nil},
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			i := 0
+			reset := testutil.Override(t, &executeEndpointProbe, func(context.Context, kubernetes.Interface, statusCheckEndpoint) (int, error) {
+				if i >= len(test.statuses) {
+					i = len(test.statuses) - 1
+				}
+				status, err := test.statuses[i], test.errs[i]
+				i++
+				return status, err
+			})
+			defer reset()
+			resetPoll := testutil.Override(t, &endpointPollPeriod, time.Millisecond)
+			defer resetPoll()
+
+			e := statusCheckEndpoint{
+				statusCheckResource: statusCheckResource{kind: "Service", name: "web"},
+				config:              latest.StatusCheckEndpoint{Status: test.expected},
+			}
+			err := pollEndpoint(context.Background(), nil, e, 100*time.Millisecond)
+
+			testutil.CheckError(t, test.shouldErr, err)
+		})
+	}
+}
@@ -22,33 +22,42 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/deploy/kubectl"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/docker"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
 	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/sops"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	yaml "gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
+// maxReleaseNameLength is the longest release name Helm accepts.
+const maxReleaseNameLength = 53
+
 type HelmDeployer struct {
 	*latest.HelmDeploy
 
 	kubeContext string
 	namespace   string
-	defaultRepo string
+	defaultRepo util.DefaultRepoSubstitution
 	forceDeploy bool
+	concurrency int
+	offline     bool
 }
 
 // NewHelmDeployer returns a new HelmDeployer for a DeployConfig filled
@@ -58,8 +67,10 @@ func NewHelmDeployer(runCtx *runcontext.RunContext) *HelmDeployer {
 		HelmDeploy:  runCtx.Cfg.Deploy.HelmDeploy,
 		kubeContext: runCtx.KubeContext,
 		namespace:   runCtx.Opts.Namespace,
-		defaultRepo: runCtx.DefaultRepo,
+		defaultRepo: runCtx.DefaultRepoSubstitution(),
 		forceDeploy: runCtx.Opts.ForceDeploy(),
+		concurrency: runCtx.Cfg.Deploy.Concurrency,
+		offline:     runCtx.Opts.Offline,
 	}
 }
 
@@ -74,26 +85,79 @@ func (h *HelmDeployer) Deploy(ctx context.Context, out io.Writer, builds []build
 
 	event.DeployInProgress()
 
-	for _, r := range h.Releases {
-		results, err := h.deployRelease(ctx, out, r, builds)
-		if err != nil {
-			releaseName, _ := evaluateReleaseName(r.Name)
+	if err := h.checkReleaseNames(); err != nil {
+		event.DeployFailed(err)
+		return err
+	}
 
+	if h.concurrency > 1 {
+		results, err := h.deployReleasesConcurrently(ctx, out, builds)
+		if err != nil {
 			event.DeployFailed(err)
-			return errors.Wrapf(err, "deploying %s", releaseName)
+			return err
 		}
 
-		dRes = append(dRes, results...)
+		dRes = results
+	} else {
+		for _, r := range h.Releases {
+			results, err := h.deployRelease(ctx, out, r, builds)
+			if err != nil {
+				releaseName, _ := evaluateReleaseName(r.Name)
+
+				event.DeployFailed(err)
+				return errors.Wrapf(err, "deploying %s", releaseName)
+			}
+
+			dRes = append(dRes, results...)
+		}
 	}
 
 	event.DeployComplete()
 
-	labels := merge(labellers...)
-	labelDeployResults(labels, dRes)
+	labelDeployResults(merge(labellers...), mergeAnnotations(labellers...), dRes)
 
 	return nil
 }
 
+// deployReleasesConcurrently installs or upgrades h.concurrency releases at a time.
+func (h *HelmDeployer) deployReleasesConcurrently(ctx context.Context, out io.Writer, builds []build.Artifact) ([]Artifact, error) {
+	var (
+		wg       sync.WaitGroup
+		outLock  sync.Mutex
+		results  = make([][]Artifact, len(h.Releases))
+		errs     = make([]error, len(h.Releases))
+		throttle = make(chan struct{}, h.concurrency)
+	)
+	for i, r := range h.Releases {
+		wg.Add(1)
+		go func(i int, r latest.HelmRelease) {
+			defer wg.Done()
+			throttle <- struct{}{}
+			defer func() { <-throttle }()
+
+			// Buffer the output so that concurrent releases don't interleave.
+			var buf bytes.Buffer
+			results[i], errs[i] = h.deployRelease(ctx, &buf, r, builds)
+
+			outLock.Lock()
+			defer outLock.Unlock()
+			io.Copy(out, &buf)
+		}(i, r)
+	}
+	wg.Wait()
+
+	var dRes []Artifact
+	for i, r := range h.Releases {
+		if errs[i] != nil {
+			releaseName, _ := evaluateReleaseName(r.Name)
+			return nil, errors.Wrapf(errs[i], "deploying %s", releaseName)
+		}
+		dRes = append(dRes, results[i]...)
+	}
+
+	return dRes, nil
+}
+
 func (h *HelmDeployer) Dependencies() ([]string, error) {
 	var deps []string
 	for _, release := range h.Releases {
@@ -141,19 +205,74 @@ func (h *HelmDeployer) Cleanup(ctx context.Context, out io.Writer) error {
 	return nil
 }
 
-func (h *HelmDeployer) helm(ctx context.Context, out io.Writer, useSecrets bool, arg ...string) error {
-	args := append([]string{"--kube-context", h.kubeContext}, arg...)
+func (h *HelmDeployer) helm(ctx context.Context, out io.Writer, kubeContext string, useSecrets bool, arg ...string) error {
+	cmd := util.CommandContext(ctx, util.ToolPath("helm"), h.helmArgs(kubeContext, useSecrets, arg...)...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+
+	return cmd.Run()
+}
+
+// helmOut runs helm and returns its standard output.
+func (h *HelmDeployer) helmOut(ctx context.Context, kubeContext string, useSecrets bool, arg ...string) ([]byte, error) {
+	cmd := util.CommandContext(ctx, util.ToolPath("helm"), h.helmArgs(kubeContext, useSecrets, arg...)...)
+	return cmd.RunOut()
+}
+
+func (h *HelmDeployer) helmArgs(kubeContext string, useSecrets bool, arg ...string) []string {
+	args := append([]string{"--kube-context", kubeContext}, arg...)
 	args = append(args, h.Flags.Global...)
 
 	if useSecrets {
 		args = append([]string{"secrets"}, args...)
 	}
 
-	cmd := exec.CommandContext(ctx, "helm", args...)
-	cmd.Stdout = out
-	cmd.Stderr = out
+	return args
+}
+
+// Render runs `helm template` on each release.
+func (h *HelmDeployer) Render(ctx context.Context, out io.Writer, builds []build.Artifact, labellers []Labeller) error {
+	if err := h.checkReleaseNames(); err != nil {
+		return err
+	}
 
-	return util.RunCmd(cmd)
+	var manifests kubectl.ManifestList
+	for _, r := range h.Releases {
+		releaseName, err := evaluateReleaseName(r.Name)
+		if err != nil {
+			return errors.Wrap(err, "cannot parse the release name template")
+		}
+		if r.Remote {
+			return fmt.Errorf("rendering remote chart %s is not supported", r.ChartPath)
+		}
+
+		kubeContext, err := h.releaseKubeContext(r)
+		if err != nil {
+			return errors.Wrapf(err, "rendering %s", releaseName)
+		}
+
+		chartArgs, setOpts, cleanup, err := h.releaseArgs(ctx, ioutil.Discard, r, builds)
+		if err != nil {
+			return errors.Wrapf(err, "rendering %s", releaseName)
+		}
+
+		args := append([]string{"template", "--name", releaseName}, chartArgs...)
+		args = append(args, setOpts...)
+		rendered, err := h.helmOut(ctx, kubeContext, r.UseHelmSecrets, args...)
+		cleanup()
+		if err != nil {
+			return errors.Wrapf(err, "rendering %s", releaseName)
+		}
+
+		manifests.Append(rendered)
+	}
+
+	manifests, err := setMetadata(manifests, labellers)
+	if err != nil {
+		return err
+	}
+
+	return writeManifests(out, manifests)
 }
 
 func (h *HelmDeployer) deployRelease(ctx context.Context, out io.Writer, r latest.HelmRelease, builds []build.Artifact) ([]Artifact, error) {
@@ -163,22 +282,110 @@ func (h *HelmDeployer) deployRelease(ctx context.Context, out io.Writer, r lates
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot parse the release name template")
 	}
-	if err := h.helm(ctx, out, false, "get", releaseName); err != nil {
+	kubeContext, err := h.releaseKubeContext(r)
+	if err != nil {
+		return nil, err
+	}
+	ns, err := h.releaseNamespace(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.helm(ctx, out, kubeContext, false, "get", releaseName); err != nil {
 		color.Red.Fprintf(out, "Helm release %s not installed. Installing...\n", releaseName)
 		isInstalled = false
 	}
+
+	chartArgs, setOpts, cleanup, err := h.releaseArgs(ctx, out, r, builds)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	var args []string
+	if !isInstalled {
+		args = append(args, "install", "--name", releaseName)
+		args = append(args, h.Flags.Install...)
+	} else {
+		args = append(args, "upgrade", releaseName)
+		args = append(args, h.Flags.Upgrade...)
+		if h.forceDeploy {
+			args = append(args, "--force")
+		}
+		if r.RecreatePods {
+			args = append(args, "--recreate-pods")
+		}
+	}
+	args = append(args, chartArgs...)
+
+	if r.Wait {
+		args = append(args, "--wait")
+	}
+	args = append(args, setOpts...)
+
+	helmErr := h.helm(ctx, out, kubeContext, r.UseHelmSecrets, args...)
+	if kubeContext != h.kubeContext {
+		// Resources deployed to other contexts are not labelled nor status checked.
+		return nil, helmErr
+	}
+	return h.getDeployResults(ctx, ns, releaseName), helmErr
+}
+
+// releaseNamespace returns the namespace of a release. The --namespace flag
+// takes precedence over the release's templated namespace.
+func (h *HelmDeployer) releaseNamespace(r latest.HelmRelease) (string, error) {
+	if h.namespace != "" {
+		return h.namespace, nil
+	}
+
+	ns, err := concretize(r.Namespace)
+	if err != nil {
+		return "", errors.Wrap(err, `concretize "namespace" template`)
+	}
+	return ns, nil
+}
+
+// releaseKubeContext returns the templated kubectl context of a release,
+// defaulting to the current context.
+func (h *HelmDeployer) releaseKubeContext(r latest.HelmRelease) (string, error) {
+	if r.KubeContext == "" {
+		return h.kubeContext, nil
+	}
+
+	kubeContext, err := concretize(r.KubeContext)
+	if err != nil {
+		return "", errors.Wrap(err, `concretize "kubeContext" template`)
+	}
+	return kubeContext, nil
+}
+
+// releaseArgs builds the chart dependencies and returns the arguments that
+// select the chart, its namespace and its values, followed by the values to set.
+// The returned cleanup function removes the temporary values files.
+func (h *HelmDeployer) releaseArgs(ctx context.Context, out io.Writer, r latest.HelmRelease, builds []build.Artifact) (args []string, setOpts []string, cleanup func(), err error) {
+	var cleanups []func()
+	cleanupAll := func() {
+		for _, c := range cleanups {
+			c()
+		}
+	}
+	defer func() {
+		if err != nil {
+			cleanupAll()
+		}
+	}()
+
 	params, err := h.joinTagsToBuildResult(builds, r.Values)
 	if err != nil {
-		return nil, errors.Wrap(err, "matching build results to chart values")
+		return nil, nil, nil, errors.Wrap(err, "matching build results to chart values")
 	}
 
-	var setOpts []string
 	for k, v := range params {
 		setOpts = append(setOpts, "--set")
 		if r.ImageStrategy.HelmImageConfig.HelmConventionConfig != nil {
 			dockerRef, err := docker.ParseReference(v.Tag)
 			if err != nil {
-				return nil, errors.Wrapf(err, "cannot parse the docker image reference %s", v.Tag)
+				return nil, nil, nil, errors.Wrapf(err, "cannot parse the docker image reference %s", v.Tag)
 			}
 			imageRepositoryTag := fmt.Sprintf("%s.repository=%s,%s.tag=%s", k, dockerRef.BaseName, k, dockerRef.Tag)
 			setOpts = append(setOpts, imageRepositoryTag)
@@ -191,26 +398,12 @@ func (h *HelmDeployer) deployRelease(ctx context.Context, out io.Writer, r lates
 	// with local dependencies in the chart folder, e.g. the istio helm chart.
 	// This decision is left to the user.
 	// Dep builds should also be skipped whenever a remote chart path is specified.
-	if !r.SkipBuildDependencies && !r.Remote {
+	// In offline mode, the dependencies are expected to be already in the chart folder.
+	if !r.SkipBuildDependencies && !r.Remote && !h.offline {
 		// First build dependencies.
 		logrus.Infof("Building helm dependencies...")
-		if err := h.helm(ctx, out, false, "dep", "build", r.ChartPath); err != nil {
-			return nil, errors.Wrap(err, "building helm dependencies")
-		}
-	}
-
-	var args []string
-	if !isInstalled {
-		args = append(args, "install", "--name", releaseName)
-		args = append(args, h.Flags.Install...)
-	} else {
-		args = append(args, "upgrade", releaseName)
-		args = append(args, h.Flags.Upgrade...)
-		if h.forceDeploy {
-			args = append(args, "--force")
-		}
-		if r.RecreatePods {
-			args = append(args, "--recreate-pods")
+		if err := h.helm(ctx, out, h.kubeContext, false, "dep", "build", r.ChartPath); err != nil {
+			return nil, nil, nil, errors.Wrap(err, "building helm dependencies")
 		}
 	}
 
@@ -229,16 +422,14 @@ func (h *HelmDeployer) deployRelease(ctx context.Context, out io.Writer, r lates
 	} else {
 		chartPath, err := h.packageChart(ctx, r)
 		if err != nil {
-			return nil, errors.WithMessage(err, "cannot package chart")
+			return nil, nil, nil, errors.WithMessage(err, "cannot package chart")
 		}
 		args = append(args, chartPath)
 	}
 
-	var ns string
-	if h.namespace != "" {
-		ns = h.namespace
-	} else if r.Namespace != "" {
-		ns = r.Namespace
+	ns, err := h.releaseNamespace(r)
+	if err != nil {
+		return nil, nil, nil, err
 	}
 	if ns != "" {
 		args = append(args, "--namespace", ns)
@@ -246,22 +437,31 @@ func (h *HelmDeployer) deployRelease(ctx context.Context, out io.Writer, r lates
 	if len(r.Overrides.Values) != 0 {
 		overrides, err := yaml.Marshal(r.Overrides)
 		if err != nil {
-			return nil, errors.Wrap(err, "cannot marshal overrides to create overrides values.yaml")
+			return nil, nil, nil, errors.Wrap(err, "cannot marshal overrides to create overrides values.yaml")
 		}
 		overridesFile, err := os.Create(constants.HelmOverridesFilename)
 		if err != nil {
-			return nil, errors.Wrapf(err, "cannot create file %s", constants.HelmOverridesFilename)
+			return nil, nil, nil, errors.Wrapf(err, "cannot create file %s", constants.HelmOverridesFilename)
 		}
-		defer func() {
+		cleanups = append(cleanups, func() {
 			overridesFile.Close()
 			os.Remove(constants.HelmOverridesFilename)
-		}()
+		})
 		if _, err := overridesFile.WriteString(string(overrides)); err != nil {
-			return nil, errors.Wrapf(err, "failed to write file %s", constants.HelmOverridesFilename)
+			return nil, nil, nil, errors.Wrapf(err, "failed to write file %s", constants.HelmOverridesFilename)
 		}
 		args = append(args, "-f", constants.HelmOverridesFilename)
 	}
 	for _, valuesFile := range r.ValuesFiles {
+		// helm-secrets decrypts the values files itself.
+		if !r.UseHelmSecrets && sops.IsEncrypted(valuesFile) {
+			decrypted, remove, err := sops.DecryptToTempFile(ctx, valuesFile)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			cleanups = append(cleanups, remove)
+			valuesFile = decrypted
+		}
 		args = append(args, "-f", valuesFile)
 	}
 
@@ -285,11 +485,11 @@ func (h *HelmDeployer) deployRelease(ctx context.Context, out io.Writer, r lates
 		for k, v := range r.SetValueTemplates {
 			t, err := util.ParseEnvTemplate(v)
 			if err != nil {
-				return nil, errors.Wrapf(err, "failed to parse setValueTemplates")
+				return nil, nil, nil, errors.Wrapf(err, "failed to parse setValueTemplates")
 			}
 			result, err := util.ExecuteEnvTemplate(t, envMap)
 			if err != nil {
-				return nil, errors.Wrapf(err, "failed to generate setValueTemplates")
+				return nil, nil, nil, errors.Wrapf(err, "failed to generate setValueTemplates")
 			}
 			setValues[k] = result
 		}
@@ -298,13 +498,8 @@ func (h *HelmDeployer) deployRelease(ctx context.Context, out io.Writer, r lates
 		setOpts = append(setOpts, "--set")
 		setOpts = append(setOpts, fmt.Sprintf("%s=%s", k, v))
 	}
-	if r.Wait {
-		args = append(args, "--wait")
-	}
-	args = append(args, setOpts...)
 
-	helmErr := h.helm(ctx, out, r.UseHelmSecrets, args...)
-	return h.getDeployResults(ctx, ns, releaseName), helmErr
+	return args, setOpts, cleanupAll, nil
 }
 
 func createEnvVarMap(imageName string, digest string) map[string]string {
@@ -358,7 +553,7 @@ func (h *HelmDeployer) packageChart(ctx context.Context, r latest.HelmRelease) (
 	}
 
 	buf := &bytes.Buffer{}
-	err := h.helm(ctx, buf, false, packageArgs...)
+	err := h.helm(ctx, buf, h.kubeContext, false, packageArgs...)
 	output := strings.TrimSpace(buf.String())
 	if err != nil {
 		return "", errors.Wrapf(err, "package chart into a .tgz archive (%s)", output)
@@ -374,7 +569,7 @@ func (h *HelmDeployer) packageChart(ctx context.Context, r latest.HelmRelease) (
 
 func (h *HelmDeployer) getReleaseInfo(ctx context.Context, release string) (*bufio.Reader, error) {
 	var releaseInfo bytes.Buffer
-	if err := h.helm(ctx, &releaseInfo, false, "get", release); err != nil {
+	if err := h.helm(ctx, &releaseInfo, h.kubeContext, false, "get", release); err != nil {
 		return nil, fmt.Errorf("error retrieving helm deployment info: %s", releaseInfo.String())
 	}
 	return bufio.NewReader(&releaseInfo), nil
@@ -397,8 +592,12 @@ func (h *HelmDeployer) deleteRelease(ctx context.Context, out io.Writer, r lates
 	if err != nil {
 		return errors.Wrap(err, "cannot parse the release name template")
 	}
+	kubeContext, err := h.releaseKubeContext(r)
+	if err != nil {
+		return err
+	}
 
-	if err := h.helm(ctx, out, false, "delete", releaseName, "--purge"); err != nil {
+	if err := h.helm(ctx, out, kubeContext, false, "delete", releaseName, "--purge"); err != nil {
 		logrus.Debugf("deleting release %s: %v\n", releaseName, err)
 	}
 
@@ -413,7 +612,7 @@ func (h *HelmDeployer) joinTagsToBuildResult(builds []build.Artifact, params map
 
 	paramToBuildResult := map[string]build.Artifact{}
 	for param, imageName := range params {
-		newImageName := util.SubstituteDefaultRepoIntoImage(h.defaultRepo, imageName)
+		newImageName := h.defaultRepo.Substitute(imageName)
 		b, ok := imageToBuildResult[newImageName]
 		if !ok {
 			if len(builds) == 0 {
@@ -428,15 +627,48 @@ func (h *HelmDeployer) joinTagsToBuildResult(builds []build.Artifact, params map
 	return paramToBuildResult, nil
 }
 
+// evaluateReleaseName executes the release name template. Missing values are
+// an error, so that releases of different developers don't all end up with
+// the same `<no value>` name.
 func evaluateReleaseName(nameTemplate string) (string, error) {
 	tmpl, err := util.ParseEnvTemplate(nameTemplate)
 	if err != nil {
 		return "", errors.Wrap(err, "parsing template")
 	}
 
+	tmpl.Option("missingkey=error")
 	return util.ExecuteEnvTemplate(tmpl, nil)
 }
 
+// checkReleaseNames fails if a release name isn't a valid Helm release name,
+// or if two releases resolve to the same name in the same context: one would
+// overwrite the other.
+func (h *HelmDeployer) checkReleaseNames() error {
+	seen := map[string]string{}
+	for _, r := range h.Releases {
+		releaseName, err := evaluateReleaseName(r.Name)
+		if err != nil {
+			return errors.Wrapf(err, "cannot parse the release name template %q", r.Name)
+		}
+		if len(releaseName) > maxReleaseNameLength || len(validation.IsDNS1123Subdomain(releaseName)) > 0 {
+			return fmt.Errorf("invalid release name %q from %q: release names must consist of lowercase alphanumeric characters, '-' or '.', start and end with an alphanumeric character and be at most %d characters long", releaseName, r.Name, maxReleaseNameLength)
+		}
+
+		kubeContext, err := h.releaseKubeContext(r)
+		if err != nil {
+			return err
+		}
+
+		key := kubeContext + "/" + releaseName
+		if other, found := seen[key]; found {
+			return fmt.Errorf("releases %q and %q both resolve to the name %q", other, r.Name, releaseName)
+		}
+		seen[key] = r.Name
+	}
+
+	return nil
+}
+
 // concretize parses and executes template s with OS environment variables.
 // If s is not a template but a simple string, returns unchanged s.
 func concretize(s string) (string, error) {
@@ -458,6 +458,7 @@ func TestHelmDeploy(t *testing.T) {
 		t.Run(tt.description, func(t *testing.T) {
 			reset := testutil.Override(t, &util.DefaultExecCommand, tt.cmd)
 			defer reset()
+			defer testutil.SetEnvs(t, map[string]string{"USER": "jdoe"})()
 
 			event.InitializeState(tt.runContext)
 			err := NewHelmDeployer(tt.runContext).Deploy(context.Background(), ioutil.Discard, tt.builds, nil)
@@ -467,6 +468,143 @@ func TestHelmDeploy(t *testing.T) {
 	}
 }
 
+func TestHelmDeployReleaseContexts(t *testing.T) {
+	defer testutil.SetEnvs(t, map[string]string{"USER": "jdoe", "INFRA_CONTEXT": "shared"})()
+	defer testutil.Override(t, &util.DefaultExecCommand, testutil.
+		FakeRunErr(t, "helm --kube-context shared get infra", fmt.Errorf("not found")).
+		WithRun("helm --kube-context shared install --name infra charts/infra --namespace infra").
+		WithRun("helm --kube-context kubecontext get app").
+		WithRun("helm --kube-context kubecontext upgrade app charts/app --namespace jdoe-dev").
+		WithRun("helm --kube-context kubecontext get app"))()
+
+	runCtx := makeRunContext(&latest.HelmDeploy{
+		Releases: []latest.HelmRelease{
+			{
+				Name:                  "infra",
+				ChartPath:             "charts/infra",
+				Namespace:             "infra",
+				KubeContext:           "{{.INFRA_CONTEXT}}",
+				SkipBuildDependencies: true,
+			},
+			{
+				Name:                  "app",
+				ChartPath:             "charts/app",
+				Namespace:             "{{.USER}}-dev",
+				SkipBuildDependencies: true,
+			},
+		},
+	}, false)
+	runCtx.Opts.Namespace = ""
+
+	event.InitializeState(runCtx)
+	err := NewHelmDeployer(runCtx).Deploy(context.Background(), ioutil.Discard, nil, nil)
+
+	testutil.CheckError(t, false, err)
+}
+
+func TestHelmCheckReleaseNames(t *testing.T) {
+	tests := []struct {
+		description string
+		releases    []latest.HelmRelease
+		shouldErr   bool
+	}{
+		{
+			description: "templated names",
+			releases:    []latest.HelmRelease{{Name: "myapp-{{.USER}}"}, {Name: "db-{{.USER}}"}},
+		},
+		{
+			description: "same name in different contexts",
+			releases:    []latest.HelmRelease{{Name: "myapp"}, {Name: "myapp", KubeContext: "other"}},
+		},
+		{
+			description: "collision",
+			releases:    []latest.HelmRelease{{Name: "myapp-{{.USER}}"}, {Name: "myapp-jdoe"}},
+			shouldErr:   true,
+		},
+		{
+			description: "missing value",
+			releases:    []latest.HelmRelease{{Name: "myapp-{{.UNKNOWN_VALUE}}"}},
+			shouldErr:   true,
+		},
+		{
+			description: "invalid characters",
+			releases:    []latest.HelmRelease{{Name: "MyApp_{{.USER}}"}},
+			shouldErr:   true,
+		},
+		{
+			description: "too long",
+			releases:    []latest.HelmRelease{{Name: strings.Repeat("a", 54)}},
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			defer testutil.SetEnvs(t, map[string]string{"USER": "jdoe"})()
+
+			deployer := NewHelmDeployer(makeRunContext(&latest.HelmDeploy{Releases: test.releases}, false))
+			err := deployer.checkReleaseNames()
+
+			testutil.CheckError(t, test.shouldErr, err)
+		})
+	}
+}
+
+func TestHelmRender(t *testing.T) {
+	reset := testutil.Override(t, &util.DefaultExecCommand, testutil.
+		FakeRun(t, "helm --kube-context kubecontext dep build examples/test").
+		WithRunOut("helm --kube-context kubecontext template --name skaffold-helm examples/test --namespace testNamespace -f skaffold-overrides.yaml --set image=docker.io:5000/skaffold-helm:3605e7bc17cf46e53f4d81c4cbc24e5b4c495184 --set some.key=somevalue", `---
+# Source: test/templates/pod.yaml
+apiVersion: v1
+kind: Pod
+metadata:
+  name: skaffold-helm
+spec:
+  containers:
+  - image: docker.io:5000/skaffold-helm:3605e7bc17cf46e53f4d81c4cbc24e5b4c495184
+    name: skaffold-helm
+`))
+	defer reset()
+
+	var out bytes.Buffer
+	err := NewHelmDeployer(makeRunContext(testDeployConfig, false)).Render(context.Background(), &out, testBuilds, nil)
+
+	testutil.CheckErrorAndDeepEqual(t, false, err, `apiVersion: v1
+kind: Pod
+metadata:
+  name: skaffold-helm
+spec:
+  containers:
+  - image: docker.io:5000/skaffold-helm:3605e7bc17cf46e53f4d81c4cbc24e5b4c495184
+    name: skaffold-helm
+`, out.String())
+}
+
+func TestHelmRenderOffline(t *testing.T) {
+	reset := testutil.Override(t, &util.DefaultExecCommand, testutil.
+		FakeRunOut(t, "helm --kube-context kubecontext template --name skaffold-helm examples/test --namespace testNamespace -f skaffold-overrides.yaml --set image=docker.io:5000/skaffold-helm:3605e7bc17cf46e53f4d81c4cbc24e5b4c495184 --set some.key=somevalue", ""))
+	defer reset()
+
+	runCtx := makeRunContext(testDeployConfig, false)
+	runCtx.Opts.Offline = true
+	err := NewHelmDeployer(runCtx).Render(context.Background(), ioutil.Discard, testBuilds, nil)
+
+	testutil.CheckError(t, false, err)
+}
+
+func TestHelmRenderRemoteChart(t *testing.T) {
+	remoteChart := &latest.HelmDeploy{
+		Releases: []latest.HelmRelease{{
+			Name:      "skaffold-helm",
+			ChartPath: "stable/chartmuseum",
+			Remote:    true,
+		}},
+	}
+
+	err := NewHelmDeployer(makeRunContext(remoteChart, false)).Render(context.Background(), ioutil.Discard, testBuilds, nil)
+
+	testutil.CheckError(t, true, err)
+}
+
 type CommandMatcher func(*exec.Cmd) bool
 
 type MockHelm struct {
@@ -0,0 +1,117 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"fmt"
+
+	pkgkubernetes "github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// knativeServiceKind is the kind used to check Knative Services, which
+// shouldn't be confused with Kubernetes Services.
+const knativeServiceKind = "ksvc"
+
+var knativeServices = schema.GroupVersionResource{Group: "serving.knative.dev", Version: "v1", Resource: "services"}
+
+// getKnativeServices lists the Knative Services matching the given selector.
+// Clusters without Knative don't have any.
+func getKnativeServices(namespaces []string, opts metav1.ListOptions) ([]statusCheckResource, error) {
+	client, err := pkgkubernetes.DynamicClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting kubernetes dynamic client")
+	}
+
+	var resources []statusCheckResource
+	for _, ns := range namespaces {
+		list, err := client.Resource(knativeServices).Namespace(ns).List(opts)
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "listing knative services")
+		}
+
+		for _, s := range list.Items {
+			resources = append(resources, statusCheckResource{kind: knativeServiceKind, name: s.GetName(), namespace: s.GetNamespace()})
+		}
+	}
+
+	return resources, nil
+}
+
+func getKnativeServiceStatus(r statusCheckResource) (string, error) {
+	client, err := pkgkubernetes.DynamicClient()
+	if err != nil {
+		return "", errors.Wrap(err, "getting kubernetes dynamic client")
+	}
+
+	service, err := client.Resource(knativeServices).Namespace(r.namespace).Get(r.name, metav1.GetOptions{})
+	if err != nil {
+		return "", errors.Wrap(err, "getting knative service")
+	}
+
+	return knativeServiceStatus(service)
+}
+
+// knativeServiceStatus mimics the output of `kubectl rollout status` for a Knative Service.
+// A service is rolled out once its latest revision is ready and routed.
+// A service that is not ready is an error, so that the status check can fail fast.
+func knativeServiceStatus(service *unstructured.Unstructured) (string, error) {
+	observed, _, _ := unstructured.NestedInt64(service.Object, "status", "observedGeneration")
+	if service.GetGeneration() > observed {
+		return "Waiting for knative service spec update to be observed...", nil
+	}
+
+	created, _, _ := unstructured.NestedString(service.Object, "status", "latestCreatedRevisionName")
+	ready, _, _ := unstructured.NestedString(service.Object, "status", "latestReadyRevisionName")
+
+	status, reason, message := knativeReadyCondition(service)
+	switch {
+	case status == "False":
+		return "", fmt.Errorf("knative service %q is not ready: %s %s", service.GetName(), reason, message)
+	case created == "" || created != ready:
+		return fmt.Sprintf("Waiting for revision %q of knative service %q to be ready...", created, service.GetName()), nil
+	case status != "True":
+		return fmt.Sprintf("Waiting for knative service %q to route traffic to revision %q...", service.GetName(), ready), nil
+	}
+
+	return fmt.Sprintf("knative service %q successfully rolled out", service.GetName()), nil
+}
+
+// knativeReadyCondition returns the status, reason and message of the `Ready` condition.
+func knativeReadyCondition(service *unstructured.Unstructured) (string, string, string) {
+	conditions, _, _ := unstructured.NestedSlice(service.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != "Ready" {
+			continue
+		}
+
+		status, _ := condition["status"].(string)
+		reason, _ := condition["reason"].(string)
+		message, _ := condition["message"].(string)
+		return status, reason, message
+	}
+
+	return "Unknown", "", ""
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestKnativeServiceStatus(t *testing.T) {
+	ksvc := func(generation int64, status map[string]interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "serving.knative.dev/v1",
+			"kind":       "Service",
+			"metadata":   map[string]interface{}{"name": "hello", "generation": generation},
+			"status":     status,
+		}}
+	}
+	ready := func(status string) []interface{} {
+		return []interface{}{
+			map[string]interface{}{"type": "ConfigurationsReady", "status": "True"},
+			map[string]interface{}{"type": "Ready", "status": status, "reason": "RevisionMissing", "message": "Revision \"hello-00002\" failed."},
+		}
+	}
+
+	tests := []struct {
+		description string
+		service     *unstructured.Unstructured
+		expected    string
+		shouldErr   bool
+	}{
+		{
+			description: "spec update not observed",
+			service:     ksvc(2, map[string]interface{}{"observedGeneration": int64(1)}),
+			expected:    "Waiting for knative service spec update to be observed...",
+		},
+		{
+			description: "new revision not ready",
+			service: ksvc(2, map[string]interface{}{
+				"observedGeneration":        int64(2),
+				"latestCreatedRevisionName": "hello-00002",
+				"latestReadyRevisionName":   "hello-00001",
+				"conditions":                ready("Unknown"),
+			}),
+			expected: `Waiting for revision "hello-00002" of knative service "hello" to be ready...`,
+		},
+		{
+			description: "revision ready but not routed",
+			service: ksvc(2, map[string]interface{}{
+				"observedGeneration":        int64(2),
+				"latestCreatedRevisionName": "hello-00002",
+				"latestReadyRevisionName":   "hello-00002",
+				"conditions":                ready("Unknown"),
+			}),
+			expected: `Waiting for knative service "hello" to route traffic to revision "hello-00002"...`,
+		},
+		{
+			description: "rolled out",
+			service: ksvc(2, map[string]interface{}{
+				"observedGeneration":        int64(2),
+				"latestCreatedRevisionName": "hello-00002",
+				"latestReadyRevisionName":   "hello-00002",
+				"conditions":                ready("True"),
+			}),
+			expected: `knative service "hello" successfully rolled out`,
+		},
+		{
+			description: "failed",
+			service: ksvc(2, map[string]interface{}{
+				"observedGeneration":        int64(2),
+				"latestCreatedRevisionName": "hello-00002",
+				"latestReadyRevisionName":   "hello-00001",
+				"conditions":                ready("False"),
+			}),
+			shouldErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			status, err := knativeServiceStatus(test.service)
+
+			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.expected, status)
+		})
+	}
+}
@@ -19,6 +19,7 @@ package deploy
 import (
 	"context"
 	"io"
+	"io/ioutil"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
@@ -27,6 +28,7 @@ import (
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
 	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/sops"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -38,8 +40,13 @@ type KubectlDeployer struct {
 
 	workingDir         string
 	kubectl            kubectl.CLI
-	defaultRepo        string
+	defaultRepo        util.DefaultRepoSubstitution
 	insecureRegistries map[string]bool
+	imagesOS           map[string]string
+	policy             *latest.PolicyConfig
+	transforms         []latest.ManifestTransform
+	offline            bool
+	cleanup            cleanupOptions
 }
 
 // NewKubectlDeployer returns a new KubectlDeployer for a DeployConfig filled
@@ -53,9 +60,15 @@ func NewKubectlDeployer(runCtx *runcontext.RunContext) *KubectlDeployer {
 			KubeContext: runCtx.KubeContext,
 			Flags:       runCtx.Cfg.Deploy.KubectlDeploy.Flags,
 			ForceDeploy: runCtx.Opts.ForceDeploy(),
+			Concurrency: runCtx.Cfg.Deploy.Concurrency,
 		},
-		defaultRepo:        runCtx.DefaultRepo,
+		defaultRepo:        runCtx.DefaultRepoSubstitution(),
 		insecureRegistries: runCtx.InsecureRegistries,
+		imagesOS:           imagesOS(runCtx.Cfg.Build.Artifacts),
+		policy:             runCtx.Cfg.Deploy.Policy,
+		transforms:         runCtx.Cfg.Deploy.Transforms,
+		offline:            runCtx.Opts.Offline,
+		cleanup:            newCleanupOptions(runCtx),
 	}
 }
 
@@ -85,43 +98,70 @@ func (k *KubectlDeployer) Deploy(ctx context.Context, out io.Writer, builds []bu
 
 	event.DeployInProgress()
 
-	manifests, err := k.readManifests(ctx)
+	manifests, err := k.renderManifests(ctx, builds, labellers)
 	if err != nil {
 		event.DeployFailed(err)
-		return errors.Wrap(err, "reading manifests")
+		return err
 	}
 
 	if len(manifests) == 0 {
 		return nil
 	}
 
-	manifests, err = manifests.ReplaceImages(builds, k.defaultRepo)
-	if err != nil {
+	if err := checkPolicies(ctx, out, k.policy, k.workingDir, manifests); err != nil {
 		event.DeployFailed(err)
-		return errors.Wrap(err, "replacing images in manifests")
+		return errors.Wrap(err, "checking policies")
 	}
 
-	manifests, err = manifests.SetLabels(merge(labellers...))
+	err = k.kubectl.Apply(ctx, out, manifests)
 	if err != nil {
 		event.DeployFailed(err)
-		return errors.Wrap(err, "setting labels in manifests")
+		return errors.Wrap(err, "kubectl error")
 	}
 
-	for _, transform := range manifestTransforms {
-		manifests, err = transform(manifests, builds, k.insecureRegistries)
-		if err != nil {
-			return errors.Wrap(err, "unable to transform manifests")
-		}
+	event.DeployComplete()
+	return err
+}
+
+// Render writes the manifests that Deploy would apply.
+func (k *KubectlDeployer) Render(ctx context.Context, out io.Writer, builds []build.Artifact, labellers []Labeller) error {
+	manifests, err := k.renderManifests(ctx, builds, labellers)
+	if err != nil {
+		return err
 	}
 
-	err = k.kubectl.Apply(ctx, out, manifests)
+	return writeManifests(out, manifests)
+}
+
+// renderManifests reads the manifests and replaces the images with the build results.
+func (k *KubectlDeployer) renderManifests(ctx context.Context, builds []build.Artifact, labellers []Labeller) (kubectl.ManifestList, error) {
+	manifests, err := k.readManifests(ctx)
 	if err != nil {
-		event.DeployFailed(err)
-		return errors.Wrap(err, "kubectl error")
+		return nil, errors.Wrap(err, "reading manifests")
 	}
 
-	event.DeployComplete()
-	return err
+	if len(manifests) == 0 {
+		return nil, nil
+	}
+
+	manifests, err = transformManifests(manifests, builds, labellers, k.defaultRepo, k.insecureRegistries, k.imagesOS)
+	if err != nil {
+		return nil, err
+	}
+
+	return applyTransforms(ctx, k.transforms, k.workingDir, manifests)
+}
+
+// KubectlFlags returns the `kubectl` flags of the kubectl or kustomize deployer.
+func KubectlFlags(cfg latest.DeployConfig) latest.KubectlFlags {
+	switch {
+	case cfg.KubectlDeploy != nil:
+		return cfg.KubectlDeploy.Flags
+	case cfg.KustomizeDeploy != nil:
+		return cfg.KustomizeDeploy.Flags
+	default:
+		return latest.KubectlFlags{}
+	}
 }
 
 // Cleanup deletes what was deployed by calling Deploy.
@@ -131,7 +171,7 @@ func (k *KubectlDeployer) Cleanup(ctx context.Context, out io.Writer) error {
 		return errors.Wrap(err, "reading manifests")
 	}
 
-	if err := k.kubectl.Delete(ctx, out, manifests); err != nil {
+	if err := k.cleanup.deleteManifests(ctx, out, &k.kubectl, manifests); err != nil {
 		return errors.Wrap(err, "delete")
 	}
 
@@ -174,5 +214,41 @@ func (k *KubectlDeployer) readManifests(ctx context.Context) (kubectl.ManifestLi
 		return kubectl.ManifestList{}, nil
 	}
 
-	return k.kubectl.ReadManifests(ctx, manifests)
+	// Manifests encrypted with SOPS can't be read by kubectl.
+	var plain, encrypted []string
+	for _, manifest := range manifests {
+		if sops.IsEncrypted(manifest) {
+			encrypted = append(encrypted, manifest)
+		} else {
+			plain = append(plain, manifest)
+		}
+	}
+
+	manifestList := kubectl.ManifestList{}
+	switch {
+	case len(plain) == 0:
+	case k.offline:
+		// `kubectl create --dry-run` might need to access the cluster.
+		for _, manifest := range plain {
+			buf, err := ioutil.ReadFile(manifest)
+			if err != nil {
+				return nil, errors.Wrapf(err, "reading %s", manifest)
+			}
+			manifestList.Append(buf)
+		}
+	default:
+		if manifestList, err = k.kubectl.ReadManifests(ctx, plain); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, manifest := range encrypted {
+		decrypted, err := sops.Decrypt(ctx, manifest)
+		if err != nil {
+			return nil, err
+		}
+		manifestList.Append(decrypted)
+	}
+
+	return manifestList, nil
 }
@@ -17,9 +17,10 @@ limitations under the License.
 package kubectl
 
 import (
+	"bytes"
 	"context"
 	"io"
-	"os/exec"
+	"strings"
 	"sync"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
@@ -37,6 +38,7 @@ type CLI struct {
 	version       ClientVersion
 	versionOnce   sync.Once
 	ForceDeploy   bool
+	Concurrency   int
 	previousApply ManifestList
 }
 
@@ -49,6 +51,15 @@ func (c *CLI) Delete(ctx context.Context, out io.Writer, manifests ManifestList)
 	return nil
 }
 
+// DeleteSelected runs `kubectl delete` on the resources that match a label selector.
+func (c *CLI) DeleteSelected(ctx context.Context, out io.Writer, resources []string, selector string) error {
+	if err := c.Run(ctx, nil, out, "delete", c.Flags.Delete, "--ignore-not-found=true", "-l", selector, strings.Join(resources, ",")); err != nil {
+		return errors.Wrap(err, "kubectl delete")
+	}
+
+	return nil
+}
+
 // Apply runs `kubectl apply` on a list of manifests.
 func (c *CLI) Apply(ctx context.Context, out io.Writer, manifests ManifestList) error {
 	// Only redeploy modified or new manifests
@@ -60,18 +71,68 @@ func (c *CLI) Apply(ctx context.Context, out io.Writer, manifests ManifestList)
 		return nil
 	}
 
+	if c.Concurrency > 1 {
+		return c.applyConcurrently(ctx, out, updated)
+	}
+
+	return c.apply(ctx, out, updated)
+}
+
+func (c *CLI) apply(ctx context.Context, out io.Writer, manifests ManifestList) error {
 	args := []string{"-f", "-"}
 	if c.ForceDeploy {
 		args = append(args, "--force")
 	}
 
-	if err := c.Run(ctx, updated.Reader(), out, "apply", c.Flags.Apply, args...); err != nil {
+	if err := c.Run(ctx, manifests.Reader(), out, "apply", c.Flags.Apply, args...); err != nil {
 		return errors.Wrap(err, "kubectl apply")
 	}
 
 	return nil
 }
 
+// applyConcurrently applies namespaces and custom resource definitions first,
+// then applies each of the other manifests separately, c.Concurrency at a time.
+func (c *CLI) applyConcurrently(ctx context.Context, out io.Writer, manifests ManifestList) error {
+	priority, others := manifests.SplitPriority()
+	if len(priority) > 0 {
+		if err := c.apply(ctx, out, priority); err != nil {
+			return err
+		}
+	}
+
+	var (
+		wg       sync.WaitGroup
+		outLock  sync.Mutex
+		errs     = make([]error, len(others))
+		throttle = make(chan struct{}, c.Concurrency)
+	)
+	for i, manifest := range others {
+		wg.Add(1)
+		go func(i int, manifest []byte) {
+			defer wg.Done()
+			throttle <- struct{}{}
+			defer func() { <-throttle }()
+
+			// Buffer the output so that concurrent applies don't interleave.
+			var buf bytes.Buffer
+			errs[i] = c.apply(ctx, &buf, ManifestList{manifest})
+
+			outLock.Lock()
+			defer outLock.Unlock()
+			io.Copy(out, &buf)
+		}(i, manifest)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // ReadManifests reads a list of manifests in yaml format.
 func (c *CLI) ReadManifests(ctx context.Context, manifests []string) (ManifestList, error) {
 	var list []string
@@ -81,8 +142,8 @@ func (c *CLI) ReadManifests(ctx context.Context, manifests []string) (ManifestLi
 
 	args := c.args("create", []string{"--dry-run", "-oyaml"}, list...)
 
-	cmd := exec.CommandContext(ctx, "kubectl", args...)
-	buf, err := util.RunCmdOut(cmd)
+	cmd := util.CommandContext(ctx, util.ToolPath("kubectl"), args...)
+	buf, err := cmd.RunOut()
 	if err != nil {
 		return nil, errors.Wrap(err, "kubectl create")
 	}
@@ -98,12 +159,20 @@ func (c *CLI) ReadManifests(ctx context.Context, manifests []string) (ManifestLi
 func (c *CLI) Run(ctx context.Context, in io.Reader, out io.Writer, command string, commandFlags []string, arg ...string) error {
 	args := c.args(command, commandFlags, arg...)
 
-	cmd := exec.CommandContext(ctx, "kubectl", args...)
+	cmd := util.CommandContext(ctx, util.ToolPath("kubectl"), args...)
 	cmd.Stdin = in
 	cmd.Stdout = out
 	cmd.Stderr = out
 
-	return util.RunCmd(cmd)
+	return cmd.Run()
+}
+
+// RunOut shells out kubectl CLI and returns its output.
+func (c *CLI) RunOut(ctx context.Context, command string, commandFlags []string, arg ...string) ([]byte, error) {
+	args := c.args(command, commandFlags, arg...)
+
+	cmd := util.CommandContext(ctx, util.ToolPath("kubectl"), args...)
+	return cmd.RunOut()
 }
 
 func (c *CLI) args(command string, commandFlags []string, arg ...string) []string {
@@ -27,7 +27,7 @@ import (
 )
 
 // ReplaceImages replaces image names in a list of manifests.
-func (l *ManifestList) ReplaceImages(builds []build.Artifact, defaultRepo string) (ManifestList, error) {
+func (l *ManifestList) ReplaceImages(builds []build.Artifact, defaultRepo util.DefaultRepoSubstitution) (ManifestList, error) {
 	replacer := newImageReplacer(builds, defaultRepo)
 
 	updated, err := l.Visit(replacer)
@@ -42,12 +42,12 @@ func (l *ManifestList) ReplaceImages(builds []build.Artifact, defaultRepo string
 }
 
 type imageReplacer struct {
-	defaultRepo     string
+	defaultRepo     util.DefaultRepoSubstitution
 	tagsByImageName map[string]string
 	found           map[string]bool
 }
 
-func newImageReplacer(builds []build.Artifact, defaultRepo string) *imageReplacer {
+func newImageReplacer(builds []build.Artifact, defaultRepo util.DefaultRepoSubstitution) *imageReplacer {
 	tagsByImageName := make(map[string]string)
 	for _, build := range builds {
 		tagsByImageName[build.ImageName] = build.Tag
@@ -111,5 +111,5 @@ func (r *imageReplacer) Check() {
 }
 
 func (r *imageReplacer) substituteRepoIntoImage(originalImage string) string {
-	return util.SubstituteDefaultRepoIntoImage(r.defaultRepo, originalImage)
+	return r.defaultRepo.Substitute(originalImage)
 }
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/warnings"
 	"github.com/GoogleContainerTools/skaffold/testutil"
 )
@@ -90,7 +91,7 @@ spec:
 	reset := testutil.Override(t, &warnings.Printf, fakeWarner.Warnf)
 	defer reset()
 
-	resultManifest, err := manifests.ReplaceImages(builds, "")
+	resultManifest, err := manifests.ReplaceImages(builds, util.DefaultRepoSubstitution{})
 
 	testutil.CheckErrorAndDeepEqual(t, false, err, expected.String(), resultManifest.String())
 	testutil.CheckErrorAndDeepEqual(t, false, err, []string{
@@ -100,11 +101,46 @@ spec:
 	}, fakeWarner.Warnings)
 }
 
+func TestReplaceImagesInKnativeService(t *testing.T) {
+	manifests := ManifestList{[]byte(`
+apiVersion: serving.knative.dev/v1
+kind: Service
+metadata:
+  name: hello
+spec:
+  template:
+    spec:
+      containers:
+      - image: gcr.io/k8s-skaffold/hello
+`)}
+
+	builds := []build.Artifact{{
+		ImageName: "gcr.io/k8s-skaffold/hello",
+		Tag:       "gcr.io/k8s-skaffold/hello:TAG@sha256:81daf011d63b68cfa514ddab7741a1adddd59d3264118dfb0fd9266328bb8883",
+	}}
+
+	expected := ManifestList{[]byte(`
+apiVersion: serving.knative.dev/v1
+kind: Service
+metadata:
+  name: hello
+spec:
+  template:
+    spec:
+      containers:
+      - image: gcr.io/k8s-skaffold/hello:TAG@sha256:81daf011d63b68cfa514ddab7741a1adddd59d3264118dfb0fd9266328bb8883
+`)}
+
+	resultManifest, err := manifests.ReplaceImages(builds, util.DefaultRepoSubstitution{})
+
+	testutil.CheckErrorAndDeepEqual(t, false, err, expected.String(), resultManifest.String())
+}
+
 func TestReplaceEmptyManifest(t *testing.T) {
 	manifests := ManifestList{[]byte(""), []byte("  ")}
 	expected := ManifestList{}
 
-	resultManifest, err := manifests.ReplaceImages(nil, "")
+	resultManifest, err := manifests.ReplaceImages(nil, util.DefaultRepoSubstitution{})
 
 	testutil.CheckErrorAndDeepEqual(t, false, err, expected.String(), resultManifest.String())
 }
@@ -112,7 +148,7 @@ func TestReplaceEmptyManifest(t *testing.T) {
 func TestReplaceInvalidManifest(t *testing.T) {
 	manifests := ManifestList{[]byte("INVALID")}
 
-	_, err := manifests.ReplaceImages(nil, "")
+	_, err := manifests.ReplaceImages(nil, util.DefaultRepoSubstitution{})
 
 	testutil.CheckError(t, true, err)
 }
@@ -125,7 +161,7 @@ image:
 - value2
 `)}
 
-	output, err := manifests.ReplaceImages(nil, "")
+	output, err := manifests.ReplaceImages(nil, util.DefaultRepoSubstitution{})
 
 	testutil.CheckErrorAndDeepEqual(t, false, err, manifests.String(), output.String())
 }
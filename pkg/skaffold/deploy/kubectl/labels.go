@@ -23,7 +23,7 @@ import (
 
 // SetLabels add labels to a list of Kubernetes manifests.
 func (l *ManifestList) SetLabels(labels map[string]string) (ManifestList, error) {
-	replacer := newLabelsSetter(labels)
+	replacer := newMetadataSetter("labels", labels)
 
 	updated, err := l.Visit(replacer)
 	if err != nil {
@@ -35,22 +35,39 @@ func (l *ManifestList) SetLabels(labels map[string]string) (ManifestList, error)
 	return updated, nil
 }
 
-type labelsSetter struct {
-	labels map[string]string
+// SetAnnotations add annotations to a list of Kubernetes manifests.
+func (l *ManifestList) SetAnnotations(annotations map[string]string) (ManifestList, error) {
+	if len(annotations) == 0 {
+		return *l, nil
+	}
+
+	updated, err := l.Visit(newMetadataSetter("annotations", annotations))
+	if err != nil {
+		return nil, errors.Wrap(err, "setting annotations")
+	}
+
+	return updated, nil
+}
+
+// metadataSetter sets key/values in a field of the metadata: labels or annotations.
+type metadataSetter struct {
+	field  string
+	values map[string]string
 }
 
-func newLabelsSetter(labels map[string]string) *labelsSetter {
-	return &labelsSetter{
-		labels: labels,
+func newMetadataSetter(field string, values map[string]string) *metadataSetter {
+	return &metadataSetter{
+		field:  field,
+		values: values,
 	}
 }
 
-func (r *labelsSetter) Matches(key string) bool {
+func (r *metadataSetter) Matches(key string) bool {
 	return key == "metadata"
 }
 
-func (r *labelsSetter) NewValue(old interface{}) (bool, interface{}) {
-	if len(r.labels) == 0 {
+func (r *metadataSetter) NewValue(old interface{}) (bool, interface{}) {
+	if len(r.values) == 0 {
 		return false, nil
 	}
 
@@ -59,19 +76,19 @@ func (r *labelsSetter) NewValue(old interface{}) (bool, interface{}) {
 		return false, nil
 	}
 
-	l, present := metadata["labels"]
+	l, present := metadata[r.field]
 	if !present {
-		metadata["labels"] = r.labels
+		metadata[r.field] = r.values
 		return true, metadata
 	}
 
-	labels, ok := l.(map[interface{}]interface{})
+	values, ok := l.(map[interface{}]interface{})
 	if !ok {
 		return false, nil
 	}
 
-	for k, v := range r.labels {
-		labels[k] = v
+	for k, v := range r.values {
+		values[k] = v
 	}
 
 	return true, metadata
@@ -18,11 +18,24 @@ package kubectl
 
 import (
 	"bytes"
+	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
+
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
 )
 
+// Kinds of resources that others may depend on, and that should be applied first.
+var priorityKinds = map[string]bool{
+	"Namespace":                true,
+	"CustomResourceDefinition": true,
+}
+
 // ManifestList is a list of yaml manifests.
 type ManifestList [][]byte
 
@@ -77,3 +90,86 @@ func (l *ManifestList) Diff(latest ManifestList) ManifestList {
 func (l *ManifestList) Reader() io.Reader {
 	return strings.NewReader(l.String())
 }
+
+// SplitPriority separates the manifests of resources that others may depend on,
+// such as namespaces and custom resource definitions, from the other manifests.
+func (l *ManifestList) SplitPriority() (ManifestList, ManifestList) {
+	var priority, others ManifestList
+
+	for _, manifest := range *l {
+		var m struct {
+			Kind string `yaml:"kind"`
+		}
+		if err := yaml.Unmarshal(manifest, &m); err == nil && priorityKinds[m.Kind] {
+			priority = append(priority, manifest)
+		} else {
+			others = append(others, manifest)
+		}
+	}
+
+	return priority, others
+}
+
+// ExcludeKinds removes the manifests of resources of the given kinds.
+func (l *ManifestList) ExcludeKinds(kinds map[string]bool) ManifestList {
+	var kept ManifestList
+
+	for _, manifest := range *l {
+		var m struct {
+			Kind string `yaml:"kind"`
+		}
+		if err := yaml.Unmarshal(manifest, &m); err == nil && kinds[m.Kind] {
+			continue
+		}
+		kept = append(kept, manifest)
+	}
+
+	return kept
+}
+
+// WriteToDir writes each manifest to its own file, named after the kind and the name
+// of the resource, in a sub-directory per namespace. Files whose content didn't change
+// are left untouched. It returns the paths of the files.
+func (l *ManifestList) WriteToDir(dir string) ([]string, error) {
+	written := map[string]bool{}
+	var paths []string
+
+	for _, manifest := range *l {
+		var m struct {
+			Kind     string `yaml:"kind"`
+			Metadata struct {
+				Name      string `yaml:"name"`
+				Namespace string `yaml:"namespace"`
+			} `yaml:"metadata"`
+		}
+		if err := yaml.Unmarshal(manifest, &m); err != nil {
+			return nil, errors.Wrap(err, "reading manifest")
+		}
+		if m.Kind == "" {
+			// Empty documents, e.g. helm templates that render nothing.
+			continue
+		}
+
+		name := strings.ToLower(m.Kind + "-" + m.Metadata.Name)
+		path := filepath.Join(dir, m.Metadata.Namespace, name+".yaml")
+		for i := 2; written[path]; i++ {
+			path = filepath.Join(dir, m.Metadata.Namespace, fmt.Sprintf("%s-%d.yaml", name, i))
+		}
+		written[path] = true
+		paths = append(paths, path)
+
+		content := append(bytes.TrimSpace(manifest), '\n')
+		if existing, err := ioutil.ReadFile(path); err == nil && bytes.Equal(existing, content) {
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, errors.Wrap(err, "creating output directory")
+		}
+		if err := ioutil.WriteFile(path, content, 0644); err != nil {
+			return nil, errors.Wrapf(err, "writing %s", path)
+		}
+	}
+
+	return paths, nil
+}
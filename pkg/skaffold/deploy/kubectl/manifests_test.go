@@ -17,6 +17,7 @@ limitations under the License.
 package kubectl
 
 import (
+	"io/ioutil"
 	"testing"
 
 	"github.com/GoogleContainerTools/skaffold/testutil"
@@ -59,3 +60,60 @@ func TestAppendWithoutSeperator(t *testing.T) {
 	testutil.CheckDeepEqual(t, pod1, string(manifests[0]))
 	testutil.CheckDeepEqual(t, pod2, string(manifests[1]))
 }
+
+func TestSplitPriority(t *testing.T) {
+	namespace := `apiVersion: v1
+kind: Namespace
+metadata:
+  name: leeroy`
+	crd := `apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: crontabs.stable.example.com`
+
+	manifests := ManifestList{[]byte(pod1), []byte(namespace), []byte(crd)}
+
+	priority, others := manifests.SplitPriority()
+
+	testutil.CheckDeepEqual(t, ManifestList{[]byte(namespace), []byte(crd)}, priority)
+	testutil.CheckDeepEqual(t, ManifestList{[]byte(pod1)}, others)
+}
+
+func TestExcludeKinds(t *testing.T) {
+	pvc := `apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: data`
+
+	manifests := ManifestList{[]byte(pod1), []byte(pvc), []byte(pod2)}
+
+	kept := manifests.ExcludeKinds(map[string]bool{"PersistentVolumeClaim": true})
+
+	testutil.CheckDeepEqual(t, ManifestList{[]byte(pod1), []byte(pod2)}, kept)
+}
+
+func TestWriteToDir(t *testing.T) {
+	tmpDir, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+
+	namespaced := `apiVersion: v1
+kind: Service
+metadata:
+  name: leeroy-web
+  namespace: leeroy`
+	otherGroup := `apiVersion: serving.knative.dev/v1
+kind: Service
+metadata:
+  name: leeroy-web
+  namespace: leeroy`
+
+	manifests := ManifestList{[]byte(pod1), []byte(namespaced), []byte("# Source: empty.yaml\n"), []byte(otherGroup)}
+	paths, err := manifests.WriteToDir(tmpDir.Root())
+	testutil.CheckErrorAndDeepEqual(t, false, err, tmpDir.Paths("pod-leeroy-web.yaml", "leeroy/service-leeroy-web.yaml", "leeroy/service-leeroy-web-2.yaml"), paths)
+
+	files, err := tmpDir.List()
+	testutil.CheckErrorAndDeepEqual(t, false, err, tmpDir.Paths("", "leeroy", "leeroy/service-leeroy-web.yaml", "leeroy/service-leeroy-web-2.yaml", "pod-leeroy-web.yaml"), files)
+
+	content, err := ioutil.ReadFile(tmpDir.Path("leeroy/service-leeroy-web.yaml"))
+	testutil.CheckErrorAndDeepEqual(t, false, err, namespaced+"\n", string(content))
+}
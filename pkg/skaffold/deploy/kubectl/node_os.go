@@ -0,0 +1,117 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// nodeOSLabels are the well-known labels for the operating system of the nodes.
+var nodeOSLabels = []string{"kubernetes.io/os", "beta.kubernetes.io/os"}
+
+// SetNodeOS schedules the pods that run images built for a given OS on nodes of that OS,
+// for clusters with both Linux and Windows nodes. The OS of the images is given by image reference.
+// Pods that already select nodes by OS, or that run images built for different OSes, are left unchanged.
+func (l *ManifestList) SetNodeOS(osByImage map[string]string) (ManifestList, error) {
+	if len(osByImage) == 0 {
+		return *l, nil
+	}
+
+	var updated ManifestList
+	for _, manifest := range *l {
+		m := make(map[interface{}]interface{})
+		if err := yaml.Unmarshal(manifest, &m); err != nil {
+			return nil, errors.Wrap(err, "reading kubernetes YAML")
+		}
+
+		if len(m) == 0 {
+			continue
+		}
+
+		setNodeOS(m, osByImage)
+
+		updatedManifest, err := yaml.Marshal(m)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshalling yaml")
+		}
+
+		updated = append(updated, updatedManifest)
+	}
+
+	return updated, nil
+}
+
+func setNodeOS(i interface{}, osByImage map[string]string) {
+	switch t := i.(type) {
+	case []interface{}:
+		for _, v := range t {
+			setNodeOS(v, osByImage)
+		}
+	case map[interface{}]interface{}:
+		if _, isPodSpec := t["containers"].([]interface{}); !isPodSpec {
+			for _, v := range t {
+				setNodeOS(v, osByImage)
+			}
+			return
+		}
+
+		if os := podOS(t, osByImage); os != "" {
+			selectNodeOS(t, os)
+		}
+	}
+}
+
+// podOS finds the OS of the images run by a pod spec.
+func podOS(spec map[interface{}]interface{}, osByImage map[string]string) string {
+	var os string
+
+	for _, field := range []string{"initContainers", "containers"} {
+		containers, _ := spec[field].([]interface{})
+		for _, c := range containers {
+			container, _ := c.(map[interface{}]interface{})
+			image, _ := container["image"].(string)
+
+			imageOS := osByImage[image]
+			if imageOS == "" {
+				continue
+			}
+			if os != "" && os != imageOS {
+				return ""
+			}
+			os = imageOS
+		}
+	}
+
+	return os
+}
+
+func selectNodeOS(spec map[interface{}]interface{}, os string) {
+	nodeSelector, _ := spec["nodeSelector"].(map[interface{}]interface{})
+	if nodeSelector == nil {
+		nodeSelector = map[interface{}]interface{}{}
+	}
+
+	for _, label := range nodeOSLabels {
+		if _, present := nodeSelector[label]; present {
+			return
+		}
+	}
+
+	nodeSelector[nodeOSLabels[0]] = os
+	spec["nodeSelector"] = nodeSelector
+}
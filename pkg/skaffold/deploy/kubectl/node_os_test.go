@@ -0,0 +1,124 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestSetNodeOS(t *testing.T) {
+	manifests := ManifestList{[]byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: windows
+spec:
+  template:
+    spec:
+      containers:
+      - image: gcr.io/k8s-skaffold/windows:v1
+        name: windows
+`), []byte(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: linux
+spec:
+  containers:
+  - image: gcr.io/k8s-skaffold/linux:v1
+    name: linux
+`), []byte(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: selected
+spec:
+  containers:
+  - image: gcr.io/k8s-skaffold/windows:v1
+    name: windows
+  nodeSelector:
+    beta.kubernetes.io/os: linux
+`), []byte(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: mixed
+spec:
+  containers:
+  - image: gcr.io/k8s-skaffold/windows:v1
+    name: windows
+  initContainers:
+  - image: gcr.io/k8s-skaffold/arm:v1
+    name: arm
+`)}
+
+	expected := ManifestList{[]byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: windows
+spec:
+  template:
+    spec:
+      containers:
+      - image: gcr.io/k8s-skaffold/windows:v1
+        name: windows
+      nodeSelector:
+        kubernetes.io/os: windows
+`), []byte(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: linux
+spec:
+  containers:
+  - image: gcr.io/k8s-skaffold/linux:v1
+    name: linux
+`), []byte(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: selected
+spec:
+  containers:
+  - image: gcr.io/k8s-skaffold/windows:v1
+    name: windows
+  nodeSelector:
+    beta.kubernetes.io/os: linux
+`), []byte(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: mixed
+spec:
+  containers:
+  - image: gcr.io/k8s-skaffold/windows:v1
+    name: windows
+  initContainers:
+  - image: gcr.io/k8s-skaffold/arm:v1
+    name: arm
+`)}
+
+	resultManifest, err := manifests.SetNodeOS(map[string]string{
+		"gcr.io/k8s-skaffold/windows:v1": "windows",
+		"gcr.io/k8s-skaffold/arm:v1":     "linux",
+	})
+
+	testutil.CheckErrorAndDeepEqual(t, false, err, expected.String(), resultManifest.String())
+}
@@ -91,6 +91,6 @@ func (c *CLI) Version(ctx context.Context) ClientVersion {
 }
 
 func (c *CLI) getVersion(ctx context.Context) ([]byte, error) {
-	cmd := exec.CommandContext(ctx, "kubectl", "version", "--client", "-ojson")
+	cmd := exec.CommandContext(ctx, util.ToolPath("kubectl"), "version", "--client", "-ojson")
 	return util.RunCmdOut(cmd)
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package deploy
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io/ioutil"
@@ -45,6 +46,17 @@ spec:
   - name: leeroy-web
     image: leeroy-web`
 
+const encryptedDeploymentYAML = `apiVersion: ENC[AES256_GCM,data:8Fg=,type:str]
+kind: ENC[AES256_GCM,data:Q2Y=,type:str]
+sops:
+  mac: ENC[AES256_GCM,data:M2Y=,type:str]
+  version: 3.3.1`
+
+const namespaceYAML = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: leeroy`
+
 const deploymentAppYAML = `apiVersion: v1
 kind: Pod
 metadata:
@@ -60,6 +72,7 @@ func TestKubectlDeploy(t *testing.T) {
 
 	tmpDir.Write("deployment.yaml", deploymentWebYAML)
 	tmpDir.Write("empty.ignored", "")
+	tmpDir.Write("encrypted.yaml", encryptedDeploymentYAML)
 
 	var tests = []struct {
 		description string
@@ -68,6 +81,7 @@ func TestKubectlDeploy(t *testing.T) {
 		command     util.Command
 		shouldErr   bool
 		forceDeploy bool
+		concurrency int
 	}{
 		{
 			description: "no manifest",
@@ -117,6 +131,20 @@ func TestKubectlDeploy(t *testing.T) {
 				Tag:       "leeroy-web:123",
 			}},
 		},
+		{
+			description: "deploy sops encrypted manifest",
+			cfg: &latest.KubectlDeploy{
+				Manifests: []string{"encrypted.yaml"},
+			},
+			command: testutil.NewFakeCmd(t).
+				WithRunOut("kubectl version --client -ojson", kubectlVersion).
+				WithRunOut("sops --decrypt --output-type yaml "+tmpDir.Path("encrypted.yaml"), deploymentWebYAML).
+				WithRun("kubectl --context kubecontext --namespace testNamespace apply -f -"),
+			builds: []build.Artifact{{
+				ImageName: "leeroy-web",
+				Tag:       "leeroy-web:123",
+			}},
+		},
 		{
 			description: "deploy command error",
 			cfg: &latest.KubectlDeploy{
@@ -152,6 +180,22 @@ func TestKubectlDeploy(t *testing.T) {
 			}},
 			shouldErr: true,
 		},
+		{
+			description: "concurrent deploy applies namespaces first",
+			cfg: &latest.KubectlDeploy{
+				Manifests: []string{"deployment.yaml"},
+			},
+			command: testutil.NewFakeCmd(t).
+				WithRunOut("kubectl version --client -ojson", kubectlVersion).
+				WithRunOut("kubectl --context kubecontext --namespace testNamespace create --dry-run -oyaml -f "+tmpDir.Path("deployment.yaml"), namespaceYAML+"\n---\n"+deploymentWebYAML).
+				WithRun("kubectl --context kubecontext --namespace testNamespace apply -f -").
+				WithRun("kubectl --context kubecontext --namespace testNamespace apply -f -"),
+			builds: []build.Artifact{{
+				ImageName: "leeroy-web",
+				Tag:       "leeroy-web:123",
+			}},
+			concurrency: 2,
+		},
 	}
 
 	for _, test := range tests {
@@ -166,6 +210,7 @@ func TestKubectlDeploy(t *testing.T) {
 						DeployType: latest.DeployType{
 							KubectlDeploy: test.cfg,
 						},
+						Concurrency: test.concurrency,
 					},
 				},
 				KubeContext: testKubeContext,
@@ -187,10 +232,13 @@ func TestKubectlCleanup(t *testing.T) {
 	defer cleanup()
 
 	tmpDir.Write("deployment.yaml", deploymentWebYAML)
+	tmpDir.Write("namespace.yaml", namespaceYAML)
 
 	var tests = []struct {
 		description string
 		cfg         *latest.KubectlDeploy
+		cleanup     *latest.CleanupConfig
+		retain      []string
 		command     util.Command
 		shouldErr   bool
 	}{
@@ -227,6 +275,29 @@ func TestKubectlCleanup(t *testing.T) {
 				WithRunOut("kubectl --context kubecontext --namespace testNamespace -v=0 create --dry-run -oyaml -f "+tmpDir.Path("deployment.yaml"), deploymentWebYAML).
 				WithRun("kubectl --context kubecontext --namespace testNamespace -v=0 delete --grace-period=1 --ignore-not-found=true -f -"),
 		},
+		{
+			description: "retain namespaces",
+			cfg: &latest.KubectlDeploy{
+				Manifests: []string{"namespace.yaml"},
+			},
+			retain: []string{"Namespace"},
+			command: testutil.
+				FakeRunOut(t, "kubectl --context kubecontext --namespace testNamespace create --dry-run -oyaml -f "+tmpDir.Path("namespace.yaml"), namespaceYAML),
+		},
+		{
+			description: "delete by selector",
+			cfg: &latest.KubectlDeploy{
+				Manifests: []string{"deployment.yaml"},
+			},
+			cleanup: &latest.CleanupConfig{
+				Retain:   []string{"PersistentVolumeClaim", "Secret"},
+				Selector: "app=leeroy",
+			},
+			command: testutil.NewFakeCmd(t).
+				WithRunOut("kubectl --context kubecontext --namespace testNamespace create --dry-run -oyaml -f "+tmpDir.Path("deployment.yaml"), deploymentWebYAML).
+				WithRun("kubectl --context kubecontext --namespace testNamespace delete --ignore-not-found=true -f -").
+				WithRun("kubectl --context kubecontext --namespace testNamespace delete --ignore-not-found=true -l app=leeroy deployments,statefulsets,daemonsets,replicasets,jobs,cronjobs,pods,services,ingresses,configmaps,serviceaccounts"),
+		},
 	}
 
 	for _, test := range tests {
@@ -241,11 +312,13 @@ func TestKubectlCleanup(t *testing.T) {
 						DeployType: latest.DeployType{
 							KubectlDeploy: test.cfg,
 						},
+						Cleanup: test.cleanup,
 					},
 				},
 				KubeContext: testKubeContext,
 				Opts: &config.SkaffoldOptions{
-					Namespace: testNamespace,
+					Namespace:   testNamespace,
+					RetainKinds: test.retain,
 				},
 			})
 			err := k.Cleanup(context.Background(), ioutil.Discard)
@@ -255,6 +328,90 @@ func TestKubectlCleanup(t *testing.T) {
 	}
 }
 
+func TestKubectlRender(t *testing.T) {
+	tmpDir, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+
+	tmpDir.Write("deployment.yaml", deploymentWebYAML)
+
+	reset := testutil.Override(t, &util.DefaultExecCommand, testutil.
+		FakeRunOut(t, "kubectl --context kubecontext --namespace testNamespace create --dry-run -oyaml -f "+tmpDir.Path("deployment.yaml"), deploymentWebYAML))
+	defer reset()
+
+	k := NewKubectlDeployer(&runcontext.RunContext{
+		WorkingDir: tmpDir.Root(),
+		Cfg: &latest.Pipeline{
+			Deploy: latest.DeployConfig{
+				DeployType: latest.DeployType{
+					KubectlDeploy: &latest.KubectlDeploy{
+						Manifests: []string{"deployment.yaml"},
+					},
+				},
+			},
+		},
+		KubeContext: testKubeContext,
+		Opts: &config.SkaffoldOptions{
+			Namespace: testNamespace,
+		},
+	})
+	var out bytes.Buffer
+	err := k.Render(context.Background(), &out, []build.Artifact{{
+		ImageName: "leeroy-web",
+		Tag:       "leeroy-web@sha256:abcd",
+	}}, nil)
+
+	testutil.CheckErrorAndDeepEqual(t, false, err, `apiVersion: v1
+kind: Pod
+metadata:
+  name: leeroy-web
+spec:
+  containers:
+  - image: leeroy-web@sha256:abcd
+    name: leeroy-web
+`, out.String())
+}
+
+func TestKubectlRenderOffline(t *testing.T) {
+	tmpDir, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+
+	tmpDir.Write("deployment.yaml", deploymentWebYAML)
+
+	reset := testutil.Override(t, &util.DefaultExecCommand, testutil.NewFakeCmd(t))
+	defer reset()
+
+	k := NewKubectlDeployer(&runcontext.RunContext{
+		WorkingDir: tmpDir.Root(),
+		Cfg: &latest.Pipeline{
+			Deploy: latest.DeployConfig{
+				DeployType: latest.DeployType{
+					KubectlDeploy: &latest.KubectlDeploy{
+						Manifests: []string{"deployment.yaml"},
+					},
+				},
+			},
+		},
+		Opts: &config.SkaffoldOptions{
+			Offline: true,
+		},
+	})
+	var out bytes.Buffer
+	err := k.Render(context.Background(), &out, []build.Artifact{{
+		ImageName: "leeroy-web",
+		Tag:       "leeroy-web:PLACEHOLDER",
+	}}, nil)
+
+	testutil.CheckErrorAndDeepEqual(t, false, err, `apiVersion: v1
+kind: Pod
+metadata:
+  name: leeroy-web
+spec:
+  containers:
+  - image: leeroy-web:PLACEHOLDER
+    name: leeroy-web
+`, out.String())
+}
+
 func TestKubectlRedeploy(t *testing.T) {
 	tmpDir, cleanup := testutil.NewTempDir(t)
 	defer cleanup()
@@ -340,3 +497,33 @@ spec:
 	}, labellers)
 	testutil.CheckError(t, false, err)
 }
+
+func TestKubectlFlags(t *testing.T) {
+	flags := latest.KubectlFlags{Global: []string{"-v=0"}, Delete: []string{"--grace-period=1"}}
+
+	tests := []struct {
+		description string
+		cfg         latest.DeployConfig
+		expected    latest.KubectlFlags
+	}{
+		{
+			description: "kubectl",
+			cfg:         latest.DeployConfig{DeployType: latest.DeployType{KubectlDeploy: &latest.KubectlDeploy{Flags: flags}}},
+			expected:    flags,
+		},
+		{
+			description: "kustomize",
+			cfg:         latest.DeployConfig{DeployType: latest.DeployType{KustomizeDeploy: &latest.KustomizeDeploy{Flags: flags}}},
+			expected:    flags,
+		},
+		{
+			description: "helm",
+			cfg:         latest.DeployConfig{DeployType: latest.DeployType{HelmDeploy: &latest.HelmDeploy{}}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			testutil.CheckDeepEqual(t, test.expected, KubectlFlags(test.cfg))
+		})
+	}
+}
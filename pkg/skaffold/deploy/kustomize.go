@@ -20,7 +20,6 @@ import (
 	"context"
 	"io"
 	"io/ioutil"
-	"os/exec"
 	"path/filepath"
 
 	yaml "gopkg.in/yaml.v2"
@@ -63,22 +62,33 @@ type secretGenerator struct {
 type KustomizeDeployer struct {
 	*latest.KustomizeDeploy
 
+	workingDir         string
 	kubectl            kubectl.CLI
-	defaultRepo        string
+	defaultRepo        util.DefaultRepoSubstitution
 	insecureRegistries map[string]bool
+	imagesOS           map[string]string
+	policy             *latest.PolicyConfig
+	transforms         []latest.ManifestTransform
+	cleanup            cleanupOptions
 }
 
 func NewKustomizeDeployer(runCtx *runcontext.RunContext) *KustomizeDeployer {
 	return &KustomizeDeployer{
 		KustomizeDeploy: runCtx.Cfg.Deploy.KustomizeDeploy,
+		workingDir:      runCtx.WorkingDir,
 		kubectl: kubectl.CLI{
 			Namespace:   runCtx.Opts.Namespace,
 			KubeContext: runCtx.KubeContext,
 			Flags:       runCtx.Cfg.Deploy.KustomizeDeploy.Flags,
 			ForceDeploy: runCtx.Opts.ForceDeploy(),
+			Concurrency: runCtx.Cfg.Deploy.Concurrency,
 		},
-		defaultRepo:        runCtx.DefaultRepo,
+		defaultRepo:        runCtx.DefaultRepoSubstitution(),
 		insecureRegistries: runCtx.InsecureRegistries,
+		imagesOS:           imagesOS(runCtx.Cfg.Build.Artifacts),
+		policy:             runCtx.Cfg.Deploy.Policy,
+		transforms:         runCtx.Cfg.Deploy.Transforms,
+		cleanup:            newCleanupOptions(runCtx),
 	}
 }
 
@@ -96,10 +106,10 @@ func (k *KustomizeDeployer) Deploy(ctx context.Context, out io.Writer, builds []
 		color.Default.Fprintln(out, err)
 	}
 
-	manifests, err := k.readManifests(ctx)
+	manifests, err := k.renderManifests(ctx, builds, labellers)
 	if err != nil {
 		event.DeployFailed(err)
-		return errors.Wrap(err, "reading manifests")
+		return err
 	}
 
 	if len(manifests) == 0 {
@@ -108,33 +118,47 @@ func (k *KustomizeDeployer) Deploy(ctx context.Context, out io.Writer, builds []
 
 	event.DeployInProgress()
 
-	manifests, err = manifests.ReplaceImages(builds, k.defaultRepo)
-	if err != nil {
+	if err := checkPolicies(ctx, out, k.policy, k.workingDir, manifests); err != nil {
 		event.DeployFailed(err)
-		return errors.Wrap(err, "replacing images in manifests")
+		return errors.Wrap(err, "checking policies")
 	}
 
-	manifests, err = manifests.SetLabels(merge(labellers...))
+	err = k.kubectl.Apply(ctx, out, manifests)
 	if err != nil {
 		event.DeployFailed(err)
-		return errors.Wrap(err, "setting labels in manifests")
+		return errors.Wrap(err, "kubectl error")
 	}
 
-	for _, transform := range manifestTransforms {
-		manifests, err = transform(manifests, builds, k.insecureRegistries)
-		if err != nil {
-			return errors.Wrap(err, "unable to transform manifests")
-		}
+	event.DeployComplete()
+	return nil
+}
+
+// Render writes the manifests generated by kustomize, with the images replaced.
+func (k *KustomizeDeployer) Render(ctx context.Context, out io.Writer, builds []build.Artifact, labellers []Labeller) error {
+	manifests, err := k.renderManifests(ctx, builds, labellers)
+	if err != nil {
+		return err
 	}
 
-	err = k.kubectl.Apply(ctx, out, manifests)
+	return writeManifests(out, manifests)
+}
+
+func (k *KustomizeDeployer) renderManifests(ctx context.Context, builds []build.Artifact, labellers []Labeller) (kubectl.ManifestList, error) {
+	manifests, err := k.readManifests(ctx)
 	if err != nil {
-		event.DeployFailed(err)
-		return errors.Wrap(err, "kubectl error")
+		return nil, errors.Wrap(err, "reading manifests")
 	}
 
-	event.DeployComplete()
-	return nil
+	if len(manifests) == 0 {
+		return nil, nil
+	}
+
+	manifests, err = transformManifests(manifests, builds, labellers, k.defaultRepo, k.insecureRegistries, k.imagesOS)
+	if err != nil {
+		return nil, err
+	}
+
+	return applyTransforms(ctx, k.transforms, k.workingDir, manifests)
 }
 
 // Cleanup deletes what was deployed by calling Deploy.
@@ -144,7 +168,7 @@ func (k *KustomizeDeployer) Cleanup(ctx context.Context, out io.Writer) error {
 		return errors.Wrap(err, "reading manifests")
 	}
 
-	if err := k.kubectl.Delete(ctx, out, manifests); err != nil {
+	if err := k.cleanup.deleteManifests(ctx, out, &k.kubectl, manifests); err != nil {
 		return errors.Wrap(err, "delete")
 	}
 
@@ -207,8 +231,8 @@ func (k *KustomizeDeployer) Dependencies() ([]string, error) {
 }
 
 func (k *KustomizeDeployer) readManifests(ctx context.Context) (kubectl.ManifestList, error) {
-	cmd := exec.CommandContext(ctx, "kustomize", "build", k.KustomizePath)
-	out, err := util.RunCmdOut(cmd)
+	cmd := util.CommandContext(ctx, util.ToolPath("kustomize"), "build", k.KustomizePath)
+	out, err := cmd.RunOut()
 	if err != nil {
 		return nil, errors.Wrap(err, "kustomize build")
 	}
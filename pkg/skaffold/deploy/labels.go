@@ -48,6 +48,24 @@ type Labeller interface {
 	Labels() map[string]string
 }
 
+// Annotator is a Labeller that also gives annotations to set on deployed resources.
+type Annotator interface {
+	Annotations() map[string]string
+}
+
+// mergeAnnotations merges the annotations from the sources that are Annotators.
+func mergeAnnotations(sources ...Labeller) map[string]string {
+	merged := make(map[string]string)
+
+	for _, src := range sources {
+		if a, ok := src.(Annotator); ok {
+			copyMap(merged, a.Annotations())
+		}
+	}
+
+	return merged
+}
+
 // merge merges the labels from multiple sources.
 func merge(sources ...Labeller) map[string]string {
 	merged := make(map[string]string)
@@ -65,7 +83,7 @@ const (
 	sleeptime = 300 * time.Millisecond
 )
 
-func labelDeployResults(labels map[string]string, results []Artifact) {
+func labelDeployResults(labels, annotations map[string]string, results []Artifact) {
 	// use the kubectl client to update all k8s objects with a skaffold watermark
 	dynClient, err := kubernetes.DynamicClient()
 	if err != nil {
@@ -82,7 +100,7 @@ func labelDeployResults(labels map[string]string, results []Artifact) {
 	for _, res := range results {
 		err = nil
 		for i := 0; i < tries; i++ {
-			if err = updateRuntimeObject(dynClient, client.Discovery(), labels, res); err == nil {
+			if err = updateRuntimeObject(dynClient, client.Discovery(), labels, annotations, res); err == nil {
 				break
 			}
 			time.Sleep(sleeptime)
@@ -102,7 +120,20 @@ func addLabels(labels map[string]string, accessor metav1.Object) {
 	accessor.SetLabels(kv)
 }
 
-func updateRuntimeObject(client dynamic.Interface, disco discovery.DiscoveryInterface, labels map[string]string, res Artifact) error {
+func addAnnotations(annotations map[string]string, accessor metav1.Object) {
+	if len(annotations) == 0 {
+		return
+	}
+
+	kv := make(map[string]string)
+
+	copyMap(kv, accessor.GetAnnotations())
+	copyMap(kv, annotations)
+
+	accessor.SetAnnotations(kv)
+}
+
+func updateRuntimeObject(client dynamic.Interface, disco discovery.DiscoveryInterface, labels, annotations map[string]string, res Artifact) error {
 	originalJSON, _ := json.Marshal(res.Obj)
 	modifiedObj := res.Obj.DeepCopyObject()
 	accessor, err := meta.Accessor(modifiedObj)
@@ -118,6 +149,7 @@ func updateRuntimeObject(client dynamic.Interface, disco discovery.DiscoveryInte
 	}
 
 	addLabels(labels, accessor)
+	addAnnotations(annotations, accessor)
 
 	modifiedJSON, _ := json.Marshal(modifiedObj)
 	p, _ := patch.CreateTwoWayMergePatch(originalJSON, modifiedJSON, modifiedObj)
@@ -0,0 +1,202 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/deploy/plugin"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/deploy/plugin/proto"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// For testing
+	startDeployerPlugin = plugin.Start
+	pluginPollPeriod    = time.Second
+)
+
+// PluginDeployer delegates the deployments to an external deployer plugin, over gRPC.
+type PluginDeployer struct {
+	*latest.PluginDeploy
+
+	workingDir  string
+	checkStatus bool
+	statusCheck *latest.StatusCheckConfig
+}
+
+// NewPluginDeployer returns a new PluginDeployer for a RunContext.
+func NewPluginDeployer(runCtx *runcontext.RunContext) *PluginDeployer {
+	return &PluginDeployer{
+		PluginDeploy: runCtx.Cfg.Deploy.PluginDeploy,
+		workingDir:   runCtx.WorkingDir,
+		checkStatus:  runCtx.Opts.StatusCheck || runCtx.Cfg.Deploy.StatusCheck != nil,
+		statusCheck:  runCtx.Cfg.Deploy.StatusCheck,
+	}
+}
+
+// Labels returns the labels specific to deployer plugins.
+func (p *PluginDeployer) Labels() map[string]string {
+	return map[string]string{
+		constants.Labels.Deployer: "plugin",
+	}
+}
+
+// Deploy asks the plugin to deploy the built images. When the status check is enabled,
+// it then waits for the plugin to report the deployment as ready.
+func (p *PluginDeployer) Deploy(ctx context.Context, out io.Writer, builds []build.Artifact, labellers []Labeller) error {
+	event.DeployInProgress()
+
+	if err := p.deploy(ctx, out, builds, labellers); err != nil {
+		event.DeployFailed(err)
+		return errors.Wrapf(err, "deploying with plugin %s", p.Command)
+	}
+
+	event.DeployComplete()
+	return nil
+}
+
+func (p *PluginDeployer) deploy(ctx context.Context, out io.Writer, builds []build.Artifact, labellers []Labeller) error {
+	client, stop, err := p.start(out)
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	resp, err := client.Deploy(ctx, &proto.DeployRequest{
+		Config:    p.Config,
+		Artifacts: pluginArtifacts(builds),
+		Labels:    merge(labellers...),
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(out, resp.Output)
+
+	if !p.checkStatus {
+		return nil
+	}
+	return p.waitForStatus(ctx, out, client)
+}
+
+// waitForStatus polls the plugin until it reports the deployment as ready or failed.
+func (p *PluginDeployer) waitForStatus(ctx context.Context, out io.Writer, client proto.DeployerClient) error {
+	deadline := settingsForResource(p.statusCheck, statusCheckResource{kind: "plugin", name: p.Command}).deadline
+	ctx, cancel := context.WithTimeout(ctx, deadline)
+	defer cancel()
+
+	for {
+		resp, err := client.Status(ctx, &proto.StatusRequest{Config: p.Config})
+		if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("could not stabilize within %v", deadline)
+			}
+			return errors.Wrap(err, "getting status")
+		}
+
+		switch resp.State {
+		case proto.StatusResponse_READY:
+			color.Default.Fprintln(out, "Deployment is ready.", resp.Message)
+			return nil
+		case proto.StatusResponse_FAILED:
+			return errors.New(resp.Message)
+		}
+		logrus.Debugln(resp.Message)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("could not stabilize within %v", deadline)
+		case <-time.After(pluginPollPeriod):
+		}
+	}
+}
+
+// Render asks the plugin for what it would deploy.
+func (p *PluginDeployer) Render(ctx context.Context, out io.Writer, builds []build.Artifact, labellers []Labeller) error {
+	client, stop, err := p.start(out)
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	resp, err := client.Render(ctx, &proto.RenderRequest{
+		Config:    p.Config,
+		Artifacts: pluginArtifacts(builds),
+		Labels:    merge(labellers...),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "rendering with plugin %s", p.Command)
+	}
+
+	_, err = out.Write(resp.Manifests)
+	return err
+}
+
+// Dependencies returns nothing since the plugin is in charge of what it deploys.
+func (p *PluginDeployer) Dependencies() ([]string, error) {
+	return nil, nil
+}
+
+// Cleanup asks the plugin to delete what was deployed.
+func (p *PluginDeployer) Cleanup(ctx context.Context, out io.Writer) error {
+	client, stop, err := p.start(out)
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	resp, err := client.Cleanup(ctx, &proto.CleanupRequest{Config: p.Config})
+	if err != nil {
+		return errors.Wrapf(err, "cleaning up with plugin %s", p.Command)
+	}
+	fmt.Fprint(out, resp.Output)
+
+	return nil
+}
+
+// start runs the plugin for the duration of a single operation.
+// Its logs go to the output.
+func (p *PluginDeployer) start(out io.Writer) (proto.DeployerClient, func(), error) {
+	command := p.Command
+	if filepath.Base(command) != command && !filepath.IsAbs(command) {
+		command = filepath.Join(p.workingDir, command)
+	}
+
+	return startDeployerPlugin(command, p.Args, out)
+}
+
+func pluginArtifacts(builds []build.Artifact) []*proto.Artifact {
+	var artifacts []*proto.Artifact
+	for _, b := range builds {
+		artifacts = append(artifacts, &proto.Artifact{
+			ImageName: b.ImageName,
+			Tag:       b.Tag,
+		})
+	}
+	return artifacts
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"io"
+	"os/exec"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/deploy/plugin/proto"
+	hclog "github.com/hashicorp/go-hclog"
+	plugin "github.com/hashicorp/go-plugin"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+// Handshake is shared by skaffold and the deployer plugins. It ensures that
+// a binary is actually meant to be run as a deployer plugin.
+var Handshake = plugin.HandshakeConfig{
+	ProtocolVersion:  1,
+	MagicCookieKey:   "SKAFFOLD_DEPLOYER_PLUGIN",
+	MagicCookieValue: "a8e6cb47-35d3-4dcb-a98e-6c2f8c6b7e37",
+}
+
+const deployerPlugin = "deployer"
+
+// Serve is called from the main of a deployer plugin, to serve
+// its implementation of the Deployer service.
+func Serve(impl proto.DeployerServer) {
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: Handshake,
+		Plugins: plugin.PluginSet{
+			deployerPlugin: &grpcPlugin{impl: impl},
+		},
+		GRPCServer: plugin.DefaultGRPCServer,
+	})
+}
+
+// Start runs a deployer plugin and connects to it.
+// The returned function stops the plugin.
+func Start(command string, args []string, stderr io.Writer) (proto.DeployerClient, func(), error) {
+	client := plugin.NewClient(&plugin.ClientConfig{
+		HandshakeConfig:  Handshake,
+		Plugins:          plugin.PluginSet{deployerPlugin: &grpcPlugin{}},
+		Cmd:              exec.Command(command, args...),
+		AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+		Logger:           hclog.NewNullLogger(),
+		Stderr:           stderr,
+	})
+
+	rpcClient, err := client.Client()
+	if err != nil {
+		client.Kill()
+		return nil, nil, errors.Wrapf(err, "starting plugin %s", command)
+	}
+
+	raw, err := rpcClient.Dispense(deployerPlugin)
+	if err != nil {
+		client.Kill()
+		return nil, nil, errors.Wrapf(err, "connecting to plugin %s", command)
+	}
+
+	return raw.(proto.DeployerClient), client.Kill, nil
+}
+
+// grpcPlugin serves and consumes the Deployer service over gRPC.
+type grpcPlugin struct {
+	plugin.NetRPCUnsupportedPlugin
+
+	impl proto.DeployerServer
+}
+
+func (p *grpcPlugin) GRPCServer(_ *plugin.GRPCBroker, s *grpc.Server) error {
+	proto.RegisterDeployerServer(s, p.impl)
+	return nil
+}
+
+func (p *grpcPlugin) GRPCClient(_ context.Context, _ *plugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
+	return proto.NewDeployerClient(c), nil
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/config"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/deploy/plugin/proto"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	plugin "github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+)
+
+type fakeDeployerPlugin struct {
+	proto.UnimplementedDeployerServer
+
+	deployed *proto.DeployRequest
+	statuses []proto.StatusResponse_State
+}
+
+func (f *fakeDeployerPlugin) Render(_ context.Context, req *proto.RenderRequest) (*proto.RenderResponse, error) {
+	manifests := ""
+	for _, a := range req.Artifacts {
+		manifests += "image: " + a.Tag + "\n"
+	}
+	return &proto.RenderResponse{Manifests: []byte(manifests)}, nil
+}
+
+func (f *fakeDeployerPlugin) Deploy(_ context.Context, req *proto.DeployRequest) (*proto.DeployResponse, error) {
+	f.deployed = req
+	return &proto.DeployResponse{Output: "deployed to " + req.Config["target"] + "\n"}, nil
+}
+
+func (f *fakeDeployerPlugin) Cleanup(_ context.Context, req *proto.CleanupRequest) (*proto.CleanupResponse, error) {
+	return &proto.CleanupResponse{Output: "deleted from " + req.Config["target"] + "\n"}, nil
+}
+
+func (f *fakeDeployerPlugin) Status(context.Context, *proto.StatusRequest) (*proto.StatusResponse, error) {
+	state := f.statuses[0]
+	f.statuses = f.statuses[1:]
+	return &proto.StatusResponse{State: state, Message: state.String()}, nil
+}
+
+func servePlugin(t *testing.T, impl proto.DeployerServer) func() {
+	return testutil.Override(t, &startDeployerPlugin, func(command string, args []string, _ io.Writer) (proto.DeployerClient, func(), error) {
+		testutil.CheckDeepEqual(t, "/workspace/bin/deployer", command)
+		testutil.CheckDeepEqual(t, []string{"--verbose"}, args)
+
+		conn, server := plugin.TestGRPCConn(t, func(s *grpc.Server) {
+			proto.RegisterDeployerServer(s, impl)
+		})
+		return proto.NewDeployerClient(conn), func() {
+			conn.Close()
+			server.Stop()
+		}, nil
+	})
+}
+
+func pluginRunContext(statusCheck bool) *runcontext.RunContext {
+	return &runcontext.RunContext{
+		Cfg: &latest.Pipeline{
+			Deploy: latest.DeployConfig{
+				DeployType: latest.DeployType{
+					PluginDeploy: &latest.PluginDeploy{
+						Command: "bin/deployer",
+						Args:    []string{"--verbose"},
+						Config:  map[string]string{"target": "paas"},
+					},
+				},
+			},
+		},
+		Opts:       &config.SkaffoldOptions{StatusCheck: statusCheck},
+		WorkingDir: "/workspace",
+	}
+}
+
+func TestPluginDeploy(t *testing.T) {
+	tests := []struct {
+		description string
+		statusCheck bool
+		statuses    []proto.StatusResponse_State
+		expected    string
+		shouldErr   bool
+	}{
+		{
+			description: "deploy",
+			expected:    "deployed to paas\n",
+		},
+		{
+			description: "wait for the deployment",
+			statusCheck: true,
+			statuses:    []proto.StatusResponse_State{proto.StatusResponse_IN_PROGRESS, proto.StatusResponse_READY},
+			expected:    "deployed to paas\nDeployment is ready. READY\n",
+		},
+		{
+			description: "deployment fails",
+			statusCheck: true,
+			statuses:    []proto.StatusResponse_State{proto.StatusResponse_FAILED},
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			impl := &fakeDeployerPlugin{statuses: test.statuses}
+			defer servePlugin(t, impl)()
+			defer testutil.Override(t, &pluginPollPeriod, time.Duration(0))()
+
+			runCtx := pluginRunContext(test.statusCheck)
+			event.InitializeState(runCtx)
+			deployer := NewPluginDeployer(runCtx)
+
+			var out bytes.Buffer
+			err := deployer.Deploy(context.Background(), &out, []build.Artifact{{ImageName: "app", Tag: "app:v1"}}, []Labeller{deployer})
+
+			testutil.CheckError(t, test.shouldErr, err)
+			if !test.shouldErr {
+				testutil.CheckDeepEqual(t, test.expected, out.String())
+				testutil.CheckDeepEqual(t, "app:v1", impl.deployed.Artifacts[0].Tag)
+				testutil.CheckDeepEqual(t, "plugin", impl.deployed.Labels["skaffold.dev/deployer"])
+			}
+		})
+	}
+}
+
+func TestPluginRender(t *testing.T) {
+	defer servePlugin(t, &fakeDeployerPlugin{})()
+
+	var out bytes.Buffer
+	err := NewPluginDeployer(pluginRunContext(false)).Render(context.Background(), &out, []build.Artifact{{ImageName: "app", Tag: "app:v1"}}, nil)
+
+	testutil.CheckErrorAndDeepEqual(t, false, err, "image: app:v1\n", out.String())
+}
+
+func TestPluginCleanup(t *testing.T) {
+	defer servePlugin(t, &fakeDeployerPlugin{})()
+
+	var out bytes.Buffer
+	err := NewPluginDeployer(pluginRunContext(false)).Cleanup(context.Background(), &out)
+
+	testutil.CheckErrorAndDeepEqual(t, false, err, "deleted from paas\n", out.String())
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/ci"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/deploy/kubectl"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/pkg/errors"
+)
+
+// policyResult is the part of conftest's JSON output that Skaffold reads.
+type policyResult struct {
+	Warnings []policyMessage `json:"warnings"`
+	Failures []policyMessage `json:"failures"`
+}
+
+type policyMessage struct {
+	Msg string `json:"msg"`
+}
+
+// checkPolicies evaluates the Rego policies configured in `deploy.policy`
+// against the rendered manifests, with `conftest`.
+// It fails if any `deny` or `violation` rule matches, or any `warn` rule
+// matches when `failOnWarn` is set.
+func checkPolicies(ctx context.Context, out io.Writer, cfg *latest.PolicyConfig, workingDir string, manifests kubectl.ManifestList) error {
+	if cfg == nil || len(manifests) == 0 {
+		return nil
+	}
+
+	cmd := exec.CommandContext(ctx, "conftest", conftestArgs(cfg)...)
+	cmd.Dir = workingDir
+	cmd.Stdin = manifests.Reader()
+
+	// conftest exits with an error when policies fail, but still prints its report.
+	buf, runErr := util.RunCmdOut(cmd)
+
+	var results []policyResult
+	if err := json.Unmarshal(buf, &results); err != nil {
+		if runErr != nil {
+			return errors.Wrap(runErr, "running conftest")
+		}
+		return errors.Wrap(err, "reading conftest output")
+	}
+
+	var failures, warnings int
+	for _, r := range results {
+		for _, w := range r.Warnings {
+			warnings++
+			color.Yellow.Fprintf(out, "WARN - %s\n", w.Msg)
+			ci.Warning(out, ci.Annotation{Title: "Policy warning", Message: w.Msg})
+		}
+		for _, f := range r.Failures {
+			failures++
+			color.Red.Fprintf(out, "FAIL - %s\n", f.Msg)
+			ci.Error(out, ci.Annotation{Title: "Policy failure", Message: f.Msg})
+		}
+	}
+
+	if failures > 0 || (cfg.FailOnWarn && warnings > 0) {
+		return fmt.Errorf("manifests don't comply with the policies: %d failures, %d warnings", failures, warnings)
+	}
+	return nil
+}
+
+func conftestArgs(cfg *latest.PolicyConfig) []string {
+	args := []string{"test", "--no-color", "--output", "json", "--parser", "yaml"}
+	for _, p := range cfg.Policies {
+		args = append(args, "--policy", p)
+	}
+	for _, n := range cfg.Namespaces {
+		args = append(args, "--namespace", n)
+	}
+	args = append(args, cfg.Flags...)
+
+	// Read the manifests from stdin.
+	return append(args, "-")
+}
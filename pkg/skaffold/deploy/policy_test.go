@@ -0,0 +1,97 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/deploy/kubectl"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestCheckPolicies(t *testing.T) {
+	manifests := kubectl.ManifestList{[]byte(deploymentWebYAML)}
+
+	tests := []struct {
+		description string
+		cfg         *latest.PolicyConfig
+		command     util.Command
+		expected    string
+		shouldErr   bool
+	}{
+		{
+			description: "no policy",
+			command:     testutil.NewFakeCmd(t),
+		},
+		{
+			description: "compliant",
+			cfg:         &latest.PolicyConfig{},
+			command:     testutil.FakeRunOut(t, "conftest test --no-color --output json --parser yaml -", `[{"filename":"","namespace":"main","successes":2}]`),
+		},
+		{
+			description: "policies and namespaces",
+			cfg: &latest.PolicyConfig{
+				Policies:   []string{"policy", "../shared"},
+				Namespaces: []string{"kubernetes"},
+				Flags:      []string{"--trace"},
+			},
+			command: testutil.FakeRunOut(t, "conftest test --no-color --output json --parser yaml --policy policy --policy ../shared --namespace kubernetes --trace -", `[]`),
+		},
+		{
+			description: "warnings",
+			cfg:         &latest.PolicyConfig{},
+			command:     testutil.FakeRunOut(t, "conftest test --no-color --output json --parser yaml -", `[{"warnings":[{"msg":"no resource limits"}]}]`),
+			expected:    "WARN - no resource limits\n",
+		},
+		{
+			description: "fail on warnings",
+			cfg:         &latest.PolicyConfig{FailOnWarn: true},
+			command:     testutil.FakeRunOut(t, "conftest test --no-color --output json --parser yaml -", `[{"warnings":[{"msg":"no resource limits"}]}]`),
+			expected:    "WARN - no resource limits\n",
+			shouldErr:   true,
+		},
+		{
+			description: "failures",
+			cfg:         &latest.PolicyConfig{},
+			command:     testutil.FakeRunOutErr(t, "conftest test --no-color --output json --parser yaml -", `[{"failures":[{"msg":"privileged container"}]}]`, errors.New("exit status 1")),
+			expected:    "FAIL - privileged container\n",
+			shouldErr:   true,
+		},
+		{
+			description: "conftest error",
+			cfg:         &latest.PolicyConfig{},
+			command:     testutil.FakeRunOutErr(t, "conftest test --no-color --output json --parser yaml -", "", errors.New("no policies found")),
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			reset := testutil.Override(t, &util.DefaultExecCommand, test.command)
+			defer reset()
+
+			var out bytes.Buffer
+			err := checkPolicies(context.Background(), &out, test.cfg, "", manifests)
+
+			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.expected, out.String())
+		})
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/deploy/kubectl"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/docker"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/pkg/errors"
+)
+
+// transformManifests replaces the images with the build results, sets the labels
+// and applies the registered manifest transforms.
+func transformManifests(manifests kubectl.ManifestList, builds []build.Artifact, labellers []Labeller, defaultRepo util.DefaultRepoSubstitution, insecureRegistries map[string]bool, imagesOS map[string]string) (kubectl.ManifestList, error) {
+	manifests, err := manifests.ReplaceImages(builds, defaultRepo)
+	if err != nil {
+		return nil, errors.Wrap(err, "replacing images in manifests")
+	}
+
+	manifests, err = manifests.SetNodeOS(osByTag(builds, imagesOS))
+	if err != nil {
+		return nil, errors.Wrap(err, "setting node selectors in manifests")
+	}
+
+	manifests, err = setMetadata(manifests, labellers)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, transform := range manifestTransforms {
+		manifests, err = transform(manifests, builds, insecureRegistries)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to transform manifests")
+		}
+	}
+
+	return manifests, nil
+}
+
+// imagesOS lists the OS of the artifacts that set a platform, by image name.
+func imagesOS(artifacts []*latest.Artifact) map[string]string {
+	imagesOS := map[string]string{}
+	for _, a := range artifacts {
+		if a.DockerArtifact != nil && a.DockerArtifact.Platform != "" {
+			imagesOS[a.ImageName] = docker.PlatformOS(a.DockerArtifact.Platform)
+		}
+	}
+	return imagesOS
+}
+
+// osByTag gives the OS of the built images, by image reference.
+func osByTag(builds []build.Artifact, imagesOS map[string]string) map[string]string {
+	tags := map[string]string{}
+	for _, b := range builds {
+		if os, found := imagesOS[b.ImageName]; found {
+			tags[b.Tag] = os
+		}
+	}
+	return tags
+}
+
+// setMetadata sets the labels and the annotations given by the labellers.
+func setMetadata(manifests kubectl.ManifestList, labellers []Labeller) (kubectl.ManifestList, error) {
+	manifests, err := manifests.SetLabels(merge(labellers...))
+	if err != nil {
+		return nil, errors.Wrap(err, "setting labels in manifests")
+	}
+
+	manifests, err = manifests.SetAnnotations(mergeAnnotations(labellers...))
+	if err != nil {
+		return nil, errors.Wrap(err, "setting annotations in manifests")
+	}
+
+	return manifests, nil
+}
+
+// writeManifests writes rendered manifests as a single yaml stream.
+func writeManifests(out io.Writer, manifests kubectl.ManifestList) error {
+	if len(manifests) == 0 {
+		return nil
+	}
+
+	_, err := fmt.Fprintln(out, manifests.String())
+	return err
+}
@@ -0,0 +1,389 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/ci"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/deploy/kubectl"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	pkgkubernetes "github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes"
+	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	defaultStatusCheckDeadline = 10 * time.Minute
+
+	// Poll period for checking the rollout status of a resource.
+	defaultPollPeriod = 100 * time.Millisecond
+
+	// Period at which the progress of the resources that aren't stable yet is reported.
+	defaultProgressPeriod = 5 * time.Second
+)
+
+var (
+	// For testing
+	executeRolloutStatus       = getRolloutStatus
+	executeListKnativeServices = getKnativeServices
+	pollPeriod                 = defaultPollPeriod
+	progressPeriod             = defaultProgressPeriod
+)
+
+// statusCheckResource is a resource whose rollout status is checked.
+type statusCheckResource struct {
+	kind      string
+	name      string
+	namespace string
+}
+
+func (r statusCheckResource) String() string {
+	if r.namespace == "" {
+		return fmt.Sprintf("%s/%s", strings.ToLower(r.kind), r.name)
+	}
+	return fmt.Sprintf("%s:%s/%s", r.namespace, strings.ToLower(r.kind), r.name)
+}
+
+// statusCheckSettings are the deadline and failure threshold used to check a resource.
+type statusCheckSettings struct {
+	deadline         time.Duration
+	failureThreshold int
+}
+
+// StatusCheckEnabled returns true if Skaffold should wait for deployed resources to stabilize.
+func StatusCheckEnabled(runCtx *runcontext.RunContext) bool {
+	if runCtx.Cfg.Deploy.DockerComposeDeploy != nil {
+		// Nothing runs on a cluster.
+		return false
+	}
+	if runCtx.Cfg.Deploy.PluginDeploy != nil {
+		// Plugins report the status of their deployments.
+		return false
+	}
+	return runCtx.Opts.StatusCheck || runCtx.Cfg.Deploy.StatusCheck != nil
+}
+
+// StatusCheck waits for the resources labelled by the given labeller to stabilize.
+func StatusCheck(ctx context.Context, out io.Writer, l Labeller, runCtx *runcontext.RunContext) error {
+	client, err := pkgkubernetes.Client()
+	if err != nil {
+		return errors.Wrap(err, "getting kubernetes client")
+	}
+
+	resources, err := getStatusCheckResources(client, runCtx.Namespaces, l)
+	if err != nil {
+		return errors.Wrap(err, "listing resources to check")
+	}
+	endpoints, err := getStatusCheckEndpoints(client, runCtx.Namespaces, runCtx.Cfg.Deploy.StatusCheck)
+	if err != nil {
+		return errors.Wrap(err, "listing endpoints to probe")
+	}
+	if len(resources) == 0 && len(endpoints) == 0 {
+		return nil
+	}
+
+	color.Default.Fprintln(out, "Waiting for deployed resources to stabilize...")
+	start := time.Now()
+
+	cli := &kubectl.CLI{
+		KubeContext: runCtx.KubeContext,
+		Flags:       KubectlFlags(runCtx.Cfg.Deploy),
+	}
+
+	progress := newStatusCheckProgress(resources)
+	reportCtx, stopReporting := context.WithCancel(ctx)
+	reported := make(chan struct{})
+	go func() {
+		defer close(reported)
+		reportProgress(reportCtx, out, client, progress)
+	}()
+
+	errs := make([]error, len(resources))
+	var wg sync.WaitGroup
+	for i, r := range resources {
+		wg.Add(1)
+		go func(i int, r statusCheckResource) {
+			defer wg.Done()
+			errs[i] = pollRolloutStatus(ctx, cli, r, settingsForResource(runCtx.Cfg.Deploy.StatusCheck, r), progress)
+			progress.finish(r)
+		}(i, r)
+	}
+	wg.Wait()
+
+	// Stop reporting before printing the results.
+	stopReporting()
+	<-reported
+
+	var (
+		failed []string
+		diags  []diagnostic
+	)
+	for i, r := range resources {
+		if errs[i] != nil {
+			event.StatusCheckFailed(r.String(), errs[i])
+			color.Red.Fprintf(out, " - %s failed: %s\n", r, errs[i])
+			ci.Error(out, ci.Annotation{Title: fmt.Sprintf("Status check failed: %s", r), Message: errs[i].Error()})
+			failed = append(failed, r.String())
+
+			resourceDiags := gatherDiagnostics(ctx, cli, client, r, diagnosticsLogLines(runCtx.Cfg.Deploy.StatusCheck))
+			printDiagnostics(out, resourceDiags)
+			diags = append(diags, resourceDiags...)
+			continue
+		}
+		event.StatusCheckComplete(r.String())
+		color.Default.Fprintf(out, " - %s is ready\n", r)
+	}
+	if len(failed) > 0 {
+		if tarball := diagnosticsTarball(runCtx.Cfg.Deploy.StatusCheck); tarball != "" {
+			if err := writeDiagnosticsTarball(tarball, diags); err != nil {
+				logrus.Warnln("Unable to write diagnostics:", err)
+			} else {
+				color.Default.Fprintln(out, "Diagnostics written to", tarball)
+			}
+		}
+		return fmt.Errorf("%d/%d resources failed to stabilize: %s", len(failed), len(resources), strings.Join(failed, ", "))
+	}
+
+	if err := probeEndpoints(ctx, out, client, endpoints, runCtx.Cfg.Deploy.StatusCheck); err != nil {
+		return err
+	}
+
+	color.Default.Fprintln(out, "Resources stabilized in", time.Since(start))
+	return nil
+}
+
+// probeEndpoints waits for all the endpoints to answer with their expected status.
+func probeEndpoints(ctx context.Context, out io.Writer, client kubernetes.Interface, endpoints []statusCheckEndpoint, cfg *latest.StatusCheckConfig) error {
+	errs := make([]error, len(endpoints))
+	var wg sync.WaitGroup
+	for i, e := range endpoints {
+		wg.Add(1)
+		go func(i int, e statusCheckEndpoint) {
+			defer wg.Done()
+			errs[i] = pollEndpoint(ctx, client, e, endpointDeadline(cfg, e))
+		}(i, e)
+	}
+	wg.Wait()
+
+	var failed []string
+	for i, e := range endpoints {
+		if errs[i] != nil {
+			color.Red.Fprintf(out, " - %s failed: %s\n", e, errs[i])
+			ci.Error(out, ci.Annotation{Title: fmt.Sprintf("Endpoint check failed: %s", e), Message: errs[i].Error()})
+			failed = append(failed, e.String())
+			continue
+		}
+		color.Default.Fprintf(out, " - %s is reachable\n", e)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d/%d endpoints are not reachable: %s", len(failed), len(endpoints), strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+func getStatusCheckResources(client kubernetes.Interface, namespaces []string, l Labeller) ([]statusCheckResource, error) {
+	opts := metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(l.Labels()).String(),
+	}
+
+	var resources []statusCheckResource
+	for _, ns := range namespaces {
+		deps, err := client.AppsV1().Deployments(ns).List(opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "listing deployments")
+		}
+		for _, d := range deps.Items {
+			resources = append(resources, statusCheckResource{kind: "Deployment", name: d.Name, namespace: d.Namespace})
+		}
+
+		// `kubectl rollout status` is only available for the RollingUpdate strategy.
+		sets, err := client.AppsV1().StatefulSets(ns).List(opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "listing stateful sets")
+		}
+		for _, s := range sets.Items {
+			if s.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+				logrus.Debugf("Skipping status check for stateful set %s with OnDelete update strategy", s.Name)
+				continue
+			}
+			resources = append(resources, statusCheckResource{kind: "StatefulSet", name: s.Name, namespace: s.Namespace})
+		}
+
+		daemons, err := client.AppsV1().DaemonSets(ns).List(opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "listing daemon sets")
+		}
+		for _, d := range daemons.Items {
+			if d.Spec.UpdateStrategy.Type == appsv1.OnDeleteDaemonSetStrategyType {
+				logrus.Debugf("Skipping status check for daemon set %s with OnDelete update strategy", d.Name)
+				continue
+			}
+			resources = append(resources, statusCheckResource{kind: "DaemonSet", name: d.Name, namespace: d.Namespace})
+		}
+
+		// Replica sets managed by a deployment are already covered by the deployment's rollout.
+		replicas, err := client.AppsV1().ReplicaSets(ns).List(opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "listing replica sets")
+		}
+		for _, r := range replicas.Items {
+			if metav1.GetControllerOf(&r) != nil {
+				continue
+			}
+			resources = append(resources, statusCheckResource{kind: "ReplicaSet", name: r.Name, namespace: r.Namespace})
+		}
+	}
+
+	ksvcs, err := executeListKnativeServices(namespaces, opts)
+	if err != nil {
+		return nil, err
+	}
+	resources = append(resources, ksvcs...)
+
+	return resources, nil
+}
+
+// settingsForResource finds the deadline and failure threshold for a resource.
+// Settings from the last matching entry of `statusCheck.resources` win over the
+// global settings.
+func settingsForResource(cfg *latest.StatusCheckConfig, r statusCheckResource) statusCheckSettings {
+	settings := statusCheckSettings{
+		deadline: defaultStatusCheckDeadline,
+	}
+	if cfg == nil {
+		return settings
+	}
+
+	if cfg.DeadlineSeconds > 0 {
+		settings.deadline = time.Duration(cfg.DeadlineSeconds) * time.Second
+	}
+	settings.failureThreshold = cfg.FailureThreshold
+
+	for _, override := range cfg.Resources {
+		if override.Kind != "" && !strings.EqualFold(override.Kind, r.kind) {
+			continue
+		}
+		if override.Name != "" && override.Name != r.name {
+			continue
+		}
+		if override.DeadlineSeconds > 0 {
+			settings.deadline = time.Duration(override.DeadlineSeconds) * time.Second
+		}
+		if override.FailureThreshold != nil {
+			settings.failureThreshold = *override.FailureThreshold
+		}
+	}
+
+	return settings
+}
+
+// pollRolloutStatus waits for a resource to be rolled out. Its latest status is
+// recorded in the progress, if any.
+func pollRolloutStatus(ctx context.Context, cli *kubectl.CLI, r statusCheckResource, settings statusCheckSettings, progress *statusCheckProgress) error {
+	ctx, cancel := context.WithTimeout(ctx, settings.deadline)
+	defer cancel()
+
+	failures := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("could not stabilize within %v", settings.deadline)
+		case <-time.After(pollPeriod):
+			status, err := executeRolloutStatus(ctx, cli, r)
+			if err != nil {
+				failures++
+				if failures > settings.failureThreshold {
+					return err
+				}
+				logrus.Debugf("Ignoring failed status check %d/%d for %s: %s", failures, settings.failureThreshold, r, err)
+				continue
+			}
+			failures = 0
+			progress.update(r, status)
+
+			if strings.Contains(status, "successfully rolled out") {
+				return nil
+			}
+		}
+	}
+}
+
+func getRolloutStatus(ctx context.Context, cli *kubectl.CLI, r statusCheckResource) (string, error) {
+	// `kubectl rollout status` doesn't support replica sets and knative services.
+	switch r.kind {
+	case "ReplicaSet":
+		return getReplicaSetStatus(r)
+	case knativeServiceKind:
+		return getKnativeServiceStatus(r)
+	}
+
+	args := []string{fmt.Sprintf("%s/%s", strings.ToLower(r.kind), r.name), "--watch=false"}
+	if r.namespace != "" {
+		args = append(args, "--namespace", r.namespace)
+	}
+
+	b, err := cli.RunOut(ctx, "rollout", []string{"status"}, args...)
+	if err != nil {
+		return "", errors.Wrap(err, "getting rollout status")
+	}
+	return string(b), nil
+}
+
+func getReplicaSetStatus(r statusCheckResource) (string, error) {
+	client, err := pkgkubernetes.Client()
+	if err != nil {
+		return "", errors.Wrap(err, "getting kubernetes client")
+	}
+
+	rs, err := client.AppsV1().ReplicaSets(r.namespace).Get(r.name, metav1.GetOptions{})
+	if err != nil {
+		return "", errors.Wrap(err, "getting replica set")
+	}
+
+	return replicaSetStatus(rs), nil
+}
+
+// replicaSetStatus mimics the output of `kubectl rollout status` for a replica set.
+func replicaSetStatus(rs *appsv1.ReplicaSet) string {
+	if rs.Generation > rs.Status.ObservedGeneration {
+		return "Waiting for replica set spec update to be observed..."
+	}
+
+	replicas := int32(1)
+	if rs.Spec.Replicas != nil {
+		replicas = *rs.Spec.Replicas
+	}
+	if rs.Status.AvailableReplicas < replicas {
+		return fmt.Sprintf("Waiting for replica set %q rollout to finish: %d of %d replicas are available...", rs.Name, rs.Status.AvailableReplicas, replicas)
+	}
+
+	return fmt.Sprintf("replica set %q successfully rolled out", rs.Name)
+}
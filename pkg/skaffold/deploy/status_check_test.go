@@ -0,0 +1,247 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/deploy/kubectl"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type testLabeller map[string]string
+
+func (l testLabeller) Labels() map[string]string { return l }
+
+func TestGetStatusCheckResources(t *testing.T) {
+	skaffoldLabels := map[string]string{"app.kubernetes.io/managed-by": "skaffold-v0"}
+	meta := func(name string, labels map[string]string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: "test", Labels: labels}
+	}
+	isController := true
+
+	client := fake.NewSimpleClientset(
+		&appsv1.Deployment{ObjectMeta: meta("dep1", skaffoldLabels)},
+		&appsv1.Deployment{ObjectMeta: meta("dep2", nil)},
+		&appsv1.StatefulSet{ObjectMeta: meta("db", skaffoldLabels)},
+		&appsv1.StatefulSet{
+			ObjectMeta: meta("on-delete", skaffoldLabels),
+			Spec:       appsv1.StatefulSetSpec{UpdateStrategy: appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType}},
+		},
+		&appsv1.DaemonSet{ObjectMeta: meta("agent", skaffoldLabels)},
+		&appsv1.ReplicaSet{ObjectMeta: meta("rs", skaffoldLabels)},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name:            "dep1-123",
+			Namespace:       "test",
+			Labels:          skaffoldLabels,
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "dep1", Controller: &isController}},
+		}},
+	)
+
+	defer testutil.Override(t, &executeListKnativeServices, func(namespaces []string, opts metav1.ListOptions) ([]statusCheckResource, error) {
+		testutil.CheckDeepEqual(t, "app.kubernetes.io/managed-by=skaffold-v0", opts.LabelSelector)
+		return []statusCheckResource{{kind: knativeServiceKind, name: "hello", namespace: "test"}}, nil
+	})()
+
+	actual, err := getStatusCheckResources(client, []string{"test"}, testLabeller(skaffoldLabels))
+
+	expected := []statusCheckResource{
+		{kind: "Deployment", name: "dep1", namespace: "test"},
+		{kind: "StatefulSet", name: "db", namespace: "test"},
+		{kind: "DaemonSet", name: "agent", namespace: "test"},
+		{kind: "ReplicaSet", name: "rs", namespace: "test"},
+		{kind: knativeServiceKind, name: "hello", namespace: "test"},
+	}
+	testutil.CheckErrorAndDeepEqual(t, false, err, expected, actual, cmp.AllowUnexported(statusCheckResource{}))
+}
+
+func TestReplicaSetStatus(t *testing.T) {
+	two := int32(2)
+
+	tests := []struct {
+		description string
+		rs          *appsv1.ReplicaSet
+		expected    string
+	}{
+		{
+			description: "spec update not observed",
+			rs: &appsv1.ReplicaSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "rs", Generation: 2},
+				Status:     appsv1.ReplicaSetStatus{ObservedGeneration: 1},
+			},
+			expected: "Waiting for replica set spec update to be observed...",
+		},
+		{
+			description: "not all available",
+			rs: &appsv1.ReplicaSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "rs"},
+				Spec:       appsv1.ReplicaSetSpec{Replicas: &two},
+				Status:     appsv1.ReplicaSetStatus{AvailableReplicas: 1},
+			},
+			expected: `Waiting for replica set "rs" rollout to finish: 1 of 2 replicas are available...`,
+		},
+		{
+			description: "rolled out",
+			rs: &appsv1.ReplicaSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "rs"},
+				Spec:       appsv1.ReplicaSetSpec{Replicas: &two},
+				Status:     appsv1.ReplicaSetStatus{AvailableReplicas: 2},
+			},
+			expected: `replica set "rs" successfully rolled out`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			testutil.CheckDeepEqual(t, test.expected, replicaSetStatus(test.rs))
+		})
+	}
+}
+
+func TestSettingsForResource(t *testing.T) {
+	three := 3
+	zero := 0
+
+	tests := []struct {
+		description string
+		cfg         *latest.StatusCheckConfig
+		resource    statusCheckResource
+		expected    statusCheckSettings
+	}{
+		{
+			description: "no config",
+			resource:    statusCheckResource{kind: "Deployment", name: "dep"},
+			expected:    statusCheckSettings{deadline: defaultStatusCheckDeadline},
+		},
+		{
+			description: "global settings",
+			cfg:         &latest.StatusCheckConfig{DeadlineSeconds: 60, FailureThreshold: 2},
+			resource:    statusCheckResource{kind: "Deployment", name: "dep"},
+			expected:    statusCheckSettings{deadline: time.Minute, failureThreshold: 2},
+		},
+		{
+			description: "per kind",
+			cfg: &latest.StatusCheckConfig{
+				DeadlineSeconds: 60,
+				Resources: []latest.StatusCheckResource{
+					{Kind: "statefulset", DeadlineSeconds: 1200, FailureThreshold: &three},
+				},
+			},
+			resource: statusCheckResource{kind: "StatefulSet", name: "db"},
+			expected: statusCheckSettings{deadline: 20 * time.Minute, failureThreshold: 3},
+		},
+		{
+			description: "per kind doesn't match",
+			cfg: &latest.StatusCheckConfig{
+				DeadlineSeconds: 60,
+				Resources: []latest.StatusCheckResource{
+					{Kind: "StatefulSet", DeadlineSeconds: 1200},
+				},
+			},
+			resource: statusCheckResource{kind: "Deployment", name: "db"},
+			expected: statusCheckSettings{deadline: time.Minute},
+		},
+		{
+			description: "last match wins",
+			cfg: &latest.StatusCheckConfig{
+				FailureThreshold: 1,
+				Resources: []latest.StatusCheckResource{
+					{Kind: "Deployment", DeadlineSeconds: 120, FailureThreshold: &three},
+					{Name: "web", DeadlineSeconds: 30, FailureThreshold: &zero},
+				},
+			},
+			resource: statusCheckResource{kind: "Deployment", name: "web"},
+			expected: statusCheckSettings{deadline: 30 * time.Second},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			testutil.CheckDeepEqual(t, test.expected, settingsForResource(test.cfg, test.resource), cmp.AllowUnexported(statusCheckSettings{}))
+		})
+	}
+}
+
+func TestPollRolloutStatus(t *testing.T) {
+	tests := []struct {
+		description      string
+		statuses         []string
+		errs             []error
+		failureThreshold int
+		shouldErr        bool
+	}{
+		{
+			description: "rolled out",
+			statuses:    []string{"Waiting for rollout to finish", `deployment "dep" successfully rolled out`},
+			errs:        []error{nil, nil},
+		},
+		{
+			description: "failure",
+			statuses:    []string{""},
+			errs:        []error{errors.New("exceeded its progress deadline")},
+			shouldErr:   true,
+		},
+		{
+			description:      "tolerated transient failure",
+			statuses:         []string{"", `deployment "dep" successfully rolled out`},
+			errs:             []error{errors.New("connection refused"), nil},
+			failureThreshold: 1,
+		},
+		{
+			description:      "too many failures",
+			statuses:         []string{"", ""},
+			errs:             []error{errors.New("connection refused"), errors.New("connection refused")},
+			failureThreshold: 1,
+			shouldErr:        true,
+		},
+		{
+			description: "deadline",
+			statuses:    []string{"Waiting for rollout to finish"},
+			errs:        []error{nil},
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			i := 0
+			reset := testutil.Override(t, &executeRolloutStatus, func(context.Context, *kubectl.CLI, statusCheckResource) (string, error) {
+				if i >= len(test.statuses) {
+					i = len(test.statuses) - 1
+				}
+				status, err := test.statuses[i], test.errs[i]
+				i++
+				return status, err
+			})
+			defer reset()
+			resetPoll := testutil.Override(t, &pollPeriod, time.Millisecond)
+			defer resetPoll()
+
+			err := pollRolloutStatus(context.Background(), &kubectl.CLI{}, statusCheckResource{kind: "Deployment", name: "dep"}, statusCheckSettings{
+				deadline:         100 * time.Millisecond,
+				failureThreshold: test.failureThreshold,
+			}, nil)
+
+			testutil.CheckError(t, test.shouldErr, err)
+		})
+	}
+}
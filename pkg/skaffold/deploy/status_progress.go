@@ -0,0 +1,193 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+)
+
+// replicasPattern matches the replica counts in the output of `kubectl rollout status`, e.g.
+// `1 of 3 updated replicas are available` or `2 out of 3 new replicas have been updated`.
+var replicasPattern = regexp.MustCompile(`(\d+) (?:out )?of (\d+)`)
+
+// statusCheckProgress tracks the latest rollout status of the resources being checked.
+type statusCheckProgress struct {
+	lock      sync.Mutex
+	resources []statusCheckResource
+	statuses  map[statusCheckResource]string
+	done      map[statusCheckResource]bool
+}
+
+// pendingResource is a resource that isn't stable yet.
+type pendingResource struct {
+	resource statusCheckResource
+	status   string
+}
+
+func newStatusCheckProgress(resources []statusCheckResource) *statusCheckProgress {
+	return &statusCheckProgress{
+		resources: resources,
+		statuses:  map[statusCheckResource]string{},
+		done:      map[statusCheckResource]bool{},
+	}
+}
+
+func (p *statusCheckProgress) update(r statusCheckResource, status string) {
+	if p == nil {
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.statuses[r] = strings.TrimSpace(status)
+}
+
+func (p *statusCheckProgress) finish(r statusCheckResource) {
+	if p == nil {
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.done[r] = true
+}
+
+func (p *statusCheckProgress) pending() []pendingResource {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var pending []pendingResource
+	for _, r := range p.resources {
+		if !p.done[r] {
+			pending = append(pending, pendingResource{resource: r, status: p.statuses[r]})
+		}
+	}
+	return pending
+}
+
+// reportProgress periodically prints the resources that aren't stable yet, with
+// the recent events of their pending pods, and sends the same information as events.
+// It returns when the context is cancelled.
+func reportProgress(ctx context.Context, out io.Writer, client kubernetes.Interface, p *statusCheckProgress) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(progressPeriod):
+			for _, r := range p.pending() {
+				ready, total, ok := replicaCounts(r.status)
+				events := pendingPodEvents(client, r.resource)
+
+				event.StatusCheckProgress(r.resource.String(), r.status, ready, total, events)
+
+				switch {
+				case ok:
+					color.Default.Fprintf(out, " - %s: %d/%d replicas ready (%d%%)\n", r.resource, ready, total, ready*100/total)
+				case r.status != "":
+					color.Default.Fprintf(out, " - %s: %s\n", r.resource, r.status)
+				default:
+					color.Default.Fprintf(out, " - %s: waiting for rollout to start\n", r.resource)
+				}
+				for _, e := range events {
+					color.Yellow.Fprintf(out, "    %s\n", e)
+				}
+			}
+		}
+	}
+}
+
+// replicaCounts extracts the number of ready and total replicas from a rollout status.
+func replicaCounts(status string) (int32, int32, bool) {
+	match := replicasPattern.FindStringSubmatch(status)
+	if match == nil {
+		return 0, 0, false
+	}
+
+	ready, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	total, err := strconv.Atoi(match[2])
+	if err != nil || total == 0 {
+		return 0, 0, false
+	}
+	return int32(ready), int32(total), true
+}
+
+// pendingPodEvents returns the latest event of each pod of a resource that isn't ready,
+// e.g. to show that an image is being pulled or that a pod can't be scheduled.
+func pendingPodEvents(client kubernetes.Interface, r statusCheckResource) []string {
+	pods, err := podsForResource(client, r)
+	if err != nil {
+		logrus.Debugf("Unable to list pods for %s: %s", r, err)
+		return nil
+	}
+
+	var events []string
+	for _, pod := range pods {
+		if podReady(pod) {
+			continue
+		}
+
+		list, err := client.CoreV1().Events(pod.Namespace).List(metav1.ListOptions{
+			FieldSelector: fields.Set{
+				"involvedObject.kind": "Pod",
+				"involvedObject.name": pod.Name,
+			}.AsSelector().String(),
+		})
+		if err != nil {
+			logrus.Debugf("Unable to list events for pod %s: %s", pod.Name, err)
+			continue
+		}
+		if len(list.Items) == 0 {
+			continue
+		}
+
+		items := list.Items
+		sort.Slice(items, func(i, j int) bool {
+			return items[i].LastTimestamp.Before(&items[j].LastTimestamp)
+		})
+		latest := items[len(items)-1]
+		events = append(events, fmt.Sprintf("pod/%s: %s: %s", pod.Name, latest.Reason, latest.Message))
+	}
+	return events
+}
+
+func podReady(pod v1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestReplicaCounts(t *testing.T) {
+	tests := []struct {
+		description   string
+		status        string
+		expectedReady int32
+		expectedTotal int32
+		expectedOk    bool
+	}{
+		{
+			description:   "deployment",
+			status:        `Waiting for deployment "web" rollout to finish: 1 of 3 updated replicas are available...`,
+			expectedReady: 1,
+			expectedTotal: 3,
+			expectedOk:    true,
+		},
+		{
+			description:   "deployment update",
+			status:        `Waiting for deployment "web" rollout to finish: 2 out of 4 new replicas have been updated...`,
+			expectedReady: 2,
+			expectedTotal: 4,
+			expectedOk:    true,
+		},
+		{
+			description: "no replica count",
+			status:      "Waiting for 1 pods to be ready...",
+		},
+		{
+			description: "no replicas",
+			status:      `Waiting for daemon set "agent" rollout to finish: 0 of 0 updated pods are available...`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			ready, total, ok := replicaCounts(test.status)
+
+			testutil.CheckDeepEqual(t, []interface{}{test.expectedReady, test.expectedTotal, test.expectedOk}, []interface{}{ready, total, ok})
+		})
+	}
+}
+
+func TestReportProgress(t *testing.T) {
+	labels := map[string]string{"app": "web"}
+	client := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "test"},
+			Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: labels}},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "test", Labels: labels},
+			Status: v1.PodStatus{
+				Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionFalse}},
+			},
+		},
+		&v1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "web-1.pulling", Namespace: "test"},
+			InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: "web-1"},
+			Reason:         "Pulling",
+			Message:        `Pulling image "gcr.io/web"`,
+		},
+	)
+	event.InitializeState(&runcontext.RunContext{Cfg: &latest.Pipeline{}})
+	reset := testutil.Override(t, &progressPeriod, time.Millisecond)
+	defer reset()
+
+	web := statusCheckResource{kind: "Deployment", name: "web", namespace: "test"}
+	db := statusCheckResource{kind: "StatefulSet", name: "db", namespace: "test"}
+	progress := newStatusCheckProgress([]statusCheckResource{web, db})
+	progress.update(web, `Waiting for deployment "web" rollout to finish: 1 of 3 updated replicas are available...`)
+	progress.finish(db)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var out bytes.Buffer
+	reportProgress(ctx, &out, client, progress)
+
+	testutil.CheckContains(t, " - test:deployment/web: 1/3 replicas ready (33%)\n    pod/web-1: Pulling: Pulling image \"gcr.io/web\"\n", out.String())
+	if bytes.Contains(out.Bytes(), []byte("statefulset/db")) {
+		t.Errorf("finished resources shouldn't be reported: %s", out.String())
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/deploy/kubectl"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// applyTransforms applies the transformations configured in `deploy.transforms`,
+// in order, to the rendered manifests.
+func applyTransforms(ctx context.Context, transforms []latest.ManifestTransform, workingDir string, manifests kubectl.ManifestList) (kubectl.ManifestList, error) {
+	for i, t := range transforms {
+		var err error
+
+		if len(t.Patches) > 0 {
+			manifests, err = patchManifests(t, manifests)
+			if err != nil {
+				return nil, errors.Wrapf(err, "applying transform %d", i)
+			}
+		}
+
+		if t.Command != "" {
+			manifests, err = runTransformCommand(ctx, t.Command, workingDir, manifests)
+			if err != nil {
+				return nil, errors.Wrapf(err, "applying transform %d", i)
+			}
+		}
+	}
+
+	return manifests, nil
+}
+
+// patchManifests applies the transformation's patches to each manifest of a matching kind.
+func patchManifests(t latest.ManifestTransform, manifests kubectl.ManifestList) (kubectl.ManifestList, error) {
+	var patched kubectl.ManifestList
+
+	for _, manifest := range manifests {
+		var m struct {
+			Kind     string `yaml:"kind"`
+			Metadata struct {
+				Name string `yaml:"name"`
+			} `yaml:"metadata"`
+		}
+		if err := yaml.Unmarshal(manifest, &m); err != nil {
+			return nil, errors.Wrap(err, "reading manifest")
+		}
+
+		if m.Kind == "" || !matchesKind(t.Kinds, m.Kind) {
+			patched = append(patched, manifest)
+			continue
+		}
+
+		buf, err := schema.ApplyPatches(manifest, t.Patches)
+		if err != nil {
+			return nil, errors.Wrapf(err, "patching %s %s", m.Kind, m.Metadata.Name)
+		}
+		patched = append(patched, buf)
+	}
+
+	return patched, nil
+}
+
+func matchesKind(kinds []string, kind string) bool {
+	if len(kinds) == 0 {
+		return true
+	}
+
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// runTransformCommand pipes the manifests through a user-defined command.
+func runTransformCommand(ctx context.Context, command, workingDir string, manifests kubectl.ManifestList) (kubectl.ManifestList, error) {
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = workingDir
+	cmd.Stdin = manifests.Reader()
+
+	buf, err := util.RunCmdOut(cmd)
+	if err != nil {
+		return nil, errors.Wrapf(err, "running %q", command)
+	}
+
+	// The output may start with a document separator.
+	var all kubectl.ManifestList
+	all.Append(append([]byte("\n"), buf...))
+
+	var transformed kubectl.ManifestList
+	for _, manifest := range all {
+		if len(bytes.TrimSpace(manifest)) > 0 {
+			transformed = append(transformed, manifest)
+		}
+	}
+	return transformed, nil
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/deploy/kubectl"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestApplyTransforms(t *testing.T) {
+	manifests := kubectl.ManifestList{[]byte(namespaceYAML), []byte(deploymentWebYAML)}
+
+	tests := []struct {
+		description string
+		transforms  string
+		command     util.Command
+		expected    kubectl.ManifestList
+		shouldErr   bool
+	}{
+		{
+			description: "no transforms",
+			command:     testutil.NewFakeCmd(t),
+			expected:    manifests,
+		},
+		{
+			description: "patch all kinds",
+			transforms: `
+- patches:
+  - op: add
+    path: /metadata/annotations
+    value:
+      team: backend`,
+			command: testutil.NewFakeCmd(t),
+			expected: kubectl.ManifestList{[]byte(`apiVersion: v1
+kind: Namespace
+metadata:
+  annotations:
+    team: backend
+  name: leeroy
+`), []byte(`apiVersion: v1
+kind: Pod
+metadata:
+  annotations:
+    team: backend
+  name: leeroy-web
+spec:
+  containers:
+  - image: leeroy-web
+    name: leeroy-web
+`)},
+		},
+		{
+			description: "patch some kinds",
+			transforms: `
+- kinds: [Pod]
+  patches:
+  - path: /spec/containers/0/image
+    value: gcr.io/leeroy-web`,
+			command: testutil.NewFakeCmd(t),
+			expected: kubectl.ManifestList{[]byte(namespaceYAML), []byte(`apiVersion: v1
+kind: Pod
+metadata:
+  name: leeroy-web
+spec:
+  containers:
+  - image: gcr.io/leeroy-web
+    name: leeroy-web
+`)},
+		},
+		{
+			description: "invalid patch",
+			transforms: `
+- kinds: [Pod]
+  patches:
+  - path: /spec/unknown/0/image
+    value: gcr.io/leeroy-web`,
+			command:   testutil.NewFakeCmd(t),
+			shouldErr: true,
+		},
+		{
+			description: "command",
+			transforms: `
+- command: ./transform.sh`,
+			command:  testutil.FakeRunOut(t, "sh -c ./transform.sh", "---\n"+namespaceYAML+"\n---\n"),
+			expected: kubectl.ManifestList{[]byte(namespaceYAML)},
+		},
+		{
+			description: "command error",
+			transforms: `
+- command: ./transform.sh`,
+			command:   testutil.FakeRunOutErr(t, "sh -c ./transform.sh", "", errors.New("exit status 1")),
+			shouldErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			reset := testutil.Override(t, &util.DefaultExecCommand, test.command)
+			defer reset()
+
+			var transforms []latest.ManifestTransform
+			err := yaml.Unmarshal([]byte(test.transforms), &transforms)
+			testutil.CheckError(t, false, err)
+
+			transformed, err := applyTransforms(context.Background(), transforms, "", manifests)
+
+			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.expected, transformed)
+		})
+	}
+}
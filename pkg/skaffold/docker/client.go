@@ -29,6 +29,7 @@ import (
 	"strings"
 	"sync"
 
+	configutil "github.com/GoogleContainerTools/skaffold/cmd/skaffold/app/cmd/config"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
 	kubectx "github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes/context"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
@@ -44,6 +45,9 @@ var (
 	dockerAPIClientOnce sync.Once
 	dockerAPIClient     LocalDaemon
 	dockerAPIClientErr  error
+
+	// For testing
+	minikubeDockerEnv = configutil.GetMinikubeDockerEnv
 )
 
 // NewAPIClient guesses the docker client to use based on current kubernetes context.
@@ -66,11 +70,29 @@ func NewAPIClient(forceRemove bool, insecureRegistries map[string]bool) (LocalDa
 // newAPIClient guesses the docker client to use based on current kubernetes context.
 func newAPIClient(kubeContext string) ([]string, client.CommonAPIClient, error) {
 	if kubeContext == constants.DefaultMinikubeContext {
-		return newMinikubeAPIClient()
+		useDockerEnv, err := minikubeDockerEnv()
+		if err != nil {
+			logrus.Debugf("unable to read minikube-docker-env from global config: %s", err)
+		}
+		if useDockerEnv {
+			return newMinikubeAPIClient()
+		}
+		logrus.Debugln("Using local docker daemon, images will be loaded into minikube")
 	}
 	return newEnvAPIClient()
 }
 
+// UsesMinikubeDaemon tells if images are built by the docker daemon of minikube,
+// configured with `minikube docker-env`.
+func UsesMinikubeDaemon(localDocker LocalDaemon) bool {
+	for _, env := range localDocker.ExtraEnv() {
+		if strings.HasPrefix(env, "DOCKER_HOST=") {
+			return true
+		}
+	}
+	return false
+}
+
 // newEnvAPIClient returns a docker client based on the environment variables set.
 // It will "negotiate" the highest possible API version supported by both the client
 // and the server if there is a mismatch.
@@ -89,7 +111,7 @@ func newEnvAPIClient() ([]string, client.CommonAPIClient, error) {
 func newMinikubeAPIClient() ([]string, client.CommonAPIClient, error) {
 	env, err := getMinikubeDockerEnv()
 	if err != nil {
-		logrus.Warnf("Could not get minikube docker env, falling back to local docker daemon: %s", err)
+		logrus.Warnf("Could not get minikube docker env, falling back to local docker daemon and `minikube image load`: %s", err)
 		return newEnvAPIClient()
 	}
 
@@ -130,3 +130,19 @@ DOCKER_API_VERSION=1.23`,
 		})
 	}
 }
+
+func TestNewAPIClientWithoutMinikubeDockerEnv(t *testing.T) {
+	reset := testutil.Override(t, &minikubeDockerEnv, func() (bool, error) { return false, nil })
+	defer reset()
+	resetCmd := testutil.Override(t, &util.DefaultExecCommand, testutil.NewFakeCmd(t))
+	defer resetCmd()
+
+	env, _, err := newAPIClient("minikube")
+
+	testutil.CheckErrorAndDeepEqual(t, false, err, []string(nil), env)
+}
+
+func TestUsesMinikubeDaemon(t *testing.T) {
+	testutil.CheckDeepEqual(t, true, UsesMinikubeDaemon(NewLocalDaemon(nil, []string{"DOCKER_HOST=tcp://192.168.99.100:2376"}, false, nil)))
+	testutil.CheckDeepEqual(t, false, UsesMinikubeDaemon(NewLocalDaemon(nil, nil, false, nil)))
+}
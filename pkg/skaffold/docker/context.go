@@ -27,7 +27,9 @@ import (
 )
 
 func CreateDockerTarContext(ctx context.Context, w io.Writer, workspace string, a *latest.DockerArtifact, insecureRegistries map[string]bool) error {
-	paths, err := GetDependencies(ctx, workspace, a.DockerfilePath, a.BuildArgs, insecureRegistries)
+	// The legacy builder builds all the stages before the target, so the context
+	// has to include the files of every stage.
+	paths, err := GetDependencies(ctx, workspace, a.DockerfilePath, a.BuildArgs, "", a.DockerignorePath, insecureRegistries)
 	if err != nil {
 		return errors.Wrap(err, "getting relative tar paths")
 	}
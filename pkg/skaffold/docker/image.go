@@ -24,6 +24,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/docker/docker/api/types"
@@ -43,10 +44,11 @@ type LocalDaemon interface {
 	ExtraEnv() []string
 	ServerVersion(ctx context.Context) (types.Version, error)
 	ConfigFile(ctx context.Context, image string) (*v1.ConfigFile, error)
-	Build(ctx context.Context, out io.Writer, workspace string, a *latest.DockerArtifact, ref string) (string, error)
+	Build(ctx context.Context, out io.Writer, workspace string, a *latest.DockerArtifact, ref string, labels map[string]string) (string, error)
 	Push(ctx context.Context, out io.Writer, ref string) (string, error)
 	Pull(ctx context.Context, out io.Writer, ref string) error
 	Load(ctx context.Context, out io.Writer, input io.Reader, ref string) (string, error)
+	Normalize(ctx context.Context, out io.Writer, ref string, t time.Time) (string, error)
 	Tag(ctx context.Context, image, ref string) error
 	ImageID(ctx context.Context, ref string) (string, error)
 	ImageInspectWithRaw(ctx context.Context, image string) (types.ImageInspect, []byte, error)
@@ -132,7 +134,7 @@ func (l *localDaemon) ConfigFile(ctx context.Context, image string) (*v1.ConfigF
 }
 
 // Build performs a docker build and returns the imageID.
-func (l *localDaemon) Build(ctx context.Context, out io.Writer, workspace string, a *latest.DockerArtifact, ref string) (string, error) {
+func (l *localDaemon) Build(ctx context.Context, out io.Writer, workspace string, a *latest.DockerArtifact, ref string, labels map[string]string) (string, error) {
 	logrus.Debugf("Running docker build: context: %s, dockerfile: %s", workspace, a.DockerfilePath)
 
 	// Like `docker build`, we ignore the errors
@@ -162,6 +164,8 @@ func (l *localDaemon) Build(ctx context.Context, out io.Writer, workspace string
 		ForceRemove: l.forceRemove,
 		NetworkMode: a.NetworkMode,
 		NoCache:     a.NoCache,
+		Labels:      labels,
+		Platform:    a.Platform,
 	})
 	if err != nil {
 		return "", errors.Wrap(err, "docker build")
@@ -332,6 +336,22 @@ func (l *localDaemon) ImageRemove(ctx context.Context, image string, opts types.
 	return l.apiClient.ImageRemove(ctx, image, opts)
 }
 
+// GetLabelArgs gives the label flags for docker build, sorted by label.
+// Kaniko accepts the same flags.
+func GetLabelArgs(labels map[string]string) []string {
+	var keys []string
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var args []string
+	for _, k := range keys {
+		args = append(args, "--label", fmt.Sprintf("%s=%s", k, labels[k]))
+	}
+	return args
+}
+
 // GetBuildArgs gives the build args flags for docker build.
 func GetBuildArgs(a *latest.DockerArtifact) ([]string, error) {
 	var args []string
@@ -373,5 +393,9 @@ func GetBuildArgs(a *latest.DockerArtifact) ([]string, error) {
 		args = append(args, "--no-cache")
 	}
 
+	if a.Platform != "" {
+		args = append(args, "--platform", a.Platform)
+	}
+
 	return args, nil
 }
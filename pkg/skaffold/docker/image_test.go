@@ -123,7 +123,7 @@ func TestRunBuild(t *testing.T) {
 				apiClient: &test.api,
 			}
 
-			_, err := localDocker.Build(context.Background(), ioutil.Discard, ".", &latest.DockerArtifact{}, "finalimage")
+			_, err := localDocker.Build(context.Background(), ioutil.Discard, ".", &latest.DockerArtifact{}, "finalimage", nil)
 
 			testutil.CheckError(t, test.shouldErr, err)
 		})
@@ -234,6 +234,13 @@ func TestGetBuildArgs(t *testing.T) {
 			},
 			want: []string{"--no-cache"},
 		},
+		{
+			description: "platform",
+			artifact: &latest.DockerArtifact{
+				Platform: "windows/amd64",
+			},
+			want: []string{"--platform", "windows/amd64"},
+		},
 		{
 			description: "all",
 			artifact: &latest.DockerArtifact{
@@ -266,6 +273,12 @@ func TestGetBuildArgs(t *testing.T) {
 	}
 }
 
+func TestGetLabelArgs(t *testing.T) {
+	args := GetLabelArgs(map[string]string{"team": "backend", "revision": "a1b2c3"})
+
+	testutil.CheckDeepEqual(t, []string{"--label", "revision=a1b2c3", "--label", "team=backend"}, args)
+}
+
 func TestImageExists(t *testing.T) {
 	tests := []struct {
 		name            string
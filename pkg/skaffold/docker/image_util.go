@@ -20,10 +20,14 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sort"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/config"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 var (
@@ -56,3 +60,77 @@ func Prune(ctx context.Context, out io.Writer, images []string, client LocalDaem
 	}
 	return nil
 }
+
+// PruneArtifactImages removes the images built by Skaffold for an artifact, except for
+// the `keep` most recent ones and the protected ones. Images that have tags in other
+// repositories than the given ones are shared, and never removed.
+func PruneArtifactImages(ctx context.Context, out io.Writer, client LocalDaemon, imageName string, repositories map[string]bool, keep int, protected map[string]bool) error {
+	images, err := client.ImageList(ctx, types.ImageListOptions{
+		Filters: filters.NewArgs(filters.Arg("label", fmt.Sprintf("%s=%s", constants.Labels.Artifact, imageName))),
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing images")
+	}
+
+	sort.SliceStable(images, func(i, j int) bool {
+		return images[i].Created > images[j].Created
+	})
+
+	for i, image := range images {
+		if i < keep || protected[image.ID] {
+			continue
+		}
+
+		tags := imageTags(image)
+		if shared := sharedTag(tags, repositories); shared != "" {
+			logrus.Debugf("not pruning image %s, tagged %s", image.ID, shared)
+			continue
+		}
+
+		// Removing each tag, rather than the image ID, doesn't require forcing
+		// the removal. Images in use by containers are left alone.
+		if len(tags) == 0 {
+			tags = []string{image.ID}
+		}
+		for _, tag := range tags {
+			resp, err := client.ImageRemove(ctx, tag, types.ImageRemoveOptions{
+				PruneChildren: !opts.NoPruneChildren,
+			})
+			if err != nil {
+				logrus.Warnf("unable to prune image %s: %s", tag, err)
+				break
+			}
+			for _, r := range resp {
+				if r.Deleted != "" {
+					fmt.Fprintf(out, "deleted image %s\n", r.Deleted)
+				}
+				if r.Untagged != "" {
+					fmt.Fprintf(out, "untagged image %s\n", r.Untagged)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func imageTags(image types.ImageSummary) []string {
+	var tags []string
+	for _, tag := range image.RepoTags {
+		if tag != "<none>:<none>" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// sharedTag returns the first tag that is not in one of the repositories.
+func sharedTag(tags []string, repositories map[string]bool) string {
+	for _, tag := range tags {
+		parsed, err := ParseReference(tag)
+		if err != nil || !repositories[parsed.BaseName] {
+			return tag
+		}
+	}
+	return ""
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package docker
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	"github.com/docker/docker/api/types"
+)
+
+func TestPruneArtifactImages(t *testing.T) {
+	images := []types.ImageSummary{
+		{ID: "sha256:1", Created: 1, RepoTags: []string{"gcr.io/project/app:v1", "app:1"}},
+		{ID: "sha256:3", Created: 3, RepoTags: []string{"gcr.io/project/app:v3", "app:3"}},
+		{ID: "sha256:2", Created: 2, RepoTags: []string{"gcr.io/project/app:v2", "app:2", "app:latest"}},
+		{ID: "sha256:4", Created: 4, RepoTags: []string{"gcr.io/project/app:v4", "app:4"}},
+		{ID: "sha256:0", Created: 0, RepoTags: []string{"<none>:<none>"}},
+		{ID: "sha256:5", Created: 5, RepoTags: []string{"gcr.io/project/app:v5", "other:shared"}},
+	}
+	repositories := map[string]bool{"app": true, "gcr.io/project/app": true}
+
+	var tests = []struct {
+		description string
+		keep        int
+		protected   map[string]bool
+		expected    []string
+	}{
+		{
+			description: "remove all but shared",
+			expected:    []string{"gcr.io/project/app:v4", "app:4", "gcr.io/project/app:v3", "app:3", "gcr.io/project/app:v2", "app:2", "app:latest", "gcr.io/project/app:v1", "app:1", "sha256:0"},
+		},
+		{
+			description: "keep most recent",
+			keep:        3,
+			expected:    []string{"gcr.io/project/app:v2", "app:2", "app:latest", "gcr.io/project/app:v1", "app:1", "sha256:0"},
+		},
+		{
+			description: "protected images",
+			keep:        1,
+			protected:   map[string]bool{"sha256:4": true, "sha256:1": true},
+			expected:    []string{"gcr.io/project/app:v3", "app:3", "gcr.io/project/app:v2", "app:2", "app:latest", "sha256:0"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			api := &testutil.FakeAPIClient{
+				ImageSummaries: append([]types.ImageSummary{}, images...),
+			}
+			localDocker := NewLocalDaemon(api, nil, false, nil)
+
+			err := PruneArtifactImages(context.Background(), ioutil.Discard, localDocker, "app", repositories, test.keep, test.protected)
+
+			testutil.CheckErrorAndDeepEqual(t, false, err, test.expected, api.Removed)
+		})
+	}
+}
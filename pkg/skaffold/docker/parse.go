@@ -17,18 +17,20 @@ limitations under the License.
 package docker
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
 	"github.com/docker/docker/builder/dockerignore"
-	"github.com/docker/docker/pkg/fileutils"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
-	"github.com/karrick/godirwalk"
 	"github.com/moby/buildkit/frontend/dockerfile/command"
 	"github.com/moby/buildkit/frontend/dockerfile/parser"
 	"github.com/moby/buildkit/frontend/dockerfile/shell"
@@ -44,6 +46,50 @@ type from struct {
 // RetrieveImage is overridden for unit testing
 var RetrieveImage = retrieveImage
 
+var (
+	// heredocInstruction matches the instructions that can use heredocs.
+	heredocInstruction = regexp.MustCompile(`(?i)^\s*(RUN|COPY|ADD)\s`)
+
+	// heredocMarker matches `<<EOF`, `<<-EOF`, `<<"EOF"` and `<<'EOF'`.
+	heredocMarker = regexp.MustCompile(`<<(-?)["']?([a-zA-Z_][a-zA-Z0-9_]*)["']?`)
+)
+
+type heredoc struct {
+	word      string
+	stripTabs bool
+}
+
+// removeHeredocs blanks out the content of the heredocs, which the Dockerfile
+// parser doesn't support. The instructions, with their `<<EOF` markers, and the
+// line numbers are kept.
+func removeHeredocs(dockerfile []byte) []byte {
+	lines := strings.Split(string(dockerfile), "\n")
+
+	var pending []heredoc
+	for i, line := range lines {
+		if len(pending) > 0 {
+			word := strings.TrimRight(line, "\r")
+			if pending[0].stripTabs {
+				word = strings.TrimLeft(word, "\t")
+			}
+			if word == pending[0].word {
+				pending = pending[1:]
+			}
+			lines[i] = ""
+			continue
+		}
+
+		if !heredocInstruction.MatchString(line) {
+			continue
+		}
+		for _, m := range heredocMarker.FindAllStringSubmatch(line, -1) {
+			pending = append(pending, heredoc{word: m[2], stripTabs: m[1] == "-"})
+		}
+	}
+
+	return []byte(strings.Join(lines, "\n"))
+}
+
 func evaluateBuildArgsValue(nameTemplate string) (string, error) {
 	tmpl, err := util.ParseEnvTemplate(nameTemplate)
 	if err != nil {
@@ -170,6 +216,15 @@ func copiedFiles(nodes []*parser.Node) ([][]string, error) {
 			if len(files) > 0 {
 				copied = append(copied, files)
 			}
+		case command.Run:
+			sources, err := bindMountSources(node, envs)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, src := range sources {
+				copied = append(copied, []string{src})
+			}
 		case command.Env:
 			// one env command may define multiple variables
 			for node := node.Next; node != nil && node.Next != nil; node = node.Next.Next {
@@ -181,14 +236,130 @@ func copiedFiles(nodes []*parser.Node) ([][]string, error) {
 	return copied, nil
 }
 
-func readDockerfile(workspace, absDockerfilePath string, buildArgs map[string]*string, insecureRegistries map[string]bool) ([]string, error) {
-	f, err := os.Open(absDockerfilePath)
+// stage is a `FROM` instruction and the instructions that follow it.
+type stage struct {
+	name  string
+	nodes []*parser.Node
+}
+
+// pruneStages keeps the instructions of the target stage and of the stages
+// it depends on, through `FROM`, `COPY --from` or `RUN --mount=from=`, since
+// the other stages aren't built. Instructions before the first `FROM` are kept.
+func pruneStages(nodes []*parser.Node, target string) ([]*parser.Node, error) {
+	var (
+		global []*parser.Node
+		stages []*stage
+	)
+	for _, node := range nodes {
+		if node.Value == command.From {
+			stages = append(stages, &stage{name: fromInstruction(node).as})
+		}
+		if len(stages) == 0 {
+			global = append(global, node)
+			continue
+		}
+		current := stages[len(stages)-1]
+		current.nodes = append(current.nodes, node)
+	}
+
+	// Stages can be referenced by name or by index. Names are case insensitive.
+	stageIndexes := map[string]int{}
+	for i, s := range stages {
+		stageIndexes[strconv.Itoa(i)] = i
+		if s.name != "" {
+			stageIndexes[s.name] = i
+		}
+	}
+
+	targetIndex, found := stageIndexes[strings.ToLower(target)]
+	if !found {
+		return nil, fmt.Errorf("target stage %s could not be found", target)
+	}
+
+	needed := map[int]bool{}
+	var visit func(i int)
+	visit = func(i int) {
+		if needed[i] {
+			return
+		}
+		needed[i] = true
+
+		for _, dep := range stageDependencies(stages[i].nodes) {
+			if j, found := stageIndexes[strings.ToLower(dep)]; found {
+				visit(j)
+			}
+		}
+	}
+	visit(targetIndex)
+
+	pruned := global
+	for i, s := range stages {
+		if needed[i] {
+			pruned = append(pruned, s.nodes...)
+		}
+	}
+	return pruned, nil
+}
+
+// stageDependencies lists the stages or images a stage is built from or copies files from.
+func stageDependencies(nodes []*parser.Node) []string {
+	var deps []string
+
+	for _, node := range nodes {
+		switch node.Value {
+		case command.From:
+			deps = append(deps, fromInstruction(node).image)
+		case command.Add, command.Copy:
+			for _, f := range node.Flags {
+				if strings.HasPrefix(f, "--from=") {
+					deps = append(deps, strings.TrimPrefix(f, "--from="))
+				}
+			}
+		case command.Run:
+			for _, f := range node.Flags {
+				if !strings.HasPrefix(f, "--mount=") {
+					continue
+				}
+				for _, field := range strings.Split(strings.TrimPrefix(f, "--mount="), ",") {
+					if strings.HasPrefix(field, "from=") {
+						deps = append(deps, strings.TrimPrefix(field, "from="))
+					}
+				}
+			}
+		}
+	}
+
+	return deps
+}
+
+func readDockerfile(workspace, absDockerfilePath string, buildArgs map[string]*string, target string, insecureRegistries map[string]bool) ([]string, error) {
+	dockerfileLines, err := parseDockerfile(absDockerfilePath, buildArgs, target)
+	if err != nil {
+		return nil, err
+	}
+
+	instructions, err := onbuildInstructions(dockerfileLines, insecureRegistries)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing ONBUILD instructions")
+	}
+
+	copied, err := copiedFiles(append(instructions, dockerfileLines...))
+	if err != nil {
+		return nil, errors.Wrap(err, "listing copied files")
+	}
+
+	return expandPaths(workspace, copied)
+}
+
+// parseDockerfile returns the instructions of a Dockerfile, with the build arguments
+// expanded and, if a target is given, only the stages needed to build that target.
+func parseDockerfile(absDockerfilePath string, buildArgs map[string]*string, target string) ([]*parser.Node, error) {
+	content, err := ioutil.ReadFile(absDockerfilePath)
 	if err != nil {
 		return nil, errors.Wrapf(err, "opening dockerfile: %s", absDockerfilePath)
 	}
-	defer f.Close()
 
-	res, err := parser.Parse(f)
+	res, err := parser.Parse(bytes.NewReader(removeHeredocs(content)))
 	if err != nil {
 		return nil, errors.Wrap(err, "parsing dockerfile")
 	}
@@ -200,17 +371,43 @@ func readDockerfile(workspace, absDockerfilePath string, buildArgs map[string]*s
 		return nil, errors.Wrap(err, "putting build arguments")
 	}
 
-	instructions, err := onbuildInstructions(dockerfileLines, insecureRegistries)
+	if target != "" {
+		dockerfileLines, err = pruneStages(dockerfileLines, target)
+		if err != nil {
+			return nil, errors.Wrap(err, "pruning stages")
+		}
+	}
+
+	return dockerfileLines, nil
+}
+
+// BaseImages lists the images a Dockerfile is based on, in order and without duplicates.
+// Stages of the same Dockerfile and `scratch` are left out.
+// If a target is given, only the stages needed to build that target are considered.
+func BaseImages(workspace, dockerfilePath string, buildArgs map[string]*string, target string) ([]string, error) {
+	absDockerfilePath, err := NormalizeDockerfilePath(workspace, dockerfilePath)
 	if err != nil {
-		return nil, errors.Wrap(err, "listing ONBUILD instructions")
+		return nil, errors.Wrap(err, "normalizing dockerfile path")
 	}
 
-	copied, err := copiedFiles(append(instructions, dockerfileLines...))
+	dockerfileLines, err := parseDockerfile(absDockerfilePath, buildArgs, target)
 	if err != nil {
-		return nil, errors.Wrap(err, "listing copied files")
+		return nil, err
 	}
 
-	return expandPaths(workspace, copied)
+	var images []string
+	known := map[string]bool{"scratch": true}
+	for _, from := range fromInstructions(dockerfileLines) {
+		if !known[strings.ToLower(from.image)] {
+			images = append(images, from.image)
+			known[strings.ToLower(from.image)] = true
+		}
+		if from.as != "" {
+			known[from.as] = true
+		}
+	}
+
+	return images, nil
 }
 
 func expandPaths(workspace string, copied [][]string) ([]string, error) {
@@ -271,26 +468,44 @@ func NormalizeDockerfilePath(context, dockerfile string) (string, error) {
 	return filepath.Abs(dockerfile)
 }
 
+// dockerignoreFile returns the path of the ignore file that applies to a Dockerfile:
+// the configured one, or `<dockerfile>.dockerignore` if it exists, or `.dockerignore`
+// at the root of the workspace.
+func dockerignoreFile(workspace, absDockerfilePath, dockerignorePath string) (string, bool) {
+	if dockerignorePath != "" {
+		if filepath.IsAbs(dockerignorePath) {
+			return dockerignorePath, true
+		}
+		return filepath.Join(workspace, dockerignorePath), true
+	}
+
+	if perDockerfile := absDockerfilePath + ".dockerignore"; fileExists(perDockerfile) {
+		return perDockerfile, false
+	}
+	return filepath.Join(workspace, ".dockerignore"), false
+}
+
 // GetDependencies finds the sources dependencies for the given docker artifact.
 // All paths are relative to the workspace.
-func GetDependencies(ctx context.Context, workspace string, dockerfilePath string, buildArgs map[string]*string, insecureRegistries map[string]bool) ([]string, error) {
+// If a target is given, only the stages needed to build that target are considered.
+func GetDependencies(ctx context.Context, workspace string, dockerfilePath string, buildArgs map[string]*string, target, dockerignorePath string, insecureRegistries map[string]bool) ([]string, error) {
 	absDockerfilePath, err := NormalizeDockerfilePath(workspace, dockerfilePath)
 	if err != nil {
 		return nil, errors.Wrap(err, "normalizing dockerfile path")
 	}
 
-	deps, err := readDockerfile(workspace, absDockerfilePath, buildArgs, insecureRegistries)
+	deps, err := readDockerfile(workspace, absDockerfilePath, buildArgs, target, insecureRegistries)
 	if err != nil {
 		return nil, err
 	}
 
 	// Read patterns to ignore
 	var excludes []string
-	dockerignorePath := filepath.Join(workspace, ".dockerignore")
-	if _, err := os.Stat(dockerignorePath); !os.IsNotExist(err) {
+	dockerignorePath, explicit := dockerignoreFile(workspace, absDockerfilePath, dockerignorePath)
+	if explicit || fileExists(dockerignorePath) {
 		r, err := os.Open(dockerignorePath)
 		if err != nil {
-			return nil, err
+			return nil, errors.Wrap(err, "reading dockerignore file")
 		}
 		defer r.Close()
 
@@ -312,8 +527,11 @@ func GetDependencies(ctx context.Context, workspace string, dockerfilePath strin
 		files[absDockerfilePath] = true
 	}
 
-	// Ignore .dockerignore
+	// Ignore .dockerignore and the ignore file that was actually used
 	delete(files, ".dockerignore")
+	if rel, err := filepath.Rel(workspace, dockerignorePath); err == nil {
+		delete(files, rel)
+	}
 
 	var dependencies []string
 	for file := range files {
@@ -324,67 +542,20 @@ func GetDependencies(ctx context.Context, workspace string, dockerfilePath strin
 	return dependencies, nil
 }
 
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return !os.IsNotExist(err)
+}
+
+// WalkWorkspace lists the files of a workspace found in the given dependencies,
+// except for those matching the exclude patterns.
 func WalkWorkspace(workspace string, excludes, deps []string) (map[string]bool, error) {
-	pExclude, err := fileutils.NewPatternMatcher(excludes)
+	filter, err := util.NewPathFilter(excludes, false, util.Symlinks)
 	if err != nil {
-		return nil, errors.Wrap(err, "invalid exclude patterns")
+		return nil, err
 	}
 
-	// Walk the workspace
-	files := make(map[string]bool)
-	for _, dep := range deps {
-		dep = filepath.Clean(dep)
-		absDep := filepath.Join(workspace, dep)
-
-		fi, err := os.Stat(absDep)
-		if err != nil {
-			return nil, errors.Wrapf(err, "stating file %s", absDep)
-		}
-
-		switch mode := fi.Mode(); {
-		case mode.IsDir():
-			if err := godirwalk.Walk(absDep, &godirwalk.Options{
-				Unsorted: true,
-				Callback: func(fpath string, info *godirwalk.Dirent) error {
-					if fpath == absDep {
-						return nil
-					}
-
-					relPath, err := filepath.Rel(workspace, fpath)
-					if err != nil {
-						return err
-					}
-
-					ignored, err := pExclude.Matches(relPath)
-					if err != nil {
-						return err
-					}
-
-					if info.IsDir() {
-						if ignored {
-							return filepath.SkipDir
-						}
-					} else if !ignored {
-						files[relPath] = true
-					}
-
-					return nil
-				},
-			}); err != nil {
-				return nil, errors.Wrapf(err, "walking folder %s", absDep)
-			}
-		case mode.IsRegular():
-			ignored, err := pExclude.Matches(dep)
-			if err != nil {
-				return nil, err
-			}
-
-			if !ignored {
-				files[dep] = true
-			}
-		}
-	}
-	return files, nil
+	return filter.Walk(workspace, deps)
 }
 
 func retrieveImage(image string, insecureRegistries map[string]bool) (*v1.ConfigFile, error) {
@@ -414,10 +585,13 @@ func processCopy(value *parser.Node, envs map[string]string) ([]string, error) {
 		if hasMultiStageFlag(value.Flags) {
 			return nil, nil
 		}
-		if !strings.HasPrefix(src, "http://") && !strings.HasPrefix(src, "https://") {
-			copied = append(copied, src)
-		} else {
+		switch {
+		case strings.HasPrefix(src, "http://"), strings.HasPrefix(src, "https://"), strings.HasPrefix(src, "git@"):
 			logrus.Debugf("Skipping watch on remote dependency %s", src)
+		case strings.HasPrefix(src, "<<"):
+			// Inline file, from a heredoc
+		default:
+			copied = append(copied, src)
 		}
 
 		value = value.Next
@@ -434,6 +608,48 @@ func processShellWord(lex *shell.Lex, word string, envs map[string]string) (stri
 	return lex.ProcessWord(word, envSlice)
 }
 
+// bindMountSources lists the paths of the build context that are mounted by
+// `RUN --mount=type=bind` instructions. Mounts from other stages or images,
+// caches, secrets... are not source dependencies.
+func bindMountSources(node *parser.Node, envs map[string]string) ([]string, error) {
+	var sources []string
+
+	slex := shell.NewLex('\\')
+	for _, flag := range node.Flags {
+		if !strings.HasPrefix(flag, "--mount=") {
+			continue
+		}
+
+		// Bind is the default mount type, and the root of the context the default source.
+		mountType, source, from := "bind", ".", ""
+		for _, field := range strings.Split(strings.TrimPrefix(flag, "--mount="), ",") {
+			kv := strings.SplitN(field, "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			switch strings.ToLower(kv[0]) {
+			case "type":
+				mountType = kv[1]
+			case "source", "src":
+				source = kv[1]
+			case "from":
+				from = kv[1]
+			}
+		}
+		if mountType != "bind" || from != "" {
+			continue
+		}
+
+		src, err := processShellWord(slex, source, envs)
+		if err != nil {
+			return nil, errors.Wrap(err, "processing word")
+		}
+		sources = append(sources, src)
+	}
+
+	return sources, nil
+}
+
 func hasMultiStageFlag(flags []string) bool {
 	for _, f := range flags {
 		if strings.HasPrefix(f, "--from=") {
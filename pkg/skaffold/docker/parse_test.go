@@ -195,6 +195,59 @@ FROM jboss/wildfly:14.0.1.Final
 ADD ./file /etc/file
 `
 
+const heredocs = `
+FROM ubuntu:14.04
+RUN <<EOF
+COPY worker.go .
+apt-get update
+EOF
+COPY <<-"CONF" /etc/app.conf
+	COPY missing.go .
+	CONF
+COPY server.go .
+`
+
+const multipleHeredocs = `
+FROM ubuntu:14.04
+RUN <<FIRST bash && <<SECOND bash
+echo first
+FIRST
+COPY missing.go .
+SECOND
+ADD file /etc/file
+`
+
+const bindMounts = `
+FROM golang:1.9.2
+RUN --mount=type=bind,source=server.go,target=/src/server.go \
+    --mount=type=cache,target=/root/.cache \
+    --mount=type=secret,id=token \
+    --mount=type=bind,from=builder,source=/out,target=/out \
+    --mount=src=docker/nginx.conf,target=/etc/nginx.conf go build
+`
+
+const addChecksum = `
+FROM ubuntu:14.04
+ADD --checksum=sha256:24454f830cdb571e2c4ad15481119c43b3cafd48dd869a9b2945d1036d1dc68d https://example.com/archive.tar.gz /
+ADD git@github.com:moby/buildkit.git /buildkit
+COPY --link --chmod=755 worker.go /
+`
+
+const multiStageTargets = `
+FROM golang:1.9.2 as builder
+COPY server.go .
+FROM builder as test
+COPY worker.go .
+FROM ubuntu:14.04 as assets
+COPY docker/nginx.conf /tmp
+FROM busybox as dev
+COPY --from=assets /tmp/nginx.conf /etc/nginx.conf
+RUN --mount=from=0,source=/go,target=/go true
+COPY file .
+FROM dev as prod
+COPY test.conf .
+`
+
 type fakeImageFetcher struct {
 	fetched []string
 }
@@ -224,7 +277,10 @@ func TestGetDependencies(t *testing.T) {
 		dockerfile  string
 		workspace   string
 		ignore      string
+		ignoreFile  string
+		ignorePath  string
 		buildArgs   map[string]*string
+		target      string
 		env         []string
 
 		expected  []string
@@ -322,6 +378,33 @@ func TestGetDependencies(t *testing.T) {
 			expected:    []string{".dot", "Dockerfile", "file", "server.go", "test.conf", "worker.go"},
 			fetched:     []string{"nginx"},
 		},
+		{
+			description: "dockerignore next to the dockerfile",
+			dockerfile:  copyDirectory,
+			ignore:      "bar\ndocker/*",
+			ignoreFile:  "Dockerfile.dockerignore",
+			workspace:   ".",
+			expected:    []string{".dot", "Dockerfile", "file", "server.go", "test.conf", "worker.go"},
+			fetched:     []string{"nginx"},
+		},
+		{
+			description: "configured dockerignore",
+			dockerfile:  copyDirectory,
+			ignore:      "bar\ndocker/*",
+			ignoreFile:  "ignore/web.dockerignore",
+			ignorePath:  "ignore/web.dockerignore",
+			workspace:   ".",
+			expected:    []string{".dot", "Dockerfile", "file", "server.go", "test.conf", "worker.go"},
+			fetched:     []string{"nginx"},
+		},
+		{
+			description: "missing configured dockerignore",
+			dockerfile:  copyDirectory,
+			ignorePath:  "missing.dockerignore",
+			workspace:   ".",
+			shouldErr:   true,
+			fetched:     []string{"nginx"},
+		},
 		{
 			description: "dockerignore dockerfile",
 			dockerfile:  copyServerGo,
@@ -496,6 +579,72 @@ func TestGetDependencies(t *testing.T) {
 			expected:    []string{"Dockerfile", "server.go"},
 			fetched:     []string{"ubuntu:14.04"},
 		},
+		{
+			description: "heredocs",
+			dockerfile:  heredocs,
+			workspace:   ".",
+			expected:    []string{"Dockerfile", "server.go"},
+			fetched:     []string{"ubuntu:14.04"},
+		},
+		{
+			description: "multiple heredocs",
+			dockerfile:  multipleHeredocs,
+			workspace:   ".",
+			expected:    []string{"Dockerfile", "file"},
+			fetched:     []string{"ubuntu:14.04"},
+		},
+		{
+			description: "bind mounts",
+			dockerfile:  bindMounts,
+			workspace:   ".",
+			expected:    []string{"Dockerfile", "docker/nginx.conf", "server.go"},
+			fetched:     []string{"golang:1.9.2"},
+		},
+		{
+			description: "add checksum, add git, copy link",
+			dockerfile:  addChecksum,
+			workspace:   ".",
+			expected:    []string{"Dockerfile", "worker.go"},
+			fetched:     []string{"ubuntu:14.04"},
+		},
+		{
+			description: "target the first stage",
+			dockerfile:  multiStageTargets,
+			workspace:   ".",
+			target:      "BUILDER",
+			expected:    []string{"Dockerfile", "server.go"},
+			fetched:     []string{"golang:1.9.2"},
+		},
+		{
+			description: "target an intermediate stage",
+			dockerfile:  multiStageTargets,
+			workspace:   ".",
+			target:      "dev",
+			expected:    []string{"Dockerfile", "docker/nginx.conf", "file", "server.go"},
+			fetched:     []string{"golang:1.9.2", "ubuntu:14.04", "busybox"},
+		},
+		{
+			description: "target the last stage",
+			dockerfile:  multiStageTargets,
+			workspace:   ".",
+			target:      "prod",
+			expected:    []string{"Dockerfile", "docker/nginx.conf", "file", "server.go", "test.conf"},
+			fetched:     []string{"golang:1.9.2", "ubuntu:14.04", "busybox"},
+		},
+		{
+			description: "no target",
+			dockerfile:  multiStageTargets,
+			workspace:   ".",
+			expected:    []string{"Dockerfile", "docker/nginx.conf", "file", "server.go", "test.conf", "worker.go"},
+			fetched:     []string{"golang:1.9.2", "ubuntu:14.04", "busybox"},
+		},
+		{
+			description: "unknown target",
+			dockerfile:  multiStageTargets,
+			workspace:   ".",
+			target:      "unknown",
+			shouldErr:   true,
+		},
 		{
 			description: "invalid go template as build arg",
 			dockerfile:  copyServerGoBuildArg,
@@ -526,14 +675,70 @@ func TestGetDependencies(t *testing.T) {
 			}
 
 			if test.ignore != "" {
-				tmpDir.Write(test.workspace+"/.dockerignore", test.ignore)
+				ignoreFile := ".dockerignore"
+				if test.ignoreFile != "" {
+					ignoreFile = test.ignoreFile
+				}
+				tmpDir.Write(test.workspace+"/"+ignoreFile, test.ignore)
 			}
 
 			workspace := tmpDir.Path(test.workspace)
-			deps, err := GetDependencies(context.Background(), workspace, "Dockerfile", test.buildArgs, map[string]bool{})
+			deps, err := GetDependencies(context.Background(), workspace, "Dockerfile", test.buildArgs, test.target, test.ignorePath, map[string]bool{})
 
 			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.expected, deps)
 			testutil.CheckDeepEqual(t, test.fetched, imageFetcher.fetched)
 		})
 	}
 }
+
+func TestBaseImages(t *testing.T) {
+	var tests = []struct {
+		description string
+		dockerfile  string
+		buildArgs   map[string]*string
+		target      string
+		expected    []string
+		shouldErr   bool
+	}{
+		{
+			description: "multi-stage",
+			dockerfile:  multiStageTargets,
+			expected:    []string{"golang:1.9.2", "ubuntu:14.04", "busybox"},
+		},
+		{
+			description: "target",
+			dockerfile:  multiStageTargets,
+			target:      "builder",
+			expected:    []string{"golang:1.9.2"},
+		},
+		{
+			description: "scratch and duplicates",
+			dockerfile:  "FROM golang:1.12 as builder\nFROM golang:1.12\nFROM scratch\nCOPY --from=builder /app /app",
+			expected:    []string{"golang:1.12"},
+		},
+		{
+			description: "build args",
+			dockerfile:  "ARG BASE\nFROM $BASE",
+			buildArgs:   map[string]*string{"BASE": util.StringPtr("alpine:3.9")},
+			expected:    []string{"alpine:3.9"},
+		},
+		{
+			description: "unknown target",
+			dockerfile:  multiStageTargets,
+			target:      "unknown",
+			shouldErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			tmpDir, cleanup := testutil.NewTempDir(t)
+			defer cleanup()
+			tmpDir.Write("Dockerfile", test.dockerfile)
+
+			images, err := BaseImages(tmpDir.Root(), "Dockerfile", test.buildArgs, test.target)
+
+			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.expected, images)
+		})
+	}
+}
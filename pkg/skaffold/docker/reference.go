@@ -22,6 +22,7 @@ import "github.com/docker/distribution/reference"
 type ImageReference struct {
 	BaseName       string
 	Tag            string
+	Digest         string
 	FullyQualified bool
 }
 
@@ -47,9 +48,15 @@ func ParseReference(image string) (*ImageReference, error) {
 		fullyQualified = true
 	}
 
+	digest := ""
+	if d, ok := r.(reference.Digested); ok {
+		digest = d.Digest().String()
+	}
+
 	return &ImageReference{
 		BaseName:       baseName,
 		Tag:            tag,
+		Digest:         digest,
 		FullyQualified: fullyQualified,
 	}, nil
 }
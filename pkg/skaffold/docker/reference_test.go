@@ -28,6 +28,7 @@ func TestParseReference(t *testing.T) {
 		image                  string
 		expectedName           string
 		expectedTag            string
+		expectedDigest         string
 		expectedFullyQualified bool
 	}{
 		{
@@ -63,6 +64,15 @@ func TestParseReference(t *testing.T) {
 			image:                  "gcr.io/k8s-skaffold/example@sha256:81daf011d63b68cfa514ddab7741a1adddd59d3264118dfb0fd9266328bb8883",
 			expectedName:           "gcr.io/k8s-skaffold/example",
 			expectedTag:            "",
+			expectedDigest:         "sha256:81daf011d63b68cfa514ddab7741a1adddd59d3264118dfb0fd9266328bb8883",
+			expectedFullyQualified: true,
+		},
+		{
+			description:            "tag and digest",
+			image:                  "gcr.io/k8s-skaffold/example:v1@sha256:81daf011d63b68cfa514ddab7741a1adddd59d3264118dfb0fd9266328bb8883",
+			expectedName:           "gcr.io/k8s-skaffold/example",
+			expectedTag:            "v1",
+			expectedDigest:         "sha256:81daf011d63b68cfa514ddab7741a1adddd59d3264118dfb0fd9266328bb8883",
 			expectedFullyQualified: true,
 		},
 		{
@@ -80,6 +90,7 @@ func TestParseReference(t *testing.T) {
 
 			testutil.CheckErrorAndDeepEqual(t, false, err, test.expectedName, parsed.BaseName)
 			testutil.CheckDeepEqual(t, test.expectedTag, parsed.Tag)
+			testutil.CheckDeepEqual(t, test.expectedDigest, parsed.Digest)
 			testutil.CheckDeepEqual(t, test.expectedFullyQualified, parsed.FullyQualified)
 		})
 	}
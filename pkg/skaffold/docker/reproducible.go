@@ -0,0 +1,341 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package docker
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// imageManifest is an entry of the manifest.json file found in `docker save` tarballs.
+type imageManifest struct {
+	Config   string
+	RepoTags []string
+	Layers   []string
+}
+
+// ReproducibleTime returns the time that reproducible images are normalized to:
+// $SOURCE_DATE_EPOCH if it's set, the Unix epoch otherwise.
+func ReproducibleTime() (time.Time, error) {
+	epoch := os.Getenv("SOURCE_DATE_EPOCH")
+	if epoch == "" {
+		return time.Unix(0, 0).UTC(), nil
+	}
+
+	seconds, err := strconv.ParseInt(epoch, 10, 64)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "parsing SOURCE_DATE_EPOCH %s", epoch)
+	}
+	return time.Unix(seconds, 0).UTC(), nil
+}
+
+// Normalize rewrites an image so that its creation timestamps and the
+// modification times of the files in its layers are all set to the given time.
+// The rewritten image is loaded back under the same reference and its ID returned.
+func (l *localDaemon) Normalize(ctx context.Context, out io.Writer, ref string, t time.Time) (string, error) {
+	saved, err := l.apiClient.ImageSave(ctx, []string{ref})
+	if err != nil {
+		return "", errors.Wrap(err, "saving image")
+	}
+	defer saved.Close()
+
+	dir, err := ioutil.TempDir("", "skaffold-reproducible")
+	if err != nil {
+		return "", errors.Wrap(err, "creating temp directory")
+	}
+	defer os.RemoveAll(dir)
+
+	if err := extractTar(saved, filepath.Join(dir, "saved")); err != nil {
+		return "", errors.Wrap(err, "reading saved image")
+	}
+
+	normalized, err := os.Create(filepath.Join(dir, "image.tar"))
+	if err != nil {
+		return "", err
+	}
+	defer normalized.Close()
+
+	if err := normalizeImage(filepath.Join(dir, "saved"), normalized, t); err != nil {
+		return "", errors.Wrap(err, "normalizing image")
+	}
+	if _, err := normalized.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	return l.Load(ctx, out, normalized, ref)
+}
+
+// normalizeImage writes, in the `docker load` format, the image saved in a directory
+// with all its timestamps set to the given time.
+func normalizeImage(dir string, w io.Writer, t time.Time) error {
+	var manifests []imageManifest
+	if err := readJSON(filepath.Join(dir, "manifest.json"), &manifests); err != nil {
+		return errors.Wrap(err, "reading manifest")
+	}
+	if len(manifests) != 1 {
+		return fmt.Errorf("expected one image, found %d", len(manifests))
+	}
+	manifest := manifests[0]
+
+	var config map[string]json.RawMessage
+	if err := readJSON(filepath.Join(dir, manifest.Config), &config); err != nil {
+		return errors.Wrap(err, "reading config")
+	}
+
+	tw := tar.NewWriter(w)
+
+	var diffIDs, layers []string
+	written := map[string]bool{}
+	for i, layer := range manifest.Layers {
+		normalizedLayer := filepath.Join(dir, fmt.Sprintf("normalized-%d.tar", i))
+		digest, err := normalizeLayerFile(filepath.Join(dir, layer), normalizedLayer, t)
+		if err != nil {
+			return errors.Wrapf(err, "normalizing layer %s", layer)
+		}
+
+		name := digest + "/layer.tar"
+		if !written[name] {
+			if err := addFileToTar(tw, name, normalizedLayer, t); err != nil {
+				return err
+			}
+			written[name] = true
+		}
+
+		diffIDs = append(diffIDs, "sha256:"+digest)
+		layers = append(layers, name)
+	}
+
+	if err := normalizeConfig(config, diffIDs, t); err != nil {
+		return err
+	}
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	configDigest := sha256.Sum256(configJSON)
+	configName := hex.EncodeToString(configDigest[:]) + ".json"
+	if err := addBytesToTar(tw, configName, configJSON, t); err != nil {
+		return err
+	}
+
+	manifestJSON, err := json.Marshal([]imageManifest{{
+		Config:   configName,
+		RepoTags: manifest.RepoTags,
+		Layers:   layers,
+	}})
+	if err != nil {
+		return err
+	}
+	if err := addBytesToTar(tw, "manifest.json", manifestJSON, t); err != nil {
+		return err
+	}
+
+	return tw.Close()
+}
+
+// normalizeConfig sets the creation times of an image and of its history, and the
+// ids of its rewritten layers.
+func normalizeConfig(config map[string]json.RawMessage, diffIDs []string, t time.Time) error {
+	created, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	config["created"] = created
+
+	if raw, present := config["history"]; present {
+		var history []map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &history); err != nil {
+			return errors.Wrap(err, "parsing history")
+		}
+		for _, entry := range history {
+			entry["created"] = created
+		}
+		if config["history"], err = json.Marshal(history); err != nil {
+			return err
+		}
+	}
+
+	var rootfs map[string]json.RawMessage
+	if err := json.Unmarshal(config["rootfs"], &rootfs); err != nil {
+		return errors.Wrap(err, "parsing rootfs")
+	}
+	if rootfs["diff_ids"], err = json.Marshal(diffIDs); err != nil {
+		return err
+	}
+	config["rootfs"], err = json.Marshal(rootfs)
+	return err
+}
+
+// normalizeLayerFile rewrites a layer with every modification time set to the given time.
+// It returns the digest of the rewritten layer.
+func normalizeLayerFile(src, dst string, t time.Time) (string, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return "", err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return "", err
+	}
+	defer out.Close()
+
+	hash := sha256.New()
+	if err := normalizeLayer(in, io.MultiWriter(out, hash), t); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func normalizeLayer(r io.Reader, w io.Writer, t time.Time) error {
+	tr := tar.NewReader(r)
+	tw := tar.NewWriter(w)
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		hdr.ModTime = t
+		hdr.AccessTime = time.Time{}
+		hdr.ChangeTime = time.Time{}
+		for _, key := range []string{"mtime", "atime", "ctime"} {
+			delete(hdr.PAXRecords, key)
+		}
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return err
+		}
+	}
+
+	return tw.Close()
+}
+
+// extractTar extracts the files, directories and symlinks of a tarball into a directory.
+func extractTar(r io.Reader, dir string) error {
+	tr := tar.NewReader(r)
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		path := filepath.Join(dir, hdr.Name)
+		if !strings.HasPrefix(path, filepath.Clean(dir)+string(os.PathSeparator)) {
+			return fmt.Errorf("invalid path in tarball: %s", hdr.Name)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, 0755); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := os.Symlink(hdr.Linkname, path); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			f, err := os.Create(path)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr)
+			f.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func addFileToTar(tw *tar.Writer, name, path string, t time.Time) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Size:     fi.Size(),
+		Mode:     0644,
+		ModTime:  t,
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return err
+	}
+
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+func addBytesToTar(tw *tar.Writer, name string, content []byte, t time.Time) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Size:     int64(len(content)),
+		Mode:     0644,
+		ModTime:  t,
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return err
+	}
+
+	_, err := tw.Write(content)
+	return err
+}
+
+func readJSON(path string, v interface{}) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(content, v)
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestReproducibleTime(t *testing.T) {
+	var tests = []struct {
+		description string
+		epoch       string
+		expected    time.Time
+		shouldErr   bool
+	}{
+		{
+			description: "unix epoch by default",
+			expected:    time.Unix(0, 0).UTC(),
+		},
+		{
+			description: "source date epoch",
+			epoch:       "1560000000",
+			expected:    time.Unix(1560000000, 0).UTC(),
+		},
+		{
+			description: "invalid source date epoch",
+			epoch:       "yesterday",
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			defer testutil.SetEnvs(t, map[string]string{"SOURCE_DATE_EPOCH": test.epoch})()
+
+			reproducibleTime, err := ReproducibleTime()
+
+			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.expected, reproducibleTime)
+		})
+	}
+}
+
+func TestNormalizeImage(t *testing.T) {
+	normalized := func(buildTime time.Time) []byte {
+		tmpDir, cleanup := testutil.NewTempDir(t)
+		defer cleanup()
+
+		tmpDir.Write("manifest.json", `[{"Config":"abc.json","RepoTags":["image:tag"],"Layers":["layer1/layer.tar"]}]`)
+		tmpDir.Write("abc.json", `{"architecture":"amd64","created":"`+buildTime.Format(time.RFC3339)+`","history":[{"created":"`+buildTime.Format(time.RFC3339)+`","created_by":"COPY . /app"}],"os":"linux","rootfs":{"type":"layers","diff_ids":["sha256:old"]}}`)
+		tmpDir.Write("layer1/layer.tar", string(layerTar(t, buildTime)))
+
+		var buf bytes.Buffer
+		err := normalizeImage(tmpDir.Root(), &buf, time.Unix(0, 0).UTC())
+		testutil.CheckError(t, false, err)
+
+		return buf.Bytes()
+	}
+
+	first := normalized(time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC))
+	second := normalized(time.Date(2019, 6, 2, 11, 30, 0, 0, time.UTC))
+	testutil.CheckDeepEqual(t, first, second)
+
+	files := readTar(t, first)
+	var manifests []imageManifest
+	testutil.CheckError(t, false, json.Unmarshal(files["manifest.json"], &manifests))
+	testutil.CheckDeepEqual(t, 1, len(manifests))
+	testutil.CheckDeepEqual(t, []string{"image:tag"}, manifests[0].RepoTags)
+
+	var config struct {
+		Created string
+		History []struct{ Created string }
+		RootFS  struct {
+			DiffIDs []string `json:"diff_ids"`
+		}
+	}
+	testutil.CheckError(t, false, json.Unmarshal(files[manifests[0].Config], &config))
+	testutil.CheckDeepEqual(t, "1970-01-01T00:00:00Z", config.Created)
+	testutil.CheckDeepEqual(t, "1970-01-01T00:00:00Z", config.History[0].Created)
+	testutil.CheckDeepEqual(t, "sha256:"+manifests[0].Layers[0][:64], config.RootFS.DiffIDs[0])
+
+	layerFiles := readTar(t, files[manifests[0].Layers[0]])
+	testutil.CheckDeepEqual(t, []byte("hello"), layerFiles["app/main"])
+}
+
+func layerTar(t *testing.T, modTime time.Time) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	testutil.CheckError(t, false, tw.WriteHeader(&tar.Header{Name: "app/", Typeflag: tar.TypeDir, Mode: 0755, ModTime: modTime}))
+	testutil.CheckError(t, false, tw.WriteHeader(&tar.Header{Name: "app/main", Typeflag: tar.TypeReg, Mode: 0644, Size: 5, ModTime: modTime}))
+	_, err := tw.Write([]byte("hello"))
+	testutil.CheckError(t, false, err)
+	testutil.CheckError(t, false, tw.Close())
+	return buf.Bytes()
+}
+
+func readTar(t *testing.T, content []byte) map[string][]byte {
+	files := map[string][]byte{}
+	tr := tar.NewReader(bytes.NewReader(content))
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			break
+		}
+		var buf bytes.Buffer
+		_, err = buf.ReadFrom(tr)
+		testutil.CheckError(t, false, err)
+		files[hdr.Name] = buf.Bytes()
+	}
+	return files
+}
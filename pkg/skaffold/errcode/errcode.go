@@ -0,0 +1,146 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errcode
+
+import (
+	"strings"
+)
+
+// Code identifies a class of failures. Codes are stable, so that tools wrapping
+// Skaffold can rely on them to present actionable guidance.
+type Code string
+
+const (
+	Unknown            Code = "UNKNOWN"
+	ConfigInvalid      Code = "CONFIG_INVALID"
+	BuildFailed        Code = "BUILD_FAILED"
+	BuildPushAuth      Code = "BUILD_PUSH_AUTH"
+	TestFailed         Code = "TEST_FAILED"
+	VerifyFailed       Code = "VERIFY_FAILED"
+	DeployFailed       Code = "DEPLOY_FAILED"
+	StatusCheckFailed  Code = "STATUS_CHECK_FAILED"
+	StatusCheckTimeout Code = "STATUS_CHECK_TIMEOUT"
+	SyncFailed         Code = "SYNC_FAILED"
+	ContainerCrashed   Code = "CONTAINER_CRASHED"
+	Timeout            Code = "TIMEOUT"
+)
+
+// exitCodes are the exit codes of Skaffold when it fails. CI pipelines can branch
+// on these, so they must never change: new codes get new numbers.
+var exitCodes = map[Code]int{
+	Unknown:            1,
+	ConfigInvalid:      2,
+	BuildFailed:        3,
+	BuildPushAuth:      4,
+	TestFailed:         5,
+	DeployFailed:       6,
+	StatusCheckFailed:  7,
+	StatusCheckTimeout: 8,
+	VerifyFailed:       9,
+	SyncFailed:         10,
+	ContainerCrashed:   11,
+	Timeout:            12,
+}
+
+var suggestions = map[Code]string{
+	ConfigInvalid:      "Check the command line flags, and run `skaffold diagnose` or `skaffold fix` to check skaffold.yaml.",
+	BuildFailed:        "Check the build output. Building the artifact locally, with `docker build` for example, helps reproduce the failure.",
+	BuildPushAuth:      "Log in to the image registry, with `docker login` or `gcloud auth configure-docker`, or push to a registry you have access to with `--default-repo`.",
+	TestFailed:         "Check the output of the tests run against the built images.",
+	VerifyFailed:       "Check the output of the verification tests run against the deployed application.",
+	DeployFailed:       "Check the deployer output and that the current kube-context, shown by `kubectl config current-context`, is the expected one.",
+	StatusCheckFailed:  "Inspect the failing resources with `kubectl describe` and `kubectl logs`.",
+	StatusCheckTimeout: "Inspect the pods that didn't become ready with `kubectl describe`, or raise `deploy.statusCheck.deadlineSeconds` if they are slow to start.",
+	SyncFailed:         "Check that `tar` is available in the container and that its user can write to the sync destination.",
+	ContainerCrashed:   "Check the logs of the container before it crashed with `kubectl logs --previous`.",
+	Timeout:            "Check what's hanging in the output, or raise `--timeout`, `--build-timeout`, `--render-timeout`, `--deploy-timeout` or `--wait-for-images`.",
+}
+
+// codedError assigns a code to an error.
+type codedError struct {
+	code Code
+	err  error
+}
+
+func (e *codedError) Error() string {
+	return e.err.Error()
+}
+
+// Cause returns the underlying error.
+func (e *codedError) Cause() error {
+	return e.err
+}
+
+// WithCode assigns a code to an error.
+func WithCode(code Code, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &codedError{code: code, err: err}
+}
+
+// CodeOf returns the most specific code of an error: the code closest to the root cause.
+func CodeOf(err error) Code {
+	code := Unknown
+	for err != nil {
+		if coded, ok := err.(*codedError); ok {
+			code = coded.code
+		}
+
+		cause, ok := err.(interface{ Cause() error })
+		if !ok {
+			break
+		}
+		err = cause.Cause()
+	}
+	return code
+}
+
+// ExitCode returns the exit code of Skaffold for the failures of a given class.
+func ExitCode(code Code) int {
+	if exitCode, found := exitCodes[code]; found {
+		return exitCode
+	}
+	return exitCodes[Unknown]
+}
+
+// Suggestion returns how to fix the failures of a given class.
+func Suggestion(code Code) string {
+	return suggestions[code]
+}
+
+// ForBuild classifies build failures.
+func ForBuild(err error) Code {
+	message := strings.ToLower(err.Error())
+	if strings.Contains(message, "timed out after") {
+		return Timeout
+	}
+	for _, hint := range []string{"unauthorized", "authentication required", "access to the resource is denied", "no basic auth credentials"} {
+		if strings.Contains(message, hint) {
+			return BuildPushAuth
+		}
+	}
+	return BuildFailed
+}
+
+// ForStatusCheck classifies status check failures.
+func ForStatusCheck(err error) Code {
+	if strings.Contains(err.Error(), "could not stabilize within") {
+		return StatusCheckTimeout
+	}
+	return StatusCheckFailed
+}
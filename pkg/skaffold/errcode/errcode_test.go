@@ -0,0 +1,108 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errcode
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	"github.com/pkg/errors"
+)
+
+func TestCodeOf(t *testing.T) {
+	var tests = []struct {
+		description string
+		err         error
+		expected    Code
+	}{
+		{
+			description: "no code",
+			err:         errors.New("failure"),
+			expected:    Unknown,
+		},
+		{
+			description: "code",
+			err:         WithCode(SyncFailed, errors.New("failure")),
+			expected:    SyncFailed,
+		},
+		{
+			description: "wrapped code",
+			err:         errors.Wrap(WithCode(DeployFailed, errors.New("failure")), "deploying"),
+			expected:    DeployFailed,
+		},
+		{
+			description: "most specific code",
+			err:         WithCode(BuildFailed, errors.Wrap(WithCode(BuildPushAuth, errors.New("unauthorized")), "pushing")),
+			expected:    BuildPushAuth,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			testutil.CheckDeepEqual(t, test.expected, CodeOf(test.err))
+		})
+	}
+}
+
+func TestWithCode(t *testing.T) {
+	testutil.CheckDeepEqual(t, nil, WithCode(BuildFailed, nil))
+	testutil.CheckDeepEqual(t, "failure", WithCode(BuildFailed, errors.New("failure")).Error())
+}
+
+func TestForBuild(t *testing.T) {
+	testutil.CheckDeepEqual(t, BuildFailed, ForBuild(errors.New("building: exit status 1")))
+	testutil.CheckDeepEqual(t, BuildPushAuth, ForBuild(errors.New("pushing image: unauthorized: authentication required")))
+	testutil.CheckDeepEqual(t, BuildPushAuth, ForBuild(errors.New("denied: requested access to the resource is denied")))
+	testutil.CheckDeepEqual(t, Timeout, ForBuild(errors.New("building [app]: timed out after 10m0s: context deadline exceeded")))
+}
+
+func TestForStatusCheck(t *testing.T) {
+	testutil.CheckDeepEqual(t, StatusCheckTimeout, ForStatusCheck(errors.New("deployment/app could not stabilize within 2m0s")))
+	testutil.CheckDeepEqual(t, StatusCheckFailed, ForStatusCheck(errors.New("pod crashed")))
+}
+
+func TestSuggestion(t *testing.T) {
+	for _, code := range []Code{BuildFailed, BuildPushAuth, TestFailed, DeployFailed, StatusCheckFailed, StatusCheckTimeout, SyncFailed, Timeout} {
+		testutil.CheckDeepEqual(t, true, Suggestion(code) != "")
+	}
+	testutil.CheckDeepEqual(t, "", Suggestion(Unknown))
+}
+
+func TestExitCode(t *testing.T) {
+	testutil.CheckDeepEqual(t, 1, ExitCode(Unknown))
+	testutil.CheckDeepEqual(t, 1, ExitCode(Code("NOT_A_CODE")))
+	testutil.CheckDeepEqual(t, 2, ExitCode(ConfigInvalid))
+	testutil.CheckDeepEqual(t, 3, ExitCode(BuildFailed))
+	testutil.CheckDeepEqual(t, 5, ExitCode(TestFailed))
+	testutil.CheckDeepEqual(t, 6, ExitCode(DeployFailed))
+	testutil.CheckDeepEqual(t, 8, ExitCode(StatusCheckTimeout))
+}
+
+func TestExitCodesAreDistinct(t *testing.T) {
+	codes := map[int]Code{}
+	for code, exitCode := range exitCodes {
+		if other, found := codes[exitCode]; found {
+			t.Errorf("%s and %s have the same exit code %d", code, other, exitCode)
+		}
+		codes[exitCode] = code
+	}
+
+	for code := range suggestions {
+		if _, found := exitCodes[code]; !found {
+			t.Errorf("%s has no exit code", code)
+		}
+	}
+}
@@ -20,12 +20,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"sync"
+	"time"
 
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/errcode"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event/proto"
 	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/version"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/sirupsen/logrus"
 )
 
 const (
@@ -35,6 +38,13 @@ const (
 	Failed     = "Failed"
 )
 
+// Statuses of a port-forward.
+const (
+	Established   = "Established"
+	Lost          = "Lost"
+	Reestablished = "Re-established"
+)
+
 var (
 	handler *eventHandler
 	once    sync.Once
@@ -48,6 +58,9 @@ type eventHandler struct {
 	stateLock sync.Mutex
 
 	listeners []listener
+
+	// pending tracks the events that are still being handled.
+	pending sync.WaitGroup
 }
 
 type listener struct {
@@ -87,7 +100,8 @@ func (ev *eventHandler) getState() proto.State {
 func (ev *eventHandler) logEvent(entry proto.LogEntry) {
 	ev.logLock.Lock()
 
-	for _, listener := range ev.listeners {
+	for i := range ev.listeners {
+		listener := &ev.listeners[i]
 		if listener.closed {
 			continue
 		}
@@ -126,6 +140,24 @@ func (ev *eventHandler) forEachEvent(callback func(*proto.LogEntry) error) error
 	return <-listener.errors
 }
 
+// addListener calls back for each event already logged, and then for each new event,
+// until the callback fails.
+func (ev *eventHandler) addListener(callback func(*proto.LogEntry) error) {
+	ev.logLock.Lock()
+	defer ev.logLock.Unlock()
+
+	for i := range ev.eventLog {
+		if err := callback(&ev.eventLog[i]); err != nil {
+			return
+		}
+	}
+
+	ev.listeners = append(ev.listeners, listener{
+		callback: callback,
+		errors:   make(chan error, 1),
+	})
+}
+
 func emptyState(build *latest.BuildConfig) proto.State {
 	builds := map[string]string{}
 	if build != nil {
@@ -141,6 +173,18 @@ func emptyState(build *latest.BuildConfig) proto.State {
 		DeployState: &proto.DeployState{
 			Status: NotStarted,
 		},
+		VerifyState: &proto.VerifyState{
+			Tests: map[string]string{},
+		},
+		ScanState: &proto.ScanState{
+			Artifacts: map[string]string{},
+		},
+		StatusCheckState: &proto.StatusCheckState{
+			Resources: map[string]string{},
+		},
+		FileSyncState: &proto.FileSyncState{
+			Status: NotStarted,
+		},
 		ForwardedPorts: make(map[string]*proto.PortEvent),
 	}
 }
@@ -151,6 +195,14 @@ func InitializeState(runCtx *runcontext.RunContext) {
 		handler = &eventHandler{
 			state: emptyState(&runCtx.Cfg.Build),
 		}
+		for _, v := range runCtx.Cfg.Verify {
+			handler.state.VerifyState.Tests[v.Name] = NotStarted
+		}
+		for _, a := range runCtx.Cfg.Build.Artifacts {
+			if a.Scan != nil {
+				handler.state.ScanState.Artifacts[a.ImageName] = NotStarted
+			}
+		}
 	})
 }
 
@@ -184,24 +236,118 @@ func BuildComplete(imageName string) {
 	handler.handleBuildEvent(&proto.BuildEvent{Artifact: imageName, Status: Complete})
 }
 
+// VerifyInProgress notifies that a verification test has been started.
+func VerifyInProgress(name string) {
+	handler.handleVerifyEvent(&proto.VerifyEvent{Name: name, Status: InProgress})
+}
+
+// VerifyFailed notifies that a verification test has failed.
+func VerifyFailed(name string, err error) {
+	handler.handleVerifyEvent(&proto.VerifyEvent{Name: name, Status: Failed, Err: err.Error()})
+}
+
+// VerifyComplete notifies that a verification test has passed.
+func VerifyComplete(name string) {
+	handler.handleVerifyEvent(&proto.VerifyEvent{Name: name, Status: Complete})
+}
+
+// ScanInProgress notifies that the vulnerability scan of an image has been started.
+func ScanInProgress(imageName string) {
+	handler.handleScanEvent(&proto.ScanEvent{Artifact: imageName, Status: InProgress})
+}
+
+// ScanFailed notifies that the vulnerability scan of an image has failed,
+// or found vulnerabilities above the threshold.
+func ScanFailed(imageName string, vulnerabilities map[string]int32, err error) {
+	handler.handleScanEvent(&proto.ScanEvent{Artifact: imageName, Status: Failed, Vulnerabilities: vulnerabilities, Err: err.Error()})
+}
+
+// ScanComplete notifies that the vulnerability scan of an image has passed,
+// with the number of vulnerabilities found by severity.
+func ScanComplete(imageName string, vulnerabilities map[string]int32) {
+	handler.handleScanEvent(&proto.ScanEvent{Artifact: imageName, Status: Complete, Vulnerabilities: vulnerabilities})
+}
+
+// StatusCheckProgress notifies that a resource isn't stable yet, with how many of its
+// replicas are ready and the recent events of its pending pods.
+func StatusCheckProgress(resource, message string, ready, total int32, events []string) {
+	handler.handleStatusCheckEvent(&proto.StatusCheckEvent{
+		Resource:      resource,
+		Status:        InProgress,
+		Message:       message,
+		ReadyReplicas: ready,
+		TotalReplicas: total,
+		Percent:       percent(ready, total),
+		Events:        events,
+	})
+}
+
+// StatusCheckFailed notifies that a resource failed to stabilize.
+func StatusCheckFailed(resource string, err error) {
+	handler.handleStatusCheckEvent(&proto.StatusCheckEvent{Resource: resource, Status: Failed, Err: err.Error()})
+}
+
+// StatusCheckComplete notifies that a resource has stabilized.
+func StatusCheckComplete(resource string) {
+	handler.handleStatusCheckEvent(&proto.StatusCheckEvent{Resource: resource, Status: Complete, Percent: 100})
+}
+
+func percent(ready, total int32) int32 {
+	if total <= 0 {
+		return 0
+	}
+	return ready * 100 / total
+}
+
+// FileSyncInProgress notifies that the sync of changed files to an image's containers has been started.
+func FileSyncInProgress(fileCount int, image string) {
+	handler.handleFileSyncEvent(&proto.FileSyncEvent{FileCount: int32(fileCount), Image: image, Status: InProgress})
+}
+
+// FileSyncFailed notifies that the sync of changed files to an image's containers has failed.
+func FileSyncFailed(fileCount int, image string, err error) {
+	handler.handleFileSyncEvent(&proto.FileSyncEvent{FileCount: int32(fileCount), Image: image, Status: Failed, Err: err.Error()})
+}
+
+// FileSyncSucceeded notifies that the changed files have been synced to an image's containers.
+func FileSyncSucceeded(fileCount int, image string) {
+	handler.handleFileSyncEvent(&proto.FileSyncEvent{FileCount: int32(fileCount), Image: image, Status: Complete})
+}
+
 // PortForwarded notifies that a remote port has been forwarded locally.
 func PortForwarded(localPort, remotePort int32, podName, containerName, namespace string, portName string) {
-	go handler.handle(&proto.Event{
-		EventType: &proto.Event_PortEvent{
-			PortEvent: &proto.PortEvent{
-				LocalPort:     localPort,
-				RemotePort:    remotePort,
-				PodName:       podName,
-				ContainerName: containerName,
-				Namespace:     namespace,
-				PortName:      portName,
+	handler.handlePortEvent(localPort, remotePort, podName, containerName, namespace, portName, Established)
+}
+
+// PortForwardLost notifies that a port-forward has stopped unexpectedly.
+func PortForwardLost(localPort, remotePort int32, podName, containerName, namespace string, portName string) {
+	handler.handlePortEvent(localPort, remotePort, podName, containerName, namespace, portName, Lost)
+}
+
+// PortForwardReestablished notifies that a port-forward has been established again,
+// to a new version of the pod.
+func PortForwardReestablished(localPort, remotePort int32, podName, containerName, namespace string, portName string) {
+	handler.handlePortEvent(localPort, remotePort, podName, containerName, namespace, portName, Reestablished)
+}
+
+// Error notifies that Skaffold has failed, with the error's code
+// and a suggestion to fix it.
+func Error(err error) {
+	code := errcode.CodeOf(err)
+
+	handler.handleAsync(&proto.Event{
+		EventType: &proto.Event_ErrorEvent{
+			ErrorEvent: &proto.ErrorEvent{
+				Code:       string(code),
+				Message:    err.Error(),
+				Suggestion: errcode.Suggestion(code),
 			},
 		},
 	})
 }
 
 func (ev *eventHandler) handleDeployEvent(e *proto.DeployEvent) {
-	go ev.handle(&proto.Event{
+	ev.handleAsync(&proto.Event{
 		EventType: &proto.Event_DeployEvent{
 			DeployEvent: e,
 		},
@@ -209,13 +355,62 @@ func (ev *eventHandler) handleDeployEvent(e *proto.DeployEvent) {
 }
 
 func (ev *eventHandler) handleBuildEvent(e *proto.BuildEvent) {
-	go ev.handle(&proto.Event{
+	ev.handleAsync(&proto.Event{
 		EventType: &proto.Event_BuildEvent{
 			BuildEvent: e,
 		},
 	})
 }
 
+func (ev *eventHandler) handleVerifyEvent(e *proto.VerifyEvent) {
+	ev.handleAsync(&proto.Event{
+		EventType: &proto.Event_VerifyEvent{
+			VerifyEvent: e,
+		},
+	})
+}
+
+func (ev *eventHandler) handleScanEvent(e *proto.ScanEvent) {
+	ev.handleAsync(&proto.Event{
+		EventType: &proto.Event_ScanEvent{
+			ScanEvent: e,
+		},
+	})
+}
+
+func (ev *eventHandler) handleStatusCheckEvent(e *proto.StatusCheckEvent) {
+	ev.handleAsync(&proto.Event{
+		EventType: &proto.Event_StatusCheckEvent{
+			StatusCheckEvent: e,
+		},
+	})
+}
+
+func (ev *eventHandler) handleFileSyncEvent(e *proto.FileSyncEvent) {
+	ev.handleAsync(&proto.Event{
+		EventType: &proto.Event_FileSyncEvent{
+			FileSyncEvent: e,
+		},
+	})
+}
+
+func (ev *eventHandler) handlePortEvent(localPort, remotePort int32, podName, containerName, namespace, portName, status string) {
+	ev.handleAsync(&proto.Event{
+		EventType: &proto.Event_PortEvent{
+			PortEvent: &proto.PortEvent{
+				LocalPort:     localPort,
+				RemotePort:    remotePort,
+				PodName:       podName,
+				ContainerName: containerName,
+				Namespace:     namespace,
+				PortName:      portName,
+				Status:        status,
+				Address:       fmt.Sprintf("localhost:%d", localPort),
+			},
+		},
+	})
+}
+
 func LogSkaffoldMetadata(info *version.Info) {
 	handler.logEvent(proto.LogEntry{
 		Timestamp: ptypes.TimestampNow(),
@@ -229,6 +424,35 @@ func LogSkaffoldMetadata(info *version.Info) {
 	})
 }
 
+// handleAsync handles an event without blocking the caller.
+func (ev *eventHandler) handleAsync(event *proto.Event) {
+	ev.pending.Add(1)
+	go func() {
+		defer ev.pending.Done()
+		ev.handle(event)
+	}()
+}
+
+// Flush waits for the pending events to be logged and sent to the listeners,
+// for at most the given timeout. It's called before Skaffold exits.
+func Flush(timeout time.Duration) {
+	if handler == nil {
+		return
+	}
+
+	flushed := make(chan struct{})
+	go func() {
+		handler.pending.Wait()
+		close(flushed)
+	}()
+
+	select {
+	case <-flushed:
+	case <-time.After(timeout):
+		logrus.Debugln("Timed out flushing events")
+	}
+}
+
 func (ev *eventHandler) handle(event *proto.Event) {
 	logEntry := &proto.LogEntry{
 		Timestamp: ptypes.TimestampNow(),
@@ -266,12 +490,78 @@ func (ev *eventHandler) handle(event *proto.Event) {
 			// logEntry.Err = de.Err
 		default:
 		}
+	case *proto.Event_VerifyEvent:
+		ve := e.VerifyEvent
+		ev.stateLock.Lock()
+		ev.state.VerifyState.Tests[ve.Name] = ve.Status
+		ev.stateLock.Unlock()
+		switch ve.Status {
+		case InProgress:
+			logEntry.Entry = fmt.Sprintf("Verification started for %s", ve.Name)
+		case Complete:
+			logEntry.Entry = fmt.Sprintf("Verification passed for %s", ve.Name)
+		case Failed:
+			logEntry.Entry = fmt.Sprintf("Verification failed for %s", ve.Name)
+		default:
+		}
+	case *proto.Event_ScanEvent:
+		se := e.ScanEvent
+		ev.stateLock.Lock()
+		ev.state.ScanState.Artifacts[se.Artifact] = se.Status
+		ev.stateLock.Unlock()
+		switch se.Status {
+		case InProgress:
+			logEntry.Entry = fmt.Sprintf("Vulnerability scan started for artifact %s", se.Artifact)
+		case Complete:
+			logEntry.Entry = fmt.Sprintf("Vulnerability scan passed for artifact %s", se.Artifact)
+		case Failed:
+			logEntry.Entry = fmt.Sprintf("Vulnerability scan failed for artifact %s", se.Artifact)
+		default:
+		}
+	case *proto.Event_StatusCheckEvent:
+		se := e.StatusCheckEvent
+		ev.stateLock.Lock()
+		ev.state.StatusCheckState.Resources[se.Resource] = se.Status
+		ev.stateLock.Unlock()
+		switch se.Status {
+		case InProgress:
+			logEntry.Entry = fmt.Sprintf("Status check of %s: %d/%d replicas ready", se.Resource, se.ReadyReplicas, se.TotalReplicas)
+		case Complete:
+			logEntry.Entry = fmt.Sprintf("Status check of %s succeeded", se.Resource)
+		case Failed:
+			logEntry.Entry = fmt.Sprintf("Status check of %s failed", se.Resource)
+		default:
+		}
 	case *proto.Event_PortEvent:
 		pe := e.PortEvent
 		ev.stateLock.Lock()
 		ev.state.ForwardedPorts[pe.ContainerName] = pe
 		ev.stateLock.Unlock()
-		logEntry.Entry = fmt.Sprintf("Forwarding container %s to local port %d", pe.ContainerName, pe.LocalPort)
+		switch pe.Status {
+		case Lost:
+			logEntry.Entry = fmt.Sprintf("Lost port-forward of container %s to %s", pe.ContainerName, pe.Address)
+		case Reestablished:
+			logEntry.Entry = fmt.Sprintf("Forwarding container %s to %s again", pe.ContainerName, pe.Address)
+		default:
+			logEntry.Entry = fmt.Sprintf("Forwarding container %s to local port %d", pe.ContainerName, pe.LocalPort)
+		}
+	case *proto.Event_FileSyncEvent:
+		fe := e.FileSyncEvent
+		ev.stateLock.Lock()
+		ev.state.FileSyncState.Status = fe.Status
+		ev.stateLock.Unlock()
+		switch fe.Status {
+		case InProgress:
+			logEntry.Entry = fmt.Sprintf("File sync started for %d files for %s", fe.FileCount, fe.Image)
+		case Complete:
+			logEntry.Entry = fmt.Sprintf("File sync succeeded for %d files for %s", fe.FileCount, fe.Image)
+		case Failed:
+			logEntry.Entry = fmt.Sprintf("File sync failed for %d files for %s", fe.FileCount, fe.Image)
+		default:
+		}
+	case *proto.Event_ErrorEvent:
+		ee := e.ErrorEvent
+		logEntry.Entry = fmt.Sprintf("Failed with code %s: %s", ee.Code, ee.Message)
 	default:
 		return
 	}
@@ -22,6 +22,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/errcode"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event/proto"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/GoogleContainerTools/skaffold/testutil"
@@ -151,6 +152,108 @@ func TestBuildComplete(t *testing.T) {
 	wait(t, func() bool { return handler.getState().BuildState.Artifacts["img"] == Complete })
 }
 
+func TestVerifyInProgress(t *testing.T) {
+	defer func() { handler = nil }()
+
+	handler = &eventHandler{
+		state: emptyState(nil),
+	}
+
+	wait(t, func() bool { return handler.getState().VerifyState.Tests["e2e"] == "" })
+	VerifyInProgress("e2e")
+	wait(t, func() bool { return handler.getState().VerifyState.Tests["e2e"] == InProgress })
+}
+
+func TestVerifyFailed(t *testing.T) {
+	defer func() { handler = nil }()
+
+	handler = &eventHandler{
+		state: emptyState(nil),
+	}
+
+	VerifyFailed("e2e", errors.New("BUG"))
+	wait(t, func() bool { return handler.getState().VerifyState.Tests["e2e"] == Failed })
+}
+
+func TestVerifyComplete(t *testing.T) {
+	defer func() { handler = nil }()
+
+	handler = &eventHandler{
+		state: emptyState(nil),
+	}
+
+	VerifyComplete("e2e")
+	wait(t, func() bool { return handler.getState().VerifyState.Tests["e2e"] == Complete })
+}
+
+func TestScanInProgress(t *testing.T) {
+	defer func() { handler = nil }()
+
+	handler = &eventHandler{
+		state: emptyState(nil),
+	}
+
+	wait(t, func() bool { return handler.getState().ScanState.Artifacts["img"] == "" })
+	ScanInProgress("img")
+	wait(t, func() bool { return handler.getState().ScanState.Artifacts["img"] == InProgress })
+}
+
+func TestScanFailed(t *testing.T) {
+	defer func() { handler = nil }()
+
+	handler = &eventHandler{
+		state: emptyState(nil),
+	}
+
+	ScanFailed("img", map[string]int32{"CRITICAL": 1}, errors.New("BUG"))
+	wait(t, func() bool { return handler.getState().ScanState.Artifacts["img"] == Failed })
+}
+
+func TestScanComplete(t *testing.T) {
+	defer func() { handler = nil }()
+
+	handler = &eventHandler{
+		state: emptyState(nil),
+	}
+
+	ScanComplete("img", map[string]int32{"LOW": 2})
+	wait(t, func() bool { return handler.getState().ScanState.Artifacts["img"] == Complete })
+}
+
+func TestStatusCheckProgress(t *testing.T) {
+	defer func() { handler = nil }()
+
+	handler = &eventHandler{
+		state: emptyState(nil),
+	}
+
+	wait(t, func() bool { return handler.getState().StatusCheckState.Resources["deployment/web"] == "" })
+	StatusCheckProgress("deployment/web", "Waiting for rollout to finish", 1, 3, nil)
+	wait(t, func() bool { return handler.getState().StatusCheckState.Resources["deployment/web"] == InProgress })
+}
+
+func TestStatusCheckFailed(t *testing.T) {
+	defer func() { handler = nil }()
+
+	handler = &eventHandler{
+		state: emptyState(nil),
+	}
+
+	StatusCheckFailed("deployment/web", errors.New("BUG"))
+	wait(t, func() bool { return handler.getState().StatusCheckState.Resources["deployment/web"] == Failed })
+}
+
+func TestStatusCheckComplete(t *testing.T) {
+	defer func() { handler = nil }()
+
+	handler = &eventHandler{
+		state: emptyState(nil),
+	}
+
+	StatusCheckComplete("deployment/web")
+	wait(t, func() bool { return handler.getState().StatusCheckState.Resources["deployment/web"] == Complete })
+}
+
 func TestPortForwarded(t *testing.T) {
 	defer func() { handler = nil }()
 
@@ -161,6 +264,82 @@ func TestPortForwarded(t *testing.T) {
 	wait(t, func() bool { return handler.getState().ForwardedPorts["container"] == nil })
 	PortForwarded(8080, 8888, "pod", "container", "ns", "portname")
 	wait(t, func() bool { return handler.getState().ForwardedPorts["container"] != nil })
+
+	pe := handler.getState().ForwardedPorts["container"]
+	testutil.CheckDeepEqual(t, Established, pe.Status)
+	testutil.CheckDeepEqual(t, "localhost:8080", pe.Address)
+}
+
+func TestPortForwardLost(t *testing.T) {
+	defer func() { handler = nil }()
+
+	handler = &eventHandler{
+		state: emptyState(nil),
+	}
+
+	PortForwardLost(8080, 8888, "pod", "container", "ns", "portname")
+	wait(t, func() bool {
+		pe := handler.getState().ForwardedPorts["container"]
+		return pe != nil && pe.Status == Lost
+	})
+}
+
+func TestPortForwardReestablished(t *testing.T) {
+	defer func() { handler = nil }()
+
+	handler = &eventHandler{
+		state: emptyState(nil),
+	}
+
+	PortForwardReestablished(8080, 8888, "pod-2", "container", "ns", "portname")
+	wait(t, func() bool {
+		pe := handler.getState().ForwardedPorts["container"]
+		return pe != nil && pe.Status == Reestablished && pe.PodName == "pod-2"
+	})
+}
+
+func TestFileSyncInProgress(t *testing.T) {
+	defer func() { handler = nil }()
+
+	handler = &eventHandler{
+		state: emptyState(nil),
+	}
+
+	wait(t, func() bool { return handler.getState().FileSyncState.Status == NotStarted })
+	FileSyncInProgress(5, "image")
+	wait(t, func() bool { return handler.getState().FileSyncState.Status == InProgress })
+}
+
+func TestFileSyncFailed(t *testing.T) {
+	defer func() { handler = nil }()
+
+	handler = &eventHandler{
+		state: emptyState(nil),
+	}
+
+	FileSyncFailed(5, "image", errors.New("BUG"))
+	wait(t, func() bool {
+		handler.logLock.Lock()
+		defer handler.logLock.Unlock()
+		return len(handler.eventLog) == 1
+	})
+	testutil.CheckDeepEqual(t, Failed, handler.getState().FileSyncState.Status)
+
+	fe := handler.eventLog[0].Event.GetFileSyncEvent()
+	testutil.CheckDeepEqual(t, int32(5), fe.FileCount)
+	testutil.CheckDeepEqual(t, "BUG", fe.Err)
+	testutil.CheckDeepEqual(t, "File sync failed for 5 files for image", handler.eventLog[0].Entry)
+}
+
+func TestFileSyncSucceeded(t *testing.T) {
+	defer func() { handler = nil }()
+
+	handler = &eventHandler{
+		state: emptyState(nil),
+	}
+
+	FileSyncSucceeded(5, "image")
+	wait(t, func() bool { return handler.getState().FileSyncState.Status == Complete })
 }
 
 func wait(t *testing.T, condition func() bool) {
@@ -182,3 +361,40 @@ func wait(t *testing.T, condition func() bool) {
 		}
 	}
 }
+
+func TestError(t *testing.T) {
+	defer func() { handler = nil }()
+
+	handler = &eventHandler{
+		state: emptyState(nil),
+	}
+
+	Error(errcode.WithCode(errcode.BuildPushAuth, errors.New("unauthorized")))
+	wait(t, func() bool {
+		handler.logLock.Lock()
+		defer handler.logLock.Unlock()
+		return len(handler.eventLog) == 1
+	})
+
+	ee := handler.eventLog[0].Event.GetErrorEvent()
+	testutil.CheckDeepEqual(t, "BUILD_PUSH_AUTH", ee.Code)
+	testutil.CheckDeepEqual(t, "unauthorized", ee.Message)
+	testutil.CheckDeepEqual(t, errcode.Suggestion(errcode.BuildPushAuth), ee.Suggestion)
+	testutil.CheckDeepEqual(t, "Failed with code BUILD_PUSH_AUTH: unauthorized", handler.eventLog[0].Entry)
+}
+
+func TestFlush(t *testing.T) {
+	defer func() { handler = nil }()
+
+	handler = &eventHandler{
+		state: emptyState(&latest.BuildConfig{
+			Artifacts: []*latest.Artifact{{ImageName: "img"}},
+		}),
+	}
+
+	BuildInProgress("img")
+	DeployInProgress()
+	Flush(5 * time.Second)
+
+	testutil.CheckDeepEqual(t, 2, len(handler.eventLog))
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package event
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event/proto"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/redact"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/pkg/errors"
+)
+
+// LogToFile writes every event of the session to a file, one json entry per line.
+// Events that were already logged are written first. The file is only readable
+// by the user and redacted values are masked, since events can contain secrets.
+func LogToFile(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Wrap(err, "creating event log directory")
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return errors.Wrap(err, "creating event log")
+	}
+
+	var lock sync.Mutex
+	marshaler := &jsonpb.Marshaler{}
+	handler.addListener(func(entry *proto.LogEntry) error {
+		lock.Lock()
+		defer lock.Unlock()
+
+		line, err := marshaler.MarshalToString(entry)
+		if err != nil {
+			return err
+		}
+		if _, err := f.WriteString(redact.String(line) + "\n"); err != nil {
+			f.Close()
+			return err
+		}
+		return nil
+	})
+
+	return nil
+}
+
+// ReadLogFile reads the events of a file written by LogToFile.
+func ReadLogFile(path string) ([]*proto.LogEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "opening event log")
+	}
+	defer f.Close()
+
+	var entries []*proto.LogEntry
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var entry proto.LogEntry
+		if err := jsonpb.UnmarshalString(scanner.Text(), &entry); err != nil {
+			return nil, errors.Wrapf(err, "parsing event log %s", path)
+		}
+		entries = append(entries, &entry)
+	}
+
+	return entries, scanner.Err()
+}
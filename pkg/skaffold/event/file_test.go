@@ -0,0 +1,83 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package event
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event/proto"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/redact"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestLogToFile(t *testing.T) {
+	defer func() { handler = nil }()
+
+	handler = &eventHandler{
+		state: emptyState(nil),
+	}
+
+	tmpDir, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+
+	handler.logEvent(proto.LogEntry{Entry: "before"})
+	err := LogToFile(tmpDir.Path("events/session.jsonl"))
+	testutil.CheckError(t, false, err)
+	handler.logEvent(proto.LogEntry{Entry: "after"})
+
+	entries, err := ReadLogFile(tmpDir.Path("events/session.jsonl"))
+	testutil.CheckError(t, false, err)
+
+	var logged []string
+	for _, entry := range entries {
+		logged = append(logged, entry.Entry)
+	}
+	testutil.CheckDeepEqual(t, []string{"before", "after"}, logged)
+}
+
+func TestLogToFileRedacts(t *testing.T) {
+	defer func() { handler = nil }()
+	redact.AddValue("s3cr3t")
+	defer redact.Reset()
+
+	handler = &eventHandler{
+		state: emptyState(nil),
+	}
+
+	tmpDir, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+
+	err := LogToFile(tmpDir.Path("session.jsonl"))
+	testutil.CheckError(t, false, err)
+	handler.logEvent(proto.LogEntry{Entry: "token: s3cr3t"})
+
+	content, err := ioutil.ReadFile(tmpDir.Path("session.jsonl"))
+	testutil.CheckError(t, false, err)
+	testutil.CheckDeepEqual(t, false, strings.Contains(string(content), "s3cr3t"))
+}
+
+func TestReadLogFileInvalid(t *testing.T) {
+	tmpDir, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+
+	tmpDir.Write("session.jsonl", "{\"entry\":\"valid\"}\ninvalid\n")
+
+	_, err := ReadLogFile(tmpDir.Path("session.jsonl"))
+	testutil.CheckError(t, true, err)
+}
@@ -149,6 +149,10 @@ type State struct {
 	BuildState           *BuildState           `protobuf:"bytes,1,opt,name=buildState,proto3" json:"buildState,omitempty"`
 	DeployState          *DeployState          `protobuf:"bytes,2,opt,name=deployState,proto3" json:"deployState,omitempty"`
 	ForwardedPorts       map[string]*PortEvent `protobuf:"bytes,3,rep,name=forwardedPorts,proto3" json:"forwardedPorts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	VerifyState          *VerifyState          `protobuf:"bytes,4,opt,name=verifyState,proto3" json:"verifyState,omitempty"`
+	ScanState            *ScanState            `protobuf:"bytes,5,opt,name=scanState,proto3" json:"scanState,omitempty"`
+	StatusCheckState     *StatusCheckState     `protobuf:"bytes,6,opt,name=statusCheckState,proto3" json:"statusCheckState,omitempty"`
+	FileSyncState        *FileSyncState        `protobuf:"bytes,7,opt,name=fileSyncState,proto3" json:"fileSyncState,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
 	XXX_unrecognized     []byte                `json:"-"`
 	XXX_sizecache        int32                 `json:"-"`
@@ -200,6 +204,34 @@ func (m *State) GetForwardedPorts() map[string]*PortEvent {
 	return nil
 }
 
+func (m *State) GetVerifyState() *VerifyState {
+	if m != nil {
+		return m.VerifyState
+	}
+	return nil
+}
+
+func (m *State) GetScanState() *ScanState {
+	if m != nil {
+		return m.ScanState
+	}
+	return nil
+}
+
+func (m *State) GetStatusCheckState() *StatusCheckState {
+	if m != nil {
+		return m.StatusCheckState
+	}
+	return nil
+}
+
+func (m *State) GetFileSyncState() *FileSyncState {
+	if m != nil {
+		return m.FileSyncState
+	}
+	return nil
+}
+
 // BuildState contains a map of all skaffold artifacts to their current build
 // states
 type BuildState struct {
@@ -281,12 +313,177 @@ func (m *DeployState) GetStatus() string {
 	return ""
 }
 
+// VerifyState contains a map of all verification tests to their current states
+type VerifyState struct {
+	Tests                map[string]string `protobuf:"bytes,1,rep,name=tests,proto3" json:"tests,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *VerifyState) Reset()         { *m = VerifyState{} }
+func (m *VerifyState) String() string { return proto.CompactTextString(m) }
+func (*VerifyState) ProtoMessage()    {}
+func (*VerifyState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4f2d38e344f9dbf5, []int{6}
+}
+
+func (m *VerifyState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VerifyState.Unmarshal(m, b)
+}
+func (m *VerifyState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VerifyState.Marshal(b, m, deterministic)
+}
+func (m *VerifyState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyState.Merge(m, src)
+}
+func (m *VerifyState) XXX_Size() int {
+	return xxx_messageInfo_VerifyState.Size(m)
+}
+func (m *VerifyState) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VerifyState proto.InternalMessageInfo
+
+func (m *VerifyState) GetTests() map[string]string {
+	if m != nil {
+		return m.Tests
+	}
+	return nil
+}
+
+// ScanState contains a map of all scanned artifacts to their current scan states
+type ScanState struct {
+	Artifacts            map[string]string `protobuf:"bytes,1,rep,name=artifacts,proto3" json:"artifacts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ScanState) Reset()         { *m = ScanState{} }
+func (m *ScanState) String() string { return proto.CompactTextString(m) }
+func (*ScanState) ProtoMessage()    {}
+func (*ScanState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4f2d38e344f9dbf5, []int{7}
+}
+
+func (m *ScanState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScanState.Unmarshal(m, b)
+}
+func (m *ScanState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScanState.Marshal(b, m, deterministic)
+}
+func (m *ScanState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScanState.Merge(m, src)
+}
+func (m *ScanState) XXX_Size() int {
+	return xxx_messageInfo_ScanState.Size(m)
+}
+func (m *ScanState) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScanState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScanState proto.InternalMessageInfo
+
+func (m *ScanState) GetArtifacts() map[string]string {
+	if m != nil {
+		return m.Artifacts
+	}
+	return nil
+}
+
+// StatusCheckState contains a map of all status checked resources to their current states
+type StatusCheckState struct {
+	Resources            map[string]string `protobuf:"bytes,1,rep,name=resources,proto3" json:"resources,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *StatusCheckState) Reset()         { *m = StatusCheckState{} }
+func (m *StatusCheckState) String() string { return proto.CompactTextString(m) }
+func (*StatusCheckState) ProtoMessage()    {}
+func (*StatusCheckState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4f2d38e344f9dbf5, []int{8}
+}
+
+func (m *StatusCheckState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatusCheckState.Unmarshal(m, b)
+}
+func (m *StatusCheckState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatusCheckState.Marshal(b, m, deterministic)
+}
+func (m *StatusCheckState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatusCheckState.Merge(m, src)
+}
+func (m *StatusCheckState) XXX_Size() int {
+	return xxx_messageInfo_StatusCheckState.Size(m)
+}
+func (m *StatusCheckState) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatusCheckState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatusCheckState proto.InternalMessageInfo
+
+func (m *StatusCheckState) GetResources() map[string]string {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+// FileSyncState contains the status of the current file sync
+type FileSyncState struct {
+	Status               string   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FileSyncState) Reset()         { *m = FileSyncState{} }
+func (m *FileSyncState) String() string { return proto.CompactTextString(m) }
+func (*FileSyncState) ProtoMessage()    {}
+func (*FileSyncState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4f2d38e344f9dbf5, []int{9}
+}
+
+func (m *FileSyncState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileSyncState.Unmarshal(m, b)
+}
+func (m *FileSyncState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileSyncState.Marshal(b, m, deterministic)
+}
+func (m *FileSyncState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileSyncState.Merge(m, src)
+}
+func (m *FileSyncState) XXX_Size() int {
+	return xxx_messageInfo_FileSyncState.Size(m)
+}
+func (m *FileSyncState) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileSyncState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileSyncState proto.InternalMessageInfo
+
+func (m *FileSyncState) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
 type Event struct {
 	// Types that are valid to be assigned to EventType:
 	//	*Event_MetaEvent
 	//	*Event_BuildEvent
 	//	*Event_DeployEvent
 	//	*Event_PortEvent
+	//	*Event_VerifyEvent
+	//	*Event_ScanEvent
+	//	*Event_ErrorEvent
+	//	*Event_StatusCheckEvent
+	//	*Event_FileSyncEvent
 	EventType            isEvent_EventType `protobuf_oneof:"event_type"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
@@ -297,7 +494,7 @@ func (m *Event) Reset()         { *m = Event{} }
 func (m *Event) String() string { return proto.CompactTextString(m) }
 func (*Event) ProtoMessage()    {}
 func (*Event) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4f2d38e344f9dbf5, []int{6}
+	return fileDescriptor_4f2d38e344f9dbf5, []int{10}
 }
 
 func (m *Event) XXX_Unmarshal(b []byte) error {
@@ -338,6 +535,26 @@ type Event_PortEvent struct {
 	PortEvent *PortEvent `protobuf:"bytes,4,opt,name=portEvent,proto3,oneof"`
 }
 
+type Event_VerifyEvent struct {
+	VerifyEvent *VerifyEvent `protobuf:"bytes,5,opt,name=verifyEvent,proto3,oneof"`
+}
+
+type Event_ScanEvent struct {
+	ScanEvent *ScanEvent `protobuf:"bytes,6,opt,name=scanEvent,proto3,oneof"`
+}
+
+type Event_ErrorEvent struct {
+	ErrorEvent *ErrorEvent `protobuf:"bytes,7,opt,name=errorEvent,proto3,oneof"`
+}
+
+type Event_StatusCheckEvent struct {
+	StatusCheckEvent *StatusCheckEvent `protobuf:"bytes,8,opt,name=statusCheckEvent,proto3,oneof"`
+}
+
+type Event_FileSyncEvent struct {
+	FileSyncEvent *FileSyncEvent `protobuf:"bytes,9,opt,name=fileSyncEvent,proto3,oneof"`
+}
+
 func (*Event_MetaEvent) isEvent_EventType() {}
 
 func (*Event_BuildEvent) isEvent_EventType() {}
@@ -346,6 +563,16 @@ func (*Event_DeployEvent) isEvent_EventType() {}
 
 func (*Event_PortEvent) isEvent_EventType() {}
 
+func (*Event_VerifyEvent) isEvent_EventType() {}
+
+func (*Event_ScanEvent) isEvent_EventType() {}
+
+func (*Event_ErrorEvent) isEvent_EventType() {}
+
+func (*Event_StatusCheckEvent) isEvent_EventType() {}
+
+func (*Event_FileSyncEvent) isEvent_EventType() {}
+
 func (m *Event) GetEventType() isEvent_EventType {
 	if m != nil {
 		return m.EventType
@@ -381,6 +608,41 @@ func (m *Event) GetPortEvent() *PortEvent {
 	return nil
 }
 
+func (m *Event) GetVerifyEvent() *VerifyEvent {
+	if x, ok := m.GetEventType().(*Event_VerifyEvent); ok {
+		return x.VerifyEvent
+	}
+	return nil
+}
+
+func (m *Event) GetScanEvent() *ScanEvent {
+	if x, ok := m.GetEventType().(*Event_ScanEvent); ok {
+		return x.ScanEvent
+	}
+	return nil
+}
+
+func (m *Event) GetErrorEvent() *ErrorEvent {
+	if x, ok := m.GetEventType().(*Event_ErrorEvent); ok {
+		return x.ErrorEvent
+	}
+	return nil
+}
+
+func (m *Event) GetStatusCheckEvent() *StatusCheckEvent {
+	if x, ok := m.GetEventType().(*Event_StatusCheckEvent); ok {
+		return x.StatusCheckEvent
+	}
+	return nil
+}
+
+func (m *Event) GetFileSyncEvent() *FileSyncEvent {
+	if x, ok := m.GetEventType().(*Event_FileSyncEvent); ok {
+		return x.FileSyncEvent
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Event) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -388,6 +650,11 @@ func (*Event) XXX_OneofWrappers() []interface{} {
 		(*Event_BuildEvent)(nil),
 		(*Event_DeployEvent)(nil),
 		(*Event_PortEvent)(nil),
+		(*Event_VerifyEvent)(nil),
+		(*Event_ScanEvent)(nil),
+		(*Event_ErrorEvent)(nil),
+		(*Event_StatusCheckEvent)(nil),
+		(*Event_FileSyncEvent)(nil),
 	}
 }
 
@@ -402,7 +669,7 @@ func (m *MetaEvent) Reset()         { *m = MetaEvent{} }
 func (m *MetaEvent) String() string { return proto.CompactTextString(m) }
 func (*MetaEvent) ProtoMessage()    {}
 func (*MetaEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4f2d38e344f9dbf5, []int{7}
+	return fileDescriptor_4f2d38e344f9dbf5, []int{11}
 }
 
 func (m *MetaEvent) XXX_Unmarshal(b []byte) error {
@@ -443,7 +710,7 @@ func (m *BuildEvent) Reset()         { *m = BuildEvent{} }
 func (m *BuildEvent) String() string { return proto.CompactTextString(m) }
 func (*BuildEvent) ProtoMessage()    {}
 func (*BuildEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4f2d38e344f9dbf5, []int{8}
+	return fileDescriptor_4f2d38e344f9dbf5, []int{12}
 }
 
 func (m *BuildEvent) XXX_Unmarshal(b []byte) error {
@@ -497,7 +764,7 @@ func (m *DeployEvent) Reset()         { *m = DeployEvent{} }
 func (m *DeployEvent) String() string { return proto.CompactTextString(m) }
 func (*DeployEvent) ProtoMessage()    {}
 func (*DeployEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4f2d38e344f9dbf5, []int{9}
+	return fileDescriptor_4f2d38e344f9dbf5, []int{13}
 }
 
 func (m *DeployEvent) XXX_Unmarshal(b []byte) error {
@@ -532,6 +799,7 @@ func (m *DeployEvent) GetErr() string {
 	return ""
 }
 
+// PortEvent reports a port-forward being established, lost or re-established
 type PortEvent struct {
 	LocalPort            int32    `protobuf:"varint,1,opt,name=localPort,proto3" json:"localPort,omitempty"`
 	RemotePort           int32    `protobuf:"varint,2,opt,name=remotePort,proto3" json:"remotePort,omitempty"`
@@ -539,6 +807,8 @@ type PortEvent struct {
 	ContainerName        string   `protobuf:"bytes,4,opt,name=containerName,proto3" json:"containerName,omitempty"`
 	Namespace            string   `protobuf:"bytes,5,opt,name=namespace,proto3" json:"namespace,omitempty"`
 	PortName             string   `protobuf:"bytes,6,opt,name=portName,proto3" json:"portName,omitempty"`
+	Status               string   `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	Address              string   `protobuf:"bytes,8,opt,name=address,proto3" json:"address,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -548,7 +818,7 @@ func (m *PortEvent) Reset()         { *m = PortEvent{} }
 func (m *PortEvent) String() string { return proto.CompactTextString(m) }
 func (*PortEvent) ProtoMessage()    {}
 func (*PortEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4f2d38e344f9dbf5, []int{10}
+	return fileDescriptor_4f2d38e344f9dbf5, []int{14}
 }
 
 func (m *PortEvent) XXX_Unmarshal(b []byte) error {
@@ -611,6 +881,354 @@ func (m *PortEvent) GetPortName() string {
 	return ""
 }
 
+func (m *PortEvent) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *PortEvent) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type VerifyEvent struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Status               string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Err                  string   `protobuf:"bytes,3,opt,name=err,proto3" json:"err,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VerifyEvent) Reset()         { *m = VerifyEvent{} }
+func (m *VerifyEvent) String() string { return proto.CompactTextString(m) }
+func (*VerifyEvent) ProtoMessage()    {}
+func (*VerifyEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4f2d38e344f9dbf5, []int{15}
+}
+
+func (m *VerifyEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VerifyEvent.Unmarshal(m, b)
+}
+func (m *VerifyEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VerifyEvent.Marshal(b, m, deterministic)
+}
+func (m *VerifyEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyEvent.Merge(m, src)
+}
+func (m *VerifyEvent) XXX_Size() int {
+	return xxx_messageInfo_VerifyEvent.Size(m)
+}
+func (m *VerifyEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VerifyEvent proto.InternalMessageInfo
+
+func (m *VerifyEvent) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *VerifyEvent) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *VerifyEvent) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+type ScanEvent struct {
+	Artifact             string           `protobuf:"bytes,1,opt,name=artifact,proto3" json:"artifact,omitempty"`
+	Status               string           `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Err                  string           `protobuf:"bytes,3,opt,name=err,proto3" json:"err,omitempty"`
+	Vulnerabilities      map[string]int32 `protobuf:"bytes,4,rep,name=vulnerabilities,proto3" json:"vulnerabilities,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ScanEvent) Reset()         { *m = ScanEvent{} }
+func (m *ScanEvent) String() string { return proto.CompactTextString(m) }
+func (*ScanEvent) ProtoMessage()    {}
+func (*ScanEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4f2d38e344f9dbf5, []int{16}
+}
+
+func (m *ScanEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScanEvent.Unmarshal(m, b)
+}
+func (m *ScanEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScanEvent.Marshal(b, m, deterministic)
+}
+func (m *ScanEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScanEvent.Merge(m, src)
+}
+func (m *ScanEvent) XXX_Size() int {
+	return xxx_messageInfo_ScanEvent.Size(m)
+}
+func (m *ScanEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScanEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScanEvent proto.InternalMessageInfo
+
+func (m *ScanEvent) GetArtifact() string {
+	if m != nil {
+		return m.Artifact
+	}
+	return ""
+}
+
+func (m *ScanEvent) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *ScanEvent) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+func (m *ScanEvent) GetVulnerabilities() map[string]int32 {
+	if m != nil {
+		return m.Vulnerabilities
+	}
+	return nil
+}
+
+// StatusCheckEvent reports the progress of the status check of a resource
+type StatusCheckEvent struct {
+	Resource             string   `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	Status               string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Message              string   `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	ReadyReplicas        int32    `protobuf:"varint,4,opt,name=readyReplicas,proto3" json:"readyReplicas,omitempty"`
+	TotalReplicas        int32    `protobuf:"varint,5,opt,name=totalReplicas,proto3" json:"totalReplicas,omitempty"`
+	Percent              int32    `protobuf:"varint,6,opt,name=percent,proto3" json:"percent,omitempty"`
+	Events               []string `protobuf:"bytes,7,rep,name=events,proto3" json:"events,omitempty"`
+	Err                  string   `protobuf:"bytes,8,opt,name=err,proto3" json:"err,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StatusCheckEvent) Reset()         { *m = StatusCheckEvent{} }
+func (m *StatusCheckEvent) String() string { return proto.CompactTextString(m) }
+func (*StatusCheckEvent) ProtoMessage()    {}
+func (*StatusCheckEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4f2d38e344f9dbf5, []int{17}
+}
+
+func (m *StatusCheckEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatusCheckEvent.Unmarshal(m, b)
+}
+func (m *StatusCheckEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatusCheckEvent.Marshal(b, m, deterministic)
+}
+func (m *StatusCheckEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatusCheckEvent.Merge(m, src)
+}
+func (m *StatusCheckEvent) XXX_Size() int {
+	return xxx_messageInfo_StatusCheckEvent.Size(m)
+}
+func (m *StatusCheckEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatusCheckEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatusCheckEvent proto.InternalMessageInfo
+
+func (m *StatusCheckEvent) GetResource() string {
+	if m != nil {
+		return m.Resource
+	}
+	return ""
+}
+
+func (m *StatusCheckEvent) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *StatusCheckEvent) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *StatusCheckEvent) GetReadyReplicas() int32 {
+	if m != nil {
+		return m.ReadyReplicas
+	}
+	return 0
+}
+
+func (m *StatusCheckEvent) GetTotalReplicas() int32 {
+	if m != nil {
+		return m.TotalReplicas
+	}
+	return 0
+}
+
+func (m *StatusCheckEvent) GetPercent() int32 {
+	if m != nil {
+		return m.Percent
+	}
+	return 0
+}
+
+func (m *StatusCheckEvent) GetEvents() []string {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *StatusCheckEvent) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+// FileSyncEvent reports the progress of the sync of changed files to an image's containers
+type FileSyncEvent struct {
+	FileCount            int32    `protobuf:"varint,1,opt,name=fileCount,proto3" json:"fileCount,omitempty"`
+	Image                string   `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	Status               string   `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Err                  string   `protobuf:"bytes,4,opt,name=err,proto3" json:"err,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FileSyncEvent) Reset()         { *m = FileSyncEvent{} }
+func (m *FileSyncEvent) String() string { return proto.CompactTextString(m) }
+func (*FileSyncEvent) ProtoMessage()    {}
+func (*FileSyncEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4f2d38e344f9dbf5, []int{18}
+}
+
+func (m *FileSyncEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileSyncEvent.Unmarshal(m, b)
+}
+func (m *FileSyncEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileSyncEvent.Marshal(b, m, deterministic)
+}
+func (m *FileSyncEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileSyncEvent.Merge(m, src)
+}
+func (m *FileSyncEvent) XXX_Size() int {
+	return xxx_messageInfo_FileSyncEvent.Size(m)
+}
+func (m *FileSyncEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileSyncEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileSyncEvent proto.InternalMessageInfo
+
+func (m *FileSyncEvent) GetFileCount() int32 {
+	if m != nil {
+		return m.FileCount
+	}
+	return 0
+}
+
+func (m *FileSyncEvent) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+func (m *FileSyncEvent) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *FileSyncEvent) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+// ErrorEvent describes a failure, with a stable code and a suggestion to fix it
+type ErrorEvent struct {
+	Code                 string   `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message              string   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Suggestion           string   `protobuf:"bytes,3,opt,name=suggestion,proto3" json:"suggestion,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ErrorEvent) Reset()         { *m = ErrorEvent{} }
+func (m *ErrorEvent) String() string { return proto.CompactTextString(m) }
+func (*ErrorEvent) ProtoMessage()    {}
+func (*ErrorEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4f2d38e344f9dbf5, []int{19}
+}
+
+func (m *ErrorEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ErrorEvent.Unmarshal(m, b)
+}
+func (m *ErrorEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ErrorEvent.Marshal(b, m, deterministic)
+}
+func (m *ErrorEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ErrorEvent.Merge(m, src)
+}
+func (m *ErrorEvent) XXX_Size() int {
+	return xxx_messageInfo_ErrorEvent.Size(m)
+}
+func (m *ErrorEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ErrorEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ErrorEvent proto.InternalMessageInfo
+
+func (m *ErrorEvent) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *ErrorEvent) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *ErrorEvent) GetSuggestion() string {
+	if m != nil {
+		return m.Suggestion
+	}
+	return ""
+}
+
 type LogEntry struct {
 	Timestamp            *timestamp.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	Event                *Event               `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
@@ -624,7 +1242,7 @@ func (m *LogEntry) Reset()         { *m = LogEntry{} }
 func (m *LogEntry) String() string { return proto.CompactTextString(m) }
 func (*LogEntry) ProtoMessage()    {}
 func (*LogEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4f2d38e344f9dbf5, []int{11}
+	return fileDescriptor_4f2d38e344f9dbf5, []int{20}
 }
 
 func (m *LogEntry) XXX_Unmarshal(b []byte) error {
@@ -675,64 +1293,106 @@ func init() {
 	proto.RegisterType((*BuildState)(nil), "proto.BuildState")
 	proto.RegisterMapType((map[string]string)(nil), "proto.BuildState.ArtifactsEntry")
 	proto.RegisterType((*DeployState)(nil), "proto.DeployState")
+	proto.RegisterType((*VerifyState)(nil), "proto.VerifyState")
+	proto.RegisterMapType((map[string]string)(nil), "proto.VerifyState.TestsEntry")
+	proto.RegisterType((*ScanState)(nil), "proto.ScanState")
+	proto.RegisterMapType((map[string]string)(nil), "proto.ScanState.ArtifactsEntry")
+	proto.RegisterType((*StatusCheckState)(nil), "proto.StatusCheckState")
+	proto.RegisterMapType((map[string]string)(nil), "proto.StatusCheckState.ResourcesEntry")
+	proto.RegisterType((*FileSyncState)(nil), "proto.FileSyncState")
 	proto.RegisterType((*Event)(nil), "proto.Event")
 	proto.RegisterType((*MetaEvent)(nil), "proto.MetaEvent")
 	proto.RegisterType((*BuildEvent)(nil), "proto.BuildEvent")
 	proto.RegisterType((*DeployEvent)(nil), "proto.DeployEvent")
 	proto.RegisterType((*PortEvent)(nil), "proto.PortEvent")
+	proto.RegisterType((*VerifyEvent)(nil), "proto.VerifyEvent")
+	proto.RegisterType((*ScanEvent)(nil), "proto.ScanEvent")
+	proto.RegisterMapType((map[string]int32)(nil), "proto.ScanEvent.VulnerabilitiesEntry")
+	proto.RegisterType((*StatusCheckEvent)(nil), "proto.StatusCheckEvent")
+	proto.RegisterType((*FileSyncEvent)(nil), "proto.FileSyncEvent")
+	proto.RegisterType((*ErrorEvent)(nil), "proto.ErrorEvent")
 	proto.RegisterType((*LogEntry)(nil), "proto.LogEntry")
 }
 
 func init() { proto.RegisterFile("skaffold.proto", fileDescriptor_4f2d38e344f9dbf5) }
 
 var fileDescriptor_4f2d38e344f9dbf5 = []byte{
-	// 733 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x54, 0x4d, 0x6f, 0xd3, 0x4c,
-	0x10, 0x7e, 0xed, 0x34, 0x69, 0x3c, 0xe9, 0xe7, 0xbe, 0xaf, 0xaa, 0xc8, 0x6f, 0x80, 0xb2, 0x02,
-	0x54, 0x71, 0x48, 0xfa, 0x81, 0xa0, 0xaa, 0x10, 0x12, 0xa5, 0x85, 0x1e, 0x0a, 0x42, 0x0e, 0x77,
-	0xb4, 0x4d, 0x26, 0x21, 0xaa, 0xe3, 0x35, 0xf6, 0x26, 0x28, 0x17, 0x0e, 0x1c, 0xb9, 0x70, 0xe0,
-	0x27, 0xf1, 0x13, 0xf8, 0x0b, 0x88, 0x03, 0xbf, 0x02, 0xed, 0x57, 0xbc, 0x6e, 0xd3, 0x93, 0x77,
-	0x66, 0x9e, 0x67, 0x76, 0x66, 0x1e, 0xef, 0xc0, 0x5a, 0x7e, 0xc9, 0x06, 0x03, 0x1e, 0xf7, 0xdb,
-	0x69, 0xc6, 0x05, 0x27, 0x55, 0xf5, 0x09, 0x5b, 0x43, 0xce, 0x87, 0x31, 0x76, 0x58, 0x3a, 0xea,
-	0xb0, 0x24, 0xe1, 0x82, 0x89, 0x11, 0x4f, 0x72, 0x0d, 0x0a, 0xef, 0x98, 0xa8, 0xb2, 0x2e, 0x26,
-	0x83, 0x8e, 0x18, 0x8d, 0x31, 0x17, 0x6c, 0x9c, 0x1a, 0xc0, 0xff, 0x57, 0x01, 0x38, 0x4e, 0xc5,
-	0x4c, 0x07, 0xe9, 0x01, 0xac, 0x76, 0x05, 0x13, 0x18, 0x61, 0x9e, 0xf2, 0x24, 0x47, 0x42, 0xa1,
-	0x9a, 0x4b, 0x47, 0xd3, 0xdb, 0xf6, 0x76, 0x1a, 0xfb, 0x2b, 0x1a, 0xd7, 0xd6, 0x20, 0x1d, 0xa2,
-	0x2d, 0xa8, 0xcf, 0xf1, 0x1b, 0x50, 0x19, 0xe7, 0x43, 0x85, 0x0e, 0x22, 0x79, 0xa4, 0xb7, 0x60,
-	0x39, 0xc2, 0x8f, 0x13, 0xcc, 0x05, 0x21, 0xb0, 0x94, 0xb0, 0x31, 0x9a, 0xa8, 0x3a, 0xd3, 0x6f,
-	0x3e, 0x54, 0x55, 0x36, 0xb2, 0x07, 0x70, 0x31, 0x19, 0xc5, 0xfd, 0xae, 0x73, 0xdf, 0xa6, 0xb9,
-	0xef, 0x78, 0x1e, 0x88, 0x1c, 0x10, 0x79, 0x04, 0x8d, 0x3e, 0xa6, 0x31, 0x9f, 0x69, 0x8e, 0xaf,
-	0x38, 0xc4, 0x70, 0x4e, 0x8a, 0x48, 0xe4, 0xc2, 0xc8, 0x19, 0xac, 0x0d, 0x78, 0xf6, 0x89, 0x65,
-	0x7d, 0xec, 0xbf, 0xe5, 0x99, 0xc8, 0x9b, 0x95, 0xed, 0xca, 0x4e, 0x63, 0x7f, 0xdb, 0x6d, 0xae,
-	0xfd, 0xb2, 0x04, 0x39, 0x4d, 0x44, 0x36, 0x8b, 0xae, 0xf0, 0xc2, 0x2e, 0xfc, 0xbb, 0x00, 0x26,
-	0x87, 0x70, 0x89, 0x33, 0x3b, 0x84, 0x4b, 0x9c, 0x91, 0x07, 0x50, 0x9d, 0xb2, 0x78, 0x62, 0x4b,
-	0xdc, 0x30, 0x37, 0x49, 0xce, 0xe9, 0x14, 0x13, 0x11, 0xe9, 0xf0, 0x91, 0x7f, 0xe8, 0xd1, 0xaf,
-	0x1e, 0x40, 0xd1, 0x2f, 0x79, 0x06, 0x01, 0xcb, 0xc4, 0x68, 0xc0, 0x7a, 0x22, 0x6f, 0x7a, 0xa5,
-	0x42, 0x0b, 0x54, 0xfb, 0xb9, 0x85, 0xe8, 0x42, 0x0b, 0x4a, 0xf8, 0x14, 0xd6, 0xca, 0xc1, 0x05,
-	0xe5, 0xfd, 0xe7, 0x96, 0x17, 0xb8, 0xc5, 0xdc, 0x87, 0x86, 0x33, 0x47, 0xb2, 0x05, 0x35, 0xa9,
-	0xf9, 0x24, 0x37, 0x6c, 0x63, 0xd1, 0xdf, 0x1e, 0x54, 0x55, 0x23, 0x64, 0x17, 0x82, 0x31, 0x0a,
-	0xa6, 0x0c, 0x23, 0xa2, 0xed, 0xf6, 0xb5, 0xf5, 0x9f, 0xfd, 0x13, 0x15, 0x20, 0x72, 0x60, 0x74,
-	0xd7, 0x14, 0xff, 0xba, 0xee, 0x96, 0xe3, 0xc0, 0xc8, 0x63, 0xab, 0xbc, 0x66, 0x55, 0x16, 0x28,
-	0x6f, 0x69, 0x2e, 0x50, 0x96, 0x97, 0xda, 0xa1, 0x37, 0x97, 0x16, 0x8b, 0x21, 0xcb, 0x9b, 0x83,
-	0x8e, 0x57, 0x00, 0x50, 0x1e, 0xde, 0x8b, 0x59, 0x8a, 0xf4, 0x2e, 0x04, 0xf3, 0x36, 0xe4, 0xd8,
-	0x50, 0x4e, 0xd4, 0x0c, 0x43, 0x1b, 0x34, 0x32, 0xf2, 0x69, 0x4c, 0x08, 0x75, 0xab, 0x85, 0x81,
-	0xcd, 0x6d, 0x67, 0x9a, 0xbe, 0x3b, 0x4d, 0x29, 0x10, 0x66, 0x99, 0x6a, 0x2a, 0x88, 0xe4, 0x91,
-	0x3e, 0xb1, 0x32, 0xe8, 0xa4, 0x37, 0xc8, 0x60, 0x89, 0x7e, 0x41, 0xfc, 0xe1, 0x41, 0x30, 0x6f,
-	0x8c, 0xb4, 0x20, 0x88, 0x79, 0x8f, 0xc5, 0xd2, 0xa3, 0xa8, 0xd5, 0xa8, 0x70, 0x90, 0xdb, 0x00,
-	0x19, 0x8e, 0xb9, 0x40, 0x15, 0xf6, 0x55, 0xd8, 0xf1, 0x90, 0x26, 0x2c, 0xa7, 0xbc, 0xff, 0x46,
-	0xbe, 0x60, 0x5d, 0x9a, 0x35, 0xc9, 0x3d, 0x58, 0xed, 0xf1, 0x44, 0xb0, 0x51, 0x82, 0x99, 0x8a,
-	0x2f, 0xa9, 0x78, 0xd9, 0x29, 0x6f, 0x97, 0x4f, 0x3e, 0x4f, 0x59, 0x0f, 0x9b, 0x55, 0x85, 0x28,
-	0x1c, 0x72, 0x50, 0x72, 0xe8, 0x8a, 0x5e, 0xd3, 0x83, 0xb2, 0x36, 0xfd, 0x0c, 0xf5, 0x73, 0x3e,
-	0xd4, 0x7f, 0xef, 0x21, 0x04, 0xf3, 0x95, 0x66, 0x7e, 0xb0, 0xb0, 0xad, 0x77, 0x5a, 0xdb, 0xee,
-	0xb4, 0xf6, 0x3b, 0x8b, 0x88, 0x0a, 0xb0, 0xdc, 0x65, 0xe8, 0xfc, 0x63, 0x76, 0x97, 0x99, 0x07,
-	0x88, 0x65, 0x49, 0x2b, 0x8e, 0xa4, 0xfb, 0x7f, 0x3c, 0x58, 0xef, 0x9a, 0x65, 0xdc, 0xc5, 0x6c,
-	0x3a, 0xea, 0x21, 0x79, 0x01, 0xf5, 0x57, 0x28, 0xcc, 0xb3, 0xb8, 0x56, 0xc0, 0xa9, 0x5c, 0xaa,
-	0x61, 0x69, 0x5d, 0xd2, 0xcd, 0x2f, 0x3f, 0x7f, 0x7d, 0xf7, 0x1b, 0x24, 0xe8, 0x4c, 0xf7, 0x3a,
-	0x6a, 0x75, 0x92, 0x13, 0xa8, 0xab, 0xeb, 0xcf, 0xf9, 0x90, 0xac, 0x1b, 0xb0, 0xed, 0x34, 0xbc,
-	0xea, 0xa0, 0x44, 0x25, 0x58, 0x21, 0x20, 0x13, 0xa8, 0x7a, 0xf3, 0x1d, 0x6f, 0xd7, 0x23, 0xe7,
-	0x50, 0x3b, 0x63, 0x49, 0x3f, 0x46, 0x52, 0xea, 0x29, 0xbc, 0xa1, 0x2c, 0xda, 0x52, 0x79, 0xb6,
-	0xe8, 0x66, 0x91, 0xa7, 0xf3, 0x41, 0x25, 0x38, 0xf2, 0x1e, 0x5e, 0xd4, 0x14, 0xfa, 0xe0, 0x6f,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0xa9, 0xb2, 0x6a, 0x4c, 0x7f, 0x06, 0x00, 0x00,
+	// 1200 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0xcd, 0x6f, 0x1b, 0x45,
+	0x14, 0x67, 0x6d, 0xaf, 0xed, 0x7d, 0xce, 0xe7, 0x10, 0x15, 0xcb, 0xa4, 0x6d, 0x58, 0xd1, 0x12,
+	0x71, 0xb0, 0xdb, 0x04, 0x41, 0x14, 0x15, 0x24, 0x92, 0xa6, 0x44, 0x22, 0x7c, 0x68, 0x5c, 0xf5,
+	0xc0, 0x05, 0x4d, 0xd6, 0x63, 0x77, 0x95, 0xf5, 0xae, 0xd9, 0x19, 0x1b, 0x59, 0x42, 0x1c, 0x38,
+	0xa1, 0x5e, 0x91, 0xf8, 0xc7, 0xf8, 0x17, 0x10, 0x07, 0x4e, 0x5c, 0xe0, 0x8c, 0xe6, 0x6b, 0x77,
+	0xc6, 0x1f, 0x42, 0x91, 0xe8, 0xc9, 0x3b, 0xf3, 0x7e, 0xbf, 0x79, 0x6f, 0xde, 0x7b, 0xf3, 0x9b,
+	0x31, 0x6c, 0xb1, 0x1b, 0x32, 0x1c, 0x66, 0xc9, 0xa0, 0x3b, 0xc9, 0x33, 0x9e, 0x21, 0x5f, 0xfe,
+	0x74, 0xf6, 0x47, 0x59, 0x36, 0x4a, 0x68, 0x8f, 0x4c, 0xe2, 0x1e, 0x49, 0xd3, 0x8c, 0x13, 0x1e,
+	0x67, 0x29, 0x53, 0xa0, 0xce, 0x7d, 0x6d, 0x95, 0xa3, 0xeb, 0xe9, 0xb0, 0xc7, 0xe3, 0x31, 0x65,
+	0x9c, 0x8c, 0x27, 0x1a, 0xf0, 0xf6, 0x22, 0x80, 0x8e, 0x27, 0x7c, 0xae, 0x8c, 0xe1, 0x31, 0x6c,
+	0xf6, 0x39, 0xe1, 0x14, 0x53, 0x36, 0xc9, 0x52, 0x46, 0x51, 0x08, 0x3e, 0x13, 0x13, 0x6d, 0xef,
+	0xc0, 0x3b, 0x6c, 0x1d, 0x6d, 0x28, 0x5c, 0x57, 0x81, 0x94, 0x29, 0xdc, 0x87, 0x66, 0x81, 0xdf,
+	0x81, 0xea, 0x98, 0x8d, 0x24, 0x3a, 0xc0, 0xe2, 0x33, 0xbc, 0x0b, 0x0d, 0x4c, 0xbf, 0x9b, 0x52,
+	0xc6, 0x11, 0x82, 0x5a, 0x4a, 0xc6, 0x54, 0x5b, 0xe5, 0x77, 0xf8, 0x4f, 0x15, 0x7c, 0xb9, 0x1a,
+	0x7a, 0x0c, 0x70, 0x3d, 0x8d, 0x93, 0x41, 0xdf, 0xf2, 0xb7, 0xab, 0xfd, 0x9d, 0x15, 0x06, 0x6c,
+	0x81, 0xd0, 0x07, 0xd0, 0x1a, 0xd0, 0x49, 0x92, 0xcd, 0x15, 0xa7, 0x22, 0x39, 0x48, 0x73, 0x9e,
+	0x96, 0x16, 0x6c, 0xc3, 0xd0, 0x25, 0x6c, 0x0d, 0xb3, 0xfc, 0x7b, 0x92, 0x0f, 0xe8, 0xe0, 0xeb,
+	0x2c, 0xe7, 0xac, 0x5d, 0x3d, 0xa8, 0x1e, 0xb6, 0x8e, 0x0e, 0xec, 0xcd, 0x75, 0x9f, 0x39, 0x90,
+	0x8b, 0x94, 0xe7, 0x73, 0xbc, 0xc0, 0x13, 0xfe, 0x67, 0x34, 0x8f, 0x87, 0xda, 0x7f, 0xcd, 0xf1,
+	0xff, 0xa2, 0xb4, 0x60, 0x1b, 0x86, 0xba, 0x10, 0xb0, 0x88, 0xa4, 0x8a, 0xe3, 0x4b, 0xce, 0x8e,
+	0x71, 0x6d, 0xe6, 0x71, 0x09, 0x41, 0xe7, 0xb0, 0x23, 0x12, 0x3d, 0x65, 0xe7, 0x2f, 0x69, 0x74,
+	0xa3, 0x68, 0x75, 0x49, 0x7b, 0xcb, 0x8a, 0xd8, 0x36, 0xe3, 0x25, 0x02, 0x3a, 0x85, 0xcd, 0x61,
+	0x9c, 0xd0, 0xfe, 0x3c, 0x8d, 0xd4, 0x0a, 0x0d, 0xb9, 0xc2, 0x9e, 0x5e, 0xe1, 0x99, 0x6d, 0xc3,
+	0x2e, 0xb4, 0xd3, 0x87, 0x37, 0x57, 0x64, 0x43, 0xd4, 0xfa, 0x86, 0xce, 0x4d, 0xad, 0x6f, 0xe8,
+	0x1c, 0x3d, 0x04, 0x7f, 0x46, 0x92, 0xa9, 0xa9, 0x84, 0xd9, 0x95, 0xe0, 0x5c, 0xcc, 0x68, 0xca,
+	0xb1, 0x32, 0x9f, 0x56, 0x4e, 0xbc, 0xf0, 0x95, 0x07, 0x50, 0x96, 0x15, 0x7d, 0x02, 0x01, 0xc9,
+	0x79, 0x3c, 0x24, 0x11, 0x67, 0x6d, 0xcf, 0xa9, 0x47, 0x89, 0xea, 0x7e, 0x6a, 0x20, 0xaa, 0x1e,
+	0x25, 0xa5, 0xf3, 0x04, 0xb6, 0x5c, 0xe3, 0x8a, 0xf0, 0xf6, 0xec, 0xf0, 0x02, 0x3b, 0x98, 0x07,
+	0xd0, 0xb2, 0xda, 0x05, 0xdd, 0x81, 0xba, 0x4a, 0xa0, 0x66, 0xeb, 0x51, 0xf8, 0x03, 0xb4, 0xac,
+	0xaa, 0xa2, 0x63, 0xf0, 0x39, 0x65, 0x45, 0xbc, 0x77, 0x97, 0x0b, 0xdf, 0x7d, 0x4e, 0x99, 0x8e,
+	0x07, 0x2b, 0x6c, 0xe7, 0x04, 0xa0, 0x9c, 0xbc, 0x55, 0x90, 0x3f, 0x7b, 0x10, 0x14, 0x0d, 0x82,
+	0x3e, 0x5e, 0x4e, 0xd8, 0xfd, 0xc5, 0x2e, 0x7a, 0x6d, 0xf9, 0xfa, 0xd5, 0x83, 0x9d, 0xc5, 0xa6,
+	0x43, 0x4f, 0x21, 0xc8, 0x29, 0xcb, 0xa6, 0x79, 0x44, 0x4d, 0x44, 0x0f, 0xd7, 0x34, 0x68, 0x17,
+	0x1b, 0xa0, 0x0e, 0xac, 0x20, 0x8a, 0xc0, 0x5c, 0xe3, 0xad, 0x02, 0x7b, 0x0f, 0x36, 0x9d, 0x56,
+	0x5e, 0x5b, 0xca, 0x57, 0x35, 0xf0, 0x65, 0x4f, 0xa2, 0x47, 0x10, 0x8c, 0x29, 0x27, 0x72, 0xd0,
+	0xf6, 0x9c, 0xc6, 0xfd, 0xc2, 0xcc, 0x5f, 0xbe, 0x81, 0x4b, 0x10, 0x3a, 0xd6, 0x4a, 0xa5, 0x28,
+	0x95, 0x65, 0xa5, 0x32, 0x1c, 0x0b, 0x86, 0x3e, 0x34, 0x5a, 0xa5, 0x58, 0xd5, 0x15, 0x5a, 0x65,
+	0x68, 0x36, 0x50, 0x84, 0x37, 0x31, 0xe7, 0xa7, 0x5d, 0x73, 0xc2, 0x2b, 0xce, 0x95, 0x08, 0xaf,
+	0x00, 0x09, 0x4f, 0x4a, 0x6e, 0x14, 0xc7, 0x5f, 0xa1, 0x4a, 0x85, 0x27, 0x0b, 0x28, 0x3c, 0x09,
+	0xd1, 0x51, 0xac, 0xfa, 0x92, 0x2e, 0x15, 0x9e, 0x0a, 0x90, 0x48, 0x04, 0xcd, 0xf3, 0x2c, 0x57,
+	0x94, 0x86, 0x93, 0x88, 0x8b, 0xc2, 0x20, 0x12, 0x51, 0xc2, 0xd0, 0x85, 0x23, 0x67, 0x8a, 0xda,
+	0x5c, 0x27, 0x67, 0x66, 0x81, 0x25, 0x0a, 0x7a, 0x52, 0x0a, 0x9a, 0x5a, 0x23, 0x58, 0x29, 0x68,
+	0x66, 0x01, 0x17, 0x7c, 0xb6, 0x01, 0x40, 0xc5, 0xc7, 0xb7, 0x7c, 0x3e, 0xa1, 0xe1, 0x3b, 0x10,
+	0x14, 0xa5, 0x16, 0xcd, 0x45, 0x45, 0xdf, 0xe9, 0x86, 0x51, 0x83, 0x10, 0x6b, 0xb5, 0x52, 0x98,
+	0x0e, 0x34, 0xcd, 0x51, 0xd2, 0xb0, 0x62, 0x6c, 0x75, 0x5c, 0xc5, 0xee, 0x38, 0xd1, 0xc6, 0x34,
+	0xcf, 0x65, 0xe1, 0x03, 0x2c, 0x3e, 0xc3, 0x8f, 0x8c, 0xea, 0xa8, 0x45, 0xd7, 0xb4, 0xaa, 0x21,
+	0x56, 0x4a, 0xe2, 0xdf, 0x1e, 0x04, 0x45, 0xf1, 0xd1, 0x3e, 0x04, 0x49, 0x16, 0x91, 0x44, 0xcc,
+	0x48, 0xaa, 0x8f, 0xcb, 0x09, 0x74, 0x0f, 0x20, 0xa7, 0xe3, 0x8c, 0x53, 0x69, 0xae, 0x48, 0xb3,
+	0x35, 0x83, 0xda, 0xd0, 0x98, 0x64, 0x83, 0x2f, 0xc5, 0xbd, 0xac, 0x42, 0x33, 0x43, 0xf4, 0x2e,
+	0x6c, 0x46, 0x59, 0xca, 0x49, 0x9c, 0xd2, 0x5c, 0xda, 0x6b, 0xd2, 0xee, 0x4e, 0x0a, 0xef, 0xe2,
+	0x22, 0x67, 0x13, 0x12, 0xa9, 0xdb, 0x2c, 0xc0, 0xe5, 0x84, 0x48, 0x94, 0x68, 0x4c, 0x49, 0xaf,
+	0xab, 0x44, 0x99, 0xb1, 0xb5, 0xdf, 0x86, 0xb3, 0xdf, 0x36, 0x34, 0xc8, 0x60, 0x90, 0x53, 0xc6,
+	0x64, 0x5f, 0x04, 0xd8, 0x0c, 0xc3, 0xcf, 0x8d, 0xfe, 0xaa, 0x8d, 0xaf, 0x78, 0x4f, 0xdc, 0x22,
+	0xfb, 0x7f, 0x68, 0x39, 0xfd, 0x1f, 0x2b, 0x8a, 0xbe, 0x82, 0xed, 0xd9, 0x34, 0x49, 0x69, 0x4e,
+	0xae, 0xe3, 0x24, 0xe6, 0x31, 0x65, 0xed, 0x9a, 0x14, 0xc2, 0x07, 0x8b, 0x07, 0xa9, 0xfb, 0xc2,
+	0xc5, 0x29, 0x1d, 0x5c, 0x64, 0x77, 0xce, 0x60, 0x6f, 0x15, 0xf0, 0xbf, 0x34, 0xd1, 0xb7, 0x35,
+	0xf1, 0x2f, 0x57, 0xac, 0x8b, 0xfd, 0x1a, 0xcd, 0x35, 0xfb, 0x35, 0xe3, 0xb5, 0xfb, 0x6d, 0x43,
+	0x63, 0x4c, 0x19, 0x23, 0xa3, 0xa2, 0x55, 0xf4, 0x50, 0xb4, 0x4a, 0x4e, 0xc9, 0x60, 0x8e, 0xe9,
+	0x24, 0x89, 0x23, 0xc2, 0x64, 0xab, 0xf8, 0xd8, 0x9d, 0x14, 0x28, 0x9e, 0x71, 0x92, 0x14, 0x28,
+	0x5f, 0xa1, 0x9c, 0x49, 0xd9, 0x90, 0x34, 0x8f, 0x8c, 0x08, 0xf9, 0xd8, 0x0c, 0x45, 0x5c, 0xf2,
+	0xd0, 0x8a, 0x86, 0xa9, 0x8a, 0xb8, 0xd4, 0xc8, 0xd4, 0xa1, 0x59, 0xd6, 0x76, 0x5c, 0x5e, 0x03,
+	0xc5, 0x19, 0x11, 0x02, 0x70, 0x9e, 0x4d, 0xd3, 0xe2, 0x8c, 0x14, 0x13, 0x22, 0x77, 0xf1, 0x58,
+	0x6c, 0x4b, 0xdf, 0x27, 0x72, 0x60, 0xa5, 0xa1, 0xba, 0xaa, 0xec, 0xb5, 0xd2, 0xdd, 0x37, 0x00,
+	0xa5, 0xdc, 0x89, 0xb6, 0x8c, 0xb2, 0x41, 0xd1, 0x96, 0xe2, 0xdb, 0x4e, 0x5d, 0xc5, 0x4d, 0xdd,
+	0x3d, 0x00, 0x36, 0x1d, 0x8d, 0x28, 0x13, 0xaf, 0x78, 0xed, 0xc9, 0x9a, 0x09, 0x7f, 0x84, 0xe6,
+	0x55, 0x36, 0x52, 0x55, 0x3f, 0x81, 0xa0, 0x78, 0xce, 0xeb, 0xab, 0xaa, 0xd3, 0x55, 0xef, 0xf9,
+	0xae, 0x79, 0xcf, 0x77, 0x9f, 0x1b, 0x04, 0x2e, 0xc1, 0xe2, 0x1d, 0x4f, 0xad, 0xdb, 0xca, 0xbc,
+	0xe3, 0xf5, 0xab, 0x8c, 0xba, 0xc2, 0x57, 0xb5, 0x84, 0xef, 0xe8, 0x4f, 0x0f, 0xb6, 0xfb, 0xfa,
+	0x8f, 0x48, 0x9f, 0xe6, 0xb3, 0x38, 0x12, 0x2f, 0xd2, 0xe6, 0x67, 0x94, 0xeb, 0x0b, 0x76, 0x29,
+	0x80, 0x0b, 0xf1, 0x87, 0xa2, 0xe3, 0xfc, 0x55, 0x08, 0x77, 0x7f, 0xfa, 0xed, 0xf7, 0x5f, 0x2a,
+	0x2d, 0x14, 0xf4, 0x66, 0x8f, 0x7b, 0x4c, 0x3f, 0x17, 0x9a, 0xd2, 0xfd, 0x55, 0x36, 0x42, 0xdb,
+	0x1a, 0x6c, 0x76, 0xda, 0x59, 0x9c, 0x08, 0x91, 0x5c, 0x60, 0x03, 0x81, 0x58, 0x40, 0xd5, 0xfd,
+	0xd0, 0x7b, 0xe4, 0xa1, 0x2b, 0xa8, 0x5f, 0x92, 0x74, 0x90, 0x50, 0xe4, 0xec, 0xa9, 0xb3, 0x26,
+	0xac, 0x70, 0x5f, 0xae, 0x73, 0xe7, 0xd4, 0x7b, 0x3f, 0xdc, 0x2d, 0x97, 0xea, 0xbd, 0x94, 0x6b,
+	0x5c, 0xd7, 0x25, 0xfa, 0xf8, 0xdf, 0x01, 0x00, 0x8b, 0x5f, 0x98, 0x78, 0x7b, 0x0d, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -0,0 +1,304 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package initializer
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/sirupsen/logrus"
+)
+
+// InitBuilder is a way of building an image that `skaffold init` can propose.
+type InitBuilder interface {
+	// Name returns the name of the builder.
+	Name() string
+	// Path returns the path to the build definition.
+	Path() string
+	// Describe returns the text shown when prompting the user to choose a builder.
+	Describe() string
+	// CreateArtifact creates the artifact that builds the given image.
+	CreateArtifact(image string) *latest.Artifact
+}
+
+// dockerfile builds an image from a Dockerfile.
+type dockerfile struct {
+	path string
+}
+
+func (d dockerfile) Name() string     { return "Docker" }
+func (d dockerfile) Path() string     { return d.path }
+func (d dockerfile) Describe() string { return d.path }
+
+func (d dockerfile) CreateArtifact(image string) *latest.Artifact {
+	a := newArtifact(image, filepath.Dir(d.path))
+	if dockerfilePath := filepath.Base(d.path); dockerfilePath != constants.DefaultDockerfilePath {
+		a.ArtifactType = latest.ArtifactType{
+			DockerArtifact: &latest.DockerArtifact{
+				DockerfilePath: dockerfilePath,
+			},
+		}
+	}
+	return a
+}
+
+// jibMaven builds an image from a Maven project that uses the Jib plugin.
+type jibMaven struct {
+	path      string
+	workspace string
+	module    string
+}
+
+func (j jibMaven) Name() string     { return "Jib Maven Plugin" }
+func (j jibMaven) Path() string     { return j.path }
+func (j jibMaven) Describe() string { return fmt.Sprintf("%s (%s)", j.Name(), j.path) }
+
+func (j jibMaven) CreateArtifact(image string) *latest.Artifact {
+	a := newArtifact(image, j.workspace)
+	a.ArtifactType = latest.ArtifactType{
+		JibMavenArtifact: &latest.JibMavenArtifact{
+			Module: j.module,
+		},
+	}
+	return a
+}
+
+// jibGradle builds an image from a Gradle project that uses the Jib plugin.
+type jibGradle struct {
+	path string
+}
+
+func (j jibGradle) Name() string     { return "Jib Gradle Plugin" }
+func (j jibGradle) Path() string     { return j.path }
+func (j jibGradle) Describe() string { return fmt.Sprintf("%s (%s)", j.Name(), j.path) }
+
+func (j jibGradle) CreateArtifact(image string) *latest.Artifact {
+	a := newArtifact(image, filepath.Dir(j.path))
+	a.ArtifactType = latest.ArtifactType{
+		JibGradleArtifact: &latest.JibGradleArtifact{},
+	}
+	return a
+}
+
+// bazel builds an image from a rules_docker target.
+type bazel struct {
+	path      string
+	workspace string
+	target    string
+}
+
+func (b bazel) Name() string     { return "Bazel" }
+func (b bazel) Path() string     { return b.path }
+func (b bazel) Describe() string { return fmt.Sprintf("%s (%s)", b.Name(), b.target) }
+
+func (b bazel) CreateArtifact(image string) *latest.Artifact {
+	a := newArtifact(image, b.workspace)
+	a.ArtifactType = latest.ArtifactType{
+		BazelArtifact: &latest.BazelArtifact{
+			BuildTarget: b.target,
+		},
+	}
+	return a
+}
+
+func newArtifact(image, workspace string) *latest.Artifact {
+	a := &latest.Artifact{
+		ImageName: image,
+	}
+	if workspace != "." {
+		a.Workspace = workspace
+	}
+	return a
+}
+
+var (
+	bazelImageRule = regexp.MustCompile(`\b(container_image|cc_image|d_image|go_image|groovy_image|java_image|nodejs_image|py_image|py3_image|rust_image|scala_image|war_image)\s*\(`)
+	bazelName      = regexp.MustCompile(`^\s*name\s*=\s*"([^"]+)"`)
+
+	// Files that buildpacks know how to build, without a Dockerfile.
+	buildpacksFiles = []string{"package.json", "go.mod", "pom.xml", "build.gradle", "requirements.txt", "Gemfile"}
+)
+
+// detectBuilders returns the builders that can build images from the given file.
+func detectBuilders(root, path string, validateDockerfile func(string) bool) []InitBuilder {
+	switch filepath.Base(path) {
+	case "pom.xml":
+		if j, ok := detectJibMaven(root, path); ok {
+			return []InitBuilder{j}
+		}
+		return nil
+	case "build.gradle", "build.gradle.kts":
+		if fileContains(path, "com.google.cloud.tools.jib") {
+			return []InitBuilder{jibGradle{path: path}}
+		}
+		return nil
+	case "BUILD", "BUILD.bazel":
+		return detectBazelTargets(root, path)
+	}
+
+	if validateDockerfile(path) {
+		logrus.Infof("existing dockerfile found: %s", path)
+		return []InitBuilder{dockerfile{path: path}}
+	}
+	return nil
+}
+
+// detectJibMaven recognizes Maven projects using the Jib plugin. Modules of
+// a multi-module project are built from the directory of their parent.
+func detectJibMaven(root, path string) (jibMaven, bool) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil || !strings.Contains(string(content), "jib-maven-plugin") || strings.Contains(string(content), "<packaging>pom</packaging>") {
+		return jibMaven{}, false
+	}
+
+	workspace := filepath.Dir(path)
+	for dir := workspace; dir != root && dir != "." && dir != filepath.Dir(dir); {
+		dir = filepath.Dir(dir)
+		if _, err := os.Stat(filepath.Join(dir, "pom.xml")); err != nil {
+			break
+		}
+		workspace = dir
+	}
+
+	var module string
+	if dir := filepath.Dir(path); dir != workspace {
+		rel, err := filepath.Rel(workspace, dir)
+		if err != nil {
+			return jibMaven{}, false
+		}
+		module = filepath.ToSlash(rel)
+	}
+
+	logrus.Infof("existing Jib Maven project found: %s", path)
+	return jibMaven{path: path, workspace: workspace, module: module}, true
+}
+
+// detectBazelTargets lists the image targets of a BUILD file, relative to
+// the closest Bazel workspace.
+func detectBazelTargets(root, path string) []InitBuilder {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	workspace, ok := findBazelWorkspace(root, filepath.Dir(path))
+	if !ok {
+		logrus.Debugf("skipping %s outside of a Bazel workspace", path)
+		return nil
+	}
+	pkg, err := filepath.Rel(workspace, filepath.Dir(path))
+	if err != nil {
+		return nil
+	}
+	if pkg == "." {
+		pkg = ""
+	}
+
+	var builders []InitBuilder
+	for _, name := range bazelImageNames(string(content)) {
+		target := fmt.Sprintf("//%s:%s.tar", filepath.ToSlash(pkg), name)
+		logrus.Infof("existing Bazel image target found: %s", target)
+		builders = append(builders, bazel{path: path, workspace: workspace, target: target})
+	}
+	return builders
+}
+
+func findBazelWorkspace(root, dir string) (string, bool) {
+	for {
+		for _, file := range []string{"WORKSPACE", "WORKSPACE.bazel"} {
+			if _, err := os.Stat(filepath.Join(dir, file)); err == nil {
+				return dir, true
+			}
+		}
+		if dir == root || dir == "." || dir == filepath.Dir(dir) {
+			return "", false
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// bazelImageNames returns the names of the image rules declared in a BUILD file.
+func bazelImageNames(content string) []string {
+	var names []string
+	for _, loc := range bazelImageRule.FindAllStringIndex(content, -1) {
+		if name, ok := bazelRuleName(content[loc[1]:]); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// bazelRuleName finds the name attribute among the top level arguments of a rule.
+func bazelRuleName(args string) (string, bool) {
+	depth := 0
+	for i := 0; i < len(args); i++ {
+		if depth == 0 && (i == 0 || args[i-1] == ',') {
+			if m := bazelName.FindStringSubmatch(args[i:]); m != nil {
+				return m[1], true
+			}
+		}
+
+		switch args[i] {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			if depth == 0 {
+				return "", false
+			}
+			depth--
+		}
+	}
+	return "", false
+}
+
+// warnAboutBuildpacksProjects warns about the directories that buildpacks could build
+// but for which no builder was found, since Skaffold doesn't support buildpacks.
+func warnAboutBuildpacksProjects(files []string, builders []InitBuilder) {
+	dirs := map[string]bool{}
+	for _, b := range builders {
+		dirs[filepath.Dir(b.Path())] = true
+	}
+
+	for _, file := range files {
+		dir := filepath.Dir(file)
+		if dirs[dir] {
+			continue
+		}
+		dirs[dir] = true
+		logrus.Warnf("%s looks like a project that buildpacks could build, but Skaffold needs a Dockerfile, Jib or Bazel to build it", dir)
+	}
+}
+
+func isBuildpacksFile(path string) bool {
+	for _, file := range buildpacksFiles {
+		if filepath.Base(path) == file {
+			return true
+		}
+	}
+	return false
+}
+
+func fileContains(path, s string) bool {
+	content, err := ioutil.ReadFile(path)
+	return err == nil && strings.Contains(string(content), s)
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package initializer
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestDetectBuilders(t *testing.T) {
+	tests := []struct {
+		description       string
+		filesWithContents map[string]string
+		path              string
+		expected          []*latest.Artifact
+	}{
+		{
+			description:       "dockerfile",
+			filesWithContents: map[string]string{"web/dev.Dockerfile": ""},
+			path:              "web/dev.Dockerfile",
+			expected: []*latest.Artifact{{
+				ImageName:    "image",
+				Workspace:    "web",
+				ArtifactType: latest.ArtifactType{DockerArtifact: &latest.DockerArtifact{DockerfilePath: "dev.Dockerfile"}},
+			}},
+		},
+		{
+			description:       "jib maven",
+			filesWithContents: map[string]string{"pom.xml": "<artifactId>jib-maven-plugin</artifactId>"},
+			path:              "pom.xml",
+			expected: []*latest.Artifact{{
+				ImageName:    "image",
+				ArtifactType: latest.ArtifactType{JibMavenArtifact: &latest.JibMavenArtifact{}},
+			}},
+		},
+		{
+			description: "jib maven module",
+			filesWithContents: map[string]string{
+				"pom.xml":          "<packaging>pom</packaging><artifactId>jib-maven-plugin</artifactId>",
+				"project1/pom.xml": "<artifactId>jib-maven-plugin</artifactId>",
+			},
+			path: "project1/pom.xml",
+			expected: []*latest.Artifact{{
+				ImageName:    "image",
+				ArtifactType: latest.ArtifactType{JibMavenArtifact: &latest.JibMavenArtifact{Module: "project1"}},
+			}},
+		},
+		{
+			description:       "maven parent pom",
+			filesWithContents: map[string]string{"pom.xml": "<packaging>pom</packaging><artifactId>jib-maven-plugin</artifactId>"},
+			path:              "pom.xml",
+		},
+		{
+			description:       "jib gradle",
+			filesWithContents: map[string]string{"app/build.gradle": "plugins { id 'com.google.cloud.tools.jib' version '1.3.0' }"},
+			path:              "app/build.gradle",
+			expected: []*latest.Artifact{{
+				ImageName:    "image",
+				Workspace:    "app",
+				ArtifactType: latest.ArtifactType{JibGradleArtifact: &latest.JibGradleArtifact{}},
+			}},
+		},
+		{
+			description:       "gradle without jib",
+			filesWithContents: map[string]string{"build.gradle": "plugins { id 'java' }"},
+			path:              "build.gradle",
+		},
+		{
+			description: "bazel targets",
+			filesWithContents: map[string]string{
+				"WORKSPACE": "",
+				"cmd/app/BUILD.bazel": `
+container_image(
+    base = select({":debug": "@base_debug//image", "//conditions:default": "@base//image"}),
+    name = "app",
+)
+
+java_image(name = "server", srcs = ["Main.java"])
+`,
+			},
+			path: "cmd/app/BUILD.bazel",
+			expected: []*latest.Artifact{
+				{ImageName: "image", ArtifactType: latest.ArtifactType{BazelArtifact: &latest.BazelArtifact{BuildTarget: "//cmd/app:app.tar"}}},
+				{ImageName: "image", ArtifactType: latest.ArtifactType{BazelArtifact: &latest.BazelArtifact{BuildTarget: "//cmd/app:server.tar"}}},
+			},
+		},
+		{
+			description:       "bazel outside of a workspace",
+			filesWithContents: map[string]string{"BUILD": `go_image(name = "app")`},
+			path:              "BUILD",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			tmpDir, cleanup := testutil.NewTempDir(t)
+			defer cleanup()
+			writeAllFiles(tmpDir, test.filesWithContents)
+
+			var artifacts []*latest.Artifact
+			for _, b := range detectBuilders(tmpDir.Root(), tmpDir.Path(test.path), testValidDocker) {
+				a := b.CreateArtifact("image")
+				a.Workspace = relativeWorkspace(tmpDir, a.Workspace)
+				artifacts = append(artifacts, a)
+			}
+
+			testutil.CheckDeepEqual(t, test.expected, artifacts)
+		})
+	}
+}
+
+func TestBuilderForPath(t *testing.T) {
+	testutil.CheckDeepEqual(t, &latest.Artifact{
+		ImageName:    "image",
+		Workspace:    "java",
+		ArtifactType: latest.ArtifactType{JibMavenArtifact: &latest.JibMavenArtifact{}},
+	}, builderForPath("java/pom.xml").CreateArtifact("image"))
+
+	testutil.CheckDeepEqual(t, &latest.Artifact{
+		ImageName: "image",
+		Workspace: "web",
+	}, builderForPath("web/Dockerfile").CreateArtifact("image"))
+}
+
+// relativeWorkspace makes a workspace relative to the test directory.
+func relativeWorkspace(tmpDir *testutil.TempDir, workspace string) string {
+	if workspace == "" || workspace == tmpDir.Root() {
+		return ""
+	}
+	return workspace[len(tmpDir.Root())+1:]
+}
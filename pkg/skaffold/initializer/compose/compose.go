@@ -0,0 +1,231 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Project is the subset of a docker-compose file that Skaffold translates.
+type Project struct {
+	Services map[string]*Service `yaml:"services"`
+}
+
+// Service is a docker-compose service.
+type Service struct {
+	Image       string      `yaml:"image"`
+	Build       *Build      `yaml:"build"`
+	Ports       []Port      `yaml:"ports"`
+	Environment stringMap   `yaml:"environment"`
+	EnvFile     stringOrSeq `yaml:"env_file"`
+	Entrypoint  stringOrSeq `yaml:"entrypoint"`
+	Command     stringOrSeq `yaml:"command"`
+	Profiles    []string    `yaml:"profiles"`
+}
+
+// Build describes how the image of a service is built.
+type Build struct {
+	Context    string    `yaml:"context"`
+	Dockerfile string    `yaml:"dockerfile"`
+	Target     string    `yaml:"target"`
+	Args       stringMap `yaml:"args"`
+}
+
+// Port is a port published by a service.
+type Port struct {
+	Target    int
+	Published int
+	Protocol  string
+}
+
+// Load reads a docker-compose file.
+func Load(path string) (*Project, error) {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading docker-compose file")
+	}
+
+	var project Project
+	if err := yaml.Unmarshal(buf, &project); err != nil {
+		return nil, errors.Wrapf(err, "parsing docker-compose file %s", path)
+	}
+	if len(project.Services) == 0 {
+		return nil, fmt.Errorf("no services found in %s", path)
+	}
+
+	return &project, nil
+}
+
+// ServiceNames returns the names of the services, sorted.
+func (p *Project) ServiceNames() []string {
+	var names []string
+	for name := range p.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// UnmarshalYAML accepts both the short `build: ./dir` and the long syntax.
+func (b *Build) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var context string
+	if err := unmarshal(&context); err == nil {
+		b.Context = context
+		return nil
+	}
+
+	type build Build
+	return unmarshal((*build)(b))
+}
+
+// UnmarshalYAML accepts both the short `"8080:80/tcp"` and the long syntax.
+func (p *Port) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var short string
+	if err := unmarshal(&short); err == nil {
+		return p.parse(short)
+	}
+
+	var long struct {
+		Target    int    `yaml:"target"`
+		Published int    `yaml:"published"`
+		Protocol  string `yaml:"protocol"`
+	}
+	if err := unmarshal(&long); err != nil {
+		return err
+	}
+	*p = Port{Target: long.Target, Published: long.Published, Protocol: strings.ToUpper(long.Protocol)}
+	return nil
+}
+
+// parse reads the `[[ip:]published:]target[/protocol]` syntax.
+func (p *Port) parse(s string) error {
+	spec := s
+	if i := strings.Index(spec, "/"); i >= 0 {
+		p.Protocol = strings.ToUpper(spec[i+1:])
+		spec = spec[:i]
+	}
+
+	parts := strings.Split(spec, ":")
+	target, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return fmt.Errorf("unsupported port %q: port ranges aren't supported", s)
+	}
+	p.Target = target
+
+	if len(parts) > 1 && parts[len(parts)-2] != "" {
+		published, err := strconv.Atoi(parts[len(parts)-2])
+		if err != nil {
+			return fmt.Errorf("unsupported port %q: port ranges aren't supported", s)
+		}
+		p.Published = published
+	}
+
+	return nil
+}
+
+// stringMap accepts both a list of `KEY=VALUE` and a map.
+type stringMap map[string]string
+
+func (m *stringMap) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var list []string
+	if err := unmarshal(&list); err == nil {
+		*m = stringMap{}
+		for _, kv := range list {
+			key, value := splitKeyValue(kv)
+			(*m)[key] = value
+		}
+		return nil
+	}
+
+	var values map[string]*string
+	if err := unmarshal(&values); err != nil {
+		return err
+	}
+	*m = stringMap{}
+	for key, value := range values {
+		if value == nil {
+			(*m)[key] = os.Getenv(key)
+		} else {
+			(*m)[key] = *value
+		}
+	}
+	return nil
+}
+
+// stringOrSeq accepts both a single string and a list.
+type stringOrSeq []string
+
+func (s *stringOrSeq) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var single string
+	if err := unmarshal(&single); err == nil {
+		*s = strings.Fields(single)
+		return nil
+	}
+
+	var list []string
+	if err := unmarshal(&list); err != nil {
+		return err
+	}
+	*s = list
+	return nil
+}
+
+// readEnvFile reads the `KEY=VALUE` lines of an env file.
+func readEnvFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading env file")
+	}
+	defer f.Close()
+
+	env := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value := splitKeyValue(line)
+		env[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "reading env file %s", path)
+	}
+
+	logrus.Debugf("read %d variables from %s", len(env), path)
+	return env, nil
+}
+
+// splitKeyValue splits `KEY=VALUE`. A variable without a value is read from the environment.
+func splitKeyValue(kv string) (string, string) {
+	parts := strings.SplitN(kv, "=", 2)
+	if len(parts) == 1 {
+		return parts[0], os.Getenv(parts[0])
+	}
+	return parts[0], parts[1]
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestLoad(t *testing.T) {
+	tmpDir, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+
+	tmpDir.Write("docker-compose.yaml", `version: '3'
+services:
+  web:
+    build: ./web
+    ports:
+    - "8080:80"
+    - 9000
+    environment:
+    - MODE=dev
+  db:
+    image: postgres
+    build:
+      context: ./db
+      dockerfile: Dockerfile.db
+      args:
+        VERSION: "11"
+    ports:
+    - target: 5432
+      protocol: udp
+    env_file: db.env
+    command: postgres -c fsync=off
+    profiles: ["debug"]
+`)
+
+	project, err := Load(tmpDir.Path("docker-compose.yaml"))
+
+	testutil.CheckErrorAndDeepEqual(t, false, err, &Project{
+		Services: map[string]*Service{
+			"web": {
+				Build:       &Build{Context: "./web"},
+				Ports:       []Port{{Target: 80, Published: 8080}, {Target: 9000}},
+				Environment: stringMap{"MODE": "dev"},
+			},
+			"db": {
+				Image:    "postgres",
+				Build:    &Build{Context: "./db", Dockerfile: "Dockerfile.db", Args: stringMap{"VERSION": "11"}},
+				Ports:    []Port{{Target: 5432, Protocol: "UDP"}},
+				EnvFile:  stringOrSeq{"db.env"},
+				Command:  stringOrSeq{"postgres", "-c", "fsync=off"},
+				Profiles: []string{"debug"},
+			},
+		},
+	}, project)
+}
+
+func TestLoadErrors(t *testing.T) {
+	tmpDir, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+
+	tmpDir.Write("empty.yaml", "version: '3'")
+	tmpDir.Write("ranges.yaml", `services:
+  web:
+    ports: ["3000-3005:3000-3005"]`)
+
+	_, err := Load(tmpDir.Path("empty.yaml"))
+	testutil.CheckError(t, true, err)
+
+	_, err = Load(tmpDir.Path("ranges.yaml"))
+	testutil.CheckError(t, true, err)
+
+	_, err = Load(tmpDir.Path("missing.yaml"))
+	testutil.CheckError(t, true, err)
+}
+
+func TestParsePort(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected Port
+	}{
+		{spec: "80", expected: Port{Target: 80}},
+		{spec: "8080:80", expected: Port{Target: 80, Published: 8080}},
+		{spec: "127.0.0.1:8080:80/udp", expected: Port{Target: 80, Published: 8080, Protocol: "UDP"}},
+		{spec: "127.0.0.1::80", expected: Port{Target: 80}},
+	}
+	for _, test := range tests {
+		t.Run(test.spec, func(t *testing.T) {
+			var port Port
+			err := yaml.Unmarshal([]byte(`"`+test.spec+`"`), &port)
+
+			testutil.CheckErrorAndDeepEqual(t, false, err, test.expected, port)
+		})
+	}
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Translation is what a docker-compose file translates to.
+type Translation struct {
+	// Manifests are the generated Kubernetes manifests, by path.
+	Manifests map[string][]byte
+
+	// Pipeline builds and deploys the services that don't belong to any compose profile.
+	Pipeline latest.Pipeline
+
+	// Profiles add the services of each compose profile to the pipeline.
+	Profiles []latest.Profile
+}
+
+type translatedService struct {
+	artifact *latest.Artifact
+	manifest string
+}
+
+// Translate converts the services of a docker-compose file into artifacts built
+// with Docker and Kubernetes manifests stored in manifestsDir. Ports become container
+// ports, that Skaffold forwards with `--port-forward`, and a Service.
+// Env files are used both as build args and as the environment of the containers.
+func Translate(p *Project, composeDir, manifestsDir string) (*Translation, error) {
+	t := &Translation{
+		Manifests: map[string][]byte{},
+	}
+
+	var base []translatedService
+	profiles := map[string][]translatedService{}
+
+	for _, name := range p.ServiceNames() {
+		svc := p.Services[name]
+
+		env := map[string]string{}
+		for _, file := range svc.EnvFile {
+			vars, err := readEnvFile(filepath.Join(composeDir, file))
+			if err != nil {
+				return nil, errors.Wrapf(err, "service %s", name)
+			}
+			for k, v := range vars {
+				env[k] = v
+			}
+		}
+
+		image := svc.Image
+		if image == "" {
+			image = name
+		}
+
+		manifest, err := serviceManifests(name, image, svc, mergeEnv(env, svc.Environment))
+		if err != nil {
+			return nil, errors.Wrapf(err, "generating manifests for service %s", name)
+		}
+		path := filepath.Join(manifestsDir, name+".yaml")
+		t.Manifests[path] = manifest
+
+		translated := translatedService{manifest: path}
+		if svc.Build != nil {
+			translated.artifact = serviceArtifact(image, svc.Build, composeDir, env)
+		}
+
+		if len(svc.Profiles) == 0 {
+			base = append(base, translated)
+		}
+		for _, profile := range svc.Profiles {
+			profiles[profile] = append(profiles[profile], translated)
+		}
+	}
+
+	t.Pipeline = pipeline(base)
+
+	var names []string
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		t.Profiles = append(t.Profiles, latest.Profile{
+			Name:     name,
+			Pipeline: pipeline(append(append([]translatedService{}, base...), profiles[name]...)),
+		})
+	}
+
+	return t, nil
+}
+
+func pipeline(services []translatedService) latest.Pipeline {
+	var artifacts []*latest.Artifact
+	var manifests []string
+	for _, s := range services {
+		if s.artifact != nil {
+			artifacts = append(artifacts, s.artifact)
+		}
+		manifests = append(manifests, s.manifest)
+	}
+
+	return latest.Pipeline{
+		Build: latest.BuildConfig{
+			Artifacts: artifacts,
+		},
+		Deploy: latest.DeployConfig{
+			DeployType: latest.DeployType{
+				KubectlDeploy: &latest.KubectlDeploy{
+					Manifests: manifests,
+				},
+			},
+		},
+	}
+}
+
+func serviceArtifact(image string, build *Build, composeDir string, env map[string]string) *latest.Artifact {
+	a := &latest.Artifact{
+		ImageName: image,
+	}
+	if workspace := filepath.Join(composeDir, build.Context); workspace != "." {
+		a.Workspace = workspace
+	}
+
+	buildArgs := map[string]*string{}
+	for k, v := range mergeEnv(env, build.Args) {
+		v := v
+		buildArgs[k] = &v
+	}
+
+	docker := &latest.DockerArtifact{
+		Target: build.Target,
+	}
+	if build.Dockerfile != "" && build.Dockerfile != constants.DefaultDockerfilePath {
+		docker.DockerfilePath = build.Dockerfile
+	}
+	if len(buildArgs) > 0 {
+		docker.BuildArgs = buildArgs
+	}
+	if docker.DockerfilePath != "" || docker.Target != "" || docker.BuildArgs != nil {
+		a.ArtifactType = latest.ArtifactType{
+			DockerArtifact: docker,
+		}
+	}
+
+	return a
+}
+
+// serviceManifests generates a Deployment and, if ports are published, a Service.
+func serviceManifests(name, image string, svc *Service, env map[string]string) ([]byte, error) {
+	labels := yaml.MapSlice{{Key: "app", Value: name}}
+
+	container := yaml.MapSlice{
+		{Key: "name", Value: name},
+		{Key: "image", Value: image},
+	}
+	if len(svc.Entrypoint) > 0 {
+		container = append(container, yaml.MapItem{Key: "command", Value: []string(svc.Entrypoint)})
+	}
+	if len(svc.Command) > 0 {
+		container = append(container, yaml.MapItem{Key: "args", Value: []string(svc.Command)})
+	}
+	if len(svc.Ports) > 0 {
+		var ports []yaml.MapSlice
+		for _, p := range svc.Ports {
+			ports = append(ports, withProtocol(yaml.MapSlice{{Key: "containerPort", Value: p.Target}}, p))
+		}
+		container = append(container, yaml.MapItem{Key: "ports", Value: ports})
+	}
+	if len(env) > 0 {
+		var vars []yaml.MapSlice
+		for _, k := range sortedKeys(env) {
+			vars = append(vars, yaml.MapSlice{{Key: "name", Value: k}, {Key: "value", Value: env[k]}})
+		}
+		container = append(container, yaml.MapItem{Key: "env", Value: vars})
+	}
+
+	deployment := yaml.MapSlice{
+		{Key: "apiVersion", Value: "apps/v1"},
+		{Key: "kind", Value: "Deployment"},
+		{Key: "metadata", Value: yaml.MapSlice{{Key: "name", Value: name}, {Key: "labels", Value: labels}}},
+		{Key: "spec", Value: yaml.MapSlice{
+			{Key: "selector", Value: yaml.MapSlice{{Key: "matchLabels", Value: labels}}},
+			{Key: "template", Value: yaml.MapSlice{
+				{Key: "metadata", Value: yaml.MapSlice{{Key: "labels", Value: labels}}},
+				{Key: "spec", Value: yaml.MapSlice{{Key: "containers", Value: []yaml.MapSlice{container}}}},
+			}},
+		}},
+	}
+
+	manifest, err := yaml.Marshal(deployment)
+	if err != nil {
+		return nil, err
+	}
+	if len(svc.Ports) == 0 {
+		return manifest, nil
+	}
+
+	var ports []yaml.MapSlice
+	for _, p := range svc.Ports {
+		port := p.Published
+		if port == 0 {
+			port = p.Target
+		}
+		ports = append(ports, withProtocol(yaml.MapSlice{
+			{Key: "name", Value: fmt.Sprintf("%s-%d", name, port)},
+			{Key: "port", Value: port},
+			{Key: "targetPort", Value: p.Target},
+		}, p))
+	}
+	service := yaml.MapSlice{
+		{Key: "apiVersion", Value: "v1"},
+		{Key: "kind", Value: "Service"},
+		{Key: "metadata", Value: yaml.MapSlice{{Key: "name", Value: name}, {Key: "labels", Value: labels}}},
+		{Key: "spec", Value: yaml.MapSlice{
+			{Key: "selector", Value: labels},
+			{Key: "ports", Value: ports},
+		}},
+	}
+
+	buf, err := yaml.Marshal(service)
+	if err != nil {
+		return nil, err
+	}
+	return append(append(manifest, []byte("---\n")...), buf...), nil
+}
+
+func withProtocol(port yaml.MapSlice, p Port) yaml.MapSlice {
+	if p.Protocol != "" && p.Protocol != "TCP" {
+		return append(port, yaml.MapItem{Key: "protocol", Value: p.Protocol})
+	}
+	return port
+}
+
+func mergeEnv(env map[string]string, overrides map[string]string) map[string]string {
+	merged := map[string]string{}
+	for k, v := range env {
+		merged[k] = v
+	}
+	for k, v := range overrides {
+		merged[k] = v
+	}
+	return merged
+}
+
+func sortedKeys(m map[string]string) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestTranslate(t *testing.T) {
+	tmpDir, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+
+	tmpDir.Write("db.env", "# database\nPASSWORD=secret\n\nUSER=admin\n")
+
+	project := &Project{
+		Services: map[string]*Service{
+			"web": {
+				Image: "gcr.io/k8s-skaffold/web",
+				Build: &Build{Context: "web"},
+				Ports: []Port{{Target: 80, Published: 8080}},
+			},
+			"db": {
+				Build:       &Build{Context: ".", Dockerfile: "Dockerfile.db", Args: stringMap{"USER": "root"}},
+				EnvFile:     stringOrSeq{"db.env"},
+				Environment: stringMap{"PASSWORD": "other"},
+				Profiles:    []string{"debug"},
+			},
+			"cache": {
+				Image: "redis",
+			},
+		},
+	}
+
+	translation, err := Translate(project, tmpDir.Root(), "k8s")
+	testutil.CheckError(t, false, err)
+
+	secret, root := "secret", "root"
+	web := &latest.Artifact{ImageName: "gcr.io/k8s-skaffold/web", Workspace: filepath.Join(tmpDir.Root(), "web")}
+	db := &latest.Artifact{
+		ImageName: "db",
+		Workspace: tmpDir.Root(),
+		ArtifactType: latest.ArtifactType{
+			DockerArtifact: &latest.DockerArtifact{
+				DockerfilePath: "Dockerfile.db",
+				BuildArgs:      map[string]*string{"PASSWORD": &secret, "USER": &root},
+			},
+		},
+	}
+	cache, dbManifest, webManifest := filepath.Join("k8s", "cache.yaml"), filepath.Join("k8s", "db.yaml"), filepath.Join("k8s", "web.yaml")
+
+	testutil.CheckDeepEqual(t, []*latest.Artifact{web}, translation.Pipeline.Build.Artifacts)
+	testutil.CheckDeepEqual(t, []string{cache, webManifest}, translation.Pipeline.Deploy.KubectlDeploy.Manifests)
+	testutil.CheckDeepEqual(t, 1, len(translation.Profiles))
+	testutil.CheckDeepEqual(t, "debug", translation.Profiles[0].Name)
+	testutil.CheckDeepEqual(t, []*latest.Artifact{web, db}, translation.Profiles[0].Build.Artifacts)
+	testutil.CheckDeepEqual(t, []string{cache, webManifest, dbManifest}, translation.Profiles[0].Deploy.KubectlDeploy.Manifests)
+
+	testutil.CheckDeepEqual(t, `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  labels:
+    app: web
+spec:
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - name: web
+        image: gcr.io/k8s-skaffold/web
+        ports:
+        - containerPort: 80
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  labels:
+    app: web
+spec:
+  selector:
+    app: web
+  ports:
+  - name: web-8080
+    port: 8080
+    targetPort: 80
+`, string(translation.Manifests[webManifest]))
+
+	testutil.CheckDeepEqual(t, `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: db
+  labels:
+    app: db
+spec:
+  selector:
+    matchLabels:
+      app: db
+  template:
+    metadata:
+      labels:
+        app: db
+    spec:
+      containers:
+      - name: db
+        image: db
+        env:
+        - name: PASSWORD
+          value: other
+        - name: USER
+          value: admin
+`, string(translation.Manifests[dbManifest]))
+}
+
+func TestTranslateMissingEnvFile(t *testing.T) {
+	project := &Project{
+		Services: map[string]*Service{
+			"web": {EnvFile: stringOrSeq{"missing.env"}},
+		},
+	}
+
+	_, err := Translate(project, ".", "k8s")
+
+	testutil.CheckError(t, true, err)
+}
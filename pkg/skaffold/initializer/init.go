@@ -25,13 +25,15 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/GoogleContainerTools/skaffold/cmd/skaffold/app/tips"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/config"
-	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/docker"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/initializer/compose"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/initializer/kubectl"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/initializer/scaffold"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/defaults"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
@@ -41,9 +43,27 @@ import (
 	yaml "gopkg.in/yaml.v2"
 )
 
-// NoDockerfile allows users to specify they don't want to build
+// NoBuilder allows users to specify they don't want to build
 // an image we parse out from a kubernetes manifest
-const NoDockerfile = "None (image not built from these sources)"
+const NoBuilder = "None (image not built from these sources)"
+
+// composeManifestsDir is where the manifests translated from a docker-compose file are written.
+const composeManifestsDir = "k8s"
+
+// Deployers for which `skaffold init` can generate the deploy configuration.
+const (
+	KubectlDeployer   = "kubectl"
+	HelmDeployer      = "helm"
+	KustomizeDeployer = "kustomize"
+)
+
+// Where the deploy scaffolding is written, when no manifests are found.
+const (
+	chartsDir        = "charts"
+	kustomizationDir = "k8s"
+)
+
+const noBuilderMessage = "one or more valid builder configurations (Dockerfile, Jib or Bazel) must be present to build images with skaffold; please provide at least one and try again or run `skaffold init --skip-build`"
 
 // Initializer is the Init API of skaffold and responsible for generating
 // skaffold configuration file.
@@ -57,6 +77,8 @@ type Initializer interface {
 // Config defines the Initializer Config for Init API of skaffold.
 type Config struct {
 	ComposeFile  string
+	Kompose      bool
+	Deployer     string
 	CliArtifacts []string
 	SkipBuild    bool
 	Force        bool
@@ -68,7 +90,17 @@ type Config struct {
 func DoInit(out io.Writer, c Config) error {
 	rootDir := "."
 
+	switch c.Deployer {
+	case "", KubectlDeployer, HelmDeployer, KustomizeDeployer:
+	default:
+		return fmt.Errorf("unsupported deployer %q, should be one of %s, %s or %s", c.Deployer, KubectlDeployer, HelmDeployer, KustomizeDeployer)
+	}
+
 	if c.ComposeFile != "" {
+		if !c.Kompose {
+			return initFromCompose(out, c)
+		}
+
 		// run kompose first to generate k8s manifests, then run skaffold init
 		logrus.Infof("running 'kompose convert' for file %s", c.ComposeFile)
 		komposeCmd := exec.Command("kompose", "convert", "-f", c.ComposeFile)
@@ -77,24 +109,30 @@ func DoInit(out io.Writer, c Config) error {
 		}
 	}
 
-	potentialConfigs, dockerfiles, err := walk(rootDir, c.Force, docker.ValidateDockerfile)
+	potentialConfigs, builders, err := walk(rootDir, c.Force, docker.ValidateDockerfile)
 	if err != nil {
 		return err
 	}
 
 	k, err := kubectl.New(potentialConfigs)
 	if err != nil {
-		return err
+		if c.Deployer != HelmDeployer && c.Deployer != KustomizeDeployer {
+			return err
+		}
+		return initWithScaffolding(out, c, builders)
+	}
+	if c.Deployer != "" && c.Deployer != KubectlDeployer {
+		logrus.Warnf("found kubernetes manifests, generating a kubectl deploy config instead of %s", c.Deployer)
 	}
 	images := k.GetImages()
 	if c.Analyze {
-		return printAnalyzeJSON(out, c.SkipBuild, dockerfiles, images)
+		return printAnalyzeJSON(out, c.SkipBuild, builders, images)
 	}
-	var pairs []dockerfilePair
+	var pairs []builderImagePair
 	// conditionally generate build artifacts
 	if !c.SkipBuild {
-		if len(dockerfiles) == 0 {
-			return errors.New("one or more valid Dockerfiles must be present to build images with skaffold; please provide at least one Dockerfile and try again or run `skaffold init --skip-build`")
+		if len(builders) == 0 {
+			return errors.New(noBuilderMessage)
 		}
 
 		if c.CliArtifacts != nil {
@@ -103,7 +141,7 @@ func DoInit(out io.Writer, c Config) error {
 				return errors.Wrap(err, "processing cli artifacts")
 			}
 		} else {
-			pairs = resolveDockerfileImages(dockerfiles, images)
+			pairs = resolveBuilderImages(builders, images)
 		}
 	}
 
@@ -112,7 +150,141 @@ func DoInit(out io.Writer, c Config) error {
 		return err
 	}
 
+	return writeConfig(out, c, pipeline, nil)
+}
+
+// initWithScaffolding generates a chart or a kustomization that deploys
+// the images built from the sources, when no manifests exist.
+func initWithScaffolding(out io.Writer, c Config, builders []InitBuilder) error {
+	if c.Analyze {
+		return printAnalyzeJSON(out, c.SkipBuild, builders, nil)
+	}
+	if c.SkipBuild {
+		return errors.New("one or more valid kubernetes manifests is required to run skaffold init --skip-build")
+	}
+	if len(builders) == 0 {
+		return errors.New(noBuilderMessage)
+	}
+
+	pairs := imagesForBuilders(builders)
+	if c.CliArtifacts != nil {
+		var err error
+		if pairs, err = processCliArtifacts(c.CliArtifacts); err != nil {
+			return errors.Wrap(err, "processing cli artifacts")
+		}
+	}
+
+	var images []string
+	for _, pair := range pairs {
+		images = append(images, pair.ImageName)
+	}
+
+	s, err := newScaffolder(c.Deployer, images)
+	if err != nil {
+		return errors.Wrapf(err, "generating %s deploy scaffolding", c.Deployer)
+	}
+
+	manifests := s.Files()
+	for path := range manifests {
+		if _, err := os.Stat(path); err == nil && !c.Force {
+			return fmt.Errorf("pre-existing %s found", path)
+		}
+	}
+
+	pipeline, err := generateSkaffoldConfig(s, pairs)
+	if err != nil {
+		return err
+	}
+
+	return writeConfig(out, c, pipeline, manifests)
+}
+
+// scaffolder is an Initializer that also generates the files it deploys.
+type scaffolder interface {
+	Initializer
+	// Files returns the generated files, by path.
+	Files() map[string][]byte
+}
+
+func newScaffolder(deployer string, images []string) (scaffolder, error) {
+	if deployer == HelmDeployer {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, errors.Wrap(err, "getting current directory")
+		}
+		return scaffold.NewHelm(chartsDir, filepath.Base(wd), images)
+	}
+	return scaffold.NewKustomize(kustomizationDir, images)
+}
+
+// imagesForBuilders names the image of each builder after its directory.
+func imagesForBuilders(builders []InitBuilder) []builderImagePair {
+	var pairs []builderImagePair
+	used := map[string]int{}
+	for _, b := range builders {
+		dir, err := filepath.Abs(filepath.Dir(b.Path()))
+		if err != nil {
+			dir = b.Path()
+		}
+		image := scaffold.ResourceName(filepath.Base(dir))
+		if used[image]++; used[image] > 1 {
+			image = fmt.Sprintf("%s-%d", image, used[image])
+		}
+		pairs = append(pairs, builderImagePair{Builder: b, ImageName: image})
+	}
+	return pairs
+}
+
+// initFromCompose translates the services of a docker-compose file into
+// Kubernetes manifests and a Skaffold config that builds and deploys them.
+func initFromCompose(out io.Writer, c Config) error {
+	if _, err := os.Stat(c.Opts.ConfigurationFile); err == nil && !c.Force {
+		return fmt.Errorf("pre-existing %s found", c.Opts.ConfigurationFile)
+	}
+
+	project, err := compose.Load(c.ComposeFile)
+	if err != nil {
+		return err
+	}
+
+	t, err := compose.Translate(project, filepath.Dir(c.ComposeFile), composeManifestsDir)
+	if err != nil {
+		return errors.Wrap(err, "translating docker-compose file")
+	}
+
+	cfg := &latest.SkaffoldConfig{
+		APIVersion: latest.Version,
+		Kind:       "Config",
+		Pipeline:   t.Pipeline,
+		Profiles:   t.Profiles,
+	}
+	if c.SkipBuild {
+		cfg.Build.Artifacts = nil
+		for i := range cfg.Profiles {
+			cfg.Profiles[i].Build.Artifacts = nil
+		}
+	}
+
+	pipeline, err := yaml.Marshal(cfg)
+	if err != nil {
+		return errors.Wrap(err, "marshaling generated pipeline")
+	}
+
+	for path := range t.Manifests {
+		if _, err := os.Stat(path); err == nil && !c.Force {
+			return fmt.Errorf("pre-existing %s found", path)
+		}
+	}
+
+	return writeConfig(out, c, pipeline, t.Manifests)
+}
+
+// writeConfig writes the generated manifests and configuration, once the user confirmed.
+func writeConfig(out io.Writer, c Config, pipeline []byte, manifests map[string][]byte) error {
 	if c.Opts.ConfigurationFile == "-" {
+		if err := writeManifests(manifests); err != nil {
+			return err
+		}
 		out.Write(pipeline)
 		return nil
 	}
@@ -140,6 +312,10 @@ func DoInit(out io.Writer, c Config) error {
 		}
 	}
 
+	if err := writeManifests(manifests); err != nil {
+		return err
+	}
+
 	if err := ioutil.WriteFile(c.Opts.ConfigurationFile, pipeline, 0644); err != nil {
 		return errors.Wrap(err, "writing config to file")
 	}
@@ -150,94 +326,132 @@ func DoInit(out io.Writer, c Config) error {
 	return nil
 }
 
-func processCliArtifacts(artifacts []string) ([]dockerfilePair, error) {
-	var pairs []dockerfilePair
+func writeManifests(manifests map[string][]byte) error {
+	var paths []string
+	for path := range manifests {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return errors.Wrap(err, "creating manifests directory")
+		}
+		if err := ioutil.WriteFile(path, manifests[path], 0644); err != nil {
+			return errors.Wrap(err, "writing manifest")
+		}
+		logrus.Infof("generated manifest %s", path)
+	}
+	return nil
+}
+
+func processCliArtifacts(artifacts []string) ([]builderImagePair, error) {
+	var pairs []builderImagePair
 	for _, artifact := range artifacts {
 		parts := strings.Split(artifact, "=")
 		if len(parts) != 2 {
 			return nil, fmt.Errorf("malformed artifact provided: %s", artifact)
 		}
-		pairs = append(pairs, dockerfilePair{
-			Dockerfile: parts[0],
-			ImageName:  parts[1],
+		pairs = append(pairs, builderImagePair{
+			Builder:   builderForPath(parts[0]),
+			ImageName: parts[1],
 		})
 	}
 	return pairs, nil
 }
 
+// builderForPath chooses the builder from the name of the build definition.
+// Anything but a Maven or Gradle project is considered a Dockerfile.
+func builderForPath(path string) InitBuilder {
+	switch filepath.Base(path) {
+	case "pom.xml":
+		return jibMaven{path: path, workspace: filepath.Dir(path)}
+	case "build.gradle", "build.gradle.kts":
+		return jibGradle{path: path}
+	default:
+		return dockerfile{path: path}
+	}
+}
+
 // For each image parsed from all k8s manifests, prompt the user for
-// the dockerfile that builds the referenced image
-func resolveDockerfileImages(dockerfiles []string, images []string) []dockerfilePair {
-	// if we only have 1 image and 1 dockerfile, don't bother prompting
-	if len(images) == 1 && len(dockerfiles) == 1 {
-		return []dockerfilePair{{
-			Dockerfile: dockerfiles[0],
-			ImageName:  images[0],
+// the builder that builds the referenced image
+func resolveBuilderImages(builders []InitBuilder, images []string) []builderImagePair {
+	// if we only have 1 image and 1 builder, don't bother prompting
+	if len(images) == 1 && len(builders) == 1 {
+		return []builderImagePair{{
+			Builder:   builders[0],
+			ImageName: images[0],
 		}}
 	}
-	pairs := []dockerfilePair{}
+	pairs := []builderImagePair{}
 	for {
 		if len(images) == 0 {
 			break
 		}
 		image := images[0]
-		pair := promptUserForDockerfile(image, dockerfiles)
-		if pair.Dockerfile != NoDockerfile {
-			pairs = append(pairs, pair)
-			dockerfiles = util.RemoveFromSlice(dockerfiles, pair.Dockerfile)
+		if b, ok := promptUserForBuilder(image, builders); ok {
+			pairs = append(pairs, builderImagePair{Builder: b, ImageName: image})
+			builders = removeBuilder(builders, b)
 		}
-		images = util.RemoveFromSlice(images, pair.ImageName)
+		images = util.RemoveFromSlice(images, image)
 	}
-	if len(dockerfiles) > 0 {
-		logrus.Warnf("unused dockerfiles found in repository: %v", dockerfiles)
+	if len(builders) > 0 {
+		var unused []string
+		for _, b := range builders {
+			unused = append(unused, b.Describe())
+		}
+		logrus.Warnf("unused builders found in repository: %v", unused)
 	}
 	return pairs
 }
 
-func promptUserForDockerfile(image string, dockerfiles []string) dockerfilePair {
-	var selectedDockerfile string
-	options := append(dockerfiles, NoDockerfile)
+func promptUserForBuilder(image string, builders []InitBuilder) (InitBuilder, bool) {
+	var options []string
+	for _, b := range builders {
+		options = append(options, b.Describe())
+	}
+	options = append(options, NoBuilder)
+
+	var selected string
 	prompt := &survey.Select{
-		Message:  fmt.Sprintf("Choose the dockerfile to build image %s", image),
+		Message:  fmt.Sprintf("Choose the builder to build image %s", image),
 		Options:  options,
 		PageSize: 15,
 	}
-	survey.AskOne(prompt, &selectedDockerfile, nil)
-	return dockerfilePair{
-		Dockerfile: selectedDockerfile,
-		ImageName:  image,
+	survey.AskOne(prompt, &selected, nil)
+
+	for _, b := range builders {
+		if b.Describe() == selected {
+			return b, true
+		}
 	}
+	return nil, false
 }
 
-func processBuildArtifacts(pairs []dockerfilePair) latest.BuildConfig {
+func removeBuilder(builders []InitBuilder, target InitBuilder) []InitBuilder {
+	var remaining []InitBuilder
+	for _, b := range builders {
+		if b != target {
+			remaining = append(remaining, b)
+		}
+	}
+	return remaining
+}
+
+func processBuildArtifacts(pairs []builderImagePair) latest.BuildConfig {
 	var config latest.BuildConfig
 
 	if len(pairs) > 0 {
 		var artifacts []*latest.Artifact
 		for _, pair := range pairs {
-			workspace := filepath.Dir(pair.Dockerfile)
-			dockerfilePath := filepath.Base(pair.Dockerfile)
-			a := &latest.Artifact{
-				ImageName: pair.ImageName,
-			}
-			if workspace != "." {
-				a.Workspace = workspace
-			}
-			if dockerfilePath != constants.DefaultDockerfilePath {
-				a.ArtifactType = latest.ArtifactType{
-					DockerArtifact: &latest.DockerArtifact{
-						DockerfilePath: dockerfilePath,
-					},
-				}
-			}
-			artifacts = append(artifacts, a)
+			artifacts = append(artifacts, pair.Builder.CreateArtifact(pair.ImageName))
 		}
 		config.Artifacts = artifacts
 	}
 	return config
 }
 
-func generateSkaffoldConfig(k Initializer, dockerfilePairs []dockerfilePair) ([]byte, error) {
+func generateSkaffoldConfig(k Initializer, builderPairs []builderImagePair) ([]byte, error) {
 	// if we're here, the user has no skaffold yaml so we need to generate one
 	// if the user doesn't have any k8s yamls, generate one for each dockerfile
 	logrus.Info("generating skaffold config")
@@ -250,7 +464,7 @@ func generateSkaffoldConfig(k Initializer, dockerfilePairs []dockerfilePair) ([]
 		return nil, errors.Wrap(err, "generating default pipeline")
 	}
 
-	cfg.Build = processBuildArtifacts(dockerfilePairs)
+	cfg.Build = processBuildArtifacts(builderPairs)
 	cfg.Deploy = k.GenerateDeployConfig()
 
 	pipelineStr, err := yaml.Marshal(cfg)
@@ -261,17 +475,30 @@ func generateSkaffoldConfig(k Initializer, dockerfilePairs []dockerfilePair) ([]
 	return pipelineStr, nil
 }
 
-func printAnalyzeJSON(out io.Writer, skipBuild bool, dockerfiles, images []string) error {
-	if !skipBuild && len(dockerfiles) == 0 {
-		return errors.New("one or more valid Dockerfiles must be present to build images with skaffold; please provide at least one Dockerfile and try again or run `skaffold init --skip-build`")
+func printAnalyzeJSON(out io.Writer, skipBuild bool, builders []InitBuilder, images []string) error {
+	if !skipBuild && len(builders) == 0 {
+		return errors.New(noBuilderMessage)
+	}
+
+	type analyzedBuilder struct {
+		Name string `json:"name"`
+		Path string `json:"path"`
 	}
 	a := struct {
-		Dockerfiles []string `json:"dockerfiles,omitempty"`
-		Images      []string `json:"images,omitempty"`
+		Dockerfiles []string          `json:"dockerfiles,omitempty"`
+		Builders    []analyzedBuilder `json:"builders,omitempty"`
+		Images      []string          `json:"images,omitempty"`
 	}{
-		Dockerfiles: dockerfiles,
-		Images:      images,
+		Images: images,
 	}
+	for _, b := range builders {
+		if d, ok := b.(dockerfile); ok {
+			a.Dockerfiles = append(a.Dockerfiles, d.path)
+		} else {
+			a.Builders = append(a.Builders, analyzedBuilder{Name: b.Name(), Path: b.Path()})
+		}
+	}
+
 	contents, err := json.Marshal(a)
 	if err != nil {
 		return errors.Wrap(err, "marshalling contents")
@@ -280,19 +507,20 @@ func printAnalyzeJSON(out io.Writer, skipBuild bool, dockerfiles, images []strin
 	return err
 }
 
-type dockerfilePair struct {
-	Dockerfile string
-	ImageName  string
+type builderImagePair struct {
+	Builder   InitBuilder
+	ImageName string
 }
 
-func walk(dir string, force bool, validateDockerfile func(string) bool) ([]string, []string, error) {
-	var dockerfiles, potentialConfigs []string
+func walk(dir string, force bool, validateDockerfile func(string) bool) ([]string, []InitBuilder, error) {
+	var potentialConfigs, buildpacksFiles []string
+	var builders []InitBuilder
 	err := filepath.Walk(dir, func(path string, f os.FileInfo, e error) error {
-		if f.IsDir() && util.IsHiddenDir(f.Name()) {
+		if f.IsDir() && path != dir && util.IsHiddenDir(path) {
 			logrus.Debugf("skip walking hidden dir %s", f.Name())
 			return filepath.SkipDir
 		}
-		if f.IsDir() || util.IsHiddenFile(f.Name()) {
+		if f.IsDir() || util.IsHiddenFile(path) {
 			return nil
 		}
 		if IsSkaffoldConfig(path) {
@@ -302,19 +530,19 @@ func walk(dir string, force bool, validateDockerfile func(string) bool) ([]strin
 			logrus.Debugf("%s is a valid skaffold configuration: continuing since --force=true", path)
 			return nil
 		}
+		if isBuildpacksFile(path) {
+			buildpacksFiles = append(buildpacksFiles, path)
+		}
 		if IsSupportedKubernetesFileExtension(path) {
 			potentialConfigs = append(potentialConfigs, path)
 			return nil
 		}
-		// try and parse dockerfile
-		if validateDockerfile(path) {
-			logrus.Infof("existing dockerfile found: %s", path)
-			dockerfiles = append(dockerfiles, path)
-		}
+		builders = append(builders, detectBuilders(dir, path, validateDockerfile)...)
 		return nil
 	})
 	if err != nil {
 		return nil, nil, err
 	}
-	return potentialConfigs, dockerfiles, nil
+	warnAboutBuildpacksProjects(buildpacksFiles, builders)
+	return potentialConfigs, builders, nil
 }
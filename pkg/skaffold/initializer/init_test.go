@@ -27,7 +27,7 @@ import (
 func TestPrintAnalyzeJSON(t *testing.T) {
 	tests := []struct {
 		description string
-		dockerfiles []string
+		builders    []InitBuilder
 		images      []string
 		skipBuild   bool
 		shouldErr   bool
@@ -35,10 +35,16 @@ func TestPrintAnalyzeJSON(t *testing.T) {
 	}{
 		{
 			description: "dockerfile and image",
-			dockerfiles: []string{"Dockerfile", "Dockerfile_2"},
+			builders:    []InitBuilder{dockerfile{path: "Dockerfile"}, dockerfile{path: "Dockerfile_2"}},
 			images:      []string{"image1", "image2"},
 			expected:    "{\"dockerfiles\":[\"Dockerfile\",\"Dockerfile_2\"],\"images\":[\"image1\",\"image2\"]}",
 		},
+		{
+			description: "other builders",
+			builders:    []InitBuilder{dockerfile{path: "Dockerfile"}, jibMaven{path: "pom.xml"}, bazel{path: "BUILD", target: "//:app.tar"}},
+			images:      []string{"image1"},
+			expected:    "{\"dockerfiles\":[\"Dockerfile\"],\"builders\":[{\"name\":\"Jib Maven Plugin\",\"path\":\"pom.xml\"},{\"name\":\"Bazel\",\"path\":\"BUILD\"}],\"images\":[\"image1\"]}",
+		},
 		{
 			description: "no dockerfile, skip build",
 			images:      []string{"image1", "image2"},
@@ -57,7 +63,7 @@ func TestPrintAnalyzeJSON(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.description, func(t *testing.T) {
 			out := bytes.NewBuffer([]byte{})
-			err := printAnalyzeJSON(out, test.skipBuild, test.dockerfiles, test.images)
+			err := printAnalyzeJSON(out, test.skipBuild, test.builders, test.images)
 			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.expected, out.String())
 		})
 	}
@@ -66,12 +72,12 @@ func TestPrintAnalyzeJSON(t *testing.T) {
 func TestWalk(t *testing.T) {
 	emptyFile := ""
 	tests := []struct {
-		description         string
-		filesWithContents   map[string]string
-		expectedConfigs     []string
-		expectedDockerfiles []string
-		force               bool
-		shouldErr           bool
+		description       string
+		filesWithContents map[string]string
+		expectedConfigs   []string
+		expectedBuilders  []string
+		force             bool
+		shouldErr         bool
 	}{
 		{
 			description: "should return correct k8 configs and dockerfiles",
@@ -87,12 +93,33 @@ func TestWalk(t *testing.T) {
 				"config/test.yaml",
 				"k8pod.yml",
 			},
-			expectedDockerfiles: []string{
+			expectedBuilders: []string{
 				"Dockerfile",
 				"deploy/Dockerfile",
 			},
 			shouldErr: false,
 		},
+		{
+			description: "should return jib and bazel builders",
+			filesWithContents: map[string]string{
+				"k8pod.yml":      emptyFile,
+				"java/pom.xml":   "<artifactId>jib-maven-plugin</artifactId>",
+				"other/pom.xml":  "<artifactId>maven-jar-plugin</artifactId>",
+				"WORKSPACE":      emptyFile,
+				"go/BUILD":       `go_image(name = "app", srcs = ["main.go"])`,
+				"go/main.go":     emptyFile,
+				"lib/BUILD":      `go_library(name = "lib")`,
+				"web/Dockerfile": emptyFile,
+			},
+			expectedConfigs: []string{
+				"k8pod.yml",
+			},
+			expectedBuilders: []string{
+				"go/BUILD",
+				"java/pom.xml",
+				"web/Dockerfile",
+			},
+		},
 		{
 			description: "should skip hidden dir",
 			filesWithContents: map[string]string{
@@ -106,7 +133,7 @@ func TestWalk(t *testing.T) {
 			expectedConfigs: []string{
 				"k8pod.yml",
 			},
-			expectedDockerfiles: []string{
+			expectedBuilders: []string{
 				"Dockerfile",
 			},
 			shouldErr: false,
@@ -129,7 +156,7 @@ deploy:
 				"config/test.yaml",
 				"k8pod.yml",
 			},
-			expectedDockerfiles: []string{
+			expectedBuilders: []string{
 				"Dockerfile",
 				"deploy/Dockerfile",
 			},
@@ -148,10 +175,10 @@ kind: Config
 deploy:
   kustomize: {}`,
 			},
-			force:               false,
-			expectedConfigs:     nil,
-			expectedDockerfiles: nil,
-			shouldErr:           true,
+			force:            false,
+			expectedConfigs:  nil,
+			expectedBuilders: nil,
+			shouldErr:        true,
 		},
 	}
 	for _, test := range tests {
@@ -160,11 +187,15 @@ deploy:
 			defer cleanUp()
 			rootDir := testDir.Root()
 			writeAllFiles(testDir, test.filesWithContents)
-			potentialConfigs, dockerfiles, err := walk(rootDir, test.force, testValidDocker)
+			potentialConfigs, builders, err := walk(rootDir, test.force, testValidDocker)
+			var paths []string
+			for _, b := range builders {
+				paths = append(paths, b.Path())
+			}
 			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err,
 				testDir.Paths(test.expectedConfigs...), potentialConfigs)
 			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err,
-				testDir.Paths(test.expectedDockerfiles...), dockerfiles)
+				testDir.Paths(test.expectedBuilders...), paths)
 		})
 	}
 }
@@ -178,3 +209,24 @@ func writeAllFiles(tmpDir *testutil.TempDir, filesWithContents map[string]string
 		tmpDir.Write(file, contents)
 	}
 }
+
+func TestImagesForBuilders(t *testing.T) {
+	builders := []InitBuilder{
+		dockerfile{path: "web/Dockerfile"},
+		jibMaven{path: "backend/api/pom.xml", workspace: "backend", module: "api"},
+		dockerfile{path: "other/web/Dockerfile"},
+	}
+
+	var images []string
+	for _, pair := range imagesForBuilders(builders) {
+		images = append(images, pair.ImageName)
+	}
+
+	testutil.CheckDeepEqual(t, []string{"web", "api", "web-2"}, images)
+}
+
+func TestDoInitUnsupportedDeployer(t *testing.T) {
+	err := DoInit(&bytes.Buffer{}, Config{Deployer: "unknown"})
+
+	testutil.CheckError(t, true, err)
+}
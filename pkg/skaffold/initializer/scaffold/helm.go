@@ -0,0 +1,132 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffold
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Helm generates a chart, with one Deployment per image, whose
+// images are set by Skaffold through the chart values.
+type Helm struct {
+	name      string
+	chartPath string
+	images    []image
+	files     map[string][]byte
+}
+
+type chart struct {
+	APIVersion  string `yaml:"apiVersion"`
+	Name        string `yaml:"name"`
+	Version     string `yaml:"version"`
+	Description string `yaml:"description"`
+}
+
+// NewHelm returns a helm skaffold generator writing a chart with the given name to the given directory.
+func NewHelm(dir, name string, imageNames []string) (*Helm, error) {
+	name = ResourceName(name)
+	chartPath := filepath.Join(dir, name)
+	images := newImages(imageNames)
+
+	files := map[string][]byte{}
+	values := yaml.MapSlice{}
+	for _, img := range images {
+		key := valuesKey(img.Name)
+		files[filepath.Join(chartPath, "templates", img.Name+".yaml")] = deployment(img.Name, fmt.Sprintf("{{ .Values.%s.image }}", key))
+		values = append(values, yaml.MapItem{Key: key, Value: yaml.MapSlice{{Key: "image", Value: img.Image}}})
+	}
+
+	for path, v := range map[string]interface{}{
+		filepath.Join(chartPath, "Chart.yaml"): chart{
+			APIVersion:  "v1",
+			Name:        name,
+			Version:     "0.1.0",
+			Description: fmt.Sprintf("A Helm chart for %s, generated by skaffold init", name),
+		},
+		filepath.Join(chartPath, "values.yaml"): values,
+	} {
+		content, err := yaml.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		files[path] = content
+	}
+
+	return &Helm{
+		name:      name,
+		chartPath: chartPath,
+		images:    images,
+		files:     files,
+	}, nil
+}
+
+// GenerateDeployConfig implements the Initializer interface and generates
+// skaffold helm deployment config.
+func (h *Helm) GenerateDeployConfig() latest.DeployConfig {
+	values := map[string]string{}
+	for _, img := range h.images {
+		values[valuesKey(img.Name)+".image"] = img.Image
+	}
+
+	return latest.DeployConfig{
+		DeployType: latest.DeployType{
+			HelmDeploy: &latest.HelmDeploy{
+				Releases: []latest.HelmRelease{{
+					Name:      h.name,
+					ChartPath: h.chartPath,
+					Values:    values,
+				}},
+			},
+		},
+	}
+}
+
+// GetImages implements the Initializer interface and lists the images
+// deployed by the generated chart.
+func (h *Helm) GetImages() []string {
+	var images []string
+	for _, img := range h.images {
+		images = append(images, img.Image)
+	}
+	return images
+}
+
+// Files returns the generated files, by path.
+func (h *Helm) Files() map[string][]byte {
+	return h.files
+}
+
+// valuesKey turns a resource name into a key that can be used in
+// Helm templates, e.g. `leeroy-web` gives `leeroyWeb`.
+func valuesKey(name string) string {
+	parts := strings.Split(name, "-")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	key := strings.Join(parts, "")
+	if key[0] >= '0' && key[0] <= '9' {
+		key = "image" + key
+	}
+	return key
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffold
+
+import (
+	"path/filepath"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Kustomize generates a kustomize base, with one Deployment per image,
+// and a dev overlay that is deployed by Skaffold.
+type Kustomize struct {
+	dir    string
+	images []string
+	files  map[string][]byte
+}
+
+type kustomization struct {
+	Resources []string `yaml:"resources,omitempty"`
+	Bases     []string `yaml:"bases,omitempty"`
+}
+
+// NewKustomize returns a kustomize skaffold generator writing to the given directory.
+func NewKustomize(dir string, imageNames []string) (*Kustomize, error) {
+	files := map[string][]byte{}
+
+	var base kustomization
+	for _, img := range newImages(imageNames) {
+		file := img.Name + ".yaml"
+		files[filepath.Join(dir, "base", file)] = deployment(img.Name, img.Image)
+		base.Resources = append(base.Resources, file)
+	}
+
+	for path, k := range map[string]kustomization{
+		filepath.Join(dir, "base", "kustomization.yaml"):            base,
+		filepath.Join(dir, "overlays", "dev", "kustomization.yaml"): {Bases: []string{"../../base"}},
+	} {
+		content, err := yaml.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		files[path] = content
+	}
+
+	return &Kustomize{
+		dir:    dir,
+		images: imageNames,
+		files:  files,
+	}, nil
+}
+
+// GenerateDeployConfig implements the Initializer interface and generates
+// skaffold kustomize deployment config.
+func (k *Kustomize) GenerateDeployConfig() latest.DeployConfig {
+	return latest.DeployConfig{
+		DeployType: latest.DeployType{
+			KustomizeDeploy: &latest.KustomizeDeploy{
+				KustomizePath: filepath.Join(k.dir, "overlays", "dev"),
+			},
+		},
+	}
+}
+
+// GetImages implements the Initializer interface and lists the images
+// deployed by the generated base.
+func (k *Kustomize) GetImages() []string {
+	return k.images
+}
+
+// Files returns the generated files, by path.
+func (k *Kustomize) Files() map[string][]byte {
+	return k.files
+}
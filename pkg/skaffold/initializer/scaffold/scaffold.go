@@ -0,0 +1,96 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/docker"
+)
+
+var (
+	invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+	deploymentTemplate = template.Must(template.New("deployment").Parse(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{.Name}}
+  labels:
+    app: {{.Name}}
+spec:
+  selector:
+    matchLabels:
+      app: {{.Name}}
+  template:
+    metadata:
+      labels:
+        app: {{.Name}}
+    spec:
+      containers:
+      - name: {{.Name}}
+        image: {{.Image}}
+`))
+)
+
+// image is an image to deploy, with the name of its Kubernetes resources.
+type image struct {
+	Name  string
+	Image string
+}
+
+func newImages(imageNames []string) []image {
+	var images []image
+	used := map[string]int{}
+	for _, imageName := range imageNames {
+		name := ResourceName(imageName)
+		if used[name]++; used[name] > 1 {
+			name = fmt.Sprintf("%s-%d", name, used[name])
+		}
+
+		images = append(images, image{Name: name, Image: imageName})
+	}
+	return images
+}
+
+// ResourceName derives a valid Kubernetes resource name from an image name.
+// For example, `gcr.io/k8s-skaffold/leeroy-web:v1` gives `leeroy-web`.
+func ResourceName(imageName string) string {
+	if ref, err := docker.ParseReference(imageName); err == nil {
+		imageName = ref.BaseName
+	}
+
+	name := invalidNameChars.ReplaceAllString(strings.ToLower(path.Base(imageName)), "-")
+	name = strings.Trim(name, "-")
+	if name == "" {
+		return "app"
+	}
+	return name
+}
+
+// deployment generates the manifest of a Deployment running the image. The image
+// field is written as is, so that it can be a Helm template expression.
+func deployment(name, imageRef string) []byte {
+	var buf bytes.Buffer
+	// The template only writes strings, it can't fail on a bytes.Buffer.
+	deploymentTemplate.Execute(&buf, image{Name: name, Image: imageRef})
+	return buf.Bytes()
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffold
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestResourceName(t *testing.T) {
+	tests := []struct {
+		description string
+		image       string
+		expected    string
+	}{
+		{
+			description: "simple",
+			image:       "web",
+			expected:    "web",
+		},
+		{
+			description: "registry and tag",
+			image:       "gcr.io/k8s-skaffold/leeroy-web:v1",
+			expected:    "leeroy-web",
+		},
+		{
+			description: "invalid characters",
+			image:       "My_App",
+			expected:    "my-app",
+		},
+		{
+			description: "nothing left",
+			image:       "___",
+			expected:    "app",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			testutil.CheckDeepEqual(t, test.expected, ResourceName(test.image))
+		})
+	}
+}
+
+func TestNewKustomize(t *testing.T) {
+	k, err := NewKustomize("k8s", []string{"gcr.io/project/web", "worker"})
+	testutil.CheckError(t, false, err)
+
+	testutil.CheckDeepEqual(t, map[string]string{
+		"k8s/base/kustomization.yaml": `resources:
+- web.yaml
+- worker.yaml
+`,
+		"k8s/base/web.yaml":                   string(deployment("web", "gcr.io/project/web")),
+		"k8s/base/worker.yaml":                string(deployment("worker", "worker")),
+		"k8s/overlays/dev/kustomization.yaml": "bases:\n- ../../base\n",
+	}, toStrings(k.Files()))
+	testutil.CheckDeepEqual(t, latest.DeployConfig{
+		DeployType: latest.DeployType{
+			KustomizeDeploy: &latest.KustomizeDeploy{KustomizePath: "k8s/overlays/dev"},
+		},
+	}, k.GenerateDeployConfig())
+	testutil.CheckDeepEqual(t, []string{"gcr.io/project/web", "worker"}, k.GetImages())
+}
+
+func TestNewHelm(t *testing.T) {
+	h, err := NewHelm("charts", "My Project", []string{"gcr.io/project/leeroy-web", "other/leeroy-web"})
+	testutil.CheckError(t, false, err)
+
+	testutil.CheckDeepEqual(t, map[string]string{
+		"charts/my-project/Chart.yaml": `apiVersion: v1
+name: my-project
+version: 0.1.0
+description: A Helm chart for my-project, generated by skaffold init
+`,
+		"charts/my-project/values.yaml": `leeroyWeb:
+  image: gcr.io/project/leeroy-web
+leeroyWeb2:
+  image: other/leeroy-web
+`,
+		"charts/my-project/templates/leeroy-web.yaml":   string(deployment("leeroy-web", "{{ .Values.leeroyWeb.image }}")),
+		"charts/my-project/templates/leeroy-web-2.yaml": string(deployment("leeroy-web-2", "{{ .Values.leeroyWeb2.image }}")),
+	}, toStrings(h.Files()))
+	testutil.CheckDeepEqual(t, latest.DeployConfig{
+		DeployType: latest.DeployType{
+			HelmDeploy: &latest.HelmDeploy{
+				Releases: []latest.HelmRelease{{
+					Name:      "my-project",
+					ChartPath: "charts/my-project",
+					Values: map[string]string{
+						"leeroyWeb.image":  "gcr.io/project/leeroy-web",
+						"leeroyWeb2.image": "other/leeroy-web",
+					},
+				}},
+			},
+		},
+	}, h.GenerateDeployConfig())
+}
+
+func TestValuesKey(t *testing.T) {
+	testutil.CheckDeepEqual(t, "web", valuesKey("web"))
+	testutil.CheckDeepEqual(t, "leeroyWebApp", valuesKey("leeroy-web-app"))
+	testutil.CheckDeepEqual(t, "image2048", valuesKey("2048"))
+}
+
+func toStrings(files map[string][]byte) map[string]string {
+	m := map[string]string{}
+	for path, content := range files {
+		m[path] = string(content)
+	}
+	return m
+}
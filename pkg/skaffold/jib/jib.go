@@ -52,6 +52,26 @@ type filesLists struct {
 	BuildFileTimes map[string]time.Time
 }
 
+// GenerateLabelArgs generates the system property that makes Jib add labels
+// to the image, for both Maven and Gradle.
+func GenerateLabelArgs(labels map[string]string) []string {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	var keys []string
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, k := range keys {
+		pairs = append(pairs, k+"="+labels[k])
+	}
+	return []string{"-Djib.container.labels=" + strings.Join(pairs, ",")}
+}
+
 // watchedFiles maps from project name to watched files
 var watchedFiles = map[string]filesLists{}
 
@@ -29,6 +29,9 @@ import (
 // MavenCommand stores Maven executable and wrapper name
 var MavenCommand = util.CommandWrapper{Executable: "mvn", Wrapper: "mvnw"}
 
+// MavenDaemonCommand stores the Maven Daemon executable
+var MavenDaemonCommand = util.CommandWrapper{Executable: "mvnd"}
+
 // GetDependenciesMaven finds the source dependencies for the given jib-maven artifact.
 // All paths are absolute.
 func GetDependenciesMaven(ctx context.Context, workspace string, a *latest.JibMavenArtifact) ([]string, error) {
@@ -100,3 +100,8 @@ func TestGetDependencies(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateLabelArgs(t *testing.T) {
+	testutil.CheckDeepEqual(t, []string(nil), GenerateLabelArgs(nil))
+	testutil.CheckDeepEqual(t, []string{"-Djib.container.labels=revision=a1b2c3,team=backend"}, GenerateLabelArgs(map[string]string{"team": "backend", "revision": "a1b2c3"}))
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jib
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	// appRoot is where Jib puts the application in the image, by default.
+	appRoot = "/app"
+
+	classesDir   = appRoot + "/classes"
+	resourcesDir = appRoot + "/resources"
+)
+
+// IsSyncableSource returns true if a changed file only requires the classes
+// and resources of an artifact to be compiled again, rather than a full rebuild.
+func IsSyncableSource(workspace, file string) bool {
+	rel, err := filepath.Rel(workspace, file)
+	if err != nil {
+		return false
+	}
+
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	for i := 0; i+2 < len(parts); i++ {
+		if parts[i] == "src" && parts[i+1] == "main" {
+			// Jib's extra directories are copied as they are to the image.
+			return parts[i+2] != "jib"
+		}
+	}
+	return false
+}
+
+// CompileAndSyncMap runs an incremental compilation of an artifact with Maven or Gradle
+// and maps the classes and resources it updated to their location in the container,
+// following Jib's layout.
+func CompileAndSyncMap(ctx context.Context, a *latest.Artifact) (map[string][]string, error) {
+	// Some file systems have a one second resolution.
+	since := time.Now().Truncate(time.Second)
+
+	switch {
+	case a.JibMavenArtifact != nil:
+		args := append(mavenArgs(a.JibMavenArtifact), "compile", "--quiet")
+		if err := util.RunCmd(MavenCommand.CreateCommand(ctx, a.Workspace, args)); err != nil {
+			return nil, errors.Wrap(err, "compiling with Maven")
+		}
+
+		// Maven copies the resources next to the compiled classes.
+		return updatedFiles(since, map[string]string{
+			filepath.Join(mavenModuleDir(a.Workspace, a.JibMavenArtifact), "target", "classes"): "",
+		})
+
+	case a.JibGradleArtifact != nil:
+		args := append([]string{gradleCommand(a.JibGradleArtifact, "classes"), "-q"}, a.JibGradleArtifact.Flags...)
+		if err := util.RunCmd(GradleCommand.CreateCommand(ctx, a.Workspace, args)); err != nil {
+			return nil, errors.Wrap(err, "compiling with Gradle")
+		}
+
+		buildDir := filepath.Join(gradleProjectDir(a.Workspace, a.JibGradleArtifact), "build")
+		outputs := map[string]string{
+			filepath.Join(buildDir, "resources", "main"): resourcesDir,
+		}
+		for _, language := range []string{"java", "kotlin", "groovy", "scala"} {
+			outputs[filepath.Join(buildDir, "classes", language, "main")] = classesDir
+		}
+		return updatedFiles(since, outputs)
+
+	default:
+		return nil, errors.New("only Jib artifacts can be synced automatically")
+	}
+}
+
+// mavenModuleDir finds the directory of a Maven module. Modules given
+// as `[groupId]:artifactId` are assumed to be in a directory named after them.
+func mavenModuleDir(workspace string, a *latest.JibMavenArtifact) string {
+	if a.Module == "" {
+		return workspace
+	}
+
+	module := a.Module
+	if i := strings.LastIndex(module, ":"); i >= 0 {
+		module = module[i+1:]
+	}
+	return filepath.Join(workspace, module)
+}
+
+// gradleProjectDir finds the directory of a Gradle project, like `a/b` for `:a:b`.
+func gradleProjectDir(workspace string, a *latest.JibGradleArtifact) string {
+	return filepath.Join(workspace, filepath.FromSlash(strings.Replace(strings.TrimPrefix(a.Project, ":"), ":", "/", -1)))
+}
+
+// updatedFiles lists the files modified since a given time in output directories and maps them to
+// a directory of the container. An empty destination sends classes to the classes directory and other
+// files to the resources directory.
+func updatedFiles(since time.Time, outputs map[string]string) (map[string][]string, error) {
+	files := map[string][]string{}
+
+	for dir, dest := range outputs {
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			continue
+		}
+
+		if err := filepath.Walk(dir, func(file string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() || info.ModTime().Before(since) {
+				return nil
+			}
+
+			rel, err := filepath.Rel(dir, file)
+			if err != nil {
+				return err
+			}
+
+			target := dest
+			if target == "" {
+				target = resourcesDir
+				if strings.HasSuffix(file, ".class") {
+					target = classesDir
+				}
+			}
+
+			files[file] = []string{path.Join(target, filepath.ToSlash(rel))}
+			return nil
+		}); err != nil {
+			return nil, errors.Wrapf(err, "listing compiled files in %s", dir)
+		}
+	}
+
+	return files, nil
+}
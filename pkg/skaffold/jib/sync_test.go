@@ -0,0 +1,139 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jib
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestIsSyncableSource(t *testing.T) {
+	var tests = []struct {
+		file     string
+		expected bool
+	}{
+		{file: "src/main/java/hello/Main.java", expected: true},
+		{file: "module/src/main/resources/application.properties", expected: true},
+		{file: "src/main/jib/etc/config", expected: false},
+		{file: "src/test/java/hello/MainTest.java", expected: false},
+		{file: "pom.xml", expected: false},
+		{file: "build.gradle", expected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.file, func(t *testing.T) {
+			workspace := filepath.FromSlash("/project")
+			file := filepath.Join(workspace, filepath.FromSlash(test.file))
+
+			testutil.CheckDeepEqual(t, test.expected, IsSyncableSource(workspace, file))
+		})
+	}
+}
+
+func TestCompileAndSyncMap(t *testing.T) {
+	var tests = []struct {
+		description string
+		artifact    latest.ArtifactType
+		command     string
+		err         error
+		files       []string
+		updated     []string
+		expected    map[string][]string
+		shouldErr   bool
+	}{
+		{
+			description: "maven",
+			artifact:    latest.ArtifactType{JibMavenArtifact: &latest.JibMavenArtifact{}},
+			command:     "mvn --non-recursive compile --quiet",
+			files:       []string{"target/classes/hello/Old.class"},
+			updated:     []string{"target/classes/hello/Main.class", "target/classes/application.properties"},
+			expected: map[string][]string{
+				"target/classes/hello/Main.class":       {"/app/classes/hello/Main.class"},
+				"target/classes/application.properties": {"/app/resources/application.properties"},
+			},
+		},
+		{
+			description: "maven module",
+			artifact:    latest.ArtifactType{JibMavenArtifact: &latest.JibMavenArtifact{Module: ":service"}},
+			command:     "mvn --projects :service --also-make compile --quiet",
+			updated:     []string{"service/target/classes/hello/Main.class"},
+			expected: map[string][]string{
+				"service/target/classes/hello/Main.class": {"/app/classes/hello/Main.class"},
+			},
+		},
+		{
+			description: "gradle project",
+			artifact:    latest.ArtifactType{JibGradleArtifact: &latest.JibGradleArtifact{Project: "service"}},
+			command:     "gradle :service:classes -q",
+			files:       []string{"service/build/classes/java/main/hello/Old.class"},
+			updated:     []string{"service/build/classes/kotlin/main/hello/Main.class", "service/build/resources/main/application.yml"},
+			expected: map[string][]string{
+				"service/build/classes/kotlin/main/hello/Main.class": {"/app/classes/hello/Main.class"},
+				"service/build/resources/main/application.yml":       {"/app/resources/application.yml"},
+			},
+		},
+		{
+			description: "compilation error",
+			artifact:    latest.ArtifactType{JibGradleArtifact: &latest.JibGradleArtifact{}},
+			command:     "gradle :classes -q",
+			err:         errors.New("compilation failed"),
+			shouldErr:   true,
+		},
+		{
+			description: "not a jib artifact",
+			artifact:    latest.ArtifactType{DockerArtifact: &latest.DockerArtifact{}},
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			tmpDir, cleanup := testutil.NewTempDir(t)
+			defer cleanup()
+
+			old, recent := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+			for _, file := range test.files {
+				tmpDir.Write(file, "").Chtimes(file, old)
+			}
+			for _, file := range test.updated {
+				tmpDir.Write(file, "").Chtimes(file, recent)
+			}
+
+			reset := testutil.Override(t, &util.DefaultExecCommand, testutil.FakeRunErr(t, test.command, test.err))
+			defer reset()
+
+			files, err := CompileAndSyncMap(context.Background(), &latest.Artifact{
+				Workspace:    tmpDir.Root(),
+				ArtifactType: test.artifact,
+			})
+
+			var expected map[string][]string
+			if test.expected != nil {
+				expected = map[string][]string{}
+				for file, dests := range test.expected {
+					expected[tmpDir.Path(file)] = dests
+				}
+			}
+			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, expected, files)
+		})
+	}
+}
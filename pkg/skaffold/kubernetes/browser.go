@@ -0,0 +1,184 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+)
+
+var (
+	// For testing
+	openURL         = util.OpenBrowser
+	portWaitTimeout = 30 * time.Second
+)
+
+// browserOpener opens the URLs of forwarded ports once their pods are ready.
+type browserOpener struct {
+	// path is opened on the first forwarded port when no resource has an `open` path.
+	path      string
+	resources []*latest.PortForwardResource
+
+	// opened records the resources, by index, whose URL was already opened.
+	opened map[int]bool
+}
+
+// browserTarget is a URL to open, for a given resource.
+type browserTarget struct {
+	resource int
+	entry    *portForwardEntry
+	path     string
+}
+
+// WithBrowser configures the port forwarder to open forwarded URLs in the browser.
+func (p *PortForwarder) WithBrowser(path string, resources []*latest.PortForwardResource) *PortForwarder {
+	p.browser = &browserOpener{
+		path:      path,
+		resources: resources,
+		opened:    map[int]bool{},
+	}
+	return p
+}
+
+// openBrowser opens the URLs of a pod's forwarded ports, if it's ready.
+func (p *PortForwarder) openBrowser(ctx context.Context, pod *v1.Pod) {
+	if p.browser == nil || !isPodReady(pod) {
+		return
+	}
+
+	for _, target := range p.browser.targets(pod, p.podEntries(pod)) {
+		p.browser.opened[target.resource] = true
+		go openWhenForwarded(ctx, p.output, target)
+	}
+}
+
+// podEntries lists the forward entries of a pod, in the order of its containers' ports.
+func (p *PortForwarder) podEntries(pod *v1.Pod) []*portForwardEntry {
+	var entries []*portForwardEntry
+	for _, c := range pod.Spec.Containers {
+		for _, port := range c.Ports {
+			key := (&portForwardEntry{
+				namespace:     pod.Namespace,
+				containerName: c.Name,
+				portName:      port.Name,
+				port:          port.ContainerPort,
+			}).key()
+			if entry, found := p.forwardedPods[key]; found && entry.podName == pod.Name {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	return entries
+}
+
+// targets selects the URLs, not already opened, of a pod's forwarded ports.
+// Without any `open` path in the configuration, the first forwarded port is opened.
+func (o *browserOpener) targets(pod *v1.Pod, entries []*portForwardEntry) []browserTarget {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	var targets []browserTarget
+	configured := false
+	for i, r := range o.resources {
+		if r.Open == "" {
+			continue
+		}
+		configured = true
+
+		if o.opened[i] || !matchesResource(pod, r.ResourceName) {
+			continue
+		}
+		for _, entry := range entries {
+			if r.Port == 0 || int32(r.Port) == entry.port {
+				targets = append(targets, browserTarget{resource: i, entry: entry, path: r.Open})
+				break
+			}
+		}
+	}
+
+	if !configured && !o.opened[-1] {
+		targets = append(targets, browserTarget{resource: -1, entry: entries[0], path: o.path})
+	}
+	return targets
+}
+
+// openWhenForwarded waits for the local port to accept connections before opening the URL.
+func openWhenForwarded(ctx context.Context, out io.Writer, target browserTarget) {
+	address := fmt.Sprintf("localhost:%d", target.entry.localPort)
+	if err := waitForPort(ctx, address); err != nil {
+		logrus.Warnf("not opening the browser: %s", err)
+		return
+	}
+
+	url := "http://" + address + "/" + strings.TrimPrefix(target.path, "/")
+	color.Default.Fprintln(out, "Opening", url)
+	if err := openURL(url); err != nil {
+		logrus.Warnf("unable to open the browser: %s", err)
+	}
+}
+
+func waitForPort(ctx context.Context, address string) error {
+	ctx, cancel := context.WithTimeout(ctx, portWaitTimeout)
+	defer cancel()
+
+	for {
+		conn, err := net.DialTimeout("tcp", address, time.Second)
+		if err == nil {
+			return conn.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s isn't forwarded: %s", address, err)
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+}
+
+// matchesResource returns true for the pods with the given name, or created by a
+// workload with that name. Deployments are matched through their ReplicaSets' names.
+func matchesResource(pod *v1.Pod, name string) bool {
+	if pod.Name == name {
+		return true
+	}
+	for _, owner := range pod.OwnerReferences {
+		if owner.Name == name || (owner.Kind == "ReplicaSet" && strings.HasPrefix(owner.Name, name+"-")) {
+			return true
+		}
+	}
+	return false
+}
+
+func isPodReady(pod *v1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBrowserTargets(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "web-5d8f7-x2c4z",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-5d8f7"}},
+		},
+	}
+	http := &portForwardEntry{podName: "web-5d8f7-x2c4z", port: 8080, localPort: 8080}
+	admin := &portForwardEntry{podName: "web-5d8f7-x2c4z", port: 9000, localPort: 9001}
+
+	var tests = []struct {
+		description string
+		path        string
+		resources   []*latest.PortForwardResource
+		opened      map[int]bool
+		expected    []browserTarget
+	}{
+		{
+			description: "first port",
+			path:        "/",
+			expected:    []browserTarget{{resource: -1, entry: http, path: "/"}},
+		},
+		{
+			description: "already opened",
+			path:        "/",
+			opened:      map[int]bool{-1: true},
+		},
+		{
+			description: "resources without open path",
+			path:        "/docs",
+			resources:   []*latest.PortForwardResource{{ResourceName: "web", Port: 9000}},
+			expected:    []browserTarget{{resource: -1, entry: http, path: "/docs"}},
+		},
+		{
+			description: "configured port and path",
+			path:        "/",
+			resources: []*latest.PortForwardResource{
+				{ResourceName: "other", Open: "/"},
+				{ResourceName: "web", Port: 9000, Open: "/admin"},
+			},
+			expected: []browserTarget{{resource: 1, entry: admin, path: "/admin"}},
+		},
+		{
+			description: "configured path on another resource",
+			path:        "/",
+			resources:   []*latest.PortForwardResource{{ResourceName: "web-5d", Open: "/"}},
+		},
+		{
+			description: "configured pod name",
+			path:        "/",
+			resources:   []*latest.PortForwardResource{{ResourceName: "web-5d8f7-x2c4z", Open: "/index.html"}},
+			expected:    []browserTarget{{resource: 0, entry: http, path: "/index.html"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			opened := test.opened
+			if opened == nil {
+				opened = map[int]bool{}
+			}
+			opener := &browserOpener{path: test.path, resources: test.resources, opened: opened}
+
+			targets := opener.targets(pod, []*portForwardEntry{http, admin})
+
+			testutil.CheckDeepEqual(t, test.expected, targets, cmp.AllowUnexported(browserTarget{}, portForwardEntry{}))
+		})
+	}
+}
+
+func TestOpenBrowser(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	localPort := int32(listener.Addr().(*net.TCPAddr).Port)
+
+	opened := make(chan string, 1)
+	defer func(f func(string) error) { openURL = f }(openURL)
+	openURL = func(url string) error {
+		opened <- url
+		return nil
+	}
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "ns"},
+		Spec: v1.PodSpec{Containers: []v1.Container{{
+			Name:  "web",
+			Ports: []v1.ContainerPort{{ContainerPort: 8080}},
+		}}},
+	}
+	p := NewPortForwarder(ioutil.Discard, NewImageList(), []string{"ns"}).WithBrowser("/admin", nil)
+	entry := &portForwardEntry{podName: "web", namespace: "ns", containerName: "web", port: 8080, localPort: localPort}
+	p.forwardedPods[entry.key()] = entry
+
+	// not ready yet
+	p.openBrowser(context.Background(), pod)
+	testutil.CheckDeepEqual(t, map[int]bool{}, p.browser.opened)
+
+	pod.Status.Conditions = []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}}
+	p.openBrowser(context.Background(), pod)
+
+	select {
+	case url := <-opened:
+		testutil.CheckDeepEqual(t, fmt.Sprintf("http://localhost:%d/admin", localPort), url)
+	case <-time.After(5 * time.Second):
+		t.Fatal("browser wasn't opened")
+	}
+}
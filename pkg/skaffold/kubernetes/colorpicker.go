@@ -17,6 +17,7 @@ limitations under the License.
 package kubernetes
 
 import (
+	"hash/fnv"
 	"strings"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
@@ -49,14 +50,15 @@ type colorPicker struct {
 }
 
 // NewColorPicker creates a new ColorPicker. For each artifact, a color will be selected
-// sequentially from `colorCodes`. If all colors are used, the first color will be used
-// again. The formatter for the associated color will then be returned by `Pick` each
-// time it is called for the artifact and can be used to write to out in that color.
+// from `colorCodes` with a hash of its image name, so that an artifact keeps its color
+// between runs, whichever artifacts are deployed with it. The formatter for the associated
+// color will then be returned by `Pick` each time it is called for the artifact and can be
+// used to write to out in that color.
 func NewColorPicker(baseImageNames []string) ColorPicker {
 	imageColors := make(map[string]color.Color)
 
-	for i, baseImageName := range baseImageNames {
-		imageColors[baseImageName] = colorCodes[i%len(colorCodes)]
+	for _, baseImageName := range baseImageNames {
+		imageColors[baseImageName] = colorFor(baseImageName)
 	}
 
 	return &colorPicker{
@@ -78,6 +80,13 @@ func (p *colorPicker) Pick(pod *v1.Pod) color.Color {
 	return color.None
 }
 
+func colorFor(imageName string) color.Color {
+	h := fnv.New32a()
+	h.Write([]byte(imageName))
+
+	return colorCodes[h.Sum32()%uint32(len(colorCodes))]
+}
+
 func stripTag(image string) string {
 	if !strings.Contains(image, ":") {
 		return image
@@ -43,7 +43,7 @@ func TestColorPicker(t *testing.T) {
 					},
 				},
 			},
-			expectedColor: colorCodes[0],
+			expectedColor: colorFor("image"),
 		},
 		{
 			description: "ignore tag",
@@ -54,7 +54,7 @@ func TestColorPicker(t *testing.T) {
 					},
 				},
 			},
-			expectedColor: colorCodes[0],
+			expectedColor: colorFor("image"),
 		},
 		{
 			description: "second image",
@@ -65,7 +65,7 @@ func TestColorPicker(t *testing.T) {
 					},
 				},
 			},
-			expectedColor: colorCodes[1],
+			expectedColor: colorFor("second"),
 		},
 	}
 
@@ -81,3 +81,20 @@ func TestColorPicker(t *testing.T) {
 		})
 	}
 }
+
+func TestColorPickerIsStable(t *testing.T) {
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{Image: "image:tag"},
+			},
+		},
+	}
+
+	first := NewColorPicker([]string{"image", "second"}).Pick(pod)
+	second := NewColorPicker([]string{"other", "second", "image"}).Pick(pod)
+
+	if first != second {
+		t.Errorf("Expected the same color whatever the other images. Got %d and %d", first, second)
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// FindPodForImage returns the most recent running pod, in the given namespaces,
+// with a container that runs the given image, along with the name of that container.
+func FindPodForImage(namespaces []string, image string) (*v1.Pod, string, error) {
+	client, err := Client()
+	if err != nil {
+		return nil, "", errors.Wrap(err, "getting kubernetes client")
+	}
+
+	return findPodForImage(client, namespaces, image)
+}
+
+func findPodForImage(client kubernetes.Interface, namespaces []string, image string) (*v1.Pod, string, error) {
+	name, err := imageName(image)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "parsing image %s", image)
+	}
+
+	var pods []v1.Pod
+	for _, ns := range namespaces {
+		list, err := client.CoreV1().Pods(ns).List(meta_v1.ListOptions{})
+		if err != nil {
+			return nil, "", errors.Wrapf(err, "listing pods in namespace %s", ns)
+		}
+		for _, pod := range list.Items {
+			if pod.Status.Phase == v1.PodRunning && pod.DeletionTimestamp == nil {
+				pods = append(pods, pod)
+			}
+		}
+	}
+
+	sort.SliceStable(pods, func(i, j int) bool {
+		return pods[j].CreationTimestamp.Before(&pods[i].CreationTimestamp)
+	})
+
+	for i := range pods {
+		for _, c := range pods[i].Spec.Containers {
+			if n, err := imageName(c.Image); err == nil && n == name {
+				return &pods[i], c.Name, nil
+			}
+		}
+	}
+
+	return nil, "", fmt.Errorf("no running pod found for image %s in namespaces %s", image, strings.Join(namespaces, ", "))
+}
+
+// imageName returns the fully qualified name of an image, without its tag or digest.
+func imageName(image string) (string, error) {
+	ref, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return "", err
+	}
+	return ref.Name(), nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func pod(name, namespace string, phase v1.PodPhase, created time.Time, images ...string) *v1.Pod {
+	var containers []v1.Container
+	for i, image := range images {
+		containers = append(containers, v1.Container{Name: fmt.Sprintf("c%d", i), Image: image})
+	}
+	return &v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: namespace, CreationTimestamp: meta_v1.NewTime(created)},
+		Spec:       v1.PodSpec{Containers: containers},
+		Status:     v1.PodStatus{Phase: phase},
+	}
+}
+
+func TestFindPodForImage(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		description       string
+		objects           []runtime.Object
+		image             string
+		expectedPod       string
+		expectedContainer string
+		shouldErr         bool
+	}{
+		{
+			description:       "match on name, ignoring tag and digest",
+			objects:           []runtime.Object{pod("app", "default", v1.PodRunning, now, "redis:5", "gcr.io/project/app:v1@sha256:aaaabbbbccccddddeeeeffff00001111aaaabbbbccccddddeeeeffff00001111")},
+			image:             "gcr.io/project/app",
+			expectedPod:       "app",
+			expectedContainer: "c1",
+		},
+		{
+			description:       "short names are normalized",
+			objects:           []runtime.Object{pod("app", "default", v1.PodRunning, now, "docker.io/library/app:abc")},
+			image:             "app",
+			expectedPod:       "app",
+			expectedContainer: "c0",
+		},
+		{
+			description: "most recent running pod",
+			objects: []runtime.Object{
+				pod("old", "default", v1.PodRunning, now.Add(-time.Hour), "app:v1"),
+				pod("new", "default", v1.PodRunning, now, "app:v2"),
+				pod("pending", "default", v1.PodPending, now.Add(time.Hour), "app:v3"),
+			},
+			image:             "app",
+			expectedPod:       "new",
+			expectedContainer: "c0",
+		},
+		{
+			description: "other namespace",
+			objects:     []runtime.Object{pod("app", "other", v1.PodRunning, now, "app:v1")},
+			image:       "app",
+			shouldErr:   true,
+		},
+		{
+			description: "no match",
+			objects:     []runtime.Object{pod("redis", "default", v1.PodRunning, now, "redis")},
+			image:       "app",
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			client := fake.NewSimpleClientset(test.objects...)
+
+			pod, container, err := findPodForImage(client, []string{"default"}, test.image)
+
+			testutil.CheckError(t, test.shouldErr, err)
+			if !test.shouldErr {
+				testutil.CheckDeepEqual(t, test.expectedPod, pod.Name)
+				testutil.CheckDeepEqual(t, test.expectedContainer, container)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// knativeServiceLabel is set by Knative on the pods of a service's revisions.
+	knativeServiceLabel = "serving.knative.dev/service"
+
+	// knativeQueueProxy is the sidecar that receives the requests sent to a Knative service.
+	knativeQueueProxy = "queue-proxy"
+)
+
+// isKnativeServingPort returns true for the queue proxy's HTTP/1 and h2c ports.
+// Its admin and metrics ports are not worth forwarding, and neither is the user container,
+// which doesn't see the requests the way the service does.
+func isKnativeServingPort(c v1.Container, port v1.ContainerPort) bool {
+	return c.Name == knativeQueueProxy && (port.ContainerPort == 8012 || port.ContainerPort == 8013)
+}
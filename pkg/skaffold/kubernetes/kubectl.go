@@ -0,0 +1,48 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"os/exec"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+)
+
+// kubectlGlobalFlags are the `deploy.kubectl.flags.global` of the pipeline.
+var kubectlGlobalFlags []string
+
+// SetKubectlGlobalFlags sets the flags passed to every `kubectl` command
+// that Skaffold runs, like `kubectl logs` or `kubectl exec`.
+func SetKubectlGlobalFlags(flags []string) {
+	kubectlGlobalFlags = flags
+}
+
+// KubectlCommand returns a `kubectl` command, with the global flags.
+func KubectlCommand(ctx context.Context, arg ...string) *exec.Cmd {
+	return exec.CommandContext(ctx, util.ToolPath("kubectl"), kubectlArgs(arg)...)
+}
+
+// kubectlPortForward returns a `kubectl port-forward` command, with the global flags.
+// It's terminated gracefully when the context is done, so that no process is left behind.
+func kubectlPortForward(ctx context.Context, arg ...string) *util.Cmd {
+	return util.CommandContext(ctx, util.ToolPath("kubectl"), kubectlArgs(append([]string{"port-forward"}, arg...))...)
+}
+
+func kubectlArgs(arg []string) []string {
+	return append(append([]string{}, kubectlGlobalFlags...), arg...)
+}
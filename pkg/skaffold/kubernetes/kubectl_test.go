@@ -0,0 +1,35 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestKubectlCommand(t *testing.T) {
+	defer SetKubectlGlobalFlags(nil)
+
+	cmd := KubectlCommand(context.Background(), "logs", "-f", "pod")
+	testutil.CheckDeepEqual(t, []string{"kubectl", "logs", "-f", "pod"}, cmd.Args)
+
+	SetKubectlGlobalFlags([]string{"--request-timeout=5s", "-v=0"})
+	cmd = KubectlCommand(context.Background(), "exec", "pod", "--", "ls")
+	testutil.CheckDeepEqual(t, []string{"kubectl", "--request-timeout=5s", "-v=0", "exec", "pod", "--", "ls"}, cmd.Args)
+}
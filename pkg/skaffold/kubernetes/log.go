@@ -21,7 +21,7 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"os/exec"
+	"path/filepath"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -50,6 +50,16 @@ type LogAggregator struct {
 	startTime         time.Time
 	cancel            context.CancelFunc
 	trackedContainers trackedContainers
+	sources           []LogSource
+	containers        []string
+	crashes           chan error
+}
+
+// LogSource streams logs that don't come from pods, like the request logs of Cloud Run services.
+type LogSource interface {
+	// Logs starts streaming logs until the context is cancelled.
+	// It returns one reader per stream, keyed by the prefix of the stream's lines.
+	Logs(ctx context.Context) (map[string]io.Reader, error)
 }
 
 // NewLogAggregator creates a new LogAggregator for a given output.
@@ -62,9 +72,26 @@ func NewLogAggregator(out io.Writer, baseImageNames []string, podSelector PodSel
 		trackedContainers: trackedContainers{
 			ids: map[string]bool{},
 		},
+		crashes: make(chan error, 1),
 	}
 }
 
+// SetContainers restricts the logs to the containers with the given names,
+// or matching the given glob patterns. All the containers are logged by default.
+func (a *LogAggregator) SetContainers(containers []string) {
+	a.containers = containers
+}
+
+// Crashes reports the containers that exit with an error while their logs are streamed.
+func (a *LogAggregator) Crashes() <-chan error {
+	return a.crashes
+}
+
+// AddSource adds logs that don't come from pods.
+func (a *LogAggregator) AddSource(source LogSource) {
+	a.sources = append(a.sources, source)
+}
+
 // Start starts a logger that listens to pods and tail their logs
 // if they are matched by the `podSelector`.
 func (a *LogAggregator) Start(ctx context.Context) error {
@@ -72,10 +99,29 @@ func (a *LogAggregator) Start(ctx context.Context) error {
 	a.cancel = cancel
 	a.startTime = time.Now()
 
+	for _, source := range a.sources {
+		streams, err := source.Logs(cancelCtx)
+		if err != nil {
+			return errors.Wrap(err, "streaming logs")
+		}
+		for header, logs := range streams {
+			go func(header string, logs io.Reader) {
+				if err := a.streamRequest(cancelCtx, color.Default, fmt.Sprintf("[%s]", header), logs); err != nil {
+					logrus.Errorf("streaming request %s", err)
+				}
+			}(header, logs)
+		}
+	}
+
 	aggregate := make(chan watch.Event)
 	stopWatchers, err := AggregatePodWatcher(a.namespaces, aggregate)
 	if err != nil {
 		stopWatchers()
+		// Logs that don't come from pods don't need a cluster.
+		if len(a.sources) > 0 {
+			logrus.Debugf("Not tailing pod logs: %s", err)
+			return nil
+		}
 		return errors.Wrap(err, "initializing aggregate pod watcher")
 	}
 
@@ -105,6 +151,17 @@ func (a *LogAggregator) Start(ctx context.Context) error {
 				}
 
 				for _, container := range append(pod.Status.ContainerStatuses, pod.Status.InitContainerStatuses...) {
+					if !a.logsContainer(container.Name) {
+						continue
+					}
+
+					if err := crashOf(pod, container, a.startTime); err != nil {
+						select {
+						case a.crashes <- err:
+						default:
+						}
+					}
+
 					if container.ContainerID == "" {
 						if container.State.Waiting != nil && container.State.Waiting.Message != "" {
 							color.Red.Fprintln(a.output, container.State.Waiting.Message)
@@ -135,6 +192,33 @@ func (a *LogAggregator) Stop() {
 	}
 }
 
+func (a *LogAggregator) logsContainer(name string) bool {
+	if len(a.containers) == 0 {
+		return true
+	}
+
+	for _, pattern := range a.containers {
+		if matches, _ := filepath.Match(pattern, name); matches {
+			return true
+		}
+	}
+	return false
+}
+
+// crashOf returns an error if a container exited with an error since the given time.
+// Containers that are restarted after crashing report it as their last state.
+func crashOf(pod *v1.Pod, container v1.ContainerStatus, since time.Time) error {
+	terminated := container.State.Terminated
+	if terminated == nil {
+		terminated = container.LastTerminationState.Terminated
+	}
+	if terminated == nil || terminated.ExitCode == 0 || terminated.FinishedAt.Time.Before(since) {
+		return nil
+	}
+
+	return fmt.Errorf("container %s of pod %s exited with code %d (%s)", container.Name, pod.Name, terminated.ExitCode, terminated.Reason)
+}
+
 func sinceSeconds(d time.Duration) int64 {
 	since := int64((d + 999*time.Millisecond).Truncate(1 * time.Second).Seconds())
 	if since != 0 {
@@ -154,7 +238,7 @@ func (a *LogAggregator) streamContainerLogs(ctx context.Context, pod *v1.Pod, co
 	sinceSeconds := fmt.Sprintf("--since=%ds", sinceSeconds(time.Since(a.startTime)))
 
 	tr, tw := io.Pipe()
-	cmd := exec.CommandContext(ctx, "kubectl", "logs", sinceSeconds, "-f", pod.Name, "-c", container.Name, "--namespace", pod.Namespace)
+	cmd := KubectlCommand(ctx, "logs", sinceSeconds, "-f", pod.Name, "-c", container.Name, "--namespace", pod.Namespace)
 	cmd.Stdout = tw
 	go util.RunCmd(cmd)
 
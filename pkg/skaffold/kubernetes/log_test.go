@@ -17,8 +17,19 @@ limitations under the License.
 package kubernetes
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
 	"testing"
 	"time"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 )
 
 func TestSinceSeconds(t *testing.T) {
@@ -49,3 +60,121 @@ func TestSinceSeconds(t *testing.T) {
 		})
 	}
 }
+
+type fakeLogSource map[string]string
+
+func (s fakeLogSource) Logs(context.Context) (map[string]io.Reader, error) {
+	streams := map[string]io.Reader{}
+	for header, logs := range s {
+		streams[header] = strings.NewReader(logs)
+	}
+	return streams, nil
+}
+
+type syncBuffer struct {
+	sync.Mutex
+	bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+	return b.Buffer.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.Lock()
+	defer b.Unlock()
+	return b.Buffer.String()
+}
+
+func TestLogSourceWithoutCluster(t *testing.T) {
+	defer testutil.Override(t, &Client, func() (kubernetes.Interface, error) {
+		return nil, errors.New("no cluster")
+	})()
+
+	out := &syncBuffer{}
+	logger := NewLogAggregator(out, nil, NewImageList(), []string{"default"})
+	logger.AddSource(fakeLogSource{"hello": "GET 200 /\nGET 404 /favicon.ico\n"})
+
+	err := logger.Start(context.Background())
+	defer logger.Stop()
+	testutil.CheckError(t, false, err)
+
+	expected := "[hello] GET 200 /\n[hello] GET 404 /favicon.ico\n"
+	for i := 0; i < 100 && out.String() != expected; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	testutil.CheckDeepEqual(t, expected, out.String())
+}
+
+func TestLogsContainer(t *testing.T) {
+	var tests = []struct {
+		description string
+		containers  []string
+		name        string
+		expected    bool
+	}{
+		{"no filter", nil, "app", true},
+		{"name", []string{"app"}, "app", true},
+		{"other name", []string{"app"}, "sidecar", false},
+		{"glob pattern", []string{"web-*"}, "web-frontend", true},
+		{"several filters", []string{"app", "web-*"}, "web-frontend", true},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			logger := NewLogAggregator(nil, nil, NewImageList(), nil)
+			logger.SetContainers(test.containers)
+
+			testutil.CheckDeepEqual(t, test.expected, logger.logsContainer(test.name))
+		})
+	}
+}
+
+func TestCrashOf(t *testing.T) {
+	start := time.Now()
+	terminated := func(exitCode int32, finishedAt time.Time) *v1.ContainerStateTerminated {
+		return &v1.ContainerStateTerminated{ExitCode: exitCode, Reason: "Error", FinishedAt: metav1.NewTime(finishedAt)}
+	}
+
+	var tests = []struct {
+		description string
+		container   v1.ContainerStatus
+		shouldErr   bool
+	}{
+		{
+			description: "running",
+			container:   v1.ContainerStatus{State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}},
+		},
+		{
+			description: "exited with an error",
+			container:   v1.ContainerStatus{State: v1.ContainerState{Terminated: terminated(1, start.Add(time.Second))}},
+			shouldErr:   true,
+		},
+		{
+			description: "completed",
+			container:   v1.ContainerStatus{State: v1.ContainerState{Terminated: terminated(0, start.Add(time.Second))}},
+		},
+		{
+			description: "restarted after crashing",
+			container: v1.ContainerStatus{
+				State:                v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: v1.ContainerState{Terminated: terminated(137, start.Add(time.Second))},
+			},
+			shouldErr: true,
+		},
+		{
+			description: "crashed before the logs were streamed",
+			container:   v1.ContainerStatus{State: v1.ContainerState{Terminated: terminated(1, start.Add(-time.Minute))}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod"}}
+
+			err := crashOf(pod, test.container, start)
+
+			testutil.CheckError(t, test.shouldErr, err)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"io"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// CreateNamespaceIfMissing creates a namespace, unless it already exists.
+func CreateNamespaceIfMissing(out io.Writer, name string) error {
+	client, err := Client()
+	if err != nil {
+		return errors.Wrap(err, "getting kubernetes client")
+	}
+
+	return createNamespaceIfMissing(out, client, name)
+}
+
+func createNamespaceIfMissing(out io.Writer, client kubernetes.Interface, name string) error {
+	_, err := client.CoreV1().Namespaces().Get(name, meta_v1.GetOptions{})
+	if err == nil {
+		return nil
+	}
+	if !apierrs.IsNotFound(err) {
+		return errors.Wrapf(err, "getting namespace %s", name)
+	}
+
+	color.Default.Fprintf(out, "Creating namespace %s\n", name)
+	_, err = client.CoreV1().Namespaces().Create(&v1.Namespace{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "skaffold",
+			},
+		},
+	})
+	if err != nil && !apierrs.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "creating namespace %s", name)
+	}
+	return nil
+}
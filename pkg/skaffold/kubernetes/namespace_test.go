@@ -0,0 +1,61 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCreateNamespaceIfMissing(t *testing.T) {
+	tests := []struct {
+		description    string
+		objects        []runtime.Object
+		expectedOut    string
+		expectedLabels map[string]string
+	}{
+		{
+			description:    "missing namespace",
+			expectedOut:    "Creating namespace jdoe-dev\n",
+			expectedLabels: map[string]string{"app.kubernetes.io/managed-by": "skaffold"},
+		},
+		{
+			description: "existing namespace",
+			objects: []runtime.Object{&v1.Namespace{
+				ObjectMeta: meta_v1.ObjectMeta{Name: "jdoe-dev"},
+			}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			client := fake.NewSimpleClientset(test.objects...)
+
+			var out bytes.Buffer
+			err := createNamespaceIfMissing(&out, client, "jdoe-dev")
+			testutil.CheckErrorAndDeepEqual(t, false, err, test.expectedOut, out.String())
+
+			namespace, err := client.CoreV1().Namespaces().Get("jdoe-dev", meta_v1.GetOptions{})
+			testutil.CheckErrorAndDeepEqual(t, false, err, test.expectedLabels, namespace.Labels)
+		})
+	}
+}
@@ -21,7 +21,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"os/exec"
 	"strconv"
 	"sync"
 
@@ -48,10 +47,14 @@ type PortForwarder struct {
 
 	// forwardedPorts serves as a synchronized set of ports we've forwarded.
 	forwardedPorts *sync.Map
+
+	// browser, if set, opens forwarded URLs.
+	browser *browserOpener
 }
 
 type portForwardEntry struct {
 	resourceVersion int
+	reestablished   bool
 	podName         string
 	namespace       string
 	containerName   string
@@ -59,7 +62,11 @@ type portForwardEntry struct {
 	port            int32
 	localPort       int32
 
-	cancel context.CancelFunc
+	// knativeService is the Knative Service served by the pod, if any.
+	knativeService string
+
+	cancel     context.CancelFunc
+	terminated chan struct{}
 }
 
 // Forwarder is an interface that can modify and manage port-forward processes
@@ -83,7 +90,7 @@ func (*kubectlForwarder) Forward(parentCtx context.Context, pfe *portForwardEntr
 	ctx, cancel := context.WithCancel(parentCtx)
 	pfe.cancel = cancel
 
-	cmd := exec.CommandContext(ctx, "kubectl", "port-forward", pfe.podName, fmt.Sprintf("%d:%d", pfe.localPort, pfe.port), "--namespace", pfe.namespace)
+	cmd := kubectlPortForward(ctx, pfe.podName, fmt.Sprintf("%d:%d", pfe.localPort, pfe.port), "--namespace", pfe.namespace)
 	buf := &bytes.Buffer{}
 	cmd.Stdout = buf
 	cmd.Stderr = buf
@@ -95,20 +102,37 @@ func (*kubectlForwarder) Forward(parentCtx context.Context, pfe *portForwardEntr
 		return errors.Wrapf(err, "port forwarding pod: %s/%s, port: %d to local port: %d, err: %s", pfe.namespace, pfe.podName, pfe.port, pfe.localPort, buf.String())
 	}
 
-	event.PortForwarded(pfe.localPort, pfe.port, pfe.podName, pfe.containerName, pfe.namespace, pfe.portName)
+	if pfe.reestablished {
+		event.PortForwardReestablished(pfe.localPort, pfe.port, pfe.podName, pfe.containerName, pfe.namespace, pfe.portName)
+	} else {
+		event.PortForwarded(pfe.localPort, pfe.port, pfe.podName, pfe.containerName, pfe.namespace, pfe.portName)
+	}
 
-	go cmd.Wait()
+	terminated := make(chan struct{})
+	pfe.terminated = terminated
+	go func() {
+		cmd.Wait()
+		// The port-forward wasn't terminated by Skaffold.
+		if ctx.Err() == nil {
+			event.PortForwardLost(pfe.localPort, pfe.port, pfe.podName, pfe.containerName, pfe.namespace, pfe.portName)
+		}
+		close(terminated)
+	}()
 
 	return nil
 }
 
 // Terminate terminates an existing kubectl port-forward command using SIGTERM
+// and waits for it to exit, so that no process is left behind.
 func (*kubectlForwarder) Terminate(p *portForwardEntry) {
 	logrus.Debugf("Terminating port-forward %s", p)
 
 	if p.cancel != nil {
 		p.cancel()
 	}
+	if p.terminated != nil {
+		<-p.terminated
+	}
 }
 
 // NewPortForwarder returns a struct that tracks and port-forwards pods as they are created and modified
@@ -172,7 +196,9 @@ func (p *PortForwarder) Start(ctx context.Context) error {
 				if p.podSelector.Select(pod) && pod.Status.Phase == v1.PodRunning && pod.DeletionTimestamp == nil {
 					if err := p.portForwardPod(ctx, pod); err != nil {
 						logrus.Warnf("port forwarding pod failed: %s", err)
+						continue
 					}
+					p.openBrowser(ctx, pod)
 				}
 			}
 		}
@@ -187,8 +213,15 @@ func (p *PortForwarder) portForwardPod(ctx context.Context, pod *v1.Pod) error {
 		return errors.Wrap(err, "converting resource version to integer")
 	}
 
+	knativeService := pod.Labels[knativeServiceLabel]
+
 	for _, c := range pod.Spec.Containers {
 		for _, port := range c.Ports {
+			// Knative pods are reached like the service, through their queue proxy.
+			if knativeService != "" && !isKnativeServingPort(c, port) {
+				continue
+			}
+
 			// get current entry for this container
 			entry := p.getCurrentEntry(pod, c, port, resourceVersion)
 			if entry.port != entry.localPort {
@@ -197,6 +230,9 @@ func (p *PortForwarder) portForwardPod(ctx context.Context, pod *v1.Pod) error {
 			if err := p.forward(ctx, entry); err != nil {
 				return errors.Wrap(err, "failed to forward port")
 			}
+			if knativeService != "" {
+				color.Default.Fprintf(p.output, "Knative service %s is available at http://localhost:%d\n", knativeService, entry.localPort)
+			}
 		}
 	}
 	return nil
@@ -211,6 +247,7 @@ func (p *PortForwarder) getCurrentEntry(pod *v1.Pod, c v1.Container, port v1.Con
 		containerName:   c.Name,
 		portName:        port.Name,
 		port:            port.ContainerPort,
+		knativeService:  pod.Labels[knativeServiceLabel],
 	}
 	// If we have, return the current entry
 	oldEntry, ok := p.forwardedPods[entry.key()]
@@ -230,6 +267,7 @@ func (p *PortForwarder) forward(ctx context.Context, entry *portForwardEntry) er
 		if entry.resourceVersion > prevEntry.resourceVersion {
 			p.Terminate(prevEntry)
 		}
+		entry.reestablished = true
 	}
 
 	color.Default.Fprintln(p.output, fmt.Sprintf("Port Forwarding %s/%s %d -> %d", entry.podName, entry.containerName, entry.port, entry.localPort))
@@ -243,6 +281,10 @@ func (p *PortForwarder) forward(ctx context.Context, entry *portForwardEntry) er
 
 // Key is an identifier for the lock on a port during the skaffold dev cycle.
 func (p *portForwardEntry) key() string {
+	// Every Knative pod has the same queue proxy container.
+	if p.knativeService != "" {
+		return fmt.Sprintf("%s-%s-%s-%s-%d", p.knativeService, p.containerName, p.namespace, p.portName, p.port)
+	}
 	return fmt.Sprintf("%s-%s-%s-%d", p.containerName, p.namespace, p.portName, p.port)
 }
 
@@ -120,6 +120,40 @@ func TestPortForwardPod(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "knative pods only forward the queue proxy",
+			expectedPorts: map[int32]bool{
+				8012: true,
+				8013: true,
+			},
+			availablePorts: []int{8012, 8013},
+			expectedEntries: map[string]*portForwardEntry{
+				"hello-queue-proxy-namespace-queue-port-8012": {
+					resourceVersion: 1,
+					podName:         "hello-00001-deployment-abc",
+					containerName:   "queue-proxy",
+					namespace:       "namespace",
+					portName:        "queue-port",
+					port:            8012,
+					localPort:       8012,
+					knativeService:  "hello",
+				},
+				"world-queue-proxy-namespace-queue-port-8012": {
+					resourceVersion: 1,
+					podName:         "world-00001-deployment-def",
+					containerName:   "queue-proxy",
+					namespace:       "namespace",
+					portName:        "queue-port",
+					port:            8012,
+					localPort:       8013,
+					knativeService:  "world",
+				},
+			},
+			pods: []*v1.Pod{
+				knativePod("hello", "hello-00001-deployment-abc"),
+				knativePod("world", "world-00001-deployment-def"),
+			},
+		},
 		{
 			description: "unavailable container port",
 			expectedPorts: map[int32]bool{
@@ -380,6 +414,7 @@ func TestPortForwardPod(t *testing.T) {
 			expectedEntries: map[string]*portForwardEntry{
 				"containername-namespace-portname-8080": {
 					resourceVersion: 2,
+					reestablished:   true,
 					podName:         "podname",
 					containerName:   "containername",
 					namespace:       "namespace",
@@ -462,6 +497,33 @@ func TestPortForwardPod(t *testing.T) {
 	}
 }
 
+func knativePod(service, name string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			ResourceVersion: "1",
+			Namespace:       "namespace",
+			Labels:          map[string]string{knativeServiceLabel: service},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name:  "user-container",
+					Ports: []v1.ContainerPort{{ContainerPort: 8080, Name: "user-port"}},
+				},
+				{
+					Name: "queue-proxy",
+					Ports: []v1.ContainerPort{
+						{ContainerPort: 8022, Name: "http-queueadm"},
+						{ContainerPort: 9090, Name: "http-autometric"},
+						{ContainerPort: 8012, Name: "queue-port"},
+					},
+				},
+			},
+		},
+	}
+}
+
 func TestPortForwardEntryKey(t *testing.T) {
 	pfe := &portForwardEntry{
 		podName:       "pod",
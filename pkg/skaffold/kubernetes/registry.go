@@ -0,0 +1,272 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	clusterRegistryName  = "skaffold-registry"
+	clusterRegistryLabel = "skaffold.dev/registry"
+	registryPort         = 5000
+
+	// ClusterRegistryPort is both the node port of the registry and the local port
+	// it's forwarded to, so that images are pushed and pulled with the same name.
+	// Nodes treat registries on localhost as insecure.
+	ClusterRegistryPort = 30500
+)
+
+var (
+	// For testing
+	clusterRegistryImage      = "registry:2"
+	clusterRegistryTimeout    = 2 * time.Minute
+	clusterRegistryPollPeriod = time.Second
+)
+
+// ClusterRegistry is an image registry that runs in the cluster. Skaffold pushes
+// images to it through a port-forward and the nodes pull them through its node port.
+type ClusterRegistry struct {
+	Namespace string
+	Name      string
+	NodePort  int32
+	selector  map[string]string
+
+	cancel     context.CancelFunc
+	terminated chan struct{}
+}
+
+// StartClusterRegistry uses the registry found in the cluster, or deploys one to
+// the namespace, and forwards its port. The registry is kept when Skaffold exits
+// so that the next session can reuse it.
+func StartClusterRegistry(ctx context.Context, out io.Writer, namespace string) (*ClusterRegistry, error) {
+	client, err := Client()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting kubernetes client")
+	}
+
+	registry, err := findClusterRegistry(client)
+	if err != nil {
+		return nil, err
+	}
+
+	if registry == nil {
+		if namespace == "" {
+			namespace = "default"
+		}
+		color.Default.Fprintf(out, "Deploying an image registry to namespace %s\n", namespace)
+
+		if registry, err = deployClusterRegistry(client, namespace); err != nil {
+			return nil, errors.Wrap(err, "deploying registry")
+		}
+	} else {
+		color.Default.Fprintf(out, "Using image registry %s/%s\n", registry.Namespace, registry.Name)
+	}
+
+	if err := registry.waitForPod(ctx, client); err != nil {
+		return nil, err
+	}
+	if err := registry.forward(ctx); err != nil {
+		return nil, err
+	}
+
+	color.Default.Fprintf(out, "Images are pushed to %s\n", registry.Repo())
+	return registry, nil
+}
+
+// Repo is the default repo of the images pushed to the registry.
+func (r *ClusterRegistry) Repo() string {
+	return fmt.Sprintf("localhost:%d", r.NodePort)
+}
+
+// Stop terminates the port-forward.
+func (r *ClusterRegistry) Stop() {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	if r.terminated != nil {
+		<-r.terminated
+	}
+}
+
+// findClusterRegistry looks for a registry labelled with `skaffold.dev/registry` in all namespaces.
+func findClusterRegistry(client kubernetes.Interface) (*ClusterRegistry, error) {
+	services, err := client.CoreV1().Services("").List(meta_v1.ListOptions{
+		LabelSelector: clusterRegistryLabel,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing registries")
+	}
+
+	for _, svc := range services.Items {
+		if svc.Spec.Type != v1.ServiceTypeNodePort {
+			continue
+		}
+		for _, port := range svc.Spec.Ports {
+			if port.NodePort != 0 {
+				return &ClusterRegistry{
+					Namespace: svc.Namespace,
+					Name:      svc.Name,
+					NodePort:  port.NodePort,
+					selector:  svc.Spec.Selector,
+				}, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func deployClusterRegistry(client kubernetes.Interface, namespace string) (*ClusterRegistry, error) {
+	selector := map[string]string{"app": clusterRegistryName}
+	replicas := int32(1)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:   clusterRegistryName,
+			Labels: map[string]string{clusterRegistryLabel: "true"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &meta_v1.LabelSelector{MatchLabels: selector},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: meta_v1.ObjectMeta{Labels: selector},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{{
+						Name:  "registry",
+						Image: clusterRegistryImage,
+						Ports: []v1.ContainerPort{{ContainerPort: registryPort}},
+						ReadinessProbe: &v1.Probe{
+							Handler: v1.Handler{
+								HTTPGet: &v1.HTTPGetAction{Path: "/v2/", Port: intstr.FromInt(registryPort)},
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+	if _, err := client.AppsV1().Deployments(namespace).Create(deployment); err != nil && !apierrs.IsAlreadyExists(err) {
+		return nil, errors.Wrap(err, "creating deployment")
+	}
+
+	service := &v1.Service{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:   clusterRegistryName,
+			Labels: map[string]string{clusterRegistryLabel: "true"},
+		},
+		Spec: v1.ServiceSpec{
+			Type:     v1.ServiceTypeNodePort,
+			Selector: selector,
+			Ports: []v1.ServicePort{{
+				Port:       registryPort,
+				TargetPort: intstr.FromInt(registryPort),
+				NodePort:   ClusterRegistryPort,
+			}},
+		},
+	}
+	if _, err := client.CoreV1().Services(namespace).Create(service); err != nil && !apierrs.IsAlreadyExists(err) {
+		return nil, errors.Wrap(err, "creating service")
+	}
+
+	return &ClusterRegistry{
+		Namespace: namespace,
+		Name:      clusterRegistryName,
+		NodePort:  ClusterRegistryPort,
+		selector:  selector,
+	}, nil
+}
+
+// waitForPod waits for a pod of the registry to be ready.
+func (r *ClusterRegistry) waitForPod(ctx context.Context, client kubernetes.Interface) error {
+	ctx, cancel := context.WithTimeout(ctx, clusterRegistryTimeout)
+	defer cancel()
+
+	for {
+		pods, err := client.CoreV1().Pods(r.Namespace).List(meta_v1.ListOptions{
+			LabelSelector: labels.SelectorFromSet(r.selector).String(),
+		})
+		if err != nil {
+			return errors.Wrap(err, "listing registry pods")
+		}
+		for _, pod := range pods.Items {
+			if pod.Status.Phase == v1.PodRunning && isPodReady(&pod) {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("registry %s/%s not ready within %v", r.Namespace, r.Name, clusterRegistryTimeout)
+		case <-time.After(clusterRegistryPollPeriod):
+		}
+	}
+}
+
+// forward forwards the node port of the registry to the same local port, and
+// waits for the registry to answer.
+func (r *ClusterRegistry) forward(parentCtx context.Context) error {
+	ctx, cancel := context.WithCancel(parentCtx)
+	r.cancel = cancel
+
+	cmd := kubectlPortForward(ctx, "svc/"+r.Name, fmt.Sprintf("%d:%d", r.NodePort, registryPort), "--namespace", r.Namespace)
+	if err := cmd.Start(); err != nil {
+		cancel()
+		return errors.Wrap(err, "port forwarding registry")
+	}
+
+	terminated := make(chan struct{})
+	r.terminated = terminated
+	go func() {
+		cmd.Wait()
+		close(terminated)
+	}()
+
+	timeout := time.After(clusterRegistryTimeout)
+	for {
+		resp, err := http.Get(fmt.Sprintf("http://%s/v2/", r.Repo()))
+		if err == nil {
+			resp.Body.Close()
+			return nil
+		}
+		logrus.Debugln("waiting for registry:", err)
+
+		select {
+		case <-terminated:
+			return errors.New("port forwarding registry: kubectl exited")
+		case <-timeout:
+			r.Stop()
+			return fmt.Errorf("registry not reachable at %s within %v", r.Repo(), clusterRegistryTimeout)
+		case <-time.After(clusterRegistryPollPeriod):
+		}
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func registryService(namespace string, serviceType v1.ServiceType, nodePort int32) *v1.Service {
+	return &v1.Service{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "registry",
+			Namespace: namespace,
+			Labels:    map[string]string{clusterRegistryLabel: "true"},
+		},
+		Spec: v1.ServiceSpec{
+			Type:     serviceType,
+			Selector: map[string]string{"app": "registry"},
+			Ports:    []v1.ServicePort{{Port: 5000, NodePort: nodePort}},
+		},
+	}
+}
+
+func TestFindClusterRegistry(t *testing.T) {
+	tests := []struct {
+		description string
+		objects     []runtime.Object
+		expected    *ClusterRegistry
+	}{
+		{
+			description: "no registry",
+		},
+		{
+			description: "registry with a node port",
+			objects:     []runtime.Object{registryService("tools", v1.ServiceTypeNodePort, 31000)},
+			expected: &ClusterRegistry{
+				Namespace: "tools",
+				Name:      "registry",
+				NodePort:  31000,
+				selector:  map[string]string{"app": "registry"},
+			},
+		},
+		{
+			description: "ignore registry without a node port",
+			objects:     []runtime.Object{registryService("tools", v1.ServiceTypeClusterIP, 0)},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			registry, err := findClusterRegistry(fake.NewSimpleClientset(test.objects...))
+
+			testutil.CheckErrorAndDeepEqual(t, false, err, test.expected, registry, cmp.AllowUnexported(ClusterRegistry{}))
+		})
+	}
+}
+
+func TestDeployClusterRegistry(t *testing.T) {
+	client := fake.NewSimpleClientset()
+
+	registry, err := deployClusterRegistry(client, "dev")
+	testutil.CheckError(t, false, err)
+	testutil.CheckDeepEqual(t, "localhost:30500", registry.Repo())
+
+	// Deploying again reuses the existing registry.
+	_, err = deployClusterRegistry(client, "dev")
+	testutil.CheckError(t, false, err)
+
+	found, err := findClusterRegistry(client)
+	testutil.CheckErrorAndDeepEqual(t, false, err, registry, found, cmp.AllowUnexported(ClusterRegistry{}))
+
+	deployment, err := client.AppsV1().Deployments("dev").Get(clusterRegistryName, meta_v1.GetOptions{})
+	testutil.CheckErrorAndDeepEqual(t, false, err, "registry:2", deployment.Spec.Template.Spec.Containers[0].Image)
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localcluster
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// k3dContextPrefix prefixes the name of the kubectl contexts created by k3d.
+const k3dContextPrefix = "k3d-"
+
+// k3dLoader imports images into all the nodes of a k3d cluster.
+type k3dLoader struct {
+	cluster string
+}
+
+func (l *k3dLoader) LoadImage(ctx context.Context, out io.Writer, image string) error {
+	return runLoadCommand(ctx, out, image, fmt.Sprintf("k3d cluster %s", l.cluster), "k3d", "image", "import", "--cluster", l.cluster, image)
+}
+
+func k3dClusterName(kubeContext string) (string, bool) {
+	if strings.HasPrefix(kubeContext, k3dContextPrefix) && len(kubeContext) > len(k3dContextPrefix) {
+		return strings.TrimPrefix(kubeContext, k3dContextPrefix), true
+	}
+	return "", false
+}
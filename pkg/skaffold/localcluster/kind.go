@@ -0,0 +1,52 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localcluster
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// kindContextPrefix prefixes the name of the kubectl contexts created by kind.
+	kindContextPrefix = "kind-"
+
+	// kindLegacyContext is the context created by kind before v0.6 for the default cluster.
+	kindLegacyContext = "kubernetes-admin@kind"
+)
+
+// kindLoader loads images into all the nodes of a kind cluster.
+// kind skips the nodes that already have the image.
+type kindLoader struct {
+	cluster string
+}
+
+func (l *kindLoader) LoadImage(ctx context.Context, out io.Writer, image string) error {
+	return runLoadCommand(ctx, out, image, fmt.Sprintf("kind cluster %s", l.cluster), "kind", "load", "docker-image", "--name", l.cluster, image)
+}
+
+func kindClusterName(kubeContext string) (string, bool) {
+	if kubeContext == kindLegacyContext {
+		return "kind", true
+	}
+	if strings.HasPrefix(kubeContext, kindContextPrefix) && len(kubeContext) > len(kindContextPrefix) {
+		return strings.TrimPrefix(kubeContext, kindContextPrefix), true
+	}
+	return "", false
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localcluster
+
+import (
+	"context"
+	"io"
+	"os/exec"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/pkg/errors"
+)
+
+// Loader copies images from the local docker daemon to a local cluster
+// whose nodes don't share that daemon, so that the images can be deployed
+// without being pushed to a registry.
+type Loader interface {
+	LoadImage(ctx context.Context, out io.Writer, image string) error
+}
+
+// NewLoader returns the Loader for the local cluster that a kubectl context
+// points to, or false if the context wasn't created by kind, k3d, microk8s or minikube.
+// Images are only loaded into minikube when they're not built by its docker daemon.
+func NewLoader(kubeContext string, minikubeDaemon bool) (Loader, bool) {
+	if cluster, ok := kindClusterName(kubeContext); ok {
+		return &kindLoader{cluster: cluster}, true
+	}
+	if cluster, ok := k3dClusterName(kubeContext); ok {
+		return &k3dLoader{cluster: cluster}, true
+	}
+	if kubeContext == microk8sContext {
+		return &microk8sLoader{}, true
+	}
+	if kubeContext == minikubeContext && !minikubeDaemon {
+		return &minikubeLoader{}, true
+	}
+	return nil, false
+}
+
+// ClusterType returns the tool that created the local cluster a kubectl context
+// points to: kind, k3d, microk8s or minikube. It returns an empty string for other contexts.
+func ClusterType(kubeContext string) string {
+	switch {
+	case kubeContext == microk8sContext:
+		return "microk8s"
+	case kubeContext == minikubeContext:
+		return "minikube"
+	}
+	if _, ok := kindClusterName(kubeContext); ok {
+		return "kind"
+	}
+	if _, ok := k3dClusterName(kubeContext); ok {
+		return "k3d"
+	}
+	return ""
+}
+
+// IsLoadable tells if a kubectl context points to a local cluster that images can be loaded into.
+func IsLoadable(kubeContext string) bool {
+	_, ok := NewLoader(kubeContext, false)
+	return ok
+}
+
+func runLoadCommand(ctx context.Context, out io.Writer, image, cluster string, args ...string) error {
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+
+	if err := util.RunCmd(cmd); err != nil {
+		return errors.Wrapf(err, "loading %s into %s", image, cluster)
+	}
+	return nil
+}
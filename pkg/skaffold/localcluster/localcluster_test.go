@@ -0,0 +1,131 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localcluster
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewLoader(t *testing.T) {
+	tests := []struct {
+		kubeContext    string
+		minikubeDaemon bool
+		expected       Loader
+	}{
+		{kubeContext: "kind-kind", expected: &kindLoader{cluster: "kind"}},
+		{kubeContext: "kind-multi-node", expected: &kindLoader{cluster: "multi-node"}},
+		{kubeContext: "kubernetes-admin@kind", expected: &kindLoader{cluster: "kind"}},
+		{kubeContext: "k3d-dev", expected: &k3dLoader{cluster: "dev"}},
+		{kubeContext: "microk8s", expected: &microk8sLoader{}},
+		{kubeContext: "minikube", expected: &minikubeLoader{}},
+		{kubeContext: "minikube", minikubeDaemon: true},
+		{kubeContext: "kind-"},
+		{kubeContext: "k3d-"},
+		{kubeContext: "gke_project_zone_kind"},
+	}
+	for _, test := range tests {
+		t.Run(test.kubeContext, func(t *testing.T) {
+			loader, ok := NewLoader(test.kubeContext, test.minikubeDaemon)
+
+			testutil.CheckDeepEqual(t, test.expected, loader, cmp.AllowUnexported(kindLoader{}, k3dLoader{}))
+			testutil.CheckDeepEqual(t, test.expected != nil, ok)
+		})
+	}
+}
+
+func TestIsLoadable(t *testing.T) {
+	testutil.CheckDeepEqual(t, true, IsLoadable("kind-kind"))
+	testutil.CheckDeepEqual(t, true, IsLoadable("minikube"))
+	testutil.CheckDeepEqual(t, false, IsLoadable("docker-desktop"))
+}
+
+func TestClusterType(t *testing.T) {
+	testutil.CheckDeepEqual(t, "kind", ClusterType("kind-multi-node"))
+	testutil.CheckDeepEqual(t, "kind", ClusterType("kubernetes-admin@kind"))
+	testutil.CheckDeepEqual(t, "k3d", ClusterType("k3d-dev"))
+	testutil.CheckDeepEqual(t, "microk8s", ClusterType("microk8s"))
+	testutil.CheckDeepEqual(t, "minikube", ClusterType("minikube"))
+	testutil.CheckDeepEqual(t, "", ClusterType("gke_project_zone_kind"))
+}
+
+func TestLoadImage(t *testing.T) {
+	tmpDir, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+	archive := filepath.Join(tmpDir.Root(), "image.tar")
+
+	tests := []struct {
+		description string
+		loader      Loader
+		command     util.Command
+		shouldErr   bool
+	}{
+		{
+			description: "kind",
+			loader:      &kindLoader{cluster: "dev"},
+			command:     testutil.FakeRun(t, "kind load docker-image --name dev app:abcdef"),
+		},
+		{
+			description: "kind failure",
+			loader:      &kindLoader{cluster: "dev"},
+			command:     testutil.FakeRunErr(t, "kind load docker-image --name dev app:abcdef", errors.New("cluster not found")),
+			shouldErr:   true,
+		},
+		{
+			description: "k3d",
+			loader:      &k3dLoader{cluster: "dev"},
+			command:     testutil.FakeRun(t, "k3d image import --cluster dev app:abcdef"),
+		},
+		{
+			description: "minikube",
+			loader:      &minikubeLoader{},
+			command:     testutil.FakeRun(t, "minikube image load app:abcdef"),
+		},
+		{
+			description: "microk8s",
+			loader:      &microk8sLoader{},
+			command: testutil.NewFakeCmd(t).
+				WithRun("docker save --output " + archive + " app:abcdef").
+				WithRun("microk8s ctr image import " + archive),
+		},
+		{
+			description: "microk8s save failure",
+			loader:      &microk8sLoader{},
+			command:     testutil.FakeRunErr(t, "docker save --output "+archive+" app:abcdef", errors.New("no such image")),
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			reset := testutil.Override(t, &util.DefaultExecCommand, test.command)
+			defer reset()
+			resetTempDir := testutil.Override(t, &tempDir, func(string, string) (string, error) { return tmpDir.Root(), nil })
+			defer resetTempDir()
+
+			err := test.loader.LoadImage(context.Background(), ioutil.Discard, "app:abcdef")
+
+			testutil.CheckError(t, test.shouldErr, err)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localcluster
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// microk8sContext is the kubectl context created by `microk8s config`.
+const microk8sContext = "microk8s"
+
+var (
+	// For testing
+	tempDir = ioutil.TempDir
+)
+
+// microk8sLoader imports images into the containerd of microk8s.
+// The image is saved to an archive first since microk8s has no access to the docker daemon.
+type microk8sLoader struct{}
+
+func (l *microk8sLoader) LoadImage(ctx context.Context, out io.Writer, image string) error {
+	dir, err := tempDir("", "skaffold-microk8s")
+	if err != nil {
+		return errors.Wrap(err, "creating temporary directory")
+	}
+	defer os.RemoveAll(dir)
+
+	archive := filepath.Join(dir, "image.tar")
+	if err := runLoadCommand(ctx, out, image, "microk8s", "docker", "save", "--output", archive, image); err != nil {
+		return err
+	}
+	return runLoadCommand(ctx, out, image, "microk8s", "microk8s", "ctr", "image", "import", archive)
+}
@@ -0,0 +1,33 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localcluster
+
+import (
+	"context"
+	"io"
+)
+
+// minikubeContext is the kubectl context created by minikube for the default profile.
+const minikubeContext = "minikube"
+
+// minikubeLoader loads images into minikube when they are not built by its
+// docker daemon, for example with the containerd runtime.
+type minikubeLoader struct{}
+
+func (l *minikubeLoader) LoadImage(ctx context.Context, out io.Writer, image string) error {
+	return runLoadCommand(ctx, out, image, "minikube", "minikube", "image", "load", image)
+}
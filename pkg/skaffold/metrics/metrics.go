@@ -0,0 +1,207 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"encoding/json"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/errcode"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/version"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	collector *recorder
+	lock      sync.Mutex
+)
+
+// Record holds the metrics of a command. It doesn't hold any image name,
+// path or other detail of the project.
+type Record struct {
+	Command         string            `json:"command"`
+	Version         string            `json:"version"`
+	OS              string            `json:"os"`
+	Arch            string            `json:"arch"`
+	StartTime       time.Time         `json:"startTime"`
+	DurationSeconds float64           `json:"durationSeconds"`
+	Status          string            `json:"status"`
+	ErrorCode       errcode.Code      `json:"errorCode,omitempty"`
+	BuildEnv        string            `json:"buildEnv,omitempty"`
+	Builders        []string          `json:"builders,omitempty"`
+	Deployers       []string          `json:"deployers,omitempty"`
+	Phases          map[string]*Phase `json:"phases,omitempty"`
+}
+
+// Phase aggregates the runs of a phase, like build or deploy, during a command.
+// In dev mode, phases run once per iteration of the dev loop.
+type Phase struct {
+	Count        int     `json:"count"`
+	Failures     int     `json:"failures,omitempty"`
+	TotalSeconds float64 `json:"totalSeconds"`
+	MaxSeconds   float64 `json:"maxSeconds"`
+}
+
+type recorder struct {
+	export string
+	record Record
+	lock   sync.Mutex
+}
+
+// Initialize starts collecting the metrics of a command. They are exported,
+// when the command ends, to `export`: either an http(s) endpoint or a local file.
+func Initialize(command, export string) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	collector = &recorder{
+		export: export,
+		record: Record{
+			Command:   command,
+			Version:   version.Get().Version,
+			OS:        runtime.GOOS,
+			Arch:      runtime.GOARCH,
+			StartTime: time.Now(),
+			Phases:    map[string]*Phase{},
+		},
+	}
+}
+
+// SetConfig records the types of builders and deployers used by the command.
+func SetConfig(cfg *latest.SkaffoldConfig) {
+	r := current()
+	if r == nil {
+		return
+	}
+
+	builders := map[string]bool{}
+	for _, a := range cfg.Build.Artifacts {
+		for _, builder := range setFields(a.ArtifactType) {
+			builders[builder] = true
+		}
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.record.BuildEnv = strings.Join(setFields(cfg.Build.BuildType), ",")
+	r.record.Builders = sortedKeys(builders)
+	r.record.Deployers = setFields(cfg.Deploy.DeployType)
+}
+
+// StartPhase starts timing a phase. It returns a function that ends it,
+// counting it as failed if err isn't nil. It's a no-op if metrics aren't collected.
+func StartPhase(name string) func(err error) {
+	r := current()
+	if r == nil {
+		return func(error) {}
+	}
+
+	start := time.Now()
+	return func(err error) {
+		elapsed := time.Since(start).Seconds()
+
+		r.lock.Lock()
+		defer r.lock.Unlock()
+
+		phase, found := r.record.Phases[name]
+		if !found {
+			phase = &Phase{}
+			r.record.Phases[name] = phase
+		}
+		phase.Count++
+		if err != nil {
+			phase.Failures++
+		}
+		phase.TotalSeconds += elapsed
+		if elapsed > phase.MaxSeconds {
+			phase.MaxSeconds = elapsed
+		}
+	}
+}
+
+// Export records how the command exited, and exports its metrics.
+// Metrics are best effort: failing to export them doesn't fail the command.
+func Export(status string, code errcode.Code) {
+	lock.Lock()
+	r := collector
+	collector = nil
+	lock.Unlock()
+
+	if r == nil {
+		return
+	}
+
+	r.lock.Lock()
+	r.record.DurationSeconds = time.Since(r.record.StartTime).Seconds()
+	r.record.Status = status
+	r.record.ErrorCode = code
+	if len(r.record.Phases) == 0 {
+		r.record.Phases = nil
+	}
+	body, err := json.Marshal(r.record)
+	r.lock.Unlock()
+	if err != nil {
+		logrus.Debugf("marshalling metrics: %s", err)
+		return
+	}
+
+	if err := export(r.export, body); err != nil {
+		logrus.Debugf("exporting metrics: %s", err)
+	}
+}
+
+func current() *recorder {
+	lock.Lock()
+	defer lock.Unlock()
+
+	return collector
+}
+
+// setFields returns the yaml names of the fields that are set on a `oneOf` struct,
+// like `latest.ArtifactType`.
+func setFields(oneOf interface{}) []string {
+	var names []string
+
+	v := reflect.ValueOf(oneOf)
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() != reflect.Ptr || field.IsNil() {
+			continue
+		}
+
+		name := strings.Split(v.Type().Field(i).Tag.Get("yaml"), ",")[0]
+		names = append(names, name)
+	}
+
+	return names
+}
+
+func sortedKeys(m map[string]bool) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
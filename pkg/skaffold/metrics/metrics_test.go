@@ -0,0 +1,113 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/errcode"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+var testConfig = &latest.SkaffoldConfig{
+	Pipeline: latest.Pipeline{
+		Build: latest.BuildConfig{
+			Artifacts: []*latest.Artifact{
+				{ImageName: "web", ArtifactType: latest.ArtifactType{DockerArtifact: &latest.DockerArtifact{}}},
+				{ImageName: "api", ArtifactType: latest.ArtifactType{JibMavenArtifact: &latest.JibMavenArtifact{}}},
+				{ImageName: "worker", ArtifactType: latest.ArtifactType{DockerArtifact: &latest.DockerArtifact{}}},
+			},
+			BuildType: latest.BuildType{LocalBuild: &latest.LocalBuild{}},
+		},
+		Deploy: latest.DeployConfig{
+			DeployType: latest.DeployType{HelmDeploy: &latest.HelmDeploy{}},
+		},
+	},
+}
+
+func TestNotInitialized(t *testing.T) {
+	SetConfig(testConfig)
+	StartPhase("build")(nil)
+	Export("succeeded", "")
+
+	testutil.CheckDeepEqual(t, (*recorder)(nil), current())
+}
+
+func TestExportToEndpoint(t *testing.T) {
+	var received Record
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer server.Close()
+
+	Initialize("dev", server.URL)
+	SetConfig(testConfig)
+	StartPhase("build")(nil)
+	StartPhase("build")(errors.New("BUG"))
+	StartPhase("deploy")(nil)
+	Export("failed", errcode.BuildFailed)
+
+	testutil.CheckDeepEqual(t, "dev", received.Command)
+	testutil.CheckDeepEqual(t, "failed", received.Status)
+	testutil.CheckDeepEqual(t, errcode.BuildFailed, received.ErrorCode)
+	testutil.CheckDeepEqual(t, "local", received.BuildEnv)
+	testutil.CheckDeepEqual(t, []string{"docker", "jibMaven"}, received.Builders)
+	testutil.CheckDeepEqual(t, []string{"helm"}, received.Deployers)
+	testutil.CheckDeepEqual(t, 2, len(received.Phases))
+	testutil.CheckDeepEqual(t, 2, received.Phases["build"].Count)
+	testutil.CheckDeepEqual(t, 1, received.Phases["build"].Failures)
+	testutil.CheckDeepEqual(t, 1, received.Phases["deploy"].Count)
+	testutil.CheckDeepEqual(t, 0, received.Phases["deploy"].Failures)
+}
+
+func TestExportToFile(t *testing.T) {
+	tmpDir, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+
+	reset := testutil.Override(t, &defaultFile, func() (string, error) { return tmpDir.Path("metrics.jsonl"), nil })
+	defer reset()
+
+	Initialize("run", "")
+	Export("succeeded", "")
+	Initialize("build", tmpDir.Path("other/metrics.jsonl"))
+	Export("succeeded", "")
+	Initialize("deploy", "")
+	Export("succeeded", "")
+
+	commands := func(file string) []string {
+		content, err := ioutil.ReadFile(file)
+		testutil.CheckError(t, false, err)
+
+		var commands []string
+		for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+			var record Record
+			testutil.CheckError(t, false, json.Unmarshal([]byte(line), &record))
+			commands = append(commands, record.Command)
+		}
+		return commands
+	}
+
+	testutil.CheckDeepEqual(t, []string{"run", "deploy"}, commands(tmpDir.Path("metrics.jsonl")))
+	testutil.CheckDeepEqual(t, []string{"build"}, commands(tmpDir.Path("other/metrics.jsonl")))
+}
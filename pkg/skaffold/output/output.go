@@ -0,0 +1,86 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"sync"
+)
+
+// Level is how much Skaffold prints to the console.
+type Level int
+
+const (
+	// Default prints a headline for each phase. The output of the builders, testers
+	// and deployers is only printed when they fail.
+	Default Level = iota
+	// Quiet only prints the final summary, and the output of the steps that fail.
+	Quiet
+	// Verbose prints everything, including the full output of the builders, testers and deployers.
+	Verbose
+)
+
+// Current is the level used by the running command.
+var Current = Default
+
+// Headlines returns the writer to print the headlines of a phase to.
+// They are discarded in quiet mode.
+func Headlines(out io.Writer) io.Writer {
+	if Current == Quiet {
+		return ioutil.Discard
+	}
+	return out
+}
+
+// Step returns the writer to give to a step, like the build of an artifact or a deployment,
+// and the function to call with the step's error when it's done.
+// Unless in verbose mode, the step's output is held back and is only printed if it fails.
+func Step(out io.Writer) (io.Writer, func(error)) {
+	if Current == Verbose {
+		return out, func(error) {}
+	}
+
+	w := &heldBackWriter{}
+	return w, func(err error) {
+		if err != nil {
+			w.writeTo(out)
+		}
+	}
+}
+
+// heldBackWriter keeps the output of a step in memory. It's safe
+// for concurrent use, since steps often write from several goroutines.
+type heldBackWriter struct {
+	lock sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (w *heldBackWriter) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.buf.Write(p)
+}
+
+func (w *heldBackWriter) writeTo(out io.Writer) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.buf.WriteTo(out)
+}
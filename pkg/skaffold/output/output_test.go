@@ -0,0 +1,104 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestHeadlines(t *testing.T) {
+	var tests = []struct {
+		description string
+		level       Level
+		expected    string
+	}{
+		{
+			description: "default",
+			level:       Default,
+			expected:    "Starting build...\n",
+		},
+		{
+			description: "quiet",
+			level:       Quiet,
+			expected:    "",
+		},
+		{
+			description: "verbose",
+			level:       Verbose,
+			expected:    "Starting build...\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			defer testutil.Override(t, &Current, test.level)()
+
+			var out bytes.Buffer
+			fmt.Fprintln(Headlines(&out), "Starting build...")
+
+			testutil.CheckDeepEqual(t, test.expected, out.String())
+		})
+	}
+}
+
+func TestStep(t *testing.T) {
+	var tests = []struct {
+		description string
+		level       Level
+		err         error
+		expected    string
+	}{
+		{
+			description: "held back on success",
+			level:       Default,
+			expected:    "",
+		},
+		{
+			description: "printed on failure",
+			level:       Default,
+			err:         errors.New("BUG"),
+			expected:    "Step 1/2 : FROM busybox\n",
+		},
+		{
+			description: "printed on failure, in quiet mode",
+			level:       Quiet,
+			err:         errors.New("BUG"),
+			expected:    "Step 1/2 : FROM busybox\n",
+		},
+		{
+			description: "always printed in verbose mode",
+			level:       Verbose,
+			expected:    "Step 1/2 : FROM busybox\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			defer testutil.Override(t, &Current, test.level)()
+
+			var out bytes.Buffer
+			stepOut, finish := Step(&out)
+			fmt.Fprintln(stepOut, "Step 1/2 : FROM busybox")
+			finish(test.err)
+
+			testutil.CheckDeepEqual(t, test.expected, out.String())
+		})
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redact
+
+import (
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Mask replaces the redacted values.
+const Mask = "********"
+
+var (
+	patterns []*regexp.Regexp
+	values   []string
+	lock     sync.RWMutex
+)
+
+// AddPatterns redacts every match of the given regular expressions.
+func AddPatterns(exprs []string) error {
+	var compiled []*regexp.Regexp
+	for _, expr := range exprs {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return errors.Wrapf(err, "invalid redaction pattern %q", expr)
+		}
+		compiled = append(compiled, re)
+	}
+
+	lock.Lock()
+	patterns = append(patterns, compiled...)
+	lock.Unlock()
+	return nil
+}
+
+// AddValue redacts every occurrence of a value, like a secret read from a secret manager.
+func AddValue(value string) {
+	if value == "" {
+		return
+	}
+
+	lock.Lock()
+	values = append(values, value)
+	lock.Unlock()
+}
+
+// Reset forgets every pattern and value.
+func Reset() {
+	lock.Lock()
+	patterns = nil
+	values = nil
+	lock.Unlock()
+}
+
+// String masks the redacted values and patterns in a string.
+func String(s string) string {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	for _, value := range values {
+		s = strings.Replace(s, value, Mask, -1)
+	}
+	for _, pattern := range patterns {
+		s = pattern.ReplaceAllString(s, Mask)
+	}
+	return s
+}
+
+// NewWriter returns a writer that masks the redacted values and patterns.
+// Each write is masked on its own, which is what line or message oriented outputs,
+// like build logs or container logs, need.
+func NewWriter(out io.Writer) io.Writer {
+	w := &writer{out: out}
+	if f, ok := out.(interface{ Fd() uintptr }); ok {
+		// Keep the terminal detection working.
+		return &fileWriter{writer: w, fd: f.Fd()}
+	}
+	return w
+}
+
+type writer struct {
+	out io.Writer
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if !enabled() {
+		return w.out.Write(p)
+	}
+
+	if _, err := io.WriteString(w.out, String(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+type fileWriter struct {
+	*writer
+	fd uintptr
+}
+
+func (w *fileWriter) Fd() uintptr {
+	return w.fd
+}
+
+func enabled() bool {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	return len(patterns) > 0 || len(values) > 0
+}
@@ -0,0 +1,287 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remotedev
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/deploy/kubectl"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/sync"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/watch"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// syncedMarker is created once the sources are synced. The app is only started after that.
+	syncedMarker = "/tmp/.skaffold-synced"
+
+	// pidFile holds the process group of the running app.
+	pidFile = "/tmp/.skaffold-pid"
+)
+
+var (
+	// For testing
+	perform     = sync.Perform
+	syncTimeout = 2 * time.Minute
+	syncRetry   = 2 * time.Second
+
+	// current is used by the manifest transform.
+	current *Developer
+)
+
+// Developer develops artifacts in the cluster: their sources are synced into
+// long-running pods that rebuild and restart the apps.
+type Developer struct {
+	// artifacts are indexed by the image of their pod.
+	artifacts  map[string]*latest.Artifact
+	syncer     sync.Syncer
+	namespaces []string
+}
+
+// New creates a Developer for the artifacts that are configured for remote development.
+func New(artifacts []*latest.Artifact, syncer sync.Syncer, namespaces []string) (*Developer, error) {
+	byImage := map[string]*latest.Artifact{}
+	for _, a := range artifacts {
+		if a.RemoteDev == nil {
+			continue
+		}
+
+		if other, found := byImage[a.RemoteDev.Image]; found {
+			return nil, fmt.Errorf("artifacts %s and %s can't be developed remotely with the same image %s", other.ImageName, a.ImageName, a.RemoteDev.Image)
+		}
+		byImage[a.RemoteDev.Image] = a
+	}
+
+	d := &Developer{
+		artifacts:  byImage,
+		syncer:     syncer,
+		namespaces: namespaces,
+	}
+	current = d
+	return d, nil
+}
+
+// Split returns the builds of the artifacts that are developed remotely, which are
+// not built and use the image of their pod, and the artifacts that still need to be built.
+func (d *Developer) Split(artifacts []*latest.Artifact) ([]build.Artifact, []*latest.Artifact) {
+	var (
+		builds  []build.Artifact
+		toBuild []*latest.Artifact
+	)
+
+	for _, a := range artifacts {
+		if d.isRemote(a) {
+			builds = append(builds, build.Artifact{
+				ImageName: a.ImageName,
+				Tag:       a.RemoteDev.Image,
+			})
+		} else {
+			toBuild = append(toBuild, a)
+		}
+	}
+
+	return builds, toBuild
+}
+
+func (d *Developer) isRemote(a *latest.Artifact) bool {
+	return a.RemoteDev != nil && d.artifacts[a.RemoteDev.Image] == a
+}
+
+// NewItem returns the files to sync for changes to an artifact that's developed remotely.
+// Unlike with sync rules, all the changed files are synced.
+func (d *Developer) NewItem(a *latest.Artifact, e watch.Events) (*sync.Item, error) {
+	if !d.isRemote(a) || !e.HasChanged() {
+		return nil, nil
+	}
+
+	toCopy, err := destinations(a, append(e.Added, e.Modified...))
+	if err != nil {
+		return nil, err
+	}
+	toDelete, err := destinations(a, e.Deleted)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sync.Item{
+		Image:  a.RemoteDev.Image,
+		Copy:   toCopy,
+		Delete: toDelete,
+	}, nil
+}
+
+// SyncAll copies the sources of every artifact developed remotely into their pods,
+// which can then start the apps. Pods that are not running yet are waited for.
+func (d *Developer) SyncAll(ctx context.Context, out io.Writer, dependencies func(*latest.Artifact) ([]string, error)) error {
+	for _, image := range d.images() {
+		a := d.artifacts[image]
+
+		deps, err := dependencies(a)
+		if err != nil {
+			return errors.Wrapf(err, "listing files for %s", a.ImageName)
+		}
+		files, err := destinations(a, deps)
+		if err != nil {
+			return err
+		}
+
+		color.Default.Fprintf(out, "Syncing %d files to the pods of %s\n", len(files), a.ImageName)
+		item := &sync.Item{Image: image, Copy: files}
+		if err := d.retry(ctx, func() error { return d.syncer.Sync(ctx, item) }); err != nil {
+			return errors.Wrapf(err, "syncing the sources of %s", a.ImageName)
+		}
+
+		if err := d.exec(ctx, image, "touch "+syncedMarker); err != nil {
+			return errors.Wrapf(err, "starting %s", a.ImageName)
+		}
+	}
+
+	return nil
+}
+
+// Restart restarts the app in the pods that use an image, if it's developed remotely.
+func (d *Developer) Restart(ctx context.Context, image string) error {
+	if _, found := d.artifacts[image]; !found {
+		return nil
+	}
+
+	// Killing the process group stops the processes started by the command too.
+	return d.exec(ctx, image, fmt.Sprintf("kill -- -$(cat %s)", pidFile))
+}
+
+func (d *Developer) images() []string {
+	var images []string
+	for image := range d.artifacts {
+		images = append(images, image)
+	}
+	sort.Strings(images)
+	return images
+}
+
+func (d *Developer) retry(ctx context.Context, fn func() error) error {
+	deadline := time.Now().Add(syncTimeout)
+
+	for {
+		err := fn()
+		if err == nil || time.Now().After(deadline) {
+			return err
+		}
+		logrus.Debugln("Waiting for pods:", err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(syncRetry):
+		}
+	}
+}
+
+// exec runs a shell command in the containers that use an image.
+func (d *Developer) exec(ctx context.Context, image, command string) error {
+	// sync.Perform only runs commands when there are files.
+	files := map[string][]string{command: nil}
+
+	return perform(ctx, image, files, func(ctx context.Context, pod v1.Pod, container v1.Container, _ map[string][]string) []*exec.Cmd {
+		return []*exec.Cmd{kubernetes.KubectlCommand(ctx, "exec", pod.Name, "--namespace", pod.Namespace, "-c", container.Name, "--", "sh", "-c", command)}
+	}, d.namespaces)
+}
+
+// destinations maps local files to their destination in the container.
+func destinations(a *latest.Artifact, files []string) (map[string][]string, error) {
+	dsts := map[string][]string{}
+
+	for _, f := range files {
+		relPath, err := filepath.Rel(a.Workspace, f)
+		if err != nil {
+			return nil, errors.Wrapf(err, "file %s can't be found relative to context %s", f, a.Workspace)
+		}
+
+		dsts[f] = []string{path.Join(a.RemoteDev.Dest, filepath.ToSlash(relPath))}
+	}
+
+	return dsts, nil
+}
+
+// ReplaceCommands is a manifest transform that replaces the command of the containers
+// that use the image of an artifact developed remotely. The new command waits for the
+// sources, then runs the artifact's command and runs it again each time it's killed.
+func ReplaceCommands(l kubectl.ManifestList, _ []build.Artifact, _ map[string]bool) (kubectl.ManifestList, error) {
+	if current == nil || len(current.artifacts) == 0 {
+		return l, nil
+	}
+
+	return l.Visit(&commandReplacer{artifacts: current.artifacts})
+}
+
+type commandReplacer struct {
+	artifacts map[string]*latest.Artifact
+}
+
+func (r *commandReplacer) Matches(key string) bool {
+	return key == "containers"
+}
+
+func (r *commandReplacer) NewValue(old interface{}) (bool, interface{}) {
+	containers, ok := old.([]interface{})
+	if !ok {
+		return false, nil
+	}
+
+	changed := false
+	for _, c := range containers {
+		container, ok := c.(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+
+		image, _ := container["image"].(string)
+		a, found := r.artifacts[image]
+		if !found {
+			continue
+		}
+
+		container["command"] = []interface{}{"sh", "-c", supervisor(a.RemoteDev)}
+		delete(container, "args")
+		changed = true
+	}
+
+	return changed, containers
+}
+
+// supervisor is the script that runs the command of an artifact developed remotely.
+func supervisor(remote *latest.RemoteDev) string {
+	return fmt.Sprintf("mkdir -p %[1]s; while [ ! -f %[2]s ]; do sleep 1; done; cd %[1]s; set -m; while true; do sh -c %[3]s & echo $! > %[4]s; wait $!; sleep 1; done",
+		quote(remote.Dest), syncedMarker, quote(remote.Command), pidFile)
+}
+
+func quote(s string) string {
+	return "'" + strings.Replace(s, "'", `'"'"'`, -1) + "'"
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remotedev
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/deploy/kubectl"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/sync"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/watch"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	v1 "k8s.io/api/core/v1"
+)
+
+type fakeSyncer struct {
+	items []*sync.Item
+	errs  []error
+}
+
+func (s *fakeSyncer) Sync(_ context.Context, item *sync.Item) error {
+	s.items = append(s.items, item)
+	if len(s.errs) > 0 {
+		err := s.errs[0]
+		s.errs = s.errs[1:]
+		return err
+	}
+	return nil
+}
+
+func remoteArtifact(imageName, image string) *latest.Artifact {
+	return &latest.Artifact{
+		ImageName: imageName,
+		Workspace: "app",
+		RemoteDev: &latest.RemoteDev{
+			Image:   image,
+			Command: "go run .",
+			Dest:    "/workspace",
+		},
+	}
+}
+
+func TestNew(t *testing.T) {
+	_, err := New([]*latest.Artifact{
+		remoteArtifact("image1", "golang:1.12"),
+		{ImageName: "image2"},
+		remoteArtifact("image3", "node:10"),
+	}, nil, nil)
+	testutil.CheckError(t, false, err)
+
+	_, err = New([]*latest.Artifact{
+		remoteArtifact("image1", "golang:1.12"),
+		remoteArtifact("image2", "golang:1.12"),
+	}, nil, nil)
+	testutil.CheckError(t, true, err)
+}
+
+func TestSplit(t *testing.T) {
+	remote := remoteArtifact("image1", "golang:1.12")
+	local := &latest.Artifact{ImageName: "image2"}
+
+	d, err := New([]*latest.Artifact{remote, local}, nil, nil)
+	testutil.CheckError(t, false, err)
+
+	builds, toBuild := d.Split([]*latest.Artifact{remote, local})
+
+	testutil.CheckDeepEqual(t, []build.Artifact{{ImageName: "image1", Tag: "golang:1.12"}}, builds)
+	testutil.CheckDeepEqual(t, []*latest.Artifact{local}, toBuild)
+}
+
+func TestNewItem(t *testing.T) {
+	remote := remoteArtifact("image1", "golang:1.12")
+	local := &latest.Artifact{ImageName: "image2", Workspace: "app"}
+
+	d, err := New([]*latest.Artifact{remote, local}, nil, nil)
+	testutil.CheckError(t, false, err)
+
+	events := watch.Events{
+		Added:    []string{"app/main.go"},
+		Modified: []string{"app/pkg/util.go"},
+		Deleted:  []string{"app/old.go"},
+	}
+
+	item, err := d.NewItem(remote, events)
+	testutil.CheckErrorAndDeepEqual(t, false, err, &sync.Item{
+		Image: "golang:1.12",
+		Copy: map[string][]string{
+			"app/main.go":     {"/workspace/main.go"},
+			"app/pkg/util.go": {"/workspace/pkg/util.go"},
+		},
+		Delete: map[string][]string{
+			"app/old.go": {"/workspace/old.go"},
+		},
+	}, item)
+
+	item, err = d.NewItem(local, events)
+	testutil.CheckErrorAndDeepEqual(t, false, err, (*sync.Item)(nil), item)
+}
+
+func TestReplaceCommands(t *testing.T) {
+	manifests := kubectl.ManifestList{[]byte(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - args:
+        - --port=8080
+        command:
+        - /app
+        image: golang:1.12
+        name: app
+      - image: redis
+        name: cache
+`)}
+
+	expected := kubectl.ManifestList{[]byte(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - command:
+        - sh
+        - -c
+        - mkdir -p '/workspace'; while [ ! -f /tmp/.skaffold-synced ]; do sleep 1;
+          done; cd '/workspace'; set -m; while true; do sh -c 'go run .' & echo $!
+          > /tmp/.skaffold-pid; wait $!; sleep 1; done
+        image: golang:1.12
+        name: app
+      - image: redis
+        name: cache
+`)}
+
+	_, err := New([]*latest.Artifact{remoteArtifact("image1", "golang:1.12")}, nil, nil)
+	testutil.CheckError(t, false, err)
+	defer func() { current = nil }()
+
+	updated, err := ReplaceCommands(manifests, nil, nil)
+
+	testutil.CheckErrorAndDeepEqual(t, false, err, expected.String(), updated.String())
+}
+
+func TestSyncAll(t *testing.T) {
+	reset := testutil.Override(t, &syncRetry, time.Duration(0))
+	defer reset()
+
+	var commands []string
+	resetPerform := testutil.Override(t, &perform, func(ctx context.Context, image string, files map[string][]string, cmdFn func(context.Context, v1.Pod, v1.Container, map[string][]string) []*exec.Cmd, namespaces []string) error {
+		pod := v1.Pod{}
+		pod.Name, pod.Namespace = "app-1234", "default"
+		for _, cmd := range cmdFn(ctx, pod, v1.Container{Name: "app"}, files) {
+			commands = append(commands, strings.Join(cmd.Args, " "))
+		}
+		return nil
+	})
+	defer resetPerform()
+
+	syncer := &fakeSyncer{errs: []error{errors.New("pod is pending")}}
+	d, err := New([]*latest.Artifact{remoteArtifact("image1", "golang:1.12")}, syncer, []string{"default"})
+	testutil.CheckError(t, false, err)
+
+	var out bytes.Buffer
+	err = d.SyncAll(context.Background(), &out, func(*latest.Artifact) ([]string, error) {
+		return []string{"app/main.go"}, nil
+	})
+
+	testutil.CheckError(t, false, err)
+	testutil.CheckDeepEqual(t, 2, len(syncer.items))
+	testutil.CheckDeepEqual(t, map[string][]string{"app/main.go": {"/workspace/main.go"}}, syncer.items[1].Copy)
+	testutil.CheckDeepEqual(t, []string{"kubectl exec app-1234 --namespace default -c app -- sh -c touch /tmp/.skaffold-synced"}, commands)
+	testutil.CheckDeepEqual(t, "Syncing 1 files to the pods of image1\n", out.String())
+
+	commands = nil
+	err = d.Restart(context.Background(), "golang:1.12")
+	testutil.CheckError(t, false, err)
+	err = d.Restart(context.Background(), "redis")
+	testutil.CheckError(t, false, err)
+	testutil.CheckDeepEqual(t, []string{"kubectl exec app-1234 --namespace default -c app -- sh -c kill -- -$(cat /tmp/.skaffold-pid)"}, commands)
+}
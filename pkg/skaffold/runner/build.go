@@ -21,37 +21,76 @@ import (
 	"io"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/errcode"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/metrics"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/scan"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/trace"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
 // BuildAndTest builds artifacts and runs tests on built artifacts
 func (r *SkaffoldRunner) BuildAndTest(ctx context.Context, out io.Writer, artifacts []*latest.Artifact) ([]build.Artifact, error) {
-	tags, err := r.imageTags(ctx, out, artifacts)
+	// Artifacts developed remotely are not built.
+	var remoteBuilds []build.Artifact
+	if r.remoteDev != nil {
+		remoteBuilds, artifacts = r.remoteDev.Split(artifacts)
+	}
+
+	// Artifacts that are not selected with --build-image are not built either.
+	unselectedBuilds, artifacts, err := r.resolveUnselected(ctx, out, artifacts)
+	if err != nil {
+		return nil, err
+	}
+
+	tagCtx, endTrace := trace.StartTrace(ctx, "tag", nil)
+	tags, err := r.ImageTags(tagCtx, out, artifacts)
+	endTrace(err)
 	if err != nil {
 		return nil, errors.Wrap(err, "generating tag")
 	}
 	r.hasBuilt = true
 
-	artifactsToBuild, res, err := r.cache.RetrieveCachedArtifacts(ctx, out, artifacts)
+	cacheCtx, endTrace := trace.StartTrace(ctx, "cache check", nil)
+	artifactsToBuild, res, err := r.cache.RetrieveCachedArtifacts(cacheCtx, out, artifacts)
+	endTrace(err)
 	if err != nil {
 		return nil, errors.Wrap(err, "retrieving cached artifacts")
 	}
 
-	bRes, err := r.Build(ctx, out, tags, artifactsToBuild)
+	buildCtx, endTrace := trace.StartTrace(ctx, "build", nil)
+	endPhase := metrics.StartPhase("build")
+	bRes, err := r.Build(buildCtx, out, tags, artifactsToBuild)
+	endPhase(err)
+	endTrace(err)
 	if err != nil {
-		return nil, errors.Wrap(err, "build failed")
+		return nil, failed(errcode.ForBuild(err), errors.Wrap(err, "build failed"))
 	}
 	r.cache.RetagLocalImages(ctx, out, artifactsToBuild, bRes)
+
+	// Images are only cached once scanned, so that images found in the cache don't need to be scanned again.
+	scanCtx, endTrace := trace.StartTrace(ctx, "scan", nil)
+	err = scan.Scan(scanCtx, out, r.runCtx.WorkingDir, artifacts, bRes)
+	endTrace(err)
+	if err != nil {
+		return nil, errors.Wrap(err, "scan failed")
+	}
+
 	bRes = append(bRes, res...)
+	bRes = append(bRes, remoteBuilds...)
 	if err := r.cache.CacheArtifacts(ctx, artifacts, bRes); err != nil {
 		logrus.Warnf("error caching artifacts: %v", err)
 	}
 	if !r.runCtx.Opts.SkipTests {
-		if err = r.Test(ctx, out, bRes); err != nil {
-			return nil, errors.Wrap(err, "test failed")
+		testCtx, endTrace := trace.StartTrace(ctx, "test", nil)
+		endPhase := metrics.StartPhase("test")
+		err = r.Test(testCtx, out, bRes)
+		endPhase(err)
+		endTrace(err)
+		if err != nil {
+			return nil, failed(errcode.TestFailed, errors.Wrap(err, "test failed"))
 		}
 	}
-	return bRes, err
+	return append(bRes, unselectedBuilds...), err
 }
@@ -26,6 +26,7 @@ type changes struct {
 	dirtyArtifacts []*artifactChange
 	needsRebuild   []*latest.Artifact
 	needsResync    []*sync.Item
+	needsRetest    bool
 	needsRedeploy  bool
 	needsReload    bool
 }
@@ -52,6 +53,7 @@ func (c *changes) reset() {
 	c.needsRebuild = nil
 	c.needsResync = nil
 
+	c.needsRetest = false
 	c.needsRedeploy = false
 	c.needsReload = false
 }
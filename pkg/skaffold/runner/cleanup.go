@@ -0,0 +1,64 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/pkg/errors"
+)
+
+// RunCleanupHooks runs the commands listed under `deploy.cleanup.hooks`.
+func (r *SkaffoldRunner) RunCleanupHooks(ctx context.Context, out io.Writer) error {
+	cfg := r.runCtx.Cfg.Deploy.Cleanup
+	if cfg == nil || len(cfg.Hooks) == 0 {
+		return nil
+	}
+
+	var images []string
+	for _, b := range r.builds {
+		images = append(images, b.Tag)
+	}
+	env := append(util.OSEnviron(), fmt.Sprintf("%s=%s", constants.Images, strings.Join(images, " ")))
+
+	for _, hook := range cfg.Hooks {
+		color.Default.Fprintln(out, "Running cleanup hook:", hook)
+
+		split := strings.Fields(hook)
+		if len(split) == 0 {
+			continue
+		}
+
+		cmd := exec.CommandContext(ctx, split[0], split[1:]...)
+		cmd.Dir = r.runCtx.WorkingDir
+		cmd.Env = env
+		cmd.Stdout = out
+		cmd.Stderr = out
+		if err := util.RunCmd(cmd); err != nil {
+			return errors.Wrapf(err, "running cleanup hook %q", hook)
+		}
+	}
+
+	return nil
+}
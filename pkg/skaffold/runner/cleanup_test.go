@@ -0,0 +1,75 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestRunCleanupHooks(t *testing.T) {
+	restore := testutil.SetupFakeKubernetesContext(t, api.Config{CurrentContext: "cluster1"})
+	defer restore()
+
+	var tests = []struct {
+		description string
+		cleanup     *latest.CleanupConfig
+		command     util.Command
+		shouldErr   bool
+	}{
+		{
+			description: "no cleanup config",
+			command:     testutil.NewFakeCmd(t),
+		},
+		{
+			description: "run hooks in order",
+			cleanup:     &latest.CleanupConfig{Hooks: []string{"./drop-db.sh --all", "make clean"}},
+			command: testutil.NewFakeCmd(t).
+				WithRun("./drop-db.sh --all").
+				WithRun("make clean"),
+		},
+		{
+			description: "stop at first failure",
+			cleanup:     &latest.CleanupConfig{Hooks: []string{"./drop-db.sh", "make clean"}},
+			command:     testutil.NewFakeCmd(t).WithRunErr("./drop-db.sh", errors.New("BUG")),
+			shouldErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			reset := testutil.Override(t, &util.DefaultExecCommand, test.command)
+			defer reset()
+
+			runner := createRunner(t, &TestBench{})
+			runner.runCtx.Cfg.Deploy.Cleanup = test.cleanup
+			runner.builds = []build.Artifact{{ImageName: "img", Tag: "img:v1"}}
+
+			err := runner.RunCleanupHooks(context.Background(), ioutil.Discard)
+
+			testutil.CheckError(t, test.shouldErr, err)
+		})
+	}
+}
@@ -17,26 +17,42 @@ limitations under the License.
 package context
 
 import (
+	"fmt"
 	"os"
+	"strings"
 
 	configutil "github.com/GoogleContainerTools/skaffold/cmd/skaffold/app/cmd/config"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/config"
 	kubectx "github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes/context"
 	runnerutil "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/util"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 type RunContext struct {
 	Opts *config.SkaffoldOptions
 	Cfg  *latest.Pipeline
 
-	DefaultRepo        string
-	KubeContext        string
-	WorkingDir         string
-	Namespaces         []string
-	InsecureRegistries map[string]bool
+	DefaultRepo          string
+	DefaultRepoStrategy  string
+	DefaultRepoOverrides map[string]string
+	KubeContext          string
+	WorkingDir           string
+	Namespaces           []string
+	InsecureRegistries   map[string]bool
+	Session              Session
+}
+
+// DefaultRepoSubstitution returns how the default repo is substituted into image names.
+func (r *RunContext) DefaultRepoSubstitution() util.DefaultRepoSubstitution {
+	return util.DefaultRepoSubstitution{
+		Repo:      r.DefaultRepo,
+		Strategy:  r.DefaultRepoStrategy,
+		Overrides: r.DefaultRepoOverrides,
+	}
 }
 
 func GetRunContext(opts *config.SkaffoldOptions, cfg *latest.Pipeline) (*RunContext, error) {
@@ -52,12 +68,20 @@ func GetRunContext(opts *config.SkaffoldOptions, cfg *latest.Pipeline) (*RunCont
 		return nil, errors.Wrap(err, "finding current directory")
 	}
 
+	session := newSession(opts.RunID, cwd)
+
+	// Every deployer, the status check and the log tailer use the resolved namespace.
+	opts.Namespace, err = deployNamespace(opts.Namespace, cfg.Deploy.Namespace, session)
+	if err != nil {
+		return nil, err
+	}
+
 	namespaces, err := runnerutil.GetAllPodNamespaces(opts.Namespace)
 	if err != nil {
 		return nil, errors.Wrap(err, "getting namespace list")
 	}
 
-	defaultRepo, err := configutil.GetDefaultRepo(opts.DefaultRepo)
+	defaultRepo, err := configutil.GetDefaultRepoSubstitution(opts.DefaultRepo, opts.DefaultRepoStrategy, opts.DefaultRepoOverrides)
 	if err != nil {
 		return nil, errors.Wrap(err, "getting default repo")
 	}
@@ -75,12 +99,37 @@ func GetRunContext(opts *config.SkaffoldOptions, cfg *latest.Pipeline) (*RunCont
 	}
 
 	return &RunContext{
-		Opts:               opts,
-		Cfg:                cfg,
-		WorkingDir:         cwd,
-		DefaultRepo:        defaultRepo,
-		KubeContext:        kubeContext,
-		Namespaces:         namespaces,
-		InsecureRegistries: insecureRegistries,
+		Opts:                 opts,
+		Cfg:                  cfg,
+		WorkingDir:           cwd,
+		DefaultRepo:          defaultRepo.Repo,
+		DefaultRepoStrategy:  defaultRepo.Strategy,
+		DefaultRepoOverrides: defaultRepo.Overrides,
+		KubeContext:          kubeContext,
+		Namespaces:           namespaces,
+		InsecureRegistries:   insecureRegistries,
+		Session:              session,
 	}, nil
 }
+
+// deployNamespace returns the namespace given with `--namespace` or, if none is given,
+// the namespace configured by the `deploy.namespace` template.
+func deployNamespace(flag, template string, session Session) (string, error) {
+	if flag != "" || template == "" {
+		return flag, nil
+	}
+
+	tmpl, err := util.ParseEnvTemplate(template)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing namespace template %q", template)
+	}
+	namespace, err := util.ExecuteEnvTemplate(tmpl, session.TemplateValues())
+	if err != nil {
+		return "", errors.Wrapf(err, "executing namespace template %q", template)
+	}
+
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return "", fmt.Errorf("invalid namespace %q from %q: %s", namespace, template, strings.Join(errs, ", "))
+	}
+	return namespace, nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestDeployNamespace(t *testing.T) {
+	var tests = []struct {
+		description string
+		flag        string
+		template    string
+		expected    string
+		shouldErr   bool
+	}{
+		{
+			description: "no namespace",
+		},
+		{
+			description: "flag",
+			flag:        "staging",
+			template:    "{{.USER}}-dev",
+			expected:    "staging",
+		},
+		{
+			description: "env template",
+			template:    "{{.USER}}-dev",
+			expected:    "jdoe-dev",
+		},
+		{
+			description: "session template",
+			template:    "{{.RUN_USER}}-{{.GIT_BRANCH}}",
+			expected:    "alice-master",
+		},
+		{
+			description: "invalid namespace",
+			template:    "{{.USER}}.dev",
+			shouldErr:   true,
+		},
+		{
+			description: "invalid template",
+			template:    "{{.USER",
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			reset := testutil.SetEnvs(t, map[string]string{"USER": "jdoe"})
+			defer reset()
+
+			namespace, err := deployNamespace(test.flag, test.template, Session{User: "alice", GitBranch: "master"})
+
+			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.expected, namespace)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+)
+
+// Session describes a Skaffold session. Its run ID is set as a label on all the
+// deployed resources, so that external tooling can correlate them with the session.
+type Session struct {
+	RunID     string `json:"runId"`
+	User      string `json:"user,omitempty"`
+	GitCommit string `json:"gitCommit,omitempty"`
+	GitBranch string `json:"gitBranch,omitempty"`
+}
+
+// TemplateValues are the values of the session that can be used in templates.
+func (s Session) TemplateValues() map[string]string {
+	return map[string]string{
+		"RUN_ID":     s.RunID,
+		"RUN_USER":   s.User,
+		"GIT_COMMIT": s.GitCommit,
+		"GIT_BRANCH": s.GitBranch,
+	}
+}
+
+// newSession starts a session with the given run ID, or a random one.
+// The git metadata is read from the repository that contains the working directory.
+func newSession(runID, workingDir string) Session {
+	if runID == "" {
+		runID = randomRunID()
+	}
+
+	commit, branch := gitHead(workingDir)
+
+	return Session{
+		RunID:     runID,
+		User:      currentUser(),
+		GitCommit: commit,
+		GitBranch: branch,
+	}
+}
+
+func randomRunID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(buf)
+}
+
+func currentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+// gitHead reads the commit and the branch checked out in the git repository
+// that contains a directory. It doesn't shell out to git.
+func gitHead(dir string) (string, string) {
+	gitDir := findGitDir(dir)
+	if gitDir == "" {
+		return "", ""
+	}
+
+	head, err := ioutil.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return "", ""
+	}
+
+	ref := strings.TrimSpace(string(head))
+	if !strings.HasPrefix(ref, "ref: ") {
+		// Detached HEAD
+		return ref, ""
+	}
+
+	ref = strings.TrimPrefix(ref, "ref: ")
+	return resolveRef(gitDir, ref), strings.TrimPrefix(ref, "refs/heads/")
+}
+
+func findGitDir(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+
+	for {
+		gitDir := filepath.Join(dir, ".git")
+		if info, err := os.Stat(gitDir); err == nil && info.IsDir() {
+			return gitDir
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+func resolveRef(gitDir, ref string) string {
+	if commit, err := ioutil.ReadFile(filepath.Join(gitDir, filepath.FromSlash(ref))); err == nil {
+		return strings.TrimSpace(string(commit))
+	}
+
+	packed, err := ioutil.ReadFile(filepath.Join(gitDir, "packed-refs"))
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(packed), "\n") {
+		if fields := strings.Fields(line); len(fields) == 2 && fields[1] == ref {
+			return fields[0]
+		}
+	}
+	return ""
+}
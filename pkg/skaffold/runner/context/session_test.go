@@ -0,0 +1,89 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestGitHead(t *testing.T) {
+	var tests = []struct {
+		description    string
+		files          map[string]string
+		expectedCommit string
+		expectedBranch string
+	}{
+		{
+			description: "branch",
+			files: map[string]string{
+				".git/HEAD":              "ref: refs/heads/master\n",
+				".git/refs/heads/master": "aea33bcc86b5af8c8570ff45d8a643202d63c808\n",
+			},
+			expectedCommit: "aea33bcc86b5af8c8570ff45d8a643202d63c808",
+			expectedBranch: "master",
+		},
+		{
+			description: "packed ref",
+			files: map[string]string{
+				".git/HEAD":        "ref: refs/heads/feature/x\n",
+				".git/packed-refs": "# pack-refs with: peeled fully-peeled sorted\nee64c8d4a2bfe93e3e1e4a2cbe1ae1c4d5df8e76 refs/heads/feature/x\n",
+			},
+			expectedCommit: "ee64c8d4a2bfe93e3e1e4a2cbe1ae1c4d5df8e76",
+			expectedBranch: "feature/x",
+		},
+		{
+			description: "detached head",
+			files: map[string]string{
+				".git/HEAD": "aea33bcc86b5af8c8570ff45d8a643202d63c808\n",
+			},
+			expectedCommit: "aea33bcc86b5af8c8570ff45d8a643202d63c808",
+		},
+		{
+			description: "not a git repository",
+			files: map[string]string{
+				"skaffold.yaml": "",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			tmpDir, cleanup := testutil.NewTempDir(t)
+			defer cleanup()
+
+			for path, content := range test.files {
+				tmpDir.Write(path, content)
+			}
+			tmpDir.Mkdir("sub/dir")
+
+			commit, branch := gitHead(tmpDir.Path("sub/dir"))
+
+			testutil.CheckDeepEqual(t, test.expectedCommit, commit)
+			testutil.CheckDeepEqual(t, test.expectedBranch, branch)
+		})
+	}
+}
+
+func TestNewSession(t *testing.T) {
+	session := newSession("my-run", ".")
+	testutil.CheckDeepEqual(t, "my-run", session.RunID)
+
+	session = newSession("", ".")
+	testutil.CheckDeepEqual(t, 16, len(session.RunID))
+}
@@ -21,6 +21,13 @@ import (
 	"io"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/deploy"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/errcode"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/metrics"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/output"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/trace"
+	"github.com/pkg/errors"
 )
 
 // Deploy deploys build artifacts.
@@ -41,7 +48,60 @@ func (r *SkaffoldRunner) Deploy(ctx context.Context, out io.Writer, artifacts []
 
 // Deploy deploys the given artifacts and tail logs if tail present
 func (r *SkaffoldRunner) deploy(ctx context.Context, out io.Writer, artifacts []build.Artifact) error {
-	err := r.Deployer.Deploy(ctx, out, artifacts, r.labellers)
+	if err := r.createNamespace(out); err != nil {
+		return failed(errcode.DeployFailed, err)
+	}
+
+	if err := r.WaitForImages(ctx, out, artifacts); err != nil {
+		return err
+	}
+
+	deployCtx, endTrace := trace.StartTrace(ctx, "deploy", nil)
+	endPhase := metrics.StartPhase("deploy")
+	err := withTimeout(deployCtx, "deploy", r.runCtx.Opts.DeployTimeout, func(ctx context.Context) error {
+		return r.Deployer.Deploy(ctx, out, artifacts, r.labellers)
+	})
+	endPhase(err)
+	endTrace(err)
 	r.hasDeployed = true
-	return err
+	if err != nil {
+		return failed(errcode.DeployFailed, err)
+	}
+
+	// The pods of the artifacts developed remotely only become ready once their sources are synced.
+	if err := r.syncRemoteDev(ctx, out); err != nil {
+		return err
+	}
+	return r.performStatusCheck(ctx, out)
+}
+
+// createNamespace creates the namespace configured with `deploy.namespace`, if it's missing,
+// so that every developer can deploy to their own namespace without setting it up.
+func (r *SkaffoldRunner) createNamespace(out io.Writer) error {
+	cfg := r.runCtx.Cfg.Deploy
+	if cfg.Namespace == "" || r.runCtx.Opts.Namespace == "" {
+		return nil
+	}
+	if cfg.KubectlDeploy == nil && cfg.KustomizeDeploy == nil && cfg.HelmDeploy == nil {
+		// Nothing is deployed to a Kubernetes namespace.
+		return nil
+	}
+
+	return kubernetes.CreateNamespaceIfMissing(out, r.runCtx.Opts.Namespace)
+}
+
+// performStatusCheck waits for the deployed resources to stabilize, if enabled.
+func (r *SkaffoldRunner) performStatusCheck(ctx context.Context, out io.Writer) error {
+	if !deploy.StatusCheckEnabled(r.runCtx) {
+		return nil
+	}
+	ctx, endTrace := trace.StartTrace(ctx, "status check", nil)
+	endPhase := metrics.StartPhase("status check")
+	err := deploy.StatusCheck(ctx, output.Headlines(out), r.defaultLabeller, r.runCtx)
+	endPhase(err)
+	endTrace(err)
+	if err != nil {
+		return failed(errcode.ForStatusCheck(err), errors.Wrap(err, "status check"))
+	}
+	return nil
 }
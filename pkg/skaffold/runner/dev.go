@@ -19,10 +19,16 @@ package runner
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
-	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/errcode"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/metrics"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/output"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/remotedev"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/server"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/sync"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/watch"
 	"github.com/pkg/errors"
@@ -32,24 +38,44 @@ import (
 // ErrorConfigurationChanged is a special error that's returned when the skaffold configuration was changed.
 var ErrorConfigurationChanged = errors.New("configuration changed")
 
+// reverseSyncInterval is how often the files generated in containers are copied back.
+const reverseSyncInterval = 2 * time.Second
+
 // Dev watches for changes and runs the skaffold build and deploy
 // config until interrupted by the user.
 func (r *SkaffoldRunner) Dev(ctx context.Context, out io.Writer, artifacts []*latest.Artifact) error {
 	logger := r.newLogger(out, artifacts)
 	defer logger.Stop()
 
-	portForwarder := kubernetes.NewPortForwarder(out, r.imageList, r.runCtx.Namespaces)
+	portForwarder := r.newPortForwarder(out)
 	defer portForwarder.Stop()
 
+	if r.runCtx.Opts.RemoteDev {
+		remoteDev, err := remotedev.New(artifacts, r.Syncer, r.runCtx.Namespaces)
+		if err != nil {
+			return errors.Wrap(err, "remote development")
+		}
+		r.remoteDev = remoteDev
+	}
+	r.reverseSyncer = sync.NewReverseSyncer(artifacts, r.runCtx.Namespaces)
+
+	var lock devLock
+	if r.runCtx.Opts.UI {
+		r.handleUIRebuilds(ctx, out, artifacts, logger, &lock)
+		defer server.OnRebuild(nil)
+	}
+
 	// Create watcher and register artifacts to build current state of files.
 	changed := changes{}
 	onChange := func() error {
+		lock.Lock()
+		defer lock.Unlock()
 		defer changed.reset()
 
 		logger.Mute()
 
 		for _, a := range changed.dirtyArtifacts {
-			s, err := sync.NewItem(a.artifact, a.events, r.builds, r.runCtx.InsecureRegistries)
+			s, err := r.newSyncItem(ctx, a.artifact, a.events)
 			if err != nil {
 				return errors.Wrap(err, "sync")
 			}
@@ -65,18 +91,42 @@ func (r *SkaffoldRunner) Dev(ctx context.Context, out io.Writer, artifacts []*la
 			return ErrorConfigurationChanged
 		case len(changed.needsResync) > 0:
 			for _, s := range changed.needsResync {
-				color.Default.Fprintf(out, "Syncing %d files for %s\n", len(s.Copy)+len(s.Delete), s.Image)
-
-				if err := r.Syncer.Sync(ctx, s); err != nil {
-					logrus.Warnln("Skipping deploy due to sync error:", err)
+				fileCount := len(s.Copy) + len(s.Delete)
+				color.Default.Fprintf(output.Headlines(out), "Syncing %d files for %s\n", fileCount, s.Image)
+				event.FileSyncInProgress(fileCount, s.Image)
+
+				endPhase := metrics.StartPhase("sync")
+				err := r.Syncer.Sync(ctx, s)
+				endPhase(err)
+				if err != nil {
+					event.FileSyncFailed(fileCount, s.Image, err)
+					logrus.Warnln("Skipping deploy due to sync error:", failed(errcode.SyncFailed, err))
 					return nil
 				}
+				event.FileSyncSucceeded(fileCount, s.Image)
+				if r.remoteDev != nil {
+					if err := r.remoteDev.Restart(ctx, s.Image); err != nil {
+						logrus.Warnln("Unable to restart the app:", err)
+						return nil
+					}
+				}
 			}
 		case len(changed.needsRebuild) > 0:
 			if err := r.buildTestDeploy(ctx, out, changed.needsRebuild); err != nil {
 				logrus.Warnln("Skipping deploy due to error:", err)
 				return nil
 			}
+		case changed.needsRetest:
+			if !r.runCtx.Opts.SkipTests {
+				if err := r.Test(ctx, out, r.builds); err != nil {
+					logrus.Warnln("Skipping deploy due to test error:", failed(errcode.TestFailed, err))
+					return nil
+				}
+			}
+			if err := r.Deploy(ctx, out, r.builds); err != nil {
+				logrus.Warnln("Skipping deploy due to error:", err)
+				return nil
+			}
 		case changed.needsRedeploy:
 			if err := r.Deploy(ctx, out, r.builds); err != nil {
 				logrus.Warnln("Skipping deploy due to error:", err)
@@ -91,14 +141,30 @@ func (r *SkaffoldRunner) Dev(ctx context.Context, out io.Writer, artifacts []*la
 	// Watch artifacts
 	for i := range artifacts {
 		artifact := artifacts[i]
-		if !r.runCtx.Opts.IsTargetImage(artifact) {
+		// Artifacts that are not built are not watched either.
+		if !r.runCtx.Opts.IsTargetImage(artifact) || !r.runCtx.Opts.IsBuildImage(artifact) {
 			continue
 		}
 
-		if err := r.Watcher.Register(
-			func() ([]string, error) { return r.Builder.DependenciesForArtifact(ctx, artifact) },
-			func(e watch.Events) { changed.AddDirtyArtifact(artifact, e) },
-		); err != nil {
+		deps := func() ([]string, error) { return r.Builder.DependenciesForArtifact(ctx, artifact) }
+		onChange := func(e watch.Events) {
+			// Files copied from the containers don't need to be synced back.
+			if r.reverseSyncer != nil {
+				if e = r.reverseSyncer.Filter(e); !e.HasChanged() {
+					return
+				}
+			}
+			changed.AddDirtyArtifact(artifact, e)
+		}
+
+		register := r.Watcher.Register
+		if artifact.Watch == "git" {
+			register = func(deps func() ([]string, error), onChange func(watch.Events)) error {
+				return r.Watcher.RegisterGit(artifact.Workspace, deps, onChange)
+			}
+		}
+
+		if err := register(deps, onChange); err != nil {
 			return errors.Wrapf(err, "watching files for artifact %s", artifact.ImageName)
 		}
 	}
@@ -106,7 +172,7 @@ func (r *SkaffoldRunner) Dev(ctx context.Context, out io.Writer, artifacts []*la
 	// Watch test configuration
 	if err := r.Watcher.Register(
 		r.TestDependencies,
-		func(watch.Events) { changed.needsRedeploy = true },
+		func(watch.Events) { changed.needsRetest = true },
 	); err != nil {
 		return errors.Wrap(err, "watching test files")
 	}
@@ -132,6 +198,10 @@ func (r *SkaffoldRunner) Dev(ctx context.Context, out io.Writer, artifacts []*la
 		return errors.Wrap(err, "exiting dev mode because first run failed")
 	}
 
+	if r.reverseSyncer != nil {
+		r.reverseSyncer.Start(ctx, reverseSyncInterval)
+	}
+
 	// Start logs
 	if r.runCtx.Opts.TailDev {
 		if err := logger.Start(ctx); err != nil {
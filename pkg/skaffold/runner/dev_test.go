@@ -36,6 +36,10 @@ func (t *NoopWatcher) Register(func() ([]string, error), func(watch.Events)) err
 	return nil
 }
 
+func (t *NoopWatcher) RegisterGit(string, func() ([]string, error), func(watch.Events)) error {
+	return nil
+}
+
 func (t *NoopWatcher) Run(context.Context, io.Writer, func() error) error {
 	return nil
 }
@@ -46,6 +50,10 @@ func (t *FailWatcher) Register(func() ([]string, error), func(watch.Events)) err
 	return nil
 }
 
+func (t *FailWatcher) RegisterGit(string, func() ([]string, error), func(watch.Events)) error {
+	return nil
+}
+
 func (t *FailWatcher) Run(context.Context, io.Writer, func() error) error {
 	return errors.New("BUG")
 }
@@ -61,6 +69,10 @@ func (t *TestWatcher) Register(deps func() ([]string, error), onChange func(watc
 	return nil
 }
 
+func (t *TestWatcher) RegisterGit(dir string, deps func() ([]string, error), onChange func(watch.Events)) error {
+	return t.Register(deps, onChange)
+}
+
 func (t *TestWatcher) Run(ctx context.Context, out io.Writer, onChange func() error) error {
 	for _, evt := range t.events {
 		t.testBench.enterNewCycle()
@@ -71,6 +83,8 @@ func (t *TestWatcher) Run(ctx context.Context, out io.Writer, onChange func() er
 				t.callbacks[0](evt) // 1st artifact changed
 			case "file2":
 				t.callbacks[1](evt) // 2nd artifact changed
+			case "test.yaml":
+				t.callbacks[2](evt) // test configuration changed
 			case "manifest.yaml":
 				t.callbacks[3](evt) // deployment configuration changed
 			}
@@ -241,6 +255,24 @@ func TestDev(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "retest",
+			testBench:   &TestBench{},
+			watchEvents: []watch.Events{
+				{Modified: []string{"test.yaml"}},
+			},
+			expectedActions: []Actions{
+				{
+					Built:    []string{"img1:1", "img2:1"},
+					Tested:   []string{"img1:1", "img2:1"},
+					Deployed: []string{"img1:1", "img2:1"},
+				},
+				{
+					Tested:   []string{"img1:1", "img2:1"},
+					Deployed: []string{"img1:1", "img2:1"},
+				},
+			},
+		},
 		{
 			description: "redeploy",
 			testBench:   &TestBench{},
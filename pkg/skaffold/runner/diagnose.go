@@ -83,6 +83,8 @@ func typeOfArtifact(a *latest.Artifact) string {
 		return "Jib Gradle artifact"
 	case a.JibMavenArtifact != nil:
 		return "Jib Maven artifact"
+	case a.PluginArtifact != nil:
+		return "Plugin artifact"
 	default:
 		return "Unknown artifact"
 	}
@@ -0,0 +1,172 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	configutil "github.com/GoogleContainerTools/skaffold/cmd/skaffold/app/cmd/config"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/docker"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/localcluster"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/version"
+	"github.com/docker/distribution/reference"
+)
+
+var (
+	// For testing
+	globalConfigFile = configutil.GetConfigFile
+	isLocalCluster   = configutil.GetLocalCluster
+	authConfig       = func(registry string) (bool, error) {
+		cfg, err := docker.DefaultAuthHelper.GetAuthConfig(registry)
+		if err != nil {
+			return false, err
+		}
+		return cfg.Username != "" || cfg.Auth != "" || cfg.IdentityToken != "" || cfg.RegistryToken != "", nil
+	}
+)
+
+// toolVersionCommands are the commands run to get the version of each tool.
+var toolVersionCommands = []struct {
+	tool string
+	args []string
+}{
+	{"docker", []string{"docker", "version", "--format", "{{.Client.Version}}"}},
+	{"helm", []string{"helm", "version", "--client", "--short"}},
+	{"kubectl", []string{"kubectl", "version", "--client", "--short"}},
+	{"kustomize", []string{"kustomize", "version"}},
+}
+
+// Environment describes the environment Skaffold runs in.
+type Environment struct {
+	SkaffoldVersion string            `json:"skaffoldVersion"`
+	ConfigVersion   string            `json:"configVersion"`
+	ConfigFiles     []string          `json:"configFiles"`
+	Profiles        []string          `json:"profiles,omitempty"`
+	KubeContext     string            `json:"kubeContext"`
+	Cluster         string            `json:"cluster"`
+	Tools           map[string]string `json:"tools"`
+	Registries      map[string]string `json:"registries,omitempty"`
+	Watcher         string            `json:"watcher"`
+}
+
+// DiagnoseEnvironment reports the versions of the tools Skaffold uses, the cluster
+// it deploys to, the registries it pushes to and where its configuration comes from.
+// The config files are listed by order of precedence, after the command line flags.
+func (r *SkaffoldRunner) DiagnoseEnvironment(ctx context.Context, apiVersion string) *Environment {
+	return &Environment{
+		SkaffoldVersion: version.Get().Version,
+		ConfigVersion:   apiVersion,
+		ConfigFiles:     r.configFiles(),
+		Profiles:        r.runCtx.Opts.Profiles,
+		KubeContext:     r.runCtx.KubeContext,
+		Cluster:         r.clusterType(),
+		Tools:           toolVersions(ctx),
+		Registries:      r.registriesAuth(),
+		Watcher:         r.watcher(),
+	}
+}
+
+func (r *SkaffoldRunner) configFiles() []string {
+	skaffoldYaml := r.runCtx.Opts.ConfigurationFile
+	if !util.IsURL(skaffoldYaml) {
+		if abs, err := filepath.Abs(skaffoldYaml); err == nil {
+			skaffoldYaml = abs
+		}
+	}
+
+	files := []string{skaffoldYaml}
+	if global, err := globalConfigFile(); err == nil {
+		files = append(files,
+			fmt.Sprintf("%s (kube-context %s)", global, r.runCtx.KubeContext),
+			fmt.Sprintf("%s (global)", global))
+	}
+
+	return files
+}
+
+func (r *SkaffoldRunner) clusterType() string {
+	if t := localcluster.ClusterType(r.runCtx.KubeContext); t != "" {
+		return t
+	}
+
+	if local, err := isLocalCluster(); err == nil && local {
+		return "local"
+	}
+	return "remote"
+}
+
+func toolVersions(ctx context.Context) map[string]string {
+	versions := map[string]string{}
+
+	for _, c := range toolVersionCommands {
+		cmd := exec.CommandContext(ctx, util.ToolPath(c.args[0]), c.args[1:]...)
+		out, err := util.RunCmdOut(cmd)
+		if err != nil {
+			versions[c.tool] = "not found"
+			continue
+		}
+		versions[c.tool] = strings.TrimSpace(strings.SplitN(string(out), "\n", 2)[0])
+	}
+
+	return versions
+}
+
+func (r *SkaffoldRunner) registriesAuth() map[string]string {
+	var registries []string
+	for _, a := range r.runCtx.Cfg.Build.Artifacts {
+		image := r.runCtx.DefaultRepoSubstitution().Substitute(a.ImageName)
+
+		ref, err := reference.ParseNormalizedNamed(image)
+		if err != nil {
+			continue
+		}
+		registries = append(registries, reference.Domain(ref))
+	}
+	sort.Strings(registries)
+
+	auth := map[string]string{}
+	for _, registry := range registries {
+		if _, present := auth[registry]; present {
+			continue
+		}
+
+		found, err := authConfig(registry)
+		switch {
+		case err != nil:
+			auth[registry] = fmt.Sprintf("error: %s", err)
+		case found:
+			auth[registry] = "credentials found"
+		default:
+			auth[registry] = "no credentials"
+		}
+	}
+
+	return auth
+}
+
+func (r *SkaffoldRunner) watcher() string {
+	if r.runCtx.Opts.Trigger == "polling" {
+		return fmt.Sprintf("polling (every %dms)", r.runCtx.Opts.WatchPollInterval)
+	}
+	return r.runCtx.Opts.Trigger
+}
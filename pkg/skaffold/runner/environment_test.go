@@ -0,0 +1,81 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestDiagnoseEnvironment(t *testing.T) {
+	restore := testutil.SetupFakeKubernetesContext(t, api.Config{CurrentContext: "kind-dev"})
+	defer restore()
+
+	reset := testutil.Override(t, &util.DefaultExecCommand, testutil.NewFakeCmd(t).
+		WithRunOut("docker version --format {{.Client.Version}}", "19.03.5\n").
+		WithRunOut("helm version --client --short", "Client: v2.16.1+gbbdfe5e\n").
+		WithRunOut("kubectl version --client --short", "Client Version: v1.16.3\n").
+		WithRunOutErr("kustomize version", "", errors.New("not found")))
+	defer reset()
+	resetConfig := testutil.Override(t, &globalConfigFile, func() (string, error) { return "/home/skaffold/.skaffold/config", nil })
+	defer resetConfig()
+	resetAuth := testutil.Override(t, &authConfig, func(registry string) (bool, error) {
+		return registry == "gcr.io", nil
+	})
+	defer resetAuth()
+
+	runner := createRunner(t, &TestBench{})
+	runner.runCtx.KubeContext = "kind-dev"
+	runner.runCtx.Opts.ConfigurationFile = "https://example.com/skaffold.yaml"
+	runner.runCtx.Opts.WatchPollInterval = 500
+	runner.runCtx.Cfg.Build.Artifacts = []*latest.Artifact{
+		{ImageName: "gcr.io/project/app"},
+		{ImageName: "docker.io/library/worker"},
+		{ImageName: "gcr.io/project/other"},
+	}
+
+	env := runner.DiagnoseEnvironment(context.Background(), latest.Version)
+
+	testutil.CheckDeepEqual(t, &Environment{
+		SkaffoldVersion: env.SkaffoldVersion,
+		ConfigVersion:   latest.Version,
+		ConfigFiles: []string{
+			"https://example.com/skaffold.yaml",
+			"/home/skaffold/.skaffold/config (kube-context kind-dev)",
+			"/home/skaffold/.skaffold/config (global)",
+		},
+		KubeContext: "kind-dev",
+		Cluster:     "kind",
+		Tools: map[string]string{
+			"docker":    "19.03.5",
+			"helm":      "Client: v2.16.1+gbbdfe5e",
+			"kubectl":   "Client Version: v1.16.3",
+			"kustomize": "not found",
+		},
+		Registries: map[string]string{
+			"docker.io": "no credentials",
+			"gcr.io":    "credentials found",
+		},
+		Watcher: "polling (every 500ms)",
+	}, env)
+}
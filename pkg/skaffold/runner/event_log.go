@@ -0,0 +1,135 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	configutil "github.com/GoogleContainerTools/skaffold/cmd/skaffold/app/cmd/config"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// For testing
+var (
+	eventLogsDir     = defaultEventLogsDir
+	eventLogSettings = configutil.GetEventLogSettings
+)
+
+const eventLogTimeFormat = "20060102-150405"
+
+func defaultEventLogsDir() (string, error) {
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", errors.Wrap(err, "retrieving home directory")
+	}
+
+	return filepath.Join(home, constants.DefaultSkaffoldDir, "events"), nil
+}
+
+// startEventLog writes the events of `run`, `dev` and `debug` sessions to a file
+// named after the start time and the run ID. The session is not stopped if the
+// file can't be written.
+func startEventLog(runCtx *runcontext.RunContext) {
+	switch runCtx.Opts.Command {
+	case "run", "dev", "debug":
+	default:
+		return
+	}
+
+	settings, err := eventLogSettings()
+	if err != nil {
+		logrus.Warnln("Unable to write the event log:", err)
+		return
+	}
+	if !settings.Enabled {
+		return
+	}
+
+	dir, err := eventLogsDir()
+	if err != nil {
+		logrus.Warnln("Unable to write the event log:", err)
+		return
+	}
+
+	now := time.Now()
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s.jsonl", now.Format(eventLogTimeFormat), runCtx.Session.RunID))
+	if err := event.LogToFile(path); err != nil {
+		logrus.Warnln("Unable to write the event log:", err)
+		return
+	}
+	logrus.Debugln("Writing events to", path)
+
+	if err := pruneEventLogs(dir, settings.MaxFiles, settings.MaxAge, now); err != nil {
+		logrus.Warnln("Unable to remove old event logs:", err)
+	}
+}
+
+// pruneEventLogs keeps the `maxFiles` most recent event logs, and removes the ones
+// older than `maxAge`. Zero values don't limit the event logs.
+func pruneEventLogs(dir string, maxFiles int, maxAge time.Duration, now time.Time) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.jsonl"))
+	if err != nil {
+		return err
+	}
+
+	// File names start with the time of the session.
+	sort.Strings(files)
+
+	for i, file := range files {
+		remove := maxFiles > 0 && len(files)-i > maxFiles
+		if !remove && maxAge > 0 {
+			info, err := os.Stat(file)
+			remove = err == nil && now.Sub(info.ModTime()) > maxAge
+		}
+
+		if remove {
+			if err := os.Remove(file); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// EventLogFile returns the event log of the session with the given run ID.
+func EventLogFile(runID string) (string, error) {
+	dir, err := eventLogsDir()
+	if err != nil {
+		return "", err
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*-"+runID+".jsonl"))
+	if err != nil {
+		return "", err
+	}
+	if len(files) == 0 {
+		return "", fmt.Errorf("no event log found for run %s in %s", runID, dir)
+	}
+
+	sort.Strings(files)
+	return files[len(files)-1], nil
+}
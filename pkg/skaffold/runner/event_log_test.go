@@ -0,0 +1,96 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestPruneEventLogs(t *testing.T) {
+	now := time.Now()
+
+	var tests = []struct {
+		description string
+		maxFiles    int
+		maxAge      time.Duration
+		expected    []string
+	}{
+		{
+			description: "no limit",
+			expected:    []string{"20190101-100000-a.jsonl", "20190102-100000-b.jsonl", "20190103-100000-c.jsonl"},
+		},
+		{
+			description: "max files",
+			maxFiles:    2,
+			expected:    []string{"20190102-100000-b.jsonl", "20190103-100000-c.jsonl"},
+		},
+		{
+			description: "max age",
+			maxAge:      36 * time.Hour,
+			expected:    []string{"20190102-100000-b.jsonl", "20190103-100000-c.jsonl"},
+		},
+		{
+			description: "max files and age",
+			maxFiles:    1,
+			maxAge:      36 * time.Hour,
+			expected:    []string{"20190103-100000-c.jsonl"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			tmpDir, cleanup := testutil.NewTempDir(t)
+			defer cleanup()
+
+			tmpDir.Write("20190101-100000-a.jsonl", "").Chtimes("20190101-100000-a.jsonl", now.Add(-48*time.Hour))
+			tmpDir.Write("20190102-100000-b.jsonl", "").Chtimes("20190102-100000-b.jsonl", now.Add(-24*time.Hour))
+			tmpDir.Write("20190103-100000-c.jsonl", "").Chtimes("20190103-100000-c.jsonl", now)
+
+			err := pruneEventLogs(tmpDir.Root(), test.maxFiles, test.maxAge, now)
+			testutil.CheckError(t, false, err)
+
+			files, _ := filepath.Glob(tmpDir.Path("*.jsonl"))
+			sort.Strings(files)
+			testutil.CheckDeepEqual(t, tmpDir.Paths(test.expected...), files)
+		})
+	}
+}
+
+func TestEventLogFile(t *testing.T) {
+	tmpDir, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+
+	tmpDir.Write("20190101-100000-run1.jsonl", "").
+		Write("20190102-100000-run2.jsonl", "").
+		Write("20190103-100000-run2.jsonl", "")
+
+	reset := testutil.Override(t, &eventLogsDir, func() (string, error) { return tmpDir.Root(), nil })
+	defer reset()
+
+	file, err := EventLogFile("run1")
+	testutil.CheckErrorAndDeepEqual(t, false, err, tmpDir.Path("20190101-100000-run1.jsonl"), file)
+
+	file, err = EventLogFile("run2")
+	testutil.CheckErrorAndDeepEqual(t, false, err, tmpDir.Path("20190103-100000-run2.jsonl"), file)
+
+	_, err = EventLogFile("unknown")
+	testutil.CheckError(t, true, err)
+}
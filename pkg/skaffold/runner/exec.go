@@ -0,0 +1,101 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Exec runs a command in a deployed pod. The target is either an artifact, in which case
+// the command runs in the most recent pod running its image, or a resource like `deployment/app`.
+func (r *SkaffoldRunner) Exec(ctx context.Context, out io.Writer, target string, command []string) error {
+	args := []string{"exec", "-i"}
+	if color.IsTTY(os.Stdin) && color.IsTTY(out) {
+		args = append(args, "-t")
+	}
+
+	if strings.Contains(target, "/") && !r.isArtifact(target) {
+		if r.runCtx.Opts.Namespace != "" {
+			args = append(args, "--namespace", r.runCtx.Opts.Namespace)
+		}
+		args = append(args, target)
+	} else {
+		a, err := findArtifact(r.runCtx.Cfg.Build.Artifacts, target)
+		if err != nil {
+			return err
+		}
+
+		pod, container, err := kubernetes.FindPodForImage(r.runCtx.Namespaces, a.ImageName)
+		if err != nil {
+			return err
+		}
+		logrus.Infof("Running in pod %s/%s, container %s", pod.Namespace, pod.Name, container)
+
+		args = append(args, "--namespace", pod.Namespace, pod.Name, "-c", container)
+	}
+
+	cmd := kubernetes.KubectlCommand(ctx, append(append(args, "--"), command...)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = out
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return errors.Wrapf(err, "running command in %s", target)
+	}
+	if err := cmd.Wait(); err != nil {
+		return errors.Wrapf(err, "running command in %s", target)
+	}
+	return nil
+}
+
+func (r *SkaffoldRunner) isArtifact(target string) bool {
+	_, err := findArtifact(r.runCtx.Cfg.Build.Artifacts, target)
+	return err == nil
+}
+
+// findArtifact finds an artifact by image name or, when it's not ambiguous,
+// by the last part of its image name.
+func findArtifact(artifacts []*latest.Artifact, target string) (*latest.Artifact, error) {
+	var candidates []*latest.Artifact
+
+	for _, a := range artifacts {
+		if a.ImageName == target {
+			return a, nil
+		}
+		if strings.HasSuffix(a.ImageName, "/"+target) {
+			candidates = append(candidates, a)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, fmt.Errorf("no artifact named %s", target)
+	case 1:
+		return candidates[0], nil
+	default:
+		return nil, fmt.Errorf("several artifacts match %s, use the full image name", target)
+	}
+}
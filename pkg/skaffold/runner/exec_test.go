@@ -0,0 +1,76 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestFindArtifact(t *testing.T) {
+	artifacts := []*latest.Artifact{
+		{ImageName: "gcr.io/project/frontend"},
+		{ImageName: "gcr.io/project/backend"},
+		{ImageName: "gcr.io/other/backend"},
+		{ImageName: "worker"},
+	}
+
+	tests := []struct {
+		description string
+		target      string
+		expected    string
+		shouldErr   bool
+	}{
+		{
+			description: "full image name",
+			target:      "gcr.io/project/backend",
+			expected:    "gcr.io/project/backend",
+		},
+		{
+			description: "last part of the image name",
+			target:      "frontend",
+			expected:    "gcr.io/project/frontend",
+		},
+		{
+			description: "short image name",
+			target:      "worker",
+			expected:    "worker",
+		},
+		{
+			description: "ambiguous",
+			target:      "backend",
+			shouldErr:   true,
+		},
+		{
+			description: "unknown",
+			target:      "unknown",
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			a, err := findArtifact(artifacts, test.target)
+
+			testutil.CheckError(t, test.shouldErr, err)
+			if !test.shouldErr {
+				testutil.CheckDeepEqual(t, test.expected, a.ImageName)
+			}
+		})
+	}
+}
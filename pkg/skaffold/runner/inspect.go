@@ -0,0 +1,120 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/sync"
+	"github.com/pkg/errors"
+)
+
+// SessionInfo describes the current session and the metadata it adds to the deployed resources.
+type SessionInfo struct {
+	runcontext.Session
+	Labels         map[string]string `json:"labels"`
+	Annotations    map[string]string `json:"annotations,omitempty"`
+	TemplateValues map[string]string `json:"templateValues"`
+}
+
+// Session returns the run ID, user and git metadata of the session, along with the
+// labels and annotations set on the deployed resources.
+func (r *SkaffoldRunner) Session() *SessionInfo {
+	return &SessionInfo{
+		Session:        r.runCtx.Session,
+		Labels:         r.sessionLabeller.Labels(),
+		Annotations:    r.sessionLabeller.Annotations(),
+		TemplateValues: r.runCtx.Session.TemplateValues(),
+	}
+}
+
+// SyncInfo tells how an artifact handles the changes to a file.
+type SyncInfo struct {
+	Image string `json:"image"`
+	// Dependency is true if the artifact watches the file.
+	Dependency bool `json:"dependency"`
+	// Rules are the manual sync rules that copy the file into the container.
+	Rules []SyncRuleInfo `json:"rules,omitempty"`
+}
+
+// SyncRuleInfo is a sync rule that matches a file, with the file's destination in the container.
+type SyncRuleInfo struct {
+	Src         string `json:"src"`
+	Dest        string `json:"dest"`
+	Strip       string `json:"strip,omitempty"`
+	Destination string `json:"destination"`
+}
+
+// InspectSync tells, for each artifact whose context contains a file, if changes to that file
+// are synced into the container, and where to. Relative destinations are resolved against
+// the container's working dir, unless it's empty.
+func (r *SkaffoldRunner) InspectSync(ctx context.Context, file, containerWd string) ([]SyncInfo, error) {
+	absFile, err := filepath.Abs(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var infos []SyncInfo
+	for _, a := range r.runCtx.Cfg.Build.Artifacts {
+		workspace, err := filepath.Abs(a.Workspace)
+		if err != nil {
+			return nil, err
+		}
+		relPath, err := filepath.Rel(workspace, absFile)
+		if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+			continue
+		}
+
+		deps, err := r.Builder.DependenciesForArtifact(ctx, a)
+		if err != nil {
+			return nil, errors.Wrapf(err, "listing the dependencies of %s", a.ImageName)
+		}
+
+		matches, err := sync.MatchingRules(a, filepath.Join(a.Workspace, relPath), containerWd)
+		if err != nil {
+			return nil, errors.Wrapf(err, "matching the sync rules of %s", a.ImageName)
+		}
+
+		info := SyncInfo{
+			Image:      a.ImageName,
+			Dependency: isDependency(deps, absFile),
+		}
+		for _, m := range matches {
+			info.Rules = append(info.Rules, SyncRuleInfo{
+				Src:         m.Rule.Src,
+				Dest:        m.Rule.Dest,
+				Strip:       m.Rule.Strip,
+				Destination: m.Dest,
+			})
+		}
+		infos = append(infos, info)
+	}
+
+	return infos, nil
+}
+
+func isDependency(deps []string, absFile string) bool {
+	for _, dep := range deps {
+		if absDep, err := filepath.Abs(dep); err == nil && absDep == absFile {
+			return true
+		}
+	}
+	return false
+}
@@ -19,11 +19,15 @@ package runner
 import (
 	"fmt"
 
+	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/version"
+	"github.com/pkg/errors"
 )
 
 const (
 	K8ManagedByLabel = "app.kubernetes.io/managed-by"
+	RunIDLabel       = "skaffold.dev/run-id"
 	UnknownVersion   = "unknown"
 	Empty            = ""
 )
@@ -51,3 +55,55 @@ func (d *DefaultLabeller) Labels() map[string]string {
 		K8ManagedByLabel: fmt.Sprintf("skaffold-%s", version),
 	}
 }
+
+// SessionLabeller adds the run ID label, and the labels and annotations configured
+// under `deploy`, evaluated for the current session.
+type SessionLabeller struct {
+	labels      map[string]string
+	annotations map[string]string
+}
+
+// NewSessionLabeller evaluates the `deploy.labels` and `deploy.annotations` templates.
+func NewSessionLabeller(runCtx *runcontext.RunContext) (*SessionLabeller, error) {
+	labels, err := evaluateTemplates(runCtx.Cfg.Deploy.Labels)
+	if err != nil {
+		return nil, errors.Wrap(err, "evaluating labels")
+	}
+	labels[RunIDLabel] = runCtx.Session.RunID
+
+	annotations, err := evaluateTemplates(runCtx.Cfg.Deploy.Annotations)
+	if err != nil {
+		return nil, errors.Wrap(err, "evaluating annotations")
+	}
+
+	return &SessionLabeller{
+		labels:      labels,
+		annotations: annotations,
+	}, nil
+}
+
+func (s *SessionLabeller) Labels() map[string]string {
+	return s.labels
+}
+
+func (s *SessionLabeller) Annotations() map[string]string {
+	return s.annotations
+}
+
+func evaluateTemplates(templates map[string]string) (map[string]string, error) {
+	evaluated := map[string]string{}
+
+	for key, value := range templates {
+		tmpl, err := util.ParseEnvTemplate(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing template for %s", key)
+		}
+
+		evaluated[key], err = util.ExecuteEnvTemplate(tmpl, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "evaluating %s", key)
+		}
+	}
+
+	return evaluated, nil
+}
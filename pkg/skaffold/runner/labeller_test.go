@@ -20,6 +20,9 @@ import (
 	"fmt"
 	"testing"
 
+	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
 	"github.com/GoogleContainerTools/skaffold/testutil"
 )
 
@@ -52,3 +55,60 @@ func TestDefaultLabeller(t *testing.T) {
 		})
 	}
 }
+
+func TestSessionLabeller(t *testing.T) {
+	var tests = []struct {
+		description         string
+		labels              map[string]string
+		annotations         map[string]string
+		shouldErr           bool
+		expectedLabels      map[string]string
+		expectedAnnotations map[string]string
+	}{
+		{
+			description:         "run id only",
+			expectedLabels:      map[string]string{"skaffold.dev/run-id": "1234"},
+			expectedAnnotations: map[string]string{},
+		},
+		{
+			description: "templated labels and annotations",
+			labels:      map[string]string{"owner": "{{.RUN_USER}}", "team": "backend"},
+			annotations: map[string]string{"git": "{{.GIT_BRANCH}}@{{.GIT_COMMIT}}"},
+			expectedLabels: map[string]string{
+				"skaffold.dev/run-id": "1234",
+				"owner":               "john",
+				"team":                "backend",
+			},
+			expectedAnnotations: map[string]string{"git": "master@abcdef"},
+		},
+		{
+			description: "invalid template",
+			labels:      map[string]string{"owner": "{{.RUN_USER"},
+			shouldErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			session := runcontext.Session{RunID: "1234", User: "john", GitCommit: "abcdef", GitBranch: "master"}
+			defer util.SetSessionValues(nil)
+			util.SetSessionValues(session.TemplateValues())
+
+			l, err := NewSessionLabeller(&runcontext.RunContext{
+				Cfg: &latest.Pipeline{
+					Deploy: latest.DeployConfig{
+						Labels:      test.labels,
+						Annotations: test.annotations,
+					},
+				},
+				Session: session,
+			})
+
+			testutil.CheckError(t, test.shouldErr, err)
+			if !test.shouldErr {
+				testutil.CheckDeepEqual(t, test.expectedLabels, l.Labels())
+				testutil.CheckDeepEqual(t, test.expectedAnnotations, l.Annotations())
+			}
+		})
+	}
+}
@@ -19,7 +19,9 @@ package runner
 import (
 	"context"
 	"io"
+	"time"
 
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/errcode"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/pkg/errors"
@@ -34,13 +36,40 @@ func (r *SkaffoldRunner) newLogger(out io.Writer, artifacts []*latest.Artifact)
 }
 
 func (r *SkaffoldRunner) newLoggerForImages(out io.Writer, images []string) *kubernetes.LogAggregator {
-	return kubernetes.NewLogAggregator(out, images, r.imageList, r.runCtx.Namespaces)
+	logger := kubernetes.NewLogAggregator(out, images, r.imageList, r.runCtx.Namespaces)
+	if r.logSource != nil {
+		logger.AddSource(r.logSource)
+	}
+	return logger
 }
 
+// TailLogs streams the logs until interrupted, or for `--tail-for`.
+// With `--tail-fail-on-crash`, it fails as soon as a container exits with an error.
 func (r *SkaffoldRunner) TailLogs(ctx context.Context, out io.Writer, logger *kubernetes.LogAggregator) error {
+	logger.SetContainers(r.runCtx.Opts.TailContainers)
 	if err := logger.Start(ctx); err != nil {
 		return errors.Wrap(err, "starting logger")
 	}
-	<-ctx.Done()
-	return nil
+	defer logger.Stop()
+
+	var timeout <-chan time.Time
+	if r.runCtx.Opts.TailFor > 0 {
+		timer := time.NewTimer(r.runCtx.Opts.TailFor)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	var crashes <-chan error
+	if r.runCtx.Opts.TailFailOnCrash {
+		crashes = logger.Crashes()
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case <-timeout:
+		return nil
+	case err := <-crashes:
+		return failed(errcode.ContainerCrashed, err)
+	}
 }
@@ -0,0 +1,63 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/config"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes"
+	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	k8s "k8s.io/client-go/kubernetes"
+)
+
+type fakeLogSource struct{}
+
+func (fakeLogSource) Logs(context.Context) (map[string]io.Reader, error) {
+	return map[string]io.Reader{"app": strings.NewReader("")}, nil
+}
+
+func TestTailLogsFor(t *testing.T) {
+	defer testutil.Override(t, &kubernetes.Client, func() (k8s.Interface, error) {
+		return nil, errors.New("no cluster")
+	})()
+
+	r := &SkaffoldRunner{
+		runCtx: &runcontext.RunContext{
+			Opts: &config.SkaffoldOptions{TailFor: 10 * time.Millisecond},
+		},
+	}
+	logger := kubernetes.NewLogAggregator(&bytes.Buffer{}, nil, kubernetes.NewImageList(), nil)
+	logger.AddSource(fakeLogSource{})
+
+	done := make(chan error)
+	go func() { done <- r.TailLogs(context.Background(), &bytes.Buffer{}, logger) }()
+
+	select {
+	case err := <-done:
+		testutil.CheckError(t, false, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("logs were still tailed after --tail-for")
+	}
+}
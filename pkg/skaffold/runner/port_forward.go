@@ -0,0 +1,31 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"io"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes"
+)
+
+func (r *SkaffoldRunner) newPortForwarder(out io.Writer) *kubernetes.PortForwarder {
+	portForwarder := kubernetes.NewPortForwarder(out, r.imageList, r.runCtx.Namespaces)
+	if r.runCtx.Opts.Open != "" {
+		portForwarder.WithBrowser(r.runCtx.Opts.Open, r.runCtx.Cfg.PortForward)
+	}
+	return portForwarder
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"io"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/local"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/pkg/errors"
+)
+
+// PruneImages removes the images built for the artifacts from the local Docker daemon.
+// The `keep` most recent images of each artifact are kept. If `keep` is negative,
+// `build.local.prune.keep` is used.
+func (r *SkaffoldRunner) PruneImages(ctx context.Context, out io.Writer, artifacts []*latest.Artifact, keep int) error {
+	localBuild := r.runCtx.Cfg.Build.LocalBuild
+	if localBuild == nil {
+		return errors.New("only images built locally can be pruned")
+	}
+
+	if keep < 0 {
+		keep = 0
+		if localBuild.Prune != nil {
+			keep = localBuild.Prune.Keep
+		}
+	}
+
+	builder, err := local.NewBuilder(r.runCtx)
+	if err != nil {
+		return errors.Wrap(err, "getting local builder")
+	}
+
+	tags, err := r.ImageTags(ctx, out, artifacts)
+	if err != nil {
+		return errors.Wrap(err, "generating tags")
+	}
+
+	return builder.PruneArtifacts(ctx, out, tags, artifacts, keep)
+}
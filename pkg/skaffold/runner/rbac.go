@@ -0,0 +1,295 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/deploy/kubectl"
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	yamlv2 "gopkg.in/yaml.v2"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	rbacRoleName = "skaffold"
+
+	// tillerNamespace is where Helm 2 installs Tiller by default.
+	tillerNamespace = "kube-system"
+)
+
+// clusterScopedKinds are the kinds of common resources that don't belong to a namespace.
+var clusterScopedKinds = map[string]bool{
+	"APIService":                     true,
+	"ClusterRole":                    true,
+	"ClusterRoleBinding":             true,
+	"CustomResourceDefinition":       true,
+	"MutatingWebhookConfiguration":   true,
+	"Namespace":                      true,
+	"Node":                           true,
+	"PersistentVolume":               true,
+	"PodSecurityPolicy":              true,
+	"PriorityClass":                  true,
+	"StorageClass":                   true,
+	"ValidatingWebhookConfiguration": true,
+}
+
+// ruleKey is an API group and a resource.
+type ruleKey struct {
+	group    string
+	resource string
+}
+
+// ruleSet lists the verbs allowed on each resource.
+type ruleSet map[ruleKey]map[string]bool
+
+func (s ruleSet) add(group string, resources []string, verbs ...string) {
+	for _, resource := range resources {
+		key := ruleKey{group: group, resource: resource}
+		if s[key] == nil {
+			s[key] = map[string]bool{}
+		}
+		for _, verb := range verbs {
+			s[key][verb] = true
+		}
+	}
+}
+
+// policyRules merges the resources of a group that are allowed the same verbs.
+func (s ruleSet) policyRules() []rbacv1.PolicyRule {
+	byGroupAndVerbs := map[string]*rbacv1.PolicyRule{}
+	var keys []string
+
+	for key, verbSet := range s {
+		var verbs []string
+		for verb := range verbSet {
+			verbs = append(verbs, verb)
+		}
+		sort.Strings(verbs)
+
+		id := key.group + "\x00" + strings.Join(verbs, ",")
+		rule, found := byGroupAndVerbs[id]
+		if !found {
+			rule = &rbacv1.PolicyRule{APIGroups: []string{key.group}, Verbs: verbs}
+			byGroupAndVerbs[id] = rule
+			keys = append(keys, id)
+		}
+		rule.Resources = append(rule.Resources, key.resource)
+	}
+	sort.Strings(keys)
+
+	var rules []rbacv1.PolicyRule
+	for _, id := range keys {
+		rule := byGroupAndVerbs[id]
+		sort.Strings(rule.Resources)
+		rules = append(rules, *rule)
+	}
+	return rules
+}
+
+// rbacRules are the rules needed in each namespace, and at the cluster scope.
+// The empty namespace is the one Skaffold deploys to.
+type rbacRules struct {
+	namespaced map[string]ruleSet
+	cluster    ruleSet
+}
+
+func (r *rbacRules) in(namespace string) ruleSet {
+	if r.namespaced[namespace] == nil {
+		r.namespaced[namespace] = ruleSet{}
+	}
+	return r.namespaced[namespace]
+}
+
+// WriteRBACRoles writes the minimal Roles, and ClusterRole, that a service account
+// needs to build, test and deploy the current configuration. Log tailing,
+// port forwarding and status checks are covered only if they're enabled.
+func (r *SkaffoldRunner) WriteRBACRoles(ctx context.Context, out io.Writer) error {
+	rules, err := r.rbacRules(ctx)
+	if err != nil {
+		return err
+	}
+
+	var objects []interface{}
+
+	var namespaces []string
+	for namespace := range rules.namespaced {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	for _, namespace := range namespaces {
+		objects = append(objects, &rbacv1.Role{
+			TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "Role"},
+			ObjectMeta: metav1.ObjectMeta{Name: rbacRoleName, Namespace: namespace},
+			Rules:      rules.namespaced[namespace].policyRules(),
+		})
+	}
+	if len(rules.cluster) > 0 {
+		objects = append(objects, &rbacv1.ClusterRole{
+			TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRole"},
+			ObjectMeta: metav1.ObjectMeta{Name: rbacRoleName},
+			Rules:      rules.cluster.policyRules(),
+		})
+	}
+
+	for i, object := range objects {
+		buf, err := yaml.Marshal(object)
+		if err != nil {
+			return errors.Wrap(err, "marshalling role")
+		}
+		if i > 0 {
+			fmt.Fprintln(out, "---")
+		}
+		out.Write(bytes.Replace(buf, []byte("  creationTimestamp: null\n"), nil, 1))
+	}
+
+	return nil
+}
+
+func (r *SkaffoldRunner) rbacRules(ctx context.Context) (*rbacRules, error) {
+	rules := &rbacRules{
+		namespaced: map[string]ruleSet{},
+		cluster:    ruleSet{},
+	}
+	cfg := r.runCtx.Cfg
+	deployNamespace := r.runCtx.Opts.Namespace
+
+	if cfg.Deploy.HelmDeploy != nil {
+		// Helm 2 talks to Tiller through a port-forward. Tiller creates the resources.
+		rules.in(tillerNamespace).add("", []string{"pods"}, "list")
+		rules.in(tillerNamespace).add("", []string{"pods/portforward"}, "create")
+	} else {
+		if err := r.addManifestRules(ctx, rules, deployNamespace); err != nil {
+			return nil, err
+		}
+	}
+
+	if r.runCtx.Opts.StatusCheck || cfg.Deploy.StatusCheck != nil {
+		rules.in(deployNamespace).add("apps", []string{"daemonsets", "deployments", "replicasets", "statefulsets"}, "get", "list", "watch")
+		rules.in(deployNamespace).add("", []string{"events", "pods"}, "get", "list")
+		if cfg.Deploy.StatusCheck != nil && len(cfg.Deploy.StatusCheck.Endpoints) > 0 {
+			rules.in(deployNamespace).add("", []string{"services", "services/proxy"}, "get")
+			rules.in(deployNamespace).add("extensions", []string{"ingresses"}, "get")
+		}
+	}
+
+	if r.runCtx.Opts.Tail || r.runCtx.Opts.PortForward {
+		rules.in(deployNamespace).add("", []string{"pods"}, "get", "list", "watch")
+	}
+	if r.runCtx.Opts.Tail {
+		rules.in(deployNamespace).add("", []string{"pods/log"}, "get")
+	}
+	if r.runCtx.Opts.PortForward {
+		rules.in(deployNamespace).add("", []string{"pods/portforward"}, "create")
+	}
+
+	for _, a := range cfg.Build.Artifacts {
+		if a.Sync != nil {
+			rules.in(deployNamespace).add("", []string{"pods"}, "list")
+			rules.in(deployNamespace).add("", []string{"pods/exec"}, "create")
+			break
+		}
+	}
+
+	if cluster := cfg.Build.Cluster; cluster != nil {
+		rules.in(cluster.Namespace).add("", []string{"pods"}, "create", "delete", "get", "watch")
+		rules.in(cluster.Namespace).add("", []string{"pods/exec"}, "create")
+		rules.in(cluster.Namespace).add("", []string{"secrets"}, "create", "delete", "get")
+	}
+
+	for _, test := range cfg.Test {
+		for _, clusterTest := range test.ClusterTests {
+			namespace := clusterTest.Namespace
+			if namespace == "" {
+				namespace = deployNamespace
+			}
+			rules.in(namespace).add("", []string{"pods"}, "create", "delete", "get", "watch")
+			rules.in(namespace).add("", []string{"pods/log"}, "get")
+		}
+	}
+
+	return rules, nil
+}
+
+// addManifestRules allows the resources of the rendered manifests to be applied and deleted.
+func (r *SkaffoldRunner) addManifestRules(ctx context.Context, rules *rbacRules, deployNamespace string) error {
+	var buf bytes.Buffer
+	if err := r.Deployer.Render(ctx, &buf, nil, r.labellers); err != nil {
+		return errors.Wrap(err, "rendering manifests")
+	}
+
+	var manifests kubectl.ManifestList
+	manifests.Append(buf.Bytes())
+
+	for _, manifest := range manifests {
+		var resource struct {
+			APIVersion string `yaml:"apiVersion"`
+			Kind       string `yaml:"kind"`
+			Metadata   struct {
+				Namespace string `yaml:"namespace"`
+			} `yaml:"metadata"`
+		}
+		if err := yamlv2.Unmarshal(manifest, &resource); err != nil {
+			return errors.Wrap(err, "reading manifests")
+		}
+		if resource.Kind == "" {
+			continue
+		}
+
+		group := ""
+		if parts := strings.SplitN(resource.APIVersion, "/", 2); len(parts) == 2 {
+			group = parts[0]
+		}
+
+		resources := []string{resourceForKind(resource.Kind)}
+		if clusterScopedKinds[resource.Kind] {
+			rules.cluster.add(group, resources, "create", "delete", "get", "patch")
+			continue
+		}
+
+		namespace := resource.Metadata.Namespace
+		if namespace == "" {
+			namespace = deployNamespace
+		}
+		rules.in(namespace).add(group, resources, "create", "delete", "get", "patch")
+	}
+
+	return nil
+}
+
+// resourceForKind guesses the name of the resource for a kind.
+func resourceForKind(kind string) string {
+	resource := strings.ToLower(kind)
+
+	switch {
+	case resource == "endpoints":
+		return resource
+	case strings.HasSuffix(resource, "s"), strings.HasSuffix(resource, "x"), strings.HasSuffix(resource, "ch"), strings.HasSuffix(resource, "sh"):
+		return resource + "es"
+	case len(resource) > 1 && strings.HasSuffix(resource, "y") && !strings.ContainsAny(resource[len(resource)-2:len(resource)-1], "aeiou"):
+		return strings.TrimSuffix(resource, "y") + "ies"
+	default:
+		return resource + "s"
+	}
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/deploy"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+type fakeRenderer struct {
+	deploy.Deployer
+	manifests string
+}
+
+func (f *fakeRenderer) Render(_ context.Context, out io.Writer, _ []build.Artifact, _ []deploy.Labeller) error {
+	_, err := io.WriteString(out, f.manifests)
+	return err
+}
+
+const rbacManifests = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: leeroy
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: leeroy-web
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: leeroy-web
+---
+apiVersion: networking.k8s.io/v1beta1
+kind: NetworkPolicy
+metadata:
+  name: leeroy-web
+  namespace: other
+`
+
+func TestWriteRBACRoles(t *testing.T) {
+	restore := testutil.SetupFakeKubernetesContext(t, api.Config{CurrentContext: "cluster1"})
+	defer restore()
+
+	var tests = []struct {
+		description string
+		pipeline    func(*latest.Pipeline)
+		tail        bool
+		expected    string
+	}{
+		{
+			description: "kubectl deployer",
+			pipeline:    func(*latest.Pipeline) {},
+			expected: `apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: skaffold
+rules:
+- apiGroups:
+  - ""
+  resources:
+  - services
+  verbs:
+  - create
+  - delete
+  - get
+  - patch
+- apiGroups:
+  - apps
+  resources:
+  - deployments
+  verbs:
+  - create
+  - delete
+  - get
+  - patch
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: skaffold
+  namespace: other
+rules:
+- apiGroups:
+  - networking.k8s.io
+  resources:
+  - networkpolicies
+  verbs:
+  - create
+  - delete
+  - get
+  - patch
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: skaffold
+rules:
+- apiGroups:
+  - ""
+  resources:
+  - namespaces
+  verbs:
+  - create
+  - delete
+  - get
+  - patch
+`,
+		},
+		{
+			description: "helm deployer, log tailing and cluster tests",
+			pipeline: func(p *latest.Pipeline) {
+				p.Deploy.HelmDeploy = &latest.HelmDeploy{}
+				p.Test = []*latest.TestCase{{ClusterTests: []latest.ClusterTest{{Namespace: "tests"}}}}
+			},
+			tail: true,
+			expected: `apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: skaffold
+rules:
+- apiGroups:
+  - ""
+  resources:
+  - pods/log
+  verbs:
+  - get
+- apiGroups:
+  - ""
+  resources:
+  - pods
+  verbs:
+  - get
+  - list
+  - watch
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: skaffold
+  namespace: kube-system
+rules:
+- apiGroups:
+  - ""
+  resources:
+  - pods/portforward
+  verbs:
+  - create
+- apiGroups:
+  - ""
+  resources:
+  - pods
+  verbs:
+  - list
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: skaffold
+  namespace: tests
+rules:
+- apiGroups:
+  - ""
+  resources:
+  - pods
+  verbs:
+  - create
+  - delete
+  - get
+  - watch
+- apiGroups:
+  - ""
+  resources:
+  - pods/log
+  verbs:
+  - get
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			runner := createRunner(t, &TestBench{})
+			runner.Deployer = &fakeRenderer{manifests: rbacManifests}
+			runner.runCtx.Opts.Tail = test.tail
+			test.pipeline(runner.runCtx.Cfg)
+
+			var out bytes.Buffer
+			err := runner.WriteRBACRoles(context.Background(), &out)
+
+			testutil.CheckErrorAndDeepEqual(t, false, err, test.expected, out.String())
+		})
+	}
+}
+
+func TestResourceForKind(t *testing.T) {
+	testutil.CheckDeepEqual(t, "deployments", resourceForKind("Deployment"))
+	testutil.CheckDeepEqual(t, "ingresses", resourceForKind("Ingress"))
+	testutil.CheckDeepEqual(t, "networkpolicies", resourceForKind("NetworkPolicy"))
+	testutil.CheckDeepEqual(t, "gateways", resourceForKind("Gateway"))
+	testutil.CheckDeepEqual(t, "endpoints", resourceForKind("Endpoints"))
+}
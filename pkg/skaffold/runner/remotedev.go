@@ -0,0 +1,49 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"io"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/sync"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/watch"
+)
+
+// newSyncItem returns the files to sync for changes to an artifact. With `--remote-dev`,
+// all the changes to artifacts developed remotely are synced.
+func (r *SkaffoldRunner) newSyncItem(ctx context.Context, a *latest.Artifact, e watch.Events) (*sync.Item, error) {
+	if r.remoteDev != nil {
+		if s, err := r.remoteDev.NewItem(a, e); s != nil || err != nil {
+			return s, err
+		}
+	}
+
+	return sync.NewItem(ctx, a, e, r.builds, r.runCtx.InsecureRegistries)
+}
+
+// syncRemoteDev copies the sources of the artifacts developed remotely into their pods.
+func (r *SkaffoldRunner) syncRemoteDev(ctx context.Context, out io.Writer) error {
+	if r.remoteDev == nil {
+		return nil
+	}
+
+	return r.remoteDev.SyncAll(ctx, out, func(a *latest.Artifact) ([]string, error) {
+		return r.Builder.DependenciesForArtifact(ctx, a)
+	})
+}
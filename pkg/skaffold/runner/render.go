@@ -0,0 +1,181 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/docker"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/watch"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DigestSourceNone keeps the tags in the rendered manifests.
+	DigestSourceNone = "none"
+	// DigestSourceRemote resolves the digests from the registries.
+	DigestSourceRemote = "remote"
+	// DigestSourceLocal resolves the digests from the local Docker daemon.
+	DigestSourceLocal = "local"
+)
+
+// For testing
+var (
+	remoteDigest = docker.RemoteDigest
+	localDigest  = func(ctx context.Context, tag string, insecureRegistries map[string]bool) (string, error) {
+		localDocker, err := docker.NewAPIClient(false, insecureRegistries)
+		if err != nil {
+			return "", err
+		}
+		return localDocker.RepoDigest(ctx, tag)
+	}
+)
+
+// Render writes the hydrated manifests of the given artifacts, with images
+// referenced by digest unless digestSource is DigestSourceNone.
+func (r *SkaffoldRunner) Render(ctx context.Context, out io.Writer, builds []build.Artifact, digestSource string) error {
+	switch digestSource {
+	case DigestSourceNone:
+	case DigestSourceRemote, DigestSourceLocal:
+		resolved, err := r.resolveDigests(ctx, builds, digestSource)
+		if err != nil {
+			return errors.Wrap(err, "resolving digests")
+		}
+		builds = resolved
+	default:
+		return fmt.Errorf("unknown digest source %q, expected one of %s, %s or %s", digestSource, DigestSourceNone, DigestSourceRemote, DigestSourceLocal)
+	}
+
+	return withTimeout(ctx, "render", r.runCtx.Opts.RenderTimeout, func(ctx context.Context) error {
+		return r.Deployer.Render(ctx, out, builds, r.labellers)
+	})
+}
+
+// RenderOnChange calls render for all the artifacts, then again whenever the sources of artifacts
+// or the manifests change, with the artifacts to rebuild, until the context is cancelled.
+// It returns ErrorConfigurationChanged when the Skaffold configuration changes.
+func (r *SkaffoldRunner) RenderOnChange(ctx context.Context, out io.Writer, artifacts []*latest.Artifact, render func(context.Context, []*latest.Artifact) error) error {
+	changed := changes{}
+	onChange := func() error {
+		defer changed.reset()
+
+		var rebuild []*latest.Artifact
+		for _, a := range changed.dirtyArtifacts {
+			rebuild = append(rebuild, a.artifact)
+		}
+
+		switch {
+		case changed.needsReload:
+			return ErrorConfigurationChanged
+		case len(rebuild) > 0 || changed.needsRedeploy:
+			if err := render(ctx, rebuild); err != nil {
+				logrus.Warnln("Skipping render due to error:", err)
+			}
+		}
+		return nil
+	}
+
+	// Watch artifacts
+	for i := range artifacts {
+		artifact := artifacts[i]
+		if !r.runCtx.Opts.IsTargetImage(artifact) {
+			continue
+		}
+
+		if err := r.Watcher.Register(
+			func() ([]string, error) { return r.Builder.DependenciesForArtifact(ctx, artifact) },
+			func(e watch.Events) { changed.AddDirtyArtifact(artifact, e) },
+		); err != nil {
+			return errors.Wrapf(err, "watching files for artifact %s", artifact.ImageName)
+		}
+	}
+
+	// Watch manifests
+	if err := r.Watcher.Register(
+		r.Dependencies,
+		func(watch.Events) { changed.needsRedeploy = true },
+	); err != nil {
+		return errors.Wrap(err, "watching files for deployer")
+	}
+
+	// Watch Skaffold configuration
+	if err := r.Watcher.Register(
+		func() ([]string, error) { return []string{r.runCtx.Opts.ConfigurationFile}, nil },
+		func(watch.Events) { changed.needsReload = true },
+	); err != nil {
+		return errors.Wrapf(err, "watching skaffold configuration %s", r.runCtx.Opts.ConfigurationFile)
+	}
+
+	// First render
+	if err := render(ctx, artifacts); err != nil {
+		return errors.Wrap(err, "first render failed")
+	}
+
+	return r.Watcher.Run(ctx, out, onChange)
+}
+
+// resolveDigests replaces the tags of the given artifacts with digests.
+func (r *SkaffoldRunner) resolveDigests(ctx context.Context, builds []build.Artifact, digestSource string) ([]build.Artifact, error) {
+	var resolved []build.Artifact
+
+	for _, b := range builds {
+		parsed, err := docker.ParseReference(b.Tag)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing %s", b.Tag)
+		}
+
+		// The digest is already known when the image was pushed.
+		digest := parsed.Digest
+		if digest == "" {
+			if digest, err = r.findDigest(ctx, b.Tag, digestSource); err != nil {
+				return nil, errors.Wrapf(err, "getting the digest of %s", b.Tag)
+			}
+		}
+
+		resolved = append(resolved, build.Artifact{
+			ImageName: b.ImageName,
+			Tag:       parsed.BaseName + "@" + digest,
+		})
+	}
+
+	return resolved, nil
+}
+
+func (r *SkaffoldRunner) findDigest(ctx context.Context, tag string, digestSource string) (string, error) {
+	if digestSource == DigestSourceRemote {
+		return remoteDigest(tag, r.runCtx.InsecureRegistries)
+	}
+
+	repoDigest, err := localDigest(ctx, tag, r.runCtx.InsecureRegistries)
+	if err != nil {
+		return "", err
+	}
+	if repoDigest == "" {
+		return "", errors.New("the image was never pushed or pulled")
+	}
+
+	parsed, err := docker.ParseReference(repoDigest)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing %s", repoDigest)
+	}
+	return parsed.Digest, nil
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/watch"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestRender(t *testing.T) {
+	restore := testutil.SetupFakeKubernetesContext(t, api.Config{CurrentContext: "cluster1"})
+	defer restore()
+
+	var tests = []struct {
+		description  string
+		digestSource string
+		builds       []build.Artifact
+		shouldErr    bool
+		expected     []string
+	}{
+		{
+			description:  "keep tags",
+			digestSource: DigestSourceNone,
+			builds:       []build.Artifact{{ImageName: "img", Tag: "gcr.io/project/img:v1"}},
+			expected:     []string{"gcr.io/project/img:v1"},
+		},
+		{
+			description:  "remote digest",
+			digestSource: DigestSourceRemote,
+			builds:       []build.Artifact{{ImageName: "img", Tag: "gcr.io/project/img:v1"}},
+			expected:     []string{"gcr.io/project/img@sha256:remote"},
+		},
+		{
+			description:  "local digest",
+			digestSource: DigestSourceLocal,
+			builds:       []build.Artifact{{ImageName: "img", Tag: "gcr.io/project/img:v1"}},
+			expected:     []string{"gcr.io/project/img@sha256:4b825dc642cb6eb9a060e54bf8d69288fbee4904ed0bbd8d5b2c8ee6fb4a4bd6"},
+		},
+		{
+			description:  "known digest",
+			digestSource: DigestSourceRemote,
+			builds:       []build.Artifact{{ImageName: "img", Tag: "gcr.io/project/img:v1@sha256:81daf011d63b68cfa514ddab7741a1adddd59d3264118dfb0fd9266328bb8883"}},
+			expected:     []string{"gcr.io/project/img@sha256:81daf011d63b68cfa514ddab7741a1adddd59d3264118dfb0fd9266328bb8883"},
+		},
+		{
+			description:  "never pushed",
+			digestSource: DigestSourceLocal,
+			builds:       []build.Artifact{{ImageName: "img", Tag: "img:v1"}},
+			shouldErr:    true,
+		},
+		{
+			description:  "unknown digest source",
+			digestSource: "unknown",
+			shouldErr:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			reset := testutil.Override(t, &remoteDigest, func(string, map[string]bool) (string, error) {
+				return "sha256:remote", nil
+			})
+			defer reset()
+			resetLocal := testutil.Override(t, &localDigest, func(_ context.Context, tag string, _ map[string]bool) (string, error) {
+				if tag == "img:v1" {
+					return "", nil
+				}
+				if tag != "gcr.io/project/img:v1" {
+					return "", errors.New("unexpected tag")
+				}
+				return "gcr.io/project/img@sha256:4b825dc642cb6eb9a060e54bf8d69288fbee4904ed0bbd8d5b2c8ee6fb4a4bd6", nil
+			})
+			defer resetLocal()
+
+			testBench := &TestBench{}
+			runner := createRunner(t, testBench)
+			err := runner.Render(context.Background(), ioutil.Discard, test.builds, test.digestSource)
+
+			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.expected, testBench.currentActions.Rendered)
+		})
+	}
+}
+
+// callbackWatcher triggers, for each cycle, the callbacks registered at the given indexes.
+type callbackWatcher struct {
+	cycles    [][]int
+	callbacks []func(watch.Events)
+}
+
+func (w *callbackWatcher) Register(_ func() ([]string, error), onChange func(watch.Events)) error {
+	w.callbacks = append(w.callbacks, onChange)
+	return nil
+}
+
+func (w *callbackWatcher) RegisterGit(_ string, deps func() ([]string, error), onChange func(watch.Events)) error {
+	return w.Register(deps, onChange)
+}
+
+func (w *callbackWatcher) Run(_ context.Context, _ io.Writer, onChange func() error) error {
+	for _, cycle := range w.cycles {
+		for _, i := range cycle {
+			w.callbacks[i](watch.Events{})
+		}
+		if err := onChange(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestRenderOnChange(t *testing.T) {
+	restore := testutil.SetupFakeKubernetesContext(t, api.Config{CurrentContext: "cluster1"})
+	defer restore()
+
+	// Callbacks are registered for img1, img2, the manifests and the configuration.
+	var tests = []struct {
+		description string
+		cycles      [][]int
+		renderErrs  []error
+		expected    [][]string
+		shouldErr   bool
+	}{
+		{
+			description: "artifact changed",
+			cycles:      [][]int{{1}},
+			expected:    [][]string{{"img1", "img2"}, {"img2"}},
+		},
+		{
+			description: "manifests changed",
+			cycles:      [][]int{{2}, {0, 2}},
+			expected:    [][]string{{"img1", "img2"}, nil, {"img1"}},
+		},
+		{
+			description: "nothing changed",
+			cycles:      [][]int{{}},
+			expected:    [][]string{{"img1", "img2"}},
+		},
+		{
+			description: "subsequent errors are ignored",
+			cycles:      [][]int{{0}, {1}},
+			renderErrs:  []error{nil, errors.New("")},
+			expected:    [][]string{{"img1", "img2"}, {"img1"}, {"img2"}},
+		},
+		{
+			description: "configuration changed",
+			cycles:      [][]int{{3}, {0}},
+			expected:    [][]string{{"img1", "img2"}},
+			shouldErr:   true,
+		},
+		{
+			description: "first render fails",
+			cycles:      [][]int{{0}},
+			renderErrs:  []error{errors.New("")},
+			expected:    [][]string{{"img1", "img2"}},
+			shouldErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			runner := createRunner(t, &TestBench{})
+			runner.Watcher = &callbackWatcher{cycles: test.cycles}
+
+			var rendered [][]string
+			render := func(_ context.Context, artifacts []*latest.Artifact) error {
+				var images []string
+				for _, a := range artifacts {
+					images = append(images, a.ImageName)
+				}
+				rendered = append(rendered, images)
+
+				if len(test.renderErrs) >= len(rendered) {
+					return test.renderErrs[len(rendered)-1]
+				}
+				return nil
+			}
+
+			err := runner.RenderOnChange(context.Background(), ioutil.Discard, []*latest.Artifact{{ImageName: "img1"}, {ImageName: "img2"}}, render)
+
+			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.expected, rendered)
+		})
+	}
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/cache"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
+	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// runState is what's kept of a failed run, for `skaffold run --resume` to continue from.
+type runState struct {
+	Builds []builtArtifact `json:"builds"`
+}
+
+type builtArtifact struct {
+	ImageName string `json:"imageName"`
+	Tag       string `json:"tag"`
+
+	// Hash identifies the sources the image was built from.
+	Hash string `json:"hash"`
+}
+
+// For testing
+var (
+	hashArtifact = cache.HashArtifact
+	runStateFile = defaultRunStateFile
+)
+
+// defaultRunStateFile returns where the state of a run is kept. It's specific
+// to the project, the configuration, the profiles and the kube-context.
+func defaultRunStateFile(runCtx *runcontext.RunContext) (string, error) {
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", errors.Wrap(err, "retrieving home directory")
+	}
+
+	key := strings.Join([]string{runCtx.WorkingDir, runCtx.Opts.ConfigurationFile, strings.Join(runCtx.Opts.Profiles, ","), runCtx.KubeContext}, "\n")
+	sum := sha256.Sum256([]byte(key))
+
+	return filepath.Join(home, constants.DefaultSkaffoldDir, "runs", hex.EncodeToString(sum[:8])+".json"), nil
+}
+
+// resumeBuilds returns the artifacts that were built by the last failed run and whose
+// sources didn't change since, and the artifacts that still have to be built.
+func (r *SkaffoldRunner) resumeBuilds(ctx context.Context, out io.Writer, artifacts []*latest.Artifact) ([]build.Artifact, []*latest.Artifact) {
+	state, err := r.loadRunState()
+	if err != nil {
+		if !os.IsNotExist(errors.Cause(err)) {
+			logrus.Warnln("Unable to resume the last run:", err)
+		}
+		return nil, artifacts
+	}
+
+	built := map[string]builtArtifact{}
+	for _, b := range state.Builds {
+		built[b.ImageName] = b
+	}
+
+	var resumed []build.Artifact
+	var toBuild []*latest.Artifact
+	for _, a := range artifacts {
+		b, found := built[a.ImageName]
+		if found {
+			hash, err := hashArtifact(ctx, r.Builder, a)
+			if err == nil && hash == b.Hash {
+				color.Default.Fprintf(out, "Resuming with %s, built by the last run\n", b.Tag)
+				resumed = append(resumed, build.Artifact{ImageName: b.ImageName, Tag: b.Tag})
+				continue
+			}
+			color.Default.Fprintf(out, "Rebuilding %s, its sources changed since the last run\n", a.ImageName)
+		}
+		toBuild = append(toBuild, a)
+	}
+
+	return resumed, toBuild
+}
+
+// saveRunState keeps what was built by a failed run.
+func (r *SkaffoldRunner) saveRunState(ctx context.Context, artifacts []*latest.Artifact, builds []build.Artifact) error {
+	tags := map[string]string{}
+	for _, b := range builds {
+		tags[b.ImageName] = b.Tag
+	}
+
+	var state runState
+	for _, a := range artifacts {
+		tag, found := tags[a.ImageName]
+		if !found {
+			continue
+		}
+
+		hash, err := hashArtifact(ctx, r.Builder, a)
+		if err != nil {
+			return errors.Wrapf(err, "hashing %s", a.ImageName)
+		}
+		state.Builds = append(state.Builds, builtArtifact{ImageName: a.ImageName, Tag: tag, Hash: hash})
+	}
+
+	file, err := runStateFile(r.runCtx)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+
+	contents, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, contents, 0644)
+}
+
+func (r *SkaffoldRunner) loadRunState() (*runState, error) {
+	file, err := runStateFile(r.runCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	contents, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var state runState
+	if err := json.Unmarshal(contents, &state); err != nil {
+		return nil, fmt.Errorf("parsing %s: %v", file, err)
+	}
+	return &state, nil
+}
+
+// clearRunState forgets about the last failed run, once a run succeeds.
+func (r *SkaffoldRunner) clearRunState() error {
+	file, err := runStateFile(r.runCtx)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -20,17 +20,97 @@ import (
 	"context"
 	"io"
 
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/errcode"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
-// Run builds artifacts, runs tests on built artifacts, and then deploys them.
+// Run builds artifacts, runs tests on built artifacts, deploys them,
+// and then verifies the deployment.
+// The artifacts built by a failed run are kept, so that the next run can resume from there.
 func (r *SkaffoldRunner) Run(ctx context.Context, out io.Writer, artifacts []*latest.Artifact) error {
-	if err := r.buildTestDeploy(ctx, out, artifacts); err != nil {
+	if err := r.buildTestDeployVerify(ctx, out, artifacts); err != nil {
+		r.keepRunState(ctx, out, artifacts)
 		return err
 	}
+	if err := r.clearRunState(); err != nil {
+		logrus.Warnln("Unable to clear the state of the last run:", err)
+	}
+
+	if r.runCtx.Opts.PortForward {
+		portForwarder := r.newPortForwarder(out)
+		defer portForwarder.Stop()
+
+		if err := portForwarder.Start(ctx); err != nil {
+			return errors.Wrap(err, "starting port-forwarder")
+		}
+		if !r.runCtx.Opts.Tail {
+			<-ctx.Done()
+			return nil
+		}
+	}
 	if r.runCtx.Opts.Tail {
 		logger := r.newLogger(out, artifacts)
 		return r.TailLogs(ctx, out, logger)
 	}
 	return nil
 }
+
+func (r *SkaffoldRunner) buildTestDeployVerify(ctx context.Context, out io.Writer, artifacts []*latest.Artifact) error {
+	if r.runCtx.Opts.Resume {
+		var resumed []build.Artifact
+		resumed, artifacts = r.resumeBuilds(ctx, out, artifacts)
+
+		// The resumed artifacts might have failed their tests.
+		if len(resumed) > 0 && !r.runCtx.Opts.SkipTests {
+			if err := r.Test(ctx, out, resumed); err != nil {
+				return failed(errcode.TestFailed, errors.Wrap(err, "test failed"))
+			}
+		}
+		r.builds = resumed
+
+		if len(artifacts) == 0 {
+			return r.deployVerify(ctx, out)
+		}
+	}
+
+	if err := r.buildTestDeploy(ctx, out, artifacts); err != nil {
+		return err
+	}
+	return r.verify(ctx, out)
+}
+
+// deployVerify deploys and verifies the artifacts when all of them were built by the last run.
+func (r *SkaffoldRunner) deployVerify(ctx context.Context, out io.Writer) error {
+	for _, b := range r.builds {
+		r.imageList.Add(b.Tag)
+	}
+
+	if err := r.deploy(ctx, out, r.builds); err != nil {
+		return errors.Wrap(err, "deploy failed")
+	}
+	return r.verify(ctx, out)
+}
+
+func (r *SkaffoldRunner) verify(ctx context.Context, out io.Writer) error {
+	if err := r.Verify(ctx, out, r.builds); err != nil {
+		return errors.Wrap(err, "verify failed")
+	}
+	return nil
+}
+
+// keepRunState keeps the artifacts built by a failed run.
+func (r *SkaffoldRunner) keepRunState(ctx context.Context, out io.Writer, artifacts []*latest.Artifact) {
+	builds := build.MergeWithPreviousBuilds(build.Completed(), r.builds)
+	if err := r.saveRunState(ctx, artifacts, builds); err != nil {
+		logrus.Warnln("Unable to save the state of the run:", err)
+		return
+	}
+
+	if len(builds) > 0 {
+		color.Default.Fprintln(out, "Use `skaffold run --resume` to continue without rebuilding the artifacts that were built.")
+	}
+}
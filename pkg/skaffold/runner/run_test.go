@@ -21,6 +21,8 @@ import (
 	"io/ioutil"
 	"testing"
 
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/GoogleContainerTools/skaffold/testutil"
 	"github.com/pkg/errors"
@@ -71,6 +73,11 @@ func TestRun(t *testing.T) {
 		},
 	}
 
+	tmpDir, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+	defer func(f func(*runcontext.RunContext) (string, error)) { runStateFile = f }(runStateFile)
+	runStateFile = func(*runcontext.RunContext) (string, error) { return tmpDir.Path("run.json"), nil }
+
 	for _, test := range tests {
 		t.Run(test.description, func(t *testing.T) {
 			runner := createRunner(t, test.testBench)
@@ -83,3 +90,80 @@ func TestRun(t *testing.T) {
 		})
 	}
 }
+
+func TestRunResume(t *testing.T) {
+	restore := testutil.SetupFakeKubernetesContext(t, api.Config{CurrentContext: "cluster1"})
+	defer restore()
+
+	tmpDir, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+	defer func(f func(*runcontext.RunContext) (string, error)) { runStateFile = f }(runStateFile)
+	runStateFile = func(*runcontext.RunContext) (string, error) { return tmpDir.Path("run.json"), nil }
+	defer func(f func(context.Context, build.Builder, *latest.Artifact) (string, error)) { hashArtifact = f }(hashArtifact)
+	hash := "sources1"
+	hashArtifact = func(context.Context, build.Builder, *latest.Artifact) (string, error) { return hash, nil }
+
+	artifacts := []*latest.Artifact{{ImageName: "img1"}, {ImageName: "img2"}}
+
+	// The first run builds both artifacts but fails to deploy
+	testBench := &TestBench{deployErrors: []error{errors.New("")}}
+	runner := createRunner(t, testBench)
+	err := runner.Run(context.Background(), ioutil.Discard, artifacts)
+	testutil.CheckErrorAndDeepEqual(t, true, err, []Actions{{
+		Built:  []string{"img1:1", "img2:1"},
+		Tested: []string{"img1:1", "img2:1"},
+	}}, testBench.Actions())
+
+	// The second run resumes from the deploy
+	testBench = &TestBench{}
+	runner = createRunner(t, testBench)
+	runner.runCtx.Opts.Resume = true
+	err = runner.Run(context.Background(), ioutil.Discard, artifacts)
+	testutil.CheckErrorAndDeepEqual(t, false, err, []Actions{{
+		Tested:   []string{"img1:1", "img2:1"},
+		Deployed: []string{"img1:1", "img2:1"},
+	}}, testBench.Actions())
+
+	// Nothing to resume after a successful run
+	hash = "sources2"
+	testBench = &TestBench{}
+	runner = createRunner(t, testBench)
+	runner.runCtx.Opts.Resume = true
+	err = runner.Run(context.Background(), ioutil.Discard, artifacts)
+	testutil.CheckErrorAndDeepEqual(t, false, err, []Actions{{
+		Built:    []string{"img1:1", "img2:1"},
+		Tested:   []string{"img1:1", "img2:1"},
+		Deployed: []string{"img1:1", "img2:1"},
+	}}, testBench.Actions())
+}
+
+func TestRunBuildImages(t *testing.T) {
+	restore := testutil.SetupFakeKubernetesContext(t, api.Config{CurrentContext: "cluster1"})
+	defer restore()
+
+	tmpDir, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+	defer func(f func(*runcontext.RunContext) (string, error)) { runStateFile = f }(runStateFile)
+	runStateFile = func(*runcontext.RunContext) (string, error) { return tmpDir.Path("run.json"), nil }
+
+	artifacts := []*latest.Artifact{{ImageName: "img1"}, {ImageName: "img2"}}
+
+	// Unselected artifacts are deployed with the pre-built images
+	testBench := &TestBench{}
+	runner := createRunner(t, testBench)
+	runner.runCtx.Opts.BuildImages = []string{"img1"}
+	runner.SetPreBuiltImages([]build.Artifact{{ImageName: "img2", Tag: "img2:prebuilt"}})
+	err := runner.Run(context.Background(), ioutil.Discard, artifacts)
+	testutil.CheckErrorAndDeepEqual(t, false, err, []Actions{{
+		Built:    []string{"img1:1"},
+		Tested:   []string{"img1:1"},
+		Deployed: []string{"img1:1", "img2:prebuilt"},
+	}}, testBench.Actions())
+
+	// Without a pre-built image nor a cache, unselected artifacts can't be deployed
+	testBench = &TestBench{}
+	runner = createRunner(t, testBench)
+	runner.runCtx.Opts.BuildImages = []string{"*1"}
+	err = runner.Run(context.Background(), ioutil.Discard, artifacts)
+	testutil.CheckErrorAndDeepEqual(t, true, err, []Actions{{}}, testBench.Actions())
+}
@@ -27,18 +27,24 @@ import (
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/cluster"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/gcb"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/local"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/plugin"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/tag"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/config"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/deploy"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/metrics"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/output"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/remotedev"
 	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/server"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/sync"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/sync/docker"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/sync/kubectl"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/test"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/version"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/watch"
 	"github.com/pkg/errors"
@@ -57,10 +63,16 @@ type SkaffoldRunner struct {
 	cache             *cache.Cache
 	runCtx            *runcontext.RunContext
 	labellers         []deploy.Labeller
+	defaultLabeller   *DefaultLabeller
+	sessionLabeller   *SessionLabeller
+	remoteDev         *remotedev.Developer
+	reverseSyncer     *sync.ReverseSyncer
 	builds            []build.Artifact
+	preBuiltImages    []build.Artifact
 	hasBuilt          bool
 	hasDeployed       bool
 	imageList         *kubernetes.ImageList
+	logSource         kubernetes.LogSource
 	RPCServerShutdown func() error
 }
 
@@ -70,6 +82,25 @@ func NewForConfig(opts *config.SkaffoldOptions, cfg *latest.SkaffoldConfig) (*Sk
 	if err != nil {
 		return nil, errors.Wrap(err, "getting run context")
 	}
+	util.SetSessionValues(runCtx.Session.TemplateValues())
+	metrics.SetConfig(cfg)
+
+	if err := loadEnvFile(opts, cfg); err != nil {
+		return nil, err
+	}
+
+	if err := setUpTools(context.Background(), cfg.Tools); err != nil {
+		return nil, err
+	}
+
+	if err := plugin.CheckSyncModes(context.Background(), cfg.Build.Artifacts); err != nil {
+		return nil, err
+	}
+
+	sessionLabeller, err := NewSessionLabeller(runCtx)
+	if err != nil {
+		return nil, errors.Wrap(err, "evaluating deploy labels and annotations")
+	}
 
 	tagger, err := getTagger(cfg.Build.TagPolicy, opts.CustomTag)
 	if err != nil {
@@ -89,8 +120,13 @@ func NewForConfig(opts *config.SkaffoldOptions, cfg *latest.SkaffoldConfig) (*Sk
 		return nil, errors.Wrap(err, "parsing deploy config")
 	}
 
+	kubernetes.SetKubectlGlobalFlags(deploy.KubectlFlags(cfg.Deploy).Global)
+
+	// Deployers, like Cloud Run, can stream logs that don't come from pods.
+	logSource, _ := deployer.(kubernetes.LogSource)
+
 	defaultLabeller := NewLabeller("")
-	labellers := []deploy.Labeller{opts, builder, deployer, tagger, defaultLabeller}
+	labellers := []deploy.Labeller{opts, builder, deployer, tagger, defaultLabeller, sessionLabeller}
 
 	builder, tester, deployer = WithTimings(builder, tester, deployer, opts.CacheArtifacts)
 	if opts.Notification {
@@ -109,22 +145,46 @@ func NewForConfig(opts *config.SkaffoldOptions, cfg *latest.SkaffoldConfig) (*Sk
 	event.InitializeState(runCtx)
 
 	event.LogSkaffoldMetadata(version.Get())
+	startEventLog(runCtx)
 
 	return &SkaffoldRunner{
 		Builder:           builder,
 		Tester:            tester,
 		Deployer:          deployer,
 		Tagger:            tagger,
-		Syncer:            kubectl.NewSyncer(runCtx.Namespaces),
+		Syncer:            getSyncer(runCtx),
 		Watcher:           watch.NewWatcher(trigger),
 		labellers:         labellers,
+		defaultLabeller:   defaultLabeller,
+		sessionLabeller:   sessionLabeller,
 		imageList:         kubernetes.NewImageList(),
+		logSource:         logSource,
 		cache:             artifactCache,
 		runCtx:            runCtx,
 		RPCServerShutdown: shutdown,
 	}, nil
 }
 
+// loadEnvFile makes the values of the env file available to templates.
+// The --env-file flag takes precedence over the config's envFile.
+func loadEnvFile(opts *config.SkaffoldOptions, cfg *latest.SkaffoldConfig) error {
+	path := opts.EnvFile
+	if path == "" {
+		path = cfg.EnvFile
+	}
+	if path == "" {
+		util.SetEnvFileValues(nil)
+		return nil
+	}
+
+	values, err := util.ParseEnvFile(path)
+	if err != nil {
+		return errors.Wrapf(err, "loading env file %s", path)
+	}
+	util.SetEnvFileValues(values)
+	return nil
+}
+
 func getBuilder(runCtx *runcontext.RunContext) (build.Builder, error) {
 	switch {
 	case runCtx.Cfg.Build.LocalBuild != nil:
@@ -159,11 +219,28 @@ func getDeployer(runCtx *runcontext.RunContext) (deploy.Deployer, error) {
 	case runCtx.Cfg.Deploy.KustomizeDeploy != nil:
 		return deploy.NewKustomizeDeployer(runCtx), nil
 
+	case runCtx.Cfg.Deploy.CloudRunDeploy != nil:
+		return deploy.NewCloudRunDeployer(runCtx), nil
+
+	case runCtx.Cfg.Deploy.DockerComposeDeploy != nil:
+		return deploy.NewDockerComposeDeployer(runCtx), nil
+
+	case runCtx.Cfg.Deploy.PluginDeploy != nil:
+		return deploy.NewPluginDeployer(runCtx), nil
+
 	default:
 		return nil, fmt.Errorf("unknown deployer for config %+v", runCtx.Cfg.Deploy)
 	}
 }
 
+// getSyncer returns a syncer that copies files to the containers started by the deployer.
+func getSyncer(runCtx *runcontext.RunContext) sync.Syncer {
+	if runCtx.Cfg.Deploy.DockerComposeDeploy != nil {
+		return docker.NewSyncer()
+	}
+	return kubectl.NewSyncer(runCtx.Namespaces)
+}
+
 func getTagger(t latest.TagPolicy, customTag string) (tag.Tagger, error) {
 	switch {
 	case customTag != "":
@@ -203,9 +280,10 @@ type tagErr struct {
 	err error
 }
 
-// imageTags generates tags for a list of artifacts
-func (r *SkaffoldRunner) imageTags(ctx context.Context, out io.Writer, artifacts []*latest.Artifact) (tag.ImageTags, error) {
+// ImageTags generates tags for a list of artifacts, with the default repo already applied
+func (r *SkaffoldRunner) ImageTags(ctx context.Context, out io.Writer, artifacts []*latest.Artifact) (tag.ImageTags, error) {
 	start := time.Now()
+	out = output.Headlines(out)
 	color.Default.Fprintln(out, "Generating tags...")
 
 	tagErrs := make([]chan tagErr, len(artifacts))
@@ -260,6 +338,9 @@ func (r *SkaffoldRunner) buildTestDeploy(ctx context.Context, out io.Writer, art
 
 	// Make sure all artifacts are redeployed. Not only those that were just built.
 	r.builds = build.MergeWithPreviousBuilds(bRes, r.builds)
+	if r.reverseSyncer != nil {
+		r.reverseSyncer.SetBuilds(r.builds)
+	}
 
 	if err := r.deploy(ctx, out, r.builds); err != nil {
 		return errors.Wrap(err, "deploy failed")
@@ -41,6 +41,7 @@ type Actions struct {
 	Synced   []string
 	Tested   []string
 	Deployed []string
+	Rendered []string
 }
 
 type TestBench struct {
@@ -117,6 +118,11 @@ func (t *TestBench) Test(ctx context.Context, out io.Writer, artifacts []build.A
 	return nil
 }
 
+func (t *TestBench) Render(ctx context.Context, out io.Writer, artifacts []build.Artifact, labellers []deploy.Labeller) error {
+	t.currentActions.Rendered = findTags(artifacts)
+	return nil
+}
+
 func (t *TestBench) Deploy(ctx context.Context, out io.Writer, artifacts []build.Artifact, labellers []deploy.Labeller) error {
 	if len(t.deployErrors) > 0 {
 		err := t.deployErrors[0]
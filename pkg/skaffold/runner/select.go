@@ -0,0 +1,83 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/pkg/errors"
+)
+
+// SetPreBuiltImages sets the images to deploy for the artifacts that
+// are not selected with --build-image.
+func (r *SkaffoldRunner) SetPreBuiltImages(builds []build.Artifact) {
+	r.preBuiltImages = builds
+}
+
+// resolveUnselected finds the images of the artifacts that are not selected with --build-image,
+// and returns them along with the artifacts that still need to be built.
+// Images given with --images are used first, then images found in the artifact cache.
+func (r *SkaffoldRunner) resolveUnselected(ctx context.Context, out io.Writer, artifacts []*latest.Artifact) ([]build.Artifact, []*latest.Artifact, error) {
+	var (
+		builds   []build.Artifact
+		lookup   []*latest.Artifact
+		selected []*latest.Artifact
+	)
+
+	for _, a := range artifacts {
+		switch {
+		case r.runCtx.Opts.IsBuildImage(a):
+			selected = append(selected, a)
+		case findBuild(r.preBuiltImages, a.ImageName) != nil:
+			builds = append(builds, *findBuild(r.preBuiltImages, a.ImageName))
+		default:
+			lookup = append(lookup, a)
+		}
+	}
+
+	if len(lookup) == 0 {
+		return builds, selected, nil
+	}
+
+	notFound, cached, err := r.cache.RetrieveCachedArtifacts(ctx, out, lookup)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "retrieving cached artifacts")
+	}
+	if len(notFound) > 0 {
+		var names []string
+		for _, a := range notFound {
+			names = append(names, a.ImageName)
+		}
+		return nil, nil, fmt.Errorf("no image to deploy for %s: not selected with --build-image, given with --images or found in the artifact cache", strings.Join(names, ", "))
+	}
+
+	return append(builds, cached...), selected, nil
+}
+
+func findBuild(builds []build.Artifact, imageName string) *build.Artifact {
+	for i := range builds {
+		if builds[i].ImageName == imageName {
+			return &builds[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/errcode"
+	"github.com/pkg/errors"
+)
+
+// withTimeout runs a phase of the pipeline and gives up after the given timeout, if set.
+func withTimeout(ctx context.Context, phase string, timeout time.Duration, run func(context.Context) error) error {
+	if timeout <= 0 {
+		return run(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := run(ctx)
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		return errcode.WithCode(errcode.Timeout, errors.Wrapf(err, "%s timed out after %v", phase, timeout))
+	}
+	return err
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/errcode"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	"github.com/pkg/errors"
+)
+
+func TestWithTimeout(t *testing.T) {
+	hangs := func(ctx context.Context) error {
+		<-ctx.Done()
+		return errors.Wrap(ctx.Err(), "installing chart")
+	}
+	fails := func(context.Context) error {
+		return errors.New("failure")
+	}
+
+	err := withTimeout(context.Background(), "deploy", 10*time.Millisecond, hangs)
+	testutil.CheckErrorContains(t, "deploy timed out after 10ms: installing chart", err)
+	testutil.CheckDeepEqual(t, errcode.Timeout, errcode.CodeOf(err))
+
+	err = withTimeout(context.Background(), "deploy", time.Minute, fails)
+	testutil.CheckErrorContains(t, "failure", err)
+	testutil.CheckDeepEqual(t, errcode.Unknown, errcode.CodeOf(err))
+
+	err = withTimeout(context.Background(), "deploy", 0, func(context.Context) error { return nil })
+	testutil.CheckError(t, false, err)
+}
@@ -27,11 +27,14 @@ import (
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/tag"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/deploy"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/output"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/test"
 )
 
 // WithTimings creates a deployer that logs the duration of each phase.
+// Unless in verbose mode, the output of the testers and deployers is only
+// printed if they fail. The builds are handled per artifact, by the builders.
 func WithTimings(b build.Builder, t test.Tester, d deploy.Deployer, cacheArtifacts bool) (build.Builder, test.Tester, deploy.Deployer) {
 	w := withTimings{
 		Builder:        b,
@@ -59,64 +62,73 @@ func (w withTimings) Build(ctx context.Context, out io.Writer, tags tag.ImageTag
 		return nil, nil
 	}
 	start := time.Now()
-	color.Default.Fprintln(out, "Starting build...")
+	color.Default.Fprintln(output.Headlines(out), "Starting build...")
 
 	bRes, err := w.Builder.Build(ctx, out, tags, artifacts)
 	if err != nil {
 		return nil, err
 	}
 
-	color.Default.Fprintln(out, "Build complete in", time.Since(start))
+	color.Default.Fprintln(output.Headlines(out), "Build complete in", time.Since(start))
 	return bRes, nil
 }
 
 func (w withTimings) Test(ctx context.Context, out io.Writer, builds []build.Artifact) error {
 	start := time.Now()
-	color.Default.Fprintln(out, "Starting test...")
+	color.Default.Fprintln(output.Headlines(out), "Starting test...")
 
-	err := w.Tester.Test(ctx, out, builds)
+	stepOut, finish := output.Step(out)
+	err := w.Tester.Test(ctx, stepOut, builds)
+	finish(err)
 	if err != nil {
 		return err
 	}
 
-	color.Default.Fprintln(out, "Test complete in", time.Since(start))
+	color.Default.Fprintln(output.Headlines(out), "Test complete in", time.Since(start))
 	return nil
 }
 
 func (w withTimings) Deploy(ctx context.Context, out io.Writer, builds []build.Artifact, labellers []deploy.Labeller) error {
 	start := time.Now()
-	color.Default.Fprintln(out, "Starting deploy...")
+	color.Default.Fprintln(output.Headlines(out), "Starting deploy...")
 
-	if err := w.Deployer.Deploy(ctx, out, builds, labellers); err != nil {
+	stepOut, finish := output.Step(out)
+	err := w.Deployer.Deploy(ctx, stepOut, builds, labellers)
+	finish(err)
+	if err != nil {
 		return err
 	}
 
-	color.Default.Fprintln(out, "Deploy complete in", time.Since(start))
+	color.Default.Fprintln(output.Headlines(out), "Deploy complete in", time.Since(start))
 	return nil
 }
 
 func (w withTimings) Cleanup(ctx context.Context, out io.Writer) error {
 	start := time.Now()
-	color.Default.Fprintln(out, "Cleaning up...")
+	color.Default.Fprintln(output.Headlines(out), "Cleaning up...")
 
-	err := w.Deployer.Cleanup(ctx, out)
+	stepOut, finish := output.Step(out)
+	err := w.Deployer.Cleanup(ctx, stepOut)
+	finish(err)
 	if err != nil {
 		return err
 	}
 
-	color.Default.Fprintln(out, "Cleanup complete in", time.Since(start))
+	color.Default.Fprintln(output.Headlines(out), "Cleanup complete in", time.Since(start))
 	return nil
 }
 
 func (w withTimings) Prune(ctx context.Context, out io.Writer) error {
 	start := time.Now()
-	color.Default.Fprintln(out, "Pruning images...")
+	color.Default.Fprintln(output.Headlines(out), "Pruning images...")
 
-	err := w.Builder.Prune(ctx, out)
+	stepOut, finish := output.Step(out)
+	err := w.Builder.Prune(ctx, stepOut)
+	finish(err)
 	if err != nil {
 		return err
 	}
 
-	color.Default.Fprintln(out, "Image prune complete in", time.Since(start))
+	color.Default.Fprintln(output.Headlines(out), "Image prune complete in", time.Since(start))
 	return nil
 }
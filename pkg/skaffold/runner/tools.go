@@ -0,0 +1,102 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+)
+
+// versionArgs are the arguments that make each tool print its version.
+var versionArgs = map[string][]string{
+	"kubectl":   {"version", "--client", "-ojson"},
+	"helm":      {"version", "--client", "--short"},
+	"kustomize": {"version"},
+}
+
+var semverRegex = regexp.MustCompile(`\d+\.\d+\.\d+`)
+
+// setUpTools configures the binaries of the tools, and checks that their versions
+// are within the configured bounds.
+func setUpTools(ctx context.Context, cfg *latest.ToolsConfig) error {
+	tools := map[string]*latest.Tool{}
+	if cfg != nil {
+		tools["kubectl"] = cfg.Kubectl
+		tools["helm"] = cfg.Helm
+		tools["kustomize"] = cfg.Kustomize
+	}
+
+	for _, name := range []string{"kubectl", "helm", "kustomize"} {
+		tool := tools[name]
+		if tool == nil {
+			util.SetToolPath(name, "")
+			continue
+		}
+
+		util.SetToolPath(name, tool.Path)
+		if err := checkToolVersion(ctx, name, tool); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkToolVersion checks that a tool's version satisfies its bounds.
+func checkToolVersion(ctx context.Context, name string, tool *latest.Tool) error {
+	if tool.MinVersion == "" && tool.MaxVersion == "" {
+		return nil
+	}
+
+	var bounds []string
+	if tool.MinVersion != "" {
+		bounds = append(bounds, ">="+tool.MinVersion)
+	}
+	if tool.MaxVersion != "" {
+		bounds = append(bounds, "<="+tool.MaxVersion)
+	}
+
+	expected, err := semver.ParseRange(strings.Join(bounds, " "))
+	if err != nil {
+		return errors.Wrapf(err, "invalid versions for %s", name)
+	}
+
+	path := util.ToolPath(name)
+	out, err := util.RunCmdOut(exec.CommandContext(ctx, path, versionArgs[name]...))
+	if err != nil {
+		return errors.Wrapf(err, "getting the version of %s", path)
+	}
+
+	found := semverRegex.FindString(string(out))
+	version, err := semver.Parse(found)
+	if err != nil {
+		return fmt.Errorf("unable to parse the version of %s: %q", path, strings.TrimSpace(string(out)))
+	}
+
+	if !expected(version) {
+		return fmt.Errorf("%s is version %s, but the pipeline requires %s", path, version, strings.Join(bounds, " and "))
+	}
+	return nil
+}
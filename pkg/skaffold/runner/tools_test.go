@@ -0,0 +1,112 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestSetUpTools(t *testing.T) {
+	var tests = []struct {
+		description  string
+		tools        *latest.ToolsConfig
+		command      *testutil.FakeCmd
+		expectedHelm string
+		shouldErr    bool
+	}{
+		{
+			description:  "no configuration",
+			command:      testutil.NewFakeCmd(t),
+			expectedHelm: "helm",
+		},
+		{
+			description: "path without versions",
+			tools: &latest.ToolsConfig{
+				Helm: &latest.Tool{Path: "/opt/helm3/helm"},
+			},
+			command:      testutil.NewFakeCmd(t),
+			expectedHelm: "/opt/helm3/helm",
+		},
+		{
+			description: "version within bounds",
+			tools: &latest.ToolsConfig{
+				Helm: &latest.Tool{Path: "/opt/helm3/helm", MinVersion: "3.2.0", MaxVersion: "3.5.4"},
+			},
+			command:      testutil.FakeRunOut(t, "/opt/helm3/helm version --client --short", "v3.2.1+gfe51cd1\n"),
+			expectedHelm: "/opt/helm3/helm",
+		},
+		{
+			description: "version too old",
+			tools: &latest.ToolsConfig{
+				Helm: &latest.Tool{MinVersion: "3.0.0"},
+			},
+			command:   testutil.FakeRunOut(t, "helm version --client --short", "Client: v2.16.1+gbbdfe5e\n"),
+			shouldErr: true,
+		},
+		{
+			description: "version too recent",
+			tools: &latest.ToolsConfig{
+				Kubectl: &latest.Tool{MaxVersion: "1.18.99"},
+			},
+			command:   testutil.FakeRunOut(t, "kubectl version --client -ojson", `{"clientVersion":{"major":"1","minor":"19","gitVersion":"v1.19.0"}}`),
+			shouldErr: true,
+		},
+		{
+			description: "kustomize",
+			tools: &latest.ToolsConfig{
+				Kustomize: &latest.Tool{MinVersion: "3.0.0"},
+			},
+			command:      testutil.FakeRunOut(t, "kustomize version", "{Version:kustomize/v3.5.4 GitCommit:3af514fa9f85430f0c1557c4a0291e62112ab026}\n"),
+			expectedHelm: "helm",
+		},
+		{
+			description: "invalid version bounds",
+			tools: &latest.ToolsConfig{
+				Helm: &latest.Tool{MinVersion: "three"},
+			},
+			command:   testutil.NewFakeCmd(t),
+			shouldErr: true,
+		},
+		{
+			description: "unknown version",
+			tools: &latest.ToolsConfig{
+				Helm: &latest.Tool{MinVersion: "3.0.0"},
+			},
+			command:   testutil.FakeRunOut(t, "helm version --client --short", "dev build\n"),
+			shouldErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			reset := testutil.Override(t, &util.DefaultExecCommand, test.command)
+			defer reset()
+			defer util.SetToolPath("helm", "")
+
+			err := setUpTools(context.Background(), test.tools)
+
+			testutil.CheckError(t, test.shouldErr, err)
+			if !test.shouldErr {
+				testutil.CheckDeepEqual(t, test.expectedHelm, util.ToolPath("helm"))
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/server"
+	"github.com/sirupsen/logrus"
+)
+
+// devLock prevents the rebuilds requested from the web UI from running concurrently with the dev loop.
+type devLock struct {
+	sync.Mutex
+}
+
+// handleUIRebuilds rebuilds, tests and deploys the artifacts whose rebuild is requested from the web UI.
+func (r *SkaffoldRunner) handleUIRebuilds(ctx context.Context, out io.Writer, artifacts []*latest.Artifact, logger *kubernetes.LogAggregator, lock *devLock) {
+	server.OnRebuild(func(imageName string) error {
+		var artifact *latest.Artifact
+		for _, a := range artifacts {
+			if a.ImageName == imageName {
+				artifact = a
+			}
+		}
+		if artifact == nil {
+			return fmt.Errorf("unknown artifact %q", imageName)
+		}
+
+		go func() {
+			lock.Lock()
+			defer lock.Unlock()
+
+			logger.Mute()
+			defer logger.Unmute()
+
+			if err := r.buildTestDeploy(ctx, out, []*latest.Artifact{artifact}); err != nil {
+				logrus.Warnln("Skipping deploy due to error:", err)
+			}
+		}()
+		return nil
+	})
+}
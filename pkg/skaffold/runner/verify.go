@@ -0,0 +1,34 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"io"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/errcode"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/verify"
+)
+
+// Verify runs the verification tests against deployed artifacts.
+func (r *SkaffoldRunner) Verify(ctx context.Context, out io.Writer, artifacts []build.Artifact) error {
+	if err := verify.Verify(ctx, out, r.runCtx, artifacts); err != nil {
+		return failed(errcode.VerifyFailed, err)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/docker"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/errcode"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/output"
+	"github.com/sirupsen/logrus"
+)
+
+// For testing
+var (
+	imageAvailable = func(image string, insecureRegistries map[string]bool) error {
+		_, err := docker.RemoteDigest(image, insecureRegistries)
+		return err
+	}
+	imagePollPeriod = time.Second
+)
+
+// WaitForImages waits, for at most `--wait-for-images`, until the pushed images can be pulled
+// from their registries. Some registries replicate the images they receive with a lag, which
+// makes the pods fail to pull them right after the push. Images that are not referenced by
+// digest were not pushed and are skipped.
+// The registries are queried from this machine, which may not see the same replica as the cluster.
+func (r *SkaffoldRunner) WaitForImages(ctx context.Context, out io.Writer, artifacts []build.Artifact) error {
+	timeout := r.runCtx.Opts.WaitForImages
+	if timeout <= 0 {
+		return nil
+	}
+	if r.runCtx.Opts.Offline {
+		logrus.Warnln("--wait-for-images is ignored in offline mode: registries can't be reached")
+		return nil
+	}
+
+	var images []string
+	for _, a := range artifacts {
+		parsed, err := docker.ParseReference(a.Tag)
+		if err != nil || parsed.Digest == "" {
+			continue
+		}
+		images = append(images, a.Tag)
+	}
+	if len(images) == 0 {
+		return nil
+	}
+
+	color.Default.Fprintf(output.Headlines(out), "Waiting for %d pushed images to be available...\n", len(images))
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for _, image := range images {
+		if err := waitForImage(waitCtx, image, r.runCtx.InsecureRegistries); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return failed(errcode.Timeout, fmt.Errorf("%s isn't available after %v: %v", image, timeout, err))
+		}
+	}
+
+	return nil
+}
+
+func waitForImage(ctx context.Context, image string, insecureRegistries map[string]bool) error {
+	for {
+		err := imageAvailable(image, insecureRegistries)
+		if err == nil {
+			return nil
+		}
+		logrus.Debugf("%s isn't available yet: %s", image, err)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(imagePollPeriod):
+		}
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/config"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/errcode"
+	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+const pushedImage = "gcr.io/project/app:v1@sha256:3ad4b04e8f6a5e7fd6b6ec0cf81f0c9d4ef4a2f1260ea8da52dbd3d03e8b5b3b"
+
+func TestWaitForImages(t *testing.T) {
+	var tests = []struct {
+		description   string
+		waitForImages time.Duration
+		offline       bool
+		artifacts     []build.Artifact
+		availableIn   int
+		expectedCalls int
+		shouldErr     bool
+	}{
+		{
+			description:   "disabled",
+			artifacts:     []build.Artifact{{ImageName: "app", Tag: pushedImage}},
+			expectedCalls: 0,
+		},
+		{
+			description:   "available right away",
+			waitForImages: time.Minute,
+			artifacts:     []build.Artifact{{ImageName: "app", Tag: pushedImage}},
+			expectedCalls: 1,
+		},
+		{
+			description:   "replication lag",
+			waitForImages: time.Minute,
+			artifacts:     []build.Artifact{{ImageName: "app", Tag: pushedImage}},
+			availableIn:   3,
+			expectedCalls: 3,
+		},
+		{
+			description:   "offline",
+			waitForImages: time.Minute,
+			offline:       true,
+			artifacts:     []build.Artifact{{ImageName: "app", Tag: pushedImage}},
+			expectedCalls: 0,
+		},
+		{
+			description:   "images that were not pushed",
+			waitForImages: time.Minute,
+			artifacts:     []build.Artifact{{ImageName: "app", Tag: "app:3ad4b04e8f6a"}},
+			expectedCalls: 0,
+		},
+		{
+			description:   "never available",
+			waitForImages: 50 * time.Millisecond,
+			artifacts:     []build.Artifact{{ImageName: "app", Tag: pushedImage}},
+			availableIn:   1000,
+			shouldErr:     true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			calls := 0
+			reset := testutil.Override(t, &imageAvailable, func(string, map[string]bool) error {
+				calls++
+				if calls < test.availableIn {
+					return errors.New("MANIFEST_UNKNOWN")
+				}
+				return nil
+			})
+			defer reset()
+			defer testutil.Override(t, &imagePollPeriod, time.Millisecond)()
+
+			r := &SkaffoldRunner{
+				runCtx: &runcontext.RunContext{
+					Opts: &config.SkaffoldOptions{WaitForImages: test.waitForImages, Offline: test.offline},
+				},
+			}
+
+			err := r.WaitForImages(context.Background(), &bytes.Buffer{}, test.artifacts)
+
+			testutil.CheckError(t, test.shouldErr, err)
+			if test.shouldErr {
+				testutil.CheckDeepEqual(t, errcode.Timeout, errcode.CodeOf(err))
+			} else {
+				testutil.CheckDeepEqual(t, test.expectedCalls, calls)
+			}
+		})
+	}
+}
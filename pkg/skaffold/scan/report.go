@@ -0,0 +1,73 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scan
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// vulnerability is a vulnerability found by Trivy.
+type vulnerability struct {
+	VulnerabilityID  string `json:"VulnerabilityID"`
+	PkgName          string `json:"PkgName"`
+	InstalledVersion string `json:"InstalledVersion"`
+	FixedVersion     string `json:"FixedVersion"`
+	Severity         string `json:"Severity"`
+}
+
+func (v vulnerability) String() string {
+	s := fmt.Sprintf("%s (%s) in %s %s", v.VulnerabilityID, normalizeSeverity(v.Severity), v.PkgName, v.InstalledVersion)
+	if v.FixedVersion != "" {
+		s += fmt.Sprintf(", fixed in %s", v.FixedVersion)
+	}
+	return s
+}
+
+type result struct {
+	Target          string          `json:"Target"`
+	Vulnerabilities []vulnerability `json:"Vulnerabilities"`
+}
+
+// parseReport reads the vulnerabilities of a Trivy JSON report.
+// Older versions of Trivy print the list of results, newer
+// versions print an object with the results.
+func parseReport(buf []byte) ([]vulnerability, error) {
+	var results []result
+
+	buf = bytes.TrimSpace(buf)
+	if bytes.HasPrefix(buf, []byte("[")) {
+		if err := json.Unmarshal(buf, &results); err != nil {
+			return nil, err
+		}
+	} else {
+		var report struct {
+			Results []result `json:"Results"`
+		}
+		if err := json.Unmarshal(buf, &report); err != nil {
+			return nil, err
+		}
+		results = report.Results
+	}
+
+	var vulnerabilities []vulnerability
+	for _, r := range results {
+		vulnerabilities = append(vulnerabilities, r.Vulnerabilities...)
+	}
+	return vulnerabilities, nil
+}
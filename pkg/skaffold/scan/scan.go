@@ -0,0 +1,185 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scan
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultFailOn = "CRITICAL"
+	defaultWarnOn = "HIGH"
+
+	// never is the threshold that no vulnerability reaches.
+	never = "NONE"
+)
+
+// severities are ordered from the lowest to the highest.
+var severities = []string{"UNKNOWN", "LOW", "MEDIUM", "HIGH", "CRITICAL"}
+
+var (
+	// For testing
+	runScanner = runScannerCommand
+)
+
+// Scan scans the built images of the artifacts that configure a scan, one
+// after the other, and reports the results through the events API.
+// It fails if any image has vulnerabilities at or above the `failOn` severity.
+func Scan(ctx context.Context, out io.Writer, workingDir string, artifacts []*latest.Artifact, bRes []build.Artifact) error {
+	configs := map[string]*latest.ScanConfig{}
+	for _, a := range artifacts {
+		if a.Scan != nil {
+			configs[a.ImageName] = a.Scan
+		}
+	}
+
+	var scanned bool
+	start := time.Now()
+	for _, res := range bRes {
+		cfg, ok := configs[res.ImageName]
+		if !ok {
+			continue
+		}
+
+		if !scanned {
+			color.Default.Fprintln(out, "Scanning images for vulnerabilities...")
+			scanned = true
+		}
+		if err := scanImage(ctx, out, workingDir, cfg, res); err != nil {
+			return err
+		}
+	}
+
+	if scanned {
+		color.Default.Fprintln(out, "Scan complete in", time.Since(start))
+	}
+	return nil
+}
+
+func scanImage(ctx context.Context, out io.Writer, workingDir string, cfg *latest.ScanConfig, res build.Artifact) error {
+	event.ScanInProgress(res.ImageName)
+	color.Default.Fprintf(out, " - %s\n", res.Tag)
+
+	failOn, err := severityIndex(cfg.FailOn, defaultFailOn)
+	if err != nil {
+		event.ScanFailed(res.ImageName, nil, err)
+		return errors.Wrapf(err, "scanning %s", res.ImageName)
+	}
+	warnOn, err := severityIndex(cfg.WarnOn, defaultWarnOn)
+	if err != nil {
+		event.ScanFailed(res.ImageName, nil, err)
+		return errors.Wrapf(err, "scanning %s", res.ImageName)
+	}
+
+	buf, err := runScanner(ctx, workingDir, cfg.Command, res.Tag)
+	if err != nil {
+		event.ScanFailed(res.ImageName, nil, err)
+		return errors.Wrapf(err, "scanning %s", res.ImageName)
+	}
+
+	vulnerabilities, err := parseReport(buf)
+	if err != nil {
+		event.ScanFailed(res.ImageName, nil, err)
+		return errors.Wrapf(err, "reading the scan report of %s", res.ImageName)
+	}
+
+	counts := map[string]int32{}
+	var failures int
+	for _, v := range vulnerabilities {
+		severity := normalizeSeverity(v.Severity)
+		counts[severity]++
+
+		switch index := indexOf(severity); {
+		case index >= failOn:
+			failures++
+			color.Red.Fprintf(out, "   %s\n", v)
+		case index >= warnOn:
+			color.Yellow.Fprintf(out, "   %s\n", v)
+		}
+	}
+
+	if failures > 0 {
+		err := fmt.Errorf("%s has %d vulnerabilities of severity %s or higher", res.Tag, failures, severities[failOn])
+		event.ScanFailed(res.ImageName, counts, err)
+		return err
+	}
+
+	event.ScanComplete(res.ImageName, counts)
+	return nil
+}
+
+// runScannerCommand runs trivy, or the configured scanner, and returns its JSON report.
+func runScannerCommand(ctx context.Context, workingDir, command, image string) ([]byte, error) {
+	var cmd *exec.Cmd
+	if command == "" {
+		cmd = exec.CommandContext(ctx, "trivy", "image", "--format", "json", "--quiet", image)
+	} else {
+		cmd = util.ShellCommand(ctx, command)
+		cmd.Env = append(util.OSEnviron(), fmt.Sprintf("%s=%s", constants.Image, image))
+	}
+	cmd.Dir = workingDir
+
+	return util.RunCmdOut(cmd)
+}
+
+// severityIndex returns the position of a threshold in the list of severities.
+// `NONE` is after all the severities.
+func severityIndex(severity, defaultSeverity string) (int, error) {
+	if severity == "" {
+		severity = defaultSeverity
+	}
+
+	severity = strings.ToUpper(severity)
+	if severity == never {
+		return len(severities), nil
+	}
+	if index := indexOf(severity); index >= 0 {
+		return index, nil
+	}
+
+	return 0, fmt.Errorf("unknown severity %s, should be one of %s or %s", severity, strings.Join(severities, ", "), never)
+}
+
+func normalizeSeverity(severity string) string {
+	severity = strings.ToUpper(severity)
+	if indexOf(severity) < 0 {
+		return severities[0]
+	}
+	return severity
+}
+
+func indexOf(severity string) int {
+	for i, s := range severities {
+		if s == severity {
+			return i
+		}
+	}
+	return -1
+}
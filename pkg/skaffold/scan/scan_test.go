@@ -0,0 +1,154 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scan
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+const report = `{
+  "SchemaVersion": 2,
+  "Results": [{
+    "Target": "img:123 (alpine 3.9)",
+    "Vulnerabilities": [
+      {"VulnerabilityID": "CVE-1", "PkgName": "openssl", "InstalledVersion": "1.1.1a", "FixedVersion": "1.1.1b", "Severity": "HIGH"},
+      {"VulnerabilityID": "CVE-2", "PkgName": "musl", "InstalledVersion": "1.1.20", "Severity": "LOW"}
+    ]
+  }]
+}`
+
+const criticalReport = `[{
+  "Target": "img:123 (alpine 3.9)",
+  "Vulnerabilities": [
+    {"VulnerabilityID": "CVE-3", "PkgName": "zlib", "InstalledVersion": "1.2.11", "Severity": "CRITICAL"}
+  ]
+}]`
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		description string
+		scan        *latest.ScanConfig
+		report      string
+		scanErr     error
+		expectedOut string
+		shouldErr   bool
+	}{
+		{
+			description: "no scan",
+		},
+		{
+			description: "warnings only",
+			scan:        &latest.ScanConfig{},
+			report:      report,
+			expectedOut: "Scanning images for vulnerabilities...\n - img:123\n   CVE-1 (HIGH) in openssl 1.1.1a, fixed in 1.1.1b\n",
+		},
+		{
+			description: "critical vulnerability",
+			scan:        &latest.ScanConfig{},
+			report:      criticalReport,
+			expectedOut: "Scanning images for vulnerabilities...\n - img:123\n   CVE-3 (CRITICAL) in zlib 1.2.11\n",
+			shouldErr:   true,
+		},
+		{
+			description: "lower thresholds",
+			scan:        &latest.ScanConfig{FailOn: "high", WarnOn: "low"},
+			report:      report,
+			expectedOut: "Scanning images for vulnerabilities...\n - img:123\n   CVE-1 (HIGH) in openssl 1.1.1a, fixed in 1.1.1b\n   CVE-2 (LOW) in musl 1.1.20\n",
+			shouldErr:   true,
+		},
+		{
+			description: "never fail",
+			scan:        &latest.ScanConfig{FailOn: "NONE", WarnOn: "NONE"},
+			report:      criticalReport,
+			expectedOut: "Scanning images for vulnerabilities...\n - img:123\nScan complete in",
+		},
+		{
+			description: "invalid severity",
+			scan:        &latest.ScanConfig{FailOn: "SEVERE"},
+			shouldErr:   true,
+		},
+		{
+			description: "scanner error",
+			scan:        &latest.ScanConfig{},
+			scanErr:     errors.New("trivy not found"),
+			shouldErr:   true,
+		},
+		{
+			description: "invalid report",
+			scan:        &latest.ScanConfig{},
+			report:      "not json",
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			event.InitializeState(&runcontext.RunContext{
+				Cfg: &latest.Pipeline{},
+			})
+			reset := testutil.Override(t, &runScanner, func(context.Context, string, string, string) ([]byte, error) {
+				return []byte(test.report), test.scanErr
+			})
+			defer reset()
+
+			artifacts := []*latest.Artifact{{ImageName: "img", Scan: test.scan}}
+			bRes := []build.Artifact{{ImageName: "img", Tag: "img:123"}}
+
+			var out bytes.Buffer
+			err := Scan(context.Background(), &out, ".", artifacts, bRes)
+
+			testutil.CheckError(t, test.shouldErr, err)
+			if !bytes.HasPrefix(out.Bytes(), []byte(test.expectedOut)) {
+				t.Errorf("expected output to start with %q, got %q", test.expectedOut, out.String())
+			}
+		})
+	}
+}
+
+func TestRunScannerCommand(t *testing.T) {
+	reset := testutil.Override(t, &util.DefaultExecCommand, testutil.NewFakeCmd(t).
+		WithRunOut("trivy image --format json --quiet img:123", report).
+		WithRunOut(strings.Join(util.ShellCommand(context.Background(), "grype -o json $IMAGE | convert").Args, " "), criticalReport))
+	defer reset()
+
+	buf, err := runScannerCommand(context.Background(), ".", "", "img:123")
+	testutil.CheckErrorAndDeepEqual(t, false, err, report, string(buf))
+
+	buf, err = runScannerCommand(context.Background(), ".", "grype -o json $IMAGE | convert", "img:123")
+	testutil.CheckErrorAndDeepEqual(t, false, err, criticalReport, string(buf))
+}
+
+func TestParseReport(t *testing.T) {
+	vulnerabilities, err := parseReport([]byte(report))
+	testutil.CheckErrorAndDeepEqual(t, false, err, 2, len(vulnerabilities))
+
+	vulnerabilities, err = parseReport([]byte(criticalReport))
+	testutil.CheckErrorAndDeepEqual(t, false, err, []vulnerability{{VulnerabilityID: "CVE-3", PkgName: "zlib", InstalledVersion: "1.2.11", Severity: "CRITICAL"}}, vulnerabilities)
+
+	vulnerabilities, err = parseReport([]byte(`{"Results": [{"Target": "clean"}]}`))
+	testutil.CheckErrorAndDeepEqual(t, false, err, 0, len(vulnerabilities))
+}
@@ -65,6 +65,7 @@ func Set(c *latest.SkaffoldConfig) error {
 		setDefaultWorkspace(a)
 		defaultToDockerArtifact(a)
 		setDefaultDockerfile(a)
+		setDefaultRemoteDevDest(a)
 	}
 
 	return nil
@@ -151,6 +152,12 @@ func SetDefaultDockerArtifact(a *latest.DockerArtifact) {
 	a.DockerfilePath = valueOrDefault(a.DockerfilePath, constants.DefaultDockerfilePath)
 }
 
+func setDefaultRemoteDevDest(a *latest.Artifact) {
+	if a.RemoteDev != nil {
+		a.RemoteDev.Dest = valueOrDefault(a.RemoteDev.Dest, constants.DefaultRemoteDevDest)
+	}
+}
+
 func setDefaultWorkspace(a *latest.Artifact) {
 	a.Workspace = valueOrDefault(a.Workspace, ".")
 }
@@ -20,7 +20,7 @@ import (
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/util"
 )
 
-const Version string = "skaffold/v1beta11"
+const Version string = "skaffold/v1beta12"
 
 // NewSkaffoldConfig creates a SkaffoldConfig
 func NewSkaffoldConfig() util.VersionedConfig {
@@ -40,6 +40,20 @@ type SkaffoldConfig struct {
 
 	// Profiles *beta* can override be used to `build`, `test` or `deploy` configuration.
 	Profiles []Profile `yaml:"profiles,omitempty"`
+
+	// Settings *alpha* configures Skaffold for this project.
+	// They take precedence over the global Skaffold config, and can't be changed by profiles.
+	Settings *ProjectSettings `yaml:"settings,omitempty"`
+}
+
+// ProjectSettings *alpha* configures Skaffold for a project.
+type ProjectSettings struct {
+	// UpdateCheck can be set to `false` to never check for a newer version of Skaffold.
+	UpdateCheck *bool `yaml:"updateCheck,omitempty"`
+
+	// UpdateCheckURL is the URL of the file with the latest version of Skaffold, so that a mirror can serve it.
+	// For example: `https://mirror.example.com/skaffold/VERSION`.
+	UpdateCheckURL string `yaml:"updateCheckURL,omitempty"`
 }
 
 // Pipeline describes a Skaffold pipeline.
@@ -52,6 +66,61 @@ type Pipeline struct {
 
 	// Deploy describes how images are deployed.
 	Deploy DeployConfig `yaml:"deploy,omitempty"`
+
+	// Verify *alpha* lists the tests run against the deployed application,
+	// once the deployment has stabilized.
+	Verify []*VerifyTestCase `yaml:"verify,omitempty"`
+
+	// PortForward *alpha* configures the URLs of forwarded ports that `--open` opens in the browser.
+	PortForward []*PortForwardResource `yaml:"portForward,omitempty"`
+
+	// EnvFile is a file of `KEY=VALUE` pairs made available to templates, in addition to environment variables.
+	// It is meant for per-developer settings that are not committed.
+	// For example: `.skaffold.env`.
+	EnvFile string `yaml:"envFile,omitempty"`
+
+	// Tools *alpha* configures the binaries of the tools Skaffold runs, and the versions they can have.
+	Tools *ToolsConfig `yaml:"tools,omitempty"`
+}
+
+// ToolsConfig *alpha* configures the binaries of the tools Skaffold runs.
+type ToolsConfig struct {
+	// Kubectl configures the `kubectl` binary.
+	Kubectl *Tool `yaml:"kubectl,omitempty"`
+
+	// Helm configures the `helm` binary.
+	Helm *Tool `yaml:"helm,omitempty"`
+
+	// Kustomize configures the `kustomize` binary.
+	Kustomize *Tool `yaml:"kustomize,omitempty"`
+}
+
+// Tool *alpha* configures the binary of a tool. Skaffold checks its version before it runs.
+type Tool struct {
+	// Path is the path to the binary.
+	// Defaults to the name of the tool, looked up in the `PATH`.
+	Path string `yaml:"path,omitempty"`
+
+	// MinVersion is the oldest version of the tool that can be used.
+	// For example: `3.2.0`.
+	MinVersion string `yaml:"minVersion,omitempty"`
+
+	// MaxVersion is the most recent version of the tool that can be used.
+	// For example: `3.5.4`.
+	MaxVersion string `yaml:"maxVersion,omitempty"`
+}
+
+// PortForwardResource describes a forwarded port of a deployed resource.
+type PortForwardResource struct {
+	// ResourceName is the name of a pod, or of the workload that created the pods.
+	ResourceName string `yaml:"resourceName" yamltags:"required"`
+
+	// Port is the container port. Defaults to the first forwarded port of the pods.
+	Port int `yaml:"port,omitempty"`
+
+	// Open is the path opened in the browser, with `--open`, once the port is forwarded and the pod is ready.
+	// For example: `/admin`.
+	Open string `yaml:"open,omitempty"`
 }
 
 func (c *SkaffoldConfig) GetVersion() string {
@@ -67,6 +136,11 @@ type BuildConfig struct {
 	// These registries will be connected to via HTTP instead of HTTPS.
 	InsecureRegistries []string `yaml:"insecureRegistries,omitempty"`
 
+	// Labels are added to every built image. Values are templates that can reference
+	// environment variables and `{{.GIT_COMMIT}}`, `{{.BUILD_TIME}}` or `{{.SKAFFOLD_VERSION}}`.
+	// For example: `org.opencontainers.image.revision: "{{.GIT_COMMIT}}"`.
+	Labels map[string]string `yaml:"labels,omitempty"`
+
 	// TagPolicy *beta* determines how images are tagged.
 	// A few strategies are provided here, although you most likely won't need to care!
 	// If not specified, it defaults to `gitCommit: {variant: Tags}`.
@@ -156,6 +230,26 @@ type LocalBuild struct {
 
 	// UseBuildkit use BuildKit to build Docker images.
 	UseBuildkit bool `yaml:"useBuildkit,omitempty"`
+
+	// Reproducible sets the creation time of the images built from Dockerfiles, and
+	// the modification time of every file in their layers, to `$SOURCE_DATE_EPOCH`
+	// or the Unix epoch. Identical inputs then produce identical image digests.
+	Reproducible bool `yaml:"reproducible,omitempty"`
+
+	// Prune *alpha* configures how the images built by Skaffold are removed from the local Docker daemon.
+	// By default, the images built during a `skaffold dev` session are all removed when it exits.
+	Prune *PruneConfig `yaml:"prune,omitempty"`
+}
+
+// PruneConfig *alpha* configures how the images built by Skaffold are pruned.
+// Images with tags that don't belong to the artifact are never pruned.
+type PruneConfig struct {
+	// Keep is the number of most recent images kept for each artifact.
+	Keep int `yaml:"keep,omitempty"`
+
+	// AfterEachBuild prunes the images after each build of the dev loop,
+	// rather than only when Skaffold exits.
+	AfterEachBuild bool `yaml:"afterEachBuild,omitempty"`
 }
 
 // GoogleCloudBuild *beta* describes how to do a remote build on
@@ -196,6 +290,20 @@ type GoogleCloudBuild struct {
 	// See [Cloud Builders](https://cloud.google.com/cloud-build/docs/cloud-builders).
 	// Defaults to `gcr.io/cloud-builders/gradle`.
 	GradleImage string `yaml:"gradleImage,omitempty"`
+
+	// LogsBucket is the Cloud Storage bucket where build logs are written.
+	// Defaults to the bucket that sources are uploaded to, `<projectId>_cloudbuild`.
+	LogsBucket string `yaml:"logsBucket,omitempty"`
+
+	// Logging configures where build logs are stored, for example `GCS_ONLY` or `CLOUD_LOGGING_ONLY`.
+	// Skaffold can only stream the logs written to Cloud Storage.
+	// See [Cloud Build Reference](https://cloud.google.com/cloud-build/docs/api/reference/rest/v1/projects.builds#loggingmode).
+	Logging string `yaml:"logging,omitempty"`
+
+	// Substitutions are user-defined substitutions, whose names start with `_`,
+	// that build args can reference. For example: `_VERSION: "1.2"` and `VERSION: "$_VERSION"`.
+	// See [Cloud Build Reference](https://cloud.google.com/cloud-build/docs/configuring-builds/substitute-variable-values).
+	Substitutions map[string]string `yaml:"substitutions,omitempty"`
 }
 
 // LocalDir configures how Kaniko mounts sources directly via an `emptyDir` volume.
@@ -290,11 +398,242 @@ type TestCase struct {
 	// to run on that artifact.
 	// For example: `["./test/*"]`.
 	StructureTests []string `yaml:"structureTests,omitempty"`
+
+	// StructureTestsArgs are additional flags passed to `container-structure-test`
+	// when testing that artifact.
+	// For example: `["--pull", "--no-color"]`.
+	StructureTestsArgs []string `yaml:"structureTestsArgs,omitempty"`
+
+	// CustomTests *alpha* lists the commands to run against that artifact,
+	// between the build and the deploy.
+	CustomTests []CustomTest `yaml:"custom,omitempty"`
+
+	// ClusterTests *alpha* lists the commands to run inside the cluster,
+	// in a pod using that artifact's image, between the build and the deploy.
+	ClusterTests []ClusterTest `yaml:"cluster,omitempty"`
+}
+
+// ClusterTest *alpha* is a command run in a pod using a built image,
+// for tests that depend on the environment the application runs in.
+type ClusterTest struct {
+	// Command is the entrypoint of the test container.
+	// For example: `["go", "test", "./integration/..."]`.
+	Command []string `yaml:"command" yamltags:"required"`
+
+	// Namespace is the namespace where the test pod is created.
+	// Defaults to the namespace given with `--namespace`, or `default`.
+	Namespace string `yaml:"namespace,omitempty"`
+
+	// TimeoutSeconds is the maximum time to wait for the test to complete.
+	// Defaults to `600`.
+	TimeoutSeconds int `yaml:"timeoutSeconds,omitempty"`
+}
+
+// VerifyTestCase *alpha* is a container run in the cluster after a deployment,
+// for example to run end-to-end tests against the deployed application.
+type VerifyTestCase struct {
+	// Name identifies the test in the output and in the events API.
+	Name string `yaml:"name" yamltags:"required"`
+
+	// Image is the image of the test container. It can be one of the artifacts,
+	// in which case the image that was just built and deployed is used.
+	// For example: `gcr.io/k8s-skaffold/e2e`.
+	Image string `yaml:"image" yamltags:"required"`
+
+	// ClusterTest describes the command run in the test container.
+	ClusterTest `yaml:",inline"`
+}
+
+// CustomTest *alpha* is a command run against a built image,
+// for example to run integration smoke tests.
+type CustomTest struct {
+	// Command is the command to run. The fully qualified name of the image
+	// is available in the `$IMAGE` environment variable.
+	// For example: `./smoke-test.sh $IMAGE`.
+	Command string `yaml:"command" yamltags:"required"`
+
+	// Dependencies are the files used by the test.
+	// In dev mode, tests are run again when they change.
+	// For example: `["./smoke-test.sh", "./test/*"]`.
+	Dependencies []string `yaml:"dependencies,omitempty"`
 }
 
 // DeployConfig contains all the configuration needed by the deploy steps.
 type DeployConfig struct {
 	DeployType `yaml:",inline"`
+
+	// Namespace *alpha* is the namespace resources are deployed to, unless `--namespace` is set.
+	// It's a template that can reference environment variables and `{{.RUN_USER}}`.
+	// For example: `"{{.USER}}-dev"` gives every developer sharing a cluster their own namespace.
+	// The namespace is created if it doesn't exist.
+	Namespace string `yaml:"namespace,omitempty"`
+
+	// Labels are added to every deployed resource, along with `skaffold.dev/run-id`.
+	// Values are templates that can reference environment variables and `{{.RUN_ID}}`,
+	// `{{.RUN_USER}}`, `{{.GIT_COMMIT}}` or `{{.GIT_BRANCH}}`.
+	// For example: `owner: "{{.RUN_USER}}"`.
+	Labels map[string]string `yaml:"labels,omitempty"`
+
+	// Annotations are added to every deployed resource. Values are templates, like for `labels`.
+	// For example: `example.com/commit: "{{.GIT_COMMIT}}"`.
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+
+	// StatusCheck *alpha* configures how Skaffold waits for deployed resources to stabilize.
+	// Setting it enables the status check, which can also be enabled with `--status-check`.
+	StatusCheck *StatusCheckConfig `yaml:"statusCheck,omitempty"`
+
+	// Concurrency is the maximum number of manifests applied, or helm releases
+	// installed, in parallel. Namespaces and custom resource definitions are
+	// always applied first.
+	// Defaults to `1`.
+	Concurrency int `yaml:"concurrency,omitempty"`
+
+	// Policy *alpha* checks the rendered manifests against Rego policies, with `conftest`,
+	// before they're applied. Only kubectl and kustomize deployments are checked.
+	Policy *PolicyConfig `yaml:"policy,omitempty"`
+
+	// Transforms *alpha* are applied, in order, to the rendered manifests before they're
+	// applied or checked against policies. Only kubectl and kustomize deployments are transformed.
+	Transforms []ManifestTransform `yaml:"transforms,omitempty"`
+
+	// Cleanup configures what `skaffold delete` deletes, and what runs when `skaffold dev` exits.
+	Cleanup *CleanupConfig `yaml:"cleanup,omitempty"`
+}
+
+// CleanupConfig configures how deployments are cleaned up.
+// `retain` and `selector` only affect kubectl and kustomize deployments.
+type CleanupConfig struct {
+	// Retain lists the kinds of resources that are never deleted.
+	// For example: `["PersistentVolumeClaim", "Namespace"]`.
+	Retain []string `yaml:"retain,omitempty"`
+
+	// Selector is a label selector. Resources that match it are deleted too,
+	// even if they're not in the manifests anymore, e.g. because a manifest was renamed.
+	// For example: `app.kubernetes.io/part-of=leeroy`.
+	Selector string `yaml:"selector,omitempty"`
+
+	// Hooks are commands run when `skaffold dev` or `skaffold debug` exits,
+	// after the deployed resources are deleted and the images are pruned.
+	// The images that were built are listed in the `IMAGES` environment variable.
+	// For example: `["./scripts/drop-test-database.sh"]`.
+	Hooks []string `yaml:"hooks,omitempty"`
+}
+
+// ManifestTransform *alpha* modifies the rendered manifests, either with patches or with
+// an external command.
+type ManifestTransform struct {
+	// Kinds are the kinds of the resources the patches apply to, e.g. `Deployment`.
+	// Defaults to all kinds.
+	Kinds []string `yaml:"kinds,omitempty"`
+
+	// Patches are JSON patches applied to each matching resource.
+	// A resource that can't be patched fails the deployment.
+	Patches []JSONPatch `yaml:"patches,omitempty"`
+
+	// Command is run with `sh -c`, from the working directory. It reads the manifests
+	// on stdin and prints the transformed manifests on stdout.
+	Command string `yaml:"command,omitempty"`
+}
+
+// PolicyConfig *alpha* configures how rendered manifests are checked against Rego policies.
+type PolicyConfig struct {
+	// Policies are the files or directories of Rego policies.
+	// Defaults to `["policy"]`.
+	Policies []string `yaml:"policies,omitempty"`
+
+	// Namespaces are the Rego packages in which `deny`, `violation` and `warn` rules are looked up.
+	// Defaults to `["main"]`.
+	Namespaces []string `yaml:"namespaces,omitempty"`
+
+	// FailOnWarn also prevents the deployment when `warn` rules match.
+	FailOnWarn bool `yaml:"failOnWarn,omitempty"`
+
+	// Flags are additional flags passed to `conftest test`.
+	Flags []string `yaml:"flags,omitempty"`
+}
+
+// StatusCheckConfig *alpha* configures how Skaffold waits for deployed resources to stabilize.
+type StatusCheckConfig struct {
+	// DeadlineSeconds is the maximum time to wait for a resource to stabilize.
+	// Defaults to `600`.
+	DeadlineSeconds int `yaml:"deadlineSeconds,omitempty"`
+
+	// FailureThreshold is the number of consecutive failed checks tolerated
+	// for a resource before the status check fails, to ignore transient failures.
+	// Defaults to `0`.
+	FailureThreshold int `yaml:"failureThreshold,omitempty"`
+
+	// Resources overrides the deadline and failure threshold for resources
+	// of a given kind or with a given name.
+	// When several entries match a resource, the last one wins.
+	Resources []StatusCheckResource `yaml:"resources,omitempty"`
+
+	// Diagnostics configures the information gathered about resources that fail to stabilize.
+	Diagnostics *StatusCheckDiagnostics `yaml:"diagnostics,omitempty"`
+
+	// Endpoints are Services and Ingresses that are only considered ready once
+	// an HTTP(S) request to them succeeds.
+	Endpoints []StatusCheckEndpoint `yaml:"endpoints,omitempty"`
+}
+
+// StatusCheckEndpoint is a Service or an Ingress probed over HTTP(S) after
+// the deployed resources have stabilized.
+type StatusCheckEndpoint struct {
+	// Kind is either `Service` or `Ingress`.
+	Kind string `yaml:"kind" yamltags:"required"`
+
+	// Name is the name of the Service or Ingress.
+	Name string `yaml:"name" yamltags:"required"`
+
+	// Scheme is either `http` or `https`.
+	// Defaults to `http`.
+	Scheme string `yaml:"scheme,omitempty"`
+
+	// Port is the port to probe.
+	// Defaults to the only port of a Service and to the scheme's port for an Ingress.
+	Port int `yaml:"port,omitempty"`
+
+	// Path is the path to request.
+	// Defaults to `/`.
+	Path string `yaml:"path,omitempty"`
+
+	// Status is the expected HTTP status.
+	// Defaults to any `2xx` status.
+	Status int `yaml:"status,omitempty"`
+
+	// TimeoutSeconds is the maximum time to wait for the endpoint to answer with the expected status.
+	// Defaults to the global `deadlineSeconds`.
+	TimeoutSeconds int `yaml:"timeoutSeconds,omitempty"`
+}
+
+// StatusCheckDiagnostics configures the information gathered about resources that fail to stabilize:
+// the description of their pods, their recent events and the logs of their crashing containers.
+type StatusCheckDiagnostics struct {
+	// LogLines is the number of log lines printed for each crashing container.
+	// Defaults to `20`.
+	LogLines int `yaml:"logLines,omitempty"`
+
+	// Tarball is the path of a `.tar.gz` archive where diagnostics are written, in addition to being printed.
+	Tarball string `yaml:"tarball,omitempty"`
+}
+
+// StatusCheckResource overrides the status check settings for matching resources.
+type StatusCheckResource struct {
+	// Kind is the kind of the resources to match, e.g. `Deployment`.
+	// Matches resources of any kind if empty.
+	Kind string `yaml:"kind,omitempty"`
+
+	// Name is the name of the resource to match.
+	// Matches resources with any name if empty.
+	Name string `yaml:"name,omitempty"`
+
+	// DeadlineSeconds is the maximum time to wait for matching resources to stabilize.
+	// Defaults to the global `deadlineSeconds`.
+	DeadlineSeconds int `yaml:"deadlineSeconds,omitempty"`
+
+	// FailureThreshold is the number of consecutive failed checks tolerated for matching resources.
+	// Defaults to the global `failureThreshold`.
+	FailureThreshold *int `yaml:"failureThreshold,omitempty"`
 }
 
 // DeployType contains the specific implementation and parameters needed
@@ -309,6 +648,15 @@ type DeployType struct {
 
 	// KustomizeDeploy *beta* uses the `kustomize` CLI to "patch" a deployment for a target environment.
 	KustomizeDeploy *KustomizeDeploy `yaml:"kustomize,omitempty" yamltags:"oneOf=deploy"`
+
+	// CloudRunDeploy *alpha* uses the `gcloud` CLI to deploy images as Cloud Run services.
+	CloudRunDeploy *CloudRunDeploy `yaml:"cloudrun,omitempty" yamltags:"oneOf=deploy"`
+
+	// DockerComposeDeploy *alpha* uses `docker compose` to run images on the local Docker daemon.
+	DockerComposeDeploy *DockerComposeDeploy `yaml:"dockerCompose,omitempty" yamltags:"oneOf=deploy"`
+
+	// PluginDeploy *alpha* delegates the deployments to an external deployer plugin.
+	PluginDeploy *PluginDeploy `yaml:"plugin,omitempty" yamltags:"oneOf=deploy"`
 }
 
 // KubectlDeploy *beta* uses a client side `kubectl apply` to deploy manifests.
@@ -329,7 +677,8 @@ type KubectlDeploy struct {
 // line to kubectl either on every command (Global), on creations (Apply)
 // or deletions (Delete).
 type KubectlFlags struct {
-	// Global are additional flags passed on every command.
+	// Global are additional flags passed on every command, including
+	// `kubectl logs`, `kubectl port-forward` and `kubectl exec`.
 	Global []string `yaml:"global,omitempty"`
 
 	// Apply are additional flags passed on creations (`kubectl apply`).
@@ -352,7 +701,8 @@ type HelmDeploy struct {
 // HelmDeployFlags are additional option flags that are passed on the command
 // line to `helm`.
 type HelmDeployFlags struct {
-	// Global are additional flags passed on every command.
+	// Global are additional flags passed on every command, including
+	// `kubectl logs`, `kubectl port-forward` and `kubectl exec`.
 	Global []string `yaml:"global,omitempty"`
 
 	// Install are additional flags passed to (`helm install`).
@@ -372,9 +722,64 @@ type KustomizeDeploy struct {
 	Flags KubectlFlags `yaml:"flags,omitempty"`
 }
 
+// CloudRunDeploy *alpha* uses the `gcloud` CLI to deploy images as Cloud Run services.
+type CloudRunDeploy struct {
+	// ProjectID is the GCP project of the services.
+	// Defaults to the `gcloud` default project.
+	ProjectID string `yaml:"projectId,omitempty"`
+
+	// Region is the region of the services.
+	// For example: `us-central1`.
+	Region string `yaml:"region" yamltags:"required"`
+
+	// Services lists the Cloud Run services to deploy.
+	Services []CloudRunService `yaml:"services" yamltags:"required"`
+}
+
+// CloudRunService describes a Cloud Run service that runs an artifact's image.
+type CloudRunService struct {
+	// Name is the name of the service.
+	Name string `yaml:"name" yamltags:"required"`
+
+	// Image is the name of the artifact whose image the service runs.
+	Image string `yaml:"image" yamltags:"required"`
+
+	// Flags are additional flags passed to `gcloud run deploy`.
+	// For example: `["--allow-unauthenticated", "--memory=512Mi"]`.
+	Flags []string `yaml:"flags,omitempty"`
+}
+
+// DockerComposeDeploy *alpha* uses `docker compose` to run images on the local Docker daemon.
+type DockerComposeDeploy struct {
+	// ComposeFile is the path to the compose file. Images of its services are
+	// replaced with the images built by Skaffold.
+	// Defaults to a generated file with one service per artifact.
+	ComposeFile string `yaml:"composeFile,omitempty"`
+
+	// ProjectName is the compose project name.
+	// Defaults to the name of the current directory.
+	ProjectName string `yaml:"projectName,omitempty"`
+}
+
+// PluginDeploy *alpha* delegates the deployments to an external deployer plugin.
+// Skaffold talks to the plugin over gRPC to render, deploy, check the status
+// and clean up.
+type PluginDeploy struct {
+	// Command is the plugin binary.
+	// Relative paths are resolved from the working directory.
+	Command string `yaml:"command" yamltags:"required"`
+
+	// Args are passed to the plugin binary.
+	Args []string `yaml:"args,omitempty"`
+
+	// Config is passed to the plugin with each request.
+	Config map[string]string `yaml:"config,omitempty"`
+}
+
 // HelmRelease describes a helm release to be deployed.
 type HelmRelease struct {
 	// Name is the name of the Helm release.
+	// Can be a template, like `myapp-{{.USER}}`.
 	Name string `yaml:"name,omitempty" yamltags:"required"`
 
 	// ChartPath is the path to the Helm chart.
@@ -387,8 +792,13 @@ type HelmRelease struct {
 	Values map[string]string `yaml:"values,omitempty,omitempty"`
 
 	// Namespace is the Kubernetes namespace.
+	// Can be a template, like `{{.USER}}-dev`.
 	Namespace string `yaml:"namespace,omitempty"`
 
+	// KubeContext is the kubectl context to install the release to.
+	// Can be a template. Defaults to the current context.
+	KubeContext string `yaml:"kubeContext,omitempty"`
+
 	// Version is the version of the chart.
 	Version string `yaml:"version,omitempty"`
 
@@ -480,6 +890,29 @@ type Artifact struct {
 	// of triggering an image build when modified.
 	Sync *Sync `yaml:"sync,omitempty"`
 
+	// Scan configures the vulnerability scan of the built image, before it's deployed.
+	Scan *ScanConfig `yaml:"scan,omitempty"`
+
+	// RemoteDev *alpha* describes how to develop the artifact in the cluster
+	// with `skaffold dev --remote-dev`, instead of building images.
+	RemoteDev *RemoteDev `yaml:"remoteDev,omitempty"`
+
+	// Cache can be set to `false` to always rebuild the artifact, when it depends on inputs
+	// Skaffold can't see, like downloaded assets or system packages.
+	// Defaults to `true`.
+	Cache *bool `yaml:"cache,omitempty"`
+
+	// CacheSalt is added to the artifact's cache key, so that its cached images are
+	// invalidated when the salt changes. It can use environment variables.
+	// For example: `"{{.BASE_IMAGE_DIGEST}}"`.
+	CacheSalt string `yaml:"cacheSalt,omitempty"`
+
+	// Watch selects how `skaffold dev` detects changes to the artifact's sources.
+	// `stat` checks every dependency. `git` first asks `git status`, with `core.fsmonitor`
+	// when it's configured, which is much faster in large repositories, but doesn't see files ignored by git.
+	// Defaults to `stat`.
+	Watch string `yaml:"watch,omitempty"`
+
 	// ArtifactType describes how to build an artifact.
 	ArtifactType `yaml:",inline"`
 
@@ -491,6 +924,45 @@ type Artifact struct {
 type Sync struct {
 	// Manual lists manual sync rules indicating the source and destination.
 	Manual []*SyncRule `yaml:"manual,omitempty" yamltags:"oneOf=sync"`
+
+	// Auto *alpha* syncs the classes and resources of Jib artifacts to the containers
+	// when their sources change, instead of rebuilding the images. Skaffold runs an incremental
+	// compilation with Maven or Gradle and copies the compiled files following Jib's layout, under `/app`.
+	Auto *bool `yaml:"auto,omitempty" yamltags:"oneOf=sync"`
+
+	// Reverse lists directories of the containers that are copied back to
+	// the host, for files generated in the containers.
+	Reverse []*ReverseSyncRule `yaml:"reverse,omitempty"`
+}
+
+// ReverseSyncRule specifies which directory of the container to sync to a local directory.
+type ReverseSyncRule struct {
+	// Src is the absolute path of a directory in the container.
+	// For example: `"/app/generated"`
+	Src string `yaml:"src,omitempty" yamltags:"required"`
+
+	// Dest is the local directory, relative to the artifact's context, where
+	// the files are copied to.
+	// For example: `"generated"`
+	Dest string `yaml:"dest,omitempty" yamltags:"required"`
+}
+
+// RemoteDev *alpha* describes a long-running pod, in the cluster, where the sources are
+// synced and where the app is rebuilt and restarted when they change.
+type RemoteDev struct {
+	// Image is the image of the pod. It should contain the toolchain that builds the app,
+	// `sh` and `tar`.
+	// For example: `golang:1.12`.
+	Image string `yaml:"image,omitempty" yamltags:"required"`
+
+	// Command builds and runs the app from the synced sources. It's run with `sh -c`,
+	// and restarted when the sources change.
+	// For example: `go run .`.
+	Command string `yaml:"command,omitempty" yamltags:"required"`
+
+	// Dest is the directory, in the container, where the sources are synced.
+	// Defaults to `/workspace`.
+	Dest string `yaml:"dest,omitempty"`
 }
 
 // SyncRule specifies which local files to sync to remote folders.
@@ -507,6 +979,34 @@ type SyncRule struct {
 	// transplanting the files into the destination folder.
 	// For example: `"css/"`
 	Strip string `yaml:"strip,omitempty"`
+
+	// UID is the numeric user that owns the synced files in the container.
+	// The container's `tar` must run as root to change the owner.
+	// Defaults to the user running `tar`.
+	UID *int `yaml:"uid,omitempty"`
+
+	// GID is the numeric group that owns the synced files in the container.
+	// Defaults to `uid`.
+	GID *int `yaml:"gid,omitempty"`
+}
+
+// ScanConfig configures how a built image is scanned for vulnerabilities.
+// Severities are `UNKNOWN`, `LOW`, `MEDIUM`, `HIGH` and `CRITICAL`.
+type ScanConfig struct {
+	// FailOn is the lowest severity of the vulnerabilities that prevent the image from being deployed.
+	// `NONE` never fails.
+	// Defaults to `CRITICAL`.
+	FailOn string `yaml:"failOn,omitempty"`
+
+	// WarnOn is the lowest severity of the vulnerabilities that are reported,
+	// without preventing the image from being deployed.
+	// Defaults to `HIGH`.
+	WarnOn string `yaml:"warnOn,omitempty"`
+
+	// Command runs an external scanner instead of `trivy`.
+	// It's run with the image in the `$IMAGE` environment variable, and must print a report in Trivy's JSON format.
+	// For example: `trivy image --format json --ignore-unfixed $IMAGE`.
+	Command string `yaml:"command,omitempty"`
 }
 
 // Profile *beta* profiles are used to override any `build`, `test` or `deploy` configuration.
@@ -534,12 +1034,15 @@ type JSONPatch struct {
 	// Defaults to `replace`.
 	Op string `yaml:"op,omitempty"`
 
-	// Path is the position in the yaml where the operation takes place.
-	// For example, this targets the `dockerfile` of the first artifact built.
+	// Path is the position in the yaml where the operation takes place, like the `dockerfile` of the first artifact built.
+	// List entries are selected by index, or by the value of one of their fields with `key=value`,
+	// where `/` is written `~1`, like `image=gcr.io~1k8s-skaffold~1app`.
+	// `add` inserts before the selected entry and `-` appends to a list.
 	// For example: `/build/artifacts/0/docker/dockerfile`.
 	Path string `yaml:"path,omitempty" yamltags:"required"`
 
 	// From is the source position in the yaml, used for `copy` or `move` operations.
+	// It supports the same list selectors as `path`.
 	From string `yaml:"from,omitempty"`
 
 	// Value is the value to apply. Can be any portion of yaml.
@@ -560,6 +1063,14 @@ type Activation struct {
 	// Command is a Skaffold command for which the profile is auto-activated.
 	// For example: `dev`.
 	Command string `yaml:"command,omitempty"`
+
+	// File is a path, relative to the current directory, whose existence auto-activates the profile.
+	// For example: `./deploy/prod-marker`.
+	File string `yaml:"file,omitempty"`
+
+	// GitBranch is a pattern matching the current git branch for which the profile is auto-activated.
+	// For example: `release/*`.
+	GitBranch string `yaml:"gitBranch,omitempty"`
 }
 
 // ArtifactType describes how to build an artifact.
@@ -584,6 +1095,19 @@ type ArtifactType struct {
 
 	// CustomArtifact *alpha* builds images using a custom build script written by the user.
 	CustomArtifact *CustomArtifact `yaml:"custom,omitempty" yamltags:"oneOf=artifact"`
+
+	// PluginArtifact *alpha* builds images with an external builder plugin.
+	PluginArtifact *PluginArtifact `yaml:"plugin,omitempty" yamltags:"oneOf=artifact"`
+}
+
+// PluginArtifact *alpha* describes an artifact built by an external builder plugin.
+// Plugins are registered in the global config with `skaffold config set builder-plugins NAME=COMMAND`.
+type PluginArtifact struct {
+	// Name is the name of the plugin, as registered in the global config.
+	Name string `yaml:"name" yamltags:"required"`
+
+	// Config is passed to the plugin with each request.
+	Config map[string]string `yaml:"config,omitempty"`
 }
 
 // CustomArtifact *alpha* describes an artifact built from a custom build script
@@ -593,6 +1117,10 @@ type CustomArtifact struct {
 	BuildCommand string `yaml:"buildCommand,omitempty"`
 	// Dependencies are the file dependencies that skaffold should watch for both rebuilding and file syncing for this artifact.
 	Dependencies *CustomDependencies `yaml:"dependencies,omitempty"`
+	// Upload *alpha* ships the dependencies to the build command as a gzipped tarball, for commands
+	// that build the image away from the workspace. `stdin` writes the tarball to the command's
+	// standard input. `file` writes it to a temporary file whose path is given by `$BUILD_CONTEXT_TAR`.
+	Upload string `yaml:"upload,omitempty"`
 }
 
 // CustomDependencies *alpha* is used to specify dependencies for an artifact built by a custom build script.
@@ -680,6 +1208,16 @@ type DockerArtifact struct {
 
 	// NoCache used to pass in --no-cache to docker build to prevent caching.
 	NoCache bool `yaml:"noCache,omitempty"`
+
+	// DockerignorePath locates the file of patterns excluded from the build context, relative to workspace.
+	// Defaults to `<dockerfile>.dockerignore` if that file exists next to the Dockerfile, `.dockerignore` otherwise.
+	DockerignorePath string `yaml:"dockerignorePath,omitempty"`
+
+	// Platform is the target OS and architecture of the image, as `os[/arch[/variant]]`.
+	// Windows images have to be built by the local builder, on a Docker daemon that runs Windows containers.
+	// The pods that run images with an OS are scheduled on nodes of that OS.
+	// For example: `windows/amd64`, `linux/arm64`.
+	Platform string `yaml:"platform,omitempty"`
 }
 
 // BazelArtifact *beta* describes an artifact built with [Bazel](https://bazel.build/).
@@ -705,6 +1243,11 @@ type JibMavenArtifact struct {
 	// Flags are additional build flags passed to Maven.
 	// For example: `["-x", "-DskipTests"]`.
 	Flags []string `yaml:"args,omitempty"`
+
+	// Daemon *alpha* builds the artifact with the [Maven Daemon](https://github.com/apache/maven-mvnd), `mvnd`,
+	// in `skaffold dev` and `skaffold debug`, to keep a warm JVM between the builds.
+	// Maven is used if `mvnd` can't be found, or if the project has a Maven wrapper.
+	Daemon bool `yaml:"daemon,omitempty"`
 }
 
 // JibGradleArtifact *alpha* builds images using the
@@ -716,4 +1259,8 @@ type JibGradleArtifact struct {
 	// Flags are additional build flags passed to Gradle.
 	// For example: `["--no-build-cache"]`.
 	Flags []string `yaml:"args,omitempty"`
+
+	// Daemon *alpha* runs the builds of `skaffold dev` and `skaffold debug` with `--daemon`,
+	// for projects that disable the Gradle daemon with `org.gradle.daemon=false`.
+	Daemon bool `yaml:"daemon,omitempty"`
 }
@@ -19,19 +19,26 @@ package schema
 import (
 	"fmt"
 	"os"
+	"os/exec"
+	"path"
 	"reflect"
+	"strconv"
 	"strings"
 
 	cfg "github.com/GoogleContainerTools/skaffold/pkg/skaffold/config"
 	kubectx "github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes/context"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/util"
+	misc "github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
 	yamlpatch "github.com/krishicks/yaml-patch"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	yaml "gopkg.in/yaml.v2"
 )
 
+// For testing
+var currentGitBranch = gitBranch
+
 // ApplyProfiles returns configuration modified by the application
 // of a list of profiles.
 func ApplyProfiles(c *latest.SkaffoldConfig, opts *cfg.SkaffoldOptions) error {
@@ -74,7 +81,17 @@ func activatedProfiles(profiles []latest.Profile, opts *cfg.SkaffoldOptions) ([]
 				return nil, err
 			}
 
-			if command && env && kubeContext {
+			file, err := isFile(cond.File)
+			if err != nil {
+				return nil, err
+			}
+
+			gitBranch, err := isGitBranch(cond.GitBranch)
+			if err != nil {
+				return nil, err
+			}
+
+			if command && env && kubeContext && file && gitBranch {
 				activated = append(activated, profile.Name)
 			}
 		}
@@ -120,6 +137,54 @@ func isKubeContext(kubeContext string) (bool, error) {
 	return satisfies(kubeContext, currentKubeContext), nil
 }
 
+func isFile(file string) (bool, error) {
+	if file == "" {
+		return true, nil
+	}
+
+	expected := true
+	if strings.HasPrefix(file, "!") {
+		expected = false
+		file = file[1:]
+	}
+
+	_, err := os.Stat(file)
+	if err != nil && !os.IsNotExist(err) {
+		return false, errors.Wrapf(err, "checking if %s exists", file)
+	}
+	return (err == nil) == expected, nil
+}
+
+func isGitBranch(pattern string) (bool, error) {
+	if pattern == "" {
+		return true, nil
+	}
+
+	branch, err := currentGitBranch()
+	if err != nil {
+		return false, errors.Wrap(err, "getting current git branch")
+	}
+
+	negated := strings.HasPrefix(pattern, "!")
+	if negated {
+		pattern = pattern[1:]
+	}
+
+	matches, err := path.Match(pattern, branch)
+	if err != nil {
+		return false, errors.Wrapf(err, "invalid git branch pattern: %s", pattern)
+	}
+	return matches != negated, nil
+}
+
+func gitBranch() (string, error) {
+	out, err := misc.RunCmdOut(exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD"))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
 func satisfies(expected, actual string) bool {
 	if strings.HasPrefix(expected, "!") {
 		return actual != expected[1:]
@@ -135,9 +200,12 @@ func applyProfile(config *latest.SkaffoldConfig, profile latest.Profile) error {
 		APIVersion: config.APIVersion,
 		Kind:       config.Kind,
 		Pipeline: latest.Pipeline{
-			Build:  overlayProfileField(config.Build, profile.Build).(latest.BuildConfig),
-			Deploy: overlayProfileField(config.Deploy, profile.Deploy).(latest.DeployConfig),
-			Test:   overlayProfileField(config.Test, profile.Test).([]*latest.TestCase),
+			Build:       overlayProfileField(config.Build, profile.Build).(latest.BuildConfig),
+			Deploy:      overlayProfileField(config.Deploy, profile.Deploy).(latest.DeployConfig),
+			Test:        overlayProfileField(config.Test, profile.Test).([]*latest.TestCase),
+			Verify:      overlayProfileField(config.Verify, profile.Verify).([]*latest.VerifyTestCase),
+			PortForward: overlayProfileField(config.PortForward, profile.PortForward).([]*latest.PortForwardResource),
+			EnvFile:     overlayProfileField(config.EnvFile, profile.EnvFile).(string),
 		},
 	}
 
@@ -151,8 +219,18 @@ func applyProfile(config *latest.SkaffoldConfig, profile latest.Profile) error {
 		return err
 	}
 
-	var patches []yamlpatch.Operation
-	for _, patch := range profile.Patches {
+	buf, err = ApplyPatches(buf, profile.Patches)
+	if err != nil {
+		return err
+	}
+
+	return yaml.Unmarshal(buf, config)
+}
+
+// ApplyPatches applies JSON patches to a yaml document. Patches are applied one
+// after the other, so that list selectors see the changes made by the previous patches.
+func ApplyPatches(buf []byte, patches []latest.JSONPatch) ([]byte, error) {
+	for _, patch := range patches {
 		// Default patch operation to `replace`
 		op := patch.Op
 		if op == "" {
@@ -164,26 +242,98 @@ func applyProfile(config *latest.SkaffoldConfig, profile latest.Profile) error {
 			value = &v.Node
 		}
 
-		patch := yamlpatch.Operation{
+		var doc interface{}
+		if err := yaml.Unmarshal(buf, &doc); err != nil {
+			return nil, err
+		}
+
+		path, err := resolveSelectors(doc, patch.Path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid path: %s", patch.Path)
+		}
+		from, err := resolveSelectors(doc, patch.From)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid from: %s", patch.From)
+		}
+
+		operation := yamlpatch.Operation{
 			Op:    yamlpatch.Op(op),
-			Path:  yamlpatch.OpPath(patch.Path),
-			From:  yamlpatch.OpPath(patch.From),
+			Path:  yamlpatch.OpPath(path),
+			From:  yamlpatch.OpPath(from),
 			Value: value,
 		}
 
-		if !tryPatch(patch, buf) {
-			return fmt.Errorf("invalid path: %s", patch.Path)
+		if !tryPatch(operation, buf) {
+			return nil, fmt.Errorf("invalid path: %s", patch.Path)
 		}
 
-		patches = append(patches, patch)
+		buf, err = yamlpatch.Patch([]yamlpatch.Operation{operation}).Apply(buf)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	buf, err = yamlpatch.Patch(patches).Apply(buf)
-	if err != nil {
-		return err
+	return buf, nil
+}
+
+// resolveSelectors replaces the `key=value` parts of a path, that select the entry of a list
+// by the value of one of its fields, with the index of that entry. For example,
+// `/build/artifacts/image=app/docker` becomes `/build/artifacts/1/docker`
+// if `app` is the image of the second artifact.
+func resolveSelectors(doc interface{}, path string) (string, error) {
+	if !strings.Contains(path, "=") {
+		return path, nil
 	}
 
-	return yaml.Unmarshal(buf, config)
+	parts := strings.Split(path, "/")
+	node := doc
+	for i, part := range parts[1:] {
+		switch n := node.(type) {
+		case []interface{}:
+			if kv := strings.SplitN(part, "=", 2); len(kv) == 2 {
+				index, err := selectEntry(n, decodePathPart(kv[0]), decodePathPart(kv[1]))
+				if err != nil {
+					return "", err
+				}
+				part = strconv.Itoa(index)
+				parts[i+1] = part
+			}
+
+			index, err := strconv.Atoi(part)
+			if err != nil || index < 0 || index >= len(n) {
+				node = nil
+				continue
+			}
+			node = n[index]
+
+		case map[interface{}]interface{}:
+			node = n[decodePathPart(part)]
+
+		default:
+			node = nil
+		}
+	}
+
+	return strings.Join(parts, "/"), nil
+}
+
+// selectEntry returns the index of the first entry of a list that has the given value for the given field.
+func selectEntry(list []interface{}, key, value string) (int, error) {
+	for i, entry := range list {
+		fields, ok := entry.(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+		if v, found := fields[key]; found && fmt.Sprint(v) == value {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("no entry with %s=%s", key, value)
+}
+
+// decodePathPart unescapes a part of a JSON pointer.
+func decodePathPart(part string) string {
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(part)
 }
 
 // tryPatch is here to verify patches one by one before we
@@ -267,6 +417,12 @@ func overlayProfileField(config interface{}, profile interface{}) interface{} {
 			return config
 		}
 		return v.Interface()
+	case reflect.String:
+		// either return the value provided in the profile, or the original value if none was provided.
+		if v.Len() == 0 {
+			return config
+		}
+		return v.Interface()
 	default:
 		logrus.Warnf("unknown field type in profile overlay: %s. falling back to original config values", v.Kind())
 		return config
@@ -379,6 +379,32 @@ func TestActivatedProfiles(t *testing.T) {
 				{Name: "also-activated", Activation: []latest.Activation{{KubeContext: "!dev-context"}}},
 			},
 			expected: []string{"activated", "also-activated"},
+		}, {
+			description: "Auto-activated by file",
+			opts:        &cfg.SkaffoldOptions{},
+			profiles: []latest.Profile{
+				{Name: "activated", Activation: []latest.Activation{{File: "./deploy/prod-marker"}}},
+				{Name: "not-activated", Activation: []latest.Activation{{File: "missing"}}},
+				{Name: "also-activated", Activation: []latest.Activation{{File: "!missing"}}},
+			},
+			expected: []string{"activated", "also-activated"},
+		}, {
+			description: "Auto-activated by git branch",
+			opts:        &cfg.SkaffoldOptions{},
+			profiles: []latest.Profile{
+				{Name: "activated", Activation: []latest.Activation{{GitBranch: "release/*"}}},
+				{Name: "not-activated", Activation: []latest.Activation{{GitBranch: "master"}}},
+				{Name: "also-activated", Activation: []latest.Activation{{GitBranch: "!master"}}},
+				{Name: "exact-branch", Activation: []latest.Activation{{GitBranch: "release/v1"}}},
+			},
+			expected: []string{"activated", "also-activated", "exact-branch"},
+		}, {
+			description: "Invalid git branch pattern",
+			opts:        &cfg.SkaffoldOptions{},
+			profiles: []latest.Profile{
+				{Name: "activated", Activation: []latest.Activation{{GitBranch: "release/["}}},
+			},
+			shouldErr: true,
 		}, {
 			description: "AND between activation criteria",
 			opts: &cfg.SkaffoldOptions{
@@ -423,6 +449,13 @@ func TestActivatedProfiles(t *testing.T) {
 	restore := testutil.SetupFakeKubernetesContext(t, api.Config{CurrentContext: "prod-context"})
 	defer restore()
 
+	tmpDir, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+	tmpDir.Write("deploy/prod-marker", "")
+	defer testutil.Chdir(t, tmpDir.Root())()
+
+	defer testutil.Override(t, &currentGitBranch, func() (string, error) { return "release/v1", nil })()
+
 	for _, test := range tests {
 		t.Run(test.description, func(t *testing.T) {
 
@@ -438,3 +471,88 @@ func str(value string) *interface{} {
 	var v interface{} = value
 	return &v
 }
+
+func TestApplyPatchWithSelectors(t *testing.T) {
+	config := `build:
+  artifacts:
+  - image: gcr.io/k8s-skaffold/first
+    docker:
+      dockerfile: Dockerfile.first
+  - image: second
+    context: second
+  - image: third
+    context: third
+    docker:
+      buildArgs:
+        image: second
+deploy:
+  kubectl: {}
+profiles:
+- name: patches
+  patches:
+  - path: /build/artifacts/image=second/context
+    value: replaced
+  - path: /build/artifacts/image=gcr.io~1k8s-skaffold~1first/docker/dockerfile
+    value: Dockerfile.DEV
+  - op: remove
+    path: /build/artifacts/image=third
+  - op: add
+    path: /build/artifacts/image=second
+    value:
+      image: inserted
+  - op: add
+    path: /build/artifacts/image=inserted/context
+    value: inserted
+`
+
+	tmp, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+
+	yaml := fmt.Sprintf("apiVersion: %s\nkind: Config\n%s", latest.Version, config)
+	tmp.Write("skaffold.yaml", yaml)
+
+	parsed, err := ParseConfig(tmp.Path("skaffold.yaml"), false)
+	testutil.CheckError(t, false, err)
+
+	skaffoldConfig := parsed.(*latest.SkaffoldConfig)
+	err = ApplyProfiles(skaffoldConfig, &cfg.SkaffoldOptions{
+		Profiles: []string{"patches"},
+	})
+	testutil.CheckError(t, false, err)
+
+	artifacts := skaffoldConfig.Build.Artifacts
+	testutil.CheckDeepEqual(t, 3, len(artifacts))
+	testutil.CheckDeepEqual(t, "Dockerfile.DEV", artifacts[0].DockerArtifact.DockerfilePath)
+	testutil.CheckDeepEqual(t, "inserted", artifacts[1].ImageName)
+	testutil.CheckDeepEqual(t, "inserted", artifacts[1].Workspace)
+	testutil.CheckDeepEqual(t, "second", artifacts[2].ImageName)
+	testutil.CheckDeepEqual(t, "replaced", artifacts[2].Workspace)
+}
+
+func TestApplyPatchWithUnknownSelector(t *testing.T) {
+	config := `build:
+  artifacts:
+  - image: example
+profiles:
+- name: patches
+  patches:
+  - path: /build/artifacts/image=unknown/context
+    value: replacement
+`
+
+	tmp, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+
+	yaml := fmt.Sprintf("apiVersion: %s\nkind: Config\n%s", latest.Version, config)
+	tmp.Write("skaffold.yaml", yaml)
+
+	parsed, err := ParseConfig(tmp.Path("skaffold.yaml"), false)
+	testutil.CheckError(t, false, err)
+
+	skaffoldConfig := parsed.(*latest.SkaffoldConfig)
+	err = ApplyProfiles(skaffoldConfig, &cfg.SkaffoldOptions{
+		Profiles: []string{"patches"},
+	})
+
+	testutil.CheckErrorAndDeepEqual(t, true, err, "applying profile patches: invalid path: /build/artifacts/image=unknown/context: no entry with image=unknown", err.Error())
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta11
+
+import (
+	next "github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/util"
+	pkgutil "github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/pkg/errors"
+)
+
+// Upgrade upgrades a configuration to the next version.
+// Config changes from v1beta11 to v1beta12
+// 1. Additions:
+//    - `deploy.statusCheck` to configure how deployed resources are waited for
+//    - `deploy.statusCheck.diagnostics` to configure what's gathered about failed resources
+//    - `deploy.statusCheck.endpoints` to probe Services and Ingresses over HTTP(S)
+//    - `deploy.concurrency` to deploy independent manifests and helm releases in parallel
+//    - `test.custom` to run arbitrary commands against built images
+//    - `test.cluster` to run tests in the cluster, using built images
+//    - `test.structureTestsArgs` to pass flags to `container-structure-test`
+//    - `verify` to run tests against the deployed application
+//    - `build.artifacts.scan` to scan built images for vulnerabilities before deploying them
+//    - `deploy.policy` to check rendered manifests against Rego policies before applying them
+//    - `build.artifacts.docker.dockerignorePath` to use a specific ignore file per artifact
+//    - `build.local.reproducible` to normalize the timestamps of built images
+//    - `build.labels` to add templated labels to every built image
+//    - `build.googleCloudBuild.logsBucket`, `logging` and `substitutions`
+//    - `deploy.cleanup` to retain resources or delete leftovers with `skaffold delete`
+//    - `deploy.cleanup.hooks` to run commands when `skaffold dev` exits
+//    - `build.local.prune` to keep the last images of each artifact
+//    - `deploy.labels` and `deploy.annotations` to add templated metadata to deployed resources
+//    - `build.artifacts.remoteDev` to develop artifacts in the cluster with `skaffold dev --remote-dev`
+//    - `build.artifacts.sync.reverse` to copy files generated in containers back to the host
+//    - `build.artifacts.sync.manual.uid` and `gid` to set the owner of synced files
+//    - `portForward` to configure the URLs opened in the browser with `--open`
+//    - `profiles.activation.file` and `gitBranch` to activate profiles by file existence and git branch
+//    - `envFile` to load template values from a file
+//    - `deploy.cloudrun` to deploy images as Cloud Run services
+//    - `deploy.dockerCompose` to run images with `docker compose`
+//    - `deploy.helm.releases.kubeContext` to install releases to other contexts
+//    - `deploy.transforms` to patch or transform the rendered manifests
+//    - `deploy.namespace` to deploy to a templated namespace, created if missing
+//    - `build.artifacts.cache` and `build.artifacts.cacheSalt` to control an artifact's caching
+//    - `build.artifacts.docker.platform` to build images for another OS or architecture, like Windows
+//    - `build.artifacts.watch` to detect changes with `git status` in large repositories
+//    - `build.artifacts.sync.auto` to sync the compiled classes and resources of Jib artifacts
+//    - `build.artifacts.jibMaven.daemon` and `jibGradle.daemon` to build with daemons in `skaffold dev`
+//    - `build.artifacts.custom.upload` to ship the dependencies to remote custom builds
+//    - `tools` to configure the binaries of kubectl, helm and kustomize, and pin their versions
+//    - `deploy.plugin` to deploy with an external deployer plugin
+//    - `build.artifacts.plugin` to build with an external builder plugin
+//    - `settings` to configure the update check per project
+// 2. No removals
+// 3. No Updates
+func (config *SkaffoldConfig) Upgrade() (util.VersionedConfig, error) {
+	// convert Deploy (should be the same)
+	var newDeploy next.DeployConfig
+	if err := pkgutil.CloneThroughJSON(config.Deploy, &newDeploy); err != nil {
+		return nil, errors.Wrap(err, "converting deploy config")
+	}
+
+	// convert Profiles (should be the same)
+	var newProfiles []next.Profile
+	if config.Profiles != nil {
+		if err := pkgutil.CloneThroughJSON(config.Profiles, &newProfiles); err != nil {
+			return nil, errors.Wrap(err, "converting new profile")
+		}
+	}
+
+	// convert Build (should be same)
+	var newBuild next.BuildConfig
+	if err := pkgutil.CloneThroughJSON(config.Build, &newBuild); err != nil {
+		return nil, errors.Wrap(err, "converting new build")
+	}
+
+	// convert Test (should be the same)
+	var newTest []*next.TestCase
+	if err := pkgutil.CloneThroughJSON(config.Test, &newTest); err != nil {
+		return nil, errors.Wrap(err, "converting new test")
+	}
+
+	return &next.SkaffoldConfig{
+		APIVersion: next.Version,
+		Kind:       config.Kind,
+		Pipeline: next.Pipeline{
+			Build:  newBuild,
+			Test:   newTest,
+			Deploy: newDeploy,
+		},
+		Profiles: newProfiles,
+	}, nil
+}
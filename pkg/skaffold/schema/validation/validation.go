@@ -18,10 +18,13 @@ package validation
 
 import (
 	"fmt"
+	"path/filepath"
 	"reflect"
 	"strings"
 
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/docker"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/yamltags"
 )
 
@@ -34,8 +37,11 @@ var (
 func Process(config *latest.SkaffoldConfig) error {
 	errs := visitStructs(config, validateYamltags)
 	errs = append(errs, validateDockerNetworkMode(config.Build.Artifacts)...)
+	errs = append(errs, validateDockerPlatform(config.Build)...)
 	errs = append(errs, validateCustomDependencies(config.Build.Artifacts)...)
 	errs = append(errs, validateSyncRules(config.Build.Artifacts)...)
+	errs = append(errs, validateWatch(config.Build.Artifacts)...)
+	errs = append(errs, validateCustomUpload(config.Build.Artifacts)...)
 
 	if len(errs) == 0 {
 		return nil
@@ -63,6 +69,52 @@ func validateDockerNetworkMode(artifacts []*latest.Artifact) (errs []error) {
 	return
 }
 
+// validateDockerPlatform makes sure that platforms are given as `os[/arch[/variant]]`,
+// and that Windows images are built by the local builder: Kaniko and Google Cloud Build only build Linux images.
+func validateDockerPlatform(build latest.BuildConfig) (errs []error) {
+	for _, a := range build.Artifacts {
+		if a.DockerArtifact == nil || a.DockerArtifact.Platform == "" {
+			continue
+		}
+
+		platform := a.DockerArtifact.Platform
+		parts := strings.Split(platform, "/")
+		if len(parts) > 3 || util.StrSliceContains(parts, "") {
+			errs = append(errs, fmt.Errorf("artifact %s has invalid platform '%s', expected os[/arch[/variant]]", a.ImageName, platform))
+			continue
+		}
+
+		if docker.PlatformOS(platform) == "windows" && build.LocalBuild == nil {
+			errs = append(errs, fmt.Errorf("artifact %s targets windows, which only the local builder can build, with a Docker daemon that runs Windows containers", a.ImageName))
+		}
+	}
+	return
+}
+
+// validateWatch makes sure that watch is one of `stat` or `git` if set.
+func validateWatch(artifacts []*latest.Artifact) (errs []error) {
+	for _, a := range artifacts {
+		if a.Watch == "" || a.Watch == "stat" || a.Watch == "git" {
+			continue
+		}
+		errs = append(errs, fmt.Errorf("artifact %s has invalid watch '%s', expected stat or git", a.ImageName, a.Watch))
+	}
+	return
+}
+
+// validateCustomUpload makes sure that upload is one of `stdin` or `file` if set.
+func validateCustomUpload(artifacts []*latest.Artifact) (errs []error) {
+	for _, a := range artifacts {
+		if a.CustomArtifact == nil {
+			continue
+		}
+		if upload := a.CustomArtifact.Upload; upload != "" && upload != "stdin" && upload != "file" {
+			errs = append(errs, fmt.Errorf("artifact %s has invalid upload '%s', expected stdin or file", a.ImageName, upload))
+		}
+	}
+	return
+}
+
 // validateCustomDependencies makes sure that dependencies.ignore is only used in conjunction with dependencies.paths
 func validateCustomDependencies(artifacts []*latest.Artifact) (errs []error) {
 	for _, a := range artifacts {
@@ -127,15 +179,31 @@ func visitStructs(s interface{}, visitor func(interface{}) error) []error {
 }
 
 // validateSyncRules checks that all manual sync rules have a valid strip prefix
+// and a uid when they have a gid, that reverse sync rules copy files inside the artifact's context,
+// and that only Jib artifacts are synced automatically.
 func validateSyncRules(artifacts []*latest.Artifact) []error {
 	var errs []error
 	for _, a := range artifacts {
 		if a.Sync != nil {
+			if a.Sync.Auto != nil && *a.Sync.Auto && a.JibMavenArtifact == nil && a.JibGradleArtifact == nil {
+				errs = append(errs, fmt.Errorf("artifact %s can't be synced automatically: only Jib artifacts can", a.ImageName))
+			}
 			for _, r := range a.Sync.Manual {
 				if !strings.HasPrefix(r.Src, r.Strip) {
 					err := fmt.Errorf("sync rule pattern '%s' does not have prefix '%s'", r.Src, r.Strip)
 					errs = append(errs, err)
 				}
+				if r.GID != nil && r.UID == nil {
+					errs = append(errs, fmt.Errorf("sync rule pattern '%s' sets a gid without a uid", r.Src))
+				}
+			}
+			for _, r := range a.Sync.Reverse {
+				if !util.IsAbsContainerPath(r.Src) {
+					errs = append(errs, fmt.Errorf("reverse sync source '%s' should be an absolute path", r.Src))
+				}
+				if dest := filepath.Clean(r.Dest); filepath.IsAbs(dest) || dest == ".." || strings.HasPrefix(dest, ".."+string(filepath.Separator)) {
+					errs = append(errs, fmt.Errorf("reverse sync destination '%s' should be inside the artifact's context", r.Dest))
+				}
 			}
 		}
 	}
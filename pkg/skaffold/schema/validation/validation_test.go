@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
 	"github.com/GoogleContainerTools/skaffold/testutil"
 )
 
@@ -367,7 +368,113 @@ func TestValidateNetworkMode(t *testing.T) {
 	}
 }
 
+func TestValidateDockerPlatform(t *testing.T) {
+	tests := []struct {
+		description string
+		platform    string
+		local       bool
+		shouldErr   bool
+	}{
+		{
+			description: "no platform",
+		},
+		{
+			description: "linux on cloud build",
+			platform:    "linux/arm64/v8",
+		},
+		{
+			description: "windows locally",
+			platform:    "windows/amd64",
+			local:       true,
+		},
+		{
+			description: "windows on cloud build",
+			platform:    "windows/amd64",
+			shouldErr:   true,
+		},
+		{
+			description: "empty arch",
+			platform:    "linux/",
+			local:       true,
+			shouldErr:   true,
+		},
+		{
+			description: "too many parts",
+			platform:    "linux/arm/v7/extra",
+			local:       true,
+			shouldErr:   true,
+		},
+	}
+
+	// disable yamltags validation
+	reset := testutil.Override(t, &validateYamltags, func(interface{}) error { return nil })
+	defer reset()
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			build := latest.BuildConfig{
+				Artifacts: []*latest.Artifact{{
+					ImageName: "image",
+					ArtifactType: latest.ArtifactType{
+						DockerArtifact: &latest.DockerArtifact{
+							Platform: test.platform,
+						},
+					},
+				}},
+			}
+			if test.local {
+				build.LocalBuild = &latest.LocalBuild{}
+			} else {
+				build.GoogleCloudBuild = &latest.GoogleCloudBuild{}
+			}
+
+			err := Process(&latest.SkaffoldConfig{
+				Pipeline: latest.Pipeline{
+					Build: build,
+				},
+			})
+
+			testutil.CheckError(t, test.shouldErr, err)
+		})
+	}
+}
+
+func TestValidateWatch(t *testing.T) {
+	tests := []struct {
+		description string
+		watch       string
+		shouldErr   bool
+	}{
+		{description: "default"},
+		{description: "stat", watch: "stat"},
+		{description: "git", watch: "git"},
+		{description: "invalid", watch: "fsnotify", shouldErr: true},
+	}
+
+	// disable yamltags validation
+	reset := testutil.Override(t, &validateYamltags, func(interface{}) error { return nil })
+	defer reset()
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			err := Process(&latest.SkaffoldConfig{
+				Pipeline: latest.Pipeline{
+					Build: latest.BuildConfig{
+						Artifacts: []*latest.Artifact{{
+							ImageName: "image",
+							Watch:     test.watch,
+						}},
+					},
+				},
+			})
+
+			testutil.CheckError(t, test.shouldErr, err)
+		})
+	}
+}
+
 func TestValidateSyncRules(t *testing.T) {
+	gid := 1000
 	tests := []struct {
 		name      string
 		artifacts []*latest.Artifact
@@ -442,6 +549,62 @@ func TestValidateSyncRules(t *testing.T) {
 			},
 			shouldErr: true,
 		},
+		{
+			name: "gid without uid",
+			artifacts: []*latest.Artifact{
+				{
+					Sync: &latest.Sync{Manual: []*latest.SyncRule{
+						{
+							Src:  "*.sh",
+							Dest: ".",
+							GID:  &gid,
+						},
+					}},
+				},
+			},
+			shouldErr: true,
+		},
+		{
+			name: "good reverse rule",
+			artifacts: []*latest.Artifact{
+				{
+					Sync: &latest.Sync{Reverse: []*latest.ReverseSyncRule{
+						{
+							Src:  "/app/generated",
+							Dest: "generated",
+						},
+					}},
+				},
+			},
+		},
+		{
+			name: "reverse rule with relative source",
+			artifacts: []*latest.Artifact{
+				{
+					Sync: &latest.Sync{Reverse: []*latest.ReverseSyncRule{
+						{
+							Src:  "generated",
+							Dest: "generated",
+						},
+					}},
+				},
+			},
+			shouldErr: true,
+		},
+		{
+			name: "reverse rule outside of the context",
+			artifacts: []*latest.Artifact{
+				{
+					Sync: &latest.Sync{Reverse: []*latest.ReverseSyncRule{
+						{
+							Src:  "/app/generated",
+							Dest: "../generated",
+						},
+					}},
+				},
+			},
+			shouldErr: true,
+		},
 		{
 			name: "stripping part of folder name is valid",
 			artifacts: []*latest.Artifact{
@@ -456,6 +619,29 @@ func TestValidateSyncRules(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "auto sync of a jib artifact",
+			artifacts: []*latest.Artifact{
+				{
+					Sync: &latest.Sync{Auto: util.BoolPtr(true)},
+					ArtifactType: latest.ArtifactType{
+						JibGradleArtifact: &latest.JibGradleArtifact{},
+					},
+				},
+			},
+		},
+		{
+			name: "auto sync of a docker artifact",
+			artifacts: []*latest.Artifact{
+				{
+					Sync: &latest.Sync{Auto: util.BoolPtr(true)},
+					ArtifactType: latest.ArtifactType{
+						DockerArtifact: &latest.DockerArtifact{},
+					},
+				},
+			},
+			shouldErr: true,
+		},
 	}
 
 	// disable yamltags validation
@@ -24,6 +24,7 @@ import (
 	"sync"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event/proto"
 	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
@@ -85,6 +86,34 @@ func Initialize(runctx *runcontext.RunContext) (func() error, error) {
 	var err error
 	once.Do(func() {
 		callback, err = initialize(runctx.Opts.RPCPort, runctx.Opts.RPCHTTPPort)
+		if err != nil {
+			return
+		}
+
+		// deliver the last events, like failures, before the servers are stopped
+		serversCallback := callback
+		callback = func() error {
+			event.Flush(util.GracePeriod)
+			return serversCallback()
+		}
+		if !runctx.Opts.UI {
+			return
+		}
+
+		// the web UI relies on the events API
+		uiPort := util.GetAvailablePort(runctx.Opts.UIPort, &sync.Map{})
+		if uiPort != runctx.Opts.UIPort {
+			logrus.Warnf("provided port %d already in use: using %d instead", runctx.Opts.UIPort, uiPort)
+		}
+		uiCallback, uiErr := newUIServer(uiPort)
+		if uiErr != nil {
+			err = errors.Wrap(uiErr, "starting web UI")
+		}
+		rpcCallback := callback
+		callback = func() error {
+			uiCallback()
+			return rpcCallback()
+		}
 	})
 	return callback, err
 }
@@ -0,0 +1,304 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event/proto"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/redact"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// maxUILogLines is the number of output lines kept for the web UI.
+const maxUILogLines = 1000
+
+var (
+	uiLogs = newLogBuffer(maxUILogLines)
+
+	rebuildLock sync.Mutex
+	rebuild     func(imageName string) error
+)
+
+// TeeLogs returns a writer that also copies the output to the web UI.
+// Colors are kept when the output is a terminal.
+func TeeLogs(out io.Writer) io.Writer {
+	w := teeWriter{io.MultiWriter(out, uiLogs)}
+	if color.IsTerminal(out) {
+		return color.ColoredWriteCloser{WriteCloser: w}
+	}
+	return w
+}
+
+// OnRebuild registers the function called when a rebuild is requested from the web UI.
+func OnRebuild(f func(imageName string) error) {
+	rebuildLock.Lock()
+	rebuild = f
+	rebuildLock.Unlock()
+}
+
+func newUIServer(port int) (func() error, error) {
+	l, err := net.Listen("tcp", fmt.Sprintf("%s:%d", util.Loopback, port))
+	if err != nil {
+		return func() error { return nil }, errors.Wrap(err, "creating listener")
+	}
+	logrus.Infof("starting web UI on port %d", port)
+	color.Default.Fprintf(uiLogs, "Web UI available at http://%s:%d\n", util.Loopback, port)
+
+	go http.Serve(l, uiHandler())
+
+	return l.Close, nil
+}
+
+func uiHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		io.WriteString(w, uiPage)
+	})
+	mux.Handle("/api/state", sameOrigin(serveState))
+	mux.Handle("/api/events", sameOrigin(serveEvents))
+	mux.Handle("/api/logs", sameOrigin(serveLogs))
+	mux.Handle("/api/rebuild", sameOrigin(serveRebuild))
+	return mux
+}
+
+// sameOrigin rejects the requests that weren't sent by the web UI itself,
+// or by a client that isn't a browser.
+func sameOrigin(f http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isSameOrigin(r) {
+			http.Error(w, "cross-origin requests are not allowed", http.StatusForbidden)
+			return
+		}
+		f(w, r)
+	})
+}
+
+func serveState(w http.ResponseWriter, r *http.Request) {
+	state, err := event.GetState()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := (&jsonpb.Marshaler{}).MarshalToString(state)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	io.WriteString(w, redact.String(data))
+}
+
+// serveEvents streams the event log as server-sent events.
+func serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := startStream(w)
+	if !ok {
+		return
+	}
+
+	done := r.Context().Done()
+	entries := make(chan *proto.LogEntry, maxUILogLines)
+	go event.ForEachEvent(func(entry *proto.LogEntry) error {
+		select {
+		case <-done:
+			return errors.New("client disconnected")
+		case entries <- entry:
+		default:
+			// The client is too slow: drop the entry rather than blocking the other listeners.
+		}
+		return nil
+	})
+
+	marshaler := &jsonpb.Marshaler{}
+	for {
+		select {
+		case <-done:
+			return
+		case entry := <-entries:
+			data, err := marshaler.MarshalToString(entry)
+			if err != nil {
+				logrus.Debugln("marshalling event:", err)
+				continue
+			}
+			fmt.Fprintf(w, "data: %s\n\n", redact.String(data))
+			flusher.Flush()
+		}
+	}
+}
+
+// serveLogs streams the recent and upcoming output lines as server-sent events.
+func serveLogs(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := startStream(w)
+	if !ok {
+		return
+	}
+
+	lines, unsubscribe := uiLogs.subscribe()
+	defer unsubscribe()
+
+	done := r.Context().Done()
+	for {
+		select {
+		case <-done:
+			return
+		case line := <-lines:
+			fmt.Fprintf(w, "data: %s\n\n", line)
+			flusher.Flush()
+		}
+	}
+}
+
+func serveRebuild(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	rebuildLock.Lock()
+	f := rebuild
+	rebuildLock.Unlock()
+	if f == nil {
+		http.Error(w, "rebuilds are not available yet", http.StatusServiceUnavailable)
+		return
+	}
+
+	if err := f(r.URL.Query().Get("artifact")); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// isSameOrigin tells if a request was sent to the loopback address by the web UI itself,
+// or by a client that isn't a browser. This keeps other web pages, that a browser lets
+// send requests to any address, from triggering rebuilds or reading the state, events
+// and logs, including through DNS rebinding.
+func isSameOrigin(r *http.Request) bool {
+	host, _, err := net.SplitHostPort(r.Host)
+	if err != nil {
+		host = r.Host
+	}
+	if host != util.Loopback && host != "localhost" {
+		return false
+	}
+
+	origin := r.Header.Get("Origin")
+	return origin == "" || origin == "http://"+r.Host
+}
+
+func startStream(w http.ResponseWriter) (http.Flusher, bool) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	flusher.Flush()
+	return flusher, true
+}
+
+type teeWriter struct {
+	io.Writer
+}
+
+func (teeWriter) Close() error {
+	return nil
+}
+
+var ansiEscapes = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// logBuffer keeps the last lines of output and notifies the subscribers of new lines.
+// Lines are masked as a whole, so that values split across writes are still redacted.
+type logBuffer struct {
+	lock        sync.Mutex
+	max         int
+	lines       []string
+	partial     string
+	subscribers map[chan string]bool
+}
+
+func newLogBuffer(max int) *logBuffer {
+	return &logBuffer{
+		max:         max,
+		subscribers: map[chan string]bool{},
+	}
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	text := b.partial + ansiEscapes.ReplaceAllString(string(p), "")
+	lines := strings.Split(text, "\n")
+	b.partial = lines[len(lines)-1]
+
+	for _, line := range lines[:len(lines)-1] {
+		line = redact.String(strings.TrimSuffix(line, "\r"))
+		b.lines = append(b.lines, line)
+		for subscriber := range b.subscribers {
+			select {
+			case subscriber <- line:
+			default:
+				// Slow subscribers miss lines rather than blocking the output.
+			}
+		}
+	}
+	if len(b.lines) > b.max {
+		b.lines = b.lines[len(b.lines)-b.max:]
+	}
+
+	return len(p), nil
+}
+
+// subscribe returns a channel that receives the kept lines, then the new ones.
+func (b *logBuffer) subscribe() (<-chan string, func()) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	lines := make(chan string, b.max+len(b.lines))
+	for _, line := range b.lines {
+		lines <- line
+	}
+	b.subscribers[lines] = true
+
+	return lines, func() {
+		b.lock.Lock()
+		delete(b.subscribers, lines)
+		b.lock.Unlock()
+	}
+}
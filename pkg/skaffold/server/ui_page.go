@@ -0,0 +1,134 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+// uiPage is the web UI. It only uses the `/api` endpoints served next to it.
+const uiPage = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Skaffold</title>
+<style>
+  body { font-family: sans-serif; margin: 0; display: flex; flex-direction: column; height: 100vh; }
+  header { background: #2b4b8a; color: white; padding: 8px 16px; font-size: 18px; }
+  main { display: flex; flex: 1; min-height: 0; }
+  #status { width: 360px; padding: 8px 16px; overflow-y: auto; border-right: 1px solid #ddd; }
+  #output { flex: 1; display: flex; flex-direction: column; min-width: 0; }
+  #filter { margin: 8px; padding: 4px; }
+  #logs { flex: 1; margin: 0; padding: 8px; overflow-y: auto; background: #1e1e1e; color: #ddd; font-size: 12px; }
+  table { width: 100%; border-collapse: collapse; }
+  td { padding: 4px 0; }
+  h3 { margin: 16px 0 4px; }
+  .Complete { color: green; } .Failed { color: red; } .InProgress { color: orange; }
+  #events { font-size: 12px; color: #555; }
+</style>
+</head>
+<body>
+<header>Skaffold</header>
+<main>
+  <div id="status">
+    <h3>Build</h3><table id="builds"></table>
+    <h3>Deploy</h3><div id="deploy"></div>
+    <h3>Tests</h3><table id="tests"></table>
+    <h3>Forwarded ports</h3><table id="ports"></table>
+    <h3>Events</h3><div id="events"></div>
+  </div>
+  <div id="output">
+    <input id="filter" placeholder="Filter logs">
+    <pre id="logs"></pre>
+  </div>
+</main>
+<script>
+  function cssClass(status) { return (status || "").replace(/ /g, ""); }
+
+  function text(tag, value, status) {
+    var e = document.createElement(tag);
+    e.textContent = value;
+    if (status) { e.className = cssClass(status); }
+    return e;
+  }
+
+  function statusRows(table, statuses, withRebuild) {
+    table.innerHTML = "";
+    Object.keys(statuses || {}).sort().forEach(function(name) {
+      var row = document.createElement("tr");
+      row.appendChild(text("td", name));
+      row.appendChild(text("td", statuses[name], statuses[name]));
+      if (withRebuild) {
+        var button = text("button", "Rebuild");
+        button.onclick = function() { fetch("/api/rebuild?artifact=" + encodeURIComponent(name), {method: "POST"}); };
+        var cell = document.createElement("td");
+        cell.appendChild(button);
+        row.appendChild(cell);
+      }
+      table.appendChild(row);
+    });
+  }
+
+  function refresh() {
+    fetch("/api/state").then(function(r) { return r.json(); }).then(function(state) {
+      statusRows(document.getElementById("builds"), (state.buildState || {}).artifacts, true);
+      var deploy = (state.deployState || {}).status;
+      var deployStatus = document.getElementById("deploy");
+      deployStatus.textContent = deploy || "";
+      deployStatus.className = cssClass(deploy);
+      statusRows(document.getElementById("tests"), (state.verifyState || {}).tests, false);
+      var ports = {};
+      Object.keys(state.forwardedPorts || {}).forEach(function(key) {
+        var p = state.forwardedPorts[key];
+        ports[p.podName + "/" + p.containerName + ":" + p.remotePort] = "localhost:" + p.localPort;
+      });
+      statusRows(document.getElementById("ports"), ports, false);
+    });
+  }
+
+  var filter = document.getElementById("filter");
+  var logs = document.getElementById("logs");
+  var lines = [];
+
+  function showLine(line) {
+    var needle = filter.value.toLowerCase();
+    if (needle === "" || line.toLowerCase().indexOf(needle) >= 0) {
+      logs.appendChild(document.createTextNode(line + "\n"));
+    }
+  }
+
+  filter.oninput = function() {
+    logs.textContent = "";
+    lines.forEach(showLine);
+    logs.scrollTop = logs.scrollHeight;
+  };
+
+  new EventSource("/api/logs").onmessage = function(e) {
+    lines.push(e.data);
+    if (lines.length > 5000) { lines.shift(); }
+    showLine(e.data);
+    logs.scrollTop = logs.scrollHeight;
+  };
+
+  new EventSource("/api/events").onmessage = function(e) {
+    var entry = JSON.parse(e.data);
+    var events = document.getElementById("events");
+    events.insertBefore(text("div", entry.entry), events.firstChild);
+    refresh();
+  };
+
+  refresh();
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,243 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/redact"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestLogBuffer(t *testing.T) {
+	b := newLogBuffer(2)
+
+	b.Write([]byte("\x1b[32mfirst\x1b[0m\nsec"))
+	b.Write([]byte("ond\r\nthird\npartial"))
+	testutil.CheckDeepEqual(t, []string{"second", "third"}, b.lines)
+
+	lines, unsubscribe := b.subscribe()
+	b.Write([]byte(" line\n"))
+	unsubscribe()
+	b.Write([]byte("ignored\n"))
+
+	var received []string
+	for len(lines) > 0 {
+		received = append(received, <-lines)
+	}
+	testutil.CheckDeepEqual(t, []string{"second", "third", "partial line"}, received)
+}
+
+func TestLogBufferRedacts(t *testing.T) {
+	redact.AddValue("s3cr3t")
+	defer redact.Reset()
+
+	b := newLogBuffer(2)
+	b.Write([]byte("token: s3c"))
+	b.Write([]byte("r3t\n"))
+
+	testutil.CheckDeepEqual(t, []string{"token: " + redact.Mask}, b.lines)
+}
+
+func TestServeRebuild(t *testing.T) {
+	rebuilt := ""
+	handler := func(imageName string) error {
+		if imageName != "app" {
+			return errors.New("unknown artifact")
+		}
+		rebuilt = imageName
+		return nil
+	}
+
+	var tests = []struct {
+		description     string
+		method          string
+		url             string
+		host            string
+		origin          string
+		handler         func(string) error
+		expectedStatus  int
+		expectedRebuilt string
+	}{
+		{
+			description:     "rebuild",
+			method:          http.MethodPost,
+			url:             "/api/rebuild?artifact=app",
+			handler:         handler,
+			expectedStatus:  http.StatusAccepted,
+			expectedRebuilt: "app",
+		},
+		{
+			description:    "unknown artifact",
+			method:         http.MethodPost,
+			url:            "/api/rebuild?artifact=other",
+			handler:        handler,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			description:     "same origin",
+			method:          http.MethodPost,
+			url:             "/api/rebuild?artifact=app",
+			origin:          "http://127.0.0.1:50051",
+			handler:         handler,
+			expectedStatus:  http.StatusAccepted,
+			expectedRebuilt: "app",
+		},
+		{
+			description:    "cross-site request",
+			method:         http.MethodPost,
+			url:            "/api/rebuild?artifact=app",
+			origin:         "https://example.com",
+			handler:        handler,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			description:    "other host",
+			method:         http.MethodPost,
+			url:            "/api/rebuild?artifact=app",
+			host:           "attacker.example.com:50051",
+			handler:        handler,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			description:    "GET",
+			method:         http.MethodGet,
+			url:            "/api/rebuild?artifact=app",
+			handler:        handler,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			description:    "no dev loop yet",
+			method:         http.MethodPost,
+			url:            "/api/rebuild?artifact=app",
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			rebuilt = ""
+			OnRebuild(test.handler)
+			defer OnRebuild(nil)
+
+			request := httptest.NewRequest(test.method, test.url, nil)
+			request.Host = "127.0.0.1:50051"
+			if test.host != "" {
+				request.Host = test.host
+			}
+			if test.origin != "" {
+				request.Header.Set("Origin", test.origin)
+			}
+
+			recorder := httptest.NewRecorder()
+			uiHandler().ServeHTTP(recorder, request)
+
+			testutil.CheckDeepEqual(t, test.expectedStatus, recorder.Code)
+			testutil.CheckDeepEqual(t, test.expectedRebuilt, rebuilt)
+		})
+	}
+}
+
+func TestAPISameOrigin(t *testing.T) {
+	var tests = []struct {
+		description    string
+		url            string
+		host           string
+		origin         string
+		expectedStatus int
+	}{
+		{
+			description:    "state from another site",
+			url:            "/api/state",
+			origin:         "https://example.com",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			description:    "state through another host",
+			url:            "/api/state",
+			host:           "attacker.example.com:50051",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			description:    "events from another site",
+			url:            "/api/events",
+			origin:         "https://example.com",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			description:    "events through another host",
+			url:            "/api/events",
+			host:           "attacker.example.com:50051",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			description:    "logs from another site",
+			url:            "/api/logs",
+			origin:         "https://example.com",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			description:    "logs through another host",
+			url:            "/api/logs",
+			host:           "attacker.example.com:50051",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			description:    "logs from the web UI",
+			url:            "/api/logs",
+			origin:         "http://127.0.0.1:50051",
+			expectedStatus: http.StatusOK,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			// Streams end once the client disconnects.
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			request := httptest.NewRequest(http.MethodGet, test.url, nil).WithContext(ctx)
+			request.Host = "127.0.0.1:50051"
+			if test.host != "" {
+				request.Host = test.host
+			}
+			if test.origin != "" {
+				request.Header.Set("Origin", test.origin)
+			}
+
+			recorder := httptest.NewRecorder()
+			uiHandler().ServeHTTP(recorder, request)
+
+			testutil.CheckDeepEqual(t, test.expectedStatus, recorder.Code)
+		})
+	}
+}
+
+func TestServePage(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	uiHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	testutil.CheckDeepEqual(t, http.StatusOK, recorder.Code)
+	testutil.CheckDeepEqual(t, uiPage, recorder.Body.String())
+
+	recorder = httptest.NewRecorder()
+	uiHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/unknown", nil))
+
+	testutil.CheckDeepEqual(t, http.StatusNotFound, recorder.Code)
+}
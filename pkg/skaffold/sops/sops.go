@@ -0,0 +1,90 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sops
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// metadata is the section SOPS adds to the files it encrypts.
+type metadata struct {
+	Sops *struct {
+		Mac     string `yaml:"mac"`
+		Version string `yaml:"version"`
+	} `yaml:"sops"`
+}
+
+// IsEncrypted tells if a yaml or json file was encrypted by SOPS.
+// Only the first document of a multi-document yaml file is looked at.
+func IsEncrypted(path string) bool {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	var m metadata
+	if err := yaml.Unmarshal(buf, &m); err != nil {
+		return false
+	}
+
+	return m.Sops != nil && m.Sops.Mac != "" && m.Sops.Version != ""
+}
+
+// Decrypt decrypts a file with `sops`, as yaml. `sops` finds the keys
+// (KMS, age, PGP...) in the environment and in its own configuration.
+func Decrypt(ctx context.Context, path string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "sops", "--decrypt", "--output-type", "yaml", path)
+
+	buf, err := util.RunCmdOutSensitive(cmd)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decrypting %s with sops", path)
+	}
+	return buf, nil
+}
+
+// DecryptToTempFile decrypts a file to a temporary file, readable only by the user.
+// The returned function removes that temporary file.
+func DecryptToTempFile(ctx context.Context, path string) (string, func(), error) {
+	buf, err := Decrypt(ctx, path)
+	if err != nil {
+		return "", nil, err
+	}
+
+	f, err := ioutil.TempFile("", "skaffold-sops-*.yaml")
+	if err != nil {
+		return "", nil, errors.Wrap(err, "creating temporary file")
+	}
+	remove := func() { os.Remove(f.Name()) }
+
+	_, err = f.Write(buf)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		remove()
+		return "", nil, errors.Wrap(err, "writing decrypted file")
+	}
+
+	return f.Name(), remove, nil
+}
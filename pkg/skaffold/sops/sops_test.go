@@ -0,0 +1,98 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sops
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestIsEncrypted(t *testing.T) {
+	tests := []struct {
+		description string
+		content     string
+		expected    bool
+	}{
+		{
+			description: "encrypted yaml",
+			content: `password: ENC[AES256_GCM,data:8Fg=,type:str]
+sops:
+  mac: ENC[AES256_GCM,data:M2Y=,type:str]
+  version: 3.3.1`,
+			expected: true,
+		},
+		{
+			description: "encrypted json",
+			content:     `{"password": "ENC[AES256_GCM,data:8Fg=,type:str]", "sops": {"mac": "ENC[AES256_GCM,data:M2Y=,type:str]", "version": "3.3.1"}}`,
+			expected:    true,
+		},
+		{
+			description: "plain yaml",
+			content:     "password: secret",
+		},
+		{
+			description: "unrelated sops key",
+			content:     "sops: true",
+		},
+		{
+			description: "invalid yaml",
+			content:     "[",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			tmpDir, cleanup := testutil.NewTempDir(t)
+			defer cleanup()
+			tmpDir.Write("values.yaml", test.content)
+
+			testutil.CheckDeepEqual(t, test.expected, IsEncrypted(tmpDir.Path("values.yaml")))
+		})
+	}
+
+	testutil.CheckDeepEqual(t, false, IsEncrypted("missing.yaml"))
+}
+
+func TestDecryptToTempFile(t *testing.T) {
+	reset := testutil.Override(t, &util.DefaultExecCommand, testutil.NewFakeCmd(t).
+		WithRunOut("sops --decrypt --output-type yaml secrets.yaml", "password: secret"))
+	defer reset()
+
+	path, remove, err := DecryptToTempFile(context.Background(), "secrets.yaml")
+	testutil.CheckError(t, false, err)
+
+	content, err := ioutil.ReadFile(path)
+	testutil.CheckErrorAndDeepEqual(t, false, err, "password: secret", string(content))
+
+	remove()
+	_, err = os.Stat(path)
+	testutil.CheckDeepEqual(t, true, os.IsNotExist(err))
+}
+
+func TestDecryptError(t *testing.T) {
+	reset := testutil.Override(t, &util.DefaultExecCommand, testutil.NewFakeCmd(t).
+		WithRunOutErr("sops --decrypt --output-type yaml secrets.yaml", "", os.ErrPermission))
+	defer reset()
+
+	_, err := Decrypt(context.Background(), "secrets.yaml")
+
+	testutil.CheckError(t, true, err)
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package docker
+
+import (
+	"context"
+	"io"
+	"os/exec"
+	"strings"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/sync"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Syncer syncs files to the local containers running an image, like the ones started by docker compose.
+type Syncer struct{}
+
+func NewSyncer() *Syncer {
+	return &Syncer{}
+}
+
+func (d *Syncer) Sync(ctx context.Context, s *sync.Item) error {
+	if len(s.Copy) == 0 && len(s.Delete) == 0 {
+		return nil
+	}
+
+	containers, err := containersRunning(ctx, s.Image)
+	if err != nil {
+		return err
+	}
+	if len(containers) == 0 {
+		return errors.New("didn't sync any files")
+	}
+
+	for _, container := range containers {
+		if len(s.Copy) > 0 {
+			logrus.Infoln("Copying files:", s.Copy, "to", s.Image)
+
+			for _, cmd := range copyCmds(ctx, container, s.Copy, s.Owners) {
+				if _, err := util.RunCmdOut(cmd); err != nil {
+					return errors.Wrap(err, "copying files")
+				}
+			}
+		}
+
+		if len(s.Delete) > 0 {
+			logrus.Infoln("Deleting files:", s.Delete, "from", s.Image)
+
+			if _, err := util.RunCmdOut(deleteCmd(ctx, container, s.Delete)); err != nil {
+				return errors.Wrap(err, "deleting files")
+			}
+		}
+	}
+
+	return nil
+}
+
+// containersRunning lists the IDs of the containers running an image.
+func containersRunning(ctx context.Context, image string) ([]string, error) {
+	out, err := util.RunCmdOut(exec.CommandContext(ctx, "docker", "ps", "--quiet", "--filter", "ancestor="+image))
+	if err != nil {
+		return nil, errors.Wrap(err, "listing containers")
+	}
+
+	return strings.Fields(string(out)), nil
+}
+
+func deleteCmd(ctx context.Context, container string, files map[string][]string) *exec.Cmd {
+	args := []string{"exec", container, "rm", "-rf", "--"}
+	for _, dsts := range files {
+		args = append(args, dsts...)
+	}
+	return exec.CommandContext(ctx, "docker", args...)
+}
+
+// copyCmds copies the files with their mode. The files without an owner are
+// owned by the user running tar. Files with an owner are copied separately, keeping it.
+func copyCmds(ctx context.Context, container string, files map[string][]string, owners map[string]util.Owner) []*exec.Cmd {
+	owned, notOwned := sync.SplitByOwner(files, owners)
+
+	var cmds []*exec.Cmd
+	if len(notOwned) > 0 {
+		cmds = append(cmds, copyCmd(ctx, container, notOwned, nil, "--no-same-owner"))
+	}
+	if len(owned) > 0 {
+		cmds = append(cmds, copyCmd(ctx, container, owned, owners, "--same-owner"))
+	}
+	return cmds
+}
+
+func copyCmd(ctx context.Context, container string, files map[string][]string, owners map[string]util.Owner, ownerFlag string) *exec.Cmd {
+	// Use "m" flag to touch the files as they are copied and "p" to keep their mode.
+	reader, writer := io.Pipe()
+	copy := exec.CommandContext(ctx, "docker", "exec", "-i", container, "tar", "xmpf", "-", "-C", "/", ownerFlag)
+	copy.Stdin = reader
+	go func() {
+		defer writer.Close()
+
+		if err := util.CreateMappedTarWithOwners(writer, "/", files, owners); err != nil {
+			logrus.Errorln("Error creating tar archive:", err)
+		}
+	}()
+	return copy
+}
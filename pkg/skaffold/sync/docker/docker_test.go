@@ -0,0 +1,64 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package docker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/sync"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestSync(t *testing.T) {
+	tests := []struct {
+		description string
+		item        *sync.Item
+		command     *testutil.FakeCmd
+		shouldErr   bool
+	}{
+		{
+			description: "delete from every container",
+			item:        &sync.Item{Image: "web:abc", Delete: map[string][]string{"index.html": {"/app/index.html"}}},
+			command: testutil.NewFakeCmd(t).
+				WithRunOut("docker ps --quiet --filter ancestor=web:abc", "c1\nc2\n").
+				WithRunOut("docker exec c1 rm -rf -- /app/index.html", "").
+				WithRunOut("docker exec c2 rm -rf -- /app/index.html", ""),
+		},
+		{
+			description: "no running container",
+			item:        &sync.Item{Image: "web:abc", Delete: map[string][]string{"index.html": {"/app/index.html"}}},
+			command:     testutil.FakeRunOut(t, "docker ps --quiet --filter ancestor=web:abc", ""),
+			shouldErr:   true,
+		},
+		{
+			description: "nothing to sync",
+			item:        &sync.Item{Image: "web:abc"},
+			command:     testutil.NewFakeCmd(t),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			defer testutil.Override(t, &util.DefaultExecCommand, test.command)()
+
+			err := NewSyncer().Sync(context.Background(), test.item)
+
+			testutil.CheckError(t, test.shouldErr, err)
+		})
+	}
+}
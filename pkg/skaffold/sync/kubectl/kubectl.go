@@ -21,6 +21,7 @@ import (
 	"io"
 	"os/exec"
 
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/sync"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
 	"github.com/pkg/errors"
@@ -42,7 +43,7 @@ func (k *Syncer) Sync(ctx context.Context, s *sync.Item) error {
 	if len(s.Copy) > 0 {
 		logrus.Infoln("Copying files:", s.Copy, "to", s.Image)
 
-		if err := sync.Perform(ctx, s.Image, s.Copy, copyFileFn, k.namespaces); err != nil {
+		if err := sync.Perform(ctx, s.Image, s.Copy, copyFileFn(s.Owners), k.namespaces); err != nil {
 			return errors.Wrap(err, "copying files")
 		}
 	}
@@ -66,22 +67,39 @@ func deleteFileFn(ctx context.Context, pod v1.Pod, container v1.Container, files
 	for _, dsts := range files {
 		args = append(args, dsts...)
 	}
-	delete := exec.CommandContext(ctx, "kubectl", args...)
+	delete := kubernetes.KubectlCommand(ctx, args...)
 	return []*exec.Cmd{delete}
 }
 
-func copyFileFn(ctx context.Context, pod v1.Pod, container v1.Container, files map[string][]string) []*exec.Cmd {
-	// Use "m" flag to touch the files as they are copied.
+// copyFileFn copies the files with their mode. The files without an owner are
+// owned by the user running tar. Files with an owner are copied separately, keeping it.
+func copyFileFn(owners map[string]util.Owner) func(context.Context, v1.Pod, v1.Container, map[string][]string) []*exec.Cmd {
+	return func(ctx context.Context, pod v1.Pod, container v1.Container, files map[string][]string) []*exec.Cmd {
+		owned, notOwned := sync.SplitByOwner(files, owners)
+
+		var cmds []*exec.Cmd
+		if len(notOwned) > 0 {
+			cmds = append(cmds, copyCmd(ctx, pod, container, notOwned, nil, "--no-same-owner"))
+		}
+		if len(owned) > 0 {
+			cmds = append(cmds, copyCmd(ctx, pod, container, owned, owners, "--same-owner"))
+		}
+		return cmds
+	}
+}
+
+func copyCmd(ctx context.Context, pod v1.Pod, container v1.Container, files map[string][]string, owners map[string]util.Owner, ownerFlag string) *exec.Cmd {
+	// Use "m" flag to touch the files as they are copied and "p" to keep their mode.
 	reader, writer := io.Pipe()
-	copy := exec.CommandContext(ctx, "kubectl", "exec", pod.Name, "--namespace", pod.Namespace, "-c", container.Name, "-i",
-		"--", "tar", "xmf", "-", "-C", "/", "--no-same-owner")
+	copy := kubernetes.KubectlCommand(ctx, "exec", pod.Name, "--namespace", pod.Namespace, "-c", container.Name, "-i",
+		"--", "tar", "xmpf", "-", "-C", "/", ownerFlag)
 	copy.Stdin = reader
 	go func() {
 		defer writer.Close()
 
-		if err := util.CreateMappedTar(writer, "/", files); err != nil {
+		if err := util.CreateMappedTarWithOwners(writer, "/", files, owners); err != nil {
 			logrus.Errorln("Error creating tar archive:", err)
 		}
 	}()
-	return []*exec.Cmd{copy}
+	return copy
 }
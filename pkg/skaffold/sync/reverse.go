@@ -0,0 +1,269 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/watch"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	// For testing
+	runningContainers = listRunningContainers
+)
+
+// ReverseSyncer copies the files generated in containers back to the host,
+// following the `sync.reverse` rules of the artifacts.
+//
+// The files it writes are remembered so that the watcher doesn't sync them
+// back to the containers or rebuild the artifacts: see Filter.
+type ReverseSyncer struct {
+	artifacts  []*latest.Artifact
+	namespaces []string
+
+	lock    sync.Mutex
+	builds  []build.Artifact
+	written map[string]string
+}
+
+// NewReverseSyncer returns a ReverseSyncer, or nil if no artifact has reverse sync rules.
+func NewReverseSyncer(artifacts []*latest.Artifact, namespaces []string) *ReverseSyncer {
+	var withRules []*latest.Artifact
+	for _, a := range artifacts {
+		if a.Sync != nil && len(a.Sync.Reverse) > 0 {
+			withRules = append(withRules, a)
+		}
+	}
+	if len(withRules) == 0 {
+		return nil
+	}
+
+	return &ReverseSyncer{
+		artifacts:  withRules,
+		namespaces: namespaces,
+		written:    map[string]string{},
+	}
+}
+
+// SetBuilds updates the images whose containers are synced.
+func (s *ReverseSyncer) SetBuilds(builds []build.Artifact) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.builds = builds
+}
+
+// Start copies the files every interval, until the context is cancelled.
+func (s *ReverseSyncer) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.SyncAll(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// SyncAll copies the files of all the artifacts once.
+func (s *ReverseSyncer) SyncAll(ctx context.Context) {
+	s.lock.Lock()
+	builds := s.builds
+	s.lock.Unlock()
+
+	for _, a := range s.artifacts {
+		tag := latestTag(a.ImageName, builds)
+		if tag == "" {
+			continue
+		}
+
+		if err := s.syncArtifact(ctx, a, tag); err != nil {
+			logrus.Debugf("Unable to copy generated files for %s: %s", a.ImageName, err)
+		}
+	}
+}
+
+func (s *ReverseSyncer) syncArtifact(ctx context.Context, a *latest.Artifact, tag string) error {
+	containers, err := runningContainers(tag, s.namespaces)
+	if err != nil {
+		return err
+	}
+	if len(containers) == 0 {
+		return nil
+	}
+
+	// All the replicas should have generated the same files.
+	c := containers[0]
+	for _, r := range a.Sync.Reverse {
+		cmd := kubernetes.KubectlCommand(ctx, "exec", c.pod.Name, "--namespace", c.pod.Namespace, "-c", c.container.Name, "--", "tar", "cf", "-", "-C", util.ContainerPath(r.Src), ".")
+		out, err := util.RunCmdOut(cmd)
+		if err != nil {
+			return errors.Wrapf(err, "reading %s", r.Src)
+		}
+
+		copied, err := s.extract(bytes.NewReader(out), filepath.Join(a.Workspace, r.Dest))
+		if err != nil {
+			return errors.Wrapf(err, "copying %s", r.Src)
+		}
+		for _, f := range copied {
+			logrus.Infoln("Copied generated file", f)
+		}
+	}
+
+	return nil
+}
+
+// extract writes the files of a tar archive to a directory, unless they didn't change.
+func (s *ReverseSyncer) extract(r io.Reader, dir string) ([]string, error) {
+	var copied []string
+
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return copied, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := filepath.Clean(filepath.FromSlash(header.Name))
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			logrus.Warnln("Ignoring generated file outside of the destination:", header.Name)
+			continue
+		}
+		path := filepath.Join(dir, name)
+
+		content, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		if current, err := ioutil.ReadFile(path); err == nil && bytes.Equal(current, content) {
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, err
+		}
+		s.remember(path, content)
+		if err := ioutil.WriteFile(path, content, os.FileMode(header.Mode).Perm()); err != nil {
+			return nil, err
+		}
+		copied = append(copied, path)
+	}
+}
+
+func (s *ReverseSyncer) remember(path string, content []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.written[path] = hash(content)
+}
+
+// Filter removes from the events the files that were written by the ReverseSyncer
+// and haven't changed since, so that they don't trigger a sync or a rebuild.
+func (s *ReverseSyncer) Filter(e watch.Events) watch.Events {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return watch.Events{
+		Added:    s.notWritten(e.Added),
+		Modified: s.notWritten(e.Modified),
+		Deleted:  e.Deleted,
+	}
+}
+
+func (s *ReverseSyncer) notWritten(files []string) []string {
+	var changed []string
+
+	for _, f := range files {
+		if written, found := s.written[f]; found {
+			if content, err := ioutil.ReadFile(f); err == nil && hash(content) == written {
+				continue
+			}
+		}
+		changed = append(changed, f)
+	}
+
+	return changed
+}
+
+func hash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+type podContainer struct {
+	pod       v1.Pod
+	container v1.Container
+}
+
+func listRunningContainers(image string, namespaces []string) ([]podContainer, error) {
+	client, err := kubernetes.Client()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting k8s client")
+	}
+
+	var containers []podContainer
+	for _, ns := range namespaces {
+		pods, err := client.CoreV1().Pods(ns).List(meta_v1.ListOptions{})
+		if err != nil {
+			return nil, errors.Wrap(err, "getting pods for namespace "+ns)
+		}
+
+		for _, p := range pods.Items {
+			if p.Status.Phase != v1.PodRunning {
+				continue
+			}
+			for _, c := range p.Spec.Containers {
+				if c.Image == image {
+					containers = append(containers, podContainer{pod: p, container: c})
+				}
+			}
+		}
+	}
+
+	return containers, nil
+}
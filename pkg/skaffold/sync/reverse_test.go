@@ -0,0 +1,110 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/watch"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	v1 "k8s.io/api/core/v1"
+)
+
+func tarball(t *testing.T, files map[string]string) string {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, name := range []string{"./schema.json", "./sub/types.go", "../escape"} {
+		content, found := files[name]
+		if !found {
+			continue
+		}
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		tw.Write([]byte(content))
+	}
+	tw.Close()
+	return buf.String()
+}
+
+func TestNewReverseSyncer(t *testing.T) {
+	testutil.CheckDeepEqual(t, (*ReverseSyncer)(nil), NewReverseSyncer([]*latest.Artifact{{ImageName: "image"}}, nil))
+
+	s := NewReverseSyncer([]*latest.Artifact{
+		{ImageName: "image1"},
+		{ImageName: "image2", Sync: &latest.Sync{Reverse: []*latest.ReverseSyncRule{{Src: "/app/generated", Dest: "generated"}}}},
+	}, nil)
+	testutil.CheckDeepEqual(t, 1, len(s.artifacts))
+}
+
+func TestReverseSync(t *testing.T) {
+	tmpDir, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+	tmpDir.Write("generated/schema.json", "{}")
+
+	reset := testutil.Override(t, &runningContainers, func(image string, namespaces []string) ([]podContainer, error) {
+		pod := v1.Pod{}
+		pod.Name, pod.Namespace = "app-1234", "default"
+		return []podContainer{{pod: pod, container: v1.Container{Name: "app", Image: image}}}, nil
+	})
+	defer reset()
+
+	content := tarball(t, map[string]string{
+		"./schema.json":  "{}",
+		"./sub/types.go": "package sub",
+		"../escape":      "ignored",
+	})
+	resetCmd := testutil.Override(t, &util.DefaultExecCommand, testutil.FakeRunOut(t, "kubectl exec app-1234 --namespace default -c app -- tar cf - -C /app/generated .", content))
+	defer resetCmd()
+
+	artifact := &latest.Artifact{
+		ImageName: "image",
+		Workspace: tmpDir.Root(),
+		Sync:      &latest.Sync{Reverse: []*latest.ReverseSyncRule{{Src: "/app/generated", Dest: "generated"}}},
+	}
+	s := NewReverseSyncer([]*latest.Artifact{artifact}, []string{"default"})
+	s.SetBuilds([]build.Artifact{{ImageName: "image", Tag: "image:tag"}})
+	s.SyncAll(context.Background())
+
+	testutil.CheckDeepEqual(t, "package sub", readFile(t, tmpDir.Path("generated/sub/types.go")))
+	testutil.CheckDeepEqual(t, "{}", readFile(t, tmpDir.Path("generated/schema.json")))
+
+	// Only the copied file is filtered out, until it's modified locally.
+	filtered := s.Filter(watch.Events{
+		Modified: []string{tmpDir.Path("generated/sub/types.go"), tmpDir.Path("generated/schema.json")},
+	})
+	testutil.CheckDeepEqual(t, watch.Events{Modified: []string{tmpDir.Path("generated/schema.json")}}, filtered)
+
+	tmpDir.Write("generated/sub/types.go", "package other")
+	filtered = s.Filter(watch.Events{Modified: []string{tmpDir.Path("generated/sub/types.go")}})
+	testutil.CheckDeepEqual(t, watch.Events{Modified: []string{tmpDir.Path("generated/sub/types.go")}}, filtered)
+}
+
+func readFile(t *testing.T, path string) string {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
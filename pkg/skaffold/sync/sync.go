@@ -26,6 +26,7 @@ import (
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/docker"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/jib"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
@@ -41,6 +42,9 @@ import (
 var (
 	// WorkingDir is here for testing
 	WorkingDir = retrieveWorkingDir
+
+	// CompileAndSyncMap is here for testing
+	CompileAndSyncMap = jib.CompileAndSyncMap
 )
 
 type Syncer interface {
@@ -51,11 +55,17 @@ type Item struct {
 	Image  string
 	Copy   map[string][]string
 	Delete map[string][]string
+	// Owners are the owners of the copied files, by destination, for the sync rules that set one.
+	Owners map[string]util.Owner
 }
 
-func NewItem(a *latest.Artifact, e watch.Events, builds []build.Artifact, insecureRegistries map[string]bool) (*Item, error) {
+func NewItem(ctx context.Context, a *latest.Artifact, e watch.Events, builds []build.Artifact, insecureRegistries map[string]bool) (*Item, error) {
 	// If there are no changes, short circuit and don't sync anything
-	if !e.HasChanged() || a.Sync == nil || len(a.Sync.Manual) == 0 {
+	if !e.HasChanged() || a.Sync == nil {
+		return nil, nil
+	}
+	auto := a.Sync.Auto != nil && *a.Sync.Auto
+	if len(a.Sync.Manual) == 0 && !auto {
 		return nil, nil
 	}
 
@@ -64,6 +74,10 @@ func NewItem(a *latest.Artifact, e watch.Events, builds []build.Artifact, insecu
 		return nil, fmt.Errorf("could not find latest tag for image %s in builds: %v", a.ImageName, builds)
 	}
 
+	if auto {
+		return autoSyncItem(ctx, a, e, tag), nil
+	}
+
 	containerWd, err := WorkingDir(tag, insecureRegistries)
 	if err != nil {
 		return nil, errors.Wrapf(err, "retrieving working dir for %s", tag)
@@ -84,13 +98,95 @@ func NewItem(a *latest.Artifact, e watch.Events, builds []build.Artifact, insecu
 		return nil, nil
 	}
 
+	owners, err := ownersOf(a.Workspace, containerWd, a.Sync.Manual, toCopy)
+	if err != nil {
+		return nil, errors.Wrap(err, "finding the owners of added, modified files")
+	}
+
 	return &Item{
 		Image:  tag,
 		Copy:   toCopy,
 		Delete: toDelete,
+		Owners: owners,
 	}, nil
 }
 
+// autoSyncItem compiles the changed sources of a Jib artifact and returns the compiled
+// files to sync. The image is rebuilt if files are deleted, if other files than sources
+// are changed or if the compilation fails.
+func autoSyncItem(ctx context.Context, a *latest.Artifact, e watch.Events, tag string) *Item {
+	if len(e.Deleted) > 0 {
+		logrus.Infoln("Files were deleted. Skipping sync")
+		return nil
+	}
+	for _, f := range append(e.Added, e.Modified...) {
+		if !jib.IsSyncableSource(a.Workspace, f) {
+			logrus.Infof("Changed file %s is not a source file. Skipping sync", f)
+			return nil
+		}
+	}
+
+	toCopy, err := CompileAndSyncMap(ctx, a)
+	if err != nil {
+		logrus.Warnf("Unable to compile %s, rebuilding the image: %s", a.ImageName, err)
+		return nil
+	}
+	if len(toCopy) == 0 {
+		logrus.Infoln("No compiled file changed. Skipping sync")
+		return nil
+	}
+
+	return &Item{
+		Image:  tag,
+		Copy:   toCopy,
+		Delete: map[string][]string{},
+	}
+}
+
+// ownersOf finds the owners of the copied files for the sync rules that set one.
+func ownersOf(contextWd, containerWd string, syncRules []*latest.SyncRule, toCopy map[string][]string) (map[string]util.Owner, error) {
+	var owners map[string]util.Owner
+
+	for _, r := range syncRules {
+		if r.UID == nil && r.GID == nil {
+			continue
+		}
+		owner := ruleOwner(r)
+
+		for f := range toCopy {
+			relPath, err := filepath.Rel(contextWd, f)
+			if err != nil {
+				return nil, err
+			}
+
+			dsts, err := matchSyncRules([]*latest.SyncRule{r}, relPath, containerWd)
+			if err != nil {
+				return nil, err
+			}
+			for _, dst := range dsts {
+				if owners == nil {
+					owners = map[string]util.Owner{}
+				}
+				owners[dst] = owner
+			}
+		}
+	}
+
+	return owners, nil
+}
+
+func ruleOwner(r *latest.SyncRule) util.Owner {
+	var owner util.Owner
+	if r.UID != nil {
+		owner.UID = *r.UID
+		owner.GID = *r.UID
+	}
+	if r.GID != nil {
+		owner.GID = *r.GID
+	}
+	return owner
+}
+
 func retrieveWorkingDir(tagged string, insecureRegistries map[string]bool) (string, error) {
 	var cf *registry_v1.ConfigFile
 	var err error
@@ -107,6 +203,9 @@ func retrieveWorkingDir(tagged string, insecureRegistries map[string]bool) (stri
 	}
 
 	if cf.Config.WorkingDir == "" {
+		if cf.OS == "windows" {
+			return `C:\`, nil
+		}
 		return "/", nil
 	}
 	return cf.Config.WorkingDir, nil
@@ -156,19 +255,74 @@ func matchSyncRules(syncRules []*latest.SyncRule, relPath, containerWd string) (
 			continue
 		}
 
+		// Paths in Windows containers are written with forward slashes too.
+		dest := util.ContainerPath(r.Dest)
+		if util.IsWindowsContainerPath(containerWd) {
+			dest = strings.Replace(r.Dest, `\`, "/", -1)
+		}
+
 		wd := ""
-		if !path.IsAbs(r.Dest) {
+		if !util.IsAbsContainerPath(dest) {
 			// Convert relative destinations to absolute via the working dir in the container.
-			wd = containerWd
+			wd = util.ContainerPath(containerWd)
 		}
 
 		// Map the paths as a tree from the prefix.
 		subPath := strings.TrimPrefix(filepath.ToSlash(relPath), r.Strip)
-		dsts = append(dsts, path.Join(wd, r.Dest, subPath))
+		dsts = append(dsts, path.Join(wd, dest, subPath))
 	}
 	return dsts, nil
 }
 
+// Match is a manual sync rule that matches a changed file, with the destination of the file in the container.
+type Match struct {
+	Rule *latest.SyncRule
+	Dest string
+}
+
+// MatchingRules finds the manual sync rules of an artifact that match a changed file, in the artifact's context.
+// Relative destinations are resolved against the container's working dir, unless it's empty.
+func MatchingRules(a *latest.Artifact, file, containerWd string) ([]Match, error) {
+	if a.Sync == nil {
+		return nil, nil
+	}
+
+	relPath, err := filepath.Rel(a.Workspace, file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "changed file %s can't be found relative to context %s", file, a.Workspace)
+	}
+
+	var matches []Match
+	for _, r := range a.Sync.Manual {
+		dsts, err := matchSyncRules([]*latest.SyncRule{r}, relPath, containerWd)
+		if err != nil {
+			return nil, err
+		}
+		for _, dst := range dsts {
+			matches = append(matches, Match{Rule: r, Dest: dst})
+		}
+	}
+	return matches, nil
+}
+
+// SplitByOwner splits the files to copy between those that have an owner and those that don't.
+func SplitByOwner(files map[string][]string, owners map[string]util.Owner) (map[string][]string, map[string][]string) {
+	owned := map[string][]string{}
+	notOwned := map[string][]string{}
+
+	for src, dsts := range files {
+		for _, dst := range dsts {
+			if _, found := owners[dst]; found {
+				owned[src] = append(owned[src], dst)
+			} else {
+				notOwned[src] = append(notOwned[src], dst)
+			}
+		}
+	}
+
+	return owned, notOwned
+}
+
 func Perform(ctx context.Context, image string, files map[string][]string, cmdFn func(context.Context, v1.Pod, v1.Container, map[string][]string) []*exec.Cmd, namespaces []string) error {
 	if len(files) == 0 {
 		return nil
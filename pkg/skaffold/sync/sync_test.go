@@ -17,6 +17,7 @@ package sync
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os/exec"
 	"path/filepath"
@@ -36,6 +37,7 @@ import (
 )
 
 func TestNewSyncItem(t *testing.T) {
+	uid := 1000
 	var tests = []struct {
 		description string
 		artifact    *latest.Artifact
@@ -71,6 +73,39 @@ func TestNewSyncItem(t *testing.T) {
 				Delete: map[string][]string{},
 			},
 		},
+		{
+			description: "match copy with owner",
+			artifact: &latest.Artifact{
+				ImageName: "test",
+				Sync: &latest.Sync{
+					Manual: []*latest.SyncRule{
+						{Src: "*.sh", Dest: "scripts", UID: &uid},
+						{Src: "*.html", Dest: "."},
+					},
+				},
+				Workspace: ".",
+			},
+			builds: []build.Artifact{
+				{
+					ImageName: "test",
+					Tag:       "test:123",
+				},
+			},
+			evt: watch.Events{
+				Added: []string{"index.html", "run.sh"},
+			},
+			expected: &Item{
+				Image: "test:123",
+				Copy: map[string][]string{
+					"index.html": {"index.html"},
+					"run.sh":     {"scripts/run.sh"},
+				},
+				Delete: map[string][]string{},
+				Owners: map[string]util.Owner{
+					"scripts/run.sh": {UID: 1000, GID: 1000},
+				},
+			},
+		},
 		{
 			description: "no tag for image",
 			artifact: &latest.Artifact{
@@ -392,13 +427,78 @@ func TestNewSyncItem(t *testing.T) {
 			})
 			defer reset()
 
-			actual, err := NewItem(test.artifact, test.evt, test.builds, map[string]bool{})
+			actual, err := NewItem(context.Background(), test.artifact, test.evt, test.builds, map[string]bool{})
 
 			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.expected, actual)
 		})
 	}
 }
 
+func TestNewItemAutoSync(t *testing.T) {
+	compiled := map[string][]string{"target/classes/Main.class": {"/app/classes/Main.class"}}
+
+	var tests = []struct {
+		description string
+		evt         watch.Events
+		compiled    map[string][]string
+		err         error
+		expected    *Item
+	}{
+		{
+			description: "compiled sources",
+			evt:         watch.Events{Modified: []string{filepath.Join("src", "main", "java", "Main.java")}},
+			compiled:    compiled,
+			expected: &Item{
+				Image:  "test:123",
+				Copy:   compiled,
+				Delete: map[string][]string{},
+			},
+		},
+		{
+			description: "build file changed",
+			evt:         watch.Events{Modified: []string{filepath.Join("src", "main", "java", "Main.java"), "pom.xml"}},
+			compiled:    compiled,
+		},
+		{
+			description: "deleted source",
+			evt:         watch.Events{Deleted: []string{filepath.Join("src", "main", "java", "Main.java")}},
+			compiled:    compiled,
+		},
+		{
+			description: "compilation error",
+			evt:         watch.Events{Modified: []string{filepath.Join("src", "main", "java", "Main.java")}},
+			err:         errors.New("compilation failed"),
+		},
+		{
+			description: "nothing compiled",
+			evt:         watch.Events{Modified: []string{filepath.Join("src", "main", "java", "Main.java")}},
+			compiled:    map[string][]string{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			reset := testutil.Override(t, &CompileAndSyncMap, func(context.Context, *latest.Artifact) (map[string][]string, error) {
+				return test.compiled, test.err
+			})
+			defer reset()
+
+			artifact := &latest.Artifact{
+				ImageName: "test",
+				Workspace: ".",
+				Sync:      &latest.Sync{Auto: util.BoolPtr(true)},
+				ArtifactType: latest.ArtifactType{
+					JibMavenArtifact: &latest.JibMavenArtifact{},
+				},
+			}
+			builds := []build.Artifact{{ImageName: "test", Tag: "test:123"}}
+
+			actual, err := NewItem(context.Background(), artifact, test.evt, builds, map[string]bool{})
+
+			testutil.CheckErrorAndDeepEqual(t, false, err, test.expected, actual)
+		})
+	}
+}
+
 func TestIntersect(t *testing.T) {
 	var tests = []struct {
 		description string
@@ -434,6 +534,28 @@ func TestIntersect(t *testing.T) {
 				"index.html": {"/html/index.html"},
 			},
 		},
+		{
+			description: "windows container with relative destination",
+			files:       []string{filepath.Join("static", "index.html")},
+			workingDir:  `C:\app`,
+			syncRules: []*latest.SyncRule{
+				{Src: filepath.Join("static", "*.html"), Dest: `static\html`, Strip: "static/"},
+			},
+			expected: map[string][]string{
+				filepath.Join("static", "index.html"): {"C:/app/static/html/index.html"},
+			},
+		},
+		{
+			description: "windows container with absolute destination",
+			files:       []string{"index.html"},
+			workingDir:  `C:\app`,
+			syncRules: []*latest.SyncRule{
+				{Src: "*.html", Dest: `D:\www`},
+			},
+			expected: map[string][]string{
+				"index.html": {"D:/www/index.html"},
+			},
+		},
 		{
 			description: "file not in . copies to correct destination",
 			files:       []string{filepath.Join("node", "server.js")},
@@ -464,6 +586,54 @@ func TestIntersect(t *testing.T) {
 	}
 }
 
+func TestMatchingRules(t *testing.T) {
+	html := &latest.SyncRule{Src: filepath.Join("static", "*.html"), Dest: "/html", Strip: "static/"}
+	all := &latest.SyncRule{Src: filepath.Join("static", "**"), Dest: "assets"}
+
+	var tests = []struct {
+		description string
+		file        string
+		workingDir  string
+		expected    []Match
+	}{
+		{
+			description: "several rules match",
+			file:        filepath.Join("app", "static", "index.html"),
+			expected: []Match{
+				{Rule: html, Dest: "/html/index.html"},
+				{Rule: all, Dest: "assets/static/index.html"},
+			},
+		},
+		{
+			description: "relative destination with working dir",
+			file:        filepath.Join("app", "static", "app.css"),
+			workingDir:  "/usr/src",
+			expected: []Match{
+				{Rule: all, Dest: "/usr/src/assets/static/app.css"},
+			},
+		},
+		{
+			description: "no rule matches",
+			file:        filepath.Join("app", "main.go"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			artifact := &latest.Artifact{
+				Workspace: "app",
+				Sync: &latest.Sync{
+					Manual: []*latest.SyncRule{html, all},
+				},
+			}
+
+			matches, err := MatchingRules(artifact, test.file, test.workingDir)
+
+			testutil.CheckErrorAndDeepEqual(t, false, err, test.expected, matches)
+		})
+	}
+}
+
 type TestCmdRecorder struct {
 	cmds []string
 	err  error
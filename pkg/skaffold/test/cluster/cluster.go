@@ -0,0 +1,129 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+const (
+	defaultTimeout    = 10 * time.Minute
+	testContainerName = "test"
+)
+
+var (
+	// For testing
+	streamLogs = streamPodLogs
+)
+
+// Test runs the test command in a pod using the given image, streams its logs
+// and waits for it to complete. The test fails if the pod fails.
+func (tr *Runner) Test(ctx context.Context, out io.Writer, image string) error {
+	logrus.Infof("Running cluster test %v for image %s", tr.test.Command, image)
+
+	client, err := kubernetes.Client()
+	if err != nil {
+		return errors.Wrap(err, "getting kubernetes client")
+	}
+	pods := client.CoreV1().Pods(tr.namespace)
+
+	pod, err := pods.Create(tr.testPod(image))
+	if err != nil {
+		return errors.Wrap(err, "creating test pod")
+	}
+	defer func() {
+		if err := pods.Delete(pod.Name, &metav1.DeleteOptions{
+			GracePeriodSeconds: new(int64),
+		}); err != nil {
+			logrus.Warnf("deleting test pod %s: %s", pod.Name, err)
+		}
+	}()
+
+	waitForLogs := streamLogs(out, pod.Name, pods)
+	err = kubernetes.WaitForPodComplete(ctx, pods, pod.Name, tr.timeout())
+	waitForLogs()
+
+	if err != nil {
+		return errors.Wrapf(err, "running cluster test %v", tr.test.Command)
+	}
+	return nil
+}
+
+func (tr *Runner) testPod(image string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "skaffold-test-" + util.RandomID()[:16],
+		},
+		Spec: v1.PodSpec{
+			RestartPolicy: v1.RestartPolicyNever,
+			Containers: []v1.Container{{
+				Name:    testContainerName,
+				Image:   image,
+				Command: tr.test.Command,
+			}},
+		},
+	}
+}
+
+func (tr *Runner) timeout() time.Duration {
+	if tr.test.TimeoutSeconds > 0 {
+		return time.Duration(tr.test.TimeoutSeconds) * time.Second
+	}
+	return defaultTimeout
+}
+
+func streamPodLogs(out io.Writer, name string, pods corev1.PodInterface) func() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	var retry int32 = 1
+	go func() {
+		defer wg.Done()
+
+		for atomic.LoadInt32(&retry) == 1 {
+			r, err := pods.GetLogs(name, &v1.PodLogOptions{
+				Follow:    true,
+				Container: testContainerName,
+			}).Stream()
+			if err != nil {
+				logrus.Debugln("unable to get test pod logs:", err)
+				time.Sleep(1 * time.Second)
+				continue
+			}
+
+			io.Copy(out, r)
+			return
+		}
+	}()
+
+	return func() {
+		atomic.StoreInt32(&retry, 0)
+		wg.Wait()
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	k8s "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestNewRunner(t *testing.T) {
+	testutil.CheckDeepEqual(t, "default", NewRunner(latest.ClusterTest{}, "").namespace)
+	testutil.CheckDeepEqual(t, "dev", NewRunner(latest.ClusterTest{}, "dev").namespace)
+	testutil.CheckDeepEqual(t, "tests", NewRunner(latest.ClusterTest{Namespace: "tests"}, "dev").namespace)
+}
+
+func TestClusterTest(t *testing.T) {
+	tests := []struct {
+		description string
+		phase       v1.PodPhase
+		shouldErr   bool
+	}{
+		{
+			description: "success",
+			phase:       v1.PodSucceeded,
+		},
+		{
+			description: "failure",
+			phase:       v1.PodFailed,
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			var created *v1.Pod
+			client := fake.NewSimpleClientset()
+			client.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				created = action.(k8stesting.CreateAction).GetObject().(*v1.Pod)
+				return false, nil, nil
+			})
+			client.PrependWatchReactor("pods", func(k8stesting.Action) (bool, watch.Interface, error) {
+				w := watch.NewFake()
+				go w.Modify(&v1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: created.Name},
+					Status:     v1.PodStatus{Phase: test.phase},
+				})
+				return true, w, nil
+			})
+
+			reset := testutil.Override(t, &kubernetes.Client, func() (k8s.Interface, error) { return client, nil })
+			defer reset()
+			resetLogs := testutil.Override(t, &streamLogs, func(io.Writer, string, corev1.PodInterface) func() { return func() {} })
+			defer resetLogs()
+
+			runner := NewRunner(latest.ClusterTest{Command: []string{"go", "test"}}, "test")
+			err := runner.Test(context.Background(), ioutil.Discard, "image:tag")
+
+			testutil.CheckError(t, test.shouldErr, err)
+			testutil.CheckDeepEqual(t, "image:tag", created.Spec.Containers[0].Image)
+			testutil.CheckDeepEqual(t, []string{"go", "test"}, created.Spec.Containers[0].Command)
+
+			// The test pod is deleted
+			pods, _ := client.CoreV1().Pods("test").List(metav1.ListOptions{})
+			testutil.CheckDeepEqual(t, 0, len(pods.Items))
+		})
+	}
+}
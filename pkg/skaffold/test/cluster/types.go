@@ -0,0 +1,40 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import "github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+
+// Runner runs a test command in the cluster, in a pod using the image under test.
+type Runner struct {
+	test      latest.ClusterTest
+	namespace string
+}
+
+// NewRunner creates a new cluster.Runner.
+func NewRunner(test latest.ClusterTest, namespace string) *Runner {
+	if test.Namespace != "" {
+		namespace = test.Namespace
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	return &Runner{
+		test:      test,
+		namespace: namespace,
+	}
+}
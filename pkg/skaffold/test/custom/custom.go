@@ -0,0 +1,45 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package custom
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Test runs the custom test command with the image passed in `$IMAGE`.
+func (tr *Runner) Test(ctx context.Context, out io.Writer, image string) error {
+	logrus.Infof("Running custom test %q for image %s", tr.test.Command, image)
+
+	cmd := util.ShellCommand(ctx, tr.test.Command)
+	cmd.Dir = tr.workingDir
+	cmd.Env = append(util.OSEnviron(), fmt.Sprintf("%s=%s", constants.Image, image))
+	cmd.Stdout = out
+	cmd.Stderr = out
+
+	if err := util.RunCmd(cmd); err != nil {
+		return errors.Wrapf(err, "running custom test %q", tr.test.Command)
+	}
+
+	return nil
+}
@@ -34,6 +34,7 @@ func (tr *Runner) Test(ctx context.Context, out io.Writer, image string) error {
 	for _, f := range tr.testFiles {
 		args = append(args, "--config", f)
 	}
+	args = append(args, tr.extraArgs...)
 
 	cmd := exec.CommandContext(ctx, "container-structure-test", args...)
 	cmd.Stdout = out
@@ -18,11 +18,13 @@ package structure
 
 type Runner struct {
 	testFiles []string
+	extraArgs []string
 }
 
 // NewRunner creates a new structure.Runner.
-func NewRunner(files []string) *Runner {
+func NewRunner(files []string, extraArgs []string) *Runner {
 	return &Runner{
 		testFiles: files,
+		extraArgs: extraArgs,
 	}
 }
@@ -17,12 +17,16 @@ limitations under the License.
 package test
 
 import (
+	"bytes"
 	"context"
 	"io"
+	"sync"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
 	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/test/cluster"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/test/custom"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/test/structure"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
 
@@ -36,6 +40,7 @@ func NewTester(runCtx *runcontext.RunContext) Tester {
 	return FullTester{
 		testCases:  runCtx.Cfg.Test,
 		workingDir: runCtx.WorkingDir,
+		namespace:  runCtx.Opts.Namespace,
 	}
 }
 
@@ -50,6 +55,15 @@ func (t FullTester) TestDependencies() ([]string, error) {
 		}
 
 		deps = append(deps, files...)
+
+		for _, c := range test.CustomTests {
+			files, err := util.ExpandPathsGlob(t.workingDir, c.Dependencies)
+			if err != nil {
+				return nil, errors.Wrap(err, "expanding custom test dependencies")
+			}
+
+			deps = append(deps, files...)
+		}
 	}
 
 	return deps, nil
@@ -58,9 +72,45 @@ func (t FullTester) TestDependencies() ([]string, error) {
 // Test is the top level testing execution call. It serves as the
 // entrypoint to all individual tests.
 func (t FullTester) Test(ctx context.Context, out io.Writer, bRes []build.Artifact) error {
+	if err := t.runAllStructureTests(ctx, out, bRes); err != nil {
+		return errors.Wrap(err, "running structure tests")
+	}
+
 	for _, test := range t.testCases {
-		if err := t.runStructureTests(ctx, out, bRes, test); err != nil {
-			return errors.Wrap(err, "running structure tests")
+		if err := t.runCustomTests(ctx, out, bRes, test); err != nil {
+			return errors.Wrap(err, "running custom tests")
+		}
+
+		if err := t.runClusterTests(ctx, out, bRes, test); err != nil {
+			return errors.Wrap(err, "running cluster tests")
+		}
+	}
+
+	return nil
+}
+
+// runAllStructureTests runs the structure tests of all the artifacts concurrently.
+// Their output is printed in order, once they all completed.
+func (t FullTester) runAllStructureTests(ctx context.Context, out io.Writer, bRes []build.Artifact) error {
+	outputs := make([]bytes.Buffer, len(t.testCases))
+	errs := make([]error, len(t.testCases))
+
+	var wg sync.WaitGroup
+	for i, test := range t.testCases {
+		wg.Add(1)
+		go func(i int, test *latest.TestCase) {
+			defer wg.Done()
+			errs[i] = t.runStructureTests(ctx, &outputs[i], bRes, test)
+		}(i, test)
+	}
+	wg.Wait()
+
+	for i := range t.testCases {
+		if _, err := io.Copy(out, &outputs[i]); err != nil {
+			return errors.Wrap(err, "writing test output")
+		}
+		if errs[i] != nil {
+			return errs[i]
 		}
 	}
 
@@ -79,10 +129,36 @@ func (t FullTester) runStructureTests(ctx context.Context, out io.Writer, bRes [
 
 	fqn := resolveArtifactImageTag(testCase.ImageName, bRes)
 
-	runner := structure.NewRunner(files)
+	runner := structure.NewRunner(files, testCase.StructureTestsArgs)
 	return runner.Test(ctx, out, fqn)
 }
 
+func (t FullTester) runCustomTests(ctx context.Context, out io.Writer, bRes []build.Artifact, testCase *latest.TestCase) error {
+	fqn := resolveArtifactImageTag(testCase.ImageName, bRes)
+
+	for _, c := range testCase.CustomTests {
+		runner := custom.NewRunner(c, t.workingDir)
+		if err := runner.Test(ctx, out, fqn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t FullTester) runClusterTests(ctx context.Context, out io.Writer, bRes []build.Artifact, testCase *latest.TestCase) error {
+	fqn := resolveArtifactImageTag(testCase.ImageName, bRes)
+
+	for _, c := range testCase.ClusterTests {
+		runner := cluster.NewRunner(c, t.namespace)
+		if err := runner.Test(ctx, out, fqn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func resolveArtifactImageTag(imageName string, bRes []build.Artifact) string {
 	for _, res := range bRes {
 		if imageName == res.ImageName {
@@ -20,9 +20,14 @@ import (
 	"context"
 	"errors"
 	"io/ioutil"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/config"
 	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
@@ -31,7 +36,8 @@ import (
 
 func TestNoTestDependencies(t *testing.T) {
 	runCtx := &runcontext.RunContext{
-		Cfg: &latest.Pipeline{},
+		Opts: &config.SkaffoldOptions{},
+		Cfg:  &latest.Pipeline{},
 	}
 
 	deps, err := NewTester(runCtx).TestDependencies()
@@ -48,6 +54,7 @@ func TestTestDependencies(t *testing.T) {
 	tmpDir.Write("test3.yaml", "")
 
 	runCtx := &runcontext.RunContext{
+		Opts:       &config.SkaffoldOptions{},
 		WorkingDir: tmpDir.Root(),
 		Cfg: &latest.Pipeline{
 			Test: []*latest.TestCase{
@@ -66,7 +73,8 @@ func TestTestDependencies(t *testing.T) {
 
 func TestNoTest(t *testing.T) {
 	runCtx := &runcontext.RunContext{
-		Cfg: &latest.Pipeline{},
+		Opts: &config.SkaffoldOptions{},
+		Cfg:  &latest.Pipeline{},
 	}
 
 	err := NewTester(runCtx).Test(context.Background(), ioutil.Discard, nil)
@@ -74,6 +82,24 @@ func TestNoTest(t *testing.T) {
 	testutil.CheckError(t, false, err)
 }
 
+// concurrentCmd records the commands it runs, that can be run concurrently and in any order.
+type concurrentCmd struct {
+	sync.Mutex
+	commands []string
+}
+
+func (c *concurrentCmd) RunCmd(cmd *exec.Cmd) error {
+	c.Lock()
+	defer c.Unlock()
+
+	c.commands = append(c.commands, strings.Join(cmd.Args, " "))
+	return nil
+}
+
+func (c *concurrentCmd) RunCmdOut(cmd *exec.Cmd) ([]byte, error) {
+	return nil, c.RunCmd(cmd)
+}
+
 func TestTestSuccess(t *testing.T) {
 	tmpDir, cleanup := testutil.NewTempDir(t)
 	defer cleanup()
@@ -82,13 +108,12 @@ func TestTestSuccess(t *testing.T) {
 	tmpDir.Write("tests/test2.yaml", "")
 	tmpDir.Write("test3.yaml", "")
 
-	defer func(c util.Command) { util.DefaultExecCommand = c }(util.DefaultExecCommand)
-	util.DefaultExecCommand = testutil.
-		NewFakeCmd(t).
-		WithRun("container-structure-test test -v warn --image TAG --config " + tmpDir.Path("tests/test1.yaml") + " --config " + tmpDir.Path("tests/test2.yaml")).
-		WithRun("container-structure-test test -v warn --image TAG --config " + tmpDir.Path("test3.yaml"))
+	cmd := &concurrentCmd{}
+	reset := testutil.Override(t, &util.DefaultExecCommand, cmd)
+	defer reset()
 
 	runCtx := &runcontext.RunContext{
+		Opts:       &config.SkaffoldOptions{},
 		WorkingDir: tmpDir.Root(),
 		Cfg: &latest.Pipeline{
 			Test: []*latest.TestCase{
@@ -98,8 +123,9 @@ func TestTestSuccess(t *testing.T) {
 				},
 				{},
 				{
-					ImageName:      "image",
-					StructureTests: []string{"test3.yaml"},
+					ImageName:          "image",
+					StructureTests:     []string{"test3.yaml"},
+					StructureTestsArgs: []string{"--pull"},
 				},
 			},
 		},
@@ -110,7 +136,11 @@ func TestTestSuccess(t *testing.T) {
 		Tag:       "TAG",
 	}})
 
-	testutil.CheckError(t, false, err)
+	sort.Strings(cmd.commands)
+	testutil.CheckErrorAndDeepEqual(t, false, err, []string{
+		"container-structure-test test -v warn --image TAG --config " + tmpDir.Path("test3.yaml") + " --pull",
+		"container-structure-test test -v warn --image TAG --config " + tmpDir.Path("tests/test1.yaml") + " --config " + tmpDir.Path("tests/test2.yaml"),
+	}, cmd.commands)
 }
 
 func TestTestFailure(t *testing.T) {
@@ -125,6 +155,7 @@ func TestTestFailure(t *testing.T) {
 		WithRunErr("container-structure-test test -v warn --image broken-image --config "+tmpDir.Path("test.yaml"), errors.New("FAIL"))
 
 	runCtx := &runcontext.RunContext{
+		Opts:       &config.SkaffoldOptions{},
 		WorkingDir: tmpDir.Root(),
 		Cfg: &latest.Pipeline{
 			Test: []*latest.TestCase{
@@ -140,3 +171,81 @@ func TestTestFailure(t *testing.T) {
 
 	testutil.CheckError(t, true, err)
 }
+
+func TestCustomTestDependencies(t *testing.T) {
+	tmpDir, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+
+	tmpDir.Write("smoke-test.sh", "")
+	tmpDir.Write("fixtures/data.json", "")
+
+	runCtx := &runcontext.RunContext{
+		Opts:       &config.SkaffoldOptions{},
+		WorkingDir: tmpDir.Root(),
+		Cfg: &latest.Pipeline{
+			Test: []*latest.TestCase{
+				{CustomTests: []latest.CustomTest{{
+					Command:      "./smoke-test.sh",
+					Dependencies: []string{"smoke-test.sh", "fixtures/*"},
+				}}},
+			},
+		},
+	}
+
+	deps, err := NewTester(runCtx).TestDependencies()
+
+	expectedDeps := tmpDir.Paths("fixtures/data.json", "smoke-test.sh")
+	testutil.CheckErrorAndDeepEqual(t, false, err, expectedDeps, deps)
+}
+
+func TestCustomTests(t *testing.T) {
+	shell := func(command string) string {
+		return strings.Join(util.ShellCommand(context.Background(), command).Args, " ")
+	}
+
+	tests := []struct {
+		description string
+		command     util.Command
+		shouldErr   bool
+	}{
+		{
+			description: "success",
+			command: testutil.NewFakeCmd(t).
+				WithRun(shell("./smoke-test.sh $IMAGE")).
+				WithRun(shell("curl --fail localhost")),
+		},
+		{
+			description: "failure",
+			command:     testutil.FakeRunErr(t, shell("./smoke-test.sh $IMAGE"), errors.New("FAIL")),
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			reset := testutil.Override(t, &util.DefaultExecCommand, test.command)
+			defer reset()
+
+			runCtx := &runcontext.RunContext{
+				Opts: &config.SkaffoldOptions{},
+				Cfg: &latest.Pipeline{
+					Test: []*latest.TestCase{
+						{
+							ImageName: "image",
+							CustomTests: []latest.CustomTest{
+								{Command: "./smoke-test.sh $IMAGE"},
+								{Command: "curl --fail localhost"},
+							},
+						},
+					},
+				},
+			}
+
+			err := NewTester(runCtx).Test(context.Background(), ioutil.Discard, []build.Artifact{{
+				ImageName: "image",
+				Tag:       "TAG",
+			}})
+
+			testutil.CheckError(t, test.shouldErr, err)
+		})
+	}
+}
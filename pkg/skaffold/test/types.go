@@ -44,6 +44,7 @@ type Tester interface {
 type FullTester struct {
 	testCases  []*latest.TestCase
 	workingDir string
+	namespace  string
 }
 
 // Runner is the lowest-level test executor in Skaffold, responsible for
@@ -0,0 +1,210 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// OTLP span kind and status codes.
+const (
+	spanKindInternal = 1
+	statusCodeError  = 2
+)
+
+// exporter sends spans to an OTLP/HTTP endpoint, with the JSON encoding.
+type exporter struct {
+	endpoint       string
+	headers        map[string]string
+	serviceName    string
+	serviceVersion string
+	client         *http.Client
+}
+
+type exportRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource     `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type resource struct {
+	Attributes []attribute `json:"attributes"`
+}
+
+type scopeSpans struct {
+	Scope scope      `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type scope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string      `json:"traceId"`
+	SpanID            string      `json:"spanId"`
+	ParentSpanID      string      `json:"parentSpanId,omitempty"`
+	Name              string      `json:"name"`
+	Kind              int         `json:"kind"`
+	StartTimeUnixNano string      `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string      `json:"endTimeUnixNano"`
+	Attributes        []attribute `json:"attributes,omitempty"`
+	Status            *status     `json:"status,omitempty"`
+}
+
+type attribute struct {
+	Key   string         `json:"key"`
+	Value attributeValue `json:"value"`
+}
+
+type attributeValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type status struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+func newExporter(endpoint string, headers map[string]string, serviceName, serviceVersion string) *exporter {
+	return &exporter{
+		endpoint:       endpoint,
+		headers:        headers,
+		serviceName:    serviceName,
+		serviceVersion: serviceVersion,
+		client:         http.DefaultClient,
+	}
+}
+
+func (e *exporter) export(ctx context.Context, spans []*span) error {
+	body, err := json.Marshal(e.exportRequest(spans))
+	if err != nil {
+		return errors.Wrap(err, "marshalling spans")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "creating request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (e *exporter) exportRequest(spans []*span) exportRequest {
+	var converted []otlpSpan
+	for _, s := range spans {
+		converted = append(converted, toOTLP(s))
+	}
+
+	return exportRequest{
+		ResourceSpans: []resourceSpans{{
+			Resource: resource{
+				Attributes: toAttributes(map[string]string{
+					"service.name":    e.serviceName,
+					"service.version": e.serviceVersion,
+				}),
+			},
+			ScopeSpans: []scopeSpans{{
+				Scope: scope{Name: "skaffold", Version: e.serviceVersion},
+				Spans: converted,
+			}},
+		}},
+	}
+}
+
+func toOTLP(s *span) otlpSpan {
+	converted := otlpSpan{
+		TraceID:           s.traceID,
+		SpanID:            s.spanID,
+		ParentSpanID:      s.parentID,
+		Name:              s.name,
+		Kind:              spanKindInternal,
+		StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+		Attributes:        toAttributes(s.attributes),
+	}
+	if s.err != nil {
+		converted.Status = &status{Code: statusCodeError, Message: s.err.Error()}
+	}
+	return converted
+}
+
+func toAttributes(values map[string]string) []attribute {
+	var keys []string
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var attributes []attribute
+	for _, k := range keys {
+		attributes = append(attributes, attribute{Key: k, Value: attributeValue{StringValue: values[k]}})
+	}
+	return attributes
+}
+
+// tracesEndpoint returns the url spans are sent to. The signal specific
+// endpoint is used as is, while `/v1/traces` is appended to the generic one.
+func tracesEndpoint() string {
+	if endpoint := os.Getenv(tracesEndpointEnvVar); endpoint != "" {
+		return endpoint
+	}
+	if endpoint := os.Getenv(endpointEnvVar); endpoint != "" {
+		return strings.TrimSuffix(endpoint, "/") + "/v1/traces"
+	}
+	return ""
+}
+
+// parseHeaders parses a list of headers formatted as `key1=value1,key2=value2`.
+func parseHeaders(list string) map[string]string {
+	headers := map[string]string{}
+	for _, header := range strings.Split(list, ",") {
+		kv := strings.SplitN(header, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			continue
+		}
+		headers[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+	return headers
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/version"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// Environment variables configuring the OTLP exporter, as defined by OpenTelemetry.
+	endpointEnvVar       = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	tracesEndpointEnvVar = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+	headersEnvVar        = "OTEL_EXPORTER_OTLP_HEADERS"
+	serviceNameEnvVar    = "OTEL_SERVICE_NAME"
+
+	defaultServiceName = "skaffold"
+
+	// Period between two exports of the ended spans.
+	exportPeriod = 5 * time.Second
+
+	// Time given to the last export, when skaffold exits.
+	shutdownTimeout = 5 * time.Second
+)
+
+var (
+	tracer *spanTracer
+	lock   sync.Mutex
+)
+
+type spanKey struct{}
+
+// span is a timed operation of a skaffold phase.
+type span struct {
+	traceID    string
+	spanID     string
+	parentID   string
+	name       string
+	attributes map[string]string
+	start      time.Time
+	end        time.Time
+	err        error
+}
+
+// EndTrace ends a span, marking it as failed if err isn't nil.
+type EndTrace func(err error)
+
+type spanTracer struct {
+	exporter *exporter
+	root     *span
+
+	ended []*span
+	lock  sync.Mutex
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// Initialize starts exporting spans over OTLP/HTTP, if an OTLP endpoint is configured
+// with the standard OpenTelemetry environment variables. A root span, named after
+// the command, is the parent of all the spans started without a parent.
+func Initialize(command string) {
+	endpoint := tracesEndpoint()
+	if endpoint == "" {
+		return
+	}
+
+	serviceName := os.Getenv(serviceNameEnvVar)
+	if serviceName == "" {
+		serviceName = defaultServiceName
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	tracer = newTracer(newExporter(endpoint, parseHeaders(os.Getenv(headersEnvVar)), serviceName, version.Get().Version), command)
+	go tracer.exportPeriodically(exportPeriod)
+	logrus.Debugf("exporting traces to %s", endpoint)
+}
+
+// Shutdown ends the root span and exports all the ended spans.
+func Shutdown() {
+	lock.Lock()
+	t := tracer
+	tracer = nil
+	lock.Unlock()
+
+	if t != nil {
+		t.shutdown(shutdownTimeout)
+	}
+}
+
+// StartTrace starts a span as a child of the span in the context, or of the
+// root span. It returns a context holding the new span, and a function to end it.
+// It's a no-op if tracing isn't initialized.
+func StartTrace(ctx context.Context, name string, attributes map[string]string) (context.Context, EndTrace) {
+	lock.Lock()
+	t := tracer
+	lock.Unlock()
+
+	if t == nil {
+		return ctx, func(error) {}
+	}
+
+	parent, ok := ctx.Value(spanKey{}).(*span)
+	if !ok {
+		parent = t.root
+	}
+
+	s := &span{
+		traceID:    parent.traceID,
+		spanID:     newID(8),
+		parentID:   parent.spanID,
+		name:       name,
+		attributes: attributes,
+		start:      time.Now(),
+	}
+
+	return context.WithValue(ctx, spanKey{}, s), func(err error) {
+		s.end = time.Now()
+		s.err = err
+		t.addEnded(s)
+	}
+}
+
+func newTracer(exporter *exporter, command string) *spanTracer {
+	return &spanTracer{
+		exporter: exporter,
+		root: &span{
+			traceID: newID(16),
+			spanID:  newID(8),
+			name:    command,
+			start:   time.Now(),
+		},
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+}
+
+func (t *spanTracer) addEnded(s *span) {
+	t.lock.Lock()
+	t.ended = append(t.ended, s)
+	t.lock.Unlock()
+}
+
+func (t *spanTracer) popEnded() []*span {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	spans := t.ended
+	t.ended = nil
+	return spans
+}
+
+func (t *spanTracer) exportPeriodically(period time.Duration) {
+	defer close(t.done)
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.stop:
+			return
+		case <-ticker.C:
+			t.export(context.Background())
+		}
+	}
+}
+
+func (t *spanTracer) export(ctx context.Context) {
+	spans := t.popEnded()
+	if len(spans) == 0 {
+		return
+	}
+
+	if err := t.exporter.export(ctx, spans); err != nil {
+		logrus.Debugf("exporting %d spans: %s", len(spans), err)
+	}
+}
+
+func (t *spanTracer) shutdown(timeout time.Duration) {
+	close(t.stop)
+	<-t.done
+
+	t.root.end = time.Now()
+	t.addEnded(t.root)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	t.export(ctx)
+}
+
+func newID(size int) string {
+	id := make([]byte, size)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestStartTraceNotInitialized(t *testing.T) {
+	ctx, endTrace := StartTrace(context.Background(), "build", nil)
+	endTrace(nil)
+
+	testutil.CheckDeepEqual(t, nil, ctx.Value(spanKey{}))
+}
+
+func TestExportSpans(t *testing.T) {
+	var received exportRequest
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer server.Close()
+
+	reset := testutil.SetEnvs(t, map[string]string{
+		tracesEndpointEnvVar: server.URL,
+		headersEnvVar:        "Authorization=Bearer token",
+		serviceNameEnvVar:    "",
+	})
+	defer reset()
+
+	Initialize("skaffold dev")
+	ctx, endBuild := StartTrace(context.Background(), "build", nil)
+	_, endArtifact := StartTrace(ctx, "build artifact", map[string]string{"image": "web"})
+	endArtifact(errors.New("BUG"))
+	endBuild(nil)
+	Shutdown()
+
+	testutil.CheckDeepEqual(t, "Bearer token", authorization)
+	testutil.CheckDeepEqual(t, 1, len(received.ResourceSpans))
+	testutil.CheckDeepEqual(t, attribute{Key: "service.name", Value: attributeValue{StringValue: "skaffold"}}, received.ResourceSpans[0].Resource.Attributes[0])
+
+	spans := received.ResourceSpans[0].ScopeSpans[0].Spans
+	testutil.CheckDeepEqual(t, 3, len(spans))
+
+	artifact, build, root := spans[0], spans[1], spans[2]
+	testutil.CheckDeepEqual(t, "build artifact", artifact.Name)
+	testutil.CheckDeepEqual(t, []attribute{{Key: "image", Value: attributeValue{StringValue: "web"}}}, artifact.Attributes)
+	testutil.CheckDeepEqual(t, &status{Code: statusCodeError, Message: "BUG"}, artifact.Status)
+	testutil.CheckDeepEqual(t, build.SpanID, artifact.ParentSpanID)
+	testutil.CheckDeepEqual(t, "build", build.Name)
+	testutil.CheckDeepEqual(t, (*status)(nil), build.Status)
+	testutil.CheckDeepEqual(t, root.SpanID, build.ParentSpanID)
+	testutil.CheckDeepEqual(t, "skaffold dev", root.Name)
+	testutil.CheckDeepEqual(t, "", root.ParentSpanID)
+	testutil.CheckDeepEqual(t, root.TraceID, artifact.TraceID)
+	testutil.CheckDeepEqual(t, root.TraceID, build.TraceID)
+}
+
+func TestTracesEndpoint(t *testing.T) {
+	tests := []struct {
+		description string
+		env         map[string]string
+		expected    string
+	}{
+		{
+			description: "not configured",
+			env:         map[string]string{endpointEnvVar: "", tracesEndpointEnvVar: ""},
+		},
+		{
+			description: "generic endpoint",
+			env:         map[string]string{endpointEnvVar: "http://collector:4318/", tracesEndpointEnvVar: ""},
+			expected:    "http://collector:4318/v1/traces",
+		},
+		{
+			description: "traces endpoint",
+			env:         map[string]string{endpointEnvVar: "http://collector:4318", tracesEndpointEnvVar: "http://traces:4318/custom"},
+			expected:    "http://traces:4318/custom",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			reset := testutil.SetEnvs(t, test.env)
+			defer reset()
+
+			testutil.CheckDeepEqual(t, test.expected, tracesEndpoint())
+		})
+	}
+}
+
+func TestParseHeaders(t *testing.T) {
+	testutil.CheckDeepEqual(t, map[string]string{}, parseHeaders(""))
+	testutil.CheckDeepEqual(t, map[string]string{"api-key": "secret", "team": "a=b"}, parseHeaders("api-key=secret, team=a=b,invalid"))
+}
@@ -28,27 +28,36 @@ import (
 	"github.com/pkg/errors"
 )
 
-const latestVersionURL = "https://storage.googleapis.com/skaffold/releases/latest/VERSION"
+// LatestVersionURL is the default location of the VERSION file.
+const LatestVersionURL = "https://storage.googleapis.com/skaffold/releases/latest/VERSION"
 
-// IsUpdateCheckEnabled returns whether or not the update check is enabled
-// It is true by default, but setting it to any other value than true will disable the check
-func IsUpdateCheckEnabled() bool {
+// IsUpdateCheckEnabled returns whether or not the update check is enabled.
+// The environment variable takes precedence over the configured value: setting it
+// to any other value than true will disable the check.
+func IsUpdateCheckEnabled(configured bool) bool {
 	// Don't perform a version check on dirty trees
 	if version.Get().GitTreeState == "dirty" {
 		return false
 	}
 
-	v := os.Getenv(constants.UpdateCheckEnvironmentVariable)
-	return v == "" || strings.ToLower(v) == "true"
+	if v, present := os.LookupEnv(constants.UpdateCheckEnvironmentVariable); present && v != "" {
+		return strings.ToLower(v) == "true"
+	}
+	return configured
 }
 
-// GetLatestAndCurrentVersion uses a VERSION file stored on GCS to determine the latest released version
-// and returns it with the current version of Skaffold
-func GetLatestAndCurrentVersion() (semver.Version, semver.Version, error) {
+// GetLatestAndCurrentVersion uses a VERSION file stored on GCS, or on a mirror, to determine the
+// latest released version and returns it with the current version of Skaffold.
+// An empty URL means LatestVersionURL. The proxy is read from the HTTPS_PROXY environment variable.
+func GetLatestAndCurrentVersion(url string) (semver.Version, semver.Version, error) {
+	if url == "" {
+		url = LatestVersionURL
+	}
+
 	none := semver.Version{}
-	resp, err := http.Get(latestVersionURL)
+	resp, err := http.Get(url)
 	if err != nil {
-		return none, none, errors.Wrap(err, "getting latest version info from GCS")
+		return none, none, errors.Wrapf(err, "getting latest version info from %s", url)
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
@@ -42,11 +42,24 @@ func RunCmd(cmd *exec.Cmd) error {
 	return DefaultExecCommand.RunCmd(cmd)
 }
 
+// RunCmdOutSensitive is like RunCmdOut, for commands that print secrets or decrypted files:
+// their output is neither logged nor added to errors.
+func RunCmdOutSensitive(cmd *exec.Cmd) ([]byte, error) {
+	if commander, ok := DefaultExecCommand.(*Commander); ok {
+		return commander.runCmdOut(&Cmd{Cmd: cmd}, false)
+	}
+	return DefaultExecCommand.RunCmdOut(cmd)
+}
+
 // Commander is the exec.Cmd implementation of the Command interface
 type Commander struct{}
 
 // RunCmdOut runs an exec.Command and returns the stdout and error.
-func (*Commander) RunCmdOut(cmd *exec.Cmd) ([]byte, error) {
+func (c *Commander) RunCmdOut(cmd *exec.Cmd) ([]byte, error) {
+	return c.runCmdOut(&Cmd{Cmd: cmd}, true)
+}
+
+func (*Commander) runCmdOut(cmd *Cmd, logOutput bool) ([]byte, error) {
 	logrus.Debugf("Running command: %s", cmd.Args)
 	stdoutPipe, err := cmd.StdoutPipe()
 	if err != nil {
@@ -59,7 +72,7 @@ func (*Commander) RunCmdOut(cmd *exec.Cmd) ([]byte, error) {
 	}
 
 	if err := cmd.Start(); err != nil {
-		return nil, errors.Wrapf(err, "starting command %v", cmd)
+		return nil, errors.Wrapf(err, "starting command %v", cmd.Cmd)
 	}
 
 	stdout, err := ioutil.ReadAll(stdoutPipe)
@@ -73,6 +86,13 @@ func (*Commander) RunCmdOut(cmd *exec.Cmd) ([]byte, error) {
 	}
 
 	err = cmd.Wait()
+	if !logOutput {
+		if err != nil {
+			return nil, errors.Wrapf(err, "Running %s: stderr: %s, err: %v", cmd.Args, stderr, err)
+		}
+		return stdout, nil
+	}
+
 	if err != nil {
 		return stdout, errors.Wrapf(err, "Running %s: stdout %s, stderr: %s, err: %v", cmd.Args, stdout, stderr, err)
 	}
@@ -87,7 +107,14 @@ func (*Commander) RunCmdOut(cmd *exec.Cmd) ([]byte, error) {
 }
 
 // RunCmd runs an exec.Command.
-func (*Commander) RunCmd(cmd *exec.Cmd) error {
+func (c *Commander) RunCmd(cmd *exec.Cmd) error {
+	return c.run(&Cmd{Cmd: cmd})
+}
+
+func (*Commander) run(cmd *Cmd) error {
 	logrus.Debugf("Running command: %s", cmd.Args)
-	return cmd.Run()
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	return cmd.Wait()
 }
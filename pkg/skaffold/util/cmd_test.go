@@ -17,12 +17,16 @@ limitations under the License.
 package util
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"strings"
 	"testing"
 
 	"github.com/GoogleContainerTools/skaffold/testutil"
+	"github.com/sirupsen/logrus"
 )
 
 func helperCommandContext(s ...string) (cmd *exec.Cmd) {
@@ -99,3 +103,19 @@ func TestCmd_RunCmdOut(t *testing.T) {
 		})
 	}
 }
+
+func TestCmd_RunCmdOutSensitive(t *testing.T) {
+	defer func(level logrus.Level, out io.Writer) {
+		logrus.SetLevel(level)
+		logrus.SetOutput(out)
+	}(logrus.GetLevel(), logrus.StandardLogger().Out)
+
+	var logs bytes.Buffer
+	logrus.SetLevel(logrus.DebugLevel)
+	logrus.SetOutput(&logs)
+
+	got, err := RunCmdOutSensitive(helperCommand("skaffold", "s3cr3t"))
+
+	testutil.CheckErrorAndDeepEqual(t, false, err, "s3cr3t\n", string(got))
+	testutil.CheckDeepEqual(t, false, strings.Contains(logs.String(), "s3cr3t\n"))
+}
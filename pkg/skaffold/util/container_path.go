@@ -0,0 +1,45 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// windowsDrive matches the start of absolute paths in Windows containers, like `C:\app`.
+var windowsDrive = regexp.MustCompile(`^[a-zA-Z]:[\\/]`)
+
+// IsWindowsContainerPath checks if a path in a container starts with a drive letter.
+func IsWindowsContainerPath(p string) bool {
+	return windowsDrive.MatchString(p)
+}
+
+// IsAbsContainerPath checks if a path in a Linux or a Windows container is absolute.
+func IsAbsContainerPath(p string) bool {
+	return path.IsAbs(p) || IsWindowsContainerPath(p)
+}
+
+// ContainerPath writes a path in a container with forward slashes,
+// which both Linux and Windows containers understand.
+func ContainerPath(p string) string {
+	if !IsWindowsContainerPath(p) {
+		return p
+	}
+	return strings.Replace(p, `\`, "/", -1)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestContainerPath(t *testing.T) {
+	var tests = []struct {
+		description     string
+		path            string
+		expected        string
+		expectedAbs     bool
+		expectedWindows bool
+	}{
+		{
+			description: "absolute linux path",
+			path:        "/app/src",
+			expected:    "/app/src",
+			expectedAbs: true,
+		},
+		{
+			description: "relative path",
+			path:        "src",
+			expected:    "src",
+		},
+		{
+			description:     "absolute windows path",
+			path:            `C:\app\src`,
+			expected:        "C:/app/src",
+			expectedAbs:     true,
+			expectedWindows: true,
+		},
+		{
+			description:     "windows path with forward slashes",
+			path:            "c:/app",
+			expected:        "c:/app",
+			expectedAbs:     true,
+			expectedWindows: true,
+		},
+		{
+			description: "drive relative windows path",
+			path:        `C:app`,
+			expected:    `C:app`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			testutil.CheckDeepEqual(t, test.expected, ContainerPath(test.path))
+			testutil.CheckDeepEqual(t, test.expectedAbs, IsAbsContainerPath(test.path))
+			testutil.CheckDeepEqual(t, test.expectedWindows, IsWindowsContainerPath(test.path))
+		})
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bufio"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ParseEnvFile reads `KEY=VALUE` pairs from a file.
+// Empty lines and lines starting with `#` are ignored.
+// Values can be surrounded by single or double quotes.
+func ParseEnvFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "opening env file")
+	}
+	defer f.Close()
+
+	values := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for i := 1; scanner.Scan(); i++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		kvp := strings.SplitN(strings.TrimPrefix(line, "export "), "=", 2)
+		key := strings.TrimSpace(kvp[0])
+		if len(kvp) != 2 || key == "" {
+			return nil, errors.Errorf("%s:%d: %q is not a KEY=VALUE pair", path, i, line)
+		}
+
+		values[key] = unquote(strings.TrimSpace(kvp[1]))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "reading env file")
+	}
+
+	return values, nil
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 {
+		if first, last := value[0], value[len(value)-1]; first == last && (first == '"' || first == '\'') {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestParseEnvFile(t *testing.T) {
+	tests := []struct {
+		description string
+		content     string
+		expected    map[string]string
+		shouldErr   bool
+	}{
+		{
+			description: "key value pairs",
+			content:     "FOO=foo\nBAR=bar=baz\n",
+			expected:    map[string]string{"FOO": "foo", "BAR": "bar=baz"},
+		},
+		{
+			description: "comments, empty lines and export",
+			content:     "# comment\n\nexport FOO=foo\n  BAR = bar \n",
+			expected:    map[string]string{"FOO": "foo", "BAR": "bar"},
+		},
+		{
+			description: "quoted values",
+			content:     "FOO=\"foo bar\"\nBAR='bar'\nEMPTY=\nQUOTE=\"",
+			expected:    map[string]string{"FOO": "foo bar", "BAR": "bar", "EMPTY": "", "QUOTE": "\""},
+		},
+		{
+			description: "missing =",
+			content:     "FOO",
+			shouldErr:   true,
+		},
+		{
+			description: "missing key",
+			content:     "=foo",
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			tmpDir, cleanup := testutil.NewTempDir(t)
+			defer cleanup()
+			tmpDir.Write(".skaffold.env", test.content)
+
+			values, err := ParseEnvFile(tmpDir.Path(".skaffold.env"))
+
+			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.expected, values)
+		})
+	}
+}
+
+func TestParseMissingEnvFile(t *testing.T) {
+	_, err := ParseEnvFile("missing.env")
+
+	testutil.CheckError(t, true, err)
+}
@@ -32,16 +32,36 @@ var (
 	OSEnviron = os.Environ
 )
 
-// ParseEnvTemplate is a simple wrapper to parse an env template
+// ParseEnvTemplate is a simple wrapper to parse an env template.
+// Secrets can be referenced with `{{secret "kv/path#key"}}`, see GetSecret.
 func ParseEnvTemplate(t string) (*template.Template, error) {
-	tmpl, err := template.New("envTemplate").Parse(t)
+	tmpl, err := template.New("envTemplate").Funcs(template.FuncMap{"secret": GetSecret}).Parse(t)
 	return tmpl, err
 }
 
+// sessionValues are available to every template, unless overridden by the custom map.
+var sessionValues map[string]string
+
+// SetSessionValues makes values, like the run ID, available to every template.
+func SetSessionValues(values map[string]string) {
+	sessionValues = values
+}
+
+// envFileValues are loaded from the env file. OS environment variables take precedence.
+var envFileValues map[string]string
+
+// SetEnvFileValues makes values read from an env file available to every template.
+func SetEnvFileValues(values map[string]string) {
+	envFileValues = values
+}
+
 // ExecuteEnvTemplate executes an envTemplate based on OS environment variables and a custom map
 func ExecuteEnvTemplate(envTemplate *template.Template, customMap map[string]string) (string, error) {
 	var buf bytes.Buffer
 	envMap := map[string]string{}
+	for k, v := range envFileValues {
+		envMap[k] = v
+	}
 	for _, env := range OSEnviron() {
 		kvp := strings.SplitN(env, "=", 2)
 		if len(kvp) != 2 {
@@ -50,6 +70,9 @@ func ExecuteEnvTemplate(envTemplate *template.Template, customMap map[string]str
 		envMap[kvp[0]] = kvp[1]
 	}
 
+	for k, v := range sessionValues {
+		envMap[k] = v
+	}
 	for k, v := range customMap {
 		envMap[k] = v
 	}
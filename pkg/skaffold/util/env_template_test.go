@@ -28,6 +28,7 @@ func TestEnvTemplate_ExecuteEnvTemplate(t *testing.T) {
 		name      string
 		template  string
 		customMap map[string]string
+		envFile   map[string]string
 		env       []string
 		want      string
 		shouldErr bool
@@ -57,6 +58,16 @@ func TestEnvTemplate_ExecuteEnvTemplate(t *testing.T) {
 			},
 			want: "from_custom-FOO:latest",
 		},
+		{
+			name:     "env file",
+			template: "{{.FROM_FILE}}-{{.FROM_ENV}}:latest",
+			env:      []string{"FROM_ENV=FOO"},
+			envFile: map[string]string{
+				"FROM_FILE": "file",
+				"FROM_ENV":  "overridden",
+			},
+			want: "file-FOO:latest",
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -65,6 +76,9 @@ func TestEnvTemplate_ExecuteEnvTemplate(t *testing.T) {
 				return test.env
 			}
 
+			defer SetEnvFileValues(nil)
+			SetEnvFileValues(test.envFile)
+
 			got, err := ExecuteEnvTemplate(testTemplate, test.customMap)
 			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.want, got)
 		})
@@ -30,6 +30,85 @@ const prefixRegexStr = "gcr.io/[a-zA-Z0-9-_]+/"
 var escapeRegex = regexp.MustCompile(escapeChars)
 var prefixRegex = regexp.MustCompile(prefixRegexStr)
 
+// Strategies used to combine a default repo with an image name.
+const (
+	// DefaultRepoStrategyAuto concatenates GCR repos and flattens the image name for any other registry.
+	DefaultRepoStrategyAuto = "auto"
+	// DefaultRepoStrategyPrefix prepends the default repo to the image name, as is.
+	DefaultRepoStrategyPrefix = "prefix"
+	// DefaultRepoStrategyFlatten escapes every separator of the image name before prepending the default repo.
+	DefaultRepoStrategyFlatten = "flatten"
+	// DefaultRepoStrategyReplaceRegistry only replaces the registry part of the image name with the default repo.
+	DefaultRepoStrategyReplaceRegistry = "replace-registry"
+)
+
+// DefaultRepoStrategies lists the supported default repo strategies.
+var DefaultRepoStrategies = []string{
+	DefaultRepoStrategyAuto,
+	DefaultRepoStrategyPrefix,
+	DefaultRepoStrategyFlatten,
+	DefaultRepoStrategyReplaceRegistry,
+}
+
+// DefaultRepoSubstitution describes how a default repo is substituted into image names.
+type DefaultRepoSubstitution struct {
+	// Repo is the default repo. No substitution is done if it's empty.
+	Repo string
+
+	// Strategy is one of the DefaultRepoStrategies. Defaults to `auto`.
+	Strategy string
+
+	// Overrides maps original image names to the image names that
+	// should be used instead, whatever the default repo and strategy.
+	Overrides map[string]string
+}
+
+// Substitute returns the name under which originalImage should be built and deployed.
+func (s DefaultRepoSubstitution) Substitute(originalImage string) string {
+	if override, present := s.Overrides[originalImage]; present {
+		return override
+	}
+	if s.Repo == "" {
+		return originalImage
+	}
+
+	switch s.Strategy {
+	case DefaultRepoStrategyPrefix:
+		if strings.HasPrefix(originalImage, s.Repo+"/") {
+			return originalImage
+		}
+		return truncate(s.Repo + "/" + originalImage)
+	case DefaultRepoStrategyFlatten:
+		return truncate(s.Repo + "/" + escapeRegex.ReplaceAllString(originalImage, "_"))
+	case DefaultRepoStrategyReplaceRegistry:
+		if strings.HasPrefix(originalImage, s.Repo+"/") {
+			return originalImage
+		}
+		return truncate(s.Repo + "/" + stripRegistry(originalImage))
+	default:
+		return SubstituteDefaultRepoIntoImage(s.Repo, originalImage)
+	}
+}
+
+// IsValidDefaultRepoStrategy returns true if the strategy is empty or one of the DefaultRepoStrategies.
+func IsValidDefaultRepoStrategy(strategy string) bool {
+	return strategy == "" || StrSliceContains(DefaultRepoStrategies, strategy)
+}
+
+// stripRegistry removes the registry host from an image name, following
+// the same rules as docker: the first path component is a registry host
+// if it contains a `.` or a `:`, or if it's `localhost`.
+func stripRegistry(image string) string {
+	parts := strings.SplitN(image, "/", 2)
+	if len(parts) == 1 {
+		return image
+	}
+	if strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost" {
+		return parts[1]
+	}
+	return image
+}
+
 func SubstituteDefaultRepoIntoImage(defaultRepo string, originalImage string) string {
 	if defaultRepo == "" {
 		return originalImage
@@ -78,3 +78,72 @@ func TestImageReplaceDefaultRepo(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultRepoSubstitution(t *testing.T) {
+	tests := []struct {
+		name          string
+		image         string
+		substitution  DefaultRepoSubstitution
+		expectedImage string
+	}{
+		{
+			name:          "auto strategy",
+			image:         "gcr.io/some/registry",
+			substitution:  DefaultRepoSubstitution{Repo: "aws_account_id.dkr.ecr.region.amazonaws.com"},
+			expectedImage: "aws_account_id.dkr.ecr.region.amazonaws.com/gcr_io_some_registry",
+		},
+		{
+			name:          "prefix strategy",
+			image:         "gcr.io/some/registry",
+			substitution:  DefaultRepoSubstitution{Repo: "us-docker.pkg.dev/project/repo", Strategy: DefaultRepoStrategyPrefix},
+			expectedImage: "us-docker.pkg.dev/project/repo/gcr.io/some/registry",
+		},
+		{
+			name:          "prefix strategy, image already prefixed",
+			image:         "us-docker.pkg.dev/project/repo/app",
+			substitution:  DefaultRepoSubstitution{Repo: "us-docker.pkg.dev/project/repo", Strategy: DefaultRepoStrategyPrefix},
+			expectedImage: "us-docker.pkg.dev/project/repo/app",
+		},
+		{
+			name:          "flatten strategy with gcr",
+			image:         "gcr.io/some/registry",
+			substitution:  DefaultRepoSubstitution{Repo: "gcr.io/default", Strategy: DefaultRepoStrategyFlatten},
+			expectedImage: "gcr.io/default/gcr_io_some_registry",
+		},
+		{
+			name:          "replace registry",
+			image:         "gcr.io/some/registry",
+			substitution:  DefaultRepoSubstitution{Repo: "us-docker.pkg.dev/project/repo", Strategy: DefaultRepoStrategyReplaceRegistry},
+			expectedImage: "us-docker.pkg.dev/project/repo/some/registry",
+		},
+		{
+			name:          "replace registry, no registry in image",
+			image:         "some/registry",
+			substitution:  DefaultRepoSubstitution{Repo: "localhost:5000", Strategy: DefaultRepoStrategyReplaceRegistry},
+			expectedImage: "localhost:5000/some/registry",
+		},
+		{
+			name:  "override",
+			image: "gcr.io/some/registry",
+			substitution: DefaultRepoSubstitution{
+				Repo:      "gcr.io/default",
+				Overrides: map[string]string{"gcr.io/some/registry": "eu.gcr.io/other/image"},
+			},
+			expectedImage: "eu.gcr.io/other/image",
+		},
+		{
+			name:  "override without default repo",
+			image: "gcr.io/some/registry",
+			substitution: DefaultRepoSubstitution{
+				Overrides: map[string]string{"gcr.io/some/registry": "eu.gcr.io/other/image"},
+			},
+			expectedImage: "eu.gcr.io/other/image",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testutil.CheckDeepEqual(t, test.expectedImage, test.substitution.Substitute(test.image))
+		})
+	}
+}
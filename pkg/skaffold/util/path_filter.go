@@ -0,0 +1,218 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/pkg/fileutils"
+	"github.com/karrick/godirwalk"
+	"github.com/pkg/errors"
+)
+
+// SymlinkPolicy tells how a PathFilter handles symbolic links.
+type SymlinkPolicy int
+
+const (
+	// KeepSymlinks lists symbolic links like files, without following them.
+	KeepSymlinks SymlinkPolicy = iota
+	// FollowSymlinks follows symbolic links to directories.
+	FollowSymlinks
+	// IgnoreSymlinks ignores symbolic links.
+	IgnoreSymlinks
+	// ErrorOnSymlinks fails on any symbolic link.
+	ErrorOnSymlinks
+)
+
+// Symlinks is the policy for symbolic links found while listing the dependencies of artifacts.
+var Symlinks = KeepSymlinks
+
+// ParseSymlinkPolicy parses a symlink policy: `keep`, `follow`, `ignore` or `error`.
+func ParseSymlinkPolicy(policy string) (SymlinkPolicy, error) {
+	switch strings.ToLower(policy) {
+	case "", "keep":
+		return KeepSymlinks, nil
+	case "follow":
+		return FollowSymlinks, nil
+	case "ignore":
+		return IgnoreSymlinks, nil
+	case "error":
+		return ErrorOnSymlinks, nil
+	default:
+		return KeepSymlinks, fmt.Errorf("invalid symlink policy: %q. Must be one of keep, follow, ignore or error", policy)
+	}
+}
+
+// PathFilter decides which files of a workspace are considered, so that
+// the same rules apply whatever the OS.
+type PathFilter struct {
+	// SkipHidden ignores hidden files and directories.
+	SkipHidden bool
+
+	// Symlinks tells how symbolic links are handled.
+	Symlinks SymlinkPolicy
+
+	excludes *fileutils.PatternMatcher
+}
+
+// NewPathFilter creates a PathFilter that ignores files matching
+// `.dockerignore` style exclude patterns, relative to the workspace.
+func NewPathFilter(excludes []string, skipHidden bool, symlinks SymlinkPolicy) (*PathFilter, error) {
+	pExclude, err := fileutils.NewPatternMatcher(excludes)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid exclude patterns")
+	}
+
+	return &PathFilter{
+		SkipHidden: skipHidden,
+		Symlinks:   symlinks,
+		excludes:   pExclude,
+	}, nil
+}
+
+// Ignores returns true if a file, given by its path relative to the workspace, should be ignored.
+func (f *PathFilter) Ignores(workspace, relPath string, isSymlink bool) (bool, error) {
+	if isSymlink {
+		switch f.Symlinks {
+		case IgnoreSymlinks:
+			return true, nil
+		case ErrorOnSymlinks:
+			return false, fmt.Errorf("symbolic link found at %s", filepath.Join(workspace, relPath))
+		}
+	}
+	if f.SkipHidden && IsHiddenFile(filepath.Join(workspace, relPath)) {
+		return true, nil
+	}
+	if f.excludes == nil {
+		return false, nil
+	}
+
+	return f.excludes.Matches(relPath)
+}
+
+// Walk lists the files of a workspace that are found in the given directories and files,
+// and that aren't ignored. The files are listed relative to the workspace.
+func (f *PathFilter) Walk(workspace string, paths []string) (map[string]bool, error) {
+	files := make(map[string]bool)
+	for _, path := range paths {
+		path = filepath.Clean(path)
+		absPath := filepath.Join(workspace, path)
+
+		fi, err := os.Lstat(absPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "stating file %s", absPath)
+		}
+		isSymlink := fi.Mode()&os.ModeSymlink != 0
+		if isSymlink {
+			if _, err := f.Ignores(workspace, path, true); err != nil {
+				return nil, err
+			}
+		}
+		if isSymlink && f.Symlinks != IgnoreSymlinks {
+			if fi, err = os.Stat(absPath); err != nil {
+				return nil, errors.Wrapf(err, "stating file %s", absPath)
+			}
+		}
+
+		switch mode := fi.Mode(); {
+		case mode.IsDir():
+			if err := godirwalk.Walk(absPath, &godirwalk.Options{
+				Unsorted:            true,
+				FollowSymbolicLinks: f.Symlinks == FollowSymlinks,
+				Callback: func(fpath string, info *godirwalk.Dirent) error {
+					if fpath == absPath {
+						return nil
+					}
+
+					relPath, err := filepath.Rel(workspace, fpath)
+					if err != nil {
+						return err
+					}
+
+					ignored, err := f.Ignores(workspace, relPath, info.IsSymlink())
+					if err != nil {
+						return err
+					}
+
+					isDir := info.IsDir()
+					if info.IsSymlink() && f.Symlinks == FollowSymlinks {
+						if target, err := os.Stat(fpath); err == nil {
+							isDir = target.IsDir()
+						}
+						if isDir && !ignored {
+							cycle, err := isSymlinkCycle(workspace, fpath)
+							if err != nil {
+								return err
+							}
+							if cycle {
+								return fmt.Errorf("symbolic link cycle found at %s", fpath)
+							}
+						}
+					}
+
+					if isDir {
+						if ignored {
+							return filepath.SkipDir
+						}
+					} else if !ignored {
+						files[relPath] = true
+					}
+
+					return nil
+				},
+			}); err != nil {
+				return nil, errors.Wrapf(err, "walking folder %s", absPath)
+			}
+		case mode.IsRegular():
+			ignored, err := f.Ignores(workspace, path, isSymlink)
+			if err != nil {
+				return nil, err
+			}
+
+			if !ignored {
+				files[path] = true
+			}
+		}
+	}
+	return files, nil
+}
+
+// isSymlinkCycle returns true if a symbolic link points to one of the directories
+// it was reached through, either in the workspace or above it.
+func isSymlinkCycle(workspace, link string) (bool, error) {
+	target, err := filepath.EvalSymlinks(link)
+	if err != nil {
+		return false, errors.Wrapf(err, "resolving symbolic link %s", link)
+	}
+
+	for dir := filepath.Dir(link); ; dir = filepath.Dir(dir) {
+		real, err := filepath.EvalSymlinks(dir)
+		if err != nil {
+			return false, errors.Wrapf(err, "resolving directory %s", dir)
+		}
+		if real == target || strings.HasPrefix(real, target+string(filepath.Separator)) {
+			return true, nil
+		}
+
+		if dir == workspace || dir == filepath.Dir(dir) {
+			return false, nil
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// +build !windows
+
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"os"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+// Creating symlinks requires extra privileges on Windows
+func TestPathFilterSymlinks(t *testing.T) {
+	var tests = []struct {
+		description string
+		symlinks    SymlinkPolicy
+		expected    map[string]bool
+		shouldErr   bool
+	}{
+		{
+			description: "keep symlinks",
+			symlinks:    KeepSymlinks,
+			expected:    map[string]bool{"src/main.go": true, "linked": true, "main.link": true},
+		},
+		{
+			description: "follow symlinks",
+			symlinks:    FollowSymlinks,
+			expected:    map[string]bool{"src/main.go": true, "linked/main.go": true, "main.link": true},
+		},
+		{
+			description: "ignore symlinks",
+			symlinks:    IgnoreSymlinks,
+			expected:    map[string]bool{"src/main.go": true},
+		},
+		{
+			description: "error on symlinks",
+			symlinks:    ErrorOnSymlinks,
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			tmpDir, cleanup := testutil.NewTempDir(t)
+			defer cleanup()
+
+			tmpDir.Write("src/main.go", "")
+			if err := os.Symlink(tmpDir.Path("src"), tmpDir.Path("linked")); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Symlink(tmpDir.Path("src/main.go"), tmpDir.Path("main.link")); err != nil {
+				t.Fatal(err)
+			}
+
+			filter, err := NewPathFilter(nil, false, test.symlinks)
+			var files map[string]bool
+			if err == nil {
+				files, err = filter.Walk(tmpDir.Root(), []string{"."})
+			}
+
+			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.expected, files)
+		})
+	}
+}
+
+func TestPathFilterSymlinkCycles(t *testing.T) {
+	var tests = []struct {
+		description string
+		links       map[string]string
+		shouldErr   bool
+	}{
+		{
+			description: "link to a sibling",
+			links:       map[string]string{"a/linked": "b"},
+		},
+		{
+			description: "link to the parent directory",
+			links:       map[string]string{"a/parent": "a"},
+			shouldErr:   true,
+		},
+		{
+			description: "link to the workspace",
+			links:       map[string]string{"a/root": "."},
+			shouldErr:   true,
+		},
+		{
+			description: "links to each other",
+			links:       map[string]string{"a/linked": "b", "b/linked": "a"},
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			tmpDir, cleanup := testutil.NewTempDir(t)
+			defer cleanup()
+
+			tmpDir.Write("a/a.go", "").Write("b/b.go", "")
+			for link, target := range test.links {
+				if err := os.Symlink(tmpDir.Path(target), tmpDir.Path(link)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			filter, err := NewPathFilter(nil, false, FollowSymlinks)
+			if err == nil {
+				_, err = filter.Walk(tmpDir.Root(), []string{"."})
+			}
+
+			testutil.CheckError(t, test.shouldErr, err)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestPathFilterWalk(t *testing.T) {
+	var tests = []struct {
+		description string
+		excludes    []string
+		skipHidden  bool
+		paths       []string
+		expected    map[string]bool
+		shouldErr   bool
+	}{
+		{
+			description: "all files",
+			paths:       []string{"."},
+			expected:    map[string]bool{"main.go": true, "README.md": true, ".env": true, ".git/config": true, "vendor/lib.go": true},
+		},
+		{
+			description: "exclude patterns",
+			excludes:    []string{"vendor", "*.md"},
+			paths:       []string{"."},
+			expected:    map[string]bool{"main.go": true, ".env": true, ".git/config": true},
+		},
+		{
+			description: "exception to an exclude pattern",
+			excludes:    []string{"*.md", "!README.md"},
+			paths:       []string{"."},
+			expected:    map[string]bool{"main.go": true, "README.md": true, ".env": true, ".git/config": true, "vendor/lib.go": true},
+		},
+		{
+			description: "skip hidden files and directories",
+			skipHidden:  true,
+			paths:       []string{"."},
+			expected:    map[string]bool{"main.go": true, "README.md": true, "vendor/lib.go": true},
+		},
+		{
+			description: "single files",
+			excludes:    []string{"*.md"},
+			paths:       []string{"main.go", "README.md"},
+			expected:    map[string]bool{"main.go": true},
+		},
+		{
+			description: "invalid exclude pattern",
+			excludes:    []string{"["},
+			paths:       []string{"."},
+			shouldErr:   true,
+		},
+		{
+			description: "missing path",
+			paths:       []string{"unknown"},
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			tmpDir, cleanup := testutil.NewTempDir(t)
+			defer cleanup()
+
+			tmpDir.Write("main.go", "").
+				Write("README.md", "").
+				Write(".env", "").
+				Write(".git/config", "").
+				Write("vendor/lib.go", "")
+
+			filter, err := NewPathFilter(test.excludes, test.skipHidden, KeepSymlinks)
+			var files map[string]bool
+			if err == nil {
+				files, err = filter.Walk(tmpDir.Root(), test.paths)
+			}
+
+			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.expected, files)
+		})
+	}
+}
+
+func TestParseSymlinkPolicy(t *testing.T) {
+	var tests = []struct {
+		policy    string
+		expected  SymlinkPolicy
+		shouldErr bool
+	}{
+		{policy: "", expected: KeepSymlinks},
+		{policy: "keep", expected: KeepSymlinks},
+		{policy: "follow", expected: FollowSymlinks},
+		{policy: "Ignore", expected: IgnoreSymlinks},
+		{policy: "error", expected: ErrorOnSymlinks},
+		{policy: "unknown", shouldErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.policy, func(t *testing.T) {
+			policy, err := ParseSymlinkPolicy(test.policy)
+
+			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.expected, policy)
+		})
+	}
+}
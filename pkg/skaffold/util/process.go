@@ -0,0 +1,131 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"runtime"
+	"syscall"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
+)
+
+// GracePeriod is how long child processes are given to exit, once their context is done, before they are killed.
+var GracePeriod = constants.DefaultGracePeriod
+
+// Cmd is a command that, once its context is done, is first asked to terminate
+// and only killed if still running after GracePeriod.
+// This lets kubectl and helm clean up, instead of leaving resources or processes behind.
+type Cmd struct {
+	*exec.Cmd
+
+	ctx context.Context
+	// kill cancels the context of the embedded exec.Cmd, which kills the process.
+	kill context.CancelFunc
+	// exited is closed once the process has exited.
+	exited chan struct{}
+}
+
+// CommandContext is like `exec.CommandContext` except that the process
+// is terminated gracefully when the context is done.
+func CommandContext(ctx context.Context, name string, args ...string) *Cmd {
+	killCtx, kill := context.WithCancel(context.Background())
+
+	return &Cmd{
+		Cmd:  exec.CommandContext(killCtx, name, args...),
+		ctx:  ctx,
+		kill: kill,
+	}
+}
+
+// Start starts the command and watches its context.
+// Wait has to be called for the command to release its resources.
+func (c *Cmd) Start() error {
+	if c.ctx == nil {
+		return c.Cmd.Start()
+	}
+	if err := c.ctx.Err(); err != nil {
+		c.kill()
+		return err
+	}
+	if err := c.Cmd.Start(); err != nil {
+		c.kill()
+		return err
+	}
+
+	c.exited = make(chan struct{})
+	go c.watchContext(GracePeriod)
+	return nil
+}
+
+// Wait waits for a command started with Start to exit.
+func (c *Cmd) Wait() error {
+	err := c.Cmd.Wait()
+	if c.exited != nil {
+		close(c.exited)
+	}
+	if c.kill != nil {
+		c.kill()
+	}
+	return err
+}
+
+// Run runs the command with DefaultExecCommand, so that it can be faked in tests.
+func (c *Cmd) Run() error {
+	if commander, ok := DefaultExecCommand.(*Commander); ok {
+		return commander.run(c)
+	}
+	return DefaultExecCommand.RunCmd(c.Cmd)
+}
+
+// RunOut runs the command with DefaultExecCommand and returns its standard output.
+func (c *Cmd) RunOut() ([]byte, error) {
+	if commander, ok := DefaultExecCommand.(*Commander); ok {
+		return commander.runCmdOut(c, true)
+	}
+	return DefaultExecCommand.RunCmdOut(c.Cmd)
+}
+
+// watchContext terminates the process once the context is done,
+// then kills it if it's still running after the grace period.
+func (c *Cmd) watchContext(gracePeriod time.Duration) {
+	select {
+	case <-c.exited:
+		return
+	case <-c.ctx.Done():
+	}
+
+	terminate(c.Process)
+
+	select {
+	case <-c.exited:
+	case <-time.After(gracePeriod):
+		c.kill()
+	}
+}
+
+// terminate asks a process to terminate. Windows doesn't support SIGTERM
+// so the process is killed right away.
+func terminate(process *os.Process) error {
+	if runtime.GOOS == "windows" {
+		return process.Kill()
+	}
+	return process.Signal(syscall.SIGTERM)
+}
@@ -0,0 +1,83 @@
+// +build !windows
+
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestCommandContextTerminates(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cmd := CommandContext(ctx, "sh", "-c", "trap 'exit 3' TERM; while true; do sleep 0.01; done")
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+	cmd.Wait()
+
+	// The process exited on its own, with the status of its trap.
+	testutil.CheckDeepEqual(t, 3, cmd.ProcessState.Sys().(syscall.WaitStatus).ExitStatus())
+}
+
+func TestCommandContextKills(t *testing.T) {
+	defer func(gracePeriod time.Duration) { GracePeriod = gracePeriod }(GracePeriod)
+	GracePeriod = 50 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cmd := CommandContext(ctx, "sh", "-c", "trap '' TERM; while true; do sleep 0.01; done")
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+	start := time.Now()
+	cmd.Wait()
+
+	// The process ignored SIGTERM and was killed after the grace period.
+	testutil.CheckDeepEqual(t, syscall.SIGKILL, cmd.ProcessState.Sys().(syscall.WaitStatus).Signal())
+	testutil.CheckDeepEqual(t, true, time.Since(start) >= GracePeriod)
+}
+
+func TestCommandContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := CommandContext(ctx, "true").Start()
+
+	if err != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestCommandContextRunFaked(t *testing.T) {
+	reset := testutil.Override(t, &DefaultExecCommand, testutil.NewFakeCmd(t).WithRunOut("kubectl version", "v1"))
+	defer reset()
+
+	out, err := CommandContext(context.Background(), "kubectl", "version").RunOut()
+
+	testutil.CheckErrorAndDeepEqual(t, false, err, "v1", string(out))
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/redact"
+	"github.com/pkg/errors"
+)
+
+const (
+	vaultPrefix = "vault:"
+	gcpPrefix   = "gcp:"
+)
+
+// SecretProvider fetches secrets from a secret manager.
+type SecretProvider interface {
+	GetSecret(ref string) (string, error)
+}
+
+var (
+	secretsCache = map[string]string{}
+	secretsLock  sync.Mutex
+)
+
+// GetSecret fetches the secret referenced by `ref`, from Vault or GCP Secret Manager.
+// Secrets are fetched once per Skaffold session, using the user's credentials:
+//   - `kv/path#key` or `vault:kv/path#key` reads the key of a Vault secret.
+//   - `gcp:projects/<project>/secrets/<name>[/versions/<version>]` or `gcp:<name>`
+//     reads a version of a GCP secret, the latest by default.
+func GetSecret(ref string) (string, error) {
+	secretsLock.Lock()
+	defer secretsLock.Unlock()
+
+	if value, present := secretsCache[ref]; present {
+		return value, nil
+	}
+
+	provider, path, err := secretProvider(ref)
+	if err != nil {
+		return "", err
+	}
+
+	value, err := provider.GetSecret(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "getting secret %s", ref)
+	}
+
+	secretsCache[ref] = value
+	redact.AddValue(value)
+	return value, nil
+}
+
+func secretProvider(ref string) (SecretProvider, string, error) {
+	switch {
+	case strings.HasPrefix(ref, gcpPrefix):
+		return gcpSecretManager{}, strings.TrimPrefix(ref, gcpPrefix), nil
+	case strings.HasPrefix(ref, vaultPrefix):
+		return vault{}, strings.TrimPrefix(ref, vaultPrefix), nil
+	case strings.Contains(ref, "://"):
+		return nil, "", fmt.Errorf("unsupported secret provider in %s, should be vault: or gcp:", ref)
+	default:
+		return vault{}, ref, nil
+	}
+}
+
+// vault reads secrets with the `vault` CLI, that supports both versions of the KV secrets engine.
+type vault struct{}
+
+func (vault) GetSecret(ref string) (string, error) {
+	parts := strings.SplitN(ref, "#", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", fmt.Errorf("invalid Vault secret %s, should be <path>#<key>", ref)
+	}
+
+	cmd := exec.Command("vault", "kv", "get", "-field="+parts[1], parts[0])
+	out, err := RunCmdOutSensitive(cmd)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(out), "\r\n"), nil
+}
+
+// gcpSecretManager reads secrets with `gcloud`.
+type gcpSecretManager struct{}
+
+func (gcpSecretManager) GetSecret(ref string) (string, error) {
+	var project, name string
+	version := "latest"
+
+	parts := strings.Split(ref, "/")
+	switch {
+	case len(parts) == 1 && parts[0] != "":
+		name = parts[0]
+	case (len(parts) == 4 || len(parts) == 6) && parts[0] == "projects" && parts[2] == "secrets":
+		project, name = parts[1], parts[3]
+		if len(parts) == 6 {
+			if parts[4] != "versions" {
+				return "", fmt.Errorf("invalid GCP secret %s", ref)
+			}
+			version = parts[5]
+		}
+	default:
+		return "", fmt.Errorf("invalid GCP secret %s, should be projects/<project>/secrets/<name>[/versions/<version>]", ref)
+	}
+
+	args := []string{"secrets", "versions", "access", version, "--secret", name}
+	if project != "" {
+		args = append(args, "--project", project)
+	}
+
+	out, err := RunCmdOutSensitive(exec.Command("gcloud", args...))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(out), "\r\n"), nil
+}
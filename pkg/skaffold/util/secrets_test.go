@@ -0,0 +1,124 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestGetSecret(t *testing.T) {
+	tests := []struct {
+		description string
+		ref         string
+		command     Command
+		expected    string
+		shouldErr   bool
+	}{
+		{
+			description: "vault",
+			ref:         "secret/ci#token",
+			command:     testutil.NewFakeCmd(t).WithRunOut("vault kv get -field=token secret/ci", "s3cr3t\n"),
+			expected:    "s3cr3t",
+		},
+		{
+			description: "vault prefix",
+			ref:         "vault:kv/team/app#password",
+			command:     testutil.NewFakeCmd(t).WithRunOut("vault kv get -field=password kv/team/app", "pass"),
+			expected:    "pass",
+		},
+		{
+			description: "vault without key",
+			ref:         "secret/ci",
+			shouldErr:   true,
+		},
+		{
+			description: "gcp short name",
+			ref:         "gcp:npm-token",
+			command:     testutil.NewFakeCmd(t).WithRunOut("gcloud secrets versions access latest --secret npm-token", "token"),
+			expected:    "token",
+		},
+		{
+			description: "gcp full name",
+			ref:         "gcp:projects/my-project/secrets/npm-token/versions/3",
+			command:     testutil.NewFakeCmd(t).WithRunOut("gcloud secrets versions access 3 --secret npm-token --project my-project", "token"),
+			expected:    "token",
+		},
+		{
+			description: "invalid gcp name",
+			ref:         "gcp:projects/my-project",
+			shouldErr:   true,
+		},
+		{
+			description: "unsupported provider",
+			ref:         "aws://secret",
+			shouldErr:   true,
+		},
+		{
+			description: "command failure",
+			ref:         "secret/ci#token",
+			command:     testutil.NewFakeCmd(t).WithRunOutErr("vault kv get -field=token secret/ci", "", errors.New("permission denied")),
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			resetCache := testutil.Override(t, &secretsCache, map[string]string{})
+			defer resetCache()
+			if test.command != nil {
+				reset := testutil.Override(t, &DefaultExecCommand, test.command)
+				defer reset()
+			}
+
+			value, err := GetSecret(test.ref)
+
+			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.expected, value)
+		})
+	}
+}
+
+func TestGetSecretIsCached(t *testing.T) {
+	resetCache := testutil.Override(t, &secretsCache, map[string]string{})
+	defer resetCache()
+	reset := testutil.Override(t, &DefaultExecCommand, testutil.NewFakeCmd(t).WithRunOut("vault kv get -field=token secret/ci", "s3cr3t"))
+	defer reset()
+
+	GetSecret("secret/ci#token")
+	// The fake command fails on unexpected calls.
+	resetCmd := testutil.Override(t, &DefaultExecCommand, testutil.NewFakeCmd(t))
+	defer resetCmd()
+
+	value, err := GetSecret("secret/ci#token")
+
+	testutil.CheckErrorAndDeepEqual(t, false, err, "s3cr3t", value)
+}
+
+func TestSecretInEnvTemplate(t *testing.T) {
+	resetCache := testutil.Override(t, &secretsCache, map[string]string{})
+	defer resetCache()
+	reset := testutil.Override(t, &DefaultExecCommand, testutil.NewFakeCmd(t).WithRunOut("vault kv get -field=token secret/ci", "s3cr3t"))
+	defer reset()
+
+	tmpl, err := ParseEnvTemplate(`TOKEN={{secret "secret/ci#token"}}`)
+	testutil.CheckError(t, false, err)
+
+	value, err := ExecuteEnvTemplate(tmpl, nil)
+
+	testutil.CheckErrorAndDeepEqual(t, false, err, "TOKEN=s3cr3t", value)
+}
@@ -0,0 +1,31 @@
+// +build !windows
+
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"os/exec"
+)
+
+// ShellCommand runs a user-defined command through `sh -c`, so that it can use pipes
+// and environment variables. Variables set by Skaffold, like the image passed to custom
+// tests and scanners, are expanded by the shell: `$IMAGE`, or `%IMAGE%` on Windows.
+func ShellCommand(ctx context.Context, command string) *exec.Cmd {
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
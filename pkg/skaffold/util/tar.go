@@ -27,13 +27,30 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// Owner is the numeric owner of a file in a tar archive.
+type Owner struct {
+	UID int
+	GID int
+}
+
 func CreateMappedTar(w io.Writer, root string, pathMap map[string][]string) error {
+	return CreateMappedTarWithOwners(w, root, pathMap, nil)
+}
+
+// CreateMappedTarWithOwners is like CreateMappedTar but sets the owner of the
+// destinations found in owners. Other files keep the owner they have locally.
+func CreateMappedTarWithOwners(w io.Writer, root string, pathMap map[string][]string, owners map[string]Owner) error {
 	tw := tar.NewWriter(w)
 	defer tw.Close()
 
 	for src, dsts := range pathMap {
 		for _, dst := range dsts {
-			if err := addFileToTar(root, src, dst, tw); err != nil {
+			var owner *Owner
+			if o, found := owners[dst]; found {
+				owner = &o
+			}
+
+			if err := addFileToTar(root, src, dst, owner, tw); err != nil {
 				return err
 			}
 		}
@@ -47,7 +64,7 @@ func CreateTar(w io.Writer, root string, paths []string) error {
 	defer tw.Close()
 
 	for _, path := range paths {
-		if err := addFileToTar(root, path, "", tw); err != nil {
+		if err := addFileToTar(root, path, "", nil, tw); err != nil {
 			return err
 		}
 	}
@@ -61,7 +78,7 @@ func CreateTarGz(w io.Writer, root string, paths []string) error {
 	return CreateTar(gw, root, paths)
 }
 
-func addFileToTar(root string, src string, dst string, tw *tar.Writer) error {
+func addFileToTar(root string, src string, dst string, owner *Owner, tw *tar.Writer) error {
 	var (
 		absPath string
 		err     error
@@ -101,6 +118,7 @@ func addFileToTar(root string, src string, dst string, tw *tar.Writer) error {
 			return err
 		}
 		tarHeader.Name = tarPath
+		setOwner(tarHeader, owner)
 
 		if err := tw.WriteHeader(tarHeader); err != nil {
 			return err
@@ -111,6 +129,7 @@ func addFileToTar(root string, src string, dst string, tw *tar.Writer) error {
 			return err
 		}
 		tarHeader.Name = tarPath
+		setOwner(tarHeader, owner)
 
 		if err := tw.WriteHeader(tarHeader); err != nil {
 			return err
@@ -140,6 +159,7 @@ func addFileToTar(root string, src string, dst string, tw *tar.Writer) error {
 			return err
 		}
 		tarHeader.Name = tarPath
+		setOwner(tarHeader, owner)
 		if err := tw.WriteHeader(tarHeader); err != nil {
 			return err
 		}
@@ -156,3 +176,16 @@ func addFileToTar(root string, src string, dst string, tw *tar.Writer) error {
 	}
 	return nil
 }
+
+// setOwner sets the numeric owner of a file. The user and group names are
+// removed, so that they are not looked up where the archive is extracted.
+func setOwner(header *tar.Header, owner *Owner) {
+	if owner == nil {
+		return
+	}
+
+	header.Uid = owner.UID
+	header.Gid = owner.GID
+	header.Uname = ""
+	header.Gname = ""
+}
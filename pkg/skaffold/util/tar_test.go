@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"io"
 	"io/ioutil"
+	"os"
 	"testing"
 
 	"github.com/GoogleContainerTools/skaffold/testutil"
@@ -179,3 +180,39 @@ func TestCreateTarWithAbsolutePaths(t *testing.T) {
 
 	testutil.CheckErrorAndDeepEqual(t, false, err, files, tarFiles)
 }
+
+func TestCreateMappedTarWithOwners(t *testing.T) {
+	tmpDir, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+
+	tmpDir.Write("run.sh", "#!/bin/sh").Write("index.html", "<html>")
+	if err := os.Chmod(tmpDir.Path("run.sh"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	err := CreateMappedTarWithOwners(&b, "/", map[string][]string{
+		tmpDir.Path("run.sh"):     {"app/run.sh"},
+		tmpDir.Path("index.html"): {"app/index.html"},
+	}, map[string]Owner{
+		"app/run.sh": {UID: 1000, GID: 2000},
+	})
+	testutil.CheckError(t, false, err)
+
+	headers := map[string]*tar.Header{}
+	tr := tar.NewReader(&b)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		testutil.CheckError(t, false, err)
+		headers[hdr.Name] = hdr
+	}
+
+	testutil.CheckDeepEqual(t, int64(0755), headers["app/run.sh"].Mode&0777)
+	testutil.CheckDeepEqual(t, 1000, headers["app/run.sh"].Uid)
+	testutil.CheckDeepEqual(t, 2000, headers["app/run.sh"].Gid)
+	testutil.CheckDeepEqual(t, "", headers["app/run.sh"].Uname)
+	testutil.CheckDeepEqual(t, os.Getuid(), headers["app/index.html"].Uid)
+}
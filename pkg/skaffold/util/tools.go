@@ -0,0 +1,50 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"sync"
+)
+
+var (
+	toolPathsLock sync.RWMutex
+	toolPaths     = map[string]string{}
+)
+
+// ToolPath returns the binary to run for a tool, like `kubectl`: the path
+// configured in the pipeline or, by default, the name of the tool, looked up in the `PATH`.
+func ToolPath(name string) string {
+	toolPathsLock.RLock()
+	defer toolPathsLock.RUnlock()
+
+	if path, found := toolPaths[name]; found {
+		return path
+	}
+	return name
+}
+
+// SetToolPath configures the binary to run for a tool. An empty path restores the default.
+func SetToolPath(name, path string) {
+	toolPathsLock.Lock()
+	defer toolPathsLock.Unlock()
+
+	if path == "" {
+		delete(toolPaths, name)
+	} else {
+		toolPaths[name] = path
+	}
+}
@@ -133,6 +133,12 @@ func BoolPtr(b bool) *bool {
 	return &o
 }
 
+// IntPtr returns a pointer to an int
+func IntPtr(i int) *int {
+	o := i
+	return &o
+}
+
 // StringPtr returns a pointer to a string
 func StringPtr(s string) *string {
 	o := s
@@ -264,18 +270,21 @@ func AbsolutePaths(workspace string, paths []string) []string {
 }
 
 // IsHiddenDir returns if a directory is hidden.
-func IsHiddenDir(filename string) bool {
+// A directory is hidden if its name starts with prefix "." or, on Windows,
+// if it has the hidden attribute.
+func IsHiddenDir(path string) bool {
 	// Return false for current dir
-	if filename == hiddenPrefix {
+	if name := filepath.Base(path); name == hiddenPrefix || name == ".." {
 		return false
 	}
-	return hasHiddenPrefix(filename)
+	return IsHiddenFile(path)
 }
 
 // IsHiddenFile returns if a file is hidden.
-// File is hidden if it starts with prefix "."
-func IsHiddenFile(filename string) bool {
-	return hasHiddenPrefix(filename)
+// File is hidden if it starts with prefix "." or, on Windows,
+// if it has the hidden attribute.
+func IsHiddenFile(path string) bool {
+	return hasHiddenPrefix(filepath.Base(path)) || hasHiddenAttribute(path)
 }
 
 func hasHiddenPrefix(s string) bool {
@@ -0,0 +1,80 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verify
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/test/cluster"
+	"github.com/pkg/errors"
+)
+
+var (
+	// For testing
+	runTest = runClusterTest
+)
+
+// Verify runs the verification tests against the deployed application,
+// one after the other, and reports their results through the events API.
+// It stops at the first failing test.
+func Verify(ctx context.Context, out io.Writer, runCtx *runcontext.RunContext, bRes []build.Artifact) error {
+	if len(runCtx.Cfg.Verify) == 0 {
+		return nil
+	}
+
+	start := time.Now()
+	color.Default.Fprintln(out, "Verifying deployment...")
+
+	for _, tc := range runCtx.Cfg.Verify {
+		event.VerifyInProgress(tc.Name)
+		color.Default.Fprintf(out, " - %s\n", tc.Name)
+
+		if err := runTest(ctx, out, *tc, runCtx.Opts.Namespace, resolveImage(tc.Image, bRes)); err != nil {
+			event.VerifyFailed(tc.Name, err)
+			color.Red.Fprintf(out, " - %s failed\n", tc.Name)
+			return errors.Wrapf(err, "verification test %s", tc.Name)
+		}
+
+		event.VerifyComplete(tc.Name)
+		color.Default.Fprintf(out, " - %s passed\n", tc.Name)
+	}
+
+	color.Default.Fprintln(out, "Deployment verified in", time.Since(start))
+	return nil
+}
+
+func runClusterTest(ctx context.Context, out io.Writer, tc latest.VerifyTestCase, namespace, image string) error {
+	return cluster.NewRunner(tc.ClusterTest, namespace).Test(ctx, out, image)
+}
+
+// resolveImage uses the image that was built and deployed if the test image is an artifact.
+func resolveImage(image string, bRes []build.Artifact) string {
+	for _, res := range bRes {
+		if image == res.ImageName {
+			return res.Tag
+		}
+	}
+
+	return image
+}
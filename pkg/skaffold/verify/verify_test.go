@@ -0,0 +1,82 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verify
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/config"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		description string
+		failing     string
+		expected    []string
+		shouldErr   bool
+	}{
+		{
+			description: "all tests pass",
+			expected:    []string{"smoke gcr.io/k8s-skaffold/app:TAG", "e2e gcr.io/k8s-skaffold/e2e"},
+		},
+		{
+			description: "stop at first failure",
+			failing:     "smoke",
+			expected:    []string{"smoke gcr.io/k8s-skaffold/app:TAG"},
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			var ran []string
+			reset := testutil.Override(t, &runTest, func(_ context.Context, _ io.Writer, tc latest.VerifyTestCase, namespace, image string) error {
+				ran = append(ran, tc.Name+" "+image)
+				if tc.Name == test.failing {
+					return errors.New("BUG")
+				}
+				return nil
+			})
+			defer reset()
+
+			runCtx := &runcontext.RunContext{
+				Opts: &config.SkaffoldOptions{},
+				Cfg: &latest.Pipeline{
+					Verify: []*latest.VerifyTestCase{
+						{Name: "smoke", Image: "gcr.io/k8s-skaffold/app"},
+						{Name: "e2e", Image: "gcr.io/k8s-skaffold/e2e"},
+					},
+				},
+			}
+			event.InitializeState(runCtx)
+
+			err := Verify(context.Background(), ioutil.Discard, runCtx, []build.Artifact{
+				{ImageName: "gcr.io/k8s-skaffold/app", Tag: "gcr.io/k8s-skaffold/app:TAG"},
+			})
+
+			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.expected, ran)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// gitTrigger watches for new commits on a remote branch. New commits are merged,
+// fast-forward only, into the current branch and the watcher then sees them as
+// file changes.
+type gitTrigger struct {
+	Ref      string
+	Interval time.Duration
+	Webhook  *webhookTrigger
+
+	remote string
+	branch string
+}
+
+// Debounce tells the watcher to not debounce rapid sequence of changes.
+func (t *gitTrigger) Debounce() bool {
+	return false
+}
+
+func (t *gitTrigger) WatchForChanges(out io.Writer) {
+	color.Yellow.Fprintf(out, "Watching for new commits on %s every %v...\n", t.ref(), t.Interval)
+}
+
+// Start polls the remote branch.
+func (t *gitTrigger) Start(ctx context.Context) (<-chan bool, error) {
+	if err := t.resolveRef(ctx); err != nil {
+		return nil, err
+	}
+
+	var notifications <-chan bool
+	if t.Webhook != nil {
+		var err error
+		if notifications, err = t.Webhook.Start(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	trigger := make(chan bool)
+
+	ticker := time.NewTicker(t.Interval)
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+			case <-notifications:
+			case <-ctx.Done():
+				ticker.Stop()
+				return
+			}
+
+			pulled, err := t.pull(ctx)
+			if err != nil {
+				logrus.Warnf("Unable to pull new commits from %s: %s", t.ref(), err)
+				continue
+			}
+			if pulled {
+				trigger <- true
+			}
+		}
+	}()
+
+	return trigger, nil
+}
+
+func (t *gitTrigger) ref() string {
+	if t.remote == "" {
+		return t.Ref
+	}
+	return t.remote + "/" + t.branch
+}
+
+// resolveRef finds the remote and the branch to watch. It defaults to the upstream
+// of the current branch.
+func (t *gitTrigger) resolveRef(ctx context.Context) error {
+	ref := t.Ref
+	if ref == "" {
+		out, err := util.RunCmdOut(exec.CommandContext(ctx, "git", "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}"))
+		if err != nil {
+			return errors.Wrap(err, "finding the upstream of the current branch. Use --git-ref")
+		}
+		ref = strings.TrimSpace(string(out))
+	}
+
+	parts := strings.SplitN(ref, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("invalid git ref %q, expected <remote>/<branch>", ref)
+	}
+
+	t.remote, t.branch = parts[0], parts[1]
+	return nil
+}
+
+// pull fetches the remote branch and merges it if it has new commits.
+func (t *gitTrigger) pull(ctx context.Context) (bool, error) {
+	if err := util.RunCmd(exec.CommandContext(ctx, "git", "fetch", "--quiet", t.remote, t.branch)); err != nil {
+		return false, errors.Wrap(err, "fetching")
+	}
+
+	head, err := revParse(ctx, "HEAD")
+	if err != nil {
+		return false, err
+	}
+	fetched, err := revParse(ctx, "FETCH_HEAD")
+	if err != nil {
+		return false, err
+	}
+	if head == fetched {
+		return false, nil
+	}
+
+	if err := util.RunCmd(exec.CommandContext(ctx, "git", "merge", "--ff-only", "--quiet", "FETCH_HEAD")); err != nil {
+		return false, errors.Wrap(err, "merging")
+	}
+
+	logrus.Infof("Pulled %s..%s from %s", shortCommit(head), shortCommit(fetched), t.ref())
+	return true, nil
+}
+
+func revParse(ctx context.Context, rev string) (string, error) {
+	out, err := util.RunCmdOut(exec.CommandContext(ctx, "git", "rev-parse", rev))
+	if err != nil {
+		return "", errors.Wrapf(err, "resolving %s", rev)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func shortCommit(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return commit
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watch
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/pkg/errors"
+)
+
+// gitStatus tells if the files of a git working tree might have changed, using
+// `git status`, which relies on `core.fsmonitor` when it's configured.
+// This is much faster than stat-ing every file of a large repository.
+// Files ignored by git aren't seen.
+type gitStatus struct {
+	dir   string
+	root  string
+	state string
+}
+
+func newGitStatus(dir string) (*gitStatus, error) {
+	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+	cmd.Dir = dir
+	out, err := util.RunCmdOut(cmd)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s is not in a git repository", dir)
+	}
+
+	g := &gitStatus{
+		dir:  dir,
+		root: strings.TrimSpace(string(out)),
+	}
+	if g.state, err = g.status(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// changed returns true if the working tree changed since the last call:
+// a new commit was checked out, or files were added, modified or deleted.
+func (g *gitStatus) changed() (bool, error) {
+	state, err := g.status()
+	if err != nil {
+		return false, err
+	}
+
+	if state == g.state {
+		return false, nil
+	}
+	g.state = state
+	return true, nil
+}
+
+// status summarizes the state of the working tree: the current commit and the
+// modification times of the files that differ from it.
+func (g *gitStatus) status() (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", "HEAD")
+	cmd.Dir = g.dir
+	// Fails in a repository without commits.
+	head, _ := util.RunCmdOut(cmd)
+
+	cmd = exec.Command("git", "status", "--porcelain", "-z", "--untracked-files=all", "--", ".")
+	cmd.Dir = g.dir
+	out, err := util.RunCmdOut(cmd)
+	if err != nil {
+		return "", errors.Wrap(err, "running git status")
+	}
+
+	files := changedFiles(string(out))
+	lines := []string{strings.TrimSpace(string(head))}
+	for _, file := range files {
+		if info, err := os.Stat(filepath.Join(g.root, file)); err == nil {
+			lines = append(lines, fmt.Sprintf("%s %d %d", file, info.ModTime().UnixNano(), info.Size()))
+		} else {
+			lines = append(lines, file+" deleted")
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// changedFiles parses the output of `git status --porcelain -z`. Paths are
+// relative to the root of the repository.
+func changedFiles(status string) []string {
+	var files []string
+
+	entries := strings.Split(status, "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+
+		files = append(files, filepath.FromSlash(entry[3:]))
+		if (entry[0] == 'R' || entry[0] == 'C') && i+1 < len(entries) {
+			// Renamed and copied files are followed by their original path.
+			i++
+			files = append(files, filepath.FromSlash(entries[i]))
+		}
+	}
+
+	sort.Strings(files)
+	return files
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watch
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestChangedFiles(t *testing.T) {
+	var tests = []struct {
+		description string
+		status      string
+		expected    []string
+	}{
+		{
+			description: "clean",
+			status:      "",
+		},
+		{
+			description: "modified and untracked files",
+			status:      " M src/main.go\x00?? new.txt\x00",
+			expected:    []string{"new.txt", filepath.FromSlash("src/main.go")},
+		},
+		{
+			description: "renamed file",
+			status:      "R  new.go\x00old.go\x00 D gone.go\x00",
+			expected:    []string{"gone.go", "new.go", "old.go"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			testutil.CheckDeepEqual(t, test.expected, changedFiles(test.status))
+		})
+	}
+}
+
+func TestGitStatusChanged(t *testing.T) {
+	var tests = []struct {
+		description string
+		command     *testutil.FakeCmd
+		expected    bool
+	}{
+		{
+			description: "no change",
+			command: fakeGitStatus(t, "sha1", " M main.go\x00").
+				WithRunOut("git rev-parse --verify --quiet HEAD", "sha1\n").
+				WithRunOut("git status --porcelain -z --untracked-files=all -- .", " M main.go\x00"),
+			expected: false,
+		},
+		{
+			description: "new commit",
+			command: fakeGitStatus(t, "sha1", "").
+				WithRunOut("git rev-parse --verify --quiet HEAD", "sha2\n").
+				WithRunOut("git status --porcelain -z --untracked-files=all -- .", ""),
+			expected: true,
+		},
+		{
+			description: "new file",
+			command: fakeGitStatus(t, "sha1", "").
+				WithRunOut("git rev-parse --verify --quiet HEAD", "sha1\n").
+				WithRunOut("git status --porcelain -z --untracked-files=all -- .", "?? main.go\x00"),
+			expected: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			reset := testutil.Override(t, &util.DefaultExecCommand, test.command)
+			defer reset()
+
+			status, err := newGitStatus(".")
+			testutil.CheckError(t, false, err)
+
+			changed, err := status.changed()
+
+			testutil.CheckErrorAndDeepEqual(t, false, err, test.expected, changed)
+		})
+	}
+}
+
+func TestNewGitStatusNotARepository(t *testing.T) {
+	reset := testutil.Override(t, &util.DefaultExecCommand, testutil.FakeRunOutErr(t, "git rev-parse --show-toplevel", "", errNotARepository))
+	defer reset()
+
+	_, err := newGitStatus(".")
+
+	testutil.CheckError(t, true, err)
+}
+
+var errNotARepository = errors.New("not a git repository")
+
+func fakeGitStatus(t *testing.T, head, status string) *testutil.FakeCmd {
+	return testutil.FakeRunOut(t, "git rev-parse --show-toplevel", "/repo\n").
+		WithRunOut("git rev-parse --verify --quiet HEAD", head+"\n").
+		WithRunOut("git status --porcelain -z --untracked-files=all -- .", status)
+}
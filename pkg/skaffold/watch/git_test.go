@@ -0,0 +1,137 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestGitTrigger_WatchForChanges(t *testing.T) {
+	out := new(bytes.Buffer)
+
+	trigger := &gitTrigger{Ref: "origin/master", Interval: time.Minute}
+	trigger.WatchForChanges(out)
+
+	got, want := out.String(), "Watching for new commits on origin/master every 1m0s...\n"
+	testutil.CheckDeepEqual(t, want, got)
+}
+
+func TestGitTrigger_ResolveRef(t *testing.T) {
+	var tests = []struct {
+		description    string
+		ref            string
+		command        *testutil.FakeCmd
+		shouldErr      bool
+		expectedRemote string
+		expectedBranch string
+	}{
+		{
+			description:    "explicit ref",
+			ref:            "upstream/feature/x",
+			command:        testutil.NewFakeCmd(t),
+			expectedRemote: "upstream",
+			expectedBranch: "feature/x",
+		},
+		{
+			description:    "upstream of the current branch",
+			command:        testutil.FakeRunOut(t, "git rev-parse --abbrev-ref --symbolic-full-name @{u}", "origin/master\n"),
+			expectedRemote: "origin",
+			expectedBranch: "master",
+		},
+		{
+			description: "no upstream",
+			command:     testutil.FakeRunOutErr(t, "git rev-parse --abbrev-ref --symbolic-full-name @{u}", "", errors.New("no upstream configured")),
+			shouldErr:   true,
+		},
+		{
+			description: "invalid ref",
+			ref:         "master",
+			command:     testutil.NewFakeCmd(t),
+			shouldErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			reset := testutil.Override(t, &util.DefaultExecCommand, test.command)
+			defer reset()
+
+			trigger := &gitTrigger{Ref: test.ref}
+			err := trigger.resolveRef(context.Background())
+
+			testutil.CheckError(t, test.shouldErr, err)
+			testutil.CheckDeepEqual(t, test.expectedRemote, trigger.remote)
+			testutil.CheckDeepEqual(t, test.expectedBranch, trigger.branch)
+		})
+	}
+}
+
+func TestGitTrigger_Pull(t *testing.T) {
+	var tests = []struct {
+		description string
+		command     *testutil.FakeCmd
+		shouldErr   bool
+		expected    bool
+	}{
+		{
+			description: "no new commits",
+			command: testutil.FakeRun(t, "git fetch --quiet origin master").
+				WithRunOut("git rev-parse HEAD", "aea33bcc86b5af8c8570ff45d8a643202d63c808\n").
+				WithRunOut("git rev-parse FETCH_HEAD", "aea33bcc86b5af8c8570ff45d8a643202d63c808\n"),
+		},
+		{
+			description: "new commits",
+			command: testutil.FakeRun(t, "git fetch --quiet origin master").
+				WithRunOut("git rev-parse HEAD", "aea33bcc86b5af8c8570ff45d8a643202d63c808\n").
+				WithRunOut("git rev-parse FETCH_HEAD", "ee64c8d4a2bfe93e3e1e4a2cbe1ae1c4d5df8e76\n").
+				WithRun("git merge --ff-only --quiet FETCH_HEAD"),
+			expected: true,
+		},
+		{
+			description: "fetch error",
+			command:     testutil.FakeRunErr(t, "git fetch --quiet origin master", errors.New("network error")),
+			shouldErr:   true,
+		},
+		{
+			description: "diverged branches",
+			command: testutil.FakeRun(t, "git fetch --quiet origin master").
+				WithRunOut("git rev-parse HEAD", "aea33bcc86b5af8c8570ff45d8a643202d63c808\n").
+				WithRunOut("git rev-parse FETCH_HEAD", "ee64c8d4a2bfe93e3e1e4a2cbe1ae1c4d5df8e76\n").
+				WithRunErr("git merge --ff-only --quiet FETCH_HEAD", errors.New("not possible to fast-forward")),
+			shouldErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			reset := testutil.Override(t, &util.DefaultExecCommand, test.command)
+			defer reset()
+
+			trigger := &gitTrigger{remote: "origin", branch: "master"}
+			pulled, err := trigger.pull(context.Background())
+
+			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.expected, pulled)
+		})
+	}
+}
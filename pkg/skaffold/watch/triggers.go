@@ -19,8 +19,11 @@ package watch
 import (
 	"bufio"
 	"context"
+	"crypto/subtle"
 	"fmt"
 	"io"
+	"net"
+	"net/http"
 	"os"
 	"strings"
 	"sync/atomic"
@@ -52,6 +55,17 @@ func NewTrigger(opts *config.SkaffoldOptions) (Trigger, error) {
 		}, nil
 	case "manual":
 		return &manualTrigger{}, nil
+	case "webhook":
+		return newWebhookTrigger(opts), nil
+	case "git":
+		trigger := &gitTrigger{
+			Ref:      opts.GitRef,
+			Interval: time.Duration(opts.GitPollInterval) * time.Second,
+		}
+		if opts.GitWebhook {
+			trigger.Webhook = newWebhookTrigger(opts)
+		}
+		return trigger, nil
 	default:
 		return nil, fmt.Errorf("unsupported type of trigger: %s", opts.Trigger)
 	}
@@ -180,3 +194,81 @@ func (t *fsNotifyTrigger) Start(ctx context.Context) (<-chan bool, error) {
 
 	return trigger, nil
 }
+
+// webhookTrigger watches for changes when an HTTP notification is received.
+type webhookTrigger struct {
+	Port  int
+	Token string
+}
+
+func newWebhookTrigger(opts *config.SkaffoldOptions) *webhookTrigger {
+	return &webhookTrigger{
+		Port:  opts.WebhookPort,
+		Token: opts.WebhookToken,
+	}
+}
+
+// Debounce tells the watcher to not debounce rapid sequence of changes.
+func (t *webhookTrigger) Debounce() bool {
+	return false
+}
+
+func (t *webhookTrigger) WatchForChanges(out io.Writer) {
+	color.Yellow.Fprintf(out, "Waiting for notifications on port %d...\n", t.Port)
+}
+
+// Start serves an HTTP endpoint. Any POST request triggers a check for changes.
+func (t *webhookTrigger) Start(ctx context.Context) (<-chan bool, error) {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", t.Port))
+	if err != nil {
+		return nil, err
+	}
+
+	// Notifications received during a rebuild are grouped.
+	trigger := make(chan bool, 1)
+	server := &http.Server{
+		Handler: t.handler(trigger),
+	}
+
+	go func() {
+		if err := server.Serve(listener); err != http.ErrServerClosed {
+			logrus.Warnln("webhook trigger error:", err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+
+	return trigger, nil
+}
+
+func (t *webhookTrigger) handler(trigger chan<- bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if t.Token != "" && !t.authorized(r) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		logrus.Debugln("Notification received from", r.RemoteAddr)
+		select {
+		case trigger <- true:
+		default:
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func (t *webhookTrigger) authorized(r *http.Request) bool {
+	token := r.URL.Query().Get("token")
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		token = strings.TrimPrefix(auth, "Bearer ")
+	}
+
+	return subtle.ConstantTimeCompare([]byte(token), []byte(t.Token)) == 1
+}
@@ -18,11 +18,14 @@ package watch
 
 import (
 	"bytes"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/config"
 	"github.com/GoogleContainerTools/skaffold/testutil"
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestNewTrigger(t *testing.T) {
@@ -51,6 +54,23 @@ func TestNewTrigger(t *testing.T) {
 			opts:        &config.SkaffoldOptions{Trigger: "manual"},
 			expected:    &manualTrigger{},
 		},
+		{
+			description: "webhook trigger",
+			opts:        &config.SkaffoldOptions{Trigger: "webhook", WebhookPort: 50053, WebhookToken: "secret"},
+			expected: &webhookTrigger{
+				Port:  50053,
+				Token: "secret",
+			},
+		},
+		{
+			description: "git trigger",
+			opts:        &config.SkaffoldOptions{Trigger: "git", GitRef: "origin/master", GitPollInterval: 60, GitWebhook: true, WebhookPort: 50053},
+			expected: &gitTrigger{
+				Ref:      "origin/master",
+				Interval: time.Duration(60) * time.Second,
+				Webhook:  &webhookTrigger{Port: 50053},
+			},
+		},
 		{
 			description: "unknown trigger",
 			opts:        &config.SkaffoldOptions{Trigger: "unknown"},
@@ -61,7 +81,7 @@ func TestNewTrigger(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.description, func(t *testing.T) {
 			got, err := NewTrigger(test.opts)
-			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.expected, got)
+			testutil.CheckErrorAndDeepEqual(t, test.shouldErr, err, test.expected, got, cmp.AllowUnexported(gitTrigger{}))
 		})
 	}
 }
@@ -113,3 +133,100 @@ func TestManualTrigger_WatchForChanges(t *testing.T) {
 	got, want := out.String(), "Press any key to rebuild/redeploy the changes\n"
 	testutil.CheckDeepEqual(t, want, got)
 }
+
+func TestWebhookTrigger_WatchForChanges(t *testing.T) {
+	out := new(bytes.Buffer)
+
+	trigger := &webhookTrigger{Port: 50053}
+	trigger.WatchForChanges(out)
+
+	got, want := out.String(), "Waiting for notifications on port 50053...\n"
+	testutil.CheckDeepEqual(t, want, got)
+}
+
+func TestWebhookTrigger_Handler(t *testing.T) {
+	var tests = []struct {
+		description     string
+		token           string
+		method          string
+		url             string
+		authorization   string
+		expectedStatus  int
+		expectedTrigger bool
+	}{
+		{
+			description:     "post",
+			method:          "POST",
+			url:             "/",
+			expectedStatus:  http.StatusNoContent,
+			expectedTrigger: true,
+		},
+		{
+			description:    "get",
+			method:         "GET",
+			url:            "/",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			description:     "bearer token",
+			token:           "secret",
+			method:          "POST",
+			url:             "/",
+			authorization:   "Bearer secret",
+			expectedStatus:  http.StatusNoContent,
+			expectedTrigger: true,
+		},
+		{
+			description:     "token query parameter",
+			token:           "secret",
+			method:          "POST",
+			url:             "/push?token=secret",
+			expectedStatus:  http.StatusNoContent,
+			expectedTrigger: true,
+		},
+		{
+			description:    "wrong token",
+			token:          "secret",
+			method:         "POST",
+			url:            "/?token=other",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			description:    "missing token",
+			token:          "secret",
+			method:         "POST",
+			url:            "/",
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			trigger := make(chan bool, 1)
+			webhook := &webhookTrigger{Token: test.token}
+
+			req := httptest.NewRequest(test.method, test.url, nil)
+			if test.authorization != "" {
+				req.Header.Set("Authorization", test.authorization)
+			}
+			rec := httptest.NewRecorder()
+			webhook.handler(trigger).ServeHTTP(rec, req)
+
+			testutil.CheckDeepEqual(t, test.expectedStatus, rec.Code)
+			testutil.CheckDeepEqual(t, test.expectedTrigger, len(trigger) == 1)
+		})
+	}
+}
+
+func TestWebhookTrigger_GroupNotifications(t *testing.T) {
+	trigger := make(chan bool, 1)
+	handler := (&webhookTrigger{}).handler(trigger)
+
+	for i := 0; i < 3; i++ {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("POST", "/", nil))
+		testutil.CheckDeepEqual(t, http.StatusNoContent, rec.Code)
+	}
+
+	testutil.CheckDeepEqual(t, 1, len(trigger))
+}
@@ -29,6 +29,7 @@ type Factory func() Watcher
 // Watcher monitors files changes for multiples components.
 type Watcher interface {
 	Register(deps func() ([]string, error), onChange func(Events)) error
+	RegisterGit(dir string, deps func() ([]string, error), onChange func(Events)) error
 	Run(ctx context.Context, out io.Writer, onChange func() error) error
 }
 
@@ -45,10 +46,11 @@ func NewWatcher(trigger Trigger) Watcher {
 }
 
 type component struct {
-	deps     func() ([]string, error)
-	onChange func(Events)
-	state    FileMap
-	events   Events
+	deps      func() ([]string, error)
+	onChange  func(Events)
+	state     FileMap
+	events    Events
+	gitStatus *gitStatus
 }
 
 // Register adds a new component to the watch list.
@@ -66,6 +68,22 @@ func (w *watchList) Register(deps func() ([]string, error), onChange func(Events
 	return nil
 }
 
+// RegisterGit adds a new component, whose files are in the git repository of dir,
+// to the watch list. Its files are only checked when `git status` reports a change.
+func (w *watchList) RegisterGit(dir string, deps func() ([]string, error), onChange func(Events)) error {
+	status, err := newGitStatus(dir)
+	if err != nil {
+		return err
+	}
+
+	if err := w.Register(deps, onChange); err != nil {
+		return err
+	}
+
+	w.components[len(w.components)-1].gitStatus = status
+	return nil
+}
+
 // Run watches files until the context is cancelled or an error occurs.
 func (w *watchList) Run(ctx context.Context, out io.Writer, onChange func() error) error {
 	ctxTrigger, cancelTrigger := context.WithCancel(ctx)
@@ -86,6 +104,16 @@ func (w *watchList) Run(ctx context.Context, out io.Writer, onChange func() erro
 		case <-t:
 			changed := 0
 			for i, component := range w.components {
+				if component.gitStatus != nil {
+					changed, err := component.gitStatus.changed()
+					if err != nil {
+						return errors.Wrap(err, "checking git status")
+					}
+					if !changed {
+						continue
+					}
+				}
+
 				state, err := Stat(component.deps)
 				if err != nil {
 					return errors.Wrap(err, "listing files")
@@ -40,11 +40,14 @@ type FakeAPIClient struct {
 	ErrImageTag     bool
 	ErrImagePush    bool
 	ErrImagePull    bool
+	ErrImageRemove  bool
 	ErrStream       bool
+	DaemonOS        string
 
 	nextImageID  int
 	Pushed       []string
 	PushedImages []string
+	Removed      []string
 }
 
 type errReader struct{}
@@ -59,6 +62,13 @@ func (f *FakeAPIClient) body(digest string) io.ReadCloser {
 	return ioutil.NopCloser(strings.NewReader(fmt.Sprintf(`{"aux":{"digest":"%s"}}`, digest)))
 }
 
+func (f *FakeAPIClient) ServerVersion(context.Context) (types.Version, error) {
+	if f.DaemonOS == "" {
+		return types.Version{Os: "linux"}, nil
+	}
+	return types.Version{Os: f.DaemonOS}, nil
+}
+
 func (f *FakeAPIClient) ImageBuild(_ context.Context, _ io.Reader, options types.ImageBuildOptions) (types.ImageBuildResponse, error) {
 	if f.ErrImageBuild {
 		return types.ImageBuildResponse{}, fmt.Errorf("")
@@ -150,4 +160,14 @@ func (f *FakeAPIClient) ImageList(ctx context.Context, options types.ImageListOp
 	return f.ImageSummaries, nil
 }
 
+func (f *FakeAPIClient) ImageRemove(_ context.Context, image string, _ types.ImageRemoveOptions) ([]types.ImageDeleteResponseItem, error) {
+	if f.ErrImageRemove {
+		return nil, fmt.Errorf("")
+	}
+
+	f.Removed = append(f.Removed, image)
+
+	return []types.ImageDeleteResponseItem{{Untagged: image}}, nil
+}
+
 func (f *FakeAPIClient) Close() error { return nil }